@@ -21,6 +21,8 @@ func main() {
 	logger = base.Logger()
 	logger.SetLevel(hclog.Debug)
 
+	snowflake.PluginVersion = version
+
 	err := base.RegisterPlugins(
 		wrappers.IdentityStoreSync(snowflake.NewIdentityStoreSyncer()),
 		wrappers.DataSourceSync(snowflake.NewDataSourceSyncer()),
@@ -33,11 +35,24 @@ func main() {
 				Parameters: []*plugin.ParameterInfo{
 					{Name: snowflake.SfAccount, Description: "The account name of the Snowflake account to connect to. For example, xy123456.eu-central-1", Mandatory: true},
 					{Name: snowflake.SfUser, Description: "The username to authenticate against the Snowflake account.", Mandatory: true},
-					{Name: snowflake.SfPassword, Description: fmt.Sprintf("The password to authenticate against the Snowflake account. Either this or %s must be specified", snowflake.SfPrivateKey), Mandatory: false},
-					{Name: snowflake.SfPrivateKey, Description: fmt.Sprintf("The path of the file containing the private key to authenticate against the Snowflake account. Either this or %s must be specified.", snowflake.SfPassword), Mandatory: false},
+					{Name: snowflake.SfPassword, Description: fmt.Sprintf("The password to authenticate against the Snowflake account. Either this, %s, %s or an OAuth token source must be specified. This and the other sensitive parameters also accept a secret reference in the format 'file:/path', 'env:NAME' or 'exec:command args' (the arguments are split like a shell does, so quote arguments containing spaces).", snowflake.SfPrivateKey, snowflake.SfPat), Mandatory: false},
+					{Name: snowflake.SfPrivateKey, Description: fmt.Sprintf("The private key to authenticate against the Snowflake account. This can be the path of the file containing the private key, the PEM encoded private key itself (optionally base64 encoded) or a reference to an environment variable containing it, in the format 'env:VAR_NAME'. Either this or %s must be specified.", snowflake.SfPassword), Mandatory: false},
 					{Name: snowflake.SfPrivateKeyPassphrase, Description: "The passphrase for the private key in case it is encrypted.", Mandatory: false},
+					{Name: snowflake.SfPrivateKeySecondary, Description: fmt.Sprintf("An optional secondary private key, in the same formats as %s. This key is used when the primary key is rejected by Snowflake, e.g. while rotating keys using RSA_PUBLIC_KEY_2.", snowflake.SfPrivateKey), Mandatory: false},
+					{Name: snowflake.SfPrivateKeySecondaryPassphrase, Description: "The passphrase for the secondary private key in case it is encrypted.", Mandatory: false},
+					{Name: snowflake.SfPat, Description: fmt.Sprintf("The programmatic access token (PAT) to authenticate against the Snowflake account. This cannot be combined with %s, %s or OAuth authentication.", snowflake.SfPassword, snowflake.SfPrivateKey), Mandatory: false},
+					{Name: snowflake.SfOAuthTokenFile, Description: "The path of the file containing an OAuth access token to authenticate against the Snowflake account. The file is read again whenever a new connection is opened, so the token can be rotated externally.", Mandatory: false},
+					{Name: snowflake.SfOAuthTokenEnv, Description: "The name of the environment variable containing an OAuth access token to authenticate against the Snowflake account.", Mandatory: false},
+					{Name: snowflake.SfOAuthTokenEndpoint, Description: fmt.Sprintf("The URL of the OAuth token endpoint to fetch an access token from, using the client credentials flow. When set, %s and %s must be specified as well. The token is refreshed automatically when it expires.", snowflake.SfOAuthClientId, snowflake.SfOAuthClientSecret), Mandatory: false},
+					{Name: snowflake.SfOAuthClientId, Description: "The client id used to fetch an OAuth access token from the token endpoint.", Mandatory: false},
+					{Name: snowflake.SfOAuthClientSecret, Description: "The client secret used to fetch an OAuth access token from the token endpoint.", Mandatory: false},
+					{Name: snowflake.SfOAuthScope, Description: "The optional scope to request when fetching an OAuth access token from the token endpoint. e.g. 'session:role:RAITO_SYNC'", Mandatory: false},
 					{Name: snowflake.SfRole, Description: "The name of the role to use for executing the necessary queries. If not specified 'ACCOUNTADMIN' is used.", Mandatory: false},
 					{Name: snowflake.SfWarehouse, Description: "The name of the warehouse to use for executing the necessary queries. If not specified, the default warehouse for the user is used.", Mandatory: false},
+					{Name: snowflake.SfUsageRole, Description: fmt.Sprintf("The role to use for the data usage sync instead of the role in '%s'. The role needs access to the SNOWFLAKE.ACCOUNT_USAGE schema.", snowflake.SfRole), Mandatory: false},
+					{Name: snowflake.SfUsageWarehouse, Description: fmt.Sprintf("The warehouse to use for the data usage sync instead of the warehouse in '%s', so the heavy query history queries don't compete with the other syncs.", snowflake.SfWarehouse), Mandatory: false},
+					{Name: snowflake.SfAccessRole, Description: fmt.Sprintf("The role to use for importing and exporting access controls instead of the role in '%s'.", snowflake.SfRole), Mandatory: false},
+					{Name: snowflake.SfAccessWarehouse, Description: fmt.Sprintf("The warehouse to use for importing and exporting access controls instead of the warehouse in '%s'.", snowflake.SfWarehouse), Mandatory: false},
 					{Name: snowflake.SfExcludedDatabases, Description: "The optional comma-separated list of databases that should be skipped.", Mandatory: false},
 					{Name: snowflake.SfExcludedSchemas, Description: "The optional comma-separated list of schemas that should be skipped. This can either be in a specific database (as <database>.<schema>) or a just a schema name that should be skipped in all databases. By default INFORMATION_SCHEMA is skipped since there are no access controls to manage", Mandatory: false},
 					{Name: snowflake.SfExcludedRoles, Description: "The optional comma-separated list of roles that should be skipped. Roles containing excluded roles will be imported as incomplete because this breaks the hierarchy", Mandatory: false},
@@ -46,12 +61,43 @@ func main() {
 					{Name: snowflake.SfStandardEdition, Description: "If set enterprise features will be disabled", Mandatory: false},
 					{Name: snowflake.SfSkipTags, Description: "If set, tags will not be fetched", Mandatory: false},
 					{Name: snowflake.SfSkipColumns, Description: "If set, columns and column masking policies will not be imported.", Mandatory: false},
+					{Name: snowflake.SfSchemaObjectTypes, Description: "The optional comma-separated list of the types of schema objects, besides tables, views, functions and procedures, that should be imported (e.g. 'stage,stream'). Each type is listed once per database. The supported types are stage, stream, task, pipe, sequence, file-format, secret, image-repository and service. By default all of them are imported. Image repositories and services, like compute pools, are skipped on accounts where Snowpark Container Services are not available.", Mandatory: false},
 					{Name: snowflake.SfDataUsageWindow, Description: "The maximum number of days of usage data to retrieve. Default is 90. Maximum is 90 days.", Mandatory: false},
 					{Name: snowflake.SfDatabaseRoles, Description: "If set, database-roles for all databases will be fetched.", Mandatory: false},
 					{Name: snowflake.SfApplications, Description: "If set, applications will be fetched.", Mandatory: false},
 					{Name: snowflake.SfIgnoreLinksToRoles, Description: "This comma separated list of regular expressions can be used to indicate that role hierarchy links to certain roles are never added or removed. e.g. 'SYS.+,ADMIN.+' will match all roles starting with 'SYS' or 'ADMIN', meaning that all grants to these roles will remain untouched during the sync.", Mandatory: false},
+					{Name: snowflake.SfGrantLoading, Description: fmt.Sprintf("How the grants of account roles are read when importing access controls. '%s' (default) runs SHOW GRANTS TO ROLE and SHOW GRANTS OF ROLE for every role. '%s' reads the grants of all roles at once from SNOWFLAKE.ACCOUNT_USAGE.GRANTS_TO_ROLES and GRANTS_TO_USERS, which is a lot faster for accounts with many roles, but these views have a latency of up to 2 hours so recent grant changes may be missing.", snowflake.GrantLoadingShow, snowflake.GrantLoadingAccountUsage), Mandatory: false},
 					{Name: snowflake.SfUsageBatchSize, Description: "If not set, no batching is done when fetching usage statements. This will be the fastest, however it uses more memory. If memory usage is a problem, this can be set to a number between 10.000 and 1.000.000 (higher is recommended) to fetch usage in batches of that size.", Mandatory: false},
 					{Name: snowflake.SfUsageUserExcludes, Description: "The optional comma-separated list of user names to exclude when fetching data. This is typically used for service accounts that do a large amount of operations.", Mandatory: false},
+					{Name: snowflake.SfUsageIncludeOwnQueries, Description: "All queries executed by this plugin are tagged with a QUERY_TAG containing the sync phase, the run id and the plugin version. By default, these queries are skipped when fetching usage data. Set this to 'true' to include them.", Mandatory: false},
+					{Name: snowflake.SfRetryMaxAttempts, Description: "The maximum number of attempts to execute an idempotent query when Snowflake returns a transient error (e.g. a network reset, HTTP 503 or a suspended warehouse). Default is 3. Set to 1 to disable retries.", Mandatory: false},
+					{Name: snowflake.SfRetryInitialBackoff, Description: "The time to wait before the first retry of a failed query, e.g. '2s'. The wait time doubles with every attempt. Default is 1s.", Mandatory: false},
+					{Name: snowflake.SfStatementTimeout, Description: "The maximum time a single query is allowed to run in Snowflake, e.g. '10m'. Queries running longer are cancelled by Snowflake. If not set, the timeout configured on the account, user or warehouse is used.", Mandatory: false},
+					{Name: snowflake.SfMetricsFile, Description: "If set, a JSON summary of the executed Snowflake statements (count, total time and p95 latency per statement kind and per sync) is written to this file at the end of every sync.", Mandatory: false},
+					{Name: snowflake.SfMetricsPrometheusFile, Description: "If set, the same statement metrics are written to this file in the Prometheus text format at the end of every sync.", Mandatory: false},
+					{Name: snowflake.SfTracingExporter, Description: fmt.Sprintf("If set, every sync is traced with OpenTelemetry, with a span per sync, per access provider, per repository call and per Snowflake statement (including its query id). Use '%s' to send the spans to an OTLP/HTTP collector or '%s' to write them as JSON to the file set in '%s'.", snowflake.TracingExporterOtlp, snowflake.TracingExporterFile, snowflake.SfTracingFile), Mandatory: false},
+					{Name: snowflake.SfTracingOtlpEndpoint, Description: fmt.Sprintf("The URL of the OTLP/HTTP collector the spans are sent to when '%s' is '%s'. Defaults to 'http://localhost:4318'.", snowflake.SfTracingExporter, snowflake.TracingExporterOtlp), Mandatory: false},
+					{Name: snowflake.SfTracingFile, Description: fmt.Sprintf("The file the spans are appended to, one JSON object per line, when '%s' is '%s'.", snowflake.SfTracingExporter, snowflake.TracingExporterFile), Mandatory: false},
+					{Name: snowflake.SfDryRun, Description: fmt.Sprintf("If set to true, the access controls are not exported to Snowflake. The current state is read and all statements that would be executed are written, grouped per access control, to the file set in '%s'. The feedback of every access control is marked as a dry run.", snowflake.SfDryRunFile), Mandatory: false},
+					{Name: snowflake.SfDryRunFile, Description: fmt.Sprintf("The SQL file the planned statements are written to when '%s' is set to true.", snowflake.SfDryRun), Mandatory: false},
+					{Name: snowflake.SfDryRunJsonFile, Description: fmt.Sprintf("The JSON file the change plan is written to when '%s' is set to true. For every access control, it lists the grants to add and remove, the role renames, the mask, filter and share changes. After review, the plan can be applied with '%s'.", snowflake.SfDryRun, snowflake.SfApplyPlanFile), Mandatory: false},
+					{Name: snowflake.SfApplyPlanFile, Description: fmt.Sprintf("The JSON change plan (created with '%s') to apply instead of exporting the access controls directly. The changes are calculated again from the current state of Snowflake first, and nothing is executed if they differ from the plan.", snowflake.SfDryRunJsonFile), Mandatory: false},
+					{Name: snowflake.SfAuditLogFile, Description: "If set, every DDL and DCL statement executed in Snowflake is appended to this file as a JSON line, with the timestamp, the access control ids, the Snowflake query id, the duration and the outcome.", Mandatory: false},
+					{Name: snowflake.SfAuditLogMaxSize, Description: fmt.Sprintf("The maximum size in megabytes of the file in '%s' before it is rotated. Defaults to 100. Use 0 to disable rotation.", snowflake.SfAuditLogFile), Mandatory: false},
+					{Name: snowflake.SfAuditLogMaxBackups, Description: fmt.Sprintf("The number of rotated audit log files to keep (as '%s.1', '%s.2', ...). Defaults to 5.", snowflake.SfAuditLogFile, snowflake.SfAuditLogFile), Mandatory: false},
+					{Name: snowflake.SfMaxRevokes, Description: "If set, the changes are calculated before anything is executed and the export is aborted if they contain more revoked grants than this limit. The limit is an absolute number, a percentage (e.g. '5%') or both (e.g. '100,5%'). The percentage is relative to all current grants to and of the account roles managed by Raito, read from SNOWFLAKE.ACCOUNT_USAGE, so grants changed during the last 2 hours may not be included yet. Setting any of the blast-radius limits changes how the export runs: all changes are planned first, so reads see the state from before this export; the planned statements are regrouped and executed per access provider afterwards; and the feedback of the access providers is only sent once all changes are executed.", Mandatory: false},
+					{Name: snowflake.SfMaxRoleDrops, Description: "If set, the changes are calculated before anything is executed and the export is aborted if they contain more dropped roles than this limit. The limit is an absolute number, a percentage of the existing roles (e.g. '5%') or both (e.g. '100,5%'). Setting this limit changes how the export runs, see 'sf-max-revokes'.", Mandatory: false},
+					{Name: snowflake.SfMaxMaskDrops, Description: "If set, the changes are calculated before anything is executed and the export is aborted if they contain more dropped masking policies than this limit. The limit is an absolute number, a percentage of all masking policies created by Raito in the account (e.g. '5%') or both (e.g. '100,5%'). Setting this limit changes how the export runs, see 'sf-max-revokes'.", Mandatory: false},
+					{Name: snowflake.SfMaxShareAccountRemovals, Description: "If set, the changes are calculated before anything is executed and the export is aborted if they contain more accounts removed from shares than this limit. The limit is an absolute number, a percentage of the current accounts of the outbound shares (e.g. '5%') or both (e.g. '100,5%'). Setting this limit changes how the export runs, see 'sf-max-revokes'.", Mandatory: false},
+					{Name: snowflake.SfAllowMassChanges, Description: "If set to true, the blast-radius limits ('sf-max-revokes', 'sf-max-role-drops', 'sf-max-mask-drops' and 'sf-max-share-account-removals') are ignored. Use this for intended mass changes.", Mandatory: false},
+					{Name: snowflake.SfPolicyRulesFile, Description: "A YAML or JSON file with policy rules that are checked before grants and revokes are executed in Snowflake, e.g. to never grant OWNERSHIP, never grant to PUBLIC or never grant write privileges on databases tagged as production. Access controls violating a rule are not exported and get an error naming the rule.", Mandatory: false},
+					{Name: snowflake.SfProtectedRoles, Description: "This comma separated list of regular expressions can be used to indicate roles that are not managed by Raito. e.g. 'SYS.+,ADMIN.+' will match all roles starting with 'SYS' or 'ADMIN'. These roles are imported as read-only and will never be granted, revoked, renamed or dropped during the sync.", Mandatory: false},
+					{Name: snowflake.SfUnmanagedRoleTag, Description: "The name of a Snowflake tag (e.g. 'RAITO_UNMANAGED') that marks a role as not managed by Raito when it is set to 'true' on the role. These roles are imported as read-only and will never be granted, revoked, renamed or dropped during the sync.", Mandatory: false},
+					{Name: snowflake.SfSnapshotFile, Description: fmt.Sprintf("If set, the current state of every role, mask, filter and share is written to this JSON file before the access sync changes it: the grants to and of the roles, the policy bodies and references and the share accounts. The snapshot can be restored with '%s'.", snowflake.SfRestoreSnapshotFile), Mandatory: false},
+					{Name: snowflake.SfRestoreSnapshotFile, Description: fmt.Sprintf("The JSON snapshot (created with '%s') to restore instead of exporting the access controls. The roles, masks, filters and shares in the snapshot are put back in the state they had before that sync.", snowflake.SfSnapshotFile), Mandatory: false},
+					{Name: snowflake.SfRecordFixturesFile, Description: fmt.Sprintf("If set, every statement sent to Snowflake is recorded together with its result in this gzip compressed JSON file (e.g. 'fixtures.json.gz'), so a sync can be reproduced without a Snowflake connection using '%s'.", snowflake.SfReplayFixturesFile), Mandatory: false},
+					{Name: snowflake.SfRecordFixturesAnonymise, Description: fmt.Sprintf("If set to true, email addresses, comments, descriptions, query texts and display names are replaced by pseudonyms in the file set in '%s'. Object, role and user names are kept.", snowflake.SfRecordFixturesFile), Mandatory: false},
+					{Name: snowflake.SfReplayFixturesFile, Description: fmt.Sprintf("The fixture file (recorded with '%s') to replay instead of connecting to Snowflake. Queries return the recorded results and changes that were not recorded are skipped.", snowflake.SfRecordFixturesFile), Mandatory: false},
 					{Name: snowflake.SfMaskDecryptFunction, Description: "This allows you to have an additional column masking option which will decrypt data in a column. You do this by specifying the function name that will do the decryption. This function one parameter containing the value to decrypt. e.g. MY_DATABASE.MY_SCHEMA.DECRYPT_IT. Note: Make sure the role used to connect to Snowflake (as specified in sf-role) has USAGE permissions on the decryption method.", Mandatory: false},
 					{Name: snowflake.SfMaskDecryptColumnTag, Description: fmt.Sprintf("When using the '%s' option, you can also additionally specify a tag name that contains the encryption type to use. This will pass a second argument to your decryption function with the value of this tag on the column. Use the fully qualified name for the tag. e.g. MY_DATABASE.MY_SCHEMA.MY_TAG", snowflake.SfMaskDecryptFunction), Mandatory: false},
 
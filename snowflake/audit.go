@@ -0,0 +1,200 @@
+package snowflake
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	defaultAuditLogMaxSizeMB  = 100
+	defaultAuditLogMaxBackups = 5
+)
+
+// AuditEntry is a single line of the audit log, written for every execution of DDL or DCL statements in Snowflake
+type AuditEntry struct {
+	Timestamp         time.Time `json:"timestamp"`
+	RunId             string    `json:"runId"`
+	Syncer            SyncPhase `json:"syncer"`
+	AccessProviderIds []string  `json:"accessProviderIds,omitempty"`
+	QueryId           string    `json:"queryId,omitempty"`
+	Statements        []string  `json:"statements"`
+	DurationMs        int64     `json:"durationMs"`
+	Success           bool      `json:"success"`
+	Error             string    `json:"error,omitempty"`
+}
+
+// AuditLog appends entries as JSON lines to a file.
+// When the file would exceed the maximum size, it is rotated to <path>.1, <path>.2, ... keeping at most maxBackups old files.
+type AuditLog struct {
+	mutex sync.Mutex
+
+	path       string
+	maxSize    int64
+	maxBackups int
+}
+
+var (
+	auditLogsMutex sync.Mutex
+	// auditLogs are shared by all repositories writing to the same file, so rotation is done only once
+	auditLogs = make(map[string]*AuditLog)
+)
+
+// auditLogFromParams returns the audit log configured in the parameters, or nil if no audit log is configured
+func auditLogFromParams(params map[string]string) (*AuditLog, error) {
+	path := params[SfAuditLogFile]
+	if path == "" {
+		return nil, nil //nolint:nilnil
+	}
+
+	maxSizeMB := defaultAuditLogMaxSizeMB
+
+	if v := params[SfAuditLogMaxSize]; v != "" {
+		var err error
+
+		maxSizeMB, err = strconv.Atoi(v)
+		if err != nil || maxSizeMB < 0 {
+			return nil, fmt.Errorf("invalid value %q for %q parameter (must be a number of megabytes, or 0 to disable rotation)", v, SfAuditLogMaxSize)
+		}
+	}
+
+	maxBackups := defaultAuditLogMaxBackups
+
+	if v := params[SfAuditLogMaxBackups]; v != "" {
+		var err error
+
+		maxBackups, err = strconv.Atoi(v)
+		if err != nil || maxBackups < 1 {
+			return nil, fmt.Errorf("invalid value %q for %q parameter (must be a positive number)", v, SfAuditLogMaxBackups)
+		}
+	}
+
+	auditLogsMutex.Lock()
+	defer auditLogsMutex.Unlock()
+
+	if auditLog, found := auditLogs[path]; found {
+		return auditLog, nil
+	}
+
+	// Fail before anything is executed if the audit log can't be written
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("open audit log %q: %w", path, err)
+	}
+
+	file.Close()
+
+	auditLog := &AuditLog{path: path, maxSize: int64(maxSizeMB) * 1024 * 1024, maxBackups: maxBackups}
+	auditLogs[path] = auditLog
+
+	return auditLog, nil
+}
+
+func (l *AuditLog) Write(entry *AuditEntry) error {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("marshal audit entry: %w", err)
+	}
+
+	line = append(line, '\n')
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	err = l.rotateIfNeeded(int64(len(line)))
+	if err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(l.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("open audit log %q: %w", l.path, err)
+	}
+
+	_, err = file.Write(line)
+	if err != nil {
+		file.Close()
+
+		return fmt.Errorf("write audit log %q: %w", l.path, err)
+	}
+
+	return file.Close()
+}
+
+func (l *AuditLog) rotateIfNeeded(size int64) error {
+	if l.maxSize == 0 {
+		return nil
+	}
+
+	info, err := os.Stat(l.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("stat audit log %q: %w", l.path, err)
+	}
+
+	if info.Size() == 0 || info.Size()+size <= l.maxSize {
+		return nil
+	}
+
+	for i := l.maxBackups; i > 0; i-- {
+		from := l.path
+		if i > 1 {
+			from = fmt.Sprintf("%s.%d", l.path, i-1)
+		}
+
+		err = os.Rename(from, fmt.Sprintf("%s.%d", l.path, i))
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("rotate audit log %q: %w", l.path, err)
+		}
+	}
+
+	return nil
+}
+
+// audit writes an entry to the audit log for the DDL and DCL statements of an execution
+func (repo *SnowflakeRepository) audit(ctx context.Context, statements []string, duration time.Duration, queryId string, err error) {
+	if repo.auditLog == nil {
+		return
+	}
+
+	var mutating []string
+
+	for _, statement := range statements {
+		if !isReadOnlyStatement(statement) {
+			mutating = append(mutating, statement)
+		}
+	}
+
+	if len(mutating) == 0 {
+		return
+	}
+
+	entry := AuditEntry{
+		Timestamp:  time.Now().UTC(),
+		RunId:      runId,
+		Syncer:     repo.phase,
+		QueryId:    queryId,
+		Statements: mutating,
+		DurationMs: duration.Milliseconds(),
+		Success:    err == nil,
+	}
+
+	for _, ap := range accessProvidersFromContext(ctx) {
+		entry.AccessProviderIds = append(entry.AccessProviderIds, ap.Id)
+	}
+
+	if err != nil {
+		entry.Error = err.Error()
+	}
+
+	writeErr := repo.auditLog.Write(&entry)
+	if writeErr != nil {
+		Logger.Error(fmt.Sprintf("Unable to write to audit log: %s", writeErr.Error()))
+	}
+}
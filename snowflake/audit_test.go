@@ -0,0 +1,168 @@
+package snowflake
+
+import (
+	"bufio"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	importer "github.com/raito-io/cli/base/access_provider/sync_to_target"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readAuditEntries(t *testing.T, path string) []AuditEntry {
+	t.Helper()
+
+	file, err := os.Open(path)
+	require.NoError(t, err)
+
+	defer file.Close()
+
+	var entries []AuditEntry
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var entry AuditEntry
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &entry))
+
+		entries = append(entries, entry)
+	}
+
+	require.NoError(t, scanner.Err())
+
+	return entries
+}
+
+func TestAuditLogFromParams(t *testing.T) {
+	auditLog, err := auditLogFromParams(map[string]string{})
+	require.NoError(t, err)
+	assert.Nil(t, auditLog)
+
+	path := filepath.Join(t.TempDir(), "audit.log")
+
+	auditLog, err = auditLogFromParams(map[string]string{SfAuditLogFile: path, SfAuditLogMaxSize: "1", SfAuditLogMaxBackups: "2"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1024*1024), auditLog.maxSize)
+	assert.Equal(t, 2, auditLog.maxBackups)
+	assert.FileExists(t, path)
+
+	sameLog, err := auditLogFromParams(map[string]string{SfAuditLogFile: path})
+	require.NoError(t, err)
+	assert.Same(t, auditLog, sameLog)
+
+	_, err = auditLogFromParams(map[string]string{SfAuditLogFile: filepath.Join(t.TempDir(), "other.log"), SfAuditLogMaxSize: "-1"})
+	require.Error(t, err)
+
+	_, err = auditLogFromParams(map[string]string{SfAuditLogFile: filepath.Join(t.TempDir(), "missing", "audit.log")})
+	require.Error(t, err)
+}
+
+func TestSnowflakeRepository_audit(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	repo := &SnowflakeRepository{phase: SyncPhaseAccessToTarget, auditLog: &AuditLog{path: path}}
+
+	ctx := withAccessProviders(context.Background(), &importer.AccessProvider{Id: "ap1"}, &importer.AccessProvider{Id: "ap2"})
+
+	repo.audit(ctx, []string{`USE DATABASE "DB"`, `ALTER TABLE "DB"."S"."T" ADD ROW ACCESS POLICY "DB"."S"."F" ON ("A")`}, 1500*time.Millisecond, "01b2c3d4-0000-1111-0000-000000000001", nil)
+	repo.audit(ctx, []string{`SHOW GRANTS TO ROLE "R1"`}, time.Second, "01b2c3d4-0000-1111-0000-000000000002", nil)
+	repo.audit(context.Background(), []string{`GRANT USAGE ON DATABASE "DB" TO ROLE "RAITO"`}, 0, "", errors.New("Insufficient privileges"))
+
+	entries := readAuditEntries(t, path)
+	require.Len(t, entries, 2)
+
+	assert.Equal(t, SyncPhaseAccessToTarget, entries[0].Syncer)
+	assert.Equal(t, runId, entries[0].RunId)
+	assert.Equal(t, []string{"ap1", "ap2"}, entries[0].AccessProviderIds)
+	assert.Equal(t, "01b2c3d4-0000-1111-0000-000000000001", entries[0].QueryId)
+	assert.Equal(t, []string{`ALTER TABLE "DB"."S"."T" ADD ROW ACCESS POLICY "DB"."S"."F" ON ("A")`}, entries[0].Statements)
+	assert.Equal(t, int64(1500), entries[0].DurationMs)
+	assert.True(t, entries[0].Success)
+	assert.Empty(t, entries[0].Error)
+
+	assert.Empty(t, entries[1].AccessProviderIds)
+	assert.False(t, entries[1].Success)
+	assert.Equal(t, "Insufficient privileges", entries[1].Error)
+}
+
+func TestAuditLog_Rotation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	auditLog := &AuditLog{path: path, maxSize: 200, maxBackups: 2}
+
+	for i := 0; i < 5; i++ {
+		require.NoError(t, auditLog.Write(&AuditEntry{Statements: []string{`GRANT ROLE "R1" TO USER "JOHN"`}, Success: true}))
+	}
+
+	// Every entry is larger than half of the maximum size, so every file contains a single entry
+	assert.Len(t, readAuditEntries(t, path), 1)
+	assert.Len(t, readAuditEntries(t, path+".1"), 1)
+	assert.Len(t, readAuditEntries(t, path+".2"), 1)
+	assert.NoFileExists(t, path+".3")
+}
+
+func TestSnowflakeRepository_auditMaskUpdate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+
+	player := newFixturePlayer(&FixtureArchive{Interactions: []FixtureInteraction{
+		{
+			Syncer:    SyncPhaseAccessToTarget,
+			Statement: "SELECT * FROM DB.INFORMATION_SCHEMA.COLUMNS WHERE CONCAT_WS('.', TABLE_CATALOG, TABLE_SCHEMA, TABLE_NAME, COLUMN_NAME) IN ('DB.SCHEMA1.TABLE1.COL1')",
+			Columns:   []string{"TABLE_CATALOG", "TABLE_SCHEMA", "TABLE_NAME", "COLUMN_NAME", "DATA_TYPE"},
+			Rows:      [][]FixtureValue{stringFixtureValues("DB", "SCHEMA1", "TABLE1", "COL1", "TEXT")},
+		},
+		{
+			Syncer:    SyncPhaseAccessToTarget,
+			Statement: "SELECT * FROM DB.INFORMATION_SCHEMA.TABLES WHERE TABLE_NAME = 'TABLE1' AND TABLE_SCHEMA = 'SCHEMA1'",
+			Columns:   []string{"TABLE_CATALOG", "TABLE_SCHEMA", "TABLE_NAME", "TABLE_TYPE"},
+			Rows:      [][]FixtureValue{stringFixtureValues("DB", "SCHEMA1", "TABLE1", "BASE TABLE")},
+		},
+		{
+			Syncer:    SyncPhaseAccessToTarget,
+			Statement: "SHOW MASKING POLICIES LIKE 'MASK1_%';",
+			Columns:   []string{"name", "database_name", "schema_name", "kind", "owner"},
+			Rows:      [][]FixtureValue{stringFixtureValues("MASK1_TEXT", "DB", "SCHEMA1", "MASKING_POLICY", "SYSADMIN")},
+		},
+		{
+			Syncer:    SyncPhaseAccessToTarget,
+			Statement: "select * from table(DB.information_schema.policy_references(policy_name => 'DB.SCHEMA1.MASK1_TEXT'))",
+			Columns:   []string{"POLICY_DB", "POLICY_SCHEMA", "POLICY_NAME", "POLICY_KIND", "REF_DATABASE_NAME", "REF_SCHEMA_NAME", "REF_ENTITY_NAME", "REF_ENTITY_DOMAIN", "REF_COLUMN_NAME", "POLICY_STATUS"},
+			Rows:      [][]FixtureValue{stringFixtureValues("DB", "SCHEMA1", "MASK1_TEXT", "MASKING_POLICY", "DB", "SCHEMA1", "TABLE1", "TABLE", "COL1", "ACTIVE")},
+		},
+	}})
+
+	repo := &SnowflakeRepository{
+		conn:        sql.OpenDB(newReplayConnector(player, SyncPhaseAccessToTarget)),
+		phase:       SyncPhaseAccessToTarget,
+		role:        AccountAdminRole,
+		maskFactory: NewMaskFactory(map[string]string{}),
+		auditLog:    &AuditLog{path: path},
+	}
+
+	defer repo.Close()
+
+	ctx := withAccessProviders(context.Background(), &importer.AccessProvider{Id: "mask1"})
+
+	// Updating a mask replaces the masking policy, the statements are executed in transactions
+	require.NoError(t, repo.CreateMaskPolicy(ctx, "DB", "SCHEMA1", "MASK2", []string{"DB.SCHEMA1.TABLE1.COL1"}, nil, &MaskingBeneficiaries{Roles: []string{"ROLE1"}}))
+	require.NoError(t, repo.DropMaskingPolicy(ctx, "DB", "SCHEMA1", "MASK1"))
+
+	entries := readAuditEntries(t, path)
+	require.Len(t, entries, 4)
+
+	for _, entry := range entries {
+		require.Len(t, entry.Statements, 1)
+		assert.Equal(t, []string{"mask1"}, entry.AccessProviderIds)
+		assert.True(t, entry.Success)
+	}
+
+	assert.True(t, strings.HasPrefix(entries[0].Statements[0], "CREATE MASKING POLICY DB.SCHEMA1.MASK2_TEXT"))
+	assert.Equal(t, `ALTER TABLE DB.SCHEMA1.TABLE1 ALTER COLUMN "COL1" SET MASKING POLICY DB.SCHEMA1.MASK2_TEXT FORCE`, entries[1].Statements[0])
+	assert.Equal(t, `ALTER TABLE DB.SCHEMA1.TABLE1 ALTER COLUMN "COL1" UNSET MASKING POLICY`, entries[2].Statements[0])
+	assert.Equal(t, "DROP MASKING POLICY DB.SCHEMA1.MASK1_TEXT", entries[3].Statements[0])
+}
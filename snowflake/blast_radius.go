@@ -0,0 +1,278 @@
+package snowflake
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/raito-io/golang-set/set"
+)
+
+type blastRadiusCategory string
+
+const (
+	blastRadiusRevokes              blastRadiusCategory = "revokes"
+	blastRadiusRoleDrops            blastRadiusCategory = "role drops"
+	blastRadiusMaskDrops            blastRadiusCategory = "masking policy drops"
+	blastRadiusShareAccountRemovals blastRadiusCategory = "share account removals"
+)
+
+var blastRadiusParameters = []struct {
+	category  blastRadiusCategory
+	parameter string
+}{
+	{category: blastRadiusRevokes, parameter: SfMaxRevokes},
+	{category: blastRadiusRoleDrops, parameter: SfMaxRoleDrops},
+	{category: blastRadiusMaskDrops, parameter: SfMaxMaskDrops},
+	{category: blastRadiusShareAccountRemovals, parameter: SfMaxShareAccountRemovals},
+}
+
+// blastRadiusLimit is the maximum number of changes of a category in a single sync.
+// A negative value means there is no limit.
+type blastRadiusLimit struct {
+	absolute   int
+	percentage float64
+}
+
+type blastRadiusLimits map[blastRadiusCategory]blastRadiusLimit
+
+// blastRadiusLimitsFromParams parses the configured limits. Every limit is an absolute number, a percentage (e.g. 5%) or both (e.g. 100,5%).
+// Nil is returned if no limits are configured or if they are overridden.
+func blastRadiusLimitsFromParams(params map[string]string) (blastRadiusLimits, error) {
+	if strings.EqualFold(params[SfAllowMassChanges], "true") {
+		return nil, nil //nolint:nilnil
+	}
+
+	limits := make(blastRadiusLimits)
+
+	for _, p := range blastRadiusParameters {
+		value := params[p.parameter]
+		if value == "" {
+			continue
+		}
+
+		limit := blastRadiusLimit{absolute: -1, percentage: -1}
+
+		for _, part := range strings.Split(value, ",") {
+			part = strings.TrimSpace(part)
+
+			var err error
+
+			if percentage, isPercentage := strings.CutSuffix(part, "%"); isPercentage {
+				limit.percentage, err = strconv.ParseFloat(strings.TrimSpace(percentage), 64)
+			} else {
+				limit.absolute, err = strconv.Atoi(part)
+			}
+
+			if err != nil || limit.absolute < -1 || limit.percentage < -1 {
+				return nil, fmt.Errorf("invalid value %q for %q parameter (must be a number, a percentage or both, e.g. '100,5%%')", value, p.parameter)
+			}
+		}
+
+		limits[p.category] = limit
+	}
+
+	if len(limits) == 0 {
+		return nil, nil //nolint:nilnil
+	}
+
+	return limits, nil
+}
+
+func hasBlastRadiusLimits(params map[string]string) bool {
+	limits, err := blastRadiusLimitsFromParams(params)
+
+	// Invalid limits are reported when the sync starts
+	return err != nil || limits != nil
+}
+
+// blastRadius keeps track of the current state the changes of a sync are compared to.
+// All methods can be called on a nil blastRadius, which is the case if no limits are configured.
+type blastRadius struct {
+	limits blastRadiusLimits
+
+	// current is the number of existing grants, roles, masking policies and share accounts in the account that could be affected by each category of changes.
+	// For revokes, these are the grants to and of the account roles managed by Raito. For masking policy drops, these are all masking policies created by Raito.
+	// As reading these totals takes extra queries, they are only read for the categories with a percentage limit.
+	current map[blastRadiusCategory]int
+
+	shareAccounts        map[string]set.Set[string]
+	shareAccountRemovals int
+}
+
+func newBlastRadius(limits blastRadiusLimits) *blastRadius {
+	return &blastRadius{limits: limits, current: make(map[blastRadiusCategory]int), shareAccounts: make(map[string]set.Set[string])}
+}
+
+// needsTotal checks if the total number of existing objects of the category is needed to check a percentage limit
+func (b *blastRadius) needsTotal(category blastRadiusCategory) bool {
+	if b == nil {
+		return false
+	}
+
+	limit, found := b.limits[category]
+
+	return found && limit.percentage >= 0
+}
+
+func (b *blastRadius) observe(category blastRadiusCategory, count int) {
+	if b == nil {
+		return
+	}
+
+	b.current[category] += count
+}
+
+func (b *blastRadius) observeShares(shares []ShareEntity) {
+	if b == nil {
+		return
+	}
+
+	for _, share := range shares {
+		recipient := strings.ToUpper(strings.TrimSpace(share.To))
+		if recipient == "" {
+			continue
+		}
+
+		if _, found := b.shareAccounts[share.Name]; !found {
+			b.shareAccounts[share.Name] = set.NewSet[string]()
+		}
+
+		b.shareAccounts[share.Name].Add(recipient)
+		b.current[blastRadiusShareAccountRemovals]++
+	}
+}
+
+// observeShareAccounts registers the accounts that will be removed from the share when its accounts are set to the given recipients
+func (b *blastRadius) observeShareAccounts(shareName string, recipients []string) {
+	if b == nil {
+		return
+	}
+
+	newAccounts := set.NewSet[string]()
+	for _, recipient := range recipients {
+		newAccounts.Add(strings.ToUpper(strings.TrimSpace(recipient)))
+	}
+
+	for account := range b.shareAccounts[shareName] {
+		if !newAccounts.Contains(account) {
+			b.shareAccountRemovals++
+		}
+	}
+}
+
+// observeMaskingPolicyTotal registers all masking policies created by Raito in the account, if needed for a percentage limit
+func (s *AccessToTargetSyncer) observeMaskingPolicyTotal(ctx context.Context) error {
+	if !s.blastRadius.needsTotal(blastRadiusMaskDrops) {
+		return nil
+	}
+
+	policies, err := s.repo.GetPoliciesLike(ctx, "MASKING", maskPrefix+"%")
+	if err != nil {
+		return fmt.Errorf("count masking policies: %w", err)
+	}
+
+	s.blastRadius.observe(blastRadiusMaskDrops, len(policies))
+
+	return nil
+}
+
+// observeGrantTotal registers all grants to and of the existing account roles managed by Raito, if needed for a percentage limit.
+// The grants are read from SNOWFLAKE.ACCOUNT_USAGE, so grants changed during the last 2 hours may not be included yet.
+func (s *AccessToTargetSyncer) observeGrantTotal(ctx context.Context, existingRoles set.Set[string]) error {
+	if !s.blastRadius.needsTotal(blastRadiusRevokes) {
+		return nil
+	}
+
+	grantsTo, err := s.repo.GetAllGrantsToAccountRoles(ctx)
+	if err != nil {
+		return fmt.Errorf("count grants to account roles: %w", err)
+	}
+
+	grantsOf, err := s.repo.GetAllGrantsOfAccountRoles(ctx)
+	if err != nil {
+		return fmt.Errorf("count grants of account roles: %w", err)
+	}
+
+	for externalId := range existingRoles {
+		if isDatabaseRoleByExternalId(externalId) || isApplicationRoleByExternalId(externalId) || s.unmanagedRoles.unmanagedReason(externalId) != "" {
+			continue
+		}
+
+		s.blastRadius.observe(blastRadiusRevokes, len(grantsTo[externalId])+len(grantsOf[externalId]))
+	}
+
+	return nil
+}
+
+// countChanges counts the changes per category in the change plan.
+// Revoking future grants is not counted, as they are always revoked and granted again when a role is updated.
+// Dropping a masking policy is only counted if the policy is not replaced by a new one for the same access provider.
+func (b *blastRadius) countChanges(changePlan *ChangePlan) map[blastRadiusCategory]int {
+	counts := map[blastRadiusCategory]int{blastRadiusShareAccountRemovals: b.shareAccountRemovals}
+
+	for _, plan := range changePlan.Plans {
+		maskDrops := 0
+		maskReplaced := false
+
+		for _, change := range plan.Changes {
+			normalized := strings.ToUpper(change.Statement)
+
+			switch {
+			case change.Type == PlannedChangeGrantRemove && !strings.Contains(normalized, " FUTURE "):
+				counts[blastRadiusRevokes]++
+			case change.Type == PlannedChangeRoleDrop:
+				counts[blastRadiusRoleDrops]++
+			case change.Type == PlannedChangeMask && strings.HasPrefix(normalized, "DROP MASKING POLICY"):
+				maskDrops++
+			case change.Type == PlannedChangeMask && strings.HasPrefix(normalized, "CREATE"):
+				maskReplaced = true
+			}
+		}
+
+		if !maskReplaced {
+			counts[blastRadiusMaskDrops] += maskDrops
+		}
+	}
+
+	return counts
+}
+
+// exceeded returns a description of every limit exceeded by the changes in the change plan
+func (b *blastRadius) exceeded(limits blastRadiusLimits, changePlan *ChangePlan) []string {
+	if len(limits) == 0 {
+		return nil
+	}
+
+	counts := b.countChanges(changePlan)
+
+	var result []string
+
+	for _, p := range blastRadiusParameters {
+		limit, found := limits[p.category]
+		if !found {
+			continue
+		}
+
+		count := counts[p.category]
+		current := b.current[p.category]
+
+		if limit.absolute >= 0 && count > limit.absolute {
+			result = append(result, fmt.Sprintf("%d %s exceeds the limit of %d", count, p.category, limit.absolute))
+		}
+
+		if limit.percentage >= 0 && count > 0 {
+			percentage := 100.0
+			if current > 0 {
+				percentage = float64(count) * 100 / float64(current)
+			}
+
+			if percentage > limit.percentage {
+				result = append(result, fmt.Sprintf("%d %s (%.1f%% of %d) exceeds the limit of %g%%", count, p.category, percentage, current, limit.percentage))
+			}
+		}
+	}
+
+	return result
+}
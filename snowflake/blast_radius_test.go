@@ -0,0 +1,289 @@
+package snowflake
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/smithy-go/ptr"
+	"github.com/raito-io/cli/base/access_provider"
+	importer "github.com/raito-io/cli/base/access_provider/sync_to_target"
+	"github.com/raito-io/cli/base/access_provider/types"
+	"github.com/raito-io/cli/base/util/config"
+	"github.com/raito-io/cli/base/wrappers/mocks"
+	"github.com/raito-io/golang-set/set"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlastRadiusLimitsFromParams(t *testing.T) {
+	limits, err := blastRadiusLimitsFromParams(map[string]string{SfMaxRevokes: "100", SfMaxRoleDrops: "5%", SfMaxMaskDrops: "10, 2.5%"})
+	require.NoError(t, err)
+
+	assert.Equal(t, blastRadiusLimits{
+		blastRadiusRevokes:   {absolute: 100, percentage: -1},
+		blastRadiusRoleDrops: {absolute: -1, percentage: 5},
+		blastRadiusMaskDrops: {absolute: 10, percentage: 2.5},
+	}, limits)
+
+	limits, err = blastRadiusLimitsFromParams(map[string]string{})
+	require.NoError(t, err)
+	assert.Nil(t, limits)
+
+	limits, err = blastRadiusLimitsFromParams(map[string]string{SfMaxRevokes: "100", SfAllowMassChanges: "true"})
+	require.NoError(t, err)
+	assert.Nil(t, limits)
+
+	_, err = blastRadiusLimitsFromParams(map[string]string{SfMaxShareAccountRemovals: "many"})
+	require.Error(t, err)
+
+	assert.True(t, hasBlastRadiusLimits(map[string]string{SfMaxShareAccountRemovals: "many"}))
+	assert.False(t, hasBlastRadiusLimits(map[string]string{SfMaxRevokes: "100", SfAllowMassChanges: "true"}))
+}
+
+func TestBlastRadius_exceeded(t *testing.T) {
+	changes := func(statements ...string) AccessProviderChanges {
+		result := AccessProviderChanges{}
+		for _, statement := range statements {
+			result.Changes = append(result.Changes, PlannedChange{Type: plannedChangeType(statement), Statement: statement})
+		}
+
+		return result
+	}
+
+	changePlan := &ChangePlan{Plans: []AccessProviderChanges{
+		changes(`REVOKE ALL ON FUTURE TABLES IN SCHEMA "DB"."S" FROM ROLE "R1"`, `REVOKE SELECT ON TABLE "DB"."S"."T1" FROM ROLE "R1"`, `REVOKE SELECT ON TABLE "DB"."S"."T2" FROM ROLE "R1"`),
+		changes(`DROP ROLE "R2"`),
+		changes(`CREATE MASKING POLICY "DB"."S"."RAITO_M1_NEW" AS (val STRING) RETURNS STRING -> val`, `DROP MASKING POLICY "DB"."S"."RAITO_M1_OLD"`),
+		changes(`ALTER TABLE "DB"."S"."T" ALTER COLUMN "C" UNSET MASKING POLICY`, `DROP MASKING POLICY "DB"."S"."RAITO_M2"`),
+	}}
+
+	radius := newBlastRadius(nil)
+	radius.observe(blastRadiusRevokes, 10)
+	radius.observe(blastRadiusRoleDrops, 4)
+	radius.observeShares([]ShareEntity{{Name: "SHARE1", To: "ORG.ACC1"}, {Name: "SHARE1", To: "ORG.ACC2"}, {Name: "SHARE2", To: ""}})
+	radius.observeShareAccounts("SHARE1", []string{"org.acc2"})
+
+	assert.Equal(t, map[blastRadiusCategory]int{
+		blastRadiusRevokes:              2,
+		blastRadiusRoleDrops:            1,
+		blastRadiusMaskDrops:            1,
+		blastRadiusShareAccountRemovals: 1,
+	}, radius.countChanges(changePlan))
+
+	assert.Empty(t, radius.exceeded(nil, changePlan))
+	assert.Empty(t, radius.exceeded(blastRadiusLimits{blastRadiusRevokes: {absolute: 2, percentage: 20}, blastRadiusMaskDrops: {absolute: 1, percentage: -1}}, changePlan))
+
+	assert.Equal(t, []string{
+		"2 revokes exceeds the limit of 1",
+		"1 role drops (25.0% of 4) exceeds the limit of 10%",
+		"1 masking policy drops (100.0% of 0) exceeds the limit of 50%",
+		"1 share account removals exceeds the limit of 0",
+	}, radius.exceeded(blastRadiusLimits{
+		blastRadiusRevokes:              {absolute: 1, percentage: 20},
+		blastRadiusRoleDrops:            {absolute: -1, percentage: 10},
+		blastRadiusMaskDrops:            {absolute: -1, percentage: 50},
+		blastRadiusShareAccountRemovals: {absolute: 0, percentage: 50},
+	}, changePlan))
+}
+
+func TestAccessSyncer_SyncAccessProviderToTarget_BlastRadius(t *testing.T) {
+	accessProviders := &importer.AccessProviderImport{AccessProviders: []*importer.AccessProvider{
+		{Id: "ap1", Name: "Old role", Action: types.Grant, Delete: true, ExternalId: ptr.String("OLD_ROLE")},
+	}}
+
+	setup := func(t *testing.T) (*mockDataAccessRepository, *AccessSyncer) {
+		t.Helper()
+
+		plan := NewExecutionPlan()
+
+		repo := newMockDataAccessRepository(t)
+		repo.EXPECT().ExecutionPlan().Return(plan)
+		repo.EXPECT().DropAccountRole(mock.Anything, "OLD_ROLE").Run(func(ctx context.Context, roleName string) {
+			plan.add(ctx, `DROP ROLE "OLD_ROLE"`)
+		}).Return(nil).Once()
+		repo.EXPECT().GetAccountRolesWithPrefix(mock.Anything, "").Return([]RoleEntity{{Name: "OTHER_ROLE"}}, nil).Once()
+		repo.EXPECT().TotalQueryTime().Return(0)
+		repo.EXPECT().TotalRetries().Return(0)
+		repo.EXPECT().Close().Return(nil)
+
+		syncer := &AccessSyncer{
+			namingConstraints: RoleNameConstraints,
+			repoProvider: func(params map[string]string, role string, phase SyncPhase) (dataAccessRepository, error) {
+				return repo, nil
+			},
+		}
+
+		return repo, syncer
+	}
+
+	t.Run("Within limits", func(t *testing.T) {
+		repo, syncer := setup(t)
+		repo.EXPECT().ExecutePlannedStatements(mock.Anything, `DROP ROLE "OLD_ROLE"`).Return(nil).Once()
+
+		feedbackHandler := mocks.NewSimpleAccessProviderFeedbackHandler(t)
+
+		err := syncer.SyncAccessProviderToTarget(context.Background(), accessProviders, feedbackHandler, &config.ConfigMap{Parameters: map[string]string{SfMaxRoleDrops: "1"}})
+		require.NoError(t, err)
+
+		assert.Equal(t, []importer.AccessProviderSyncFeedback{
+			{AccessProvider: "ap1", ExternalId: ptr.String("OLD_ROLE")},
+		}, feedbackHandler.AccessProviderFeedback)
+	})
+
+	t.Run("Limit exceeded", func(t *testing.T) {
+		_, syncer := setup(t)
+
+		feedbackHandler := mocks.NewSimpleAccessProviderFeedbackHandler(t)
+
+		err := syncer.SyncAccessProviderToTarget(context.Background(), accessProviders, feedbackHandler, &config.ConfigMap{Parameters: map[string]string{SfMaxRoleDrops: "0"}})
+		require.NoError(t, err)
+
+		require.Len(t, feedbackHandler.AccessProviderFeedback, 1)
+		assert.Equal(t, []string{`nothing was changed in Snowflake because the sync exceeds the blast-radius limits: 1 role drops exceeds the limit of 0. Set "sf-allow-mass-changes" to true to apply these changes anyway`}, feedbackHandler.AccessProviderFeedback[0].Errors)
+	})
+}
+
+func TestAccessSyncer_SyncAccessProviderToTarget_BlastRadius_rename(t *testing.T) {
+	// Given
+	accessProviders := &importer.AccessProviderImport{AccessProviders: []*importer.AccessProvider{
+		{
+			Id:         "ap1",
+			Name:       "New role",
+			NamingHint: "NEW_ROLE",
+			Action:     types.Grant,
+			Type:       ptr.String(access_provider.Role),
+			ExternalId: ptr.String("OLD_ROLE"),
+			Who:        importer.WhoItem{Users: []string{"USER1", "USER2"}},
+		},
+	}}
+
+	plan := NewExecutionPlan()
+
+	repo := newMockDataAccessRepository(t)
+	repo.EXPECT().ExecutionPlan().Return(plan)
+	repo.EXPECT().GetAccountRolesWithPrefix(mock.Anything, "").Return([]RoleEntity{{Name: "OLD_ROLE"}}, nil).Once()
+	repo.EXPECT().GetAccountRoles(mock.Anything).Return([]RoleEntity{{Name: "OLD_ROLE"}}, nil).Maybe()
+	repo.EXPECT().RenameAccountRole(mock.Anything, "OLD_ROLE", "NEW_ROLE").Run(func(ctx context.Context, oldName, newName string) {
+		plan.add(ctx, `ALTER ROLE IF EXISTS "OLD_ROLE" RENAME TO "NEW_ROLE"`)
+	}).Return(nil).Once()
+	repo.EXPECT().CommentAccountRoleIfExists(mock.Anything, mock.Anything, "NEW_ROLE").Return(nil).Once()
+
+	// The current grants are read from the role with its old name, as the rename is only planned
+	repo.EXPECT().GetGrantsOfAccountRole(mock.Anything, "OLD_ROLE").Return([]GrantOfRole{{GrantedTo: "USER", GranteeName: "USER1"}}, nil).Once()
+	repo.EXPECT().GetGrantsToAccountRole(mock.Anything, "OLD_ROLE").Return([]GrantToRole{}, nil).Once()
+
+	repo.EXPECT().GrantUsersToAccountRole(mock.Anything, "NEW_ROLE", "USER2").Run(func(ctx context.Context, role string, users ...string) {
+		plan.add(ctx, `GRANT ROLE "NEW_ROLE" TO USER "USER2"`)
+	}).Return(nil).Once()
+	repo.EXPECT().ExecutePlannedStatements(mock.Anything, `ALTER ROLE IF EXISTS "OLD_ROLE" RENAME TO "NEW_ROLE"`, `GRANT ROLE "NEW_ROLE" TO USER "USER2"`).Return(nil).Once()
+	repo.EXPECT().TotalQueryTime().Return(0)
+	repo.EXPECT().TotalRetries().Return(0)
+	repo.EXPECT().Close().Return(nil)
+
+	syncer := createAccessSyncer(repo)
+	feedbackHandler := mocks.NewSimpleAccessProviderFeedbackHandler(t)
+
+	// When
+	err := syncer.SyncAccessProviderToTarget(context.Background(), accessProviders, feedbackHandler, &config.ConfigMap{Parameters: map[string]string{SfMaxRevokes: "10"}})
+
+	// Then
+	require.NoError(t, err)
+
+	require.Len(t, feedbackHandler.AccessProviderFeedback, 1)
+	assert.Empty(t, feedbackHandler.AccessProviderFeedback[0].Errors)
+	assert.Equal(t, "NEW_ROLE", feedbackHandler.AccessProviderFeedback[0].ActualName)
+}
+
+func TestAccessSyncer_SyncAccessProviderToTarget_BlastRadius_maskDropPercentage(t *testing.T) {
+	accessProviders := &importer.AccessProviderImport{AccessProviders: []*importer.AccessProvider{
+		{Id: "ap1", Name: "Mask1", Action: types.Mask, Delete: true, ExternalId: ptr.String("RAITO_MASK1"), ActualName: ptr.String("RAITO_MASK1")},
+	}}
+
+	setup := func(t *testing.T) (*mockDataAccessRepository, *AccessSyncer) {
+		t.Helper()
+
+		plan := NewExecutionPlan()
+
+		repo := newMockDataAccessRepository(t)
+		repo.EXPECT().ExecutionPlan().Return(plan)
+		repo.EXPECT().GetAccountRolesWithPrefix(mock.Anything, "").Return(nil, nil).Once()
+
+		// The percentage is relative to all masking policies of Raito, not only to the policies of the removed mask
+		repo.EXPECT().GetPoliciesLike(mock.Anything, "MASKING", "RAITO_%").Return([]PolicyEntity{
+			{Name: "RAITO_MASK1_TEXT", DatabaseName: "DB", SchemaName: "S"},
+			{Name: "RAITO_MASK2_TEXT", DatabaseName: "DB", SchemaName: "S"},
+			{Name: "RAITO_MASK3_TEXT", DatabaseName: "DB", SchemaName: "S"},
+			{Name: "RAITO_MASK4_TEXT", DatabaseName: "DB", SchemaName: "S"},
+		}, nil).Once()
+		repo.EXPECT().GetPoliciesLike(mock.Anything, "MASKING", "RAITO_MASK1%").Return([]PolicyEntity{{Name: "RAITO_MASK1_TEXT", DatabaseName: "DB", SchemaName: "S"}}, nil).Once()
+		repo.EXPECT().DropMaskingPolicy(mock.Anything, "DB", "S", "RAITO_MASK1").Run(func(ctx context.Context, databaseName string, schema string, maskName string) {
+			plan.add(ctx, `DROP MASKING POLICY "DB"."S"."RAITO_MASK1_TEXT"`)
+		}).Return(nil).Once()
+		repo.EXPECT().TotalQueryTime().Return(0)
+		repo.EXPECT().TotalRetries().Return(0)
+		repo.EXPECT().Close().Return(nil)
+
+		return repo, createAccessSyncer(repo)
+	}
+
+	t.Run("Within limits", func(t *testing.T) {
+		repo, syncer := setup(t)
+		repo.EXPECT().ExecutePlannedStatements(mock.Anything, `DROP MASKING POLICY "DB"."S"."RAITO_MASK1_TEXT"`).Return(nil).Once()
+
+		feedbackHandler := mocks.NewSimpleAccessProviderFeedbackHandler(t)
+
+		err := syncer.SyncAccessProviderToTarget(context.Background(), accessProviders, feedbackHandler, &config.ConfigMap{Parameters: map[string]string{SfMaxMaskDrops: "50%"}})
+		require.NoError(t, err)
+
+		require.Len(t, feedbackHandler.AccessProviderFeedback, 1)
+		assert.Empty(t, feedbackHandler.AccessProviderFeedback[0].Errors)
+	})
+
+	t.Run("Limit exceeded", func(t *testing.T) {
+		_, syncer := setup(t)
+
+		feedbackHandler := mocks.NewSimpleAccessProviderFeedbackHandler(t)
+
+		err := syncer.SyncAccessProviderToTarget(context.Background(), accessProviders, feedbackHandler, &config.ConfigMap{Parameters: map[string]string{SfMaxMaskDrops: "20%"}})
+		require.NoError(t, err)
+
+		require.Len(t, feedbackHandler.AccessProviderFeedback, 1)
+		assert.Equal(t, []string{`nothing was changed in Snowflake because the sync exceeds the blast-radius limits: 1 masking policy drops (25.0% of 4) exceeds the limit of 20%. Set "sf-allow-mass-changes" to true to apply these changes anyway`}, feedbackHandler.AccessProviderFeedback[0].Errors)
+	})
+}
+
+func TestAccessToTargetSyncer_observeGrantTotal(t *testing.T) {
+	// Given
+	repo := newMockDataAccessRepository(t)
+	repo.EXPECT().GetAllGrantsToAccountRoles(mock.Anything).Return(map[string][]GrantToRole{
+		"R1":        {{GrantedOn: "TABLE", Name: "DB.S.T1"}, {GrantedOn: "TABLE", Name: "DB.S.T2"}},
+		"R2":        {{GrantedOn: "DATABASE", Name: "DB"}},
+		"PROTECTED": {{GrantedOn: "DATABASE", Name: "DB"}},
+		"UNKNOWN":   {{GrantedOn: "DATABASE", Name: "DB"}},
+	}, nil).Once()
+	repo.EXPECT().GetAllGrantsOfAccountRoles(mock.Anything).Return(map[string][]GrantOfRole{
+		"R1":        {{GrantedTo: "USER", GranteeName: "JOHN"}},
+		"PROTECTED": {{GrantedTo: "USER", GranteeName: "JOHN"}},
+	}, nil).Once()
+
+	unmanaged, err := newUnmanagedRoles(&config.ConfigMap{Parameters: map[string]string{SfProtectedRoles: "PROTECTED"}})
+	require.NoError(t, err)
+
+	syncer := createBasicToTargetSyncer(repo, nil, mocks.NewSimpleAccessProviderFeedbackHandler(t), &config.ConfigMap{})
+	syncer.unmanagedRoles = unmanaged
+	syncer.blastRadius = newBlastRadius(blastRadiusLimits{blastRadiusRevokes: {absolute: -1, percentage: 10}})
+
+	// When
+	err = syncer.observeGrantTotal(context.Background(), set.NewSet("R1", "R2", "PROTECTED", databaseRoleExternalIdGenerator("DB", "R1")))
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, 4, syncer.blastRadius.current[blastRadiusRevokes])
+
+	// The totals are not read without a percentage limit
+	syncer.blastRadius = newBlastRadius(blastRadiusLimits{blastRadiusRevokes: {absolute: 10, percentage: -1}})
+
+	require.NoError(t, syncer.observeGrantTotal(context.Background(), set.NewSet("R1")))
+	assert.Zero(t, syncer.blastRadius.current[blastRadiusRevokes])
+}
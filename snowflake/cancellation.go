@@ -0,0 +1,86 @@
+package snowflake
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	sf "github.com/snowflakedb/gosnowflake"
+)
+
+const (
+	statementTimeoutSessionParameter = "STATEMENT_TIMEOUT_IN_SECONDS"
+
+	cancelQueryTimeout = 10 * time.Second
+)
+
+// statementTimeoutFromParams returns the configured maximum duration of a single statement, rounded up to seconds, or 0 if no timeout is configured.
+func statementTimeoutFromParams(params map[string]string) (int, error) {
+	v, f := params[SfStatementTimeout]
+	if !f || v == "" {
+		return 0, nil
+	}
+
+	timeout, err := time.ParseDuration(v)
+	if err != nil || timeout <= 0 {
+		return 0, fmt.Errorf("invalid value %q for %q parameter (must be a positive duration, e.g. '10m')", v, SfStatementTimeout)
+	}
+
+	return int(math.Ceil(timeout.Seconds())), nil
+}
+
+// cancelOnContextDone returns a context that reports the id of the statement executed with it.
+// If ctx is cancelled before done is called, the statement is cancelled in Snowflake using SYSTEM$CANCEL_QUERY,
+// so it doesn't keep running in the warehouse after the sync is stopped.
+// done returns the id of the statement, or an empty string if it wasn't reported.
+func (repo *SnowflakeRepository) cancelOnContextDone(ctx context.Context) (context.Context, func() string) {
+	queryIdChan := make(chan string, 1)
+	finished := make(chan struct{})
+	reported := make(chan string, 1)
+
+	go func() {
+		var queryId string
+
+		select {
+		case queryId = <-queryIdChan:
+		case <-finished:
+			// The query id could have been reported right before the statement returned
+			select {
+			case queryId = <-queryIdChan:
+			default:
+			}
+
+			reported <- queryId
+
+			return
+		}
+
+		select {
+		case <-finished:
+		case <-ctx.Done():
+			repo.cancelQuery(queryId)
+		}
+
+		reported <- queryId
+	}()
+
+	return sf.WithQueryIDChan(ctx, queryIdChan), func() string {
+		close(finished)
+
+		return <-reported
+	}
+}
+
+func (repo *SnowflakeRepository) cancelQuery(queryId string) {
+	// The original context is already cancelled, so a new one is needed to send the cancel request
+	ctx, cancel := context.WithTimeout(context.Background(), cancelQueryTimeout)
+	defer cancel()
+
+	Logger.Info(fmt.Sprintf("Cancelling Snowflake query %s", queryId))
+
+	_, err := repo.conn.ExecContext(ctx, "SELECT SYSTEM$CANCEL_QUERY(?)", queryId)
+	if err != nil {
+		Logger.Warn(fmt.Sprintf("Unable to cancel Snowflake query %s: %s", queryId, err.Error()))
+	}
+}
@@ -0,0 +1,37 @@
+package snowflake
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatementTimeoutFromParams(t *testing.T) {
+	tests := []struct {
+		name     string
+		params   map[string]string
+		expected int
+		wantErr  bool
+	}{
+		{name: "not set", params: map[string]string{}, expected: 0},
+		{name: "empty", params: map[string]string{SfStatementTimeout: ""}, expected: 0},
+		{name: "minutes", params: map[string]string{SfStatementTimeout: "10m"}, expected: 600},
+		{name: "rounded up to seconds", params: map[string]string{SfStatementTimeout: "1500ms"}, expected: 2},
+		{name: "negative", params: map[string]string{SfStatementTimeout: "-1s"}, wantErr: true},
+		{name: "invalid", params: map[string]string{SfStatementTimeout: "ten minutes"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			timeout, err := statementTimeoutFromParams(tt.params)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, timeout)
+		})
+	}
+}
@@ -0,0 +1,296 @@
+package snowflake
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	importer "github.com/raito-io/cli/base/access_provider/sync_to_target"
+)
+
+type PlannedChangeType string
+
+const (
+	PlannedChangeGrantAdd      PlannedChangeType = "grant-add"
+	PlannedChangeGrantRemove   PlannedChangeType = "grant-remove"
+	PlannedChangeRoleCreate    PlannedChangeType = "role-create"
+	PlannedChangeRoleRename    PlannedChangeType = "role-rename"
+	PlannedChangeRoleDrop      PlannedChangeType = "role-drop"
+	PlannedChangeMask          PlannedChangeType = "mask"
+	PlannedChangeFilter        PlannedChangeType = "filter"
+	PlannedChangeShare         PlannedChangeType = "share"
+	PlannedChangeShareAccounts PlannedChangeType = "share-accounts"
+	PlannedChangeOther         PlannedChangeType = "other"
+)
+
+// plannedChangeType classifies a statement of the execution plan, so reviewers can filter on the type of change
+func plannedChangeType(statement string) PlannedChangeType {
+	normalized := strings.Join(strings.Fields(strings.ToUpper(statement)), " ")
+	kind := statementKind(statement)
+
+	switch {
+	case strings.Contains(normalized, "MASKING POLICY"):
+		return PlannedChangeMask
+	case strings.Contains(normalized, "ROW ACCESS POLICY"):
+		return PlannedChangeFilter
+	case strings.HasPrefix(normalized, "ALTER SHARE ") && strings.Contains(normalized, " ACCOUNTS"):
+		return PlannedChangeShareAccounts
+	case strings.HasPrefix(kind, "GRANT"):
+		return PlannedChangeGrantAdd
+	case strings.HasPrefix(kind, "REVOKE"):
+		return PlannedChangeGrantRemove
+	case strings.HasSuffix(kind, " ROLE") && strings.Contains(normalized, " RENAME TO "):
+		return PlannedChangeRoleRename
+	case kind == "CREATE ROLE" || kind == "CREATE DATABASE ROLE":
+		return PlannedChangeRoleCreate
+	case kind == "DROP ROLE" || kind == "DROP DATABASE ROLE":
+		return PlannedChangeRoleDrop
+	case strings.HasSuffix(kind, " SHARE"):
+		return PlannedChangeShare
+	default:
+		return PlannedChangeOther
+	}
+}
+
+// PlannedChange is a single statement of the change plan.
+// Consecutive changes with the same (non-zero) transaction must be applied together in a Snowflake transaction.
+type PlannedChange struct {
+	Type        PlannedChangeType `json:"type"`
+	Statement   string            `json:"statement"`
+	Transaction int               `json:"transaction,omitempty"`
+}
+
+type AccessProviderChanges struct {
+	AccessProviders []PlannedAccessProvider `json:"accessProviders"`
+	Changes         []PlannedChange         `json:"changes"`
+}
+
+func (c *AccessProviderChanges) statements() []string {
+	statements := make([]string, 0, len(c.Changes))
+	for _, change := range c.Changes {
+		statements = append(statements, change.Statement)
+	}
+
+	return statements
+}
+
+// plannedChangeBatch is a sequence of changes that are executed together, in a transaction if transactional is true
+type plannedChangeBatch struct {
+	statements    []string
+	transactional bool
+}
+
+// batches splits the changes in the sequences of changes that are executed together, keeping the order of the changes.
+// Changes of the same transaction form a single batch, consecutive changes outside a transaction are also batched together.
+func (c *AccessProviderChanges) batches() []plannedChangeBatch {
+	var batches []plannedChangeBatch
+
+	for i, change := range c.Changes {
+		if i == 0 || change.Transaction != c.Changes[i-1].Transaction {
+			batches = append(batches, plannedChangeBatch{transactional: change.Transaction != 0})
+		}
+
+		batches[len(batches)-1].statements = append(batches[len(batches)-1].statements, change.Statement)
+	}
+
+	return batches
+}
+
+// ChangePlan is the reviewable JSON representation of an execution plan.
+// It can be applied later with the sf-apply-plan-file parameter.
+type ChangePlan struct {
+	RunId     string                  `json:"runId"`
+	CreatedAt time.Time               `json:"createdAt"`
+	Plans     []AccessProviderChanges `json:"plans"`
+}
+
+func (p *ExecutionPlan) ChangePlan() *ChangePlan {
+	changePlan := ChangePlan{RunId: runId, CreatedAt: time.Now().UTC()}
+
+	for _, plan := range p.AccessProviderPlans() {
+		changes := AccessProviderChanges{AccessProviders: plan.AccessProviders, Changes: make([]PlannedChange, 0, len(plan.Statements))}
+
+		for i, statement := range plan.Statements {
+			change := PlannedChange{Type: plannedChangeType(statement), Statement: statement}
+			if plan.Transactions != nil {
+				change.Transaction = plan.Transactions[i]
+			}
+
+			changes.Changes = append(changes.Changes, change)
+		}
+
+		changePlan.Plans = append(changePlan.Plans, changes)
+	}
+
+	return &changePlan
+}
+
+// WriteJSON writes the change plan of all planned statements to the file
+func (p *ExecutionPlan) WriteJSON(path string) error {
+	data, err := json.MarshalIndent(p.ChangePlan(), "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal change plan: %w", err)
+	}
+
+	return writeFileAtomically(path, data)
+}
+
+func readChangePlan(path string) (*ChangePlan, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read change plan %q: %w", path, err)
+	}
+
+	var changePlan ChangePlan
+
+	err = json.Unmarshal(data, &changePlan)
+	if err != nil {
+		return nil, fmt.Errorf("parse change plan %q: %w", path, err)
+	}
+
+	return &changePlan, nil
+}
+
+func accessProvidersKey(aps []PlannedAccessProvider) string {
+	ids := make([]string, 0, len(aps))
+	for _, ap := range aps {
+		ids = append(ids, ap.Id)
+	}
+
+	return strings.Join(ids, ",")
+}
+
+func accessProvidersDescription(aps []PlannedAccessProvider) string {
+	if len(aps) == 0 {
+		return "changes not linked to an access provider"
+	}
+
+	names := make([]string, 0, len(aps))
+	for _, ap := range aps {
+		names = append(names, fmt.Sprintf("%q", ap.Name))
+	}
+
+	return "access provider " + strings.Join(names, ", ")
+}
+
+// Drift compares the reviewed change plan with the changes calculated from the current state of Snowflake.
+// The order of the statements is ignored, as the access providers are handled in a random order.
+// A description is returned for every (group of) access provider(s) for which the changes differ.
+func (c *ChangePlan) Drift(current *ChangePlan) []string {
+	currentPlans := make(map[string]AccessProviderChanges, len(current.Plans))
+	for _, plan := range current.Plans {
+		currentPlans[accessProvidersKey(plan.AccessProviders)] = plan
+	}
+
+	var drift []string
+
+	for _, reviewed := range c.Plans {
+		key := accessProvidersKey(reviewed.AccessProviders)
+
+		currentPlan, found := currentPlans[key]
+		delete(currentPlans, key)
+
+		reviewedStatements := reviewed.statements()
+		currentStatements := currentPlan.statements()
+
+		slices.Sort(reviewedStatements)
+		slices.Sort(currentStatements)
+
+		if !found || !slices.Equal(reviewedStatements, currentStatements) {
+			drift = append(drift, fmt.Sprintf("%s: %d change(s) reviewed, %d change(s) required now", accessProvidersDescription(reviewed.AccessProviders), len(reviewedStatements), len(currentStatements)))
+		}
+	}
+
+	for _, plan := range current.Plans {
+		if _, notReviewed := currentPlans[accessProvidersKey(plan.AccessProviders)]; notReviewed {
+			drift = append(drift, fmt.Sprintf("%s: 0 change(s) reviewed, %d change(s) required now", accessProvidersDescription(plan.AccessProviders), len(plan.Changes)))
+		}
+	}
+
+	return drift
+}
+
+// executeChangePlan executes the statements of the change plan, unless they exceed the blast-radius limits, and sends the feedback of the planned sync.
+// Execution errors are added to the feedback of the access providers the statements belong to.
+func (s *AccessToTargetSyncer) executeChangePlan(ctx context.Context, changePlan *ChangePlan, feedback []importer.AccessProviderSyncFeedback, limits blastRadiusLimits) error {
+	if exceeded := s.blastRadius.exceeded(limits, changePlan); len(exceeded) > 0 {
+		limitErr := fmt.Errorf("nothing was changed in Snowflake because the sync exceeds the blast-radius limits: %s. Set %q to true to apply these changes anyway", strings.Join(exceeded, "; "), SfAllowMassChanges)
+
+		for i := range feedback {
+			err := s.handleAccessProviderFeedback(&feedback[i], limitErr)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	errorsPerAccessProvider := make(map[string][]string)
+
+	var unlinkedErr error
+
+	for i := range changePlan.Plans {
+		changes := &changePlan.Plans[i]
+
+		err := s.executeChanges(withPlannedAccessProviders(ctx, changes.AccessProviders), changes)
+		if err != nil {
+			Logger.Error(fmt.Sprintf("Unable to apply the changes of %s: %s", accessProvidersDescription(changes.AccessProviders), err.Error()))
+
+			if len(changes.AccessProviders) == 0 {
+				unlinkedErr = multierror.Append(unlinkedErr, err)
+			}
+
+			for _, ap := range changes.AccessProviders {
+				errorsPerAccessProvider[ap.Id] = append(errorsPerAccessProvider[ap.Id], err.Error())
+			}
+		}
+	}
+
+	for i := range feedback {
+		feedback[i].Errors = append(feedback[i].Errors, errorsPerAccessProvider[feedback[i].AccessProvider]...)
+
+		err := s.accessProviderFeedbackHandler.AddAccessProviderFeedback(feedback[i])
+		if err != nil {
+			return err
+		}
+	}
+
+	return unlinkedErr
+}
+
+// executeChanges executes the changes of a (group of) access provider(s) in order, the changes of a transaction in a single transaction.
+// The execution stops at the first error.
+func (s *AccessToTargetSyncer) executeChanges(ctx context.Context, changes *AccessProviderChanges) error {
+	for _, batch := range changes.batches() {
+		var err error
+
+		if batch.transactional {
+			err = s.repo.ExecutePlannedTransaction(ctx, batch.statements...)
+		} else {
+			err = s.repo.ExecutePlannedStatements(ctx, batch.statements...)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// bufferedFeedbackHandler keeps the feedback until the reviewed change plan is applied
+type bufferedFeedbackHandler struct {
+	feedback []importer.AccessProviderSyncFeedback
+}
+
+func (h *bufferedFeedbackHandler) AddAccessProviderFeedback(feedback importer.AccessProviderSyncFeedback) error {
+	h.feedback = append(h.feedback, feedback)
+
+	return nil
+}
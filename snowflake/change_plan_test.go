@@ -0,0 +1,324 @@
+package snowflake
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/aws/smithy-go/ptr"
+	importer "github.com/raito-io/cli/base/access_provider/sync_to_target"
+	"github.com/raito-io/cli/base/access_provider/types"
+	"github.com/raito-io/cli/base/data_source"
+	"github.com/raito-io/cli/base/util/config"
+	"github.com/raito-io/cli/base/wrappers/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlannedChangeType(t *testing.T) {
+	tests := []struct {
+		statement string
+		expected  PlannedChangeType
+	}{
+		{statement: `GRANT SELECT ON TABLE "DB"."S"."T" TO ROLE "R1"`, expected: PlannedChangeGrantAdd},
+		{statement: `GRANT ROLE "R1" TO USER "JOHN"`, expected: PlannedChangeGrantAdd},
+		{statement: `REVOKE USAGE ON DATABASE "DB" FROM ROLE "R1"`, expected: PlannedChangeGrantRemove},
+		{statement: `CREATE ROLE IF NOT EXISTS "R1"`, expected: PlannedChangeRoleCreate},
+		{statement: `CREATE DATABASE ROLE IF NOT EXISTS "DB"."R1"`, expected: PlannedChangeRoleCreate},
+		{statement: `ALTER ROLE IF EXISTS "R1" RENAME TO "R2"`, expected: PlannedChangeRoleRename},
+		{statement: `ALTER DATABASE ROLE IF EXISTS "DB"."R1" RENAME TO "DB"."R2"`, expected: PlannedChangeRoleRename},
+		{statement: `DROP ROLE "R1"`, expected: PlannedChangeRoleDrop},
+		{statement: `CREATE MASKING POLICY "DB"."S"."M" AS (val STRING) RETURNS STRING -> val`, expected: PlannedChangeMask},
+		{statement: `ALTER TABLE "DB"."S"."T" MODIFY COLUMN "C" SET MASKING POLICY "DB"."S"."M" FORCE`, expected: PlannedChangeMask},
+		{statement: `ALTER TABLE "DB"."S"."T" ADD ROW ACCESS POLICY "DB"."S"."F" ON ("A")`, expected: PlannedChangeFilter},
+		{statement: `CREATE SHARE IF NOT EXISTS "SHARE1"`, expected: PlannedChangeShare},
+		{statement: `ALTER SHARE "SHARE1" SET ACCOUNTS=ORG.ACC1,ORG.ACC2`, expected: PlannedChangeShareAccounts},
+		{statement: `COMMENT IF EXISTS ON ROLE "R1" IS 'Created by Raito'`, expected: PlannedChangeOther},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.statement, func(t *testing.T) {
+			assert.Equal(t, tt.expected, plannedChangeType(tt.statement))
+		})
+	}
+}
+
+func TestChangePlan_JSON(t *testing.T) {
+	plan := NewExecutionPlan()
+	plan.add(withAccessProviders(context.Background(), &importer.AccessProvider{Id: "ap1", Name: "Sales"}), `CREATE ROLE IF NOT EXISTS "SALES"`, `GRANT ROLE "SALES" TO USER "JANE"`)
+
+	path := filepath.Join(t.TempDir(), "plan.json")
+	require.NoError(t, plan.WriteJSON(path))
+
+	changePlan, err := readChangePlan(path)
+	require.NoError(t, err)
+
+	assert.Equal(t, runId, changePlan.RunId)
+	assert.Equal(t, []AccessProviderChanges{{
+		AccessProviders: []PlannedAccessProvider{{Id: "ap1", Name: "Sales"}},
+		Changes: []PlannedChange{
+			{Type: PlannedChangeRoleCreate, Statement: `CREATE ROLE IF NOT EXISTS "SALES"`},
+			{Type: PlannedChangeGrantAdd, Statement: `GRANT ROLE "SALES" TO USER "JANE"`},
+		},
+	}}, changePlan.Plans)
+}
+
+func TestChangePlan_Transactions(t *testing.T) {
+	plan := NewExecutionPlan()
+	repo := &SnowflakeRepository{plan: plan}
+
+	ctx := withAccessProviders(context.Background(), &importer.AccessProvider{Id: "ap1", Name: "Mask"})
+
+	require.NoError(t, repo.execute(ctx, `GRANT USAGE ON SCHEMA "DB"."S" TO ROLE "RAITO"`))
+
+	tx, err := repo.beginTransaction(ctx)
+	require.NoError(t, err)
+
+	_, err = tx.Exec(`CREATE MASKING POLICY "DB"."S"."M_TEXT" AS (val STRING) RETURNS STRING -> NULL`)
+	require.NoError(t, err)
+	_, err = tx.Exec(`ALTER TABLE "DB"."S"."T" MODIFY COLUMN "C" SET MASKING POLICY "DB"."S"."M_TEXT" FORCE`)
+	require.NoError(t, err)
+	require.NoError(t, tx.Commit())
+
+	tx, err = repo.beginTransaction(ctx)
+	require.NoError(t, err)
+
+	_, err = tx.Exec(`DROP MASKING POLICY "DB"."S"."OLD_TEXT"`)
+	require.NoError(t, err)
+	require.NoError(t, tx.Commit())
+
+	require.NoError(t, repo.execute(ctx, `GRANT USAGE ON SCHEMA "DB"."S2" TO ROLE "RAITO"`))
+
+	path := filepath.Join(t.TempDir(), "plan.json")
+	require.NoError(t, plan.WriteJSON(path))
+
+	changePlan, err := readChangePlan(path)
+	require.NoError(t, err)
+
+	require.Len(t, changePlan.Plans, 1)
+	assert.Equal(t, []PlannedChange{
+		{Type: PlannedChangeGrantAdd, Statement: `GRANT USAGE ON SCHEMA "DB"."S" TO ROLE "RAITO"`},
+		{Type: PlannedChangeMask, Statement: `CREATE MASKING POLICY "DB"."S"."M_TEXT" AS (val STRING) RETURNS STRING -> NULL`, Transaction: 1},
+		{Type: PlannedChangeMask, Statement: `ALTER TABLE "DB"."S"."T" MODIFY COLUMN "C" SET MASKING POLICY "DB"."S"."M_TEXT" FORCE`, Transaction: 1},
+		{Type: PlannedChangeMask, Statement: `DROP MASKING POLICY "DB"."S"."OLD_TEXT"`, Transaction: 2},
+		{Type: PlannedChangeGrantAdd, Statement: `GRANT USAGE ON SCHEMA "DB"."S2" TO ROLE "RAITO"`},
+	}, changePlan.Plans[0].Changes)
+
+	// The statements of a transaction are applied together in a transaction
+	repoMock := newMockDataAccessRepository(t)
+	grantCall := repoMock.EXPECT().ExecutePlannedStatements(mock.Anything, `GRANT USAGE ON SCHEMA "DB"."S" TO ROLE "RAITO"`).Return(nil).Once()
+	createCall := repoMock.EXPECT().ExecutePlannedTransaction(mock.Anything, `CREATE MASKING POLICY "DB"."S"."M_TEXT" AS (val STRING) RETURNS STRING -> NULL`, `ALTER TABLE "DB"."S"."T" MODIFY COLUMN "C" SET MASKING POLICY "DB"."S"."M_TEXT" FORCE`).Return(nil).Once().NotBefore(grantCall)
+	dropCall := repoMock.EXPECT().ExecutePlannedTransaction(mock.Anything, `DROP MASKING POLICY "DB"."S"."OLD_TEXT"`).Return(nil).Once().NotBefore(createCall)
+	repoMock.EXPECT().ExecutePlannedStatements(mock.Anything, `GRANT USAGE ON SCHEMA "DB"."S2" TO ROLE "RAITO"`).Return(nil).Once().NotBefore(dropCall)
+
+	feedbackHandler := mocks.NewSimpleAccessProviderFeedbackHandler(t)
+	syncer := &AccessToTargetSyncer{repo: repoMock, accessProviderFeedbackHandler: feedbackHandler}
+
+	require.NoError(t, syncer.executeChangePlan(context.Background(), changePlan, []importer.AccessProviderSyncFeedback{{AccessProvider: "ap1"}}, nil))
+
+	assert.Equal(t, []importer.AccessProviderSyncFeedback{{AccessProvider: "ap1"}}, feedbackHandler.AccessProviderFeedback)
+}
+
+func TestChangePlan_Drift(t *testing.T) {
+	sales := []PlannedAccessProvider{{Id: "ap1", Name: "Sales"}}
+	marketing := []PlannedAccessProvider{{Id: "ap2", Name: "Marketing"}}
+
+	changes := func(aps []PlannedAccessProvider, statements ...string) AccessProviderChanges {
+		result := AccessProviderChanges{AccessProviders: aps}
+		for _, statement := range statements {
+			result.Changes = append(result.Changes, PlannedChange{Type: plannedChangeType(statement), Statement: statement})
+		}
+
+		return result
+	}
+
+	reviewed := &ChangePlan{Plans: []AccessProviderChanges{
+		changes(sales, `GRANT ROLE "SALES" TO USER "JANE"`, `GRANT ROLE "SALES" TO USER "JOHN"`),
+		changes(marketing, `DROP ROLE "MARKETING"`),
+	}}
+
+	t.Run("No drift", func(t *testing.T) {
+		current := &ChangePlan{Plans: []AccessProviderChanges{
+			changes(marketing, `DROP ROLE "MARKETING"`),
+			changes(sales, `GRANT ROLE "SALES" TO USER "JOHN"`, `GRANT ROLE "SALES" TO USER "JANE"`),
+		}}
+
+		assert.Empty(t, reviewed.Drift(current))
+	})
+
+	t.Run("Drift", func(t *testing.T) {
+		current := &ChangePlan{Plans: []AccessProviderChanges{
+			changes(sales, `GRANT ROLE "SALES" TO USER "JOHN"`),
+			changes(nil, `DROP ROLE "UNKNOWN"`),
+		}}
+
+		assert.Equal(t, []string{
+			`access provider "Sales": 2 change(s) reviewed, 1 change(s) required now`,
+			`access provider "Marketing": 1 change(s) reviewed, 0 change(s) required now`,
+			`changes not linked to an access provider: 0 change(s) reviewed, 1 change(s) required now`,
+		}, reviewed.Drift(current))
+	})
+}
+
+func TestAccessSyncer_SyncAccessProviderToTarget_ApplyPlan(t *testing.T) {
+	accessProviders := &importer.AccessProviderImport{AccessProviders: []*importer.AccessProvider{
+		{Id: "ap1", Name: "Old role", Action: types.Grant, Delete: true, ExternalId: ptr.String("OLD_ROLE")},
+	}}
+
+	setup := func(t *testing.T, reviewedStatement string) (*mockDataAccessRepository, *AccessSyncer, *config.ConfigMap) {
+		t.Helper()
+
+		plan := NewExecutionPlan()
+
+		repo := newMockDataAccessRepository(t)
+		repo.EXPECT().ExecutionPlan().Return(plan)
+		repo.EXPECT().DropAccountRole(mock.Anything, "OLD_ROLE").Run(func(ctx context.Context, roleName string) {
+			plan.add(ctx, `DROP ROLE "OLD_ROLE"`)
+		}).Return(nil).Once()
+		repo.EXPECT().GetAccountRolesWithPrefix(mock.Anything, "").Return(nil, nil).Once()
+		repo.EXPECT().TotalQueryTime().Return(0)
+		repo.EXPECT().TotalRetries().Return(0)
+		repo.EXPECT().Close().Return(nil)
+
+		reviewed := NewExecutionPlan()
+		reviewed.add(withPlannedAccessProviders(context.Background(), []PlannedAccessProvider{{Id: "ap1", Name: "Old role"}}), reviewedStatement)
+
+		path := filepath.Join(t.TempDir(), "plan.json")
+		require.NoError(t, reviewed.WriteJSON(path))
+
+		syncer := &AccessSyncer{
+			namingConstraints: RoleNameConstraints,
+			repoProvider: func(params map[string]string, role string, phase SyncPhase) (dataAccessRepository, error) {
+				return repo, nil
+			},
+		}
+
+		return repo, syncer, &config.ConfigMap{Parameters: map[string]string{SfApplyPlanFile: path}}
+	}
+
+	t.Run("Apply reviewed plan", func(t *testing.T) {
+		repo, syncer, configMap := setup(t, `DROP ROLE "OLD_ROLE"`)
+		repo.EXPECT().ExecutePlannedStatements(mock.Anything, `DROP ROLE "OLD_ROLE"`).Return(errors.New("boom")).Once()
+
+		feedbackHandler := mocks.NewSimpleAccessProviderFeedbackHandler(t)
+
+		err := syncer.SyncAccessProviderToTarget(context.Background(), accessProviders, feedbackHandler, configMap)
+		require.NoError(t, err)
+
+		assert.Equal(t, []importer.AccessProviderSyncFeedback{
+			{AccessProvider: "ap1", ExternalId: ptr.String("OLD_ROLE"), Errors: []string{"boom"}},
+		}, feedbackHandler.AccessProviderFeedback)
+	})
+
+	t.Run("Drifted state", func(t *testing.T) {
+		_, syncer, configMap := setup(t, `REVOKE ROLE "OLD_ROLE" FROM USER "JOHN"`)
+
+		feedbackHandler := mocks.NewSimpleAccessProviderFeedbackHandler(t)
+
+		err := syncer.SyncAccessProviderToTarget(context.Background(), accessProviders, feedbackHandler, configMap)
+		require.Error(t, err)
+
+		assert.Contains(t, err.Error(), "nothing was applied")
+		assert.Empty(t, feedbackHandler.AccessProviderFeedback)
+	})
+}
+
+func TestAccessSyncer_SyncAccessProviderToTarget_ApplyPlanWithMaskAndFilter(t *testing.T) {
+	accessProviders := &importer.AccessProviderImport{AccessProviders: []*importer.AccessProvider{
+		{
+			Id:     "MaskId1",
+			Name:   "Mask1",
+			Action: types.Mask,
+			Type:   ptr.String("SHA256"),
+			Who:    importer.WhoItem{Users: []string{"User1"}, InheritFrom: []string{"Role1"}},
+			What: []importer.WhatItem{
+				{DataObject: &data_source.DataObjectReference{FullName: "DB1.Schema1.Table1.Column1", Type: "column"}},
+			},
+		},
+		{
+			Id:         "FilterId1",
+			Name:       "Filter1",
+			Action:     types.Filtered,
+			Who:        importer.WhoItem{Users: []string{"User1"}},
+			What:       []importer.WhatItem{{DataObject: &data_source.DataObjectReference{FullName: "DB1.Schema1.Table1", Type: data_source.Table}}},
+			PolicyRule: ptr.String("{state} = 'NJ' AND {country} = 'US'"),
+		},
+	}}
+
+	newRepo := func(t *testing.T) *mockDataAccessRepository {
+		t.Helper()
+
+		plan := NewExecutionPlan()
+
+		repo := newMockDataAccessRepository(t)
+		repo.EXPECT().ExecutionPlan().Return(plan)
+		repo.EXPECT().GetAccountRoles(mock.Anything).Return([]RoleEntity{}, nil).Once()
+		repo.EXPECT().GetAccountRolesWithPrefix(mock.Anything, "").Return(nil, nil).Once()
+		repo.EXPECT().GetPoliciesLike(mock.Anything, "MASKING", "RAITO_MASK1%").Return(nil, nil).Once()
+		repo.EXPECT().CreateMaskPolicy(mock.Anything, "DB1", "Schema1", mock.AnythingOfType("string"), []string{"DB1.Schema1.Table1.Column1"}, ptr.String("SHA256"), mock.Anything).
+			RunAndReturn(func(ctx context.Context, database string, schema string, maskName string, _ []string, _ *string, _ *MaskingBeneficiaries) error {
+				plan.add(ctx, fmt.Sprintf(`CREATE MASKING POLICY "%s"."%s"."%s_TEXT"`, database, schema, maskName))
+
+				return nil
+			}).Once()
+		repo.EXPECT().UpdateFilter(mock.Anything, "DB1", "Schema1", "Table1", mock.AnythingOfType("string"), []string{"country", "state"}, mock.AnythingOfType("string")).
+			RunAndReturn(func(ctx context.Context, database string, schema string, _ string, filterName string, _ []string, _ string) error {
+				plan.add(ctx, fmt.Sprintf(`CREATE ROW ACCESS POLICY "%s"."%s"."%s"`, database, schema, filterName))
+
+				return nil
+			}).Once()
+		repo.EXPECT().TotalQueryTime().Return(0)
+		repo.EXPECT().TotalRetries().Return(0)
+		repo.EXPECT().Close().Return(nil)
+
+		return repo
+	}
+
+	newSyncer := func(repo dataAccessRepository) *AccessSyncer {
+		return &AccessSyncer{
+			namingConstraints: RoleNameConstraints,
+			repoProvider: func(params map[string]string, role string, phase SyncPhase) (dataAccessRepository, error) {
+				return repo, nil
+			},
+		}
+	}
+
+	planFile := filepath.Join(t.TempDir(), "plan.json")
+
+	// Review run
+	err := newSyncer(newRepo(t)).SyncAccessProviderToTarget(context.Background(), accessProviders, mocks.NewSimpleAccessProviderFeedbackHandler(t), &config.ConfigMap{Parameters: map[string]string{SfDryRun: "true", SfDryRunJsonFile: planFile}})
+	require.NoError(t, err)
+
+	reviewedPlan, err := readChangePlan(planFile)
+	require.NoError(t, err)
+	require.Len(t, reviewedPlan.Plans, 2)
+
+	// Apply run
+	repo := newRepo(t)
+
+	var executed []string
+
+	repo.EXPECT().ExecutePlannedStatements(mock.Anything, mock.Anything).Run(func(ctx context.Context, statements ...string) {
+		executed = append(executed, statements...)
+	}).Return(nil).Times(2)
+
+	feedbackHandler := mocks.NewSimpleAccessProviderFeedbackHandler(t)
+
+	err = newSyncer(repo).SyncAccessProviderToTarget(context.Background(), accessProviders, feedbackHandler, &config.ConfigMap{Parameters: map[string]string{SfApplyPlanFile: planFile}})
+	require.NoError(t, err)
+
+	require.Len(t, feedbackHandler.AccessProviderFeedback, 2)
+
+	maskFeedback := feedbackHandler.AccessProviderFeedback[0]
+	filterFeedback := feedbackHandler.AccessProviderFeedback[1]
+
+	assert.Empty(t, maskFeedback.Errors)
+	assert.Empty(t, filterFeedback.Errors)
+	assert.ElementsMatch(t, []string{
+		fmt.Sprintf(`CREATE MASKING POLICY "DB1"."Schema1"."%s_TEXT"`, maskFeedback.ActualName),
+		fmt.Sprintf(`CREATE ROW ACCESS POLICY "DB1"."Schema1"."%s"`, filterFeedback.ActualName),
+	}, executed)
+}
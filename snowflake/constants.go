@@ -6,8 +6,15 @@ const (
 	SfPassword                          = "sf-password"
 	SfPrivateKey                        = "sf-private-key"
 	SfPrivateKeyPassphrase              = "sf-private-key-passphrase" //nolint:gosec
+	SfPrivateKeySecondary               = "sf-private-key-secondary"
+	SfPrivateKeySecondaryPassphrase     = "sf-private-key-secondary-passphrase" //nolint:gosec
+	SfPat                               = "sf-pat"
 	SfRole                              = "sf-role"
 	SfWarehouse                         = "sf-warehouse"
+	SfUsageRole                         = "sf-usage-role"
+	SfUsageWarehouse                    = "sf-usage-warehouse"
+	SfAccessRole                        = "sf-access-role"
+	SfAccessWarehouse                   = "sf-access-warehouse"
 	SfExcludedDatabases                 = "sf-excluded-databases"
 	SfExcludedSchemas                   = "sf-excluded-schemas"
 	SfExcludedRoles                     = "sf-excluded-roles"
@@ -16,6 +23,7 @@ const (
 	SfLinkToExternalIdentityStoreGroups = "sf-link-to-external-identity-store-groups"
 	SfSkipTags                          = "sf-skip-tags"
 	SfSkipColumns                       = "sf-skip-columns"
+	SfSchemaObjectTypes                 = "sf-schema-object-types"
 	SfDataUsageWindow                   = "sf-data-usage-window"
 	SfDatabaseRoles                     = "sf-database-roles"
 	SfApplications                      = "sf-applications"
@@ -24,7 +32,44 @@ const (
 	SfIgnoreLinksToRoles                = "sf-ignore-links-to-roles"
 	SfUsageBatchSize                    = "sf-usage-batch-size"
 	SfUsageUserExcludes                 = "sf-usage-user-excludes"
+	SfUsageIncludeOwnQueries            = "sf-usage-include-own-queries"
 	SfWorkerPoolSize                    = "sf-worker-pool-size"
+	SfRetryMaxAttempts                  = "sf-retry-max-attempts"
+	SfRetryInitialBackoff               = "sf-retry-initial-backoff"
+	SfStatementTimeout                  = "sf-statement-timeout"
+	SfMetricsFile                       = "sf-metrics-file"
+	SfMetricsPrometheusFile             = "sf-metrics-prometheus-file"
+	SfTracingExporter                   = "sf-tracing-exporter"
+	SfTracingOtlpEndpoint               = "sf-tracing-otlp-endpoint"
+	SfTracingFile                       = "sf-tracing-file"
+	SfDryRun                            = "sf-dry-run"
+	SfDryRunFile                        = "sf-dry-run-file"
+	SfDryRunJsonFile                    = "sf-dry-run-json-file"
+	SfApplyPlanFile                     = "sf-apply-plan-file"
+	SfAuditLogFile                      = "sf-audit-log-file"
+	SfAuditLogMaxSize                   = "sf-audit-log-max-size"
+	SfAuditLogMaxBackups                = "sf-audit-log-max-backups"
+	SfMaxRevokes                        = "sf-max-revokes"
+	SfMaxRoleDrops                      = "sf-max-role-drops"
+	SfMaxMaskDrops                      = "sf-max-mask-drops"
+	SfMaxShareAccountRemovals           = "sf-max-share-account-removals"
+	SfAllowMassChanges                  = "sf-allow-mass-changes"
+	SfPolicyRulesFile                   = "sf-policy-rules-file"
+	SfProtectedRoles                    = "sf-protected-roles"
+	SfUnmanagedRoleTag                  = "sf-unmanaged-role-tag"
+	SfSnapshotFile                      = "sf-snapshot-file"
+	SfRestoreSnapshotFile               = "sf-restore-snapshot-file"
+	SfRecordFixturesFile                = "sf-record-fixtures-file"
+	SfRecordFixturesAnonymise           = "sf-record-fixtures-anonymise"
+	SfReplayFixturesFile                = "sf-replay-fixtures-file"
+	SfGrantLoading                      = "sf-grant-loading"
+
+	SfOAuthTokenFile     = "sf-oauth-token-file"
+	SfOAuthTokenEnv      = "sf-oauth-token-env"
+	SfOAuthTokenEndpoint = "sf-oauth-token-endpoint"
+	SfOAuthClientId      = "sf-oauth-client-id"
+	SfOAuthClientSecret  = "sf-oauth-client-secret" //nolint:gosec
+	SfOAuthScope         = "sf-oauth-scope"
 
 	SfRoleOwnerEmailTag = "sf-role-owner-email-tag"
 	SfRoleOwnerNameTag  = "sf-role-owner-name-tag"
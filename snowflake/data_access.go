@@ -2,6 +2,7 @@ package snowflake
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"regexp"
 	"strings"
@@ -18,7 +19,7 @@ import (
 )
 
 var RolesNotInternalizable = []string{"ORGADMIN", "ACCOUNTADMIN", "SECURITYADMIN", "USERADMIN", "SYSADMIN", "PUBLIC"}
-var AcceptedTypes = map[string]struct{}{"ACCOUNT": {}, "WAREHOUSE": {}, "DATABASE": {}, "SCHEMA": {}, "TABLE": {}, "VIEW": {}, "COLUMN": {}, "SHARED-DATABASE": {}, "EXTERNAL_TABLE": {}, "MATERIALIZED_VIEW": {}, "FUNCTION": {}, "PROCEDURE": {}, "INTEGRATION": {}}
+var AcceptedTypes = map[string]struct{}{"ACCOUNT": {}, "WAREHOUSE": {}, "DATABASE": {}, "SCHEMA": {}, "TABLE": {}, "VIEW": {}, "COLUMN": {}, "SHARED-DATABASE": {}, "EXTERNAL_TABLE": {}, "MATERIALIZED_VIEW": {}, "DYNAMIC_TABLE": {}, "STAGE": {}, "STREAM": {}, "TASK": {}, "PIPE": {}, "SEQUENCE": {}, "FILE_FORMAT": {}, "SECRET": {}, "COMPUTE_POOL": {}, "IMAGE_REPOSITORY": {}, "SERVICE": {}, "FUNCTION": {}, "PROCEDURE": {}, "INTEGRATION": {}}
 
 const (
 	whoLockedReason         = "The 'who' for this Snowflake role cannot be changed because it was imported from an external identity store"
@@ -36,50 +37,55 @@ const (
 //go:generate go run github.com/vektra/mockery/v2 --name=dataAccessRepository --with-expecter --inpackage
 type dataAccessRepository interface {
 	Close() error
-	GetSnowFlakeAccountName(ops ...func(options *GetSnowFlakeAccountNameOptions)) (string, error)
-	CommentAccountRoleIfExists(comment, objectName string) error
-	CommentDatabaseRoleIfExists(comment, database, roleName string) error
-	CreateAccountRole(roleName string) error
-	CreateDatabaseRole(database, roleName string) error
-	CreateMaskPolicy(databaseName string, schema string, maskName string, columnsFullName []string, maskType *string, beneficiaries *MaskingBeneficiaries) error
-	DescribePolicy(policyType, dbName, schema, policyName string) ([]DescribePolicyEntity, error)
-	DropAccountRole(roleName string) error
-	DropDatabaseRole(database string, roleName string) error
-	DropFilter(databaseName string, schema string, tableName string, filterName string) error
-	DropMaskingPolicy(databaseName string, schema string, maskName string) (err error)
-	ExecuteGrantOnAccountRole(perm, on, role string, isSystemGrant bool) error
-	ExecuteGrantOnDatabaseRole(perm, on, database, databaseRole string) error
-	ExecuteRevokeOnAccountRole(perm, on, role string, isSystemGrant bool) error
-	ExecuteRevokeOnDatabaseRole(perm, on, database, databaseRole string) error
-	GetAccountRoles() ([]RoleEntity, error)
-	GetOutboundShares() ([]ShareEntity, error)
-	GetAccountRolesWithPrefix(prefix string) ([]RoleEntity, error)
-	GetDatabaseRoles(database string) ([]RoleEntity, error)
-	GetApplicationRoles(application string) ([]ApplicationRoleEntity, error)
-	GetDatabaseRolesWithPrefix(database string, prefix string) ([]RoleEntity, error)
-	GetDatabases() ([]DbEntity, error)
-	GetDatabasesByKind(kind string) ([]DbEntity, error)
-	GetApplications() ([]ApplictionEntity, error)
-	GetGrantsOfAccountRole(roleName string) ([]GrantOfRole, error)
-	GetGrantsOfDatabaseRole(database, roleName string) ([]GrantOfRole, error)
-	GetGrantsOfApplicationRole(application, role string) ([]GrantOfRole, error)
-	GetGrantsToAccountRole(roleName string) ([]GrantToRole, error)
-	GetGrantsToShare(shareName string) ([]GrantToRole, error)
-	GetGrantsToDatabaseRole(database, roleName string) ([]GrantToRole, error)
-	GetPolicies(policy string) ([]PolicyEntity, error)
-	GetPoliciesLike(policy string, like string) ([]PolicyEntity, error)
-	GetPolicyReferences(dbName, schema, policyName string) ([]PolicyReferenceEntity, error)
-	GetSchemasInDatabase(databaseName string, handleEntity EntityHandler) error
-	GetInboundShares() ([]DbEntity, error)
-	GetTablesInDatabase(databaseName string, schemaName string, handleEntity EntityHandler) error
-	GetFunctionsInDatabase(databaseName string, handleEntity EntityHandler) error
-	GetFunctionsInSchema(databaseName string, schemaName string, handleEntity EntityHandler) error
-	GetProceduresInDatabase(databaseName string, handleEntity EntityHandler) error
-	GetProceduresInSchema(databaseName string, schemaName string, handleEntity EntityHandler) error
-	GetTagsByDomain(domain string) (map[string][]*tag.Tag, error)
-	GetDatabaseRoleTags(databaseName string, roleName string) (map[string][]*tag.Tag, error)
-	GetWarehouses() ([]DbEntity, error)
-	GetIntegrations() ([]DbEntity, error)
+	ExecutionPlan() *ExecutionPlan
+	ExecutePlannedStatements(ctx context.Context, statements ...string) error
+	ExecutePlannedTransaction(ctx context.Context, statements ...string) error
+	GetSnowFlakeAccountName(ctx context.Context, ops ...func(options *GetSnowFlakeAccountNameOptions)) (string, error)
+	CommentAccountRoleIfExists(ctx context.Context, comment, objectName string) error
+	CommentDatabaseRoleIfExists(ctx context.Context, comment, database, roleName string) error
+	CreateAccountRole(ctx context.Context, roleName string) error
+	CreateDatabaseRole(ctx context.Context, database, roleName string) error
+	CreateMaskPolicy(ctx context.Context, databaseName string, schema string, maskName string, columnsFullName []string, maskType *string, beneficiaries *MaskingBeneficiaries) error
+	DescribePolicy(ctx context.Context, policyType, dbName, schema, policyName string) ([]DescribePolicyEntity, error)
+	DropAccountRole(ctx context.Context, roleName string) error
+	DropDatabaseRole(ctx context.Context, database string, roleName string) error
+	DropFilter(ctx context.Context, databaseName string, schema string, tableName string, filterName string) error
+	DropMaskingPolicy(ctx context.Context, databaseName string, schema string, maskName string) (err error)
+	ExecuteGrantOnAccountRole(ctx context.Context, perm, on, role string, isSystemGrant bool) error
+	ExecuteGrantOnDatabaseRole(ctx context.Context, perm, on, database, databaseRole string) error
+	ExecuteRevokeOnAccountRole(ctx context.Context, perm, on, role string, isSystemGrant bool) error
+	ExecuteRevokeOnDatabaseRole(ctx context.Context, perm, on, database, databaseRole string) error
+	GetAccountRoles(ctx context.Context) ([]RoleEntity, error)
+	GetOutboundShares(ctx context.Context) ([]ShareEntity, error)
+	GetAccountRolesWithPrefix(ctx context.Context, prefix string) ([]RoleEntity, error)
+	GetDatabaseRoles(ctx context.Context, database string) ([]RoleEntity, error)
+	GetApplicationRoles(ctx context.Context, application string) ([]ApplicationRoleEntity, error)
+	GetDatabaseRolesWithPrefix(ctx context.Context, database string, prefix string) ([]RoleEntity, error)
+	GetDatabases(ctx context.Context) ([]DbEntity, error)
+	GetDatabasesByKind(ctx context.Context, kind string) ([]DbEntity, error)
+	GetApplications(ctx context.Context) ([]ApplictionEntity, error)
+	GetGrantsOfAccountRole(ctx context.Context, roleName string) ([]GrantOfRole, error)
+	GetGrantsOfDatabaseRole(ctx context.Context, database, roleName string) ([]GrantOfRole, error)
+	GetGrantsOfApplicationRole(ctx context.Context, application, role string) ([]GrantOfRole, error)
+	GetGrantsToAccountRole(ctx context.Context, roleName string) ([]GrantToRole, error)
+	GetAllGrantsToAccountRoles(ctx context.Context) (map[string][]GrantToRole, error)
+	GetAllGrantsOfAccountRoles(ctx context.Context) (map[string][]GrantOfRole, error)
+	GetGrantsToShare(ctx context.Context, shareName string) ([]GrantToRole, error)
+	GetGrantsToDatabaseRole(ctx context.Context, database, roleName string) ([]GrantToRole, error)
+	GetPolicies(ctx context.Context, policy string) ([]PolicyEntity, error)
+	GetPoliciesLike(ctx context.Context, policy string, like string) ([]PolicyEntity, error)
+	GetPolicyReferences(ctx context.Context, dbName, schema, policyName string) ([]PolicyReferenceEntity, error)
+	GetSchemasInDatabase(ctx context.Context, databaseName string, handleEntity EntityHandler) error
+	GetInboundShares(ctx context.Context) ([]DbEntity, error)
+	GetTablesInDatabase(ctx context.Context, databaseName string, schemaName string, handleEntity EntityHandler) error
+	GetFunctionsInDatabase(ctx context.Context, databaseName string, handleEntity EntityHandler) error
+	GetFunctionsInSchema(ctx context.Context, databaseName string, schemaName string, handleEntity EntityHandler) error
+	GetProceduresInDatabase(ctx context.Context, databaseName string, handleEntity EntityHandler) error
+	GetProceduresInSchema(ctx context.Context, databaseName string, schemaName string, handleEntity EntityHandler) error
+	GetTagsByDomain(ctx context.Context, domain string) (map[string][]*tag.Tag, error)
+	GetDatabaseRoleTags(ctx context.Context, databaseName string, roleName string) (map[string][]*tag.Tag, error)
+	GetWarehouses(ctx context.Context) ([]DbEntity, error)
+	GetIntegrations(ctx context.Context) ([]DbEntity, error)
 	GrantAccountRolesToAccountRole(ctx context.Context, role string, roles ...string) error
 	GrantAccountRolesToDatabaseRole(ctx context.Context, database string, databaseRole string, accountRoles ...string) error
 	GrantDatabaseRolesToDatabaseRole(ctx context.Context, database string, databaseRole string, databaseRoles ...string) error
@@ -87,8 +93,8 @@ type dataAccessRepository interface {
 	GrantAccountRolesToApplicationRole(ctx context.Context, application string, applicationRole string, accountRoles ...string) error
 	GrantApplicationRolesToApplicationRole(ctx context.Context, application string, applicationRole string, applicationRoles ...string) error
 	GrantUsersToAccountRole(ctx context.Context, role string, users ...string) error
-	RenameAccountRole(oldName, newName string) error
-	RenameDatabaseRole(database, oldName, newName string) error
+	RenameAccountRole(ctx context.Context, oldName, newName string) error
+	RenameDatabaseRole(ctx context.Context, database, oldName, newName string) error
 	RevokeAccountRolesFromAccountRole(ctx context.Context, role string, roles ...string) error
 	RevokeAccountRolesFromDatabaseRole(ctx context.Context, database string, databaseRole string, accountRoles ...string) error
 	RevokeDatabaseRolesFromDatabaseRole(ctx context.Context, database string, databaseRole string, databaseRoles ...string) error
@@ -97,20 +103,21 @@ type dataAccessRepository interface {
 	RevokeApplicationRolesFromApplicationRole(ctx context.Context, application string, applicationRole string, applicationRoles ...string) error
 	RevokeUsersFromAccountRole(ctx context.Context, role string, users ...string) error
 	TotalQueryTime() time.Duration
-	UpdateFilter(databaseName string, schema string, tableName string, filterName string, argumentNames []string, expression string) error
-	CreateShare(shareName string) (err error)
-	SetShareAccounts(shareName string, accounts []string) (err error)
-	DropShare(shareName string) (err error)
-	ExecuteGrantOnShare(perm, on, shareName string) error
-	ExecuteRevokeOnShare(perm, on, shareName string) error
-	SetTagOnRole(roleName, tagName, tagValue string, isDatabaseRole bool) error
+	TotalRetries() int
+	UpdateFilter(ctx context.Context, databaseName string, schema string, tableName string, filterName string, argumentNames []string, expression string) error
+	CreateShare(ctx context.Context, shareName string) (err error)
+	SetShareAccounts(ctx context.Context, shareName string, accounts []string) (err error)
+	DropShare(ctx context.Context, shareName string) (err error)
+	ExecuteGrantOnShare(ctx context.Context, perm, on, shareName string) error
+	ExecuteRevokeOnShare(ctx context.Context, perm, on, shareName string) error
+	SetTagOnRole(ctx context.Context, roleName, tagName, tagValue string, isDatabaseRole bool) error
 }
 
 var _ wrappers.AccessProviderSyncer = (*AccessSyncer)(nil)
 
 type AccessSyncer struct {
 	namingConstraints naming_hint.NamingConstraints
-	repoProvider      func(params map[string]string, role string) (dataAccessRepository, error)
+	repoProvider      func(params map[string]string, role string, phase SyncPhase) (dataAccessRepository, error)
 	repo              dataAccessRepository
 }
 
@@ -121,12 +128,32 @@ func NewDataAccessSyncer(namingConstraints naming_hint.NamingConstraints) *Acces
 	}
 }
 
-func newDataAccessSnowflakeRepo(params map[string]string, role string) (dataAccessRepository, error) {
-	return NewSnowflakeRepository(params, role)
+func newDataAccessSnowflakeRepo(params map[string]string, role string, phase SyncPhase) (dataAccessRepository, error) {
+	ops := []func(options *SnowflakeRepositoryOptions){WithSyncPhase(phase), WithWarehouse(warehouseForSyncPhase(params, phase))}
+
+	if phase == SyncPhaseAccessToTarget && planChangesFirst(params) {
+		ops = append(ops, WithExecutionPlan(NewExecutionPlan()))
+	}
+
+	return NewSnowflakeRepository(params, roleForSyncPhase(params, role, phase), ops...)
 }
 
-func (s *AccessSyncer) SyncAccessProvidersFromTarget(ctx context.Context, accessProviderHandler wrappers.AccessProviderHandler, configMap *config.ConfigMap) error {
-	repo, err := s.repoProvider(configMap.Parameters, "")
+// planChangesFirst returns true if the changes of the access export are planned before anything is executed.
+// This is the case for a dry run, when applying a change plan (to detect drift) or when blast-radius limits are set (to check them).
+// A snapshot is always restored directly, as the restore does not go through a change plan and the limits don't apply to it.
+func planChangesFirst(params map[string]string) bool {
+	if params[SfRestoreSnapshotFile] != "" {
+		return false
+	}
+
+	return isDryRun(params) || params[SfApplyPlanFile] != "" || hasBlastRadiusLimits(params)
+}
+
+func (s *AccessSyncer) SyncAccessProvidersFromTarget(ctx context.Context, accessProviderHandler wrappers.AccessProviderHandler, configMap *config.ConfigMap) (err error) {
+	ctx, endSpan := startSyncPhaseSpan(ctx, configMap.Parameters, SyncPhaseAccessFromTarget)
+	defer func() { endSpan(err) }()
+
+	repo, err := s.repoProvider(configMap.Parameters, "", SyncPhaseAccessFromTarget)
 	if err != nil {
 		return err
 	}
@@ -134,17 +161,57 @@ func (s *AccessSyncer) SyncAccessProvidersFromTarget(ctx context.Context, access
 	s.repo = repo
 
 	defer func() {
-		Logger.Info(fmt.Sprintf("Total snowflake query time:  %s", s.repo.TotalQueryTime()))
+		Logger.Info(fmt.Sprintf("Total snowflake query time:  %s (%d retries)", s.repo.TotalQueryTime(), s.repo.TotalRetries()))
 		s.repo.Close()
+
+		writeStatementMetrics(configMap.Parameters)
 	}()
 
 	fromTargetSyncer := NewAccessFromTargetSyncer(s, s.repo, accessProviderHandler, configMap)
 
-	return fromTargetSyncer.syncFromTarget()
+	return fromTargetSyncer.syncFromTarget(ctx)
 }
 
-func (s *AccessSyncer) SyncAccessProviderToTarget(ctx context.Context, accessProviders *sync_to_target.AccessProviderImport, accessProviderFeedbackHandler wrappers.AccessProviderFeedbackHandler, configMap *config.ConfigMap) error {
-	repo, err := s.repoProvider(configMap.Parameters, "")
+func (s *AccessSyncer) SyncAccessProviderToTarget(ctx context.Context, accessProviders *sync_to_target.AccessProviderImport, accessProviderFeedbackHandler wrappers.AccessProviderFeedbackHandler, configMap *config.ConfigMap) (err error) {
+	ctx, endSpan := startSyncPhaseSpan(ctx, configMap.Parameters, SyncPhaseAccessToTarget)
+	defer func() { endSpan(err) }()
+
+	dryRun := isDryRun(configMap.Parameters)
+	dryRunFile := configMap.GetString(SfDryRunFile)
+	dryRunJsonFile := configMap.GetString(SfDryRunJsonFile)
+	applyPlanFile := configMap.GetString(SfApplyPlanFile)
+	restoreSnapshotFile := configMap.GetString(SfRestoreSnapshotFile)
+
+	limits, err := blastRadiusLimitsFromParams(configMap.Parameters)
+	if err != nil {
+		return err
+	}
+
+	var reviewedPlan *ChangePlan
+
+	var snapshot *Snapshot
+
+	switch {
+	case dryRun && applyPlanFile != "":
+		return fmt.Errorf("parameters %q and %q can not be combined", SfDryRun, SfApplyPlanFile)
+	case restoreSnapshotFile != "" && (dryRun || applyPlanFile != ""):
+		return fmt.Errorf("parameter %q can not be combined with %q or %q", SfRestoreSnapshotFile, SfDryRun, SfApplyPlanFile)
+	case dryRun && dryRunFile == "" && dryRunJsonFile == "":
+		return fmt.Errorf("parameter %q or %q is required when %q is set", SfDryRunFile, SfDryRunJsonFile, SfDryRun)
+	case applyPlanFile != "":
+		// Read the plan before connecting, so an invalid plan fails fast
+		reviewedPlan, err = readChangePlan(applyPlanFile)
+		if err != nil {
+			return err
+		}
+	case restoreSnapshotFile != "":
+		snapshot, err = readSnapshot(restoreSnapshotFile)
+		if err != nil {
+			return err
+		}
+	}
+
+	repo, err := s.repoProvider(configMap.Parameters, "", SyncPhaseAccessToTarget)
 	if err != nil {
 		return err
 	}
@@ -152,21 +219,155 @@ func (s *AccessSyncer) SyncAccessProviderToTarget(ctx context.Context, accessPro
 	s.repo = repo
 
 	defer func() {
-		Logger.Info(fmt.Sprintf("Total snowflake query time:  %s", s.repo.TotalQueryTime()))
+		Logger.Info(fmt.Sprintf("Total snowflake query time:  %s (%d retries)", s.repo.TotalQueryTime(), s.repo.TotalRetries()))
 		s.repo.Close()
+
+		writeStatementMetrics(configMap.Parameters)
 	}()
 
+	switch {
+	case snapshot != nil:
+		if limits != nil {
+			Logger.Info("The blast-radius limits are not checked when restoring a snapshot")
+		}
+
+		return s.restoreSnapshotToTarget(ctx, accessProviders, accessProviderFeedbackHandler, configMap, snapshot, restoreSnapshotFile)
+	case dryRun:
+		return s.dryRunToTarget(ctx, accessProviders, accessProviderFeedbackHandler, configMap, dryRunFile, dryRunJsonFile)
+	case reviewedPlan != nil:
+		return s.applyPlanToTarget(ctx, accessProviders, accessProviderFeedbackHandler, configMap, reviewedPlan, applyPlanFile, limits)
+	case limits != nil:
+		return s.syncToTargetWithinLimits(ctx, accessProviders, accessProviderFeedbackHandler, configMap, limits)
+	default:
+		toTargetSyncer := NewAccessToTargetSyncer(s, s.namingConstraints, s.repo, accessProviders, accessProviderFeedbackHandler, configMap)
+
+		return toTargetSyncer.syncToTarget(ctx)
+	}
+}
+
+// dryRunToTarget calculates all changes without executing them and writes them to the SQL and/or JSON plan file
+func (s *AccessSyncer) dryRunToTarget(ctx context.Context, accessProviders *sync_to_target.AccessProviderImport, accessProviderFeedbackHandler wrappers.AccessProviderFeedbackHandler, configMap *config.ConfigMap, sqlFile, jsonFile string) error {
+	plan := s.repo.ExecutionPlan()
+	if plan == nil {
+		return errors.New("dry run is not supported by the repository")
+	}
+
+	planFile := sqlFile
+	if planFile == "" {
+		planFile = jsonFile
+	}
+
+	Logger.Info(fmt.Sprintf("Dry run: no changes will be made in Snowflake, the planned statements are written to %q", planFile))
+
+	feedbackHandler := &dryRunFeedbackHandler{AccessProviderFeedbackHandler: accessProviderFeedbackHandler, plan: plan, planFile: planFile}
+	toTargetSyncer := NewAccessToTargetSyncer(s, s.namingConstraints, s.repo, accessProviders, feedbackHandler, configMap)
+
+	err := toTargetSyncer.syncToTarget(ctx)
+	if err != nil {
+		return err
+	}
+
+	if sqlFile != "" {
+		err = plan.WriteSQL(sqlFile)
+		if err != nil {
+			return fmt.Errorf("write dry run plan to %q: %w", sqlFile, err)
+		}
+	}
+
+	if jsonFile != "" {
+		err = plan.WriteJSON(jsonFile)
+		if err != nil {
+			return fmt.Errorf("write change plan to %q: %w", jsonFile, err)
+		}
+	}
+
+	return nil
+}
+
+// applyPlanToTarget executes the statements of a reviewed change plan.
+// The changes are first calculated again from the current state of Snowflake. If they differ from the reviewed plan, nothing is executed.
+func (s *AccessSyncer) applyPlanToTarget(ctx context.Context, accessProviders *sync_to_target.AccessProviderImport, accessProviderFeedbackHandler wrappers.AccessProviderFeedbackHandler, configMap *config.ConfigMap, reviewedPlan *ChangePlan, planFile string, limits blastRadiusLimits) error {
+	Logger.Info(fmt.Sprintf("Applying change plan %q of run %s", planFile, reviewedPlan.RunId))
+
+	toTargetSyncer, feedback, err := s.planToTarget(ctx, accessProviders, configMap, limits)
+	if err != nil {
+		return err
+	}
+
+	drift := reviewedPlan.Drift(s.repo.ExecutionPlan().ChangePlan())
+	if len(drift) > 0 {
+		return fmt.Errorf("the state of Snowflake has drifted since change plan %q was created, nothing was applied: %s", planFile, strings.Join(drift, "; "))
+	}
+
+	toTargetSyncer.accessProviderFeedbackHandler = accessProviderFeedbackHandler
+
+	return toTargetSyncer.executeChangePlan(ctx, reviewedPlan, feedback, limits)
+}
+
+// restoreSnapshotToTarget puts the roles, masks, filters and shares of a snapshot back in the state they had before the sync that created it.
+// The access providers are not exported in this run.
+func (s *AccessSyncer) restoreSnapshotToTarget(ctx context.Context, accessProviders *sync_to_target.AccessProviderImport, accessProviderFeedbackHandler wrappers.AccessProviderFeedbackHandler, configMap *config.ConfigMap, snapshot *Snapshot, snapshotFile string) error {
+	Logger.Info(fmt.Sprintf("Restoring snapshot %q of run %s (%d roles, %d policies and %d shares)", snapshotFile, snapshot.RunId, len(snapshot.Roles), len(snapshot.Policies), len(snapshot.Shares)))
+
 	toTargetSyncer := NewAccessToTargetSyncer(s, s.namingConstraints, s.repo, accessProviders, accessProviderFeedbackHandler, configMap)
+	toTargetSyncer.databaseRoleSupportEnabled = configMap.GetBoolWithDefault(SfDatabaseRoles, false)
+
+	err := toTargetSyncer.restoreSnapshot(ctx, snapshot)
+	if err != nil {
+		return fmt.Errorf("restore snapshot %q: %w", snapshotFile, err)
+	}
+
+	for _, ap := range accessProviders.AccessProviders {
+		err = accessProviderFeedbackHandler.AddAccessProviderFeedback(sync_to_target.AccessProviderSyncFeedback{
+			AccessProvider: ap.Id,
+			ExternalId:     ap.ExternalId,
+			Errors:         []string{fmt.Sprintf("not exported because snapshot %q was restored in this run", snapshotFile)},
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// syncToTargetWithinLimits calculates all changes first and only executes them if they don't exceed the blast-radius limits
+func (s *AccessSyncer) syncToTargetWithinLimits(ctx context.Context, accessProviders *sync_to_target.AccessProviderImport, accessProviderFeedbackHandler wrappers.AccessProviderFeedbackHandler, configMap *config.ConfigMap, limits blastRadiusLimits) error {
+	toTargetSyncer, feedback, err := s.planToTarget(ctx, accessProviders, configMap, limits)
+	if err != nil {
+		return err
+	}
+
+	toTargetSyncer.accessProviderFeedbackHandler = accessProviderFeedbackHandler
+
+	return toTargetSyncer.executeChangePlan(ctx, s.repo.ExecutionPlan().ChangePlan(), feedback, limits)
+}
+
+// planToTarget calculates all changes without executing them. The feedback is returned, to be sent once the changes are executed.
+func (s *AccessSyncer) planToTarget(ctx context.Context, accessProviders *sync_to_target.AccessProviderImport, configMap *config.ConfigMap, limits blastRadiusLimits) (*AccessToTargetSyncer, []sync_to_target.AccessProviderSyncFeedback, error) {
+	if s.repo.ExecutionPlan() == nil {
+		return nil, nil, errors.New("planning the changes is not supported by the repository")
+	}
+
+	feedbackHandler := &bufferedFeedbackHandler{}
+
+	toTargetSyncer := NewAccessToTargetSyncer(s, s.namingConstraints, s.repo, accessProviders, feedbackHandler, configMap)
+	toTargetSyncer.blastRadius = newBlastRadius(limits)
+
+	err := toTargetSyncer.syncToTarget(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
 
-	return toTargetSyncer.syncToTarget(ctx)
+	return toTargetSyncer, feedbackHandler.feedback, nil
 }
 
 //
 // Functions used in both the from target and the to target syncers
 //
 
-func (s *AccessSyncer) getInboundShareNames() ([]string, error) {
-	dbShares, err := s.repo.GetInboundShares()
+func (s *AccessSyncer) getInboundShareNames(ctx context.Context) ([]string, error) {
+	dbShares, err := s.repo.GetInboundShares(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -179,8 +380,8 @@ func (s *AccessSyncer) getInboundShareNames() ([]string, error) {
 	return shareNames, nil
 }
 
-func (s *AccessSyncer) getDatabaseNames() ([]string, error) {
-	databases, err := s.repo.GetDatabases()
+func (s *AccessSyncer) getDatabaseNames(ctx context.Context) ([]string, error) {
+	databases, err := s.repo.GetDatabases(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -193,13 +394,13 @@ func (s *AccessSyncer) getDatabaseNames() ([]string, error) {
 	return databaseNames, nil
 }
 
-func (s *AccessSyncer) getAllDatabaseAndShareNames() (set.Set[string], error) {
-	databases, err := s.getDatabaseNames()
+func (s *AccessSyncer) getAllDatabaseAndShareNames(ctx context.Context) (set.Set[string], error) {
+	databases, err := s.getDatabaseNames(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	inboundShares, err := s.getInboundShareNames()
+	inboundShares, err := s.getInboundShareNames(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -211,20 +412,20 @@ func (s *AccessSyncer) getAllDatabaseAndShareNames() (set.Set[string], error) {
 	return combinedList, nil
 }
 
-func (s *AccessSyncer) getGrantsToRole(externalId string, apType *string) ([]GrantToRole, error) {
+func (s *AccessSyncer) getGrantsToRole(ctx context.Context, externalId string, apType *string) ([]GrantToRole, error) {
 	if isDatabaseRole(apType) {
 		database, parsedRoleName, err := parseDatabaseRoleExternalId(externalId)
 		if err != nil {
 			return nil, err
 		}
 
-		return s.repo.GetGrantsToDatabaseRole(database, parsedRoleName)
+		return s.repo.GetGrantsToDatabaseRole(ctx, database, parsedRoleName)
 	}
 
-	return s.repo.GetGrantsToAccountRole(externalId)
+	return s.repo.GetGrantsToAccountRole(ctx, externalId)
 }
 
-func (s *AccessSyncer) retrieveGrantsOfRole(externalId string, apType string) (grantOfEntities []GrantOfRole, err error) {
+func (s *AccessSyncer) retrieveGrantsOfRole(ctx context.Context, externalId string, apType string) (grantOfEntities []GrantOfRole, err error) {
 	switch apType {
 	case apTypeDatabaseRole:
 		database, parsedRoleName, err2 := parseDatabaseRoleExternalId(externalId)
@@ -232,25 +433,25 @@ func (s *AccessSyncer) retrieveGrantsOfRole(externalId string, apType string) (g
 			return nil, err2
 		}
 
-		grantOfEntities, err = s.repo.GetGrantsOfDatabaseRole(database, parsedRoleName)
+		grantOfEntities, err = s.repo.GetGrantsOfDatabaseRole(ctx, database, parsedRoleName)
 	case apTypeApplicationRole:
 		application, parsedRoleName, err2 := parseApplicationRoleExternalId(externalId)
 		if err2 != nil {
 			return nil, err2
 		}
 
-		grantOfEntities, err = s.repo.GetGrantsOfApplicationRole(application, parsedRoleName)
+		grantOfEntities, err = s.repo.GetGrantsOfApplicationRole(ctx, application, parsedRoleName)
 	default:
-		grantOfEntities, err = s.repo.GetGrantsOfAccountRole(externalId)
+		grantOfEntities, err = s.repo.GetGrantsOfAccountRole(ctx, externalId)
 	}
 
 	return grantOfEntities, err
 }
 
 // getFullNameFromGrant creates the full name for Raito WHAT item based on the name and type from the grant definition in Snowflake
-func (s *AccessSyncer) getFullNameFromGrant(name, objectType string) string {
+func (s *AccessSyncer) getFullNameFromGrant(ctx context.Context, name, objectType string) string {
 	if strings.EqualFold(objectType, "ACCOUNT") {
-		accountName, err := s.repo.GetSnowFlakeAccountName()
+		accountName, err := s.repo.GetSnowFlakeAccountName(ctx)
 		if err != nil {
 			Logger.Error(fmt.Sprintf("Failed to get account name from Snowflake: %s", err.Error()))
 
@@ -1,6 +1,8 @@
 package snowflake
 
 import (
+	"context"
+
 	"fmt"
 	"slices"
 	"strings"
@@ -30,6 +32,8 @@ type AccessFromTargetSyncer struct {
 	linkToExternalIdentityStoreGroups bool
 	externalGroupOwners               string
 	excludedRoles                     map[string]struct{}
+	unmanagedRoles                    *unmanagedRoles
+	accountRoleGrants                 *accountRoleGrants
 	lock                              sync.Mutex
 }
 
@@ -42,14 +46,21 @@ func NewAccessFromTargetSyncer(accessSyncer *AccessSyncer, repo dataAccessReposi
 	}
 }
 
-func (s *AccessFromTargetSyncer) syncFromTarget() error {
+func (s *AccessFromTargetSyncer) syncFromTarget(ctx context.Context) error {
 	s.externalGroupOwners = s.configMap.GetStringWithDefault(SfExternalIdentityStoreOwners, "")
 	s.excludedRoles = s.extractExcludeRoleList()
 	s.linkToExternalIdentityStoreGroups = s.configMap.GetBoolWithDefault(SfLinkToExternalIdentityStoreGroups, false)
 
+	unmanagedRoles, err := newUnmanagedRoles(s.configMap)
+	if err != nil {
+		return err
+	}
+
+	s.unmanagedRoles = unmanagedRoles
+
 	Logger.Info("Reading account and database roles from Snowflake")
 
-	inboundShares, err := s.accessSyncer.getInboundShareNames()
+	inboundShares, err := s.accessSyncer.getInboundShareNames(ctx)
 	if err != nil {
 		return err
 	}
@@ -60,12 +71,12 @@ func (s *AccessFromTargetSyncer) syncFromTarget() error {
 
 	Logger.Info("Reading account roles from Snowflake")
 
-	err = s.importAllRolesOnAccountLevel(s.accessProviderHandler)
+	err = s.importAllRolesOnAccountLevel(ctx, s.accessProviderHandler)
 	if err != nil {
 		return fmt.Errorf("importing account roles: %w", err)
 	}
 
-	err = s.importOutboundShares(s.accessProviderHandler)
+	err = s.importOutboundShares(ctx, s.accessProviderHandler)
 	if err != nil {
 		return fmt.Errorf("importing shares: %w", err)
 	}
@@ -76,7 +87,7 @@ func (s *AccessFromTargetSyncer) syncFromTarget() error {
 	if databaseRoleSupportEnabled {
 		Logger.Info("Reading database roles from Snowflake")
 
-		err = s.importAllRolesOnDatabaseLevel(s.accessProviderHandler, excludedDatabases)
+		err = s.importAllRolesOnDatabaseLevel(ctx, s.accessProviderHandler, excludedDatabases)
 		if err != nil {
 			return err
 		}
@@ -86,7 +97,7 @@ func (s *AccessFromTargetSyncer) syncFromTarget() error {
 	if applicationSupportEnabled {
 		Logger.Info("Reading application roles from Snowflake")
 
-		err = s.importAllRolesOnApplicationLevel(s.accessProviderHandler, excludedDatabases)
+		err = s.importAllRolesOnApplicationLevel(ctx, s.accessProviderHandler, excludedDatabases)
 		if err != nil {
 			return fmt.Errorf("application roles: %w", err)
 		}
@@ -99,7 +110,7 @@ func (s *AccessFromTargetSyncer) syncFromTarget() error {
 		if !skipColumns {
 			Logger.Info("Reading masking policies from Snowflake")
 
-			err = s.importMaskingPolicies()
+			err = s.importMaskingPolicies(ctx)
 			if err != nil {
 				return err
 			}
@@ -109,7 +120,7 @@ func (s *AccessFromTargetSyncer) syncFromTarget() error {
 
 		Logger.Info("Reading row access policies from Snowflake")
 
-		err = s.importRowAccessPolicies()
+		err = s.importRowAccessPolicies(ctx)
 		if err != nil {
 			return err
 		}
@@ -120,9 +131,9 @@ func (s *AccessFromTargetSyncer) syncFromTarget() error {
 	return nil
 }
 
-func (s *AccessFromTargetSyncer) importOutboundShares(accessProviderHandler wrappers.AccessProviderHandler) error {
+func (s *AccessFromTargetSyncer) importOutboundShares(ctx context.Context, accessProviderHandler wrappers.AccessProviderHandler) error {
 	// Get all output shares and import them
-	shareEntities, err := s.repo.GetOutboundShares()
+	shareEntities, err := s.repo.GetOutboundShares(ctx)
 	if err != nil {
 		return err
 	}
@@ -144,7 +155,7 @@ func (s *AccessFromTargetSyncer) importOutboundShares(accessProviderHandler wrap
 		}
 
 		wp.Submit(func() {
-			err2 := s.transformShareToAccessProvider(shareName, shareEntityItems, processedAps)
+			err2 := s.transformShareToAccessProvider(ctx, shareName, shareEntityItems, processedAps)
 			if err2 != nil {
 				Logger.Warn(fmt.Sprintf("Error importing SnowFlake share %q: %s", shareName, err2.Error()))
 				return
@@ -162,22 +173,34 @@ func (s *AccessFromTargetSyncer) importOutboundShares(accessProviderHandler wrap
 	return nil
 }
 
-func (s *AccessFromTargetSyncer) importAllRolesOnAccountLevel(accessProviderHandler wrappers.AccessProviderHandler) error {
+func (s *AccessFromTargetSyncer) importAllRolesOnAccountLevel(ctx context.Context, accessProviderHandler wrappers.AccessProviderHandler) error {
 	availableTags := make(map[string][]*tag.Tag)
 
 	if s.shouldRetrieveTags() {
 		var err error
 
-		availableTags, err = s.repo.GetTagsByDomain("ROLE")
+		availableTags, err = s.repo.GetTagsByDomain(ctx, "ROLE")
 		if err != nil {
 			Logger.Error(fmt.Sprintf("Error retrieving tags for account roles: %s", err.Error()))
 		}
+
+		s.unmanagedRoles.addRoleTags(availableTags)
+	} else {
+		err := s.unmanagedRoles.loadRoleTags(ctx, s.repo)
+		if err != nil {
+			return err
+		}
 	}
 
 	processedAps := make(map[string]*exporter.AccessProvider)
 
 	// Get all account roles and import them
-	roleEntities, err := s.repo.GetAccountRoles()
+	roleEntities, err := s.repo.GetAccountRoles(ctx)
+	if err != nil {
+		return err
+	}
+
+	s.accountRoleGrants, err = loadAccountRoleGrants(ctx, s.repo, s.configMap.Parameters)
 	if err != nil {
 		return err
 	}
@@ -191,7 +214,7 @@ func (s *AccessFromTargetSyncer) importAllRolesOnAccountLevel(accessProviderHand
 		}
 
 		wp.Submit(func() {
-			err2 := s.transformAccountRoleToAccessProvider(roleEntity, processedAps, availableTags)
+			err2 := s.transformAccountRoleToAccessProvider(ctx, roleEntity, processedAps, availableTags)
 			if err2 != nil {
 				Logger.Warn(fmt.Sprintf("Error importing SnowFlake role %q: %s", roleEntity.Name, err2.Error()))
 			}
@@ -217,7 +240,7 @@ func (s *AccessFromTargetSyncer) shouldRetrieveTags() bool {
 	return tagSupportEnabled
 }
 
-func (s *AccessFromTargetSyncer) transformShareToAccessProvider(shareName string, shareEntity []ShareEntity, processedAps map[string]*exporter.AccessProvider) error {
+func (s *AccessFromTargetSyncer) transformShareToAccessProvider(ctx context.Context, shareName string, shareEntity []ShareEntity, processedAps map[string]*exporter.AccessProvider) error {
 	Logger.Info(fmt.Sprintf("Reading SnowFlake SHARE %s (%d items)", shareName, len(shareEntity)))
 
 	externalId := apTypeSharePrefix + shareName
@@ -266,17 +289,17 @@ func (s *AccessFromTargetSyncer) transformShareToAccessProvider(shareName string
 	s.lock.Unlock()
 
 	// get objects granted TO share
-	grantToEntities, err := s.repo.GetGrantsToShare(shareName)
+	grantToEntities, err := s.repo.GetGrantsToShare(ctx, shareName)
 	if err != nil {
 		return fmt.Errorf("retrieving grants for share: %s", err.Error())
 	}
 
-	ap.What = append(ap.What, s.mapGrantToRoleToWhatItems(grantToEntities)...)
+	ap.What = append(ap.What, s.mapGrantToRoleToWhatItems(ctx, grantToEntities)...)
 
 	return nil
 }
 
-func (s *AccessFromTargetSyncer) transformAccountRoleToAccessProvider(roleEntity RoleEntity, processedAps map[string]*exporter.AccessProvider, availableTags map[string][]*tag.Tag) error {
+func (s *AccessFromTargetSyncer) transformAccountRoleToAccessProvider(ctx context.Context, roleEntity RoleEntity, processedAps map[string]*exporter.AccessProvider, availableTags map[string][]*tag.Tag) error {
 	Logger.Info(fmt.Sprintf("Reading SnowFlake ROLE %s", roleEntity.Name))
 
 	roleName := roleEntity.Name
@@ -284,7 +307,7 @@ func (s *AccessFromTargetSyncer) transformAccountRoleToAccessProvider(roleEntity
 	currentApType := access_provider.Role
 	fromExternalIS := s.comesFromExternalIdentityStore(roleEntity, s.externalGroupOwners)
 
-	users, groups, accessProviders, incomplete, err := s.retrieveWhoEntitiesForRole(roleEntity, externalId, currentApType, fromExternalIS)
+	users, groups, accessProviders, incomplete, err := s.retrieveWhoEntitiesForRole(ctx, roleEntity, externalId, currentApType, fromExternalIS)
 	if err != nil {
 		return err
 	}
@@ -336,18 +359,23 @@ func (s *AccessFromTargetSyncer) transformAccountRoleToAccessProvider(roleEntity
 	s.lock.Unlock()
 
 	// get objects granted TO role
-	grantToEntities, err := s.accessSyncer.getGrantsToRole(ap.ExternalId, ap.Type)
+	grantToEntities, err := s.getGrantsToRole(ctx, ap.ExternalId, ap.Type)
 	if err != nil {
 		return fmt.Errorf("error retrieving grants for role: %s", err.Error())
 	}
 
-	ap.What = append(ap.What, s.mapGrantToRoleToWhatItems(grantToEntities)...)
+	ap.What = append(ap.What, s.mapGrantToRoleToWhatItems(ctx, grantToEntities)...)
 
 	if isNotInternalizableRole(ap.ExternalId, ap.Type) {
 		Logger.Info(fmt.Sprintf("Marking role %s as read-only (notInternalizable)", ap.ExternalId))
 		ap.NotInternalizable = true
 	}
 
+	if reason := s.unmanagedRoles.unmanagedReason(ap.ExternalId); reason != "" {
+		Logger.Info(fmt.Sprintf("Marking role %s as read-only because %s", ap.ExternalId, reason))
+		lockUnmanagedRole(ap, reason)
+	}
+
 	if len(availableTags) > 0 && availableTags[ap.Name] != nil {
 		ap.Tags = availableTags[ap.Name]
 		Logger.Debug(fmt.Sprintf("Going to add tags to AP %s", ap.ExternalId))
@@ -371,9 +399,9 @@ func (s *AccessFromTargetSyncer) extractExcludeRoleList() map[string]struct{} {
 	return excludedRoles
 }
 
-func (s *AccessFromTargetSyncer) importAllRolesOnDatabaseLevel(accessProviderHandler wrappers.AccessProviderHandler, excludedDatabases set.Set[string]) error {
+func (s *AccessFromTargetSyncer) importAllRolesOnDatabaseLevel(ctx context.Context, accessProviderHandler wrappers.AccessProviderHandler, excludedDatabases set.Set[string]) error {
 	// Get all database roles for each database and import them
-	databases, err := s.getApplicableDatabases(excludedDatabases)
+	databases, err := s.getApplicableDatabases(ctx, excludedDatabases)
 	if err != nil {
 		return err
 	}
@@ -386,7 +414,7 @@ func (s *AccessFromTargetSyncer) importAllRolesOnDatabaseLevel(accessProviderHan
 		Logger.Info(fmt.Sprintf("Reading roles from Snowflake inside database %s", database))
 
 		// Get all database roles for database
-		roleEntities, err2 := s.repo.GetDatabaseRoles(database)
+		roleEntities, err2 := s.repo.GetDatabaseRoles(ctx, database)
 		if err2 != nil {
 			return err2
 		}
@@ -404,13 +432,13 @@ func (s *AccessFromTargetSyncer) importAllRolesOnDatabaseLevel(accessProviderHan
 				if s.shouldRetrieveTags() {
 					var err3 error
 
-					availableTags, err3 = s.repo.GetDatabaseRoleTags(database, roleEntity.Name)
+					availableTags, err3 = s.repo.GetDatabaseRoleTags(ctx, database, roleEntity.Name)
 					if err3 != nil {
 						Logger.Error(fmt.Sprintf("Error retrieving tags for database role: %q - %s", fullRoleName, err3.Error()))
 					}
 				}
 
-				err2 := s.importAccessForDatabaseRole(database, roleEntity, availableTags, processedAps)
+				err2 := s.importAccessForDatabaseRole(ctx, database, roleEntity, availableTags, processedAps)
 				if err2 != nil {
 					Logger.Warn(fmt.Sprintf("Error importing SnowFlake Database role %q: %s", fullRoleName, err2.Error()))
 				}
@@ -428,8 +456,8 @@ func (s *AccessFromTargetSyncer) importAllRolesOnDatabaseLevel(accessProviderHan
 	return nil
 }
 
-func (s *AccessFromTargetSyncer) importAllRolesOnApplicationLevel(accessProviderHandler wrappers.AccessProviderHandler, excludeDatabases set.Set[string]) error {
-	applications, err := s.getApplicableApplications(excludeDatabases)
+func (s *AccessFromTargetSyncer) importAllRolesOnApplicationLevel(ctx context.Context, accessProviderHandler wrappers.AccessProviderHandler, excludeDatabases set.Set[string]) error {
+	applications, err := s.getApplicableApplications(ctx, excludeDatabases)
 	if err != nil {
 		return fmt.Errorf("retrieving applications: %w", err)
 	}
@@ -442,7 +470,7 @@ func (s *AccessFromTargetSyncer) importAllRolesOnApplicationLevel(accessProvider
 		Logger.Info(fmt.Sprintf("Reading roles from Snowflake inside application %s", application))
 
 		wp.Submit(func() {
-			roleEntitites, err2 := s.repo.GetApplicationRoles(application)
+			roleEntitites, err2 := s.repo.GetApplicationRoles(ctx, application)
 			if err2 != nil {
 				Logger.Error(fmt.Sprintf("Error retrieving roles for application %q: %s", application, err2.Error()))
 
@@ -457,7 +485,7 @@ func (s *AccessFromTargetSyncer) importAllRolesOnApplicationLevel(accessProvider
 					continue
 				}
 
-				err2 = s.importAccessForApplicationRole(application, RoleEntity{
+				err2 = s.importAccessForApplicationRole(ctx, application, RoleEntity{
 					Name: roleEntity.Name,
 				}, processedAps)
 				if err2 != nil {
@@ -492,7 +520,7 @@ func (s *AccessFromTargetSyncer) comesFromExternalIdentityStore(roleEntity RoleE
 	return fromExternalIS
 }
 
-func (s *AccessFromTargetSyncer) importAccessForDatabaseRole(database string, roleEntity RoleEntity, availableTags map[string][]*tag.Tag, processedAps map[string]*exporter.AccessProvider) error {
+func (s *AccessFromTargetSyncer) importAccessForDatabaseRole(ctx context.Context, database string, roleEntity RoleEntity, availableTags map[string][]*tag.Tag, processedAps map[string]*exporter.AccessProvider) error {
 	Logger.Info(fmt.Sprintf("Reading SnowFlake DATABASE ROLE %s inside %s", roleEntity.Name, database))
 
 	roleName := roleEntity.Name
@@ -500,7 +528,7 @@ func (s *AccessFromTargetSyncer) importAccessForDatabaseRole(database string, ro
 	currentApType := apTypeDatabaseRole
 	fromExternalIS := s.comesFromExternalIdentityStore(roleEntity, s.externalGroupOwners)
 
-	users, groups, accessProviders, incomplete, err := s.retrieveWhoEntitiesForRole(roleEntity, externalId, currentApType, fromExternalIS)
+	users, groups, accessProviders, incomplete, err := s.retrieveWhoEntitiesForRole(ctx, roleEntity, externalId, currentApType, fromExternalIS)
 	if err != nil {
 		return err
 	}
@@ -544,18 +572,23 @@ func (s *AccessFromTargetSyncer) importAccessForDatabaseRole(database string, ro
 	s.lock.Unlock()
 
 	// get objects granted TO role
-	grantToEntities, err := s.accessSyncer.getGrantsToRole(ap.ExternalId, ap.Type)
+	grantToEntities, err := s.getGrantsToRole(ctx, ap.ExternalId, ap.Type)
 	if err != nil {
 		return fmt.Errorf("error retrieving grants for role: %s", err.Error())
 	}
 
-	ap.What = append(ap.What, s.mapGrantToRoleToWhatItems(grantToEntities)...)
+	ap.What = append(ap.What, s.mapGrantToRoleToWhatItems(ctx, grantToEntities)...)
 
 	if isNotInternalizableRole(ap.ExternalId, ap.Type) {
 		Logger.Info(fmt.Sprintf("Marking role %s as read-only (notInternalizable)", ap.ExternalId))
 		ap.NotInternalizable = true
 	}
 
+	if reason := s.unmanagedRoles.unmanagedReason(ap.ExternalId); reason != "" {
+		Logger.Info(fmt.Sprintf("Marking role %s as read-only because %s", ap.ExternalId, reason))
+		lockUnmanagedRole(ap, reason)
+	}
+
 	if len(availableTags) > 0 && availableTags[ap.Name] != nil {
 		ap.Tags = availableTags[ap.Name]
 		Logger.Debug(fmt.Sprintf("Going to add tags to AP %s", ap.ExternalId))
@@ -564,7 +597,7 @@ func (s *AccessFromTargetSyncer) importAccessForDatabaseRole(database string, ro
 	return nil
 }
 
-func (s *AccessFromTargetSyncer) importAccessForApplicationRole(application string, roleEntity RoleEntity, processedAps map[string]*exporter.AccessProvider) error {
+func (s *AccessFromTargetSyncer) importAccessForApplicationRole(ctx context.Context, application string, roleEntity RoleEntity, processedAps map[string]*exporter.AccessProvider) error {
 	Logger.Info(fmt.Sprintf("Reading SnowFlake APPLICATION ROLE %s inside %s", roleEntity.Name, application))
 
 	roleName := roleEntity.Name
@@ -572,7 +605,7 @@ func (s *AccessFromTargetSyncer) importAccessForApplicationRole(application stri
 	currentApType := apTypeApplicationRole
 	fromExternalIS := s.comesFromExternalIdentityStore(roleEntity, s.externalGroupOwners)
 
-	users, groups, accessProviders, incomplete, err := s.retrieveWhoEntitiesForRole(roleEntity, externalId, currentApType, fromExternalIS)
+	users, groups, accessProviders, incomplete, err := s.retrieveWhoEntitiesForRole(ctx, roleEntity, externalId, currentApType, fromExternalIS)
 	if err != nil {
 		return err
 	}
@@ -618,7 +651,7 @@ func (s *AccessFromTargetSyncer) importAccessForApplicationRole(application stri
 	return nil
 }
 
-func (s *AccessFromTargetSyncer) mapGrantToRoleToWhatItems(grantToEntities []GrantToRole) []exporter.WhatItem {
+func (s *AccessFromTargetSyncer) mapGrantToRoleToWhatItems(ctx context.Context, grantToEntities []GrantToRole) []exporter.WhatItem {
 	var do *ds.DataObjectReference
 
 	whatItems := make([]exporter.WhatItem, 0)
@@ -635,7 +668,7 @@ func (s *AccessFromTargetSyncer) mapGrantToRoleToWhatItems(grantToEntities []Gra
 		if first {
 			// We set type to empty string because that's not needed by the importer to match the data object
 			// + we cannot make the mapping to the correct Raito data object types here.
-			do = &ds.DataObjectReference{FullName: s.accessSyncer.getFullNameFromGrant(grant.Name, grant.GrantedOn), Type: ""}
+			do = &ds.DataObjectReference{FullName: s.accessSyncer.getFullNameFromGrant(ctx, grant.Name, grant.GrantedOn), Type: ""}
 			first = false
 		} else if do.FullName != grant.Name {
 			if len(permissions) > 0 {
@@ -647,7 +680,7 @@ func (s *AccessFromTargetSyncer) mapGrantToRoleToWhatItems(grantToEntities []Gra
 
 			// We set type to empty string because that's not needed by the importer to match the data object
 			// + we cannot make the mapping to the correct Raito data object types here.
-			do = &ds.DataObjectReference{FullName: s.accessSyncer.getFullNameFromGrant(grant.Name, grant.GrantedOn), Type: ""}
+			do = &ds.DataObjectReference{FullName: s.accessSyncer.getFullNameFromGrant(ctx, grant.Name, grant.GrantedOn), Type: ""}
 			permissions = make([]string, 0)
 		}
 
@@ -698,7 +731,7 @@ func mapPrivilege(privilege string, grantedOn string) string {
 	return privilege
 }
 
-func (s *AccessFromTargetSyncer) retrieveWhoEntitiesForRole(roleEntity RoleEntity, externalId string, apType string, fromExternalIS bool) (users []string, groups []string, accessProviders []string, incomplete bool, err error) {
+func (s *AccessFromTargetSyncer) retrieveWhoEntitiesForRole(ctx context.Context, roleEntity RoleEntity, externalId string, apType string, fromExternalIS bool) (users []string, groups []string, accessProviders []string, incomplete bool, err error) {
 	roleName := roleEntity.Name
 
 	users = make([]string, 0)
@@ -708,7 +741,7 @@ func (s *AccessFromTargetSyncer) retrieveWhoEntitiesForRole(roleEntity RoleEntit
 	if fromExternalIS && s.linkToExternalIdentityStoreGroups {
 		groups = append(groups, roleName)
 	} else {
-		grantOfEntities, err := s.accessSyncer.retrieveGrantsOfRole(externalId, apType)
+		grantOfEntities, err := s.retrieveGrantsOfRole(ctx, externalId, apType)
 		if err != nil {
 			return nil, nil, nil, false, err
 		}
@@ -750,8 +783,8 @@ func (s *AccessFromTargetSyncer) retrieveWhoEntitiesForRole(roleEntity RoleEntit
 	return users, groups, accessProviders, incomplete, nil
 }
 
-func (s *AccessFromTargetSyncer) importPoliciesOfType(policyType string, action types.Action) error {
-	policyEntities, err := s.repo.GetPolicies(policyType)
+func (s *AccessFromTargetSyncer) importPoliciesOfType(ctx context.Context, policyType string, action types.Action) error {
+	policyEntities, err := s.repo.GetPolicies(ctx, policyType)
 	if err != nil {
 		// For Standard edition, row access policies are not supported. Failsafe in case `sf-standard-edition` is overlooked.
 		// You can see the Snowflake edition in the UI, or through the 'show organization accounts;' query (ORGADMIN role needed).
@@ -787,7 +820,7 @@ func (s *AccessFromTargetSyncer) importPoliciesOfType(policyType string, action
 		}
 
 		// get policy definition
-		describeMaskingPolicyEntities, err2 := s.repo.DescribePolicy(policyType, policy.DatabaseName, policy.SchemaName, policy.Name)
+		describeMaskingPolicyEntities, err2 := s.repo.DescribePolicy(ctx, policyType, policy.DatabaseName, policy.SchemaName, policy.Name)
 		if err2 != nil {
 			Logger.Warn(fmt.Sprintf("Error fetching description for policy %s.%s.%s: %s", policy.DatabaseName, policy.SchemaName, policy.Name, err2.Error()))
 
@@ -803,7 +836,7 @@ func (s *AccessFromTargetSyncer) importPoliciesOfType(policyType string, action
 		ap.Policy = describeMaskingPolicyEntities[0].Body
 
 		// get policy references
-		policyReferenceEntities, err2 := s.repo.GetPolicyReferences(policy.DatabaseName, policy.SchemaName, policy.Name)
+		policyReferenceEntities, err2 := s.repo.GetPolicyReferences(ctx, policy.DatabaseName, policy.SchemaName, policy.Name)
 		if err2 != nil {
 			Logger.Warn(fmt.Sprintf("Error fetching policy references for %s.%s.%s: %s", policy.DatabaseName, policy.SchemaName, policy.Name, err2.Error()))
 
@@ -851,16 +884,16 @@ func (s *AccessFromTargetSyncer) importPoliciesOfType(policyType string, action
 	return nil
 }
 
-func (s *AccessFromTargetSyncer) importMaskingPolicies() error {
-	return s.importPoliciesOfType("MASKING", types.Mask)
+func (s *AccessFromTargetSyncer) importMaskingPolicies(ctx context.Context) error {
+	return s.importPoliciesOfType(ctx, "MASKING", types.Mask)
 }
 
-func (s *AccessFromTargetSyncer) importRowAccessPolicies() error {
-	return s.importPoliciesOfType("ROW ACCESS", types.Filtered)
+func (s *AccessFromTargetSyncer) importRowAccessPolicies(ctx context.Context) error {
+	return s.importPoliciesOfType(ctx, "ROW ACCESS", types.Filtered)
 }
 
-func (s *AccessFromTargetSyncer) getApplicableDatabases(dbExcludes set.Set[string]) (set.Set[string], error) {
-	allDatabases, err := s.accessSyncer.getAllDatabaseAndShareNames()
+func (s *AccessFromTargetSyncer) getApplicableDatabases(ctx context.Context, dbExcludes set.Set[string]) (set.Set[string], error) {
+	allDatabases, err := s.accessSyncer.getAllDatabaseAndShareNames(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -876,8 +909,8 @@ func (s *AccessFromTargetSyncer) getApplicableDatabases(dbExcludes set.Set[strin
 	return filteredDatabases, nil
 }
 
-func (s *AccessFromTargetSyncer) getApplicableApplications(dbExcludes set.Set[string]) (set.Set[string], error) {
-	allApplications, err := s.repo.GetApplications()
+func (s *AccessFromTargetSyncer) getApplicableApplications(ctx context.Context, dbExcludes set.Set[string]) (set.Set[string], error) {
+	allApplications, err := s.repo.GetApplications(ctx)
 	if err != nil {
 		return nil, err
 	}
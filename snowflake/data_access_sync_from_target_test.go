@@ -1,6 +1,8 @@
 package snowflake
 
 import (
+	"context"
+
 	"testing"
 
 	"github.com/aws/smithy-go/ptr"
@@ -13,6 +15,7 @@ import (
 	"github.com/raito-io/cli/base/wrappers"
 	"github.com/raito-io/cli/base/wrappers/mocks"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func Test_IsNotInternalizableRole(t *testing.T) {
@@ -126,7 +129,9 @@ func Test_ShouldRetrieveTags(t *testing.T) {
 			want: false,
 		},
 	}
-	for _, tt := range tests {
+	for i := range tests {
+		tt := &tests[i]
+
 		t.Run(tt.name, func(t *testing.T) {
 			// Given
 			repoMock := newMockDataAccessRepository(t)
@@ -144,9 +149,9 @@ func Test_ShouldRetrieveTags(t *testing.T) {
 
 func TestAccessSyncer_GetFullNameFromGrant(t *testing.T) {
 	accessSyncer := NewDataAccessSyncer(naming_hint.NamingConstraints{})
-	assert.Equal(t, "DB1.Schema1.Entity1", accessSyncer.getFullNameFromGrant("DB1.Schema1.Entity1", "table"))
-	assert.Equal(t, `MASTER_DATA.PUBLIC."DECRYPTIT"(VARCHAR, VARCHAR)`, accessSyncer.getFullNameFromGrant(`MASTER_DATA.PUBLIC."DECRYPTIT(VAL VARCHAR, ENCRYPTIONTYPE VARCHAR):VARCHAR(16777216)"`, Function))
-	assert.Equal(t, `MASTER_DATA.PUBLIC."DECRYPTIT"(VARCHAR, VARCHAR)`, accessSyncer.getFullNameFromGrant(`MASTER_DATA.PUBLIC."DECRYPTIT(VAL VARCHAR, ENCRYPTIONTYPE VARCHAR):VARCHAR(16777216)"`, Procedure))
+	assert.Equal(t, "DB1.Schema1.Entity1", accessSyncer.getFullNameFromGrant(context.Background(), "DB1.Schema1.Entity1", "table"))
+	assert.Equal(t, `MASTER_DATA.PUBLIC."DECRYPTIT"(VARCHAR, VARCHAR)`, accessSyncer.getFullNameFromGrant(context.Background(), `MASTER_DATA.PUBLIC."DECRYPTIT(VAL VARCHAR, ENCRYPTIONTYPE VARCHAR):VARCHAR(16777216)"`, Function))
+	assert.Equal(t, `MASTER_DATA.PUBLIC."DECRYPTIT"(VARCHAR, VARCHAR)`, accessSyncer.getFullNameFromGrant(context.Background(), `MASTER_DATA.PUBLIC."DECRYPTIT(VAL VARCHAR, ENCRYPTIONTYPE VARCHAR):VARCHAR(16777216)"`, Procedure))
 }
 
 func TestAccessSyncer_importPoliciesOfType(t *testing.T) {
@@ -156,7 +161,7 @@ func TestAccessSyncer_importPoliciesOfType(t *testing.T) {
 
 	policyType := "policyType"
 
-	repoMock.EXPECT().GetPolicies(policyType).Return([]PolicyEntity{
+	repoMock.EXPECT().GetPolicies(mock.Anything, policyType).Return([]PolicyEntity{
 		{
 			Name:         "Policy1",
 			Owner:        "PolicyOwner",
@@ -178,21 +183,21 @@ func TestAccessSyncer_importPoliciesOfType(t *testing.T) {
 		},
 	}, nil).Once()
 
-	repoMock.EXPECT().DescribePolicy(policyType, "DB1", "Schema1", "Policy1").Return([]DescribePolicyEntity{
+	repoMock.EXPECT().DescribePolicy(mock.Anything, policyType, "DB1", "Schema1", "Policy1").Return([]DescribePolicyEntity{
 		{
 			Name: "Policy1",
 			Body: "PolicyBody1",
 		},
 	}, nil).Once()
 
-	repoMock.EXPECT().DescribePolicy(policyType, "DB1", "Schema2", "Policy2").Return([]DescribePolicyEntity{
+	repoMock.EXPECT().DescribePolicy(mock.Anything, policyType, "DB1", "Schema2", "Policy2").Return([]DescribePolicyEntity{
 		{
 			Name: "Policy2",
 			Body: "PolicyBody2",
 		},
 	}, nil).Once()
 
-	repoMock.EXPECT().GetPolicyReferences("DB1", "Schema1", "Policy1").Return([]PolicyReferenceEntity{
+	repoMock.EXPECT().GetPolicyReferences(mock.Anything, "DB1", "Schema1", "Policy1").Return([]PolicyReferenceEntity{
 		{
 			POLICY_STATUS:     "Active",
 			REF_COLUMN_NAME:   NullString{String: "ColumnName1", Valid: true},
@@ -203,7 +208,7 @@ func TestAccessSyncer_importPoliciesOfType(t *testing.T) {
 		},
 	}, nil).Once()
 
-	repoMock.EXPECT().GetPolicyReferences("DB1", "Schema2", "Policy2").Return([]PolicyReferenceEntity{
+	repoMock.EXPECT().GetPolicyReferences(mock.Anything, "DB1", "Schema2", "Policy2").Return([]PolicyReferenceEntity{
 		{
 			POLICY_STATUS:     "Active",
 			REF_COLUMN_NAME:   NullString{Valid: false},
@@ -217,7 +222,7 @@ func TestAccessSyncer_importPoliciesOfType(t *testing.T) {
 	syncer := createBasicFromTargetSyncer(repoMock, fileCreator, nil)
 
 	// When
-	err := syncer.importPoliciesOfType(policyType, types.Grant)
+	err := syncer.importPoliciesOfType(context.Background(), policyType, types.Grant)
 
 	// Then
 	assert.NoError(t, err)
@@ -265,7 +270,7 @@ func TestAccessSyncer_importPoliciesOfType(t *testing.T) {
 
 func createBasicFromTargetSyncer(repo dataAccessRepository, accessProviderHandler wrappers.AccessProviderHandler, configMap *config.ConfigMap) *AccessFromTargetSyncer {
 	as := AccessSyncer{
-		repoProvider: func(params map[string]string, role string) (dataAccessRepository, error) {
+		repoProvider: func(params map[string]string, role string, phase SyncPhase) (dataAccessRepository, error) {
 			return repo, nil
 		},
 		repo:              repo,
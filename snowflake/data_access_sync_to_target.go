@@ -11,17 +11,18 @@ import (
 
 	"github.com/aws/smithy-go/ptr"
 	"github.com/hashicorp/go-multierror"
-	gonanoid "github.com/matoous/go-nanoid/v2"
 	"github.com/raito-io/cli/base/access_provider"
 	importer "github.com/raito-io/cli/base/access_provider/sync_to_target"
 	"github.com/raito-io/cli/base/access_provider/sync_to_target/naming_hint"
 	"github.com/raito-io/cli/base/access_provider/types"
 	ds "github.com/raito-io/cli/base/data_source"
+	"github.com/raito-io/cli/base/tag"
 	"github.com/raito-io/cli/base/util/config"
 	"github.com/raito-io/cli/base/util/match"
 	"github.com/raito-io/cli/base/util/slice"
 	"github.com/raito-io/cli/base/wrappers"
 	"github.com/raito-io/golang-set/set"
+	"go.opentelemetry.io/otel/attribute"
 
 	"github.com/raito-io/cli-plugin-snowflake/common"
 )
@@ -37,6 +38,16 @@ type AccessToTargetSyncer struct {
 	ignoreLinksToRole          []string
 	databaseRoleSupportEnabled bool
 
+	// blastRadius is only set if the changes are planned first, to check them against the blast-radius limits
+	blastRadius *blastRadius
+
+	policyRules       *PolicyRules
+	databaseTagsCache map[string][]*tag.Tag
+	unmanagedRoles    *unmanagedRoles
+
+	// snapshot is only set if the state before the changes must be written to a snapshot file
+	snapshot *Snapshot
+
 	roleNameGenerator        *RoleNameGenerator
 	tablesPerSchemaCache     map[string][]TableEntity
 	functionsPerSchemaCache  map[string][]FunctionEntity
@@ -61,7 +72,7 @@ func NewAccessToTargetSyncer(accessSyncer *AccessSyncer, namingConstraints namin
 	}
 }
 
-func (s *AccessToTargetSyncer) syncToTarget(ctx context.Context) error {
+func (s *AccessToTargetSyncer) syncToTarget(ctx context.Context) (err error) {
 	s.databaseRoleSupportEnabled = s.configMap.GetBoolWithDefault(SfDatabaseRoles, false)
 
 	ignoreLinksToRoles := s.configMap.GetString(SfIgnoreLinksToRoles)
@@ -69,6 +80,49 @@ func (s *AccessToTargetSyncer) syncToTarget(ctx context.Context) error {
 		s.ignoreLinksToRole = slice.ParseCommaSeparatedList(ignoreLinksToRoles)
 	}
 
+	if policyRulesFile := s.configMap.GetString(SfPolicyRulesFile); policyRulesFile != "" {
+		policyRules, err := loadPolicyRules(policyRulesFile)
+		if err != nil {
+			return err
+		}
+
+		s.policyRules = policyRules
+	}
+
+	unmanagedRoles, err := newUnmanagedRoles(s.configMap)
+	if err != nil {
+		return err
+	}
+
+	err = unmanagedRoles.loadRoleTags(ctx, s.repo)
+	if err != nil {
+		return err
+	}
+
+	s.unmanagedRoles = unmanagedRoles
+
+	// The snapshot is also written if the sync fails halfway, as the changes made until then must be restorable
+	if snapshotFile := s.configMap.GetString(SfSnapshotFile); snapshotFile != "" && !isDryRun(s.configMap.Parameters) {
+		s.snapshot = newSnapshot()
+
+		defer func() {
+			writeErr := s.snapshot.write(snapshotFile)
+			if writeErr != nil {
+				writeErr = fmt.Errorf("write snapshot to %q: %w", snapshotFile, writeErr)
+
+				if err != nil {
+					Logger.Error(writeErr.Error())
+				} else {
+					err = writeErr
+				}
+
+				return
+			}
+
+			Logger.Info(fmt.Sprintf("Snapshot of %d roles, %d policies and %d shares written to %q", len(s.snapshot.Roles), len(s.snapshot.Policies), len(s.snapshot.Shares), snapshotFile))
+		}()
+	}
+
 	roleNameGen, err := NewRoleNameGenerator(&s.namingConstraints, s.repo)
 	if err != nil {
 		return fmt.Errorf("creating role name generator: %w", err)
@@ -96,15 +150,15 @@ func (s *AccessToTargetSyncer) syncToTarget(ctx context.Context) error {
 
 		switch ap.Action {
 		case types.Mask:
-			_, masksMap, masksToRemove, err2 = s.syncAccessProviderToTargetHandler(ap, masksMap, masksToRemove)
+			_, masksMap, masksToRemove, err2 = s.syncAccessProviderToTargetHandler(ctx, ap, masksMap, masksToRemove)
 		case types.Filtered:
-			_, filtersMap, filtersToRemove, err2 = s.syncAccessProviderToTargetHandler(ap, filtersMap, filtersToRemove)
+			_, filtersMap, filtersToRemove, err2 = s.syncAccessProviderToTargetHandler(ctx, ap, filtersMap, filtersToRemove)
 		case types.Grant, types.Purpose:
 			var externalId string
-			externalId, rolesMap, rolesToRemove, err2 = s.syncAccessProviderToTargetHandler(ap, rolesMap, rolesToRemove)
+			externalId, rolesMap, rolesToRemove, err2 = s.syncAccessProviderToTargetHandler(ctx, ap, rolesMap, rolesToRemove)
 			apIdNameMap[ap.Id] = externalId
 		case types.Share:
-			_, sharesMap, sharesToRemove, err2 = s.syncAccessProviderToTargetHandler(ap, sharesMap, sharesToRemove)
+			_, sharesMap, sharesToRemove, err2 = s.syncAccessProviderToTargetHandler(ctx, ap, sharesMap, sharesToRemove)
 		default:
 			err2 = s.accessProviderFeedbackHandler.AddAccessProviderFeedback(importer.AccessProviderSyncFeedback{
 				AccessProvider: ap.Id,
@@ -119,7 +173,7 @@ func (s *AccessToTargetSyncer) syncToTarget(ctx context.Context) error {
 
 	// Step 1 first initiate all the shares
 	if len(sharesMap)+len(sharesToRemove) > 0 {
-		err := s.SyncAccessProviderSharesToTarget(sharesToRemove, sharesMap)
+		err := s.SyncAccessProviderSharesToTarget(ctx, sharesToRemove, sharesMap)
 		if err != nil {
 			return fmt.Errorf("sync shares to target: %w", err)
 		}
@@ -127,7 +181,7 @@ func (s *AccessToTargetSyncer) syncToTarget(ctx context.Context) error {
 
 	// Step 2 then initiate all the masks
 	if len(masksMap)+len(masksToRemove) > 0 {
-		err := s.SyncAccessProviderMasksToTarget(masksToRemove, masksMap, apIdNameMap)
+		err := s.SyncAccessProviderMasksToTarget(ctx, masksToRemove, masksMap, apIdNameMap)
 		if err != nil {
 			return fmt.Errorf("sync masks to target: %w", err)
 		}
@@ -150,7 +204,7 @@ func (s *AccessToTargetSyncer) syncToTarget(ctx context.Context) error {
 	return nil
 }
 
-func (s *AccessToTargetSyncer) syncAccessProviderToTargetHandler(ap *importer.AccessProvider, toProcessAps map[string]*importer.AccessProvider, apToRemoveMap map[string]*importer.AccessProvider) (string, map[string]*importer.AccessProvider, map[string]*importer.AccessProvider, error) {
+func (s *AccessToTargetSyncer) syncAccessProviderToTargetHandler(ctx context.Context, ap *importer.AccessProvider, toProcessAps map[string]*importer.AccessProvider, apToRemoveMap map[string]*importer.AccessProvider) (string, map[string]*importer.AccessProvider, map[string]*importer.AccessProvider, error) {
 	var externalId string
 
 	if ap.Delete {
@@ -171,7 +225,7 @@ func (s *AccessToTargetSyncer) syncAccessProviderToTargetHandler(ap *importer.Ac
 
 		apToRemoveMap[externalId] = ap
 	} else {
-		uniqueExternalId, _, err := s.generateUniqueExternalId(ap)
+		uniqueExternalId, _, err := s.generateUniqueExternalId(ctx, ap)
 		if err != nil {
 			return "", nil, nil, err
 		}
@@ -185,14 +239,14 @@ func (s *AccessToTargetSyncer) syncAccessProviderToTargetHandler(ap *importer.Ac
 	return externalId, toProcessAps, apToRemoveMap, nil
 }
 
-func (s *AccessToTargetSyncer) generateUniqueExternalId(ap *importer.AccessProvider) (string, RoleNameGenerationResultType, error) {
+func (s *AccessToTargetSyncer) generateUniqueExternalId(ctx context.Context, ap *importer.AccessProvider) (string, RoleNameGenerationResultType, error) {
 	if isDatabaseRole(ap.Type) {
 		database, err := s.extractRoleNamespace(ap, parseDatabaseRoleExternalId)
 		if err != nil {
 			return "", 0, err
 		}
 
-		roleName, resultType, err := s.roleNameGenerator.GenerateDatabaseRole(ap, database)
+		roleName, resultType, err := s.roleNameGenerator.GenerateDatabaseRole(ctx, ap, database)
 		if err != nil {
 			return "", 0, err
 		}
@@ -206,7 +260,7 @@ func (s *AccessToTargetSyncer) generateUniqueExternalId(ap *importer.AccessProvi
 			return "", 0, err
 		}
 
-		roleName, resultType, err := s.roleNameGenerator.GenerateApplicationRole(ap, application)
+		roleName, resultType, err := s.roleNameGenerator.GenerateApplicationRole(ctx, ap, application)
 		if err != nil {
 			return "", 0, err
 		}
@@ -216,7 +270,7 @@ func (s *AccessToTargetSyncer) generateUniqueExternalId(ap *importer.AccessProvi
 		return applicationRoleExternalIdGenerator(application, roleName), resultType, nil
 	}
 
-	roleName, resultType, err := s.roleNameGenerator.GenerateAccountRole(ap)
+	roleName, resultType, err := s.roleNameGenerator.GenerateAccountRole(ctx, ap)
 	if err != nil {
 		return "", 0, err
 	}
@@ -243,7 +297,7 @@ func (s *AccessToTargetSyncer) extractRoleNamespace(ap *importer.AccessProvider,
 func (s *AccessToTargetSyncer) SyncAccessProviderRolesToTarget(ctx context.Context, toRemoveAps map[string]*importer.AccessProvider, toProcessAps map[string]*importer.AccessProvider) error {
 	Logger.Info("Configuring access providers as roles in Snowflake")
 
-	err := s.removeRolesToRemove(toRemoveAps)
+	err := s.removeRolesToRemove(ctx, toRemoveAps)
 	if err != nil {
 		return err
 	}
@@ -256,7 +310,14 @@ func (s *AccessToTargetSyncer) SyncAccessProviderRolesToTarget(ctx context.Conte
 		}
 	}
 
-	existingRoles, err := s.findRoles("")
+	existingRoles, err := s.findRoles(ctx, "")
+	if err != nil {
+		return err
+	}
+
+	s.blastRadius.observe(blastRadiusRoleDrops, len(existingRoles))
+
+	err = s.observeGrantTotal(ctx, existingRoles)
 	if err != nil {
 		return err
 	}
@@ -269,14 +330,24 @@ func (s *AccessToTargetSyncer) SyncAccessProviderRolesToTarget(ctx context.Conte
 	return nil
 }
 
-func (s *AccessToTargetSyncer) SyncAccessProviderSharesToTarget(apToRemoveMap map[string]*importer.AccessProvider, apMap map[string]*importer.AccessProvider) error {
+func (s *AccessToTargetSyncer) SyncAccessProviderSharesToTarget(ctx context.Context, apToRemoveMap map[string]*importer.AccessProvider, apMap map[string]*importer.AccessProvider) error {
 	Logger.Info(fmt.Sprintf("Configuring access provider as shares in Snowflake. Update %d shares remove %d shares", len(apMap), len(apToRemoveMap)))
 
 	metadata := s.buildMetaDataMap()
 
+	if s.blastRadius != nil {
+		// The current accounts of the shares are needed to count the accounts that will be removed
+		shares, err := s.repo.GetOutboundShares(ctx)
+		if err != nil {
+			return fmt.Errorf("get outbound shares: %w", err)
+		}
+
+		s.blastRadius.observeShares(shares)
+	}
+
 	// Step 1: Update shares and create new shares
 	for _, share := range apMap {
-		shareName, err := s.updateShare(share, metadata)
+		shareName, err := s.updateShare(withAccessProviders(ctx, share), share, metadata)
 		fi := importer.AccessProviderSyncFeedback{AccessProvider: share.Id, ActualName: shareName, ExternalId: ptr.String(apTypeSharePrefix + shareName)}
 
 		if err != nil {
@@ -296,7 +367,7 @@ func (s *AccessToTargetSyncer) SyncAccessProviderSharesToTarget(apToRemoveMap ma
 		externalId := shareToRemove
 		fi := importer.AccessProviderSyncFeedback{AccessProvider: shareAp.Id, ActualName: shareToRemove, ExternalId: &externalId}
 
-		err := s.removeShare(shareToRemove)
+		err := s.removeShare(withAccessProviders(ctx, shareAp), shareToRemove)
 		if err != nil {
 			Logger.Warn(fmt.Sprintf("Unable to remove share %q: %s", shareToRemove, err.Error()))
 
@@ -312,7 +383,7 @@ func (s *AccessToTargetSyncer) SyncAccessProviderSharesToTarget(apToRemoveMap ma
 	return nil
 }
 
-func (s *AccessToTargetSyncer) SyncAccessProviderMasksToTarget(apToRemoveMap map[string]*importer.AccessProvider, apMap map[string]*importer.AccessProvider, roleNameMap map[string]string) error {
+func (s *AccessToTargetSyncer) SyncAccessProviderMasksToTarget(ctx context.Context, apToRemoveMap map[string]*importer.AccessProvider, apMap map[string]*importer.AccessProvider, roleNameMap map[string]string) error {
 	var err error
 
 	if s.configMap.GetBoolWithDefault(SfStandardEdition, false) {
@@ -325,9 +396,14 @@ func (s *AccessToTargetSyncer) SyncAccessProviderMasksToTarget(apToRemoveMap map
 
 	Logger.Info(fmt.Sprintf("Configuring access provider as masks in Snowflake. Update %d masks remove %d masks", len(apMap), len(apToRemoveMap)))
 
+	err = s.observeMaskingPolicyTotal(ctx)
+	if err != nil {
+		return err
+	}
+
 	// Step 1: Update masks and create new masks
 	for _, mask := range apMap {
-		maskName, err2 := s.updateMask(mask, roleNameMap)
+		maskName, err2 := s.updateMask(withAccessProviders(ctx, mask), mask, roleNameMap)
 		fi := importer.AccessProviderSyncFeedback{AccessProvider: mask.Id, ActualName: maskName, ExternalId: &maskName}
 
 		if err2 != nil {
@@ -347,7 +423,7 @@ func (s *AccessToTargetSyncer) SyncAccessProviderMasksToTarget(apToRemoveMap map
 		externalId := maskToRemove
 		fi := importer.AccessProviderSyncFeedback{AccessProvider: maskAp.Id, ActualName: maskToRemove, ExternalId: &externalId}
 
-		err = s.removeMask(maskToRemove)
+		err = s.removeMask(withAccessProviders(ctx, maskAp), maskToRemove)
 		if err != nil {
 			Logger.Warn(fmt.Sprintf("Unable to remove mask %q: %s", maskToRemove, err.Error()))
 
@@ -428,7 +504,7 @@ func (s *AccessToTargetSyncer) SyncAccessProviderFiltersToTarget(ctx context.Con
 	updatedTables := set.NewSet[string]()
 
 	for table, filters := range updateGroupedFilters {
-		filterName, externalId, createErr := s.updateOrCreateFilter(ctx, table, filters, roleNameMap)
+		filterName, externalId, createErr := s.updateOrCreateFilter(withAccessProviders(ctx, filters...), table, filters, roleNameMap)
 
 		ferr := feedbackFn(filters, &filterName, externalId, createErr)
 		if ferr != nil {
@@ -444,7 +520,7 @@ func (s *AccessToTargetSyncer) SyncAccessProviderFiltersToTarget(ctx context.Con
 	for table, filters := range removeGroupedFilters {
 		if _, found := updateGroupedFilters[table]; found {
 			if updatedTables.Contains(table) {
-				deleteErr := s.deleteFilter(table, filters)
+				deleteErr := s.deleteFilter(withAccessProviders(ctx, filters...), table, filters)
 
 				ferr := feedbackFn(filters, nil, nil, deleteErr)
 				if ferr != nil {
@@ -457,7 +533,7 @@ func (s *AccessToTargetSyncer) SyncAccessProviderFiltersToTarget(ctx context.Con
 				}
 			}
 		} else {
-			deleteErr := s.deleteFilter(table, filters)
+			deleteErr := s.deleteFilter(withAccessProviders(ctx, filters...), table, filters)
 
 			ferr := feedbackFn(filters, nil, nil, deleteErr)
 			if ferr != nil {
@@ -469,15 +545,31 @@ func (s *AccessToTargetSyncer) SyncAccessProviderFiltersToTarget(ctx context.Con
 	return nil
 }
 
-func (s *AccessToTargetSyncer) removeRolesToRemove(toRemoveAps map[string]*importer.AccessProvider) error {
+func (s *AccessToTargetSyncer) removeRolesToRemove(ctx context.Context, toRemoveAps map[string]*importer.AccessProvider) error {
 	if len(toRemoveAps) > 0 {
 		Logger.Info(fmt.Sprintf("Removing %d old Raito roles in Snowflake", len(toRemoveAps)))
 
 		for toRemoveExternalId, ap := range toRemoveAps {
+			refusedErr := s.unmanagedRoles.checkRoles("drop", toRemoveExternalId)
+			if refusedErr == nil {
+				refusedErr = s.checkPolicyRules(ctx, policyChange{role: toRemoveExternalId})
+			}
+
+			// A role is never dropped without its state in the snapshot
+			if refusedErr == nil {
+				refusedErr = s.captureRole(ctx, toRemoveExternalId)
+			}
+
 			if ap == nil {
+				if refusedErr != nil {
+					Logger.Warn(fmt.Sprintf("not removing %q from Snowflake: %s", toRemoveExternalId, refusedErr.Error()))
+
+					continue
+				}
+
 				Logger.Warn(fmt.Sprintf("no linked access provider found for %q, so just going to remove it from Snowflake", toRemoveExternalId))
 
-				err := s.dropRole(toRemoveExternalId, isDatabaseRoleByExternalId(toRemoveExternalId))
+				err := s.dropRole(withAccessProviders(ctx), toRemoveExternalId, isDatabaseRoleByExternalId(toRemoveExternalId))
 				if err != nil {
 					return err
 				}
@@ -490,7 +582,16 @@ func (s *AccessToTargetSyncer) removeRolesToRemove(toRemoveAps map[string]*impor
 				ExternalId:     ptr.String(toRemoveExternalId),
 			}
 
-			err := s.dropRole(toRemoveExternalId, isDatabaseRole(ap.Type))
+			if refusedErr != nil {
+				err := s.handleAccessProviderFeedback(&fi, refusedErr)
+				if err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			err := s.dropRole(withAccessProviders(ctx, ap), toRemoveExternalId, isDatabaseRole(ap.Type))
 			// If an error occurs (and not already deleted), we send an error back as feedback
 			if err != nil && !strings.Contains(err.Error(), "does not exist") {
 				Logger.Error(fmt.Sprintf("unable to drop role %q: %s", toRemoveExternalId, err.Error()))
@@ -511,10 +612,10 @@ func (s *AccessToTargetSyncer) removeRolesToRemove(toRemoveAps map[string]*impor
 }
 
 // findRoles returns the set of existing roles with the given prefix
-func (s *AccessToTargetSyncer) findRoles(prefix string) (set.Set[string], error) {
+func (s *AccessToTargetSyncer) findRoles(ctx context.Context, prefix string) (set.Set[string], error) {
 	existingRoles := set.NewSet[string]()
 
-	roleEntities, err := s.repo.GetAccountRolesWithPrefix(prefix)
+	roleEntities, err := s.repo.GetAccountRolesWithPrefix(ctx, prefix)
 	if err != nil {
 		return nil, err
 	}
@@ -528,14 +629,14 @@ func (s *AccessToTargetSyncer) findRoles(prefix string) (set.Set[string], error)
 	}
 
 	// Get all database roles for each database and add database roles to existing roles
-	databases, err := s.accessSyncer.getAllDatabaseAndShareNames()
+	databases, err := s.accessSyncer.getAllDatabaseAndShareNames(ctx)
 	if err != nil {
 		return nil, err
 	}
 
 	for database := range databases {
 		// Get all database roles for database
-		roleEntities, err := s.repo.GetDatabaseRolesWithPrefix(database, prefix)
+		roleEntities, err := s.repo.GetDatabaseRolesWithPrefix(ctx, database, prefix)
 		if err != nil {
 			return nil, err
 		}
@@ -546,14 +647,14 @@ func (s *AccessToTargetSyncer) findRoles(prefix string) (set.Set[string], error)
 	}
 
 	// Get all application roles for each database and add application roles to existing roles
-	applications, err := s.repo.GetApplications()
+	applications, err := s.repo.GetApplications(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("unable to get applications: %w", err)
 	}
 
 	for _, application := range applications {
 		// Get all application roles for application
-		roleEntities, err := s.repo.GetApplicationRoles(application.Name)
+		roleEntities, err := s.repo.GetApplicationRoles(ctx, application.Name)
 		if err != nil {
 			return nil, fmt.Errorf("get application roles: %w", err)
 		}
@@ -586,9 +687,18 @@ func (s *AccessToTargetSyncer) buildMetaDataMap() map[string]map[string]struct{}
 }
 
 //nolint:gocyclo
-func (s *AccessToTargetSyncer) handleAccessProvider(ctx context.Context, externalId string, toProcessAps map[string]*importer.AccessProvider, existingRoles set.Set[string], toRenameAps map[string]string, rolesCreated map[string]interface{}, metaData map[string]map[string]struct{}) (string, error) {
+func (s *AccessToTargetSyncer) handleAccessProvider(ctx context.Context, externalId string, toProcessAps map[string]*importer.AccessProvider, existingRoles set.Set[string], toRenameAps map[string]string, rolesCreated map[string]interface{}, metaData map[string]map[string]struct{}) (actualName string, err error) {
 	accessProvider := toProcessAps[externalId]
 
+	ctx, span := startSpan(ctx, "handleAccessProvider",
+		attribute.String("raito.access_provider.id", accessProvider.Id),
+		attribute.String("raito.access_provider.name", accessProvider.Name),
+		attribute.String("raito.access_provider.external_id", externalId),
+	)
+	defer func() { endSpan(span, err) }()
+
+	ctx = withAccessProviders(ctx, accessProvider)
+
 	ignoreWho := accessProvider.WhoLocked != nil && *accessProvider.WhoLocked
 	ignoreInheritance := accessProvider.InheritanceLocked != nil && *accessProvider.InheritanceLocked
 	ignoreWhat := accessProvider.WhatLocked != nil && *accessProvider.WhatLocked
@@ -596,12 +706,46 @@ func (s *AccessToTargetSyncer) handleAccessProvider(ctx context.Context, externa
 
 	Logger.Info(fmt.Sprintf("Generating access controls for access provider %q (Ignore who: %t; Ignore inheritance: %t; Ignore what: %t)", accessProvider.Name, ignoreWho, ignoreInheritance, ignoreWhat))
 
+	err = s.unmanagedRoles.checkRoles("change", externalId)
+	if err != nil {
+		return actualName, err
+	}
+
+	err = s.checkPolicyRules(ctx, policyChange{role: externalId})
+	if err != nil {
+		return actualName, err
+	}
+
+	err = s.captureRole(ctx, externalId)
+	if err != nil {
+		return actualName, err
+	}
+
+	if oldExternalId, f := toRenameAps[externalId]; f {
+		err = s.unmanagedRoles.checkRoles("rename", oldExternalId)
+		if err != nil {
+			return actualName, err
+		}
+
+		err = s.checkPolicyRules(ctx, policyChange{role: oldExternalId})
+		if err != nil {
+			return actualName, err
+		}
+
+		err = s.captureRole(ctx, oldExternalId)
+		if err != nil {
+			return actualName, err
+		}
+	}
+
 	// Extract RoleNames from Access Providers that are among the whoList of this one
 	inheritedRoles := make([]string, 0)
 
-	actualName := externalId
+	actualName = externalId
 	dbName := ""
-	var err error
+
+	// The name used to read the current grants of the role. When the changes are only planned (dry run, change plan or blast-radius limits), a renamed role still has its old name.
+	existingName := externalId
 
 	switch {
 	case isDatabaseRole(accessProvider.Type):
@@ -636,7 +780,7 @@ func (s *AccessToTargetSyncer) handleAccessProvider(ctx context.Context, externa
 	expectedGrants := NewGrantSet()
 
 	if !ignoreWhat {
-		expectedGrants, err = s.createGrantsForWhatObjects(accessProvider, metaData)
+		expectedGrants, err = s.createGrantsForWhatObjects(ctx, accessProvider, externalId, metaData)
 		if err != nil {
 			return actualName, err
 		}
@@ -652,12 +796,18 @@ func (s *AccessToTargetSyncer) handleAccessProvider(ctx context.Context, externa
 				Logger.Info(fmt.Sprintf("Both the old role name (%s) and the new role name (%s) exist. The old role name is already taken by another (new?) access provider.", externalId, oldExternalId))
 			} else {
 				// The old name exists and the new one doesn't exist yet, so we have to do the rename
-				err = s.renameRole(oldExternalId, externalId, accessProvider.Type)
+				s.captureRoleRename(oldExternalId, externalId)
+
+				err = s.renameRole(ctx, oldExternalId, externalId, accessProvider.Type)
 				if err != nil {
 					return actualName, fmt.Errorf("error while renaming role %q to %q: %s", oldExternalId, externalId, err.Error())
 				}
 
 				existingRoles.Add(externalId)
+
+				if s.repo.ExecutionPlan() != nil {
+					existingName = oldExternalId
+				}
 			}
 		} else if existingRoles.Contains(externalId) && existingRoles.Contains(oldExternalId) {
 			if _, oldFound := toProcessAps[oldExternalId]; oldFound {
@@ -667,7 +817,7 @@ func (s *AccessToTargetSyncer) handleAccessProvider(ctx context.Context, externa
 				Logger.Info(fmt.Sprintf("Both the old role name (%s) and the new role name (%s) exist. The old role name is already taken by another (new?) access provider.", externalId, oldExternalId))
 			} else {
 				// The old name exists but also the new one already exists. This is a weird case, but we'll delete the old one in this case and the new one will be updated in the next step of this method.
-				err = s.dropRole(oldExternalId, isDatabaseRoleByExternalId(oldExternalId))
+				err = s.dropRole(ctx, oldExternalId, isDatabaseRoleByExternalId(oldExternalId))
 				if err != nil {
 					return actualName, fmt.Errorf("error while dropping role (%s) which was the old name of access provider %q: %s", oldExternalId, accessProvider.Name, err.Error())
 				}
@@ -685,7 +835,7 @@ func (s *AccessToTargetSyncer) handleAccessProvider(ctx context.Context, externa
 
 		// Only update the comment if we have full control over the role (who , inheritance and what not ignored)
 		if !ignoreWho && !ignoreWhat && !ignoreInheritance {
-			err2 := s.commentOnRoleIfExists(createComment(accessProvider, true), externalId)
+			err2 := s.commentOnRoleIfExists(ctx, createComment(accessProvider, true), externalId)
 			if err2 != nil {
 				return actualName, fmt.Errorf("error while updating comment on role %q: %s", externalId, err2.Error())
 			}
@@ -698,36 +848,14 @@ func (s *AccessToTargetSyncer) handleAccessProvider(ctx context.Context, externa
 				apType = *accessProvider.Type
 			}
 
-			grantsOfRole, err3 := s.accessSyncer.retrieveGrantsOfRole(externalId, apType)
+			grantsOfRole, err3 := s.accessSyncer.retrieveGrantsOfRole(ctx, existingName, apType)
 			if err3 != nil {
 				return actualName, err3
 			}
 
-			usersOfRole := make([]string, 0, len(grantsOfRole))
-			rolesOfRole := make([]string, 0, len(grantsOfRole))
-
-			for _, gor := range grantsOfRole {
-				switch {
-				case strings.EqualFold(gor.GrantedTo, "USER"):
-					usersOfRole = append(usersOfRole, cleanDoubleQuotes(gor.GranteeName))
-				case strings.EqualFold(gor.GrantedTo, "ROLE"):
-					rolesOfRole = append(rolesOfRole, accountRoleExternalIdGenerator(cleanDoubleQuotes(gor.GranteeName)))
-				case strings.EqualFold(gor.GrantedTo, GrantTypeDatabaseRole):
-					database, parsedRoleName, err2 := parseNamespacedRoleRoleName(cleanDoubleQuotes(gor.GranteeName))
-					if err2 != nil {
-						return actualName, err2
-					}
-					rolesOfRole = append(rolesOfRole, databaseRoleExternalIdGenerator(database, parsedRoleName))
-				case strings.EqualFold(gor.GrantedTo, "SHARE"):
-					rolesOfRole = append(rolesOfRole, shareExternalIdGenerator(gor.GranteeName))
-				case strings.EqualFold(gor.GrantedTo, GrantTypeApplicationRole):
-					application, parsedRoleName, err2 := parseNamespacedRoleRoleName(cleanDoubleQuotes(gor.GranteeName))
-					if err2 != nil {
-						return actualName, err2
-					}
-
-					rolesOfRole = append(rolesOfRole, applicationRoleExternalIdGenerator(application, parsedRoleName))
-				}
+			usersOfRole, rolesOfRole, err3 := splitGrantsOfRole(grantsOfRole)
+			if err3 != nil {
+				return actualName, err3
 			}
 
 			if !ignoreWho {
@@ -785,74 +913,33 @@ func (s *AccessToTargetSyncer) handleAccessProvider(ctx context.Context, externa
 			// We assume nobody manually added others to this role manually.
 			for _, what := range accessProvider.What {
 				if what.DataObject.Type == "database" {
-					e := s.executeRevokeOnRole("ALL", common.FormatQuery(`FUTURE SCHEMAS IN DATABASE %s`, what.DataObject.FullName), externalId, accessProvider.Type)
+					e := s.executeRevokeOnRole(ctx, "ALL", common.FormatQuery(`FUTURE SCHEMAS IN DATABASE %s`, what.DataObject.FullName), externalId, accessProvider.Type)
 					if e != nil {
 						return actualName, fmt.Errorf("error while assigning future schema grants in database %q to role %q: %s", what.DataObject.FullName, externalId, e.Error())
 					}
 
-					e = s.executeRevokeOnRole("ALL", common.FormatQuery(`FUTURE TABLES IN DATABASE %s`, what.DataObject.FullName), externalId, accessProvider.Type)
+					e = s.executeRevokeOnRole(ctx, "ALL", common.FormatQuery(`FUTURE TABLES IN DATABASE %s`, what.DataObject.FullName), externalId, accessProvider.Type)
 					if e != nil {
 						return actualName, fmt.Errorf("error while assigning future table grants in database %q to role %q: %s", what.DataObject.FullName, externalId, e.Error())
 					}
 				} else if what.DataObject.Type == "schema" {
-					e := s.executeRevokeOnRole("ALL", fmt.Sprintf("FUTURE TABLES IN SCHEMA %s", what.DataObject.FullName), externalId, accessProvider.Type)
+					e := s.executeRevokeOnRole(ctx, "ALL", fmt.Sprintf("FUTURE TABLES IN SCHEMA %s", what.DataObject.FullName), externalId, accessProvider.Type)
 					if e != nil {
 						return actualName, fmt.Errorf("error while assigning future table grants in schema %q to role %q: %s", what.DataObject.FullName, externalId, e.Error())
 					}
 				}
 			}
 
-			grantsToRole, err3 := s.accessSyncer.getGrantsToRole(externalId, accessProvider.Type)
+			grantsToRole, err3 := s.accessSyncer.getGrantsToRole(ctx, existingName, accessProvider.Type)
 			if err3 != nil {
 				return actualName, err3
 			}
 
 			Logger.Debug(fmt.Sprintf("Found grants for role %q: %+v", externalId, grantsToRole))
 
-			foundGrants = make([]Grant, 0, len(grantsToRole))
-
-			importedDbs := make(map[string]DbEntity)
-			importedDbsFetched := false
-
-			for _, grant := range grantsToRole {
-				if strings.EqualFold(grant.GrantedOn, "ACCOUNT") {
-					foundGrants = append(foundGrants, Grant{grant.Privilege, "account", ""})
-				} else if strings.EqualFold(grant.Privilege, "OWNERSHIP") {
-					Logger.Info(fmt.Sprintf("Ignoring permission %q on %q for Role %q as this will remain untouched", grant.Privilege, grant.Name, externalId))
-				} else if strings.EqualFold(grant.Privilege, "USAGE") && (strings.EqualFold(grant.GrantedOn, "ROLE") || strings.EqualFold(grant.GrantedOn, GrantTypeDatabaseRole)) {
-					Logger.Debug(fmt.Sprintf("Ignoring USAGE permission on %s %q", grant.GrantedOn, grant.Name))
-				} else {
-					onType := convertSnowflakeGrantTypeToRaito(grant.GrantedOn)
-
-					// Snowflake reports Privilege="USAGE" and GrantedOn="DATABASE" for IMPORTED PRIVILEGES on shared databases.
-					if strings.EqualFold(grant.Privilege, "USAGE") && strings.EqualFold(grant.GrantedOn, "DATABASE") {
-						if !importedDbsFetched {
-							dbs, dgsErr := s.repo.GetDatabasesByKind("IMPORTED DATABASE")
-							if dgsErr != nil {
-								return actualName, fmt.Errorf("error while retrieving databases: %s", dgsErr.Error())
-							}
-
-							for _, db := range dbs {
-								importedDbs[db.Name] = db
-							}
-
-							importedDbsFetched = true
-						}
-
-						if _, found := importedDbs[grant.Name]; found {
-							onType = SharedPrefix + ds.Database
-							grant.Privilege = "IMPORTED PRIVILEGES"
-						}
-					}
-
-					name := grant.Name
-
-					if strings.EqualFold(onType, Function) || strings.EqualFold(onType, Procedure) { // For functions and stored procedures we need to do a special conversion
-						name = s.accessSyncer.getFullNameFromGrant(name, onType)
-					}
-
-					foundGrants = append(foundGrants, Grant{grant.Privilege, onType, name})
-				}
+			foundGrants, err3 = s.convertGrantsToRole(ctx, externalId, grantsToRole)
+			if err3 != nil {
+				return actualName, err3
 			}
 		}
 
@@ -868,13 +955,13 @@ func (s *AccessToTargetSyncer) handleAccessProvider(ctx context.Context, externa
 
 		if _, rf := rolesCreated[externalId]; !rf {
 			// Create the role if not exists
-			err = s.createRole(externalId, accessProvider.Type)
+			err = s.createRole(ctx, externalId, accessProvider.Type)
 			if err != nil {
 				return actualName, fmt.Errorf("error while creating role %q: %s", externalId, err.Error())
 			}
 
 			// Updating the comment (independent of creation)
-			err = s.commentOnRoleIfExists(createComment(accessProvider, false), externalId)
+			err = s.commentOnRoleIfExists(ctx, createComment(accessProvider, false), externalId)
 			if err != nil {
 				return actualName, fmt.Errorf("error while updating comment on role %q: %s", externalId, err.Error())
 			}
@@ -899,7 +986,7 @@ func (s *AccessToTargetSyncer) handleAccessProvider(ctx context.Context, externa
 	}
 
 	if !ignoreWhat {
-		err = s.mergeGrants(externalId, accessProvider.Type, foundGrants, expectedGrants.Slice(), metaData)
+		err = s.mergeGrants(ctx, externalId, accessProvider.Type, foundGrants, expectedGrants.Slice(), metaData)
 		if err != nil {
 			return actualName, err
 		}
@@ -910,7 +997,7 @@ func (s *AccessToTargetSyncer) handleAccessProvider(ctx context.Context, externa
 		fullName = fmt.Sprintf("%s.%s", dbName, actualName)
 	}
 
-	err = s.handleOwnerTags(fullName, accessProvider.Owners, isDatabaseRole(accessProvider.Type))
+	err = s.handleOwnerTags(ctx, fullName, accessProvider.Owners, isDatabaseRole(accessProvider.Type))
 	if err != nil {
 		return actualName, fmt.Errorf("error while setting owner tags on role %q: %s", actualName, err.Error())
 	}
@@ -918,7 +1005,7 @@ func (s *AccessToTargetSyncer) handleAccessProvider(ctx context.Context, externa
 	return actualName, nil
 }
 
-func (s *AccessToTargetSyncer) handleOwnerTags(actualName string, owners []importer.Owner, isDatabaseRole bool) error {
+func (s *AccessToTargetSyncer) handleOwnerTags(ctx context.Context, actualName string, owners []importer.Owner, isDatabaseRole bool) error {
 	if len(owners) == 0 {
 		return nil
 	}
@@ -932,7 +1019,7 @@ func (s *AccessToTargetSyncer) handleOwnerTags(actualName string, owners []impor
 			}
 		}
 
-		err := s.repo.SetTagOnRole(actualName, emailTag, strings.Join(tagValues, ","), isDatabaseRole)
+		err := s.repo.SetTagOnRole(ctx, actualName, emailTag, strings.Join(tagValues, ","), isDatabaseRole)
 		if err != nil {
 			return fmt.Errorf("setting owner email tag on role %q: %s", actualName, err.Error())
 		}
@@ -947,7 +1034,7 @@ func (s *AccessToTargetSyncer) handleOwnerTags(actualName string, owners []impor
 			}
 		}
 
-		err := s.repo.SetTagOnRole(actualName, nameTag, strings.Join(tagValues, ","), isDatabaseRole)
+		err := s.repo.SetTagOnRole(ctx, actualName, nameTag, strings.Join(tagValues, ","), isDatabaseRole)
 		if err != nil {
 			return fmt.Errorf("setting owner account name tag on role %q: %s", actualName, err.Error())
 		}
@@ -962,7 +1049,7 @@ func (s *AccessToTargetSyncer) handleOwnerTags(actualName string, owners []impor
 			}
 		}
 
-		err := s.repo.SetTagOnRole(actualName, groupTag, strings.Join(tagValues, ","), isDatabaseRole)
+		err := s.repo.SetTagOnRole(ctx, actualName, groupTag, strings.Join(tagValues, ","), isDatabaseRole)
 		if err != nil {
 			return fmt.Errorf("setting owner group name tag on role %q: %s", actualName, err.Error())
 		}
@@ -971,7 +1058,9 @@ func (s *AccessToTargetSyncer) handleOwnerTags(actualName string, owners []impor
 	return nil
 }
 
-func (s *AccessToTargetSyncer) createGrantsForWhatObjects(accessProvider *importer.AccessProvider, metaData map[string]map[string]struct{}) (GrantSet, error) {
+// createGrantsForWhatObjects builds the grants the grantee (a role or share) should have for the what of the access provider.
+// An error is returned if one of the grants violates the policy rules.
+func (s *AccessToTargetSyncer) createGrantsForWhatObjects(ctx context.Context, accessProvider *importer.AccessProvider, grantee string, metaData map[string]map[string]struct{}) (GrantSet, error) {
 	expectedGrants := NewGrantSet()
 
 	for _, what := range accessProvider.What {
@@ -987,39 +1076,51 @@ func (s *AccessToTargetSyncer) createGrantsForWhatObjects(accessProvider *import
 				return expectedGrants, err2
 			}
 		} else if what.DataObject.Type == ds.Schema {
-			err2 := s.createGrantsForSchema(permissions, what.DataObject.FullName, metaData, false, &expectedGrants)
+			err2 := s.createGrantsForSchema(ctx, permissions, what.DataObject.FullName, metaData, false, &expectedGrants)
 			if err2 != nil {
 				return expectedGrants, err2
 			}
 		} else if what.DataObject.Type == Function || what.DataObject.Type == Procedure {
 			s.createGrantsForFunctionOrProcedure(permissions, what.DataObject.FullName, metaData, &expectedGrants, what.DataObject.Type)
+		} else if isSchemaObjectType(what.DataObject.Type) {
+			err2 := s.createGrantsForSchemaObject(what.DataObject.Type, permissions, what.DataObject.FullName, metaData, &expectedGrants)
+			if err2 != nil {
+				return expectedGrants, err2
+			}
 		} else if what.DataObject.Type == "shared-schema" {
-			err2 := s.createGrantsForSchema(permissions, what.DataObject.FullName, metaData, true, &expectedGrants)
+			err2 := s.createGrantsForSchema(ctx, permissions, what.DataObject.FullName, metaData, true, &expectedGrants)
 			if err2 != nil {
 				return expectedGrants, err2
 			}
 		} else if what.DataObject.Type == "shared-database" {
-			err2 := s.createGrantsForDatabase(permissions, what.DataObject.FullName, metaData, true, &expectedGrants)
+			err2 := s.createGrantsForDatabase(ctx, permissions, what.DataObject.FullName, metaData, true, &expectedGrants)
 			if err2 != nil {
 				return expectedGrants, err2
 			}
 		} else if what.DataObject.Type == ds.Database {
-			err2 := s.createGrantsForDatabase(permissions, what.DataObject.FullName, metaData, false, &expectedGrants)
+			err2 := s.createGrantsForDatabase(ctx, permissions, what.DataObject.FullName, metaData, false, &expectedGrants)
 			if err2 != nil {
 				return expectedGrants, err2
 			}
 		} else if what.DataObject.Type == "warehouse" {
 			s.createGrantsForWarehouse(permissions, what.DataObject.FullName, metaData, &expectedGrants)
+		} else if what.DataObject.Type == ComputePool {
+			s.createGrantsForComputePool(permissions, what.DataObject.FullName, metaData, &expectedGrants)
 		} else if what.DataObject.Type == Integration {
 			s.createGrantsForIntegration(permissions, what.DataObject.FullName, metaData, &expectedGrants)
 		} else if what.DataObject.Type == ds.Datasource {
-			err2 := s.createGrantsForAccount(permissions, metaData, &expectedGrants)
+			err2 := s.createGrantsForAccount(ctx, permissions, metaData, &expectedGrants)
 			if err2 != nil {
 				return expectedGrants, err2
 			}
 		}
 	}
 
+	err := s.checkPolicyRulesOnGrants(ctx, policyActionGrant, grantee, expectedGrants.Slice())
+	if err != nil {
+		return expectedGrants, err
+	}
+
 	return expectedGrants, nil
 }
 
@@ -1043,6 +1144,11 @@ func (s *AccessToTargetSyncer) splitRoles(inheritedRoles []string) ([]string, []
 }
 
 func (s *AccessToTargetSyncer) grantRolesToRole(ctx context.Context, targetExternalId string, targetApType *string, roles ...string) error {
+	err := s.checkPolicyRulesOnRoleGrants(ctx, policyActionGrant, targetExternalId, roles)
+	if err != nil {
+		return err
+	}
+
 	toAddDatabaseRoles, toAddApplicationRoles, toAddAccountRoles := s.splitRoles(roles)
 
 	var filteredAccountRoles []string
@@ -1058,6 +1164,11 @@ func (s *AccessToTargetSyncer) grantRolesToRole(ctx context.Context, targetExter
 		}
 	}
 
+	err = s.unmanagedRoles.checkRoles("grant to", append(slices.Clone(filteredAccountRoles), toAddDatabaseRoles...)...)
+	if err != nil {
+		return err
+	}
+
 	switch {
 	case isDatabaseRole(targetApType):
 		err := s.grantRolesToDatabaseRoles(ctx, targetExternalId, toAddDatabaseRoles, filteredAccountRoles)
@@ -1077,7 +1188,7 @@ func (s *AccessToTargetSyncer) grantRolesToRole(ctx context.Context, targetExter
 		return fmt.Errorf("error can not assign database roles to an account role %q - %v", targetExternalId, toAddDatabaseRoles)
 	}
 
-	err := s.repo.GrantAccountRolesToAccountRole(ctx, targetExternalId, filteredAccountRoles...)
+	err = s.repo.GrantAccountRolesToAccountRole(ctx, targetExternalId, filteredAccountRoles...)
 	if err != nil {
 		return fmt.Errorf("granting account roles to account role: %w", err)
 	}
@@ -1109,6 +1220,11 @@ func (s *AccessToTargetSyncer) shouldIgnoreLinkedRole(roleName string) (bool, er
 }
 
 func (s *AccessToTargetSyncer) revokeRolesFromRole(ctx context.Context, targetExternalId string, targetApType *string, roles ...string) error {
+	err := s.checkPolicyRulesOnRoleGrants(ctx, policyActionRevoke, targetExternalId, roles)
+	if err != nil {
+		return err
+	}
+
 	toRemoveDatabaseRoles, toRemoveApplicationRoles, toRemoveAccountRoles := s.splitRoles(roles)
 
 	var filteredAccountRoles []string
@@ -1124,6 +1240,11 @@ func (s *AccessToTargetSyncer) revokeRolesFromRole(ctx context.Context, targetEx
 		}
 	}
 
+	err = s.unmanagedRoles.checkRoles("revoke from", append(slices.Clone(filteredAccountRoles), toRemoveDatabaseRoles...)...)
+	if err != nil {
+		return err
+	}
+
 	switch {
 	case isDatabaseRole(targetApType):
 		err := s.revokeRolesFromDatabaseRole(ctx, targetExternalId, toRemoveDatabaseRoles, filteredAccountRoles)
@@ -1204,33 +1325,33 @@ func (s *AccessToTargetSyncer) handleRolesToNamespacedRoles(ctx context.Context,
 	return nil
 }
 
-func (s *AccessToTargetSyncer) createRole(externalId string, apType *string) error {
+func (s *AccessToTargetSyncer) createRole(ctx context.Context, externalId string, apType *string) error {
 	if isDatabaseRole(apType) {
 		database, cleanedRoleName, err := parseDatabaseRoleExternalId(externalId)
 		if err != nil {
 			return err
 		}
 
-		return s.repo.CreateDatabaseRole(database, cleanedRoleName)
+		return s.repo.CreateDatabaseRole(ctx, database, cleanedRoleName)
 	}
 
-	return s.repo.CreateAccountRole(externalId)
+	return s.repo.CreateAccountRole(ctx, externalId)
 }
 
-func (s *AccessToTargetSyncer) dropRole(externalId string, databaseRole bool) error {
+func (s *AccessToTargetSyncer) dropRole(ctx context.Context, externalId string, databaseRole bool) error {
 	if databaseRole {
 		database, cleanedRoleName, err := parseDatabaseRoleExternalId(externalId)
 		if err != nil {
 			return err
 		}
 
-		return s.repo.DropDatabaseRole(database, cleanedRoleName)
+		return s.repo.DropDatabaseRole(ctx, database, cleanedRoleName)
 	}
 
-	return s.repo.DropAccountRole(externalId)
+	return s.repo.DropAccountRole(ctx, externalId)
 }
 
-func (s *AccessToTargetSyncer) renameRole(oldName, newName string, apType *string) error {
+func (s *AccessToTargetSyncer) renameRole(ctx context.Context, oldName, newName string, apType *string) error {
 	if isDatabaseRole(apType) {
 		if !isDatabaseRoleByExternalId(newName) || !isDatabaseRoleByExternalId(oldName) {
 			return fmt.Errorf("both roles should be a database role newName:%q - oldName:%q", newName, oldName)
@@ -1250,23 +1371,23 @@ func (s *AccessToTargetSyncer) renameRole(oldName, newName string, apType *strin
 			return fmt.Errorf("expected new roleName %q pointing to the same database as old roleName %q", newName, oldName)
 		}
 
-		return s.repo.RenameDatabaseRole(oldDatabase, oldRoleName, newRoleName)
+		return s.repo.RenameDatabaseRole(ctx, oldDatabase, oldRoleName, newRoleName)
 	}
 
-	return s.repo.RenameAccountRole(oldName, newName)
+	return s.repo.RenameAccountRole(ctx, oldName, newName)
 }
 
-func (s *AccessToTargetSyncer) commentOnRoleIfExists(comment, roleName string) error {
+func (s *AccessToTargetSyncer) commentOnRoleIfExists(ctx context.Context, comment, roleName string) error {
 	if isDatabaseRoleByExternalId(roleName) {
 		database, cleanedRoleName, err := parseDatabaseRoleExternalId(roleName)
 		if err != nil {
 			return err
 		}
 
-		return s.repo.CommentDatabaseRoleIfExists(comment, database, cleanedRoleName)
+		return s.repo.CommentDatabaseRoleIfExists(ctx, comment, database, cleanedRoleName)
 	}
 
-	return s.repo.CommentAccountRoleIfExists(comment, roleName)
+	return s.repo.CommentAccountRoleIfExists(ctx, comment, roleName)
 }
 
 func (s *AccessToTargetSyncer) generateAccessControls(ctx context.Context, toProcessAps map[string]*importer.AccessProvider, existingRoles set.Set[string], toRenameAps map[string]string) error {
@@ -1308,7 +1429,7 @@ func (s *AccessToTargetSyncer) handleAccessProviderFeedback(fi *importer.AccessP
 	return s.accessProviderFeedbackHandler.AddAccessProviderFeedback(*fi)
 }
 
-func (s *AccessToTargetSyncer) updateShare(share *importer.AccessProvider, metaData map[string]map[string]struct{}) (string, error) {
+func (s *AccessToTargetSyncer) updateShare(ctx context.Context, share *importer.AccessProvider, metaData map[string]map[string]struct{}) (string, error) {
 	Logger.Info(fmt.Sprintf("Updating share %q", share.Name))
 
 	databases := set.NewSet[string]()
@@ -1324,7 +1445,12 @@ func (s *AccessToTargetSyncer) updateShare(share *importer.AccessProvider, metaD
 		databases.Add(database)
 	}
 
-	err := s.repo.CreateShare(shareName)
+	err := s.captureShare(ctx, shareName)
+	if err != nil {
+		return shareName, err
+	}
+
+	err = s.repo.CreateShare(ctx, shareName)
 	if err != nil {
 		return shareName, fmt.Errorf("upsert share: %w", err)
 	}
@@ -1332,30 +1458,15 @@ func (s *AccessToTargetSyncer) updateShare(share *importer.AccessProvider, metaD
 	var foundGrants []Grant
 
 	if share.ExternalId != nil {
-		existingsGrants, err2 := s.repo.GetGrantsToShare(shareName)
+		existingsGrants, err2 := s.repo.GetGrantsToShare(ctx, shareName)
 		if err2 != nil {
 			return shareName, fmt.Errorf("get grants to share: %w", err2)
 		}
 
-		foundGrants = make([]Grant, 0, len(existingsGrants))
-
-		for _, grant := range existingsGrants {
-			if strings.EqualFold(grant.Privilege, "OWNERSHIP") {
-				Logger.Info(fmt.Sprintf("Ignoring permission %q on %q for Share %q as this will remain untouched", grant.Privilege, grant.Name, share.Name))
-			} else {
-				onType := convertSnowflakeGrantTypeToRaito(grant.GrantedOn)
-				name := grant.Name
-
-				if onType == Function { // For functions we need to do a special conversion
-					name = s.accessSyncer.getFullNameFromGrant(name, onType)
-				}
-
-				foundGrants = append(foundGrants, Grant{grant.Privilege, onType, name})
-			}
-		}
+		foundGrants = s.convertGrantsToShare(ctx, shareName, existingsGrants)
 	}
 
-	grants, err := s.createGrantsForWhatObjects(share, s.buildMetaDataMap())
+	grants, err := s.createGrantsForWhatObjects(ctx, share, shareName, s.buildMetaDataMap())
 	if err != nil {
 		return "", fmt.Errorf("create grants for what objects: %w", err)
 	}
@@ -1363,9 +1474,14 @@ func (s *AccessToTargetSyncer) updateShare(share *importer.AccessProvider, metaD
 	grantsToAdd := slice.SliceDifference(grants.Slice(), foundGrants)
 	grantsToRemove := slice.SliceDifference(foundGrants, grants.Slice())
 
+	err = s.checkPolicyRulesOnGrants(ctx, policyActionRevoke, shareName, grantsToRemove)
+	if err != nil {
+		return shareName, err
+	}
+
 	for _, grant := range grantsToAdd {
 		if verifyGrant(grant, metaData) {
-			err = s.repo.ExecuteGrantOnShare(grant.Permissions, grant.OnWithType(), shareName)
+			err = s.repo.ExecuteGrantOnShare(ctx, grant.Permissions, grant.OnWithType(), shareName)
 			if err != nil {
 				return shareName, fmt.Errorf("execute grant on share: %w", err)
 			}
@@ -1374,7 +1490,7 @@ func (s *AccessToTargetSyncer) updateShare(share *importer.AccessProvider, metaD
 
 	for _, grant := range grantsToRemove {
 		if verifyGrant(grant, metaData) {
-			err = s.repo.ExecuteRevokeOnShare(grant.Permissions, grant.OnWithType(), shareName)
+			err = s.repo.ExecuteRevokeOnShare(ctx, grant.Permissions, grant.OnWithType(), shareName)
 			if err != nil {
 				return shareName, fmt.Errorf("execute revoke on share: %w", err)
 			}
@@ -1382,7 +1498,9 @@ func (s *AccessToTargetSyncer) updateShare(share *importer.AccessProvider, metaD
 	}
 
 	if grants.Size() > 0 {
-		err = s.repo.SetShareAccounts(shareName, share.Who.Recipients)
+		s.blastRadius.observeShareAccounts(shareName, share.Who.Recipients)
+
+		err = s.repo.SetShareAccounts(ctx, shareName, share.Who.Recipients)
 		if err != nil {
 			return shareName, fmt.Errorf("set share accounts: %w", err)
 		}
@@ -1393,12 +1511,41 @@ func (s *AccessToTargetSyncer) updateShare(share *importer.AccessProvider, metaD
 	return shareName, nil
 }
 
-func (s *AccessToTargetSyncer) removeShare(shareId string) error {
+// convertGrantsToShare converts the grants to a share in Snowflake to the grants Raito manages. Ownership grants are left out as these remain untouched.
+func (s *AccessToTargetSyncer) convertGrantsToShare(ctx context.Context, shareName string, grantsToShare []GrantToRole) []Grant {
+	foundGrants := make([]Grant, 0, len(grantsToShare))
+
+	for _, grant := range grantsToShare {
+		if strings.EqualFold(grant.Privilege, "OWNERSHIP") {
+			Logger.Info(fmt.Sprintf("Ignoring permission %q on %q for Share %q as this will remain untouched", grant.Privilege, grant.Name, shareName))
+		} else {
+			onType := convertSnowflakeGrantTypeToRaito(grant.GrantedOn)
+			name := grant.Name
+
+			if onType == Function { // For functions we need to do a special conversion
+				name = s.accessSyncer.getFullNameFromGrant(ctx, name, onType)
+			}
+
+			foundGrants = append(foundGrants, Grant{grant.Privilege, onType, name})
+		}
+	}
+
+	return foundGrants
+}
+
+func (s *AccessToTargetSyncer) removeShare(ctx context.Context, shareId string) error {
 	Logger.Info(fmt.Sprintf("Remove share %q", shareId))
 
 	shareName := strings.TrimPrefix(shareId, maskPrefix)
 
-	err := s.repo.DropShare(shareName)
+	s.blastRadius.observeShareAccounts(shareName, nil)
+
+	err := s.captureShare(ctx, shareName)
+	if err != nil {
+		return err
+	}
+
+	err = s.repo.DropShare(ctx, shareName)
 	if err != nil {
 		return fmt.Errorf("drop share: %w", err)
 	}
@@ -1406,11 +1553,10 @@ func (s *AccessToTargetSyncer) removeShare(shareId string) error {
 	return nil
 }
 
-func (s *AccessToTargetSyncer) updateMask(mask *importer.AccessProvider, roleNameMap map[string]string) (string, error) {
+func (s *AccessToTargetSyncer) updateMask(ctx context.Context, mask *importer.AccessProvider, roleNameMap map[string]string) (string, error) {
 	Logger.Info(fmt.Sprintf("Updating mask %q", mask.Name))
 
 	globalMaskName := raitoMaskName(mask.Name)
-	uniqueMaskName := raitoMaskUniqueName(mask.Name)
 
 	// Step 0: Load beneficieries
 	beneficiaries := MaskingBeneficiaries{
@@ -1446,32 +1592,57 @@ func (s *AccessToTargetSyncer) updateMask(mask *importer.AccessProvider, roleNam
 		dosPerSchema[schemaFullName] = append(dosPerSchema[schemaFullName], do.DataObject.FullName)
 	}
 
-	// Step 1: Get existing masking policies with same prefix
-	existingPolicies, err := s.repo.GetPoliciesLike("MASKING", fmt.Sprintf("%s%s", globalMaskName, "%"))
+	uniqueMaskName := raitoMaskUniqueName(mask.Name, maskDefinition(mask.Type, &beneficiaries, dosPerSchema)...)
+
+	// Step 1: Get existing masking policies with same prefix. Policies with the unique name of this definition are up-to-date and are kept.
+	existingPolicies, err := s.repo.GetPoliciesLike(ctx, "MASKING", fmt.Sprintf("%s%s", globalMaskName, "%"))
 	if err != nil {
 		return uniqueMaskName, err
 	}
 
+	upToDateSchemas := set.NewSet[string]()
+	outdatedPolicies := make([]PolicyEntity, 0, len(existingPolicies))
+
+	for _, policy := range existingPolicies {
+		if strings.EqualFold(uniqueMaskNameOfPolicy(policy.Name), uniqueMaskName) {
+			upToDateSchemas.Add(policy.DatabaseName + "." + policy.SchemaName)
+		} else {
+			outdatedPolicies = append(outdatedPolicies, policy)
+		}
+	}
+
+	for _, policy := range outdatedPolicies {
+		err = s.capturePolicy(ctx, maskingPolicyKind, policy.DatabaseName, policy.SchemaName, policy.Name)
+		if err != nil {
+			return uniqueMaskName, err
+		}
+	}
+
 	// Step 2: For each schema create a new masking policy and force the DataObjects to use the new policy
 	for schema, dos := range dosPerSchema {
+		if upToDateSchemas.Contains(schema) {
+			Logger.Info(fmt.Sprintf("Mask %q is up-to-date for schema %q", mask.Name, schema))
+
+			continue
+		}
+
 		Logger.Info(fmt.Sprintf("Updating mask %q for schema %q", mask.Name, schema))
 		namesplit := strings.Split(schema, ".")
 
 		database := namesplit[0]
 		schemaName := namesplit[1]
 
-		err = s.repo.CreateMaskPolicy(database, schemaName, uniqueMaskName, dos, mask.Type, &beneficiaries)
+		s.captureCreatedPolicy(maskingPolicyKind, database, schemaName, "", uniqueMaskName)
+
+		err = s.repo.CreateMaskPolicy(ctx, database, schemaName, uniqueMaskName, dos, mask.Type, &beneficiaries)
 		if err != nil {
 			return uniqueMaskName, err
 		}
 	}
 
 	// Step 3: Remove old policies that we misted in step 1
-	for _, policy := range existingPolicies {
-		existingUniqueMaskNameSpit := strings.Split(policy.Name, "_")
-		existingUniqueMaskName := strings.Join(existingUniqueMaskNameSpit[:len(existingUniqueMaskNameSpit)-1], "_")
-
-		err = s.repo.DropMaskingPolicy(policy.DatabaseName, policy.SchemaName, existingUniqueMaskName)
+	for _, policy := range outdatedPolicies {
+		err = s.repo.DropMaskingPolicy(ctx, policy.DatabaseName, policy.SchemaName, uniqueMaskNameOfPolicy(policy.Name))
 		if err != nil {
 			return uniqueMaskName, err
 		}
@@ -1480,16 +1651,53 @@ func (s *AccessToTargetSyncer) updateMask(mask *importer.AccessProvider, roleNam
 	return uniqueMaskName, nil
 }
 
-func (s *AccessToTargetSyncer) removeMask(maskName string) error {
+// maskDefinition lists everything that determines the masking policies of a mask, to derive their unique name from
+func maskDefinition(maskType *string, beneficiaries *MaskingBeneficiaries, dosPerSchema map[string][]string) []string {
+	definition := []string{ptr.ToString(maskType)}
+
+	users := slices.Clone(beneficiaries.Users)
+	slices.Sort(users)
+
+	roles := slices.Clone(beneficiaries.Roles)
+	slices.Sort(roles)
+
+	definition = append(definition, "users:"+strings.Join(users, ","), "roles:"+strings.Join(roles, ","))
+
+	var dos []string
+
+	for _, schemaDos := range dosPerSchema {
+		dos = append(dos, schemaDos...)
+	}
+
+	slices.Sort(dos)
+
+	return append(definition, dos...)
+}
+
+// uniqueMaskNameOfPolicy strips the data type suffix from the name of a masking policy created for a mask
+func uniqueMaskNameOfPolicy(policyName string) string {
+	nameParts := strings.Split(policyName, "_")
+
+	return strings.Join(nameParts[:len(nameParts)-1], "_")
+}
+
+func (s *AccessToTargetSyncer) removeMask(ctx context.Context, maskName string) error {
 	Logger.Info(fmt.Sprintf("Remove mask %q", maskName))
 
-	existingPolicies, err := s.repo.GetPoliciesLike("MASKING", fmt.Sprintf("%s%s", maskName, "%"))
+	existingPolicies, err := s.repo.GetPoliciesLike(ctx, "MASKING", fmt.Sprintf("%s%s", maskName, "%"))
 	if err != nil {
 		return err
 	}
 
 	for _, policy := range existingPolicies {
-		err = s.repo.DropMaskingPolicy(policy.DatabaseName, policy.SchemaName, maskName)
+		err = s.capturePolicy(ctx, maskingPolicyKind, policy.DatabaseName, policy.SchemaName, policy.Name)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, policy := range existingPolicies {
+		err = s.repo.DropMaskingPolicy(ctx, policy.DatabaseName, policy.SchemaName, maskName)
 		if err != nil {
 			return err
 		}
@@ -1525,6 +1733,38 @@ func (s *AccessToTargetSyncer) createGrantsForTableOrView(doType string, permiss
 	return nil
 }
 
+// createGrantsForSchemaObject creates the grants for an object of one of the schemaObjectTypes (e.g. a stage)
+func (s *AccessToTargetSyncer) createGrantsForSchemaObject(doType string, permissions []string, fullName string, metaData map[string]map[string]struct{}, grants *GrantSet) error {
+	sfObject := common.ParseFullName(fullName)
+	if sfObject.Database == nil || sfObject.Schema == nil || sfObject.Table == nil {
+		return fmt.Errorf("expected fullName %q to have 3 parts (database.schema.%s)", fullName, doType)
+	}
+
+	objectName := common.FormatQuery(`%s.%s.%s`, *sfObject.Database, *sfObject.Schema, *sfObject.Table)
+
+	for _, p := range permissions {
+		if _, f := metaData[doType][strings.ToUpper(p)]; !f {
+			Logger.Warn(fmt.Sprintf("Permission %q does not apply to type %s", p, strings.ToUpper(doType)))
+
+			continue
+		}
+
+		// Snowflake only allows granting WRITE on a stage to roles that can also READ it
+		if doType == Stage && strings.EqualFold(p, "WRITE") {
+			grants.Add(Grant{"READ", Stage, objectName})
+		}
+
+		grants.Add(Grant{p, doType, objectName})
+	}
+
+	if grants.Size() > 0 {
+		grants.Add(Grant{"USAGE", ds.Database, common.FormatQuery(`%s`, *sfObject.Database)},
+			Grant{"USAGE", ds.Schema, common.FormatQuery(`%s.%s`, *sfObject.Database, *sfObject.Schema)})
+	}
+
+	return nil
+}
+
 func (s *AccessToTargetSyncer) createGrantsForFunctionOrProcedure(permissions []string, fullName string, metaData map[string]map[string]struct{}, grants *GrantSet, objType string) {
 	for _, p := range permissions {
 		if _, f := metaData[objType][strings.ToUpper(p)]; f {
@@ -1544,7 +1784,7 @@ func (s *AccessToTargetSyncer) createGrantsForFunctionOrProcedure(permissions []
 	}
 }
 
-func (s *AccessToTargetSyncer) getTablesForSchema(database, schema string) ([]TableEntity, error) {
+func (s *AccessToTargetSyncer) getTablesForSchema(ctx context.Context, database, schema string) ([]TableEntity, error) {
 	cacheKey := database + "." + schema
 
 	if tables, f := s.tablesPerSchemaCache[cacheKey]; f {
@@ -1553,7 +1793,7 @@ func (s *AccessToTargetSyncer) getTablesForSchema(database, schema string) ([]Ta
 
 	tables := make([]TableEntity, 0, 10)
 
-	err := s.repo.GetTablesInDatabase(database, schema, func(entity interface{}) error {
+	err := s.repo.GetTablesInDatabase(ctx, database, schema, func(entity interface{}) error {
 		table := entity.(*TableEntity)
 		tables = append(tables, *table)
 
@@ -1569,7 +1809,7 @@ func (s *AccessToTargetSyncer) getTablesForSchema(database, schema string) ([]Ta
 	return tables, nil
 }
 
-func (s *AccessToTargetSyncer) getFunctionsForSchema(database, schema string) ([]FunctionEntity, error) {
+func (s *AccessToTargetSyncer) getFunctionsForSchema(ctx context.Context, database, schema string) ([]FunctionEntity, error) {
 	cacheKey := database + "." + schema
 
 	if functions, f := s.functionsPerSchemaCache[cacheKey]; f {
@@ -1578,7 +1818,7 @@ func (s *AccessToTargetSyncer) getFunctionsForSchema(database, schema string) ([
 
 	functions := make([]FunctionEntity, 0, 10)
 
-	err := s.repo.GetFunctionsInSchema(database, schema, func(entity any) error {
+	err := s.repo.GetFunctionsInSchema(ctx, database, schema, func(entity any) error {
 		function := entity.(*FunctionEntity)
 		if *function.Schema == schema {
 			functions = append(functions, *function)
@@ -1596,7 +1836,7 @@ func (s *AccessToTargetSyncer) getFunctionsForSchema(database, schema string) ([
 	return functions, nil
 }
 
-func (s *AccessToTargetSyncer) getProceduresForSchema(database, schema string) ([]ProcedureEntity, error) {
+func (s *AccessToTargetSyncer) getProceduresForSchema(ctx context.Context, database, schema string) ([]ProcedureEntity, error) {
 	cacheKey := database + "." + schema
 
 	if procs, f := s.proceduresPerSchemaCache[cacheKey]; f {
@@ -1605,7 +1845,7 @@ func (s *AccessToTargetSyncer) getProceduresForSchema(database, schema string) (
 
 	procs := make([]ProcedureEntity, 0, 10)
 
-	err := s.repo.GetProceduresInSchema(database, schema, func(entity any) error {
+	err := s.repo.GetProceduresInSchema(ctx, database, schema, func(entity any) error {
 		proc := entity.(*ProcedureEntity)
 		if *proc.Schema == schema {
 			procs = append(procs, *proc)
@@ -1623,14 +1863,14 @@ func (s *AccessToTargetSyncer) getProceduresForSchema(database, schema string) (
 	return procs, nil
 }
 
-func (s *AccessToTargetSyncer) getSchemasForDatabase(database string) ([]SchemaEntity, error) {
+func (s *AccessToTargetSyncer) getSchemasForDatabase(ctx context.Context, database string) ([]SchemaEntity, error) {
 	if schemas, f := s.schemasPerDataBaseCache[database]; f {
 		return schemas, nil
 	}
 
 	schemas := make([]SchemaEntity, 0, 10)
 
-	err := s.repo.GetSchemasInDatabase(database, func(entity interface{}) error {
+	err := s.repo.GetSchemasInDatabase(ctx, database, func(entity interface{}) error {
 		schema := entity.(*SchemaEntity)
 		schemas = append(schemas, *schema)
 
@@ -1646,13 +1886,13 @@ func (s *AccessToTargetSyncer) getSchemasForDatabase(database string) ([]SchemaE
 	return schemas, nil
 }
 
-func (s *AccessToTargetSyncer) getWarehouses() ([]DbEntity, error) {
+func (s *AccessToTargetSyncer) getWarehouses(ctx context.Context) ([]DbEntity, error) {
 	if s.warehousesCache != nil {
 		return s.warehousesCache, nil
 	}
 
 	var err error
-	s.warehousesCache, err = s.repo.GetWarehouses()
+	s.warehousesCache, err = s.repo.GetWarehouses(ctx)
 
 	if err != nil {
 		s.warehousesCache = nil
@@ -1662,13 +1902,13 @@ func (s *AccessToTargetSyncer) getWarehouses() ([]DbEntity, error) {
 	return s.warehousesCache, nil
 }
 
-func (s *AccessToTargetSyncer) getIntegrations() ([]DbEntity, error) {
+func (s *AccessToTargetSyncer) getIntegrations(ctx context.Context) ([]DbEntity, error) {
 	if s.integrationsCache != nil {
 		return s.integrationsCache, nil
 	}
 
 	var err error
-	s.integrationsCache, err = s.repo.GetIntegrations()
+	s.integrationsCache, err = s.repo.GetIntegrations(ctx)
 
 	if err != nil {
 		s.integrationsCache = nil
@@ -1678,7 +1918,7 @@ func (s *AccessToTargetSyncer) getIntegrations() ([]DbEntity, error) {
 	return s.integrationsCache, nil
 }
 
-func (s *AccessToTargetSyncer) createGrantsForSchema(permissions []string, fullName string, metaData map[string]map[string]struct{}, isShared bool, grants *GrantSet) error {
+func (s *AccessToTargetSyncer) createGrantsForSchema(ctx context.Context, permissions []string, fullName string, metaData map[string]map[string]struct{}, isShared bool, grants *GrantSet) error {
 	// TODO: this does not work for Raito full names
 	sfObject := common.ParseFullName(fullName)
 	if sfObject.Database == nil || sfObject.Schema == nil || sfObject.Table != nil || sfObject.Column != nil {
@@ -1690,7 +1930,7 @@ func (s *AccessToTargetSyncer) createGrantsForSchema(permissions []string, fullN
 	for _, p := range permissions {
 		permissionMatchFound := false
 
-		permissionMatchFound, err = s.createPermissionGrantsForSchema(*sfObject.Database, *sfObject.Schema, p, metaData, isShared, grants)
+		permissionMatchFound, err = s.createPermissionGrantsForSchema(ctx, *sfObject.Database, *sfObject.Schema, p, metaData, isShared, grants)
 		if err != nil {
 			return err
 		}
@@ -1710,7 +1950,7 @@ func (s *AccessToTargetSyncer) createGrantsForSchema(permissions []string, fullN
 	return nil
 }
 
-func (s *AccessToTargetSyncer) createPermissionGrantsForSchema(database, schema, p string, metaData map[string]map[string]struct{}, isShared bool, grants *GrantSet) (bool, error) {
+func (s *AccessToTargetSyncer) createPermissionGrantsForSchema(ctx context.Context, database, schema, p string, metaData map[string]map[string]struct{}, isShared bool, grants *GrantSet) (bool, error) {
 	matchFound := false
 
 	schemaType := ds.Schema
@@ -1727,7 +1967,7 @@ func (s *AccessToTargetSyncer) createPermissionGrantsForSchema(database, schema,
 		grants.Add(Grant{p, schemaType, common.FormatQuery(`%s.%s`, database, schema)})
 		matchFound = true
 	} else {
-		tables, err := s.getTablesForSchema(database, schema)
+		tables, err := s.getTablesForSchema(ctx, database, schema)
 		if err != nil {
 			return false, err
 		}
@@ -1739,7 +1979,7 @@ func (s *AccessToTargetSyncer) createPermissionGrantsForSchema(database, schema,
 			matchFound = matchFound || tableMatchFound
 		}
 
-		functions, err := s.getFunctionsForSchema(database, schema)
+		functions, err := s.getFunctionsForSchema(ctx, database, schema)
 		if err != nil {
 			return false, err
 		}
@@ -1751,7 +1991,7 @@ func (s *AccessToTargetSyncer) createPermissionGrantsForSchema(database, schema,
 			matchFound = matchFound || functionMatchFound
 		}
 
-		procedures, err := s.getProceduresForSchema(database, schema)
+		procedures, err := s.getProceduresForSchema(ctx, database, schema)
 		if err != nil {
 			return false, err
 		}
@@ -1767,7 +2007,7 @@ func (s *AccessToTargetSyncer) createPermissionGrantsForSchema(database, schema,
 	return matchFound, nil
 }
 
-func (s *AccessToTargetSyncer) createPermissionGrantsForDatabase(database, p string, metaData map[string]map[string]struct{}, isShared bool, grants *GrantSet) (bool, error) {
+func (s *AccessToTargetSyncer) createPermissionGrantsForDatabase(ctx context.Context, database, p string, metaData map[string]map[string]struct{}, isShared bool, grants *GrantSet) (bool, error) {
 	matchFound := false
 
 	dbType := ds.Database
@@ -1784,7 +2024,7 @@ func (s *AccessToTargetSyncer) createPermissionGrantsForDatabase(database, p str
 
 		grants.Add(Grant{p, dbType, database})
 	} else {
-		schemas, err := s.getSchemasForDatabase(database)
+		schemas, err := s.getSchemasForDatabase(ctx, database)
 		if err != nil {
 			return false, err
 		}
@@ -1796,7 +2036,7 @@ func (s *AccessToTargetSyncer) createPermissionGrantsForDatabase(database, p str
 
 			schemaMatchFound := false
 
-			schemaMatchFound, err = s.createPermissionGrantsForSchema(database, schema.Name, p, metaData, isShared, grants)
+			schemaMatchFound, err = s.createPermissionGrantsForSchema(ctx, database, schema.Name, p, metaData, isShared, grants)
 			if err != nil {
 				return matchFound, err
 			}
@@ -1842,12 +2082,12 @@ func (s *AccessToTargetSyncer) createPermissionGrantsForFunctionOrProcedure(data
 	return false
 }
 
-func (s *AccessToTargetSyncer) createGrantsForDatabase(permissions []string, database string, metaData map[string]map[string]struct{}, isShared bool, grants *GrantSet) error {
+func (s *AccessToTargetSyncer) createGrantsForDatabase(ctx context.Context, permissions []string, database string, metaData map[string]map[string]struct{}, isShared bool, grants *GrantSet) error {
 	var err error
 
 	for _, p := range permissions {
 		databaseMatchFound := false
-		databaseMatchFound, err = s.createPermissionGrantsForDatabase(database, p, metaData, isShared, grants)
+		databaseMatchFound, err = s.createPermissionGrantsForDatabase(ctx, database, p, metaData, isShared, grants)
 
 		if err != nil {
 			return err
@@ -1878,6 +2118,17 @@ func (s *AccessToTargetSyncer) createGrantsForWarehouse(permissions []string, wa
 	}
 }
 
+func (s *AccessToTargetSyncer) createGrantsForComputePool(permissions []string, computePool string, metaData map[string]map[string]struct{}, grants *GrantSet) {
+	for _, p := range permissions {
+		if _, f := metaData[ComputePool][strings.ToUpper(p)]; !f {
+			Logger.Warn(fmt.Sprintf("Permission %q does not apply to type COMPUTE POOL. Skipping", p))
+			continue
+		}
+
+		grants.Add(Grant{p, ComputePool, common.FormatQuery(`%s`, computePool)})
+	}
+}
+
 func (s *AccessToTargetSyncer) createGrantsForIntegration(permissions []string, warehouse string, metaData map[string]map[string]struct{}, grants *GrantSet) {
 	for _, p := range permissions {
 		if _, f := metaData[Integration][strings.ToUpper(p)]; !f {
@@ -1889,7 +2140,7 @@ func (s *AccessToTargetSyncer) createGrantsForIntegration(permissions []string,
 	}
 }
 
-func (s *AccessToTargetSyncer) createGrantsForAccount(permissions []string, metaData map[string]map[string]struct{}, grants *GrantSet) error {
+func (s *AccessToTargetSyncer) createGrantsForAccount(ctx context.Context, permissions []string, metaData map[string]map[string]struct{}, grants *GrantSet) error {
 	for _, p := range permissions {
 		matchFound := false
 
@@ -1900,7 +2151,7 @@ func (s *AccessToTargetSyncer) createGrantsForAccount(permissions []string, meta
 			if _, f2 := metaData["warehouse"][strings.ToUpper(p)]; f2 {
 				matchFound = true
 
-				warehouses, err := s.getWarehouses()
+				warehouses, err := s.getWarehouses(ctx)
 				if err != nil {
 					return err
 				}
@@ -1913,7 +2164,7 @@ func (s *AccessToTargetSyncer) createGrantsForAccount(permissions []string, meta
 			if _, f2 := metaData[Integration][strings.ToUpper(p)]; f2 {
 				matchFound = true
 
-				integrations, err := s.getIntegrations()
+				integrations, err := s.getIntegrations(ctx)
 				if err != nil {
 					return err
 				}
@@ -1923,12 +2174,12 @@ func (s *AccessToTargetSyncer) createGrantsForAccount(permissions []string, meta
 				}
 			}
 
-			inboundShareNames, err := s.accessSyncer.getInboundShareNames()
+			inboundShareNames, err := s.accessSyncer.getInboundShareNames(ctx)
 			if err != nil {
 				return err
 			}
 
-			databaseNames, err := s.accessSyncer.getDatabaseNames()
+			databaseNames, err := s.accessSyncer.getDatabaseNames(ctx)
 			if err != nil {
 				return err
 			}
@@ -1940,7 +2191,7 @@ func (s *AccessToTargetSyncer) createGrantsForAccount(permissions []string, meta
 
 				isShare := slices.Contains(inboundShareNames, database)
 
-				databaseMatchFound, err = s.createPermissionGrantsForDatabase(database, p, metaData, isShare, grants)
+				databaseMatchFound, err = s.createPermissionGrantsForDatabase(ctx, database, p, metaData, isShare, grants)
 				if err != nil {
 					return err
 				}
@@ -2000,9 +2251,25 @@ func (s *AccessToTargetSyncer) updateOrCreateFilter(ctx context.Context, tableFu
 		filterExpressions = append(filterExpressions, "FALSE")
 	}
 
-	filterName := fmt.Sprintf("raito_%s_%s_%s_filter", schema, table, gonanoid.MustGenerate(idAlphabet, 8))
+	expression := strings.Join(filterExpressions, " OR ")
+
+	argumentNames := arguments.Slice()
+	slices.Sort(argumentNames)
+
+	filterName := fmt.Sprintf("raito_%s_%s_%s_filter", schema, table, definitionHash(append([]string{expression}, argumentNames...)...))
 
-	err := s.repo.UpdateFilter(database, schema, table, filterName, arguments.Slice(), strings.Join(filterExpressions, " OR "))
+	for _, ap := range aps {
+		if ap.ExternalId != nil {
+			err := s.capturePolicy(ctx, rowAccessPolicyKind, database, schema, strings.Split(*ap.ExternalId, ".")[3])
+			if err != nil {
+				return "", nil, err
+			}
+		}
+	}
+
+	s.captureCreatedPolicy(rowAccessPolicyKind, database, schema, table, filterName)
+
+	err := s.repo.UpdateFilter(ctx, database, schema, table, filterName, argumentNames, expression)
 	if err != nil {
 		return "", nil, fmt.Errorf("failed to update filter %s: %w", filterName, err)
 	}
@@ -2010,7 +2277,7 @@ func (s *AccessToTargetSyncer) updateOrCreateFilter(ctx context.Context, tableFu
 	return filterName, ptr.String(fmt.Sprintf("%s.%s", tableFullName, filterName)), nil
 }
 
-func (s *AccessToTargetSyncer) deleteFilter(tableFullName string, aps []*importer.AccessProvider) error {
+func (s *AccessToTargetSyncer) deleteFilter(ctx context.Context, tableFullName string, aps []*importer.AccessProvider) error {
 	tableFullnameSplit := strings.Split(tableFullName, ".")
 	database := tableFullnameSplit[0]
 	schema := tableFullnameSplit[1]
@@ -2028,7 +2295,12 @@ func (s *AccessToTargetSyncer) deleteFilter(tableFullName string, aps []*importe
 	var err error
 
 	for filterName := range filterNames {
-		deleteErr := s.repo.DropFilter(database, schema, table, filterName)
+		deleteErr := s.capturePolicy(ctx, rowAccessPolicyKind, database, schema, filterName)
+		if deleteErr != nil {
+			return deleteErr
+		}
+
+		deleteErr = s.repo.DropFilter(ctx, database, schema, table, filterName)
 		if deleteErr != nil {
 			err = multierror.Append(err, fmt.Errorf("failed to delete filter %s: %w", filterName, deleteErr))
 		}
@@ -2041,41 +2313,46 @@ func (s *AccessToTargetSyncer) deleteFilter(tableFullName string, aps []*importe
 	return nil
 }
 
-func (s *AccessToTargetSyncer) executeGrantOnRole(perm, on, roleName string, apType *string) error {
+func (s *AccessToTargetSyncer) executeGrantOnRole(ctx context.Context, perm, on, roleName string, apType *string) error {
 	if isDatabaseRole(apType) {
 		database, parsedRoleName, err := parseDatabaseRoleExternalId(roleName)
 		if err != nil {
 			return err
 		}
 
-		return s.repo.ExecuteGrantOnDatabaseRole(perm, on, database, parsedRoleName)
+		return s.repo.ExecuteGrantOnDatabaseRole(ctx, perm, on, database, parsedRoleName)
 	}
 
-	return s.repo.ExecuteGrantOnAccountRole(perm, on, roleName, false)
+	return s.repo.ExecuteGrantOnAccountRole(ctx, perm, on, roleName, false)
 }
 
-func (s *AccessToTargetSyncer) executeRevokeOnRole(perm, on, roleName string, apType *string) error {
+func (s *AccessToTargetSyncer) executeRevokeOnRole(ctx context.Context, perm, on, roleName string, apType *string) error {
 	if isDatabaseRole(apType) {
 		database, parsedRoleName, err := parseDatabaseRoleExternalId(roleName)
 		if err != nil {
 			return err
 		}
 
-		return s.repo.ExecuteRevokeOnDatabaseRole(perm, on, database, parsedRoleName)
+		return s.repo.ExecuteRevokeOnDatabaseRole(ctx, perm, on, database, parsedRoleName)
 	}
 
-	return s.repo.ExecuteRevokeOnAccountRole(perm, on, roleName, false)
+	return s.repo.ExecuteRevokeOnAccountRole(ctx, perm, on, roleName, false)
 }
 
-func (s *AccessToTargetSyncer) mergeGrants(externalId string, apType *string, found []Grant, expected []Grant, metaData map[string]map[string]struct{}) error {
+func (s *AccessToTargetSyncer) mergeGrants(ctx context.Context, externalId string, apType *string, found []Grant, expected []Grant, metaData map[string]map[string]struct{}) error {
 	toAdd := slice.SliceDifference(expected, found)
 	toRemove := slice.SliceDifference(found, expected)
 
 	Logger.Info(fmt.Sprintf("Found %d grants to add and %d grants to remove for role %q", len(toAdd), len(toRemove), externalId))
 
+	err := s.checkPolicyRulesOnGrants(ctx, policyActionRevoke, externalId, toRemove)
+	if err != nil {
+		return err
+	}
+
 	for _, grant := range toAdd {
 		if verifyGrant(grant, metaData) {
-			err := s.executeGrantOnRole(grant.Permissions, grant.OnWithType(), externalId, apType)
+			err := s.executeGrantOnRole(ctx, grant.Permissions, grant.OnWithType(), externalId, apType)
 			if err != nil {
 				return err
 			}
@@ -2084,7 +2361,7 @@ func (s *AccessToTargetSyncer) mergeGrants(externalId string, apType *string, fo
 
 	for _, grant := range toRemove {
 		if verifyGrant(grant, metaData) {
-			err := s.executeRevokeOnRole(grant.Permissions, grant.OnWithType(), externalId, apType)
+			err := s.executeRevokeOnRole(ctx, grant.Permissions, grant.OnWithType(), externalId, apType)
 			if err != nil {
 				return err
 			}
@@ -2094,6 +2371,90 @@ func (s *AccessToTargetSyncer) mergeGrants(externalId string, apType *string, fo
 	return nil
 }
 
+// splitGrantsOfRole splits the grants of a role in the users and the external ids of the roles and shares the role is granted to
+func splitGrantsOfRole(grantsOfRole []GrantOfRole) ([]string, []string, error) {
+	usersOfRole := make([]string, 0, len(grantsOfRole))
+	rolesOfRole := make([]string, 0, len(grantsOfRole))
+
+	for _, gor := range grantsOfRole {
+		switch {
+		case strings.EqualFold(gor.GrantedTo, "USER"):
+			usersOfRole = append(usersOfRole, cleanDoubleQuotes(gor.GranteeName))
+		case strings.EqualFold(gor.GrantedTo, "ROLE"):
+			rolesOfRole = append(rolesOfRole, accountRoleExternalIdGenerator(cleanDoubleQuotes(gor.GranteeName)))
+		case strings.EqualFold(gor.GrantedTo, GrantTypeDatabaseRole):
+			database, parsedRoleName, err := parseNamespacedRoleRoleName(cleanDoubleQuotes(gor.GranteeName))
+			if err != nil {
+				return nil, nil, err
+			}
+			rolesOfRole = append(rolesOfRole, databaseRoleExternalIdGenerator(database, parsedRoleName))
+		case strings.EqualFold(gor.GrantedTo, "SHARE"):
+			rolesOfRole = append(rolesOfRole, shareExternalIdGenerator(gor.GranteeName))
+		case strings.EqualFold(gor.GrantedTo, GrantTypeApplicationRole):
+			application, parsedRoleName, err := parseNamespacedRoleRoleName(cleanDoubleQuotes(gor.GranteeName))
+			if err != nil {
+				return nil, nil, err
+			}
+
+			rolesOfRole = append(rolesOfRole, applicationRoleExternalIdGenerator(application, parsedRoleName))
+		}
+	}
+
+	return usersOfRole, rolesOfRole, nil
+}
+
+// convertGrantsToRole converts the grants to a role in Snowflake to the grants Raito manages.
+// Ownership and role usage grants are left out as these remain untouched.
+func (s *AccessToTargetSyncer) convertGrantsToRole(ctx context.Context, externalId string, grantsToRole []GrantToRole) ([]Grant, error) {
+	foundGrants := make([]Grant, 0, len(grantsToRole))
+
+	importedDbs := make(map[string]DbEntity)
+	importedDbsFetched := false
+
+	for _, grant := range grantsToRole {
+		if strings.EqualFold(grant.GrantedOn, "ACCOUNT") {
+			foundGrants = append(foundGrants, Grant{grant.Privilege, "account", ""})
+		} else if strings.EqualFold(grant.Privilege, "OWNERSHIP") {
+			Logger.Info(fmt.Sprintf("Ignoring permission %q on %q for Role %q as this will remain untouched", grant.Privilege, grant.Name, externalId))
+		} else if strings.EqualFold(grant.Privilege, "USAGE") && (strings.EqualFold(grant.GrantedOn, "ROLE") || strings.EqualFold(grant.GrantedOn, GrantTypeDatabaseRole)) {
+			Logger.Debug(fmt.Sprintf("Ignoring USAGE permission on %s %q", grant.GrantedOn, grant.Name))
+		} else {
+			onType := convertSnowflakeGrantTypeToRaito(grant.GrantedOn)
+
+			// Snowflake reports Privilege="USAGE" and GrantedOn="DATABASE" for IMPORTED PRIVILEGES on shared databases.
+			if strings.EqualFold(grant.Privilege, "USAGE") && strings.EqualFold(grant.GrantedOn, "DATABASE") {
+				if !importedDbsFetched {
+					dbs, dgsErr := s.repo.GetDatabasesByKind(ctx, "IMPORTED DATABASE")
+					if dgsErr != nil {
+						return nil, fmt.Errorf("error while retrieving databases: %s", dgsErr.Error())
+					}
+
+					for _, db := range dbs {
+						importedDbs[db.Name] = db
+					}
+
+					importedDbsFetched = true
+				}
+
+				if _, found := importedDbs[grant.Name]; found {
+					onType = SharedPrefix + ds.Database
+					grant.Privilege = "IMPORTED PRIVILEGES"
+				}
+			}
+
+			name := grant.Name
+
+			if strings.EqualFold(onType, Function) || strings.EqualFold(onType, Procedure) { // For functions and stored procedures we need to do a special conversion
+				name = s.accessSyncer.getFullNameFromGrant(ctx, name, onType)
+			}
+
+			foundGrants = append(foundGrants, Grant{grant.Privilege, onType, name})
+		}
+	}
+
+	return foundGrants, nil
+}
+
 func filterExpression(ctx context.Context, ap *importer.AccessProvider) (string, []string, error) {
 	if ap.FilterCriteria != nil {
 		filterQueryBuilder := NewFilterCriteriaBuilder()
@@ -2235,6 +2596,7 @@ func raitoMaskName(roleName string) string {
 	return string(validMaskName)
 }
 
-func raitoMaskUniqueName(name string) string {
-	return raitoMaskName(name) + "_" + gonanoid.MustGenerate(idAlphabet, 8)
+// raitoMaskUniqueName derives the name of the masking policies of a mask from its name and definition
+func raitoMaskUniqueName(name string, definition ...string) string {
+	return raitoMaskName(name) + "_" + definitionHash(definition...)
 }
@@ -38,34 +38,34 @@ func TestAccessSyncer_SyncAccessProviderRolesToTarget(t *testing.T) {
 	repoMock := newMockDataAccessRepository(t)
 	feedbackHandler := mocks.NewSimpleAccessProviderFeedbackHandler(t)
 
-	repoMock.EXPECT().GetApplications().Return(nil, nil)
+	repoMock.EXPECT().GetApplications(mock.Anything).Return(nil, nil)
 
-	repoMock.EXPECT().DropAccountRole("ToRemove1").Return(nil).Once()
-	repoMock.EXPECT().DropAccountRole("ToRemove2").Return(nil).Once()
-	repoMock.EXPECT().DropDatabaseRole("TEST_DB", "ToRemoveDatabaseRole1").Return(nil).Once()
+	repoMock.EXPECT().DropAccountRole(mock.Anything, "ToRemove1").Return(nil).Once()
+	repoMock.EXPECT().DropAccountRole(mock.Anything, "ToRemove2").Return(nil).Once()
+	repoMock.EXPECT().DropDatabaseRole(mock.Anything, "TEST_DB", "ToRemoveDatabaseRole1").Return(nil).Once()
 
-	repoMock.EXPECT().GetAccountRolesWithPrefix("").Return([]RoleEntity{
+	repoMock.EXPECT().GetAccountRolesWithPrefix(mock.Anything, "").Return([]RoleEntity{
 		{Name: "ExistingRole1", GrantedToRoles: 2, GrantedRoles: 3, AssignedToUsers: 2, Owner: "Owner"},
 		{Name: "ExistingRole2", GrantedToRoles: 2, GrantedRoles: 3, AssignedToUsers: 2, Owner: "Owner"},
 	}, nil).Once()
-	repoMock.EXPECT().GetInboundShares().Return([]DbEntity{}, nil).Once()
-	repoMock.EXPECT().GetDatabases().Return([]DbEntity{
+	repoMock.EXPECT().GetInboundShares(mock.Anything).Return([]DbEntity{}, nil).Once()
+	repoMock.EXPECT().GetDatabases(mock.Anything).Return([]DbEntity{
 		{Name: "SNOWFLAKE"},
 		{Name: "TEST_DB"},
 	}, nil).Once()
-	repoMock.EXPECT().GetDatabaseRolesWithPrefix("SNOWFLAKE", "").Return([]RoleEntity{}, nil).Once()
-	repoMock.EXPECT().GetDatabaseRolesWithPrefix("TEST_DB", "").Return([]RoleEntity{
+	repoMock.EXPECT().GetDatabaseRolesWithPrefix(mock.Anything, "SNOWFLAKE", "").Return([]RoleEntity{}, nil).Once()
+	repoMock.EXPECT().GetDatabaseRolesWithPrefix(mock.Anything, "TEST_DB", "").Return([]RoleEntity{
 		{Name: "DatabaseRole1", AssignedToUsers: 0, GrantedRoles: 0, GrantedToRoles: 1, Owner: "Owner"},
 	}, nil).Once()
 
-	repoMock.EXPECT().CreateAccountRole("RoleName1").Return(nil).Once()
-	repoMock.EXPECT().CreateAccountRole("RoleName3").Return(nil).Once()
-	repoMock.EXPECT().CreateDatabaseRole("TEST_DB", "DatabaseRole2").Return(nil).Once()
-	repoMock.EXPECT().CommentAccountRoleIfExists(mock.Anything, "RoleName1").Return(nil).Once()
-	repoMock.EXPECT().CommentAccountRoleIfExists(mock.Anything, "RoleName3").Return(nil).Once()
+	repoMock.EXPECT().CreateAccountRole(mock.Anything, "RoleName1").Return(nil).Once()
+	repoMock.EXPECT().CreateAccountRole(mock.Anything, "RoleName3").Return(nil).Once()
+	repoMock.EXPECT().CreateDatabaseRole(mock.Anything, "TEST_DB", "DatabaseRole2").Return(nil).Once()
+	repoMock.EXPECT().CommentAccountRoleIfExists(mock.Anything, mock.Anything, "RoleName1").Return(nil).Once()
+	repoMock.EXPECT().CommentAccountRoleIfExists(mock.Anything, mock.Anything, "RoleName3").Return(nil).Once()
 
-	repoMock.EXPECT().CommentDatabaseRoleIfExists(mock.Anything, "TEST_DB", "DatabaseRole1").Return(nil).Once()
-	repoMock.EXPECT().CommentDatabaseRoleIfExists(mock.Anything, "TEST_DB", "DatabaseRole2").Return(nil).Once()
+	repoMock.EXPECT().CommentDatabaseRoleIfExists(mock.Anything, mock.Anything, "TEST_DB", "DatabaseRole1").Return(nil).Once()
+	repoMock.EXPECT().CommentDatabaseRoleIfExists(mock.Anything, mock.Anything, "TEST_DB", "DatabaseRole2").Return(nil).Once()
 
 	expectGrantUsersToRole(repoMock, "RoleName1", "User1", "User2")
 
@@ -78,26 +78,26 @@ func TestAccessSyncer_SyncAccessProviderRolesToTarget(t *testing.T) {
 	repoMock.EXPECT().GrantAccountRolesToAccountRole(mock.Anything, "RoleName1").Return(nil).Once()
 	repoMock.EXPECT().GrantAccountRolesToAccountRole(mock.Anything, "RoleName3").Return(nil).Once()
 
-	repoMock.EXPECT().GetGrantsOfAccountRole("ExistingRole1").Return([]GrantOfRole{}, nil).Once()
-	repoMock.EXPECT().GetGrantsToAccountRole("ExistingRole1").Return([]GrantToRole{}, nil).Once()
+	repoMock.EXPECT().GetGrantsOfAccountRole(mock.Anything, "ExistingRole1").Return([]GrantOfRole{}, nil).Once()
+	repoMock.EXPECT().GetGrantsToAccountRole(mock.Anything, "ExistingRole1").Return([]GrantToRole{}, nil).Once()
 
-	repoMock.EXPECT().GetGrantsOfDatabaseRole("TEST_DB", "DatabaseRole1").Return([]GrantOfRole{}, nil).Once()
-	repoMock.EXPECT().GetGrantsToDatabaseRole("TEST_DB", "DatabaseRole1").Return([]GrantToRole{}, nil).Once()
+	repoMock.EXPECT().GetGrantsOfDatabaseRole(mock.Anything, "TEST_DB", "DatabaseRole1").Return([]GrantOfRole{}, nil).Once()
+	repoMock.EXPECT().GetGrantsToDatabaseRole(mock.Anything, "TEST_DB", "DatabaseRole1").Return([]GrantToRole{}, nil).Once()
 
-	repoMock.EXPECT().CommentAccountRoleIfExists(mock.Anything, "ExistingRole1").Return(nil).Once()
+	repoMock.EXPECT().CommentAccountRoleIfExists(mock.Anything, mock.Anything, "ExistingRole1").Return(nil).Once()
 
-	repoMock.EXPECT().ExecuteGrantOnAccountRole("USAGE", "DATABASE DB1", "RoleName1", false).Return(nil).Once()
-	repoMock.EXPECT().ExecuteGrantOnAccountRole("USAGE", "SCHEMA DB1.Schema1", "RoleName1", false).Return(nil).Once()
-	repoMock.EXPECT().ExecuteGrantOnAccountRole("SELECT", "TABLE DB1.Schema1.Table1", "RoleName1", false).Return(nil).Once()
-	repoMock.EXPECT().ExecuteGrantOnAccountRole("USAGE", "DATABASE DB1", "ExistingRole1", false).Return(nil).Once()
-	repoMock.EXPECT().ExecuteGrantOnAccountRole("USAGE", "SCHEMA DB1.Schema1", "ExistingRole1", false).Return(nil).Once()
-	repoMock.EXPECT().ExecuteGrantOnAccountRole("SELECT", "TABLE DB1.Schema1.Table2", "ExistingRole1", false).Return(nil).Once()
-	repoMock.EXPECT().ExecuteGrantOnAccountRole("USAGE", "DATABASE DB1", "RoleName3", false).Return(nil).Once()
-	repoMock.EXPECT().ExecuteGrantOnAccountRole("USAGE", "SCHEMA DB1.Schema2", "RoleName3", false).Return(nil).Once()
-	repoMock.EXPECT().ExecuteGrantOnAccountRole("SELECT", "TABLE DB1.Schema2.Table1", "RoleName3", false).Return(nil).Once()
-	repoMock.EXPECT().ExecuteGrantOnDatabaseRole("USAGE", "DATABASE TEST_DB", "TEST_DB", "DatabaseRole1").Return(nil).Once()
-	repoMock.EXPECT().ExecuteGrantOnDatabaseRole("USAGE", "SCHEMA TEST_DB.Schema2", "TEST_DB", "DatabaseRole1").Return(nil).Once()
-	repoMock.EXPECT().ExecuteGrantOnDatabaseRole("SELECT", "TABLE TEST_DB.Schema2.Table1", "TEST_DB", "DatabaseRole1").Return(nil).Once()
+	repoMock.EXPECT().ExecuteGrantOnAccountRole(mock.Anything, "USAGE", "DATABASE DB1", "RoleName1", false).Return(nil).Once()
+	repoMock.EXPECT().ExecuteGrantOnAccountRole(mock.Anything, "USAGE", "SCHEMA DB1.Schema1", "RoleName1", false).Return(nil).Once()
+	repoMock.EXPECT().ExecuteGrantOnAccountRole(mock.Anything, "SELECT", "TABLE DB1.Schema1.Table1", "RoleName1", false).Return(nil).Once()
+	repoMock.EXPECT().ExecuteGrantOnAccountRole(mock.Anything, "USAGE", "DATABASE DB1", "ExistingRole1", false).Return(nil).Once()
+	repoMock.EXPECT().ExecuteGrantOnAccountRole(mock.Anything, "USAGE", "SCHEMA DB1.Schema1", "ExistingRole1", false).Return(nil).Once()
+	repoMock.EXPECT().ExecuteGrantOnAccountRole(mock.Anything, "SELECT", "TABLE DB1.Schema1.Table2", "ExistingRole1", false).Return(nil).Once()
+	repoMock.EXPECT().ExecuteGrantOnAccountRole(mock.Anything, "USAGE", "DATABASE DB1", "RoleName3", false).Return(nil).Once()
+	repoMock.EXPECT().ExecuteGrantOnAccountRole(mock.Anything, "USAGE", "SCHEMA DB1.Schema2", "RoleName3", false).Return(nil).Once()
+	repoMock.EXPECT().ExecuteGrantOnAccountRole(mock.Anything, "SELECT", "TABLE DB1.Schema2.Table1", "RoleName3", false).Return(nil).Once()
+	repoMock.EXPECT().ExecuteGrantOnDatabaseRole(mock.Anything, "USAGE", "DATABASE TEST_DB", "TEST_DB", "DatabaseRole1").Return(nil).Once()
+	repoMock.EXPECT().ExecuteGrantOnDatabaseRole(mock.Anything, "USAGE", "SCHEMA TEST_DB.Schema2", "TEST_DB", "DatabaseRole1").Return(nil).Once()
+	repoMock.EXPECT().ExecuteGrantOnDatabaseRole(mock.Anything, "SELECT", "TABLE TEST_DB.Schema2.Table1", "TEST_DB", "DatabaseRole1").Return(nil).Once()
 
 	ap1 := &importer.AccessProvider{
 		Id:         "AccessProviderId1",
@@ -226,21 +226,21 @@ func TestAccessSyncer_SyncAccessProviderRolesToTarget_ApplicationRoles(t *testin
 	repoMock := newMockDataAccessRepository(t)
 	feedbackHandler := mocks.NewSimpleAccessProviderFeedbackHandler(t)
 
-	repoMock.EXPECT().GetApplications().Return([]ApplictionEntity{
+	repoMock.EXPECT().GetApplications(mock.Anything).Return([]ApplictionEntity{
 		{
 			Name:      "TEST_APP",
 			IsDefault: "N",
 			IsCurrent: "N",
 		},
 	}, nil)
-	repoMock.EXPECT().GetApplicationRoles("TEST_APP").Return([]ApplicationRoleEntity{
+	repoMock.EXPECT().GetApplicationRoles(mock.Anything, "TEST_APP").Return([]ApplicationRoleEntity{
 		{
 			Name: "AccessProvider2",
 		}, {
 			Name: "APP_ROLE_TO_REMOVE",
 		},
 	}, nil)
-	repoMock.EXPECT().GetGrantsOfApplicationRole("TEST_APP", "AccessProvider2").Return([]GrantOfRole{
+	repoMock.EXPECT().GetGrantsOfApplicationRole(mock.Anything, "TEST_APP", "AccessProvider2").Return([]GrantOfRole{
 		{
 			GrantedTo:   "ROLE",
 			GranteeName: "ROLE_TO_REMOVE",
@@ -260,29 +260,29 @@ func TestAccessSyncer_SyncAccessProviderRolesToTarget_ApplicationRoles(t *testin
 	repoMock.EXPECT().RevokeAccountRolesFromApplicationRole(mock.Anything, "TEST_APP", "AccessProvider2", "ROLE_TO_REMOVE").Return(nil).Once()
 	repoMock.EXPECT().RevokeApplicationRolesFromApplicationRole(mock.Anything, "TEST_APP", "AccessProvider2", "APP_ROLE_TO_REMOVE").Return(nil).Once()
 
-	repoMock.EXPECT().DropAccountRole("ToRemove1").Return(nil).Once()
+	repoMock.EXPECT().DropAccountRole(mock.Anything, "ToRemove1").Return(nil).Once()
 
-	repoMock.EXPECT().CreateAccountRole("RoleName1").Return(nil).Once()
-	repoMock.EXPECT().CommentAccountRoleIfExists(mock.Anything, "RoleName1").Return(nil).Once()
+	repoMock.EXPECT().CreateAccountRole(mock.Anything, "RoleName1").Return(nil).Once()
+	repoMock.EXPECT().CommentAccountRoleIfExists(mock.Anything, mock.Anything, "RoleName1").Return(nil).Once()
 
 	expectGrantUsersToRole(repoMock, "RoleName1", "User1", "User2")
 	repoMock.EXPECT().GrantAccountRolesToAccountRole(mock.Anything, "RoleName1").Return(nil).Once()
 
-	repoMock.EXPECT().ExecuteGrantOnAccountRole("USAGE", "DATABASE DB1", "RoleName1", false).Return(nil).Once()
-	repoMock.EXPECT().ExecuteGrantOnAccountRole("USAGE", "SCHEMA DB1.Schema1", "RoleName1", false).Return(nil).Once()
-	repoMock.EXPECT().ExecuteGrantOnAccountRole("SELECT", "TABLE DB1.Schema1.Table1", "RoleName1", false).Return(nil).Once()
+	repoMock.EXPECT().ExecuteGrantOnAccountRole(mock.Anything, "USAGE", "DATABASE DB1", "RoleName1", false).Return(nil).Once()
+	repoMock.EXPECT().ExecuteGrantOnAccountRole(mock.Anything, "USAGE", "SCHEMA DB1.Schema1", "RoleName1", false).Return(nil).Once()
+	repoMock.EXPECT().ExecuteGrantOnAccountRole(mock.Anything, "SELECT", "TABLE DB1.Schema1.Table1", "RoleName1", false).Return(nil).Once()
 
-	repoMock.EXPECT().GetAccountRolesWithPrefix("").Return([]RoleEntity{
+	repoMock.EXPECT().GetAccountRolesWithPrefix(mock.Anything, "").Return([]RoleEntity{
 		{Name: "ExistingRole1", GrantedToRoles: 2, GrantedRoles: 3, AssignedToUsers: 2, Owner: "Owner"},
 		{Name: "ROLE_TO_REMOVE", GrantedToRoles: 2, GrantedRoles: 3, AssignedToUsers: 2, Owner: "Owner"},
 	}, nil).Once()
-	repoMock.EXPECT().GetInboundShares().Return([]DbEntity{}, nil).Once()
-	repoMock.EXPECT().GetDatabases().Return([]DbEntity{
+	repoMock.EXPECT().GetInboundShares(mock.Anything).Return([]DbEntity{}, nil).Once()
+	repoMock.EXPECT().GetDatabases(mock.Anything).Return([]DbEntity{
 		{Name: "SNOWFLAKE"},
 		{Name: "TEST_DB"},
 	}, nil).Once()
-	repoMock.EXPECT().GetDatabaseRolesWithPrefix("SNOWFLAKE", "").Return([]RoleEntity{}, nil).Once()
-	repoMock.EXPECT().GetDatabaseRolesWithPrefix("TEST_DB", "").Return([]RoleEntity{
+	repoMock.EXPECT().GetDatabaseRolesWithPrefix(mock.Anything, "SNOWFLAKE", "").Return([]RoleEntity{}, nil).Once()
+	repoMock.EXPECT().GetDatabaseRolesWithPrefix(mock.Anything, "TEST_DB", "").Return([]RoleEntity{
 		{Name: "DatabaseRole1", AssignedToUsers: 0, GrantedRoles: 0, GrantedToRoles: 1, Owner: "Owner"},
 	}, nil).Once()
 
@@ -381,28 +381,70 @@ func TestAccessSyncer_SyncAccessProviderMasksToTarget(t *testing.T) {
 		},
 	}
 
-	repoMock.EXPECT().GetPoliciesLike("MASKING", "RAITO_MASK1%").Return(nil, nil).Once() // No existing masks
-	repoMock.EXPECT().CreateMaskPolicy("DB1", "Schema1", mock.AnythingOfType("string"), []string{"DB1.Schema1.Table1.Column1"}, ptr.String("SHA256"), &MaskingBeneficiaries{Users: []string{"User1", "User2"}, Roles: []string{"Role1", "Role2"}}).Return(nil)
-	repoMock.EXPECT().CreateMaskPolicy("DB1", "Schema2", mock.AnythingOfType("string"), []string{"DB1.Schema2.Table1.Column1"}, ptr.String("SHA256"), &MaskingBeneficiaries{Users: []string{"User1", "User2"}, Roles: []string{"Role1", "Role2"}}).Return(nil)
+	repoMock.EXPECT().GetPoliciesLike(mock.Anything, "MASKING", "RAITO_MASK1%").Return(nil, nil).Once() // No existing masks
+	repoMock.EXPECT().CreateMaskPolicy(mock.Anything, "DB1", "Schema1", mock.AnythingOfType("string"), []string{"DB1.Schema1.Table1.Column1"}, ptr.String("SHA256"), &MaskingBeneficiaries{Users: []string{"User1", "User2"}, Roles: []string{"Role1", "Role2"}}).Return(nil)
+	repoMock.EXPECT().CreateMaskPolicy(mock.Anything, "DB1", "Schema2", mock.AnythingOfType("string"), []string{"DB1.Schema2.Table1.Column1"}, ptr.String("SHA256"), &MaskingBeneficiaries{Users: []string{"User1", "User2"}, Roles: []string{"Role1", "Role2"}}).Return(nil)
 
-	repoMock.EXPECT().GetPoliciesLike("MASKING", "RAITO_MASK2%").Return([]PolicyEntity{{Name: "RAITO_MASK2_OLD_TEXT", SchemaName: "Schema1", DatabaseName: "DB1"}}, nil).Once()
-	repoMock.EXPECT().CreateMaskPolicy("DB1", "Schema1", mock.AnythingOfType("string"), []string{"DB1.Schema1.Table3.Column1"}, (*string)(nil), &MaskingBeneficiaries{Users: []string{"User1"}}).Return(nil)
-	repoMock.EXPECT().DropMaskingPolicy("DB1", "Schema1", "RAITO_MASK2_OLD").Return(nil)
+	repoMock.EXPECT().GetPoliciesLike(mock.Anything, "MASKING", "RAITO_MASK2%").Return([]PolicyEntity{{Name: "RAITO_MASK2_OLD_TEXT", SchemaName: "Schema1", DatabaseName: "DB1"}}, nil).Once()
+	repoMock.EXPECT().CreateMaskPolicy(mock.Anything, "DB1", "Schema1", mock.AnythingOfType("string"), []string{"DB1.Schema1.Table3.Column1"}, (*string)(nil), &MaskingBeneficiaries{Users: []string{"User1"}}).Return(nil)
+	repoMock.EXPECT().DropMaskingPolicy(mock.Anything, "DB1", "Schema1", "RAITO_MASK2_OLD").Return(nil)
 
-	repoMock.EXPECT().GetPoliciesLike("MASKING", "RAITO_MASKTOREMOVE1%").Return([]PolicyEntity{{Name: "RAITO_maskToRemove1_TEXT", SchemaName: "Schema3", DatabaseName: "DB1"}, {Name: "RAITO_maskToRemove1_INT", SchemaName: "Schema1", DatabaseName: "DB1"}}, nil).Once()
-	repoMock.EXPECT().DropMaskingPolicy("DB1", "Schema3", "RAITO_MASKTOREMOVE1").Return(nil)
-	repoMock.EXPECT().DropMaskingPolicy("DB1", "Schema1", "RAITO_MASKTOREMOVE1").Return(nil)
+	repoMock.EXPECT().GetPoliciesLike(mock.Anything, "MASKING", "RAITO_MASKTOREMOVE1%").Return([]PolicyEntity{{Name: "RAITO_maskToRemove1_TEXT", SchemaName: "Schema3", DatabaseName: "DB1"}, {Name: "RAITO_maskToRemove1_INT", SchemaName: "Schema1", DatabaseName: "DB1"}}, nil).Once()
+	repoMock.EXPECT().DropMaskingPolicy(mock.Anything, "DB1", "Schema3", "RAITO_MASKTOREMOVE1").Return(nil)
+	repoMock.EXPECT().DropMaskingPolicy(mock.Anything, "DB1", "Schema1", "RAITO_MASKTOREMOVE1").Return(nil)
 
 	syncer := createBasicToTargetSyncer(repoMock, nil, fileCreator, &configParams)
 
 	// When
-	err := syncer.SyncAccessProviderMasksToTarget(masksToRemove, masks, map[string]string{"Role2-Id": "Role2"})
+	err := syncer.SyncAccessProviderMasksToTarget(context.Background(), masksToRemove, masks, map[string]string{"Role2-Id": "Role2"})
 
 	// Then
 	assert.NoError(t, err)
 	assert.Len(t, fileCreator.AccessProviderFeedback, 3)
 }
 
+func TestAccessSyncer_updateMask_upToDate(t *testing.T) {
+	// Given
+	repoMock := newMockDataAccessRepository(t)
+
+	mask := &importer.AccessProvider{
+		Id:   "MaskId1",
+		Name: "Mask1",
+		Who: importer.WhoItem{
+			Users:       []string{"User2", "User1"},
+			InheritFrom: []string{"ID:Role2-Id", "Role1"},
+		},
+		What: []importer.WhatItem{
+			{DataObject: &data_source.DataObjectReference{FullName: "DB1.Schema1.Table1.Column1", Type: "column"}},
+			{DataObject: &data_source.DataObjectReference{FullName: "DB1.Schema2.Table1.Column1", Type: "column"}},
+		},
+		Action: types.Mask,
+		Type:   ptr.String("SHA256"),
+	}
+
+	// The name only depends on the definition, not on the order of the beneficiaries or columns
+	uniqueMaskName := raitoMaskUniqueName("Mask1", maskDefinition(ptr.String("SHA256"), &MaskingBeneficiaries{Users: []string{"User1", "User2"}, Roles: []string{"Role1", "Role2"}}, map[string][]string{
+		"DB1.Schema2": {"DB1.Schema2.Table1.Column1"},
+		"DB1.Schema1": {"DB1.Schema1.Table1.Column1"},
+	})...)
+
+	repoMock.EXPECT().GetPoliciesLike(mock.Anything, "MASKING", "RAITO_MASK1%").Return([]PolicyEntity{
+		{Name: uniqueMaskName + "_TEXT", SchemaName: "Schema1", DatabaseName: "DB1"},
+		{Name: "RAITO_MASK1_OLD_TEXT", SchemaName: "Schema2", DatabaseName: "DB1"},
+	}, nil).Once()
+	repoMock.EXPECT().CreateMaskPolicy(mock.Anything, "DB1", "Schema2", uniqueMaskName, []string{"DB1.Schema2.Table1.Column1"}, ptr.String("SHA256"), &MaskingBeneficiaries{Users: []string{"User2", "User1"}, Roles: []string{"Role2", "Role1"}}).Return(nil).Once()
+	repoMock.EXPECT().DropMaskingPolicy(mock.Anything, "DB1", "Schema2", "RAITO_MASK1_OLD").Return(nil).Once()
+
+	syncer := createBasicToTargetSyncer(repoMock, nil, mocks.NewSimpleAccessProviderFeedbackHandler(t), &config.ConfigMap{})
+
+	// When
+	actualName, err := syncer.updateMask(context.Background(), mask, map[string]string{"Role2-Id": "Role2"})
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, uniqueMaskName, actualName)
+}
+
 func TestAccessSyncer_SyncAccessProviderFiltersToTarget(t *testing.T) {
 	// Given
 	configParams := config.ConfigMap{
@@ -412,8 +454,8 @@ func TestAccessSyncer_SyncAccessProviderFiltersToTarget(t *testing.T) {
 	repo := newMockDataAccessRepository(t)
 	fileCreator := mocks.NewSimpleAccessProviderFeedbackHandler(t)
 
-	repo.EXPECT().UpdateFilter("DB1", "Schema1", "Table1", mock.AnythingOfType("string"), mock.AnythingOfType("[]string"),
-		mock.AnythingOfType("string")).RunAndReturn(func(_ string, _ string, _ string, filterName string, arguments []string, query string) error {
+	repo.EXPECT().UpdateFilter(mock.Anything, "DB1", "Schema1", "Table1", mock.AnythingOfType("string"), mock.AnythingOfType("[]string"),
+		mock.AnythingOfType("string")).RunAndReturn(func(_ context.Context, _ string, _ string, _ string, filterName string, arguments []string, query string) error {
 		assert.True(t, strings.HasPrefix(filterName, "raito_Schema1_Table1_"))
 		assert.ElementsMatch(t, []string{"Column1", "state"}, arguments)
 
@@ -428,13 +470,13 @@ func TestAccessSyncer_SyncAccessProviderFiltersToTarget(t *testing.T) {
 		return nil
 	})
 
-	repo.EXPECT().UpdateFilter("DB1", "Schema2", "Table1", mock.AnythingOfType("string"), []string{}, "FALSE").RunAndReturn(func(_ string, _ string, _ string, filterName string, _ []string, _ string) error {
+	repo.EXPECT().UpdateFilter(mock.Anything, "DB1", "Schema2", "Table1", mock.AnythingOfType("string"), []string{}, "FALSE").RunAndReturn(func(ctx context.Context, _ string, _ string, _ string, filterName string, _ []string, _ string) error {
 		assert.True(t, strings.HasPrefix(filterName, "raito_Schema2_Table1_"))
 
 		return nil
 	})
 
-	repo.EXPECT().DropFilter("DB1", "Schema1", "Table3", "RAITO_FILTERTOREMOVE2").Return(nil)
+	repo.EXPECT().DropFilter(mock.Anything, "DB1", "Schema1", "Table3", "RAITO_FILTERTOREMOVE2").Return(nil)
 
 	masksToRemove := map[string]*importer.AccessProvider{
 		"RAITO_FILTERTOREMOVE1": {
@@ -564,7 +606,7 @@ func TestAccessSyncer_removeRolesToRemove_NoRoles(t *testing.T) {
 	syncer := createBasicToTargetSyncer(repo, nil, nil, nil)
 
 	// When
-	err := syncer.removeRolesToRemove(map[string]*importer.AccessProvider{})
+	err := syncer.removeRolesToRemove(context.Background(), map[string]*importer.AccessProvider{})
 
 	// Then
 	assert.NoError(t, err)
@@ -576,7 +618,7 @@ func TestAccessSyncer_removeRolesToRemove(t *testing.T) {
 
 	rolesToRemove := map[string]*importer.AccessProvider{"Role1": {Id: "xxx"}, "Role2": {Id: "yyy"}, "Role3": {Id: "zzz"}}
 
-	repo.EXPECT().DropAccountRole(mock.MatchedBy(func(roleName string) bool {
+	repo.EXPECT().DropAccountRole(mock.Anything, mock.MatchedBy(func(roleName string) bool {
 		for possibleRole := range rolesToRemove {
 			if possibleRole == roleName {
 				return true
@@ -588,7 +630,7 @@ func TestAccessSyncer_removeRolesToRemove(t *testing.T) {
 	syncer := createBasicToTargetSyncer(repo, nil, &dummyFeedbackHandler{}, nil)
 
 	// When
-	err := syncer.removeRolesToRemove(rolesToRemove)
+	err := syncer.removeRolesToRemove(context.Background(), rolesToRemove)
 
 	// Then
 	assert.NoError(t, err)
@@ -600,14 +642,14 @@ func TestAccessSyncer_removeRolesToRemove_error(t *testing.T) {
 
 	rolesToRemove := map[string]*importer.AccessProvider{"Role1": {Id: "xxx"}, "Role2": {Id: "yyy"}}
 
-	repo.EXPECT().DropAccountRole("Role1").Return(nil).Once()
-	repo.EXPECT().DropAccountRole("Role2").Return(fmt.Errorf("BOOM")).Once()
+	repo.EXPECT().DropAccountRole(mock.Anything, "Role1").Return(nil).Once()
+	repo.EXPECT().DropAccountRole(mock.Anything, "Role2").Return(fmt.Errorf("BOOM")).Once()
 
 	feedbackHandler := mocks.NewSimpleAccessProviderFeedbackHandler(t)
 	syncer := createBasicToTargetSyncer(repo, nil, feedbackHandler, nil)
 
 	// When
-	err := syncer.removeRolesToRemove(rolesToRemove)
+	err := syncer.removeRolesToRemove(context.Background(), rolesToRemove)
 
 	assert.Len(t, feedbackHandler.AccessProviderFeedback, 2)
 	assert.ElementsMatch(t, feedbackHandler.AccessProviderFeedback, []importer.AccessProviderSyncFeedback{
@@ -630,14 +672,14 @@ func generateAccessControls_table(t *testing.T) {
 	// Given
 	repoMock := newMockDataAccessRepository(t)
 
-	repoMock.EXPECT().CreateAccountRole("RoleName1").Return(nil).Once()
-	repoMock.EXPECT().CommentAccountRoleIfExists(mock.Anything, "RoleName1").Return(nil).Once()
+	repoMock.EXPECT().CreateAccountRole(mock.Anything, "RoleName1").Return(nil).Once()
+	repoMock.EXPECT().CommentAccountRoleIfExists(mock.Anything, mock.Anything, "RoleName1").Return(nil).Once()
 	expectGrantUsersToRole(repoMock, "RoleName1", "User1", "User2")
 	repoMock.EXPECT().GrantAccountRolesToAccountRole(mock.Anything, "RoleName1").Return(nil).Once()
 
-	repoMock.EXPECT().ExecuteGrantOnAccountRole("USAGE", "DATABASE DB1", "RoleName1", false).Return(nil).Once()
-	repoMock.EXPECT().ExecuteGrantOnAccountRole("USAGE", "SCHEMA DB1.Schema1", "RoleName1", false).Return(nil).Once()
-	repoMock.EXPECT().ExecuteGrantOnAccountRole("SELECT", "TABLE DB1.Schema1.Table1", "RoleName1", false).Return(nil).Once()
+	repoMock.EXPECT().ExecuteGrantOnAccountRole(mock.Anything, "USAGE", "DATABASE DB1", "RoleName1", false).Return(nil).Once()
+	repoMock.EXPECT().ExecuteGrantOnAccountRole(mock.Anything, "USAGE", "SCHEMA DB1.Schema1", "RoleName1", false).Return(nil).Once()
+	repoMock.EXPECT().ExecuteGrantOnAccountRole(mock.Anything, "SELECT", "TABLE DB1.Schema1.Table1", "RoleName1", false).Return(nil).Once()
 
 	access := map[string]*importer.AccessProvider{
 		"RoleName1": {
@@ -665,14 +707,14 @@ func generateAccessControls_view(t *testing.T) {
 	// Given
 	repoMock := newMockDataAccessRepository(t)
 
-	repoMock.EXPECT().CreateAccountRole("RoleName1").Return(nil).Once()
-	repoMock.EXPECT().CommentAccountRoleIfExists(mock.Anything, "RoleName1").Return(nil).Once()
+	repoMock.EXPECT().CreateAccountRole(mock.Anything, "RoleName1").Return(nil).Once()
+	repoMock.EXPECT().CommentAccountRoleIfExists(mock.Anything, mock.Anything, "RoleName1").Return(nil).Once()
 	expectGrantUsersToRole(repoMock, "RoleName1", "User1", "User2")
 	repoMock.EXPECT().GrantAccountRolesToAccountRole(mock.Anything, "RoleName1").Return(nil).Once()
 
-	repoMock.EXPECT().ExecuteGrantOnAccountRole("USAGE", "DATABASE DB1", "RoleName1", false).Return(nil).Once()
-	repoMock.EXPECT().ExecuteGrantOnAccountRole("USAGE", "SCHEMA DB1.Schema1", "RoleName1", false).Return(nil).Once()
-	repoMock.EXPECT().ExecuteGrantOnAccountRole("SELECT", "VIEW DB1.Schema1.Table2", "RoleName1", false).Return(nil).Once()
+	repoMock.EXPECT().ExecuteGrantOnAccountRole(mock.Anything, "USAGE", "DATABASE DB1", "RoleName1", false).Return(nil).Once()
+	repoMock.EXPECT().ExecuteGrantOnAccountRole(mock.Anything, "USAGE", "SCHEMA DB1.Schema1", "RoleName1", false).Return(nil).Once()
+	repoMock.EXPECT().ExecuteGrantOnAccountRole(mock.Anything, "SELECT", "VIEW DB1.Schema1.Table2", "RoleName1", false).Return(nil).Once()
 
 	access := map[string]*importer.AccessProvider{
 		"RoleName1": {
@@ -698,7 +740,7 @@ func generateAccessControls_view(t *testing.T) {
 
 func createBasicToTargetSyncer(repo dataAccessRepository, accessProviders *importer.AccessProviderImport, accessProviderFeedbackHandler wrappers.AccessProviderFeedbackHandler, configMap *config.ConfigMap) *AccessToTargetSyncer {
 	as := AccessSyncer{
-		repoProvider: func(params map[string]string, role string) (dataAccessRepository, error) {
+		repoProvider: func(params map[string]string, role string, phase SyncPhase) (dataAccessRepository, error) {
 			return repo, nil
 		},
 		repo:              repo,
@@ -714,31 +756,30 @@ func generateAccessControls_schema(t *testing.T) {
 	// Given
 	repoMock := newMockDataAccessRepository(t)
 
-	repoMock.EXPECT().CreateAccountRole("RoleName1").Return(nil).Once()
-	repoMock.EXPECT().CommentAccountRoleIfExists(mock.Anything, "RoleName1").Return(nil).Once()
+	repoMock.EXPECT().CreateAccountRole(mock.Anything, "RoleName1").Return(nil).Once()
+	repoMock.EXPECT().CommentAccountRoleIfExists(mock.Anything, mock.Anything, "RoleName1").Return(nil).Once()
 	expectGrantUsersToRole(repoMock, "RoleName1", "User1", "User2")
 	repoMock.EXPECT().GrantAccountRolesToAccountRole(mock.Anything, "RoleName1").Return(nil).Once()
 
-	repoMock.EXPECT().ExecuteGrantOnAccountRole("USAGE", "DATABASE DB1", "RoleName1", false).Return(nil).Once()
-	repoMock.EXPECT().ExecuteGrantOnAccountRole("USAGE", "SCHEMA DB1.Schema2", "RoleName1", false).Return(nil).Once()
-	repoMock.EXPECT().ExecuteGrantOnAccountRole("SELECT", "TABLE DB1.Schema2.Table3", "RoleName1", false).Return(nil).Once()
-	repoMock.EXPECT().ExecuteGrantOnAccountRole("SELECT", "VIEW DB1.Schema2.View3", "RoleName1", false).Return(nil).Once()
+	repoMock.EXPECT().ExecuteGrantOnAccountRole(mock.Anything, "USAGE", "DATABASE DB1", "RoleName1", false).Return(nil).Once()
+	repoMock.EXPECT().ExecuteGrantOnAccountRole(mock.Anything, "USAGE", "SCHEMA DB1.Schema2", "RoleName1", false).Return(nil).Once()
+	repoMock.EXPECT().ExecuteGrantOnAccountRole(mock.Anything, "SELECT", "TABLE DB1.Schema2.Table3", "RoleName1", false).Return(nil).Once()
+	repoMock.EXPECT().ExecuteGrantOnAccountRole(mock.Anything, "SELECT", "VIEW DB1.Schema2.View3", "RoleName1", false).Return(nil).Once()
 
 	database := "DB1"
 	schema := "Schema2"
 
-	repoMock.EXPECT().GetFunctionsInSchema(database, schema, mock.Anything).RunAndReturn(func(d string, s string, handler EntityHandler) error {
+	repoMock.EXPECT().GetTablesInDatabase(mock.Anything, database, schema, mock.Anything).RunAndReturn(func(ctx context.Context, s string, s2 string, handler EntityHandler) error {
 		handler(&TableEntity{Database: s, Schema: s2, Name: "Table3", TableType: "BASE TABLE"})
 		handler(&TableEntity{Database: s, Schema: s2, Name: "View3", TableType: "VIEW"})
 		return nil
 	}).Once()
 
-	repoMock.EXPECT().GetProceduresInSchema(database, schema, mock.Anything).RunAndReturn(func(d string, s string, handler EntityHandler) error {
-		handler(&FunctionEntity{Database: &s, Schema: utils.Ptr("Schema2"), Name: "Decrypt", ArgumentSignature: "(VARCHAR)"})
+	repoMock.EXPECT().GetFunctionsInSchema(mock.Anything, database, schema, mock.Anything).RunAndReturn(func(ctx context.Context, d string, s string, handler EntityHandler) error {
 		return nil
 	}).Once()
 
-	repoMock.EXPECT().GetProceduresInDatabase(database, mock.Anything).RunAndReturn(func(s string, handler EntityHandler) error {
+	repoMock.EXPECT().GetProceduresInSchema(mock.Anything, database, schema, mock.Anything).RunAndReturn(func(ctx context.Context, d string, s string, handler EntityHandler) error {
 		return nil
 	}).Once()
 
@@ -768,14 +809,14 @@ func generateAccessControls_schema_noverify(t *testing.T) {
 	// Given
 	repoMock := newMockDataAccessRepository(t)
 
-	repoMock.EXPECT().CreateAccountRole("RoleName1").Return(nil).Once()
-	repoMock.EXPECT().CommentAccountRoleIfExists(mock.Anything, "RoleName1").Return(nil).Once()
+	repoMock.EXPECT().CreateAccountRole(mock.Anything, "RoleName1").Return(nil).Once()
+	repoMock.EXPECT().CommentAccountRoleIfExists(mock.Anything, mock.Anything, "RoleName1").Return(nil).Once()
 	expectGrantUsersToRole(repoMock, "RoleName1", "User1", "User2")
 	repoMock.EXPECT().GrantAccountRolesToAccountRole(mock.Anything, "RoleName1").Return(nil).Once()
 
-	repoMock.EXPECT().ExecuteGrantOnAccountRole("USAGE", "DATABASE DB1", "RoleName1", false).Return(nil).Once()
-	repoMock.EXPECT().ExecuteGrantOnAccountRole("USAGE", "SCHEMA DB1.Schema2", "RoleName1", false).Return(nil).Once()
-	repoMock.EXPECT().ExecuteGrantOnAccountRole("CREATE TABLE", "SCHEMA DB1.Schema2", "RoleName1", false).Return(nil).Once()
+	repoMock.EXPECT().ExecuteGrantOnAccountRole(mock.Anything, "USAGE", "DATABASE DB1", "RoleName1", false).Return(nil).Once()
+	repoMock.EXPECT().ExecuteGrantOnAccountRole(mock.Anything, "USAGE", "SCHEMA DB1.Schema2", "RoleName1", false).Return(nil).Once()
+	repoMock.EXPECT().ExecuteGrantOnAccountRole(mock.Anything, "CREATE TABLE", "SCHEMA DB1.Schema2", "RoleName1", false).Return(nil).Once()
 
 	access := map[string]*importer.AccessProvider{
 		"RoleName1": {
@@ -803,33 +844,33 @@ func generateAccessControls_existing_schema(t *testing.T) {
 	// Given
 	repoMock := newMockDataAccessRepository(t)
 
-	repoMock.EXPECT().CommentAccountRoleIfExists(mock.AnythingOfType("string"), "RoleName1").Return(nil).Once()
+	repoMock.EXPECT().CommentAccountRoleIfExists(mock.Anything, mock.AnythingOfType("string"), "RoleName1").Return(nil).Once()
 
-	repoMock.EXPECT().GetGrantsOfAccountRole(mock.Anything).Return([]GrantOfRole{}, nil)
-	repoMock.EXPECT().GetGrantsToAccountRole(mock.Anything).Return([]GrantToRole{}, nil)
+	repoMock.EXPECT().GetGrantsOfAccountRole(mock.Anything, mock.Anything).Return([]GrantOfRole{}, nil)
+	repoMock.EXPECT().GetGrantsToAccountRole(mock.Anything, mock.Anything).Return([]GrantToRole{}, nil)
 
 	expectGrantUsersToRole(repoMock, "RoleName1", "User1", "User2")
 
-	repoMock.EXPECT().ExecuteRevokeOnAccountRole("ALL", "FUTURE TABLES IN SCHEMA DB1.Schema2", "RoleName1", false).Return(nil).Once()
+	repoMock.EXPECT().ExecuteRevokeOnAccountRole(mock.Anything, "ALL", "FUTURE TABLES IN SCHEMA DB1.Schema2", "RoleName1", false).Return(nil).Once()
 
-	repoMock.EXPECT().ExecuteGrantOnAccountRole("USAGE", "DATABASE DB1", "RoleName1", false).Return(nil).Once()
-	repoMock.EXPECT().ExecuteGrantOnAccountRole("USAGE", "SCHEMA DB1.Schema2", "RoleName1", false).Return(nil).Once()
-	repoMock.EXPECT().ExecuteGrantOnAccountRole("SELECT", "TABLE DB1.Schema2.Table3", "RoleName1", false).Return(nil).Once()
-	repoMock.EXPECT().ExecuteGrantOnAccountRole("SELECT", "VIEW DB1.Schema2.View3", "RoleName1", false).Return(nil).Once()
+	repoMock.EXPECT().ExecuteGrantOnAccountRole(mock.Anything, "USAGE", "DATABASE DB1", "RoleName1", false).Return(nil).Once()
+	repoMock.EXPECT().ExecuteGrantOnAccountRole(mock.Anything, "USAGE", "SCHEMA DB1.Schema2", "RoleName1", false).Return(nil).Once()
+	repoMock.EXPECT().ExecuteGrantOnAccountRole(mock.Anything, "SELECT", "TABLE DB1.Schema2.Table3", "RoleName1", false).Return(nil).Once()
+	repoMock.EXPECT().ExecuteGrantOnAccountRole(mock.Anything, "SELECT", "VIEW DB1.Schema2.View3", "RoleName1", false).Return(nil).Once()
 
 	database := "DB1"
 	schema := "Schema2"
-	repoMock.EXPECT().GetTablesInDatabase(database, schema, mock.Anything).RunAndReturn(func(s string, s2 string, handler EntityHandler) error {
+	repoMock.EXPECT().GetTablesInDatabase(mock.Anything, database, schema, mock.Anything).RunAndReturn(func(ctx context.Context, s string, s2 string, handler EntityHandler) error {
 		handler(&TableEntity{Database: s, Schema: s2, Name: "Table3", TableType: "BASE TABLE"})
 		handler(&TableEntity{Database: s, Schema: s2, Name: "View3", TableType: "VIEW"})
 		return nil
 	}).Once()
 
-	repoMock.EXPECT().GetFunctionsInSchema(database, schema, mock.Anything).RunAndReturn(func(d string, s string, handler EntityHandler) error {
+	repoMock.EXPECT().GetFunctionsInSchema(mock.Anything, database, schema, mock.Anything).RunAndReturn(func(ctx context.Context, d string, s string, handler EntityHandler) error {
 		return nil
 	}).Once()
 
-	repoMock.EXPECT().GetProceduresInSchema(database, schema, mock.Anything).RunAndReturn(func(d string, s string, handler EntityHandler) error {
+	repoMock.EXPECT().GetProceduresInSchema(mock.Anything, database, schema, mock.Anything).RunAndReturn(func(ctx context.Context, d string, s string, handler EntityHandler) error {
 		return nil
 	}).Once()
 
@@ -859,12 +900,12 @@ func generateAccessControls_sharedDatabase(t *testing.T) {
 	// Given
 	repoMock := newMockDataAccessRepository(t)
 
-	repoMock.EXPECT().CreateAccountRole("RoleName1").Return(nil).Once()
-	repoMock.EXPECT().CommentAccountRoleIfExists(mock.Anything, "RoleName1").Return(nil).Once()
+	repoMock.EXPECT().CreateAccountRole(mock.Anything, "RoleName1").Return(nil).Once()
+	repoMock.EXPECT().CommentAccountRoleIfExists(mock.Anything, mock.Anything, "RoleName1").Return(nil).Once()
 	expectGrantUsersToRole(repoMock, "RoleName1", "User1", "User2")
 	repoMock.EXPECT().GrantAccountRolesToAccountRole(mock.Anything, "RoleName1").Return(nil).Once()
 
-	repoMock.EXPECT().ExecuteGrantOnAccountRole("IMPORTED PRIVILEGES", "DATABASE DB2", "RoleName1", false).Return(nil).Once()
+	repoMock.EXPECT().ExecuteGrantOnAccountRole(mock.Anything, "IMPORTED PRIVILEGES", "DATABASE DB2", "RoleName1", false).Return(nil).Once()
 
 	access := map[string]*importer.AccessProvider{
 		"RoleName1": {
@@ -892,37 +933,37 @@ func generateAccessControls_database(t *testing.T) {
 	// Given
 	repoMock := newMockDataAccessRepository(t)
 
-	repoMock.EXPECT().CreateAccountRole("RoleName1").Return(nil).Once()
-	repoMock.EXPECT().CommentAccountRoleIfExists(mock.Anything, "RoleName1").Return(nil).Once()
+	repoMock.EXPECT().CreateAccountRole(mock.Anything, "RoleName1").Return(nil).Once()
+	repoMock.EXPECT().CommentAccountRoleIfExists(mock.Anything, mock.Anything, "RoleName1").Return(nil).Once()
 	expectGrantUsersToRole(repoMock, "RoleName1", "User1", "User2")
 	repoMock.EXPECT().GrantAccountRolesToAccountRole(mock.Anything, "RoleName1").Return(nil).Once()
 
 	database := "DB1"
 	schema := "Schema2"
-	repoMock.EXPECT().GetTablesInDatabase(database, schema, mock.Anything).RunAndReturn(func(s string, s2 string, handler EntityHandler) error {
+	repoMock.EXPECT().GetTablesInDatabase(mock.Anything, database, schema, mock.Anything).RunAndReturn(func(ctx context.Context, s string, s2 string, handler EntityHandler) error {
 		handler(&TableEntity{Database: s, Schema: s2, Name: "Table3", TableType: "BASE TABLE"})
 		handler(&TableEntity{Database: s, Schema: s2, Name: "View3", TableType: "VIEW"})
 		return nil
 	}).Once()
 
-	repoMock.EXPECT().GetSchemasInDatabase("DB1", mock.Anything).RunAndReturn(func(s string, handler EntityHandler) error {
+	repoMock.EXPECT().GetSchemasInDatabase(mock.Anything, "DB1", mock.Anything).RunAndReturn(func(ctx context.Context, s string, handler EntityHandler) error {
 		handler(&SchemaEntity{Database: s, Name: "Schema2"})
 		return nil
 	}).Once()
 
-	repoMock.EXPECT().ExecuteGrantOnAccountRole("USAGE", "DATABASE DB1", "RoleName1", false).Return(nil).Once()
-	repoMock.EXPECT().ExecuteGrantOnAccountRole("USAGE", "SCHEMA DB1.Schema2", "RoleName1", false).Return(nil).Once()
-	repoMock.EXPECT().ExecuteGrantOnAccountRole("SELECT", "TABLE DB1.Schema2.Table3", "RoleName1", false).Return(nil).Once()
-	repoMock.EXPECT().ExecuteGrantOnAccountRole("SELECT", "VIEW DB1.Schema2.View3", "RoleName1", false).Return(nil).Once()
-	repoMock.EXPECT().ExecuteGrantOnAccountRole("USAGE", "PROCEDURE DB1.Schema2.\"procMe\"(VARCHAR)", "RoleName1", false).Return(nil).Once()
-	repoMock.EXPECT().ExecuteGrantOnAccountRole("USAGE", "FUNCTION DB1.Schema2.\"Decrypt\"(VARCHAR)", "RoleName1", false).Return(nil).Once()
+	repoMock.EXPECT().ExecuteGrantOnAccountRole(mock.Anything, "USAGE", "DATABASE DB1", "RoleName1", false).Return(nil).Once()
+	repoMock.EXPECT().ExecuteGrantOnAccountRole(mock.Anything, "USAGE", "SCHEMA DB1.Schema2", "RoleName1", false).Return(nil).Once()
+	repoMock.EXPECT().ExecuteGrantOnAccountRole(mock.Anything, "SELECT", "TABLE DB1.Schema2.Table3", "RoleName1", false).Return(nil).Once()
+	repoMock.EXPECT().ExecuteGrantOnAccountRole(mock.Anything, "SELECT", "VIEW DB1.Schema2.View3", "RoleName1", false).Return(nil).Once()
+	repoMock.EXPECT().ExecuteGrantOnAccountRole(mock.Anything, "USAGE", "PROCEDURE DB1.Schema2.\"procMe\"(VARCHAR)", "RoleName1", false).Return(nil).Once()
+	repoMock.EXPECT().ExecuteGrantOnAccountRole(mock.Anything, "USAGE", "FUNCTION DB1.Schema2.\"Decrypt\"(VARCHAR)", "RoleName1", false).Return(nil).Once()
 
-	repoMock.EXPECT().GetFunctionsInSchema(database, schema, mock.Anything).RunAndReturn(func(d string, s string, handler EntityHandler) error {
+	repoMock.EXPECT().GetFunctionsInSchema(mock.Anything, database, schema, mock.Anything).RunAndReturn(func(ctx context.Context, d string, s string, handler EntityHandler) error {
 		handler(&FunctionEntity{Database: &s, Schema: utils.Ptr("Schema2"), Name: "Decrypt", ArgumentSignature: "(VARCHAR)"})
 		return nil
 	}).Once()
 
-	repoMock.EXPECT().GetProceduresInSchema(database, schema, mock.Anything).RunAndReturn(func(d string, s string, handler EntityHandler) error {
+	repoMock.EXPECT().GetProceduresInSchema(mock.Anything, database, schema, mock.Anything).RunAndReturn(func(ctx context.Context, d string, s string, handler EntityHandler) error {
 		handler(&ProcedureEntity{Database: &s, Schema: utils.Ptr("Schema2"), Name: "procMe", ArgumentSignature: "(VARCHAR)"})
 		return nil
 	}).Once()
@@ -953,55 +994,56 @@ func generateAccessControls_existing_database(t *testing.T) {
 	// Given
 	repoMock := newMockDataAccessRepository(t)
 
-	repoMock.EXPECT().CommentAccountRoleIfExists(mock.AnythingOfType("string"), "RoleName1").Return(nil).Once()
+	repoMock.EXPECT().CommentAccountRoleIfExists(mock.Anything, mock.AnythingOfType("string"), "RoleName1").Return(nil).Once()
 
-	repoMock.EXPECT().GetGrantsOfAccountRole(mock.Anything).Return([]GrantOfRole{}, nil)
-	repoMock.EXPECT().GetGrantsToAccountRole(mock.Anything).Return([]GrantToRole{}, nil)
+	repoMock.EXPECT().GetGrantsOfAccountRole(mock.Anything, mock.Anything).Return([]GrantOfRole{}, nil)
+	repoMock.EXPECT().GetGrantsToAccountRole(mock.Anything, mock.Anything).Return([]GrantToRole{}, nil)
 
 	expectGrantUsersToRole(repoMock, "RoleName1", "User1", "User2")
 
 	database := "DB1"
 	schema := "Schema2"
-	repoMock.EXPECT().GetTablesInDatabase(database, schema, mock.Anything).RunAndReturn(func(s string, s2 string, handler EntityHandler) error {
+	repoMock.EXPECT().GetSchemasInDatabase(mock.Anything, database, mock.Anything).RunAndReturn(func(ctx context.Context, s string, handler EntityHandler) error {
+		handler(&SchemaEntity{Database: s, Name: schema})
+		return nil
+	})
+
+	repoMock.EXPECT().GetTablesInDatabase(mock.Anything, database, schema, mock.Anything).RunAndReturn(func(ctx context.Context, s string, s2 string, handler EntityHandler) error {
 		handler(&TableEntity{Database: s, Schema: s2, Name: "Table3", TableType: "BASE TABLE"})
 		handler(&TableEntity{Database: s, Schema: s2, Name: "View3", TableType: "VIEW"})
 		return nil
 	}).Once()
 
-	repoMock.EXPECT().GetFunctionsInSchema(database, schema, mock.Anything).RunAndReturn(func(d string, s string, handler EntityHandler) error {
+	repoMock.EXPECT().GetFunctionsInSchema(mock.Anything, database, schema, mock.Anything).RunAndReturn(func(ctx context.Context, d string, s string, handler EntityHandler) error {
 		handler(&FunctionEntity{Database: &s, Schema: utils.Ptr("Schema2"), Name: "Decrypt", ArgumentSignature: "(VARCHAR)"})
 		return nil
 	}).Once()
 
-	repoMock.EXPECT().GetProceduresInSchema(database, schema, mock.Anything).RunAndReturn(func(d string, s string, handler EntityHandler) error {
-		handler(&FunctionEntity{Database: s, Schema: "Schema2", Name: "Decrypt", ArgumentSignature: "(VAL VARCHAR)"})
-		return nil
-	}).Once()
-
-	repoMock.EXPECT().GetProceduresInDatabase(database, mock.Anything).RunAndReturn(func(s string, handler EntityHandler) error {
+	repoMock.EXPECT().GetProceduresInSchema(mock.Anything, database, schema, mock.Anything).RunAndReturn(func(ctx context.Context, d string, s string, handler EntityHandler) error {
+		handler(&ProcedureEntity{Database: &s, Schema: utils.Ptr("Schema2"), Name: "Decrypt", ArgumentSignature: "(VAL VARCHAR)"})
 		return nil
 	}).Once()
 
-	repoMock.EXPECT().ExecuteRevokeOnAccountRole("ALL", "FUTURE SCHEMAS IN DATABASE DB1", "RoleName1", false).Return(nil).Once()
-	repoMock.EXPECT().ExecuteRevokeOnAccountRole("ALL", "FUTURE TABLES IN DATABASE DB1", "RoleName1", false).Return(nil).Once()
+	repoMock.EXPECT().ExecuteRevokeOnAccountRole(mock.Anything, "ALL", "FUTURE SCHEMAS IN DATABASE DB1", "RoleName1", false).Return(nil).Once()
+	repoMock.EXPECT().ExecuteRevokeOnAccountRole(mock.Anything, "ALL", "FUTURE TABLES IN DATABASE DB1", "RoleName1", false).Return(nil).Once()
 
-	repoMock.EXPECT().ExecuteGrantOnAccountRole("USAGE", "DATABASE DB1", "RoleName1", false).Return(nil).Once()
-	repoMock.EXPECT().ExecuteGrantOnAccountRole("USAGE", "SCHEMA DB1.Schema2", "RoleName1", false).Return(nil).Once()
-	repoMock.EXPECT().ExecuteGrantOnAccountRole("SELECT", "TABLE DB1.Schema2.Table3", "RoleName1", false).Return(nil).Once()
-	repoMock.EXPECT().ExecuteGrantOnAccountRole("SELECT", "VIEW DB1.Schema2.View3", "RoleName1", false).Return(nil).Once()
+	repoMock.EXPECT().ExecuteGrantOnAccountRole(mock.Anything, "USAGE", "DATABASE DB1", "RoleName1", false).Return(nil).Once()
+	repoMock.EXPECT().ExecuteGrantOnAccountRole(mock.Anything, "USAGE", "SCHEMA DB1.Schema2", "RoleName1", false).Return(nil).Once()
+	repoMock.EXPECT().ExecuteGrantOnAccountRole(mock.Anything, "SELECT", "TABLE DB1.Schema2.Table3", "RoleName1", false).Return(nil).Once()
+	repoMock.EXPECT().ExecuteGrantOnAccountRole(mock.Anything, "SELECT", "VIEW DB1.Schema2.View3", "RoleName1", false).Return(nil).Once()
 
-	repoMock.EXPECT().CommentDatabaseRoleIfExists(mock.AnythingOfType("string"), "DB1", "DatabaseRole1").Return(nil).Once()
+	repoMock.EXPECT().CommentDatabaseRoleIfExists(mock.Anything, mock.AnythingOfType("string"), "DB1", "DatabaseRole1").Return(nil).Once()
 
-	repoMock.EXPECT().GetGrantsOfDatabaseRole("DB1", "DatabaseRole1").Return([]GrantOfRole{}, nil)
-	repoMock.EXPECT().GetGrantsToDatabaseRole("DB1", "DatabaseRole1").Return([]GrantToRole{}, nil)
+	repoMock.EXPECT().GetGrantsOfDatabaseRole(mock.Anything, "DB1", "DatabaseRole1").Return([]GrantOfRole{}, nil)
+	repoMock.EXPECT().GetGrantsToDatabaseRole(mock.Anything, "DB1", "DatabaseRole1").Return([]GrantToRole{}, nil)
 
-	repoMock.EXPECT().ExecuteRevokeOnDatabaseRole("ALL", "FUTURE SCHEMAS IN DATABASE DB1", "DB1", "DatabaseRole1").Return(nil).Once()
-	repoMock.EXPECT().ExecuteRevokeOnDatabaseRole("ALL", "FUTURE TABLES IN DATABASE DB1", "DB1", "DatabaseRole1").Return(nil).Once()
+	repoMock.EXPECT().ExecuteRevokeOnDatabaseRole(mock.Anything, "ALL", "FUTURE SCHEMAS IN DATABASE DB1", "DB1", "DatabaseRole1").Return(nil).Once()
+	repoMock.EXPECT().ExecuteRevokeOnDatabaseRole(mock.Anything, "ALL", "FUTURE TABLES IN DATABASE DB1", "DB1", "DatabaseRole1").Return(nil).Once()
 
-	repoMock.EXPECT().ExecuteGrantOnDatabaseRole("USAGE", "DATABASE DB1", "DB1", "DatabaseRole1").Return(nil).Once()
-	repoMock.EXPECT().ExecuteGrantOnDatabaseRole("USAGE", "SCHEMA DB1.Schema2", "DB1", "DatabaseRole1").Return(nil).Once()
-	repoMock.EXPECT().ExecuteGrantOnDatabaseRole("SELECT", "TABLE DB1.Schema2.Table3", "DB1", "DatabaseRole1").Return(nil).Once()
-	repoMock.EXPECT().ExecuteGrantOnDatabaseRole("SELECT", "VIEW DB1.Schema2.View3", "DB1", "DatabaseRole1").Return(nil).Once()
+	repoMock.EXPECT().ExecuteGrantOnDatabaseRole(mock.Anything, "USAGE", "DATABASE DB1", "DB1", "DatabaseRole1").Return(nil).Once()
+	repoMock.EXPECT().ExecuteGrantOnDatabaseRole(mock.Anything, "USAGE", "SCHEMA DB1.Schema2", "DB1", "DatabaseRole1").Return(nil).Once()
+	repoMock.EXPECT().ExecuteGrantOnDatabaseRole(mock.Anything, "SELECT", "TABLE DB1.Schema2.Table3", "DB1", "DatabaseRole1").Return(nil).Once()
+	repoMock.EXPECT().ExecuteGrantOnDatabaseRole(mock.Anything, "SELECT", "VIEW DB1.Schema2.View3", "DB1", "DatabaseRole1").Return(nil).Once()
 
 	access := map[string]*importer.AccessProvider{
 		"RoleName1": {
@@ -1038,12 +1080,12 @@ func generateAccessControls_warehouse(t *testing.T) {
 	// Given
 	repoMock := newMockDataAccessRepository(t)
 
-	repoMock.EXPECT().CreateAccountRole("RoleName1").Return(nil).Once()
-	repoMock.EXPECT().CommentAccountRoleIfExists(mock.Anything, "RoleName1").Return(nil).Once()
+	repoMock.EXPECT().CreateAccountRole(mock.Anything, "RoleName1").Return(nil).Once()
+	repoMock.EXPECT().CommentAccountRoleIfExists(mock.Anything, mock.Anything, "RoleName1").Return(nil).Once()
 	expectGrantUsersToRole(repoMock, "RoleName1", "User1", "User2")
 	repoMock.EXPECT().GrantAccountRolesToAccountRole(mock.Anything, "RoleName1").Return(nil).Once()
 
-	repoMock.EXPECT().ExecuteGrantOnAccountRole("MONITOR", "WAREHOUSE WH1", "RoleName1", false).Return(nil).Once()
+	repoMock.EXPECT().ExecuteGrantOnAccountRole(mock.Anything, "MONITOR", "WAREHOUSE WH1", "RoleName1", false).Return(nil).Once()
 
 	access := map[string]*importer.AccessProvider{
 		"RoleName1": {
@@ -1071,12 +1113,12 @@ func generateAccessControls_integration(t *testing.T) {
 	// Given
 	repoMock := newMockDataAccessRepository(t)
 
-	repoMock.EXPECT().CreateAccountRole("RoleName1").Return(nil).Once()
-	repoMock.EXPECT().CommentAccountRoleIfExists(mock.Anything, "RoleName1").Return(nil).Once()
+	repoMock.EXPECT().CreateAccountRole(mock.Anything, "RoleName1").Return(nil).Once()
+	repoMock.EXPECT().CommentAccountRoleIfExists(mock.Anything, mock.Anything, "RoleName1").Return(nil).Once()
 	expectGrantUsersToRole(repoMock, "RoleName1", "User1")
 	repoMock.EXPECT().GrantAccountRolesToAccountRole(mock.Anything, "RoleName1").Return(nil).Once()
 
-	repoMock.EXPECT().ExecuteGrantOnAccountRole("USAGE", "INTEGRATION I1", "RoleName1", false).Return(nil).Once()
+	repoMock.EXPECT().ExecuteGrantOnAccountRole(mock.Anything, "USAGE", "INTEGRATION I1", "RoleName1", false).Return(nil).Once()
 
 	access := map[string]*importer.AccessProvider{
 		"RoleName1": {
@@ -1104,12 +1146,12 @@ func generateAccessControls_datasource(t *testing.T) {
 	// Given
 	repoMock := newMockDataAccessRepository(t)
 
-	repoMock.EXPECT().CreateAccountRole("RoleName1").Return(nil).Once()
-	repoMock.EXPECT().CommentAccountRoleIfExists(mock.Anything, "RoleName1").Return(nil).Once()
+	repoMock.EXPECT().CreateAccountRole(mock.Anything, "RoleName1").Return(nil).Once()
+	repoMock.EXPECT().CommentAccountRoleIfExists(mock.Anything, mock.Anything, "RoleName1").Return(nil).Once()
 	expectGrantUsersToRole(repoMock, "RoleName1", "User1", "User2")
 	repoMock.EXPECT().GrantAccountRolesToAccountRole(mock.Anything, "RoleName1").Return(nil).Once()
-	repoMock.EXPECT().GetInboundShares().Return([]DbEntity{}, nil).Once()
-	repoMock.EXPECT().GetDatabases().Return([]DbEntity{}, nil).Once()
+	repoMock.EXPECT().GetInboundShares(mock.Anything).Return([]DbEntity{}, nil).Once()
+	repoMock.EXPECT().GetDatabases(mock.Anything).Return([]DbEntity{}, nil).Once()
 
 	access := map[string]*importer.AccessProvider{
 		"RoleName1": {
@@ -1151,32 +1193,32 @@ func TestAccessSyncer_generateAccessControls_existingRole(t *testing.T) {
 	// Given
 	repoMock := newMockDataAccessRepository(t)
 
-	repoMock.EXPECT().CommentAccountRoleIfExists(mock.AnythingOfType("string"), "existingRole1").Return(nil).Once()
-	repoMock.EXPECT().GetGrantsOfAccountRole("existingRole1").Return([]GrantOfRole{
+	repoMock.EXPECT().CommentAccountRoleIfExists(mock.Anything, mock.AnythingOfType("string"), "existingRole1").Return(nil).Once()
+	repoMock.EXPECT().GetGrantsOfAccountRole(mock.Anything, "existingRole1").Return([]GrantOfRole{
 		{GrantedTo: "USER", GranteeName: "User1"},
 		{GrantedTo: "USER", GranteeName: "User3"},
 		{GrantedTo: "Role", GranteeName: "Role1"},
 		{GrantedTo: "Role", GranteeName: "Role3"},
 	}, nil).Once()
 
-	repoMock.EXPECT().GetGrantsToAccountRole("existingRole1").Return([]GrantToRole{}, nil).Once()
+	repoMock.EXPECT().GetGrantsToAccountRole(mock.Anything, "existingRole1").Return([]GrantToRole{}, nil).Once()
 
 	expectGrantUsersToRole(repoMock, "existingRole1", "User2")
 	repoMock.EXPECT().GrantAccountRolesToAccountRole(mock.Anything, "existingRole1", "Role2").Return(nil).Once()
 	repoMock.EXPECT().RevokeAccountRolesFromAccountRole(mock.Anything, "existingRole1", "Role3").Return(nil).Once()
 	repoMock.EXPECT().RevokeUsersFromAccountRole(mock.Anything, "existingRole1", "User3").Return(nil).Once()
 
-	repoMock.EXPECT().ExecuteGrantOnAccountRole("USAGE", "DATABASE DB1", "existingRole1", false).Return(nil).Once()
-	repoMock.EXPECT().ExecuteGrantOnAccountRole("USAGE", "SCHEMA DB1.Schema1", "existingRole1", false).Return(nil).Once()
-	repoMock.EXPECT().ExecuteGrantOnAccountRole("SELECT", "TABLE DB1.Schema1.Table1", "existingRole1", false).Return(nil).Once()
+	repoMock.EXPECT().ExecuteGrantOnAccountRole(mock.Anything, "USAGE", "DATABASE DB1", "existingRole1", false).Return(nil).Once()
+	repoMock.EXPECT().ExecuteGrantOnAccountRole(mock.Anything, "USAGE", "SCHEMA DB1.Schema1", "existingRole1", false).Return(nil).Once()
+	repoMock.EXPECT().ExecuteGrantOnAccountRole(mock.Anything, "SELECT", "TABLE DB1.Schema1.Table1", "existingRole1", false).Return(nil).Once()
 
-	repoMock.EXPECT().CommentDatabaseRoleIfExists(mock.AnythingOfType("string"), "TEST_DB", "existingDBRole1").Return(nil).Once()
-	repoMock.EXPECT().GetGrantsOfDatabaseRole("TEST_DB", "existingDBRole1").Return([]GrantOfRole{
+	repoMock.EXPECT().CommentDatabaseRoleIfExists(mock.Anything, mock.AnythingOfType("string"), "TEST_DB", "existingDBRole1").Return(nil).Once()
+	repoMock.EXPECT().GetGrantsOfDatabaseRole(mock.Anything, "TEST_DB", "existingDBRole1").Return([]GrantOfRole{
 		{GrantedTo: GrantTypeDatabaseRole, GranteeName: "TEST_DB.Role2"},
 		{GrantedTo: GrantTypeDatabaseRole, GranteeName: "TEST_DB.Role3"},
 	}, nil).Once()
 
-	repoMock.EXPECT().GetGrantsToDatabaseRole("TEST_DB", "existingDBRole1").Return([]GrantToRole{}, nil).Once()
+	repoMock.EXPECT().GetGrantsToDatabaseRole(mock.Anything, "TEST_DB", "existingDBRole1").Return([]GrantToRole{}, nil).Once()
 
 	expectGrantAccountOrDatabaseRolesToDatabaseRole(repoMock, true, "TEST_DB", "existingDBRole1", "DATABASEROLE###DATABASE:TEST_DB###ROLE:Role1")
 	expectGrantAccountOrDatabaseRolesToDatabaseRole(repoMock, false, "TEST_DB", "existingDBRole1")
@@ -1220,28 +1262,28 @@ func TestAccessSyncer_generateAccessControls_inheritance(t *testing.T) {
 	// Given
 	repoMock := newMockDataAccessRepository(t)
 
-	repoMock.EXPECT().CreateAccountRole("RoleName1").Return(nil).Once()
-	repoMock.EXPECT().CreateAccountRole("RoleName2").Return(nil).Once()
-	repoMock.EXPECT().CreateAccountRole("RoleName3").Return(nil).Once()
-	repoMock.EXPECT().CommentAccountRoleIfExists(mock.Anything, "RoleName1").Return(nil).Once()
-	repoMock.EXPECT().CommentAccountRoleIfExists(mock.Anything, "RoleName2").Return(nil).Once()
-	repoMock.EXPECT().CommentAccountRoleIfExists(mock.Anything, "RoleName3").Return(nil).Once()
+	repoMock.EXPECT().CreateAccountRole(mock.Anything, "RoleName1").Return(nil).Once()
+	repoMock.EXPECT().CreateAccountRole(mock.Anything, "RoleName2").Return(nil).Once()
+	repoMock.EXPECT().CreateAccountRole(mock.Anything, "RoleName3").Return(nil).Once()
+	repoMock.EXPECT().CommentAccountRoleIfExists(mock.Anything, mock.Anything, "RoleName1").Return(nil).Once()
+	repoMock.EXPECT().CommentAccountRoleIfExists(mock.Anything, mock.Anything, "RoleName2").Return(nil).Once()
+	repoMock.EXPECT().CommentAccountRoleIfExists(mock.Anything, mock.Anything, "RoleName3").Return(nil).Once()
 	expectGrantUsersToRole(repoMock, "RoleName3", "User1")
 	repoMock.EXPECT().GrantAccountRolesToAccountRole(mock.Anything, "RoleName1", "RoleName2").Return(nil).Once()
 	repoMock.EXPECT().GrantAccountRolesToAccountRole(mock.Anything, "RoleName2", "RoleName3").Return(nil).Once()
 	repoMock.EXPECT().GrantAccountRolesToAccountRole(mock.Anything, "RoleName3").Return(nil).Once()
 
-	repoMock.EXPECT().ExecuteGrantOnAccountRole("USAGE", "DATABASE DB1", "RoleName1", false).Return(nil).Once()
-	repoMock.EXPECT().ExecuteGrantOnAccountRole("USAGE", "SCHEMA DB1.Schema1", "RoleName1", false).Return(nil).Once()
-	repoMock.EXPECT().ExecuteGrantOnAccountRole("SELECT", "TABLE DB1.Schema1.Table1", "RoleName1", false).Return(nil).Once()
+	repoMock.EXPECT().ExecuteGrantOnAccountRole(mock.Anything, "USAGE", "DATABASE DB1", "RoleName1", false).Return(nil).Once()
+	repoMock.EXPECT().ExecuteGrantOnAccountRole(mock.Anything, "USAGE", "SCHEMA DB1.Schema1", "RoleName1", false).Return(nil).Once()
+	repoMock.EXPECT().ExecuteGrantOnAccountRole(mock.Anything, "SELECT", "TABLE DB1.Schema1.Table1", "RoleName1", false).Return(nil).Once()
 
-	repoMock.EXPECT().ExecuteGrantOnAccountRole("USAGE", "DATABASE DB1", "RoleName2", false).Return(nil).Once()
-	repoMock.EXPECT().ExecuteGrantOnAccountRole("USAGE", "SCHEMA DB1.Schema1", "RoleName2", false).Return(nil).Once()
-	repoMock.EXPECT().ExecuteGrantOnAccountRole("SELECT", "TABLE DB1.Schema1.Table2", "RoleName2", false).Return(nil).Once()
+	repoMock.EXPECT().ExecuteGrantOnAccountRole(mock.Anything, "USAGE", "DATABASE DB1", "RoleName2", false).Return(nil).Once()
+	repoMock.EXPECT().ExecuteGrantOnAccountRole(mock.Anything, "USAGE", "SCHEMA DB1.Schema1", "RoleName2", false).Return(nil).Once()
+	repoMock.EXPECT().ExecuteGrantOnAccountRole(mock.Anything, "SELECT", "TABLE DB1.Schema1.Table2", "RoleName2", false).Return(nil).Once()
 
-	repoMock.EXPECT().ExecuteGrantOnAccountRole("USAGE", "DATABASE DB1", "RoleName3", false).Return(nil).Once()
-	repoMock.EXPECT().ExecuteGrantOnAccountRole("USAGE", "SCHEMA DB1.Schema1", "RoleName3", false).Return(nil).Once()
-	repoMock.EXPECT().ExecuteGrantOnAccountRole("SELECT", "TABLE DB1.Schema1.Table3", "RoleName3", false).Return(nil).Once()
+	repoMock.EXPECT().ExecuteGrantOnAccountRole(mock.Anything, "USAGE", "DATABASE DB1", "RoleName3", false).Return(nil).Once()
+	repoMock.EXPECT().ExecuteGrantOnAccountRole(mock.Anything, "USAGE", "SCHEMA DB1.Schema1", "RoleName3", false).Return(nil).Once()
+	repoMock.EXPECT().ExecuteGrantOnAccountRole(mock.Anything, "SELECT", "TABLE DB1.Schema1.Table3", "RoleName3", false).Return(nil).Once()
 
 	access := map[string]*importer.AccessProvider{
 		"RoleName1": {
@@ -1292,18 +1334,19 @@ func TestAccessSyncer_generateAccessControls_rename(t *testing.T) {
 	feedbackHandler := mocks.NewSimpleAccessProviderFeedbackHandler(t)
 
 	expectGrantUsersToRole(repoMock, "NewRoleName", "User1")
-	repoMock.EXPECT().CommentAccountRoleIfExists(mock.Anything, "NewRoleName").Return(nil).Once()
-	repoMock.EXPECT().RenameAccountRole("OldRoleName", "NewRoleName").Return(nil).Once()
-	repoMock.EXPECT().GetGrantsOfAccountRole("NewRoleName").Return([]GrantOfRole{}, nil).Once()
-	repoMock.EXPECT().GetGrantsToAccountRole("NewRoleName").Return([]GrantToRole{}, nil).Once()
-
-	repoMock.EXPECT().CommentDatabaseRoleIfExists(mock.AnythingOfType("string"), "TEST_DB", "newDBRole").Return(nil).Once()
-	repoMock.EXPECT().RenameDatabaseRole("TEST_DB", "oldDBRole", "newDBRole").Return(nil).Once()
-	repoMock.EXPECT().GetGrantsOfDatabaseRole("TEST_DB", "newDBRole").Return([]GrantOfRole{
+	repoMock.EXPECT().CommentAccountRoleIfExists(mock.Anything, mock.Anything, "NewRoleName").Return(nil).Once()
+	repoMock.EXPECT().RenameAccountRole(mock.Anything, "OldRoleName", "NewRoleName").Return(nil).Once()
+	repoMock.EXPECT().ExecutionPlan().Return(nil)
+	repoMock.EXPECT().GetGrantsOfAccountRole(mock.Anything, "NewRoleName").Return([]GrantOfRole{}, nil).Once()
+	repoMock.EXPECT().GetGrantsToAccountRole(mock.Anything, "NewRoleName").Return([]GrantToRole{}, nil).Once()
+
+	repoMock.EXPECT().CommentDatabaseRoleIfExists(mock.Anything, mock.AnythingOfType("string"), "TEST_DB", "newDBRole").Return(nil).Once()
+	repoMock.EXPECT().RenameDatabaseRole(mock.Anything, "TEST_DB", "oldDBRole", "newDBRole").Return(nil).Once()
+	repoMock.EXPECT().GetGrantsOfDatabaseRole(mock.Anything, "TEST_DB", "newDBRole").Return([]GrantOfRole{
 		{GrantedTo: GrantTypeDatabaseRole, GranteeName: "TEST_DB.Role1"},
 		{GrantedTo: GrantTypeDatabaseRole, GranteeName: "TEST_DB.Role2"},
 	}, nil).Once()
-	repoMock.EXPECT().GetGrantsToDatabaseRole("TEST_DB", "newDBRole").Return([]GrantToRole{}, nil).Once()
+	repoMock.EXPECT().GetGrantsToDatabaseRole(mock.Anything, "TEST_DB", "newDBRole").Return([]GrantToRole{}, nil).Once()
 
 	access := map[string]*importer.AccessProvider{
 		"NewRoleName": {
@@ -1357,10 +1400,10 @@ func TestAccessSyncer_generateAccessControls_renameNewExists(t *testing.T) {
 	feedbackHandler := mocks.NewSimpleAccessProviderFeedbackHandler(t)
 
 	expectGrantUsersToRole(repoMock, "NewRoleName", "User1")
-	repoMock.EXPECT().CommentAccountRoleIfExists(mock.Anything, "NewRoleName").Return(nil).Once()
-	repoMock.EXPECT().DropAccountRole("OldRoleName").Return(nil).Once()
-	repoMock.EXPECT().GetGrantsOfAccountRole("NewRoleName").Return([]GrantOfRole{}, nil).Once()
-	repoMock.EXPECT().GetGrantsToAccountRole("NewRoleName").Return([]GrantToRole{}, nil).Once()
+	repoMock.EXPECT().CommentAccountRoleIfExists(mock.Anything, mock.Anything, "NewRoleName").Return(nil).Once()
+	repoMock.EXPECT().DropAccountRole(mock.Anything, "OldRoleName").Return(nil).Once()
+	repoMock.EXPECT().GetGrantsOfAccountRole(mock.Anything, "NewRoleName").Return([]GrantOfRole{}, nil).Once()
+	repoMock.EXPECT().GetGrantsToAccountRole(mock.Anything, "NewRoleName").Return([]GrantToRole{}, nil).Once()
 
 	access := map[string]*importer.AccessProvider{
 		"NewRoleName": {
@@ -1400,12 +1443,12 @@ func TestAccessSyncer_generateAccessControls_renameOldAlreadyTaken(t *testing.T)
 	repoMock := newMockDataAccessRepository(t)
 	feedbackHandler := mocks.NewSimpleAccessProviderFeedbackHandler(t)
 
-	repoMock.EXPECT().CreateAccountRole("NewRoleName").Return(nil).Once()
+	repoMock.EXPECT().CreateAccountRole(mock.Anything, "NewRoleName").Return(nil).Once()
 	expectGrantUsersToRole(repoMock, "NewRoleName", "User1")
-	repoMock.EXPECT().CommentAccountRoleIfExists(mock.Anything, "NewRoleName").Return(nil).Once()
-	repoMock.EXPECT().CommentAccountRoleIfExists(mock.Anything, "OldRoleName").Return(nil).Once()
-	repoMock.EXPECT().GetGrantsOfAccountRole("OldRoleName").Return([]GrantOfRole{}, nil).Once()
-	repoMock.EXPECT().GetGrantsToAccountRole("OldRoleName").Return([]GrantToRole{}, nil).Once()
+	repoMock.EXPECT().CommentAccountRoleIfExists(mock.Anything, mock.Anything, "NewRoleName").Return(nil).Once()
+	repoMock.EXPECT().CommentAccountRoleIfExists(mock.Anything, mock.Anything, "OldRoleName").Return(nil).Once()
+	repoMock.EXPECT().GetGrantsOfAccountRole(mock.Anything, "OldRoleName").Return([]GrantOfRole{}, nil).Once()
+	repoMock.EXPECT().GetGrantsToAccountRole(mock.Anything, "OldRoleName").Return([]GrantToRole{}, nil).Once()
 	repoMock.EXPECT().GrantAccountRolesToAccountRole(mock.Anything, "NewRoleName").Return(nil).Once()
 
 	access := map[string]*importer.AccessProvider{
@@ -1519,7 +1562,7 @@ func Test_RenameRole(t *testing.T) {
 			name: "account roles - basic",
 			fields: fields{
 				setup: func(repoMock *mockDataAccessRepository, feedbackHandlerMock *mocks.SimpleAccessProviderFeedbackHandler) {
-					repoMock.EXPECT().RenameAccountRole("test", "test2").Return(nil).Once()
+					repoMock.EXPECT().RenameAccountRole(mock.Anything, "test", "test2").Return(nil).Once()
 				},
 			},
 			args: args{
@@ -1533,7 +1576,7 @@ func Test_RenameRole(t *testing.T) {
 			name: "database roles - basic",
 			fields: fields{
 				setup: func(repoMock *mockDataAccessRepository, feedbackHandlerMock *mocks.SimpleAccessProviderFeedbackHandler) {
-					repoMock.EXPECT().RenameDatabaseRole("TEST_DB", "oldDBRole", "newDBRole").Return(nil).Once()
+					repoMock.EXPECT().RenameDatabaseRole(mock.Anything, "TEST_DB", "oldDBRole", "newDBRole").Return(nil).Once()
 				},
 			},
 			args: args{
@@ -1621,7 +1664,7 @@ func Test_RenameRole(t *testing.T) {
 			syncer := createBasicToTargetSyncer(repoMock, nil, nil, nil)
 
 			// When
-			err := syncer.renameRole(tt.args.oldName, tt.args.newName, tt.args.apType)
+			err := syncer.renameRole(context.Background(), tt.args.oldName, tt.args.newName, tt.args.apType)
 
 			// Then
 			tt.wantErr(t, err)
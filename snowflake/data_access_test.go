@@ -45,54 +45,55 @@ func TestAccessSyncer_SyncAccessProvidersFromTarget(t *testing.T) {
 
 					repoMock.EXPECT().Close().Return(nil).Once()
 					repoMock.EXPECT().TotalQueryTime().Return(time.Minute).Once()
-					repoMock.EXPECT().GetInboundShares().Return([]DbEntity{
+					repoMock.EXPECT().TotalRetries().Return(0).Once()
+					repoMock.EXPECT().GetInboundShares(mock.Anything).Return([]DbEntity{
 						{Name: "Share1"}, {Name: "Share2"},
 					}, nil).Once()
-					repoMock.EXPECT().GetOutboundShares().Return([]ShareEntity{}, nil).Once()
-					repoMock.EXPECT().GetAccountRoles().Return([]RoleEntity{
+					repoMock.EXPECT().GetOutboundShares(mock.Anything).Return([]ShareEntity{}, nil).Once()
+					repoMock.EXPECT().GetAccountRoles(mock.Anything).Return([]RoleEntity{
 						{Name: "Role1", AssignedToUsers: 2, GrantedRoles: 3, GrantedToRoles: 1, Owner: "Owner1"},
 						{Name: "Role2", AssignedToUsers: 3, GrantedRoles: 2, GrantedToRoles: 1, Owner: "Owner2"},
 						{Name: "Role3", AssignedToUsers: 1, GrantedRoles: 1, GrantedToRoles: 1, Owner: "ExternalOwner1"},
 					}, nil).Once()
-					repoMock.EXPECT().GetGrantsOfAccountRole("Role1").Return([]GrantOfRole{
+					repoMock.EXPECT().GetGrantsOfAccountRole(mock.Anything, "Role1").Return([]GrantOfRole{
 						{GrantedTo: "USER", GranteeName: "GranteeRole1Number1"},
 						{GrantedTo: "ROLE", GranteeName: "GranteeRole1Number2"},
 					}, nil).Once()
-					repoMock.EXPECT().GetGrantsToAccountRole("Role1").Return([]GrantToRole{
+					repoMock.EXPECT().GetGrantsToAccountRole(mock.Anything, "Role1").Return([]GrantToRole{
 						{GrantedOn: "SCHEMA", Name: "Share2.GranteeRole1Schema", Privilege: "USAGE"},
 						{GrantedOn: "SCHEMA", Name: "Share2.GranteeRole1Schema", Privilege: "READ"},
 						{GrantedOn: "TABLE", Name: "DB1.GranteeRole1Table", Privilege: "SELECT"},
 						{GrantedOn: "MATERIALIZED_VIEW", Name: "DB1.GranteeRole1MatView", Privilege: "SELECT"},
 					}, nil).Once()
-					repoMock.EXPECT().GetGrantsOfAccountRole("Role2").Return([]GrantOfRole{
+					repoMock.EXPECT().GetGrantsOfAccountRole(mock.Anything, "Role2").Return([]GrantOfRole{
 						{GrantedTo: "USER", GranteeName: "GranteeRole2"},
 					}, nil).Once()
-					repoMock.EXPECT().GetGrantsToAccountRole("Role2").Return([]GrantToRole{
+					repoMock.EXPECT().GetGrantsToAccountRole(mock.Anything, "Role2").Return([]GrantToRole{
 						{GrantedOn: "GrandOnRole2Number1", Name: "GranteeRole2", Privilege: "USAGE"},
 					}, nil).Once()
-					repoMock.EXPECT().GetGrantsOfAccountRole("Role3").Return([]GrantOfRole{
+					repoMock.EXPECT().GetGrantsOfAccountRole(mock.Anything, "Role3").Return([]GrantOfRole{
 						{GrantedTo: "ROLE", GranteeName: "\"GranteeRole.3\""},
 					}, nil).Once()
-					repoMock.EXPECT().GetGrantsToAccountRole("Role3").Return([]GrantToRole{
+					repoMock.EXPECT().GetGrantsToAccountRole(mock.Anything, "Role3").Return([]GrantToRole{
 						{GrantedOn: "GrandOnRole3Number1", Name: "GranteeRole3", Privilege: "WRITE"},
 					}, nil).Once()
 
-					repoMock.EXPECT().GetPolicies("MASKING").Return([]PolicyEntity{
+					repoMock.EXPECT().GetPolicies(mock.Anything, "MASKING").Return([]PolicyEntity{
 						{Name: "MaskingPolicy1", SchemaName: "schema1", DatabaseName: "DB", Owner: "MaskingOwner", Kind: "MASKING_POLICY"},
 					}, nil).Once()
-					repoMock.EXPECT().GetPolicies("ROW ACCESS").Return([]PolicyEntity{
+					repoMock.EXPECT().GetPolicies(mock.Anything, "ROW ACCESS").Return([]PolicyEntity{
 						{Name: "RowAccess1", SchemaName: "schema2", DatabaseName: "DB", Owner: "RowAccessOwner", Kind: "ROW_ACCESS_POLICY"},
 					}, nil).Once()
-					repoMock.EXPECT().DescribePolicy("MASKING", "DB", "schema1", "MaskingPolicy1").Return([]DescribePolicyEntity{
+					repoMock.EXPECT().DescribePolicy(mock.Anything, "MASKING", "DB", "schema1", "MaskingPolicy1").Return([]DescribePolicyEntity{
 						{Name: "DescribePolicy1", Body: "PolicyBody 1"},
 					}, nil).Once()
-					repoMock.EXPECT().DescribePolicy("ROW ACCESS", "DB", "schema2", "RowAccess1").Return([]DescribePolicyEntity{
+					repoMock.EXPECT().DescribePolicy(mock.Anything, "ROW ACCESS", "DB", "schema2", "RowAccess1").Return([]DescribePolicyEntity{
 						{Name: "DescribePolicy2", Body: "Row Access Policy Body"},
 					}, nil).Once()
-					repoMock.EXPECT().GetPolicyReferences("DB", "schema1", "MaskingPolicy1").Return([]PolicyReferenceEntity{
+					repoMock.EXPECT().GetPolicyReferences(mock.Anything, "DB", "schema1", "MaskingPolicy1").Return([]PolicyReferenceEntity{
 						{POLICY_DB: "PolicyDB"},
 					}, nil).Once()
-					repoMock.EXPECT().GetPolicyReferences("DB", "schema2", "RowAccess1").Return([]PolicyReferenceEntity{
+					repoMock.EXPECT().GetPolicyReferences(mock.Anything, "DB", "schema2", "RowAccess1").Return([]PolicyReferenceEntity{
 						{POLICY_DB: "PolicyDB"},
 					}, nil).Once()
 
@@ -207,23 +208,24 @@ func TestAccessSyncer_SyncAccessProvidersFromTarget(t *testing.T) {
 				setup: func(repoMock *mockDataAccessRepository) *mocks.SimpleAccessProviderHandler {
 					fileCreator := mocks.NewSimpleAccessProviderHandler(t, 3)
 
-					repoMock.EXPECT().GetDatabases().Return([]DbEntity{
+					repoMock.EXPECT().GetDatabases(mock.Anything).Return([]DbEntity{
 						{Name: "TEST_DB"},
 					}, nil).Once()
-					repoMock.EXPECT().GetDatabaseRoles("TEST_DB").Return([]RoleEntity{
+					repoMock.EXPECT().GetDatabaseRoles(mock.Anything, "TEST_DB").Return([]RoleEntity{
 						{Name: "DatabaseRole1", AssignedToUsers: 0, GrantedRoles: 0, GrantedToRoles: 1, Owner: "Owner1"},
 					}, nil).Once()
-					repoMock.EXPECT().GetGrantsOfDatabaseRole("TEST_DB", "DatabaseRole1").Return([]GrantOfRole{
+					repoMock.EXPECT().GetGrantsOfDatabaseRole(mock.Anything, "TEST_DB", "DatabaseRole1").Return([]GrantOfRole{
 						{GrantedTo: "SHARE", GranteeName: "Share1"},
 					}, nil).Once()
-					repoMock.EXPECT().GetGrantsToDatabaseRole("TEST_DB", "DatabaseRole1").Return([]GrantToRole{
+					repoMock.EXPECT().GetGrantsToDatabaseRole(mock.Anything, "TEST_DB", "DatabaseRole1").Return([]GrantToRole{
 						{GrantedOn: "TABLE", Name: "TEST_DB.Schema1.Table1", Privilege: "UPDATE"},
 					}, nil).Once()
 
 					repoMock.EXPECT().Close().Return(nil).Once()
 					repoMock.EXPECT().TotalQueryTime().Return(time.Minute).Once()
-					repoMock.EXPECT().GetInboundShares().Return([]DbEntity{}, nil)
-					repoMock.EXPECT().GetOutboundShares().Return([]ShareEntity{
+					repoMock.EXPECT().TotalRetries().Return(0).Once()
+					repoMock.EXPECT().GetInboundShares(mock.Anything).Return([]DbEntity{}, nil)
+					repoMock.EXPECT().GetOutboundShares(mock.Anything).Return([]ShareEntity{
 						{
 							Name:         "Share1",
 							Owner:        "owner1",
@@ -237,15 +239,15 @@ func TestAccessSyncer_SyncAccessProvidersFromTarget(t *testing.T) {
 							DatabaseName: "TEST_DB",
 						},
 					}, nil).Once()
-					repoMock.EXPECT().GetAccountRoles().Return([]RoleEntity{}, nil).Once()
+					repoMock.EXPECT().GetAccountRoles(mock.Anything).Return([]RoleEntity{}, nil).Once()
 
-					repoMock.EXPECT().GetGrantsToShare("Share1").Return([]GrantToRole{
+					repoMock.EXPECT().GetGrantsToShare(mock.Anything, "Share1").Return([]GrantToRole{
 						{GrantedOn: "SCHEMA", Name: "TEST_DB.Schema1", Privilege: "USAGE"},
 						{GrantedOn: "TABLE", Name: "TEST_DB.Schema1.Table1", Privilege: "SELECT"},
 					}, nil).Once()
 
-					repoMock.EXPECT().GetPolicies("MASKING").Return([]PolicyEntity{}, nil).Once()
-					repoMock.EXPECT().GetPolicies("ROW ACCESS").Return([]PolicyEntity{}, nil).Once()
+					repoMock.EXPECT().GetPolicies(mock.Anything, "MASKING").Return([]PolicyEntity{}, nil).Once()
+					repoMock.EXPECT().GetPolicies(mock.Anything, "ROW ACCESS").Return([]PolicyEntity{}, nil).Once()
 
 					return fileCreator
 				},
@@ -321,84 +323,85 @@ func TestAccessSyncer_SyncAccessProvidersFromTarget(t *testing.T) {
 
 					repoMock.EXPECT().Close().Return(nil).Once()
 					repoMock.EXPECT().TotalQueryTime().Return(time.Minute).Once()
-					repoMock.EXPECT().GetInboundShares().Return([]DbEntity{
+					repoMock.EXPECT().TotalRetries().Return(0).Once()
+					repoMock.EXPECT().GetInboundShares(mock.Anything).Return([]DbEntity{
 						{Name: "Share1"},
 					}, nil).Twice()
 
-					repoMock.EXPECT().GetDatabases().Return([]DbEntity{
+					repoMock.EXPECT().GetDatabases(mock.Anything).Return([]DbEntity{
 						{Name: "SNOWFLAKE"},
 						{Name: "TEST_DB"},
 					}, nil).Once()
-					repoMock.EXPECT().GetDatabaseRoles("TEST_DB").Return([]RoleEntity{
+					repoMock.EXPECT().GetDatabaseRoles(mock.Anything, "TEST_DB").Return([]RoleEntity{
 						{Name: "DatabaseRole1", AssignedToUsers: 0, GrantedRoles: 0, GrantedToRoles: 1, Owner: "Owner1"},
 						{Name: "DatabaseRole2", AssignedToUsers: 0, GrantedRoles: 1, GrantedToRoles: 0, Owner: "Owner2"},
 						{Name: "DatabaseRole3", AssignedToUsers: 0, GrantedRoles: 1, GrantedToRoles: 0, Owner: "Owner2"},
 					}, nil).Once()
-					repoMock.EXPECT().GetDatabaseRoles("Share1").Return([]RoleEntity{}, nil).Once()
-					repoMock.EXPECT().GetAccountRoles().Return([]RoleEntity{
+					repoMock.EXPECT().GetDatabaseRoles(mock.Anything, "Share1").Return([]RoleEntity{}, nil).Once()
+					repoMock.EXPECT().GetAccountRoles(mock.Anything).Return([]RoleEntity{
 						{Name: "Role1", AssignedToUsers: 2, GrantedRoles: 3, GrantedToRoles: 1, Owner: "Owner1"},
 						{Name: "Role2", AssignedToUsers: 3, GrantedRoles: 2, GrantedToRoles: 1, Owner: "Owner2"},
 						{Name: "Role3", AssignedToUsers: 1, GrantedRoles: 1, GrantedToRoles: 1, Owner: "ExternalOwner1"},
 					}, nil).Once()
-					repoMock.EXPECT().GetOutboundShares().Return([]ShareEntity{}, nil).Once()
-					repoMock.EXPECT().GetGrantsOfAccountRole("Role1").Return([]GrantOfRole{
+					repoMock.EXPECT().GetOutboundShares(mock.Anything).Return([]ShareEntity{}, nil).Once()
+					repoMock.EXPECT().GetGrantsOfAccountRole(mock.Anything, "Role1").Return([]GrantOfRole{
 						{GrantedTo: "USER", GranteeName: "GranteeRole1Number1"},
 						{GrantedTo: "ROLE", GranteeName: "GranteeRole1Number2"},
 					}, nil).Once()
-					repoMock.EXPECT().GetGrantsToAccountRole("Role1").Return([]GrantToRole{
+					repoMock.EXPECT().GetGrantsToAccountRole(mock.Anything, "Role1").Return([]GrantToRole{
 						{GrantedOn: "SCHEMA", Name: "Share2.GranteeRole1Schema", Privilege: "USAGE"},
 						{GrantedOn: "SCHEMA", Name: "Share2.GranteeRole1Schema", Privilege: "READ"},
 						{GrantedOn: "TABLE", Name: "DB1.GranteeRole1Table", Privilege: "SELECT"},
 						{GrantedOn: "MATERIALIZED_VIEW", Name: "DB1.GranteeRole1MatView", Privilege: "SELECT"},
 					}, nil).Once()
-					repoMock.EXPECT().GetGrantsOfAccountRole("Role2").Return([]GrantOfRole{
+					repoMock.EXPECT().GetGrantsOfAccountRole(mock.Anything, "Role2").Return([]GrantOfRole{
 						{GrantedTo: "USER", GranteeName: "GranteeRole2"},
 					}, nil).Once()
-					repoMock.EXPECT().GetGrantsToAccountRole("Role2").Return([]GrantToRole{
+					repoMock.EXPECT().GetGrantsToAccountRole(mock.Anything, "Role2").Return([]GrantToRole{
 						{GrantedOn: "GrandOnRole2Number1", Name: "GranteeRole2", Privilege: "USAGE"},
 					}, nil).Once()
-					repoMock.EXPECT().GetGrantsOfAccountRole("Role3").Return([]GrantOfRole{
+					repoMock.EXPECT().GetGrantsOfAccountRole(mock.Anything, "Role3").Return([]GrantOfRole{
 						{GrantedTo: "ROLE", GranteeName: "\"GranteeRole.3\""},
 					}, nil).Once()
-					repoMock.EXPECT().GetGrantsToAccountRole("Role3").Return([]GrantToRole{
+					repoMock.EXPECT().GetGrantsToAccountRole(mock.Anything, "Role3").Return([]GrantToRole{
 						{GrantedOn: "GrandOnRole3Number1", Name: "GranteeRole3", Privilege: "WRITE"},
 					}, nil).Once()
 
-					repoMock.EXPECT().GetGrantsOfDatabaseRole("TEST_DB", "DatabaseRole1").Return([]GrantOfRole{
+					repoMock.EXPECT().GetGrantsOfDatabaseRole(mock.Anything, "TEST_DB", "DatabaseRole1").Return([]GrantOfRole{
 						{GrantedTo: "ROLE", GranteeName: "GranteeDatabaseRole1Number2"},
 						{GrantedTo: GrantTypeDatabaseRole, GranteeName: "TEST_DB.DatabaseRole2"},
 					}, nil).Once()
-					repoMock.EXPECT().GetGrantsToDatabaseRole("TEST_DB", "DatabaseRole1").Return([]GrantToRole{
+					repoMock.EXPECT().GetGrantsToDatabaseRole(mock.Anything, "TEST_DB", "DatabaseRole1").Return([]GrantToRole{
 						{GrantedOn: "TABLE", Name: "TEST_DB.GranteeRole1Table", Privilege: "SELECT"},
 						{GrantedOn: "MATERIALIZED_VIEW", Name: "TEST_DB.GranteeRole1MatView", Privilege: "SELECT"},
 					}, nil).Once()
 
-					repoMock.EXPECT().GetGrantsOfDatabaseRole("TEST_DB", "DatabaseRole2").Return([]GrantOfRole{}, nil).Once()
-					repoMock.EXPECT().GetGrantsToDatabaseRole("TEST_DB", "DatabaseRole2").Return([]GrantToRole{
+					repoMock.EXPECT().GetGrantsOfDatabaseRole(mock.Anything, "TEST_DB", "DatabaseRole2").Return([]GrantOfRole{}, nil).Once()
+					repoMock.EXPECT().GetGrantsToDatabaseRole(mock.Anything, "TEST_DB", "DatabaseRole2").Return([]GrantToRole{
 						{GrantedOn: "GrandOnDatabaseRole2Number1", Name: "GranteeDatabaseRole2", Privilege: "USAGE"},
 					}, nil).Once()
 
-					repoMock.EXPECT().GetGrantsOfDatabaseRole("TEST_DB", "DatabaseRole3").Return([]GrantOfRole{}, nil).Once()
-					repoMock.EXPECT().GetGrantsToDatabaseRole("TEST_DB", "DatabaseRole3").Return([]GrantToRole{
+					repoMock.EXPECT().GetGrantsOfDatabaseRole(mock.Anything, "TEST_DB", "DatabaseRole3").Return([]GrantOfRole{}, nil).Once()
+					repoMock.EXPECT().GetGrantsToDatabaseRole(mock.Anything, "TEST_DB", "DatabaseRole3").Return([]GrantToRole{
 						{GrantedOn: "MATERIALIZED_VIEW", Name: "TEST_DB.GranteeRole3MatView", Privilege: "SELECT"},
 					}, nil).Once()
 
-					repoMock.EXPECT().GetPolicies("MASKING").Return([]PolicyEntity{
+					repoMock.EXPECT().GetPolicies(mock.Anything, "MASKING").Return([]PolicyEntity{
 						{Name: "MaskingPolicy1", SchemaName: "schema1", DatabaseName: "DB", Owner: "MaskingOwner", Kind: "MASKING_POLICY"},
 					}, nil).Once()
-					repoMock.EXPECT().GetPolicies("ROW ACCESS").Return([]PolicyEntity{
+					repoMock.EXPECT().GetPolicies(mock.Anything, "ROW ACCESS").Return([]PolicyEntity{
 						{Name: "RowAccess1", SchemaName: "schema2", DatabaseName: "DB", Owner: "RowAccessOwner", Kind: "ROW_ACCESS_POLICY"},
 					}, nil).Once()
-					repoMock.EXPECT().DescribePolicy("MASKING", "DB", "schema1", "MaskingPolicy1").Return([]DescribePolicyEntity{
+					repoMock.EXPECT().DescribePolicy(mock.Anything, "MASKING", "DB", "schema1", "MaskingPolicy1").Return([]DescribePolicyEntity{
 						{Name: "DescribePolicy1", Body: "PolicyBody 1"},
 					}, nil).Once()
-					repoMock.EXPECT().DescribePolicy("ROW ACCESS", "DB", "schema2", "RowAccess1").Return([]DescribePolicyEntity{
+					repoMock.EXPECT().DescribePolicy(mock.Anything, "ROW ACCESS", "DB", "schema2", "RowAccess1").Return([]DescribePolicyEntity{
 						{Name: "DescribePolicy2", Body: "Row Access Policy Body"},
 					}, nil).Once()
-					repoMock.EXPECT().GetPolicyReferences("DB", "schema1", "MaskingPolicy1").Return([]PolicyReferenceEntity{
+					repoMock.EXPECT().GetPolicyReferences(mock.Anything, "DB", "schema1", "MaskingPolicy1").Return([]PolicyReferenceEntity{
 						{POLICY_DB: "PolicyDB"},
 					}, nil).Once()
-					repoMock.EXPECT().GetPolicyReferences("DB", "schema2", "RowAccess1").Return([]PolicyReferenceEntity{
+					repoMock.EXPECT().GetPolicyReferences(mock.Anything, "DB", "schema2", "RowAccess1").Return([]PolicyReferenceEntity{
 						{POLICY_DB: "PolicyDB"},
 					}, nil).Once()
 
@@ -603,42 +606,43 @@ func TestAccessSyncer_SyncAccessProvidersFromTarget(t *testing.T) {
 
 					repoMock.EXPECT().Close().Return(nil).Once()
 					repoMock.EXPECT().TotalQueryTime().Return(time.Minute).Once()
-					repoMock.EXPECT().GetInboundShares().Return([]DbEntity{
+					repoMock.EXPECT().TotalRetries().Return(0).Once()
+					repoMock.EXPECT().GetInboundShares(mock.Anything).Return([]DbEntity{
 						{Name: "Share1"}, {Name: "Share2"},
 					}, nil).Once()
-					repoMock.EXPECT().GetOutboundShares().Return([]ShareEntity{}, nil).Once()
-					repoMock.EXPECT().GetAccountRoles().Return([]RoleEntity{
+					repoMock.EXPECT().GetOutboundShares(mock.Anything).Return([]ShareEntity{}, nil).Once()
+					repoMock.EXPECT().GetAccountRoles(mock.Anything).Return([]RoleEntity{
 						{Name: "Role1", AssignedToUsers: 2, GrantedRoles: 3, GrantedToRoles: 1, Owner: "Owner1"},
 						{Name: "Role3", AssignedToUsers: 1, GrantedRoles: 1, GrantedToRoles: 1, Owner: "ExternalOwner1"},
 					}, nil).Once()
-					repoMock.EXPECT().GetGrantsOfAccountRole("Role1").Return([]GrantOfRole{
+					repoMock.EXPECT().GetGrantsOfAccountRole(mock.Anything, "Role1").Return([]GrantOfRole{
 						{GrantedTo: "USER", GranteeName: "GranteeRole1Number1"},
 						{GrantedTo: "ROLE", GranteeName: "GranteeRole1Number2"},
 					}, nil).Once()
-					repoMock.EXPECT().GetGrantsToAccountRole("Role1").Return([]GrantToRole{
+					repoMock.EXPECT().GetGrantsToAccountRole(mock.Anything, "Role1").Return([]GrantToRole{
 						{GrantedOn: "SCHEMA", Name: "Share2.GranteeRole1Schema", Privilege: "USAGE"},
 						{GrantedOn: "SCHEMA", Name: "Share2.GranteeRole1Schema", Privilege: "READ"},
 						{GrantedOn: "TABLE", Name: "DB1.GranteeRole1Table", Privilege: "SELECT"},
 					}, nil).Once()
-					repoMock.EXPECT().GetGrantsToAccountRole("Role3").Return([]GrantToRole{
+					repoMock.EXPECT().GetGrantsToAccountRole(mock.Anything, "Role3").Return([]GrantToRole{
 						{GrantedOn: "GrandOnRole3Number1", Name: "GranteeRole3", Privilege: "WRITE"},
 					}, nil).Once()
-					repoMock.EXPECT().GetPolicies("MASKING").Return([]PolicyEntity{
+					repoMock.EXPECT().GetPolicies(mock.Anything, "MASKING").Return([]PolicyEntity{
 						{Name: "MaskingPolicy1", SchemaName: "schema1", DatabaseName: "DB", Owner: "MaskingOwner", Kind: "MASKING_POLICY"},
 					}, nil).Once()
-					repoMock.EXPECT().GetPolicies("ROW ACCESS").Return([]PolicyEntity{
+					repoMock.EXPECT().GetPolicies(mock.Anything, "ROW ACCESS").Return([]PolicyEntity{
 						{Name: "RowAccess1", SchemaName: "schema2", DatabaseName: "DB", Owner: "RowAccessOwner", Kind: "ROW_ACCESS_POLICY"},
 					}, nil).Once()
-					repoMock.EXPECT().DescribePolicy("MASKING", "DB", "schema1", "MaskingPolicy1").Return([]DescribePolicyEntity{
+					repoMock.EXPECT().DescribePolicy(mock.Anything, "MASKING", "DB", "schema1", "MaskingPolicy1").Return([]DescribePolicyEntity{
 						{Name: "DescribePolicy1", Body: "PolicyBody 1"},
 					}, nil).Once()
-					repoMock.EXPECT().DescribePolicy("ROW ACCESS", "DB", "schema2", "RowAccess1").Return([]DescribePolicyEntity{
+					repoMock.EXPECT().DescribePolicy(mock.Anything, "ROW ACCESS", "DB", "schema2", "RowAccess1").Return([]DescribePolicyEntity{
 						{Name: "DescribePolicy2", Body: "Row Access Policy Body"},
 					}, nil).Once()
-					repoMock.EXPECT().GetPolicyReferences("DB", "schema1", "MaskingPolicy1").Return([]PolicyReferenceEntity{
+					repoMock.EXPECT().GetPolicyReferences(mock.Anything, "DB", "schema1", "MaskingPolicy1").Return([]PolicyReferenceEntity{
 						{POLICY_DB: "PolicyDB"},
 					}, nil).Once()
-					repoMock.EXPECT().GetPolicyReferences("DB", "schema2", "RowAccess1").Return([]PolicyReferenceEntity{
+					repoMock.EXPECT().GetPolicyReferences(mock.Anything, "DB", "schema2", "RowAccess1").Return([]PolicyReferenceEntity{
 						{POLICY_DB: "PolicyDB"},
 					}, nil).Once()
 
@@ -740,34 +744,35 @@ func TestAccessSyncer_SyncAccessProvidersFromTarget(t *testing.T) {
 
 					repoMock.EXPECT().Close().Return(nil).Once()
 					repoMock.EXPECT().TotalQueryTime().Return(time.Minute).Once()
-					repoMock.EXPECT().GetInboundShares().Return([]DbEntity{
+					repoMock.EXPECT().TotalRetries().Return(0).Once()
+					repoMock.EXPECT().GetInboundShares(mock.Anything).Return([]DbEntity{
 						{Name: "Share1"}, {Name: "Share2"},
 					}, nil).Once()
-					repoMock.EXPECT().GetOutboundShares().Return([]ShareEntity{}, nil).Once()
-					repoMock.EXPECT().GetAccountRoles().Return([]RoleEntity{
+					repoMock.EXPECT().GetOutboundShares(mock.Anything).Return([]ShareEntity{}, nil).Once()
+					repoMock.EXPECT().GetAccountRoles(mock.Anything).Return([]RoleEntity{
 						{Name: "Role1", AssignedToUsers: 2, GrantedRoles: 3, GrantedToRoles: 1, Owner: "Owner1"},
 						{Name: "Role2", AssignedToUsers: 3, GrantedRoles: 2, GrantedToRoles: 1, Owner: "Owner2"},
 						{Name: "Role3", AssignedToUsers: 1, GrantedRoles: 1, GrantedToRoles: 1, Owner: "ExternalOwner2"},
 					}, nil).Once()
-					repoMock.EXPECT().GetGrantsOfAccountRole("Role1").Return([]GrantOfRole{
+					repoMock.EXPECT().GetGrantsOfAccountRole(mock.Anything, "Role1").Return([]GrantOfRole{
 						{GrantedTo: "USER", GranteeName: "GranteeRole1Number1"},
 						{GrantedTo: "ROLE", GranteeName: "GranteeRole1Number2"},
 					}, nil).Once()
-					repoMock.EXPECT().GetGrantsToAccountRole("Role1").Return([]GrantToRole{
+					repoMock.EXPECT().GetGrantsToAccountRole(mock.Anything, "Role1").Return([]GrantToRole{
 						{GrantedOn: "SCHEMA", Name: "Share2.GranteeRole1Schema", Privilege: "USAGE"},
 						{GrantedOn: "SCHEMA", Name: "Share2.GranteeRole1Schema", Privilege: "READ"},
 						{GrantedOn: "TABLE", Name: "DB1.GranteeRole1Table", Privilege: "SELECT"},
 					}, nil).Once()
-					repoMock.EXPECT().GetGrantsOfAccountRole("Role2").Return([]GrantOfRole{
+					repoMock.EXPECT().GetGrantsOfAccountRole(mock.Anything, "Role2").Return([]GrantOfRole{
 						{GrantedTo: "USER", GranteeName: "GranteeRole2"},
 					}, nil).Once()
-					repoMock.EXPECT().GetGrantsToAccountRole("Role2").Return([]GrantToRole{
+					repoMock.EXPECT().GetGrantsToAccountRole(mock.Anything, "Role2").Return([]GrantToRole{
 						{GrantedOn: "GrandOnRole2Number1", Name: "GranteeRole2", Privilege: "USAGE"},
 					}, nil).Once()
-					repoMock.EXPECT().GetGrantsOfAccountRole("Role3").Return([]GrantOfRole{
+					repoMock.EXPECT().GetGrantsOfAccountRole(mock.Anything, "Role3").Return([]GrantOfRole{
 						{GrantedTo: "ROLE", GranteeName: "GranteeRole3"},
 					}, nil).Once()
-					repoMock.EXPECT().GetGrantsToAccountRole("Role3").Return([]GrantToRole{
+					repoMock.EXPECT().GetGrantsToAccountRole(mock.Anything, "Role3").Return([]GrantToRole{
 						{GrantedOn: "GrandOnRole3Number1", Name: "GranteeRole3", Privilege: "WRITE"},
 					}, nil).Once()
 
@@ -863,39 +868,40 @@ func TestAccessSyncer_SyncAccessProvidersFromTarget(t *testing.T) {
 
 					repoMock.EXPECT().Close().Return(nil).Once()
 					repoMock.EXPECT().TotalQueryTime().Return(time.Minute).Once()
-					repoMock.EXPECT().GetInboundShares().Return([]DbEntity{}, nil).Twice()
+					repoMock.EXPECT().TotalRetries().Return(0).Once()
+					repoMock.EXPECT().GetInboundShares(mock.Anything).Return([]DbEntity{}, nil).Twice()
 
-					repoMock.EXPECT().GetTagsByDomain("ROLE").Return(map[string][]*tag.Tag{
+					repoMock.EXPECT().GetTagsByDomain(mock.Anything, "ROLE").Return(map[string][]*tag.Tag{
 						"Role1": {
 							{Key: "a_key", Value: "override_name"},
 							{Key: "an_other_key", Value: "...."},
 						},
 					}, nil).Once()
-					repoMock.EXPECT().GetOutboundShares().Return([]ShareEntity{}, nil).Once()
-					repoMock.EXPECT().GetDatabaseRoleTags("TEST_DB", "DatabaseRole1").Return(map[string][]*tag.Tag{
+					repoMock.EXPECT().GetOutboundShares(mock.Anything).Return([]ShareEntity{}, nil).Once()
+					repoMock.EXPECT().GetDatabaseRoleTags(mock.Anything, "TEST_DB", "DatabaseRole1").Return(map[string][]*tag.Tag{
 						"TEST_DB.DatabaseRole1": {
 							{Key: "a_key", Value: "override_name_2"},
 							{Key: "an_other_key", Value: "...."},
 						},
 					}, nil).Once()
-					repoMock.EXPECT().GetDatabases().Return([]DbEntity{
+					repoMock.EXPECT().GetDatabases(mock.Anything).Return([]DbEntity{
 						{Name: "TEST_DB"},
 					}, nil).Once()
-					repoMock.EXPECT().GetDatabaseRoles("TEST_DB").Return([]RoleEntity{
+					repoMock.EXPECT().GetDatabaseRoles(mock.Anything, "TEST_DB").Return([]RoleEntity{
 						{Name: "DatabaseRole1", AssignedToUsers: 0, GrantedRoles: 0, GrantedToRoles: 0, Owner: "Owner1"},
 					}, nil).Once()
 
-					repoMock.EXPECT().GetAccountRoles().Return([]RoleEntity{
+					repoMock.EXPECT().GetAccountRoles(mock.Anything).Return([]RoleEntity{
 						{Name: "Role1", AssignedToUsers: 0, GrantedRoles: 0, GrantedToRoles: 0, Owner: "Owner1"},
 					}, nil).Once()
-					repoMock.EXPECT().GetGrantsOfAccountRole("Role1").Return([]GrantOfRole{}, nil).Once()
-					repoMock.EXPECT().GetGrantsToAccountRole("Role1").Return([]GrantToRole{}, nil).Once()
+					repoMock.EXPECT().GetGrantsOfAccountRole(mock.Anything, "Role1").Return([]GrantOfRole{}, nil).Once()
+					repoMock.EXPECT().GetGrantsToAccountRole(mock.Anything, "Role1").Return([]GrantToRole{}, nil).Once()
 
-					repoMock.EXPECT().GetGrantsOfDatabaseRole("TEST_DB", "DatabaseRole1").Return([]GrantOfRole{}, nil).Once()
-					repoMock.EXPECT().GetGrantsToDatabaseRole("TEST_DB", "DatabaseRole1").Return([]GrantToRole{}, nil).Once()
+					repoMock.EXPECT().GetGrantsOfDatabaseRole(mock.Anything, "TEST_DB", "DatabaseRole1").Return([]GrantOfRole{}, nil).Once()
+					repoMock.EXPECT().GetGrantsToDatabaseRole(mock.Anything, "TEST_DB", "DatabaseRole1").Return([]GrantToRole{}, nil).Once()
 
-					repoMock.EXPECT().GetPolicies("MASKING").Return([]PolicyEntity{}, nil).Once()
-					repoMock.EXPECT().GetPolicies("ROW ACCESS").Return([]PolicyEntity{}, nil).Once()
+					repoMock.EXPECT().GetPolicies(mock.Anything, "MASKING").Return([]PolicyEntity{}, nil).Once()
+					repoMock.EXPECT().GetPolicies(mock.Anything, "ROW ACCESS").Return([]PolicyEntity{}, nil).Once()
 
 					return fileCreator
 				},
@@ -974,34 +980,35 @@ func TestAccessSyncer_SyncAccessProvidersFromTarget(t *testing.T) {
 
 					repoMock.EXPECT().Close().Return(nil).Once()
 					repoMock.EXPECT().TotalQueryTime().Return(time.Minute).Once()
-					repoMock.EXPECT().GetInboundShares().Return([]DbEntity{}, nil).Twice()
-					repoMock.EXPECT().GetOutboundShares().Return([]ShareEntity{}, nil).Once()
-					repoMock.EXPECT().GetDatabases().Return([]DbEntity{
+					repoMock.EXPECT().TotalRetries().Return(0).Once()
+					repoMock.EXPECT().GetInboundShares(mock.Anything).Return([]DbEntity{}, nil).Twice()
+					repoMock.EXPECT().GetOutboundShares(mock.Anything).Return([]ShareEntity{}, nil).Once()
+					repoMock.EXPECT().GetDatabases(mock.Anything).Return([]DbEntity{
 						{Name: "SNOWFLAKE"},
 						{Name: "TEST_DB"},
 					}, nil).Once()
-					repoMock.EXPECT().GetDatabaseRoles("TEST_DB").Return([]RoleEntity{
+					repoMock.EXPECT().GetDatabaseRoles(mock.Anything, "TEST_DB").Return([]RoleEntity{
 						{Name: "DatabaseRole1", AssignedToUsers: 0, GrantedRoles: 0, GrantedToRoles: 1, Owner: "Owner1"},
 						{Name: "DatabaseRole2", AssignedToUsers: 0, GrantedRoles: 1, GrantedToRoles: 0, Owner: "Owner2"},
 					}, nil).Once()
 
-					repoMock.EXPECT().GetAccountRoles().Return([]RoleEntity{
+					repoMock.EXPECT().GetAccountRoles(mock.Anything).Return([]RoleEntity{
 						{Name: "Role1", AssignedToUsers: 2, GrantedRoles: 3, GrantedToRoles: 1, Owner: "Owner1"},
 						{Name: "Role2", AssignedToUsers: 3, GrantedRoles: 2, GrantedToRoles: 1, Owner: "Owner2"},
 					}, nil).Once()
-					repoMock.EXPECT().GetGrantsOfAccountRole("Role2").Return([]GrantOfRole{
+					repoMock.EXPECT().GetGrantsOfAccountRole(mock.Anything, "Role2").Return([]GrantOfRole{
 						{
 							GrantedTo:   "ROLE",
 							GranteeName: "Role1",
 						},
 					}, nil).Once()
-					repoMock.EXPECT().GetGrantsToAccountRole("Role2").Return([]GrantToRole{}, nil).Once()
+					repoMock.EXPECT().GetGrantsToAccountRole(mock.Anything, "Role2").Return([]GrantToRole{}, nil).Once()
 
-					repoMock.EXPECT().GetGrantsOfDatabaseRole("TEST_DB", "DatabaseRole2").Return([]GrantOfRole{}, nil).Once()
-					repoMock.EXPECT().GetGrantsToDatabaseRole("TEST_DB", "DatabaseRole2").Return([]GrantToRole{}, nil).Once()
+					repoMock.EXPECT().GetGrantsOfDatabaseRole(mock.Anything, "TEST_DB", "DatabaseRole2").Return([]GrantOfRole{}, nil).Once()
+					repoMock.EXPECT().GetGrantsToDatabaseRole(mock.Anything, "TEST_DB", "DatabaseRole2").Return([]GrantToRole{}, nil).Once()
 
-					repoMock.EXPECT().GetPolicies("MASKING").Return([]PolicyEntity{}, nil).Once()
-					repoMock.EXPECT().GetPolicies("ROW ACCESS").Return([]PolicyEntity{}, nil).Once()
+					repoMock.EXPECT().GetPolicies(mock.Anything, "MASKING").Return([]PolicyEntity{}, nil).Once()
+					repoMock.EXPECT().GetPolicies(mock.Anything, "ROW ACCESS").Return([]PolicyEntity{}, nil).Once()
 
 					return fileCreator
 				},
@@ -1057,9 +1064,82 @@ func TestAccessSyncer_SyncAccessProvidersFromTarget(t *testing.T) {
 			},
 			wantErr: require.NoError,
 		},
+		{
+			name: "unmanaged roles",
+			fields: fields{
+				setup: func(repoMock *mockDataAccessRepository) *mocks.SimpleAccessProviderHandler {
+					fileCreator := mocks.NewSimpleAccessProviderHandler(t, 3)
+
+					repoMock.EXPECT().Close().Return(nil).Once()
+					repoMock.EXPECT().TotalQueryTime().Return(time.Minute).Once()
+					repoMock.EXPECT().TotalRetries().Return(0).Once()
+					repoMock.EXPECT().GetInboundShares(mock.Anything).Return([]DbEntity{}, nil).Once()
+					repoMock.EXPECT().GetOutboundShares(mock.Anything).Return([]ShareEntity{}, nil).Once()
+					repoMock.EXPECT().GetTagsByDomain(mock.Anything, "ROLE").Return(map[string][]*tag.Tag{
+						"Role2": {{Key: "RAITO_UNMANAGED", Value: "TRUE"}},
+						"Role3": {{Key: "RAITO_UNMANAGED", Value: "false"}},
+					}, nil).Once()
+					repoMock.EXPECT().GetAccountRoles(mock.Anything).Return([]RoleEntity{
+						{Name: "SYS_ADMIN", Owner: "Owner1"},
+						{Name: "Role2", Owner: "Owner1"},
+						{Name: "Role3", Owner: "Owner1"},
+					}, nil).Once()
+
+					for _, roleName := range []string{"SYS_ADMIN", "Role2", "Role3"} {
+						repoMock.EXPECT().GetGrantsOfAccountRole(mock.Anything, roleName).Return([]GrantOfRole{}, nil).Once()
+						repoMock.EXPECT().GetGrantsToAccountRole(mock.Anything, roleName).Return([]GrantToRole{}, nil).Once()
+					}
+
+					return fileCreator
+				},
+			},
+			args: args{
+				configMap: config.ConfigMap{
+					Parameters: map[string]string{SfStandardEdition: "true", SfProtectedRoles: "SYS_.*", SfUnmanagedRoleTag: "RAITO_UNMANAGED"},
+				},
+			},
+			wantAps: func() []sync_from_target.AccessProvider {
+				ap := func(name string, lockedReason *string) sync_from_target.AccessProvider {
+					result := sync_from_target.AccessProvider{
+						ExternalId: name,
+						Type:       ptr.String(access_provider.Role),
+						Name:       name,
+						NamingHint: name,
+						ActualName: name,
+						Who: &sync_from_target.WhoItem{
+							Users:           []string{},
+							Groups:          []string{},
+							AccessProviders: []string{},
+						},
+						What:       []sync_from_target.WhatItem{},
+						Action:     types.Grant,
+						Incomplete: ptr.Bool(false),
+					}
+
+					if lockedReason != nil {
+						result.NameLocked, result.NameLockedReason = ptr.Bool(true), lockedReason
+						result.DeleteLocked, result.DeleteLockedReason = ptr.Bool(true), lockedReason
+						result.WhoLocked, result.WhoLockedReason = ptr.Bool(true), lockedReason
+						result.InheritanceLocked, result.InheritanceLockedReason = ptr.Bool(true), lockedReason
+						result.WhatLocked, result.WhatLockedReason = ptr.Bool(true), lockedReason
+					}
+
+					return result
+				}
+
+				return []sync_from_target.AccessProvider{
+					ap("SYS_ADMIN", ptr.String(`This Snowflake role is not managed by Raito because it matches the protected role pattern "SYS_.*"`)),
+					ap("Role2", ptr.String("This Snowflake role is not managed by Raito because it has tag RAITO_UNMANAGED set to true")),
+					ap("Role3", nil),
+				}
+			}(),
+			wantErr: require.NoError,
+		},
 	}
 
-	for _, tt := range tests {
+	for i := range tests {
+		tt := &tests[i]
+
 		t.Run(tt.name, func(t *testing.T) {
 			// Given
 			repoMock := newMockDataAccessRepository(t)
@@ -1089,6 +1169,10 @@ func TestAccessSyncer_SyncAccessProviderToTarget(t *testing.T) {
 		fields  fields
 		args    args
 		wantErr require.ErrorAssertionFunc
+
+		// readsPlannedState is set if the sync reads objects changed earlier in the same sync.
+		// When the changes are planned first, these reads see the state from before the sync (see TestAccessSyncer_SyncAccessProviderToTarget_BlastRadius_rename).
+		readsPlannedState bool
 	}{
 		{
 			name: "basic - grants only",
@@ -1096,25 +1180,28 @@ func TestAccessSyncer_SyncAccessProviderToTarget(t *testing.T) {
 				setup: func(repoMock *mockDataAccessRepository, feedbackHandlerMock *mocks.SimpleAccessProviderFeedbackHandler) {
 					repoMock.EXPECT().Close().Return(nil).Once()
 					repoMock.EXPECT().TotalQueryTime().Return(time.Minute).Once()
-					repoMock.EXPECT().GetAccountRolesWithPrefix("").Return([]RoleEntity{}, nil).Once()
+					repoMock.EXPECT().TotalRetries().Return(0).Once()
+					repoMock.EXPECT().GetAccountRoles(mock.Anything).Return([]RoleEntity{}, nil).Once()
+					repoMock.EXPECT().GetDatabaseRoles(mock.Anything, "TEST_DB").Return([]RoleEntity{}, nil).Once()
+					repoMock.EXPECT().GetAccountRolesWithPrefix(mock.Anything, "").Return([]RoleEntity{}, nil).Once()
 
-					repoMock.EXPECT().CreateAccountRole("ACCESS_PROVIDER1").Return(nil).Once()
-					repoMock.EXPECT().CommentAccountRoleIfExists(mock.Anything, "ACCESS_PROVIDER1").Return(nil).Once()
+					repoMock.EXPECT().CreateAccountRole(mock.Anything, "ACCESS_PROVIDER1").Return(nil).Once()
+					repoMock.EXPECT().CommentAccountRoleIfExists(mock.Anything, mock.Anything, "ACCESS_PROVIDER1").Return(nil).Once()
 					expectGrantUsersToRole(repoMock, "ACCESS_PROVIDER1", "User1", "User2")
 					repoMock.EXPECT().GrantAccountRolesToAccountRole(mock.Anything, "ACCESS_PROVIDER1").Return(nil).Once()
 
-					repoMock.EXPECT().ExecuteGrantOnAccountRole("USAGE", "DATABASE DB1", "ACCESS_PROVIDER1", false).Return(nil).Once()
-					repoMock.EXPECT().ExecuteGrantOnAccountRole("USAGE", "SCHEMA DB1.Schema1", "ACCESS_PROVIDER1", false).Return(nil).Once()
-					repoMock.EXPECT().ExecuteGrantOnAccountRole("SELECT", "TABLE DB1.Schema1.Table1", "ACCESS_PROVIDER1", false).Return(nil).Once()
+					repoMock.EXPECT().ExecuteGrantOnAccountRole(mock.Anything, "USAGE", "DATABASE DB1", "ACCESS_PROVIDER1", false).Return(nil).Once()
+					repoMock.EXPECT().ExecuteGrantOnAccountRole(mock.Anything, "USAGE", "SCHEMA DB1.Schema1", "ACCESS_PROVIDER1", false).Return(nil).Once()
+					repoMock.EXPECT().ExecuteGrantOnAccountRole(mock.Anything, "SELECT", "TABLE DB1.Schema1.Table1", "ACCESS_PROVIDER1", false).Return(nil).Once()
 
-					repoMock.EXPECT().CreateDatabaseRole("TEST_DB", "DATABASE_ROLE1").Return(nil).Once()
-					repoMock.EXPECT().CommentDatabaseRoleIfExists(mock.Anything, "TEST_DB", "DATABASE_ROLE1").Return(nil).Once()
+					repoMock.EXPECT().CreateDatabaseRole(mock.Anything, "TEST_DB", "DATABASE_ROLE1").Return(nil).Once()
+					repoMock.EXPECT().CommentDatabaseRoleIfExists(mock.Anything, mock.Anything, "TEST_DB", "DATABASE_ROLE1").Return(nil).Once()
 					expectGrantAccountOrDatabaseRolesToDatabaseRole(repoMock, false, "TEST_DB", "DATABASE_ROLE1", "AccessProviderId1")
 					expectGrantAccountOrDatabaseRolesToDatabaseRole(repoMock, true, "TEST_DB", "DATABASE_ROLE1")
 
-					repoMock.EXPECT().ExecuteGrantOnDatabaseRole("USAGE", "DATABASE TEST_DB", "TEST_DB", "DATABASE_ROLE1").Return(nil).Once()
-					repoMock.EXPECT().ExecuteGrantOnDatabaseRole("USAGE", "SCHEMA TEST_DB.Schema1", "TEST_DB", "DATABASE_ROLE1").Return(nil).Once()
-					repoMock.EXPECT().ExecuteGrantOnDatabaseRole("SELECT", "TABLE TEST_DB.Schema1.Table1", "TEST_DB", "DATABASE_ROLE1").Return(nil).Once()
+					repoMock.EXPECT().ExecuteGrantOnDatabaseRole(mock.Anything, "USAGE", "DATABASE TEST_DB", "TEST_DB", "DATABASE_ROLE1").Return(nil).Once()
+					repoMock.EXPECT().ExecuteGrantOnDatabaseRole(mock.Anything, "USAGE", "SCHEMA TEST_DB.Schema1", "TEST_DB", "DATABASE_ROLE1").Return(nil).Once()
+					repoMock.EXPECT().ExecuteGrantOnDatabaseRole(mock.Anything, "SELECT", "TABLE TEST_DB.Schema1.Table1", "TEST_DB", "DATABASE_ROLE1").Return(nil).Once()
 				},
 			},
 			args: args{
@@ -1153,37 +1240,42 @@ func TestAccessSyncer_SyncAccessProviderToTarget(t *testing.T) {
 			wantErr: require.NoError,
 		},
 		{
-			name: "basic - renaming grants",
+			name:              "basic - renaming grants",
+			readsPlannedState: true,
 			fields: fields{
 				setup: func(repoMock *mockDataAccessRepository, feedbackHandlerMock *mocks.SimpleAccessProviderFeedbackHandler) {
 					repoMock.EXPECT().Close().Return(nil).Once()
 					repoMock.EXPECT().TotalQueryTime().Return(time.Minute).Once()
-					repoMock.EXPECT().GetAccountRolesWithPrefix("").Return([]RoleEntity{
+					repoMock.EXPECT().TotalRetries().Return(0).Once()
+					repoMock.EXPECT().GetAccountRoles(mock.Anything).Return([]RoleEntity{}, nil).Once()
+					repoMock.EXPECT().GetDatabaseRoles(mock.Anything, "TEST_DB").Return([]RoleEntity{}, nil).Once()
+					repoMock.EXPECT().GetAccountRolesWithPrefix(mock.Anything, "").Return([]RoleEntity{
 						{Name: "ACCESS_PROVIDER1_OLD"},
 						{Name: "DATABASEROLE###DATABASE:TEST_DB###ROLE:DATABASE_ROLE1_OLD"},
 					}, nil).Once()
 
-					repoMock.EXPECT().RenameAccountRole("ACCESS_PROVIDER1_OLD", "ACCESS_PROVIDER1").Return(nil).Once()
-					repoMock.EXPECT().CommentAccountRoleIfExists(mock.Anything, "ACCESS_PROVIDER1").Return(nil).Once()
-					repoMock.EXPECT().GetGrantsOfAccountRole("ACCESS_PROVIDER1").Return([]GrantOfRole{}, nil).Once()
-					repoMock.EXPECT().GetGrantsToAccountRole("ACCESS_PROVIDER1").Return([]GrantToRole{}, nil).Once()
+					repoMock.EXPECT().RenameAccountRole(mock.Anything, "ACCESS_PROVIDER1_OLD", "ACCESS_PROVIDER1").Return(nil).Once()
+					repoMock.EXPECT().ExecutionPlan().Return(nil)
+					repoMock.EXPECT().CommentAccountRoleIfExists(mock.Anything, mock.Anything, "ACCESS_PROVIDER1").Return(nil).Once()
+					repoMock.EXPECT().GetGrantsOfAccountRole(mock.Anything, "ACCESS_PROVIDER1").Return([]GrantOfRole{}, nil).Once()
+					repoMock.EXPECT().GetGrantsToAccountRole(mock.Anything, "ACCESS_PROVIDER1").Return([]GrantToRole{}, nil).Once()
 
 					expectGrantUsersToRole(repoMock, "ACCESS_PROVIDER1", "User1", "User2")
-					repoMock.EXPECT().ExecuteGrantOnAccountRole("USAGE", "DATABASE DB1", "ACCESS_PROVIDER1", false).Return(nil).Once()
-					repoMock.EXPECT().ExecuteGrantOnAccountRole("USAGE", "SCHEMA DB1.Schema1", "ACCESS_PROVIDER1", false).Return(nil).Once()
-					repoMock.EXPECT().ExecuteGrantOnAccountRole("SELECT", "TABLE DB1.Schema1.Table1", "ACCESS_PROVIDER1", false).Return(nil).Once()
+					repoMock.EXPECT().ExecuteGrantOnAccountRole(mock.Anything, "USAGE", "DATABASE DB1", "ACCESS_PROVIDER1", false).Return(nil).Once()
+					repoMock.EXPECT().ExecuteGrantOnAccountRole(mock.Anything, "USAGE", "SCHEMA DB1.Schema1", "ACCESS_PROVIDER1", false).Return(nil).Once()
+					repoMock.EXPECT().ExecuteGrantOnAccountRole(mock.Anything, "SELECT", "TABLE DB1.Schema1.Table1", "ACCESS_PROVIDER1", false).Return(nil).Once()
 
-					repoMock.EXPECT().RenameDatabaseRole("TEST_DB", "DATABASE_ROLE1_OLD", "DATABASE_ROLE1").Return(nil).Once()
-					repoMock.EXPECT().CommentDatabaseRoleIfExists(mock.Anything, "TEST_DB", "DATABASE_ROLE1").Return(nil).Once()
-					repoMock.EXPECT().GetGrantsOfDatabaseRole("TEST_DB", "DATABASE_ROLE1").Return([]GrantOfRole{}, nil).Once()
-					repoMock.EXPECT().GetGrantsToDatabaseRole("TEST_DB", "DATABASE_ROLE1").Return([]GrantToRole{}, nil).Once()
+					repoMock.EXPECT().RenameDatabaseRole(mock.Anything, "TEST_DB", "DATABASE_ROLE1_OLD", "DATABASE_ROLE1").Return(nil).Once()
+					repoMock.EXPECT().CommentDatabaseRoleIfExists(mock.Anything, mock.Anything, "TEST_DB", "DATABASE_ROLE1").Return(nil).Once()
+					repoMock.EXPECT().GetGrantsOfDatabaseRole(mock.Anything, "TEST_DB", "DATABASE_ROLE1").Return([]GrantOfRole{}, nil).Once()
+					repoMock.EXPECT().GetGrantsToDatabaseRole(mock.Anything, "TEST_DB", "DATABASE_ROLE1").Return([]GrantToRole{}, nil).Once()
 
 					expectGrantAccountOrDatabaseRolesToDatabaseRole(repoMock, false, "TEST_DB", "DATABASE_ROLE1", "AccessProviderId1")
 					expectGrantAccountOrDatabaseRolesToDatabaseRole(repoMock, true, "TEST_DB", "DATABASE_ROLE1")
 
-					repoMock.EXPECT().ExecuteGrantOnDatabaseRole("USAGE", "DATABASE TEST_DB", "TEST_DB", "DATABASE_ROLE1").Return(nil).Once()
-					repoMock.EXPECT().ExecuteGrantOnDatabaseRole("USAGE", "SCHEMA TEST_DB.Schema1", "TEST_DB", "DATABASE_ROLE1").Return(nil).Once()
-					repoMock.EXPECT().ExecuteGrantOnDatabaseRole("SELECT", "TABLE TEST_DB.Schema1.Table1", "TEST_DB", "DATABASE_ROLE1").Return(nil).Once()
+					repoMock.EXPECT().ExecuteGrantOnDatabaseRole(mock.Anything, "USAGE", "DATABASE TEST_DB", "TEST_DB", "DATABASE_ROLE1").Return(nil).Once()
+					repoMock.EXPECT().ExecuteGrantOnDatabaseRole(mock.Anything, "USAGE", "SCHEMA TEST_DB.Schema1", "TEST_DB", "DATABASE_ROLE1").Return(nil).Once()
+					repoMock.EXPECT().ExecuteGrantOnDatabaseRole(mock.Anything, "SELECT", "TABLE TEST_DB.Schema1.Table1", "TEST_DB", "DATABASE_ROLE1").Return(nil).Once()
 				},
 			},
 			args: args{
@@ -1229,7 +1321,9 @@ func TestAccessSyncer_SyncAccessProviderToTarget(t *testing.T) {
 				setup: func(repoMock *mockDataAccessRepository, feedbackHandlerMock *mocks.SimpleAccessProviderFeedbackHandler) {
 					repoMock.EXPECT().Close().Return(nil).Once()
 					repoMock.EXPECT().TotalQueryTime().Return(time.Minute).Once()
-					repoMock.EXPECT().GetAccountRolesWithPrefix("").Return([]RoleEntity{}, nil).Once()
+					repoMock.EXPECT().TotalRetries().Return(0).Once()
+					repoMock.EXPECT().GetAccountRoles(mock.Anything).Return([]RoleEntity{}, nil).Once()
+					repoMock.EXPECT().GetAccountRolesWithPrefix(mock.Anything, "").Return([]RoleEntity{}, nil).Once()
 				},
 			},
 			args: args{
@@ -1282,13 +1376,15 @@ func TestAccessSyncer_SyncAccessProviderToTarget(t *testing.T) {
 				setup: func(repoMock *mockDataAccessRepository, feedbackHandlerMock *mocks.SimpleAccessProviderFeedbackHandler) {
 					repoMock.EXPECT().Close().Return(nil).Once()
 					repoMock.EXPECT().TotalQueryTime().Return(time.Minute).Once()
-					repoMock.EXPECT().GetAccountRolesWithPrefix("").Return([]RoleEntity{}, nil).Once()
-
-					repoMock.EXPECT().GetPoliciesLike("MASKING", "RAITO_MASK1%").Return(nil, nil).Once() // No existing masks
-					repoMock.EXPECT().CreateMaskPolicy("DB1", "Schema1", mock.AnythingOfType("string"), []string{"DB1.Schema1.Table1.Column1"}, ptr.String("SHA256"), &MaskingBeneficiaries{Users: []string{"User1", "User2"}, Roles: []string{"Role1"}}).Return(nil)
-					repoMock.EXPECT().CreateMaskPolicy("DB1", "Schema2", mock.AnythingOfType("string"), []string{"DB1.Schema2.Table1.Column1"}, ptr.String("SHA256"), &MaskingBeneficiaries{Users: []string{"User1", "User2"}, Roles: []string{"Role1"}}).Return(nil)
-					repoMock.EXPECT().UpdateFilter("DB1", "Schema1", "Table1", mock.AnythingOfType("string"), mock.AnythingOfType("[]string"),
-						mock.AnythingOfType("string")).RunAndReturn(func(_ string, _ string, _ string, filterName string, arguments []string, query string) error {
+					repoMock.EXPECT().TotalRetries().Return(0).Once()
+					repoMock.EXPECT().GetAccountRoles(mock.Anything).Return([]RoleEntity{}, nil).Once()
+					repoMock.EXPECT().GetAccountRolesWithPrefix(mock.Anything, "").Return([]RoleEntity{}, nil).Once()
+
+					repoMock.EXPECT().GetPoliciesLike(mock.Anything, "MASKING", "RAITO_MASK1%").Return(nil, nil).Once() // No existing masks
+					repoMock.EXPECT().CreateMaskPolicy(mock.Anything, "DB1", "Schema1", mock.AnythingOfType("string"), []string{"DB1.Schema1.Table1.Column1"}, ptr.String("SHA256"), &MaskingBeneficiaries{Users: []string{"User1", "User2"}, Roles: []string{"Role1"}}).Return(nil)
+					repoMock.EXPECT().CreateMaskPolicy(mock.Anything, "DB1", "Schema2", mock.AnythingOfType("string"), []string{"DB1.Schema2.Table1.Column1"}, ptr.String("SHA256"), &MaskingBeneficiaries{Users: []string{"User1", "User2"}, Roles: []string{"Role1"}}).Return(nil)
+					repoMock.EXPECT().UpdateFilter(mock.Anything, "DB1", "Schema1", "Table1", mock.AnythingOfType("string"), mock.AnythingOfType("[]string"),
+						mock.AnythingOfType("string")).RunAndReturn(func(_ context.Context, _ string, _ string, _ string, filterName string, arguments []string, query string) error {
 						assert.True(t, strings.HasPrefix(filterName, "raito_Schema1_Table1_"))
 						assert.ElementsMatch(t, []string{"state"}, arguments)
 
@@ -1346,6 +1442,8 @@ func TestAccessSyncer_SyncAccessProviderToTarget(t *testing.T) {
 	}
 
 	for _, tt := range tests {
+		var feedbackWithoutLimits []importer.AccessProviderSyncFeedback
+
 		t.Run(tt.name, func(t *testing.T) {
 			// Given
 			repoMock := newMockDataAccessRepository(t)
@@ -1360,13 +1458,43 @@ func TestAccessSyncer_SyncAccessProviderToTarget(t *testing.T) {
 
 			// Then
 			tt.wantErr(t, err)
+
+			feedbackWithoutLimits = feedbackHandler.AccessProviderFeedback
+		})
+
+		if tt.readsPlannedState {
+			continue
+		}
+
+		// Setting limits that are not exceeded plans the changes first, but results in the same calls and feedback
+		t.Run(tt.name+" - within blast-radius limits", func(t *testing.T) {
+			// Given
+			repoMock := newMockDataAccessRepository(t)
+			feedbackHandler := mocks.NewSimpleAccessProviderFeedbackHandler(t)
+
+			tt.fields.setup(repoMock, feedbackHandler)
+			repoMock.EXPECT().ExecutionPlan().Return(NewExecutionPlan())
+
+			parameters := map[string]string{SfMaxRevokes: "1000", SfMaxRoleDrops: "1000", SfMaxMaskDrops: "1000", SfMaxShareAccountRemovals: "1000"}
+			for k, v := range tt.args.configMap.Parameters {
+				parameters[k] = v
+			}
+
+			syncer := createAccessSyncer(repoMock)
+
+			// When
+			err := syncer.SyncAccessProviderToTarget(context.Background(), tt.args.accessProviders, feedbackHandler, &config.ConfigMap{Parameters: parameters})
+
+			// Then
+			tt.wantErr(t, err)
+			assert.ElementsMatch(t, feedbackWithoutLimits, feedbackHandler.AccessProviderFeedback)
 		})
 	}
 }
 
 func createAccessSyncer(repo dataAccessRepository) *AccessSyncer {
 	return &AccessSyncer{
-		repoProvider: func(params map[string]string, role string) (dataAccessRepository, error) {
+		repoProvider: func(params map[string]string, role string, phase SyncPhase) (dataAccessRepository, error) {
 			return repo, nil
 		},
 		namingConstraints: RoleNameConstraints,
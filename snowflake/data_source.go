@@ -24,20 +24,23 @@ const AccountAdmin = "ACCOUNTADMIN"
 type dataSourceRepository interface {
 	Close() error
 	TotalQueryTime() time.Duration
-	GetSnowFlakeAccountName(ops ...func(options *GetSnowFlakeAccountNameOptions)) (string, error)
-	GetWarehouses() ([]DbEntity, error)
-	GetInboundShares() ([]DbEntity, error)
-	GetDatabases() ([]DbEntity, error)
-	GetSchemasInDatabase(databaseName string, handleEntity EntityHandler) error
-	GetFunctionsInDatabase(databaseName string, handleEntity EntityHandler) error
-	GetProceduresInDatabase(databaseName string, handleEntity EntityHandler) error
-	GetTablesInDatabase(databaseName string, schemaName string, handleEntity EntityHandler) error
-	GetColumnsInDatabase(databaseName string, handleEntity EntityHandler) error
-	GetTagsLinkedToDatabaseName(databaseName string) (map[string][]*tag.Tag, error)
-	GetTagsByDomain(domain string) (map[string][]*tag.Tag, error)
-	ExecuteGrantOnAccountRole(perm, on, role string, isSystemGrant bool) error
-	GetIntegrations() ([]DbEntity, error)
-	GetApplications() ([]ApplictionEntity, error)
+	TotalRetries() int
+	GetSnowFlakeAccountName(ctx context.Context, ops ...func(options *GetSnowFlakeAccountNameOptions)) (string, error)
+	GetWarehouses(ctx context.Context) ([]DbEntity, error)
+	GetComputePools(ctx context.Context) ([]DbEntity, error)
+	GetInboundShares(ctx context.Context) ([]DbEntity, error)
+	GetDatabases(ctx context.Context) ([]DbEntity, error)
+	GetSchemasInDatabase(ctx context.Context, databaseName string, handleEntity EntityHandler) error
+	GetFunctionsInDatabase(ctx context.Context, databaseName string, handleEntity EntityHandler) error
+	GetProceduresInDatabase(ctx context.Context, databaseName string, handleEntity EntityHandler) error
+	GetObjectsInDatabase(ctx context.Context, objectKind string, databaseName string, handleEntity EntityHandler) error
+	GetTablesInDatabase(ctx context.Context, databaseName string, schemaName string, handleEntity EntityHandler) error
+	GetColumnsInDatabase(ctx context.Context, databaseName string, handleEntity EntityHandler) error
+	GetTagsLinkedToDatabaseName(ctx context.Context, databaseName string) (map[string][]*tag.Tag, error)
+	GetTagsByDomain(ctx context.Context, domain string) (map[string][]*tag.Tag, error)
+	ExecuteGrantOnAccountRole(ctx context.Context, perm, on, role string, isSystemGrant bool) error
+	GetIntegrations(ctx context.Context) ([]DbEntity, error)
+	GetApplications(ctx context.Context) ([]ApplictionEntity, error)
 }
 
 type DataSourceSyncer struct {
@@ -48,6 +51,7 @@ type DataSourceSyncer struct {
 	excludeChildren   []string
 	skipColumns       bool
 	schemaExcludes    set.Set[string]
+	schemaObjectTypes []schemaObjectType
 	inboundSharesMap  set.Set[string]
 	repo              dataSourceRepository
 	dataSourceHandler wrappers.DataSourceObjectHandler
@@ -61,7 +65,7 @@ func NewDataSourceSyncer() *DataSourceSyncer {
 }
 
 func newDataSourceSnowflakeRepo(params map[string]string, role string) (dataSourceRepository, error) {
-	return NewSnowflakeRepository(params, role)
+	return NewSnowflakeRepository(params, role, WithSyncPhase(SyncPhaseDataSource))
 }
 
 // shouldHandle determines if this data object needs to be handled by the syncer or not. It does this by looking at the configuration options to only sync a part.
@@ -96,7 +100,7 @@ func (s *DataSourceSyncer) shouldGoInto(fullName string) bool {
 	return false
 }
 
-func (s *DataSourceSyncer) SyncDataSource(ctx context.Context, dataSourceHandler wrappers.DataSourceObjectHandler, config *ds.DataSourceSyncConfig) error {
+func (s *DataSourceSyncer) SyncDataSource(ctx context.Context, dataSourceHandler wrappers.DataSourceObjectHandler, config *ds.DataSourceSyncConfig) (err error) {
 	// Initializing parameters
 	configParams := config.ConfigMap
 	s.dataSourceHandler = dataSourceHandler
@@ -118,24 +122,35 @@ func (s *DataSourceSyncer) SyncDataSource(ctx context.Context, dataSourceHandler
 	}
 
 	s.schemaExcludes = parseCommaSeparatedList(excludedSchemaList)
+
+	s.schemaObjectTypes, err = getSchemaObjectTypes(configParams.GetString(SfSchemaObjectTypes))
+	if err != nil {
+		return err
+	}
+
 	standard := configParams.GetBoolWithDefault(SfStandardEdition, false)
 	skipTags := configParams.GetBoolWithDefault(SfSkipTags, false)
 	shouldRetrieveTags := !standard && !skipTags
 
+	ctx, endSpan := startSyncPhaseSpan(ctx, configParams.Parameters, SyncPhaseDataSource)
+	defer func() { endSpan(err) }()
+
 	repo, err := s.repoProvider(configParams.Parameters, "")
 	if err != nil {
 		return err
 	}
 
 	defer func() {
-		Logger.Info(fmt.Sprintf("Total snowflake query time:  %s", repo.TotalQueryTime()))
+		Logger.Info(fmt.Sprintf("Total snowflake query time:  %s (%d retries)", repo.TotalQueryTime(), repo.TotalRetries()))
 		repo.Close()
+
+		writeStatementMetrics(configParams.Parameters)
 	}()
 
 	s.repo = repo
 
 	// for data source level access import & export convenience we retrieve the snowflake account and use it as datasource name
-	sfAccount, err := repo.GetSnowFlakeAccountName()
+	sfAccount, err := repo.GetSnowFlakeAccountName(ctx)
 	if err != nil {
 		return err
 	}
@@ -144,24 +159,29 @@ func (s *DataSourceSyncer) SyncDataSource(ctx context.Context, dataSourceHandler
 	dataSourceHandler.SetDataSourceName(sfAccount)
 	dataSourceHandler.SetDataSourceFullname(sfAccount)
 
-	err = s.readIntegrations(shouldRetrieveTags)
+	err = s.readIntegrations(ctx, shouldRetrieveTags)
 	if err != nil {
 		return fmt.Errorf("reading integrations: %w", err)
 	}
 
-	err = s.readWarehouses(shouldRetrieveTags)
+	err = s.readWarehouses(ctx, shouldRetrieveTags)
 	if err != nil {
 		return fmt.Errorf("reading warehouses: %w", err)
 	}
 
-	inboundShares, inboundSharesMap, err := s.readShares(dbExcludes, shouldRetrieveTags)
+	err = s.readComputePools(ctx, shouldRetrieveTags)
+	if err != nil {
+		return fmt.Errorf("reading compute pools: %w", err)
+	}
+
+	inboundShares, inboundSharesMap, err := s.readShares(ctx, dbExcludes, shouldRetrieveTags)
 	if err != nil {
 		return fmt.Errorf("reading shares: %w", err)
 	}
 
 	s.inboundSharesMap = inboundSharesMap
 
-	databases, err := s.readDatabases(dbExcludes, inboundSharesMap, shouldRetrieveTags)
+	databases, err := s.readDatabases(ctx, dbExcludes, inboundSharesMap, shouldRetrieveTags)
 	if err != nil {
 		return fmt.Errorf("reading databases: %w", err)
 	}
@@ -175,7 +195,7 @@ func (s *DataSourceSyncer) SyncDataSource(ctx context.Context, dataSourceHandler
 
 	for _, database := range databases {
 		wp.Submit(func() {
-			err2 := s.handleDatabase(database)
+			err2 := s.handleDatabase(ctx, database)
 			if err2 != nil {
 				merr = multierror.Append(merr, err2)
 			}
@@ -191,7 +211,7 @@ func (s *DataSourceSyncer) SyncDataSource(ctx context.Context, dataSourceHandler
 				applicationExcludes.Add(share.Entity.Name)
 			}
 
-			err2 := s.readApplications(applicationExcludes)
+			err2 := s.readApplications(ctx, applicationExcludes)
 			if err2 != nil {
 				merr = multierror.Append(merr, err2)
 			}
@@ -211,10 +231,10 @@ func (s *DataSourceSyncer) SyncDataSource(ctx context.Context, dataSourceHandler
 	return nil
 }
 
-func (s *DataSourceSyncer) handleDatabase(database ExtendedDbEntity) error {
+func (s *DataSourceSyncer) handleDatabase(ctx context.Context, database ExtendedDbEntity) error {
 	Logger.Info(fmt.Sprintf("Handling database %q", database.Entity.Name))
 
-	err := s.setupDatabasePermissions(database.Entity)
+	err := s.setupDatabasePermissions(ctx, database.Entity)
 
 	if err != nil {
 		return err
@@ -225,30 +245,35 @@ func (s *DataSourceSyncer) handleDatabase(database ExtendedDbEntity) error {
 		doTypePrefix = SharedPrefix
 	}
 
-	err = s.readSchemasInDatabase(database.Entity.Name, doTypePrefix, database.LinkedTags)
+	schemas, err := s.readSchemasInDatabase(ctx, database.Entity.Name, doTypePrefix, database.LinkedTags)
 	if err != nil {
 		return err
 	}
 
 	if doTypePrefix == "" {
-		err = s.readFunctionsInDatabase(database.Entity.Name, database.LinkedTags)
+		err = s.readFunctionsInDatabase(ctx, database.Entity.Name, database.LinkedTags)
+		if err != nil {
+			return err
+		}
+
+		err = s.readProceduresInDatabase(ctx, database.Entity.Name, database.LinkedTags)
 		if err != nil {
 			return err
 		}
 
-		err = s.readProceduresInDatabase(database.Entity.Name, database.LinkedTags)
+		err = s.readSchemaObjectsInDatabase(ctx, database.Entity.Name, schemas, database.LinkedTags)
 		if err != nil {
 			return err
 		}
 	}
 
-	err = s.readTablesInDatabase(database.Entity.Name, doTypePrefix, s.repo.GetTablesInDatabase, database.LinkedTags)
+	err = s.readTablesInDatabase(ctx, database.Entity.Name, doTypePrefix, s.repo.GetTablesInDatabase, database.LinkedTags)
 	if err != nil {
 		return err
 	}
 
 	if !s.skipColumns {
-		err = s.readColumnsInDatabase(database.Entity.Name, doTypePrefix, database.LinkedTags)
+		err = s.readColumnsInDatabase(ctx, database.Entity.Name, doTypePrefix, database.LinkedTags)
 		if err != nil {
 			return err
 		}
@@ -257,10 +282,10 @@ func (s *DataSourceSyncer) handleDatabase(database ExtendedDbEntity) error {
 	return nil
 }
 
-func (s *DataSourceSyncer) readColumnsInDatabase(dbName string, doTypePrefix string, tagMap map[string][]*tag.Tag) error {
+func (s *DataSourceSyncer) readColumnsInDatabase(ctx context.Context, dbName string, doTypePrefix string, tagMap map[string][]*tag.Tag) error {
 	typeName := doTypePrefix + ds.Column
 
-	return s.repo.GetColumnsInDatabase(dbName, func(entity interface{}) error {
+	return s.repo.GetColumnsInDatabase(ctx, dbName, func(entity interface{}) error {
 		column := entity.(*ColumnEntity)
 		schemaName := column.Schema
 		schemaFullName := column.Database + "." + schemaName
@@ -293,10 +318,13 @@ func (s *DataSourceSyncer) readColumnsInDatabase(dbName string, doTypePrefix str
 	})
 }
 
-func (s *DataSourceSyncer) readSchemasInDatabase(databaseName string, doTypePrefix string, tagMap map[string][]*tag.Tag) error {
+// readSchemasInDatabase imports the schemas of the database and returns the names of the schemas that were not excluded and need to be crawled
+func (s *DataSourceSyncer) readSchemasInDatabase(ctx context.Context, databaseName string, doTypePrefix string, tagMap map[string][]*tag.Tag) ([]string, error) {
 	typeName := doTypePrefix + ds.Schema
 
-	return s.repo.GetSchemasInDatabase(databaseName, func(entity interface{}) error {
+	var schemas []string
+
+	err := s.repo.GetSchemasInDatabase(ctx, databaseName, func(entity interface{}) error {
 		schema := entity.(*SchemaEntity)
 
 		fullName := schema.Database + "." + schema.Name
@@ -304,6 +332,10 @@ func (s *DataSourceSyncer) readSchemasInDatabase(databaseName string, doTypePref
 		ff := s.schemaExcludes.Contains(fullName)
 		fs := s.schemaExcludes.Contains(schema.Name)
 
+		if !ff && !fs && s.shouldGoInto(fullName) {
+			schemas = append(schemas, schema.Name)
+		}
+
 		if ff || fs || !s.shouldHandle(fullName) {
 			Logger.Debug(fmt.Sprintf("Skipping data object (type %s) '%s'", typeName, fullName))
 			return nil
@@ -325,6 +357,11 @@ func (s *DataSourceSyncer) readSchemasInDatabase(databaseName string, doTypePref
 
 		return s.addDataObjects(&do)
 	})
+	if err != nil {
+		return nil, err
+	}
+
+	return schemas, nil
 }
 
 func (s *DataSourceSyncer) addDataObjects(dataObjects ...*ds.DataObject) error {
@@ -363,8 +400,8 @@ func (s *DataSourceSyncer) createDataObjectForFunction(doType, database, schema,
 	return &do
 }
 
-func (s *DataSourceSyncer) readFunctionsInDatabase(databaseName string, tagMap map[string][]*tag.Tag) error {
-	return s.repo.GetFunctionsInDatabase(databaseName, func(entity interface{}) error {
+func (s *DataSourceSyncer) readFunctionsInDatabase(ctx context.Context, databaseName string, tagMap map[string][]*tag.Tag) error {
+	return s.repo.GetFunctionsInDatabase(ctx, databaseName, func(entity interface{}) error {
 		function := entity.(*FunctionEntity)
 
 		do := s.createDataObjectForFunction(Function, *function.Database, *function.Schema, function.Name, function.ArgumentSignature, function.Comment, tagMap)
@@ -376,8 +413,8 @@ func (s *DataSourceSyncer) readFunctionsInDatabase(databaseName string, tagMap m
 	})
 }
 
-func (s *DataSourceSyncer) readProceduresInDatabase(databaseName string, tagMap map[string][]*tag.Tag) error {
-	return s.repo.GetProceduresInDatabase(databaseName, func(entity interface{}) error {
+func (s *DataSourceSyncer) readProceduresInDatabase(ctx context.Context, databaseName string, tagMap map[string][]*tag.Tag) error {
+	return s.repo.GetProceduresInDatabase(ctx, databaseName, func(entity interface{}) error {
 		proc := entity.(*ProcedureEntity)
 
 		do := s.createDataObjectForFunction(Procedure, *proc.Database, *proc.Schema, proc.Name, proc.ArgumentSignature, proc.Comment, tagMap)
@@ -389,8 +426,64 @@ func (s *DataSourceSyncer) readProceduresInDatabase(databaseName string, tagMap
 	})
 }
 
-func (s *DataSourceSyncer) readTablesInDatabase(databaseName string, typePrefix string, fetcher func(dbName string, schemaName string, entityHandler EntityHandler) error, tagMap map[string][]*tag.Tag) error {
-	return fetcher(databaseName, "", func(entity interface{}) error {
+// readSchemaObjectsInDatabase imports the objects of the configured schema object kinds (e.g. stages) of the given schemas in the database.
+// Every kind is listed once for the whole database and only the objects in the given schemas are kept.
+func (s *DataSourceSyncer) readSchemaObjectsInDatabase(ctx context.Context, databaseName string, schemas []string, tagMap map[string][]*tag.Tag) error {
+	schemasToCrawl := set.NewSet(schemas...)
+	schemasToCrawl.Remove("INFORMATION_SCHEMA")
+
+	if len(schemasToCrawl) == 0 {
+		return nil
+	}
+
+	for _, objectType := range s.schemaObjectTypes {
+		err := s.repo.GetObjectsInDatabase(ctx, objectType.showKind, databaseName, func(entity interface{}) error {
+			object := entity.(*SchemaObjectEntity)
+
+			if !schemasToCrawl.Contains(object.Schema) {
+				return nil
+			}
+
+			schemaFullName := object.Database + "." + object.Schema
+			fullName := schemaFullName + "." + object.Name
+
+			if !s.shouldHandle(fullName) {
+				Logger.Debug(fmt.Sprintf("Skipping data object (type %s) '%s'", objectType.doType, fullName))
+				return nil
+			}
+
+			comment := ""
+			if object.Comment != nil {
+				comment = *object.Comment
+			}
+			do := ds.DataObject{
+				ExternalId:       fullName,
+				Name:             object.Name,
+				FullName:         fullName,
+				Type:             objectType.doType,
+				Description:      comment,
+				ParentExternalId: schemaFullName,
+				Tags:             tagMap[fullName],
+			}
+
+			return s.addDataObjects(&do)
+		})
+		if err != nil && objectType.snowparkContainerServices && isUnsupportedFeatureError(err) {
+			Logger.Info(fmt.Sprintf("Skipping %s in database %s as Snowpark Container Services are not available: %s", strings.ToLower(objectType.showKind), databaseName, err.Error()))
+
+			continue
+		}
+
+		if err != nil {
+			return fmt.Errorf("reading %s in database %s: %w", strings.ToLower(objectType.showKind), databaseName, err)
+		}
+	}
+
+	return nil
+}
+
+func (s *DataSourceSyncer) readTablesInDatabase(ctx context.Context, databaseName string, typePrefix string, fetcher func(ctx context.Context, dbName string, schemaName string, entityHandler EntityHandler) error, tagMap map[string][]*tag.Tag) error {
+	return fetcher(ctx, databaseName, "", func(entity interface{}) error {
 		table := entity.(*TableEntity)
 
 		typeName := convertSnowflakeTableTypeToRaito(table)
@@ -432,13 +525,13 @@ func (s *DataSourceSyncer) readTablesInDatabase(databaseName string, typePrefix
 	})
 }
 
-func (s *DataSourceSyncer) setupDatabasePermissions(db DbEntity) error {
+func (s *DataSourceSyncer) setupDatabasePermissions(ctx context.Context, db DbEntity) error {
 	// grant the SYNC role USAGE/IMPORTED PRIVILEGES on each database so it can query the INFORMATION_SCHEMA
 	if s.SfSyncRole != AccountAdmin {
-		err := s.repo.ExecuteGrantOnAccountRole("USAGE", fmt.Sprintf("DATABASE %s", common.FormatQuery("%s", db.Name)), s.SfSyncRole, true)
+		err := s.repo.ExecuteGrantOnAccountRole(ctx, "USAGE", fmt.Sprintf("DATABASE %s", common.FormatQuery("%s", db.Name)), s.SfSyncRole, true)
 
 		if err != nil && strings.Contains(err.Error(), "IMPORTED PRIVILEGES") {
-			err2 := s.repo.ExecuteGrantOnAccountRole("IMPORTED PRIVILEGES", fmt.Sprintf("DATABASE %s", common.FormatQuery("%s", db.Name)), s.SfSyncRole, true)
+			err2 := s.repo.ExecuteGrantOnAccountRole(ctx, "IMPORTED PRIVILEGES", fmt.Sprintf("DATABASE %s", common.FormatQuery("%s", db.Name)), s.SfSyncRole, true)
 
 			if err2 != nil {
 				return err2
@@ -465,7 +558,7 @@ func (s *DataSourceSyncer) setupDatabasePermissions(db DbEntity) error {
 		}
 
 		for _, perm := range permissions {
-			err2 := s.repo.ExecuteGrantOnAccountRole(perm.permission, perm.on, s.SfSyncRole, true)
+			err2 := s.repo.ExecuteGrantOnAccountRole(ctx, perm.permission, perm.on, s.SfSyncRole, true)
 			if err2 != nil {
 				return fmt.Errorf("granting %s on %s: %w", perm.permission, perm.on, err2)
 			}
@@ -475,13 +568,13 @@ func (s *DataSourceSyncer) setupDatabasePermissions(db DbEntity) error {
 	return nil
 }
 
-func (s *DataSourceSyncer) readDatabases(excludes set.Set[string], shares map[string]struct{}, shouldRetrieveTags bool) ([]ExtendedDbEntity, error) {
-	databases, err := s.repo.GetDatabases()
+func (s *DataSourceSyncer) readDatabases(ctx context.Context, excludes set.Set[string], shares map[string]struct{}, shouldRetrieveTags bool) ([]ExtendedDbEntity, error) {
+	databases, err := s.repo.GetDatabases(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	enrichedDatabases, err := s.addTopLevelEntitiesToImporter(databases, ds.Database, shouldRetrieveTags,
+	enrichedDatabases, err := s.addTopLevelEntitiesToImporter(ctx, databases, ds.Database, shouldRetrieveTags,
 		s.repo.GetTagsLinkedToDatabaseName,
 		func(name string) string { return name },
 		func(name, fullName string) bool {
@@ -495,15 +588,15 @@ func (s *DataSourceSyncer) readDatabases(excludes set.Set[string], shares map[st
 	return enrichedDatabases, nil
 }
 
-func (s *DataSourceSyncer) readShares(excludes set.Set[string], shouldRetrieveTags bool) ([]ExtendedDbEntity, set.Set[string], error) {
+func (s *DataSourceSyncer) readShares(ctx context.Context, excludes set.Set[string], shouldRetrieveTags bool) ([]ExtendedDbEntity, set.Set[string], error) {
 	// main reason is that for export they can only have "IMPORTED PRIVILEGES" granted on the shared db level and nothing else.
 	// for now we can just exclude them but they need to be treated later on
-	inboundShares, err := s.repo.GetInboundShares()
+	inboundShares, err := s.repo.GetInboundShares(ctx)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	enrichedInboundShares, err := s.addTopLevelEntitiesToImporter(inboundShares, "shared-database", shouldRetrieveTags,
+	enrichedInboundShares, err := s.addTopLevelEntitiesToImporter(ctx, inboundShares, "shared-database", shouldRetrieveTags,
 		s.repo.GetTagsLinkedToDatabaseName,
 		func(name string) string { return name },
 		func(name, fullName string) bool {
@@ -523,8 +616,8 @@ func (s *DataSourceSyncer) readShares(excludes set.Set[string], shouldRetrieveTa
 	return enrichedInboundShares, inboundSharesMap, nil
 }
 
-func (s *DataSourceSyncer) readApplications(excludes set.Set[string]) error {
-	applications, err := s.repo.GetApplications()
+func (s *DataSourceSyncer) readApplications(ctx context.Context, excludes set.Set[string]) error {
+	applications, err := s.repo.GetApplications(ctx)
 	if err != nil {
 		return fmt.Errorf("get applications: %w", err)
 	}
@@ -539,8 +632,8 @@ func (s *DataSourceSyncer) readApplications(excludes set.Set[string]) error {
 		}
 	}
 
-	_, err = s.addTopLevelEntitiesToImporter(applicationEntities, Application, false,
-		func(name string) (map[string][]*tag.Tag, error) { return nil, nil },
+	_, err = s.addTopLevelEntitiesToImporter(ctx, applicationEntities, Application, false,
+		func(_ context.Context, name string) (map[string][]*tag.Tag, error) { return nil, nil },
 		func(name string) string { return name },
 		func(name, fullName string) bool {
 			return !excludes.Contains(fullName)
@@ -553,8 +646,8 @@ func (s *DataSourceSyncer) readApplications(excludes set.Set[string]) error {
 	return nil
 }
 
-func (s *DataSourceSyncer) readWarehouses(shouldRetrieveTags bool) error {
-	dbWarehouses, err := s.repo.GetWarehouses()
+func (s *DataSourceSyncer) readWarehouses(ctx context.Context, shouldRetrieveTags bool) error {
+	dbWarehouses, err := s.repo.GetWarehouses(ctx)
 	if err != nil {
 		return err
 	}
@@ -562,15 +655,15 @@ func (s *DataSourceSyncer) readWarehouses(shouldRetrieveTags bool) error {
 	allWarehouseTags := make(map[string][]*tag.Tag, 0)
 
 	if shouldRetrieveTags {
-		allWarehouseTags, err = s.repo.GetTagsByDomain("WAREHOUSE")
+		allWarehouseTags, err = s.repo.GetTagsByDomain(ctx, "WAREHOUSE")
 
 		if err != nil {
 			return err
 		}
 	}
 
-	_, err = s.addTopLevelEntitiesToImporter(dbWarehouses, "warehouse", shouldRetrieveTags,
-		func(name string) (map[string][]*tag.Tag, error) {
+	_, err = s.addTopLevelEntitiesToImporter(ctx, dbWarehouses, "warehouse", shouldRetrieveTags,
+		func(_ context.Context, name string) (map[string][]*tag.Tag, error) {
 			return allWarehouseTags, nil
 		},
 		func(name string) string { return name },
@@ -582,8 +675,43 @@ func (s *DataSourceSyncer) readWarehouses(shouldRetrieveTags bool) error {
 	return nil
 }
 
-func (s *DataSourceSyncer) readIntegrations(shouldRetrieveTags bool) error {
-	integrations, err := s.repo.GetIntegrations()
+func (s *DataSourceSyncer) readComputePools(ctx context.Context, shouldRetrieveTags bool) error {
+	computePools, err := s.repo.GetComputePools(ctx)
+	if err != nil && isUnsupportedFeatureError(err) {
+		Logger.Info(fmt.Sprintf("Skipping compute pools as Snowpark Container Services are not available: %s", err.Error()))
+
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	computePoolTags := make(map[string][]*tag.Tag, 0)
+
+	if shouldRetrieveTags {
+		computePoolTags, err = s.repo.GetTagsByDomain(ctx, "COMPUTE POOL")
+
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = s.addTopLevelEntitiesToImporter(ctx, computePools, ComputePool, shouldRetrieveTags,
+		func(_ context.Context, name string) (map[string][]*tag.Tag, error) {
+			return computePoolTags, nil
+		},
+		func(name string) string { return name },
+		func(name, fullName string) bool { return s.shouldGoInto(fullName) })
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *DataSourceSyncer) readIntegrations(ctx context.Context, shouldRetrieveTags bool) error {
+	integrations, err := s.repo.GetIntegrations(ctx)
 	if err != nil {
 		return err
 	}
@@ -591,15 +719,15 @@ func (s *DataSourceSyncer) readIntegrations(shouldRetrieveTags bool) error {
 	integrationTags := make(map[string][]*tag.Tag, 0)
 
 	if shouldRetrieveTags {
-		integrationTags, err = s.repo.GetTagsByDomain("INTEGRATION")
+		integrationTags, err = s.repo.GetTagsByDomain(ctx, "INTEGRATION")
 
 		if err != nil {
 			return err
 		}
 	}
 
-	_, err = s.addTopLevelEntitiesToImporter(integrations, Integration, shouldRetrieveTags,
-		func(name string) (map[string][]*tag.Tag, error) {
+	_, err = s.addTopLevelEntitiesToImporter(ctx, integrations, Integration, shouldRetrieveTags,
+		func(_ context.Context, name string) (map[string][]*tag.Tag, error) {
 			return integrationTags, nil
 		},
 		func(name string) string { return name },
@@ -611,7 +739,7 @@ func (s *DataSourceSyncer) readIntegrations(shouldRetrieveTags bool) error {
 	return nil
 }
 
-func (s *DataSourceSyncer) addTopLevelEntitiesToImporter(entities []DbEntity, doType string, shouldRetrieveTags bool, tagRetrieval func(name string) (map[string][]*tag.Tag, error), externalIdGenerator func(name string) string, filter func(name, fullName string) bool) ([]ExtendedDbEntity, error) {
+func (s *DataSourceSyncer) addTopLevelEntitiesToImporter(ctx context.Context, entities []DbEntity, doType string, shouldRetrieveTags bool, tagRetrieval func(ctx context.Context, name string) (map[string][]*tag.Tag, error), externalIdGenerator func(name string) string, filter func(name, fullName string) bool) ([]ExtendedDbEntity, error) {
 	dbEntities := make([]ExtendedDbEntity, 0, len(entities))
 
 	for _, db := range entities {
@@ -626,7 +754,7 @@ func (s *DataSourceSyncer) addTopLevelEntitiesToImporter(entities []DbEntity, do
 		fullName := externalIdGenerator(extendedEntity.Entity.Name)
 		if filter(extendedEntity.Entity.Name, fullName) {
 			if shouldRetrieveTags {
-				tagMap, err := tagRetrieval(extendedEntity.Entity.Name)
+				tagMap, err := tagRetrieval(ctx, extendedEntity.Entity.Name)
 
 				if err != nil {
 					return nil, err
@@ -21,8 +21,19 @@ const apTypeApplicationRole = "applicationRole"
 const apTypeSharePrefix = "share:"
 const ExternalTable = "external-" + ds.Table
 const IcebergTable = "iceberg-" + ds.Table
+const DynamicTable = "dynamic-" + ds.Table
 const Function = "function"
 const Procedure = "procedure"
+const Stage = "stage"
+const Stream = "stream"
+const Task = "task"
+const Pipe = "pipe"
+const Sequence = "sequence"
+const FileFormat = "file-format"
+const Secret = "secret"
+const ComputePool = "compute-pool"
+const ImageRepository = "image-repository"
+const Service = "service"
 const Integration = "integration"
 const Application = "application"
 const MaterializedView = "materialized-" + ds.View
@@ -36,7 +47,7 @@ var RoleNameConstraints = naming_hint.NamingConstraints{
 	MaxLength:         255,
 }
 
-func (s *DataSourceSyncer) GetDataSourceMetaData(_ context.Context, configParam *config.ConfigMap) (*ds.MetaData, error) {
+func (s *DataSourceSyncer) GetDataSourceMetaData(ctx context.Context, configParam *config.ConfigMap) (*ds.MetaData, error) {
 	Logger.Debug("Returning meta data for Snowflake data source")
 
 	repo, err := s.repoProvider(configParam.Parameters, "")
@@ -44,7 +55,7 @@ func (s *DataSourceSyncer) GetDataSourceMetaData(_ context.Context, configParam
 		return nil, fmt.Errorf("create Snowflake repository: %w", err)
 	}
 
-	accountIdentifier, err := repo.GetSnowFlakeAccountName(func(options *GetSnowFlakeAccountNameOptions) {
+	accountIdentifier, err := repo.GetSnowFlakeAccountName(ctx, func(options *GetSnowFlakeAccountNameOptions) {
 		options.Delimiter = '.'
 	})
 	if err != nil {
@@ -67,7 +78,7 @@ func (s *DataSourceSyncer) GetDataSourceMetaData(_ context.Context, configParam
 			Levels: []*ds.UsageMetaInputDetail{
 				{
 					Name:            ds.Table,
-					DataObjectTypes: []string{ds.Table, ds.View, ExternalTable, MaterializedView, SharedPrefix + ds.Table, "shared-" + ds.View},
+					DataObjectTypes: []string{ds.Table, ds.View, ExternalTable, MaterializedView, DynamicTable, Stage, SharedPrefix + ds.Table, "shared-" + ds.View},
 				},
 			},
 		},
@@ -119,7 +130,7 @@ func (s *DataSourceSyncer) GetDataSourceMetaData(_ context.Context, configParam
 					DataTypes:   []string{"varchar", "char", "string", "text"},
 				},
 			},
-			ApplicableTypes:         []string{ds.Table, ds.View, MaterializedView, ExternalTable, IcebergTable},
+			ApplicableTypes:         []string{ds.Table, ds.View, MaterializedView, ExternalTable, IcebergTable, DynamicTable},
 			DefaultMaskExternalName: NullMaskId,
 		}
 
@@ -133,7 +144,7 @@ func (s *DataSourceSyncer) GetDataSourceMetaData(_ context.Context, configParam
 		}
 
 		metaData.ShareMetadata = &ds.ShareMetadata{
-			ApplicableTypes:           []string{ds.Database, ds.Schema, ds.Table, ds.View, ExternalTable, MaterializedView, IcebergTable, Function},
+			ApplicableTypes:           []string{ds.Database, ds.Schema, ds.Table, ds.View, ExternalTable, MaterializedView, IcebergTable, DynamicTable, Function},
 			CommonParentType:          ds.Database,
 			DataSourceShareIdentifier: accountIdentifier,
 		}
@@ -262,7 +273,37 @@ func DataObjectTypes() []*ds.DataObjectType {
 					Description: "Grants ability to set value for the SHARE_RESTRICTIONS parameter which enables a Business Critical provider account to add a consumer account (with Non-Business Critical edition) to a share.",
 				},
 			},
-			Children: []string{ds.Database, SharedPrefix + ds.Database, "warehouse", Integration, Application},
+			Children: []string{ds.Database, SharedPrefix + ds.Database, "warehouse", ComputePool, Integration, Application},
+		},
+		{
+			Name:  ComputePool,
+			Label: "Compute Pool",
+			Type:  ComputePool,
+			Permissions: []*ds.DataObjectTypePermission{
+				{
+					Permission:        USAGE,
+					Description:       "Enables using the compute pool to run services and jobs of Snowpark Container Services.",
+					GlobalPermissions: ds.ReadGlobalPermission().StringValues(),
+				},
+				{
+					Permission:             "MONITOR",
+					Description:            "Enables viewing the details and the usage of the compute pool.",
+					GlobalPermissions:      ds.AdminGlobalPermission().StringValues(),
+					UsageGlobalPermissions: []string{ds.Admin},
+				},
+				{
+					Permission:             "OPERATE",
+					Description:            "Enables changing the state of the compute pool (suspend, resume) and stopping all services running on it.",
+					GlobalPermissions:      ds.AdminGlobalPermission().StringValues(),
+					UsageGlobalPermissions: []string{ds.Admin},
+				},
+				{
+					Permission:             "OWNERSHIP",
+					Description:            "Grants full control over the compute pool. Only a single role can hold this privilege on a specific object at a time.",
+					UsageGlobalPermissions: []string{ds.Read, ds.Write, ds.Admin},
+					CannotBeGranted:        true,
+				},
+			},
 		},
 		{
 			Name: "warehouse",
@@ -487,7 +528,7 @@ func DataObjectTypes() []*ds.DataObjectType {
 					CannotBeGranted:        true,
 				},
 			},
-			Children: []string{ds.Table, ds.View, ExternalTable, MaterializedView, IcebergTable, Function, Procedure},
+			Children: []string{ds.Table, ds.View, ExternalTable, MaterializedView, IcebergTable, DynamicTable, Function, Procedure, Stage, Stream, Task, Pipe, Sequence, FileFormat, Secret, ImageRepository, Service},
 			ShareProperties: &ds.DataObjectShareProperties{
 				ShareablePermissions:     []string{USAGE_ON_SCHEMA},
 				CorrespondingSharedTypes: []string{SharedPrefix + ds.Schema},
@@ -601,6 +642,232 @@ func DataObjectTypes() []*ds.DataObjectType {
 				},
 			},
 		},
+		{
+			Name:  Stage,
+			Label: "Stage",
+			Type:  Stage,
+			Permissions: []*ds.DataObjectTypePermission{
+				{
+					Permission:             "READ",
+					Description:            "Enables performing any operations that require reading from an internal stage (GET, LIST, COPY INTO <table>).",
+					UsageGlobalPermissions: []string{ds.Read},
+					GlobalPermissions:      ds.ReadGlobalPermission().StringValues(),
+				},
+				{
+					Permission:             "WRITE",
+					Description:            "Enables performing any operations that require writing to an internal stage (PUT, REMOVE, COPY INTO <location>). The READ privilege is granted along with it, as Snowflake requires it.",
+					UsageGlobalPermissions: []string{ds.Write},
+					GlobalPermissions:      ds.WriteGlobalPermission().StringValues(),
+				},
+				{
+					Permission:             "USAGE",
+					Description:            "Enables using an external stage object in a SQL statement.",
+					UsageGlobalPermissions: []string{ds.Read, ds.Write},
+					GlobalPermissions:      ds.ReadGlobalPermission().StringValues(),
+				},
+				{
+					Permission:             "OWNERSHIP",
+					Description:            "Grants full control over the stage. Only a single role can hold this privilege on a specific object at a time.",
+					UsageGlobalPermissions: []string{ds.Read, ds.Write, ds.Admin},
+					CannotBeGranted:        true,
+				},
+			},
+			Actions: []*ds.DataObjectTypeAction{
+				{
+					Action:        "GET",
+					GlobalActions: []string{ds.Read},
+				},
+				{
+					Action:        "PUT",
+					GlobalActions: []string{ds.Write},
+				},
+				{
+					Action:        "COPY",
+					GlobalActions: []string{ds.Read, ds.Write},
+				},
+			},
+		},
+		{
+			Name:  Stream,
+			Label: "Stream",
+			Type:  Stream,
+			Permissions: []*ds.DataObjectTypePermission{
+				{
+					Permission:             "SELECT",
+					Description:            "Enables executing a SELECT statement on a stream to query its change records.",
+					UsageGlobalPermissions: []string{ds.Read},
+					GlobalPermissions:      ds.ReadGlobalPermission().StringValues(),
+				},
+				{
+					Permission:             "OWNERSHIP",
+					Description:            "Grants full control over the stream. Only a single role can hold this privilege on a specific object at a time.",
+					UsageGlobalPermissions: []string{ds.Read, ds.Write, ds.Admin},
+					CannotBeGranted:        true,
+				},
+			},
+			Actions: []*ds.DataObjectTypeAction{
+				{
+					Action:        "SELECT",
+					GlobalActions: []string{ds.Read},
+				},
+			},
+		},
+		{
+			Name:  Task,
+			Label: "Task",
+			Type:  Task,
+			Permissions: []*ds.DataObjectTypePermission{
+				{
+					Permission:  "MONITOR",
+					Description: "Enables viewing the details and the run history of the task.",
+				},
+				{
+					Permission:             "OPERATE",
+					Description:            "Enables resuming, suspending and executing the task.",
+					UsageGlobalPermissions: []string{ds.Admin},
+				},
+				{
+					Permission:             "OWNERSHIP",
+					Description:            "Grants full control over the task. Only a single role can hold this privilege on a specific object at a time.",
+					UsageGlobalPermissions: []string{ds.Read, ds.Write, ds.Admin},
+					CannotBeGranted:        true,
+				},
+			},
+		},
+		{
+			Name:  Pipe,
+			Label: "Pipe",
+			Type:  Pipe,
+			Permissions: []*ds.DataObjectTypePermission{
+				{
+					Permission:  "MONITOR",
+					Description: "Enables viewing the details of the pipe and its load status.",
+				},
+				{
+					Permission:             "OPERATE",
+					Description:            "Enables pausing and resuming the pipe and refreshing it to load staged files.",
+					UsageGlobalPermissions: []string{ds.Admin},
+				},
+				{
+					Permission:             "OWNERSHIP",
+					Description:            "Grants full control over the pipe. Only a single role can hold this privilege on a specific object at a time.",
+					UsageGlobalPermissions: []string{ds.Read, ds.Write, ds.Admin},
+					CannotBeGranted:        true,
+				},
+			},
+		},
+		{
+			Name:  Sequence,
+			Label: "Sequence",
+			Type:  Sequence,
+			Permissions: []*ds.DataObjectTypePermission{
+				{
+					Permission:             "USAGE",
+					Description:            "Enables using the sequence to generate values, e.g. with the NEXTVAL function.",
+					UsageGlobalPermissions: []string{ds.Read},
+					GlobalPermissions:      ds.ReadGlobalPermission().StringValues(),
+				},
+				{
+					Permission:             "OWNERSHIP",
+					Description:            "Grants full control over the sequence. Only a single role can hold this privilege on a specific object at a time.",
+					UsageGlobalPermissions: []string{ds.Read, ds.Write, ds.Admin},
+					CannotBeGranted:        true,
+				},
+			},
+		},
+		{
+			Name:  FileFormat,
+			Label: "File Format",
+			Type:  FileFormat,
+			Permissions: []*ds.DataObjectTypePermission{
+				{
+					Permission:             "USAGE",
+					Description:            "Enables using the file format in a stage definition or in a COPY INTO statement.",
+					UsageGlobalPermissions: []string{ds.Read},
+					GlobalPermissions:      ds.ReadGlobalPermission().StringValues(),
+				},
+				{
+					Permission:             "OWNERSHIP",
+					Description:            "Grants full control over the file format. Only a single role can hold this privilege on a specific object at a time.",
+					UsageGlobalPermissions: []string{ds.Read, ds.Write, ds.Admin},
+					CannotBeGranted:        true,
+				},
+			},
+		},
+		{
+			Name:  Secret,
+			Label: "Secret",
+			Type:  Secret,
+			Permissions: []*ds.DataObjectTypePermission{
+				{
+					Permission:  "USAGE",
+					Description: "Enables using the secret, e.g. in an external access integration used by a function or procedure.",
+				},
+				{
+					Permission:  "READ",
+					Description: "Enables reading the value of the secret.",
+				},
+				{
+					Permission:             "OWNERSHIP",
+					Description:            "Grants full control over the secret. Only a single role can hold this privilege on a specific object at a time.",
+					UsageGlobalPermissions: []string{ds.Read, ds.Write, ds.Admin},
+					CannotBeGranted:        true,
+				},
+			},
+		},
+		{
+			Name:  ImageRepository,
+			Label: "Image Repository",
+			Type:  ImageRepository,
+			Permissions: []*ds.DataObjectTypePermission{
+				{
+					Permission:             "READ",
+					Description:            "Enables pulling images from the image repository, e.g. to run them as a service.",
+					UsageGlobalPermissions: []string{ds.Read},
+					GlobalPermissions:      ds.ReadGlobalPermission().StringValues(),
+				},
+				{
+					Permission:             "WRITE",
+					Description:            "Enables pushing images to the image repository.",
+					UsageGlobalPermissions: []string{ds.Write},
+					GlobalPermissions:      ds.WriteGlobalPermission().StringValues(),
+				},
+				{
+					Permission:             "OWNERSHIP",
+					Description:            "Grants full control over the image repository. Only a single role can hold this privilege on a specific object at a time.",
+					UsageGlobalPermissions: []string{ds.Read, ds.Write, ds.Admin},
+					CannotBeGranted:        true,
+				},
+			},
+		},
+		{
+			Name:  Service,
+			Label: "Service",
+			Type:  Service,
+			Permissions: []*ds.DataObjectTypePermission{
+				{
+					Permission:             "USAGE",
+					Description:            "Enables communicating with the public endpoints of the service.",
+					UsageGlobalPermissions: []string{ds.Read},
+					GlobalPermissions:      ds.ReadGlobalPermission().StringValues(),
+				},
+				{
+					Permission:  "MONITOR",
+					Description: "Enables viewing the status and the logs of the service.",
+				},
+				{
+					Permission:             "OPERATE",
+					Description:            "Enables altering the service, e.g. suspending and resuming it.",
+					UsageGlobalPermissions: []string{ds.Admin},
+				},
+				{
+					Permission:             "OWNERSHIP",
+					Description:            "Grants full control over the service. Only a single role can hold this privilege on a specific object at a time.",
+					UsageGlobalPermissions: []string{ds.Read, ds.Write, ds.Admin},
+					CannotBeGranted:        true,
+				},
+			},
+		},
 		{
 			Name:  IcebergTable,
 			Label: "Iceberg Table",
@@ -681,6 +948,45 @@ func DataObjectTypes() []*ds.DataObjectType {
 				CorrespondingSharedTypes: []string{SharedPrefix + ds.Table},
 			},
 		},
+		{
+			Name:  DynamicTable,
+			Label: "Dynamic Table",
+			Type:  ds.Table,
+			Permissions: []*ds.DataObjectTypePermission{
+				{
+					Permission:             "SELECT",
+					Description:            "Enables executing a SELECT statement on a dynamic table.",
+					UsageGlobalPermissions: []string{ds.Read},
+					GlobalPermissions:      ds.ReadGlobalPermission().StringValues(),
+				},
+				{
+					Permission:  "MONITOR",
+					Description: "Enables viewing the refresh history and the graph of a dynamic table, and describing it with DESCRIBE DYNAMIC TABLE.",
+				},
+				{
+					Permission:             "OPERATE",
+					Description:            "Enables altering a dynamic table, i.e. refreshing it manually and suspending or resuming its refreshes.",
+					UsageGlobalPermissions: []string{ds.Admin},
+				},
+				{
+					Permission:             "OWNERSHIP",
+					Description:            "Grants full control over the dynamic table. Only a single role can hold this privilege on a specific object at a time.",
+					UsageGlobalPermissions: []string{ds.Read, ds.Write, ds.Admin},
+					CannotBeGranted:        true,
+				},
+			},
+			Actions: []*ds.DataObjectTypeAction{
+				{
+					Action:        "SELECT",
+					GlobalActions: []string{ds.Read},
+				},
+			},
+			Children: []string{ds.Column},
+			ShareProperties: &ds.DataObjectShareProperties{
+				ShareablePermissions:     []string{"SELECT"},
+				CorrespondingSharedTypes: []string{SharedPrefix + ds.Table},
+			},
+		},
 		{
 			Name:  ExternalTable,
 			Label: "External Table",
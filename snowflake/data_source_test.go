@@ -2,6 +2,7 @@ package snowflake
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"testing"
 	"time"
@@ -12,14 +13,16 @@ import (
 	"github.com/raito-io/cli/base/util/config"
 	"github.com/raito-io/cli/base/wrappers/mocks"
 	"github.com/raito-io/golang-set/set"
+	sf "github.com/snowflakedb/gosnowflake"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 func TestDataSourceSyncer_GetMetaData(t *testing.T) {
 	//Given
 	repo := newMockDataSourceRepository(t)
-	repo.EXPECT().GetSnowFlakeAccountName(mock.Anything).Return("SnowflakeAccountName", nil).Once()
+	repo.EXPECT().GetSnowFlakeAccountName(mock.Anything, mock.Anything).Return("SnowflakeAccountName", nil).Once()
 
 	syncer := DataSourceSyncer{repoProvider: func(params map[string]string, role string) (dataSourceRepository, error) {
 		return repo, nil
@@ -47,84 +50,97 @@ func TestDataSourceSyncer_SyncDataSource(t *testing.T) {
 
 	repoMock.EXPECT().Close().Return(nil).Once()
 	repoMock.EXPECT().TotalQueryTime().Return(time.Minute).Once()
-	repoMock.EXPECT().GetSnowFlakeAccountName().Return("SnowflakeAccountName", nil).Once()
-	repoMock.EXPECT().GetWarehouses().Return([]DbEntity{
+	repoMock.EXPECT().TotalRetries().Return(0).Once()
+	repoMock.EXPECT().GetSnowFlakeAccountName(mock.Anything).Return("SnowflakeAccountName", nil).Once()
+	repoMock.EXPECT().GetWarehouses(mock.Anything).Return([]DbEntity{
 		{Name: "Warehouse1"},
 		{Name: "Warehouse2"},
 	}, nil).Once()
-	repoMock.EXPECT().GetInboundShares().Return([]DbEntity{
+	repoMock.EXPECT().GetComputePools(mock.Anything).Return([]DbEntity{
+		{Name: "ComputePool1"},
+	}, nil).Once()
+	repoMock.EXPECT().GetInboundShares(mock.Anything).Return([]DbEntity{
 		{Name: "Share1"},
 	}, nil).Once()
-	repoMock.EXPECT().GetDatabases().Return([]DbEntity{
+	repoMock.EXPECT().GetDatabases(mock.Anything).Return([]DbEntity{
 		{Name: "Database1"}, {Name: "Database2"},
 	}, nil).Once()
 
-	repoMock.EXPECT().GetTagsByDomain("WAREHOUSE").Return(map[string][]*tag.Tag{}, nil).Once()
-	repoMock.EXPECT().GetTagsByDomain("INTEGRATION").Return(map[string][]*tag.Tag{}, nil).Once()
-	repoMock.EXPECT().GetTagsLinkedToDatabaseName(mock.Anything).Return(map[string][]*tag.Tag{}, nil).Times(3)
+	repoMock.EXPECT().GetTagsByDomain(mock.Anything, "WAREHOUSE").Return(map[string][]*tag.Tag{}, nil).Once()
+	repoMock.EXPECT().GetTagsByDomain(mock.Anything, "COMPUTE POOL").Return(map[string][]*tag.Tag{}, nil).Once()
+	repoMock.EXPECT().GetTagsByDomain(mock.Anything, "INTEGRATION").Return(map[string][]*tag.Tag{}, nil).Once()
+	repoMock.EXPECT().GetTagsLinkedToDatabaseName(mock.Anything, mock.Anything).Return(map[string][]*tag.Tag{}, nil).Times(3)
 
-	repoMock.EXPECT().GetIntegrations().Return([]DbEntity{}, nil).Once()
+	repoMock.EXPECT().GetIntegrations(mock.Anything).Return([]DbEntity{}, nil).Once()
 
-	repoMock.EXPECT().GetSchemasInDatabase("Database1", mock.Anything).RunAndReturn(func(s string, handler EntityHandler) error {
+	repoMock.EXPECT().GetSchemasInDatabase(mock.Anything, "Database1", mock.Anything).RunAndReturn(func(ctx context.Context, s string, handler EntityHandler) error {
 		handler(&SchemaEntity{Database: s, Name: "schema1"})
 		return nil
 	}).Once()
 
-	repoMock.EXPECT().GetSchemasInDatabase("Database2", mock.Anything).RunAndReturn(func(s string, handler EntityHandler) error {
+	repoMock.EXPECT().GetSchemasInDatabase(mock.Anything, "Database2", mock.Anything).RunAndReturn(func(ctx context.Context, s string, handler EntityHandler) error {
 		handler(&SchemaEntity{Database: s, Name: "schema2"})
 		return nil
 	}).Once()
 
-	repoMock.EXPECT().GetSchemasInDatabase("Share1", mock.Anything).RunAndReturn(func(s string, handler EntityHandler) error {
+	repoMock.EXPECT().GetSchemasInDatabase(mock.Anything, "Share1", mock.Anything).RunAndReturn(func(ctx context.Context, s string, handler EntityHandler) error {
 		handler(&SchemaEntity{Database: s, Name: "schema3"})
 		return nil
 	}).Once()
 
-	repoMock.EXPECT().GetTablesInDatabase("Database1", "", mock.Anything).RunAndReturn(func(s string, s2 string, handler EntityHandler) error {
+	repoMock.EXPECT().GetTablesInDatabase(mock.Anything, "Database1", "", mock.Anything).RunAndReturn(func(ctx context.Context, s string, s2 string, handler EntityHandler) error {
 		handler(&TableEntity{Database: s, Schema: s2, Name: "Table1", TableType: "BASE TABLE"})
 		handler(&TableEntity{Database: s, Schema: s2, Name: "Table2", TableType: "BASE TABLE"})
 		handler(&TableEntity{Database: s, Schema: s2, Name: "View1", TableType: "VIEW"})
 		return nil
 	}).Once()
 
-	repoMock.EXPECT().GetFunctionsInDatabase("Database1", mock.Anything).RunAndReturn(func(s string, handler EntityHandler) error {
+	repoMock.EXPECT().GetFunctionsInDatabase(mock.Anything, "Database1", mock.Anything).RunAndReturn(func(ctx context.Context, s string, handler EntityHandler) error {
 		handler(&FunctionEntity{Database: &s, Schema: utils.Ptr("schema1"), Name: "Decrypt", ArgumentSignature: "(VARCHAR)"})
 		return nil
 	}).Once()
 
-	repoMock.EXPECT().GetFunctionsInDatabase("Database2", mock.Anything).RunAndReturn(func(s string, handler EntityHandler) error {
+	repoMock.EXPECT().GetFunctionsInDatabase(mock.Anything, "Database2", mock.Anything).RunAndReturn(func(ctx context.Context, s string, handler EntityHandler) error {
 		return nil
 	}).Once()
 
-	repoMock.EXPECT().GetProceduresInDatabase("Database1", mock.Anything).RunAndReturn(func(s string, handler EntityHandler) error {
+	repoMock.EXPECT().GetProceduresInDatabase(mock.Anything, "Database1", mock.Anything).RunAndReturn(func(ctx context.Context, s string, handler EntityHandler) error {
 		return nil
 	}).Once()
 
-	repoMock.EXPECT().GetProceduresInDatabase("Database2", mock.Anything).RunAndReturn(func(s string, handler EntityHandler) error {
+	repoMock.EXPECT().GetProceduresInDatabase(mock.Anything, "Database2", mock.Anything).RunAndReturn(func(ctx context.Context, s string, handler EntityHandler) error {
 		handler(&ProcedureEntity{Database: &s, Schema: utils.Ptr("schema2"), Name: "myProc", ArgumentSignature: "(VARCHAR)"})
 		return nil
 	}).Once()
 
-	repoMock.EXPECT().GetTablesInDatabase("Database2", "", mock.Anything).RunAndReturn(func(s string, s2 string, handler EntityHandler) error {
+	repoMock.EXPECT().GetObjectsInDatabase(mock.Anything, "STAGES", "Database1", mock.Anything).RunAndReturn(func(ctx context.Context, kind string, s string, handler EntityHandler) error {
+		handler(&SchemaObjectEntity{Database: s, Schema: "schema1", Name: "Stage1"})
+		return nil
+	}).Once()
+
+	repoMock.EXPECT().GetObjectsInDatabase(mock.Anything, mock.Anything, "Database1", mock.Anything).Return(nil).Times(len(schemaObjectTypes) - 1)
+	repoMock.EXPECT().GetObjectsInDatabase(mock.Anything, mock.Anything, "Database2", mock.Anything).Return(nil).Times(len(schemaObjectTypes))
+
+	repoMock.EXPECT().GetTablesInDatabase(mock.Anything, "Database2", "", mock.Anything).RunAndReturn(func(ctx context.Context, s string, s2 string, handler EntityHandler) error {
 		handler(&TableEntity{Database: s, Schema: s2, Name: "Table3", TableType: "BASE TABLE"})
 		return nil
 	}).Once()
 
-	repoMock.EXPECT().GetTablesInDatabase("Share1", "", mock.Anything).RunAndReturn(func(s string, s2 string, handler EntityHandler) error {
+	repoMock.EXPECT().GetTablesInDatabase(mock.Anything, "Share1", "", mock.Anything).RunAndReturn(func(ctx context.Context, s string, s2 string, handler EntityHandler) error {
 		handler(&TableEntity{Database: s, Schema: s2, Name: "Table4", TableType: "BASE TABLE"})
 		return nil
 	}).Once()
 
-	repoMock.EXPECT().GetColumnsInDatabase("Database1", mock.Anything).RunAndReturn(func(s string, handler EntityHandler) error {
+	repoMock.EXPECT().GetColumnsInDatabase(mock.Anything, "Database1", mock.Anything).RunAndReturn(func(ctx context.Context, s string, handler EntityHandler) error {
 		handler(&ColumnEntity{Database: s, Schema: "schema1", Table: "Table1", Name: "IDColumn"})
 		return nil
 	}).Once()
 
-	repoMock.EXPECT().GetColumnsInDatabase("Database2", mock.Anything).RunAndReturn(func(s string, handler EntityHandler) error {
+	repoMock.EXPECT().GetColumnsInDatabase(mock.Anything, "Database2", mock.Anything).RunAndReturn(func(ctx context.Context, s string, handler EntityHandler) error {
 		return nil
 	}).Once()
 
-	repoMock.EXPECT().GetColumnsInDatabase("Share1", mock.Anything).RunAndReturn(func(s string, handler EntityHandler) error {
+	repoMock.EXPECT().GetColumnsInDatabase(mock.Anything, "Share1", mock.Anything).RunAndReturn(func(ctx context.Context, s string, handler EntityHandler) error {
 		return nil
 	}).Once()
 
@@ -137,7 +153,7 @@ func TestDataSourceSyncer_SyncDataSource(t *testing.T) {
 
 	//Then
 	assert.NoError(t, err)
-	assert.Len(t, dataSourceObjectHandlerMock.DataObjects, 16)
+	assert.Len(t, dataSourceObjectHandlerMock.DataObjects, 18)
 	assert.Equal(t, "SnowflakeAccountName", dataSourceObjectHandlerMock.DataSourceName)
 	assert.Equal(t, "SnowflakeAccountName", dataSourceObjectHandlerMock.DataSourceFullName)
 }
@@ -153,7 +169,8 @@ func TestDataSourceSyncer_SyncDataSource_ErrorOnSnowflakeAccount(t *testing.T) {
 
 	repoMock.EXPECT().Close().Return(nil).Once()
 	repoMock.EXPECT().TotalQueryTime().Return(time.Minute).Once()
-	repoMock.EXPECT().GetSnowFlakeAccountName().Return("", fmt.Errorf("boom")).Once()
+	repoMock.EXPECT().TotalRetries().Return(0).Once()
+	repoMock.EXPECT().GetSnowFlakeAccountName(mock.Anything).Return("", fmt.Errorf("boom")).Once()
 
 	syncer := createSyncer(repoMock)
 
@@ -190,14 +207,14 @@ func TestDataSourceSyncer_SyncDataSource_addDbEntitiesToImporter(t *testing.T) {
 		return "external-" + name
 	}
 
-	repoMock.EXPECT().GetTagsLinkedToDatabaseName("Object1").Once().Return(nil, nil)
-	repoMock.EXPECT().GetTagsLinkedToDatabaseName("Object2").Once().Return(nil, nil)
+	repoMock.EXPECT().GetTagsLinkedToDatabaseName(mock.Anything, "Object1").Once().Return(nil, nil)
+	repoMock.EXPECT().GetTagsLinkedToDatabaseName(mock.Anything, "Object2").Once().Return(nil, nil)
 
 	syncer := createSyncer(nil)
 	syncer.dataSourceHandler = dataSourceObjectHandlerMock
 
 	//When
-	returnedEntities, err := syncer.addTopLevelEntitiesToImporter(entities, doType, true, repoMock.GetTagsLinkedToDatabaseName, externalIdGenerator, filter)
+	returnedEntities, err := syncer.addTopLevelEntitiesToImporter(context.Background(), entities, doType, true, repoMock.GetTagsLinkedToDatabaseName, externalIdGenerator, filter)
 
 	//Then
 	assert.NoError(t, err)
@@ -244,7 +261,7 @@ func TestDataSourceSyncer_SyncDataSource_addDbEntitiesToImporter_ErrorOnAddDataO
 	syncer.dataSourceHandler = dataSourceObjectHandlerMock
 
 	//When
-	returnedEntities, err := syncer.addTopLevelEntitiesToImporter(entities, doType, false, repoMock.GetTagsLinkedToDatabaseName, externalIdGenerator, filter)
+	returnedEntities, err := syncer.addTopLevelEntitiesToImporter(context.Background(), entities, doType, false, repoMock.GetTagsLinkedToDatabaseName, externalIdGenerator, filter)
 
 	//Then
 	assert.Error(t, err)
@@ -256,12 +273,12 @@ func TestDataSourceSyncer_SyncDataSource_readWarehouses(t *testing.T) {
 	repoMock := newMockDataSourceRepository(t)
 	dataSourceObjectHandlerMock := mocks.NewSimpleDataSourceObjectHandler(t, 1)
 
-	repoMock.EXPECT().GetWarehouses().Return([]DbEntity{
+	repoMock.EXPECT().GetWarehouses(mock.Anything).Return([]DbEntity{
 		{Name: "Warehouse1"},
 		{Name: "Warehouse2"},
 	}, nil).Once()
 
-	repoMock.EXPECT().GetTagsByDomain("WAREHOUSE").Return(map[string][]*tag.Tag{
+	repoMock.EXPECT().GetTagsByDomain(mock.Anything, "WAREHOUSE").Return(map[string][]*tag.Tag{
 		"Warehouse1": {
 			{Key: "tag1", Value: "value1"},
 		},
@@ -272,7 +289,7 @@ func TestDataSourceSyncer_SyncDataSource_readWarehouses(t *testing.T) {
 	syncer.dataSourceHandler = dataSourceObjectHandlerMock
 
 	//When
-	err := syncer.readWarehouses(true)
+	err := syncer.readWarehouses(context.Background(), true)
 
 	//Then
 	assert.NoError(t, err)
@@ -294,16 +311,80 @@ func TestDataSourceSyncer_SyncDataSource_readWarehouses(t *testing.T) {
 	})
 }
 
+func TestDataSourceSyncer_SyncDataSource_readComputePools(t *testing.T) {
+	//Given
+	repoMock := newMockDataSourceRepository(t)
+	dataSourceObjectHandlerMock := mocks.NewSimpleDataSourceObjectHandler(t, 1)
+
+	repoMock.EXPECT().GetComputePools(mock.Anything).Return([]DbEntity{
+		{Name: "Pool1", Comment: utils.Ptr("GPU pool")},
+		{Name: "Pool2"},
+	}, nil).Once()
+
+	repoMock.EXPECT().GetTagsByDomain(mock.Anything, "COMPUTE POOL").Return(map[string][]*tag.Tag{
+		"Pool2": {
+			{Key: "tag1", Value: "value1"},
+		},
+	}, nil).Once()
+
+	syncer := createSyncer(nil)
+	syncer.repo = repoMock
+	syncer.dataSourceHandler = dataSourceObjectHandlerMock
+
+	//When
+	err := syncer.readComputePools(context.Background(), true)
+
+	//Then
+	assert.NoError(t, err)
+	assert.Equal(t, []data_source.DataObject{
+		{
+			Name:        "Pool1",
+			Type:        ComputePool,
+			FullName:    "Pool1",
+			ExternalId:  "Pool1",
+			Description: "GPU pool",
+		},
+		{
+			Name:       "Pool2",
+			Type:       ComputePool,
+			FullName:   "Pool2",
+			ExternalId: "Pool2",
+			Tags: []*tag.Tag{
+				{Key: "tag1", Value: "value1"},
+			},
+		},
+	}, dataSourceObjectHandlerMock.DataObjects)
+}
+
+func TestDataSourceSyncer_SyncDataSource_readComputePools_notAvailable(t *testing.T) {
+	//Given
+	repoMock := newMockDataSourceRepository(t)
+	dataSourceObjectHandlerMock := mocks.NewSimpleDataSourceObjectHandler(t, 1)
+
+	repoMock.EXPECT().GetComputePools(mock.Anything).Return(nil, fmt.Errorf("querying snowflake: %w", &sf.SnowflakeError{Number: 2040, SQLState: "0A000", Message: "SQL compilation error: Unsupported feature 'COMPUTE POOL'."})).Once()
+
+	syncer := createSyncer(nil)
+	syncer.repo = repoMock
+	syncer.dataSourceHandler = dataSourceObjectHandlerMock
+
+	//When
+	err := syncer.readComputePools(context.Background(), true)
+
+	//Then
+	assert.NoError(t, err)
+	assert.Empty(t, dataSourceObjectHandlerMock.DataObjects)
+}
+
 func TestDataSourceSyncer_SyncDataSource_readIntegrations(t *testing.T) {
 	//Given
 	repoMock := newMockDataSourceRepository(t)
 	dataSourceObjectHandlerMock := mocks.NewSimpleDataSourceObjectHandler(t, 1)
 
-	repoMock.EXPECT().GetIntegrations().Return([]DbEntity{
+	repoMock.EXPECT().GetIntegrations(mock.Anything).Return([]DbEntity{
 		{Name: "Integration1"},
 	}, nil).Once()
 
-	repoMock.EXPECT().GetTagsByDomain("INTEGRATION").Return(map[string][]*tag.Tag{
+	repoMock.EXPECT().GetTagsByDomain(mock.Anything, "INTEGRATION").Return(map[string][]*tag.Tag{
 		"Integration1": {
 			{Key: "tag1", Value: "value1"},
 		},
@@ -314,7 +395,7 @@ func TestDataSourceSyncer_SyncDataSource_readIntegrations(t *testing.T) {
 	syncer.dataSourceHandler = dataSourceObjectHandlerMock
 
 	//When
-	err := syncer.readIntegrations(true)
+	err := syncer.readIntegrations(context.Background(), true)
 
 	//Then
 	assert.NoError(t, err)
@@ -337,7 +418,7 @@ func TestDataSourceSyncer_SyncDataSource_readShares(t *testing.T) {
 
 	excludedDatabases := set.NewSet[string]("ExcludeShare1", "ExcludeShare2")
 
-	repoMock.EXPECT().GetInboundShares().Return([]DbEntity{
+	repoMock.EXPECT().GetInboundShares(mock.Anything).Return([]DbEntity{
 		{Name: "Share1"}, {Name: "ExcludeShare1"}, {Name: "Share2"}, {Name: "ExcludeShare2"},
 	}, nil).Once()
 
@@ -346,7 +427,7 @@ func TestDataSourceSyncer_SyncDataSource_readShares(t *testing.T) {
 	syncer.dataSourceHandler = dataSourceObjectHandlerMock
 
 	//When
-	shares, shareMap, err := syncer.readShares(excludedDatabases, false)
+	shares, shareMap, err := syncer.readShares(context.Background(), excludedDatabases, false)
 
 	//Then
 	assert.NoError(t, err)
@@ -375,17 +456,17 @@ func TestDataSourceSyncer_SyncDataSource_readDatabases(t *testing.T) {
 
 	excludedDatabases := set.NewSet[string]("ExcludeDatabase1", "ExcludeDatabase2")
 
-	repoMock.EXPECT().GetDatabases().Return([]DbEntity{
+	repoMock.EXPECT().GetDatabases(mock.Anything).Return([]DbEntity{
 		{Name: "DB1"}, {Name: "ExcludeDatabase1"}, {Name: "DB2"}, {Name: "ExcludeDatabase2"},
 	}, nil).Once()
-	repoMock.EXPECT().GetTagsLinkedToDatabaseName("DB1").Return(map[string][]*tag.Tag{
+	repoMock.EXPECT().GetTagsLinkedToDatabaseName(mock.Anything, "DB1").Return(map[string][]*tag.Tag{
 		"DB1": {
 			{Key: "key1", Value: "val1"},
 		}, "DB1.Other": {
 			{Key: "key2", Value: "val2"},
 		},
 	}, nil).Once()
-	repoMock.EXPECT().GetTagsLinkedToDatabaseName("DB2").Return(map[string][]*tag.Tag{
+	repoMock.EXPECT().GetTagsLinkedToDatabaseName(mock.Anything, "DB2").Return(map[string][]*tag.Tag{
 		"DB2.Another": {
 			{Key: "key2", Value: "val2"},
 		},
@@ -396,7 +477,7 @@ func TestDataSourceSyncer_SyncDataSource_readDatabases(t *testing.T) {
 	syncer.dataSourceHandler = dataSourceObjectHandlerMock
 
 	//When
-	entities, err := syncer.readDatabases(excludedDatabases, map[string]struct{}{}, true)
+	entities, err := syncer.readDatabases(context.Background(), excludedDatabases, map[string]struct{}{}, true)
 
 	//Then
 	assert.NoError(t, err)
@@ -448,7 +529,7 @@ func TestDataSourceSyncer_SyncDataSource_readSchemaInDatabase(t *testing.T) {
 		"DB1.ExcludeSchema2": {},
 	}
 
-	repoMock.EXPECT().GetSchemasInDatabase(databaseName, mock.Anything).RunAndReturn(func(s string, handler EntityHandler) error {
+	repoMock.EXPECT().GetSchemasInDatabase(mock.Anything, databaseName, mock.Anything).RunAndReturn(func(ctx context.Context, s string, handler EntityHandler) error {
 		handler(&SchemaEntity{Database: s, Name: "Schema1"})
 		handler(&SchemaEntity{Database: s, Name: "ExcludeSchema1"})
 		handler(&SchemaEntity{Database: s, Name: "ExcludeSchema2"})
@@ -462,10 +543,11 @@ func TestDataSourceSyncer_SyncDataSource_readSchemaInDatabase(t *testing.T) {
 	syncer.schemaExcludes = excludeSchemas
 
 	//When
-	err := syncer.readSchemasInDatabase(databaseName, "prefix-", map[string][]*tag.Tag{})
+	schemas, err := syncer.readSchemasInDatabase(context.Background(), databaseName, "prefix-", map[string][]*tag.Tag{})
 
 	//Then
 	assert.NoError(t, err)
+	assert.Equal(t, []string{"Schema1", "Schema2"}, schemas)
 	assert.Len(t, dataSourceObjectHandlerMock.DataObjects, 2)
 	assert.Contains(t, dataSourceObjectHandlerMock.DataObjects, data_source.DataObject{
 		Name:             "Schema1",
@@ -483,6 +565,263 @@ func TestDataSourceSyncer_SyncDataSource_readSchemaInDatabase(t *testing.T) {
 	})
 }
 
+func TestDataSourceSyncer_SyncDataSource_readTablesInDatabase(t *testing.T) {
+	//Given
+	repoMock := newMockDataSourceRepository(t)
+	dataSourceObjectHandlerMock := mocks.NewSimpleDataSourceObjectHandler(t, 1)
+
+	repoMock.EXPECT().GetTablesInDatabase(mock.Anything, "DB1", "", mock.Anything).RunAndReturn(func(ctx context.Context, s string, s2 string, handler EntityHandler) error {
+		handler(&TableEntity{Database: s, Schema: "Schema1", Name: "Table1", TableType: "BASE TABLE", IsIcebergStr: "NO", IsDynamicStr: "NO"})
+		handler(&TableEntity{Database: s, Schema: "Schema1", Name: "Iceberg1", TableType: "BASE TABLE", IsIcebergStr: "YES", IsDynamicStr: "NO"})
+		handler(&TableEntity{Database: s, Schema: "Schema1", Name: "Dynamic1", TableType: "BASE TABLE", IsIcebergStr: "NO", IsDynamicStr: "YES"})
+		return nil
+	}).Once()
+
+	syncer := createSyncer(nil)
+	syncer.repo = repoMock
+	syncer.dataSourceHandler = dataSourceObjectHandlerMock
+
+	//When
+	err := syncer.readTablesInDatabase(context.Background(), "DB1", "", repoMock.GetTablesInDatabase, map[string][]*tag.Tag{})
+
+	//Then
+	assert.NoError(t, err)
+	assert.Len(t, dataSourceObjectHandlerMock.DataObjects, 3)
+	assert.Equal(t, data_source.Table, dataSourceObjectHandlerMock.DataObjects[0].Type)
+	assert.Equal(t, IcebergTable, dataSourceObjectHandlerMock.DataObjects[1].Type)
+	assert.Equal(t, data_source.DataObject{
+		Name:             "Dynamic1",
+		Type:             DynamicTable,
+		FullName:         "DB1.Schema1.Dynamic1",
+		ExternalId:       "DB1.Schema1.Dynamic1",
+		ParentExternalId: "DB1.Schema1",
+	}, dataSourceObjectHandlerMock.DataObjects[2])
+}
+
+func TestDataSourceSyncer_SyncDataSource_readSchemaObjectsInDatabase(t *testing.T) {
+	//Given
+	repoMock := newMockDataSourceRepository(t)
+	dataSourceObjectHandlerMock := mocks.NewSimpleDataSourceObjectHandler(t, 1)
+
+	repoMock.EXPECT().GetObjectsInDatabase(mock.Anything, "STAGES", "DB1", mock.Anything).RunAndReturn(func(ctx context.Context, kind string, s string, handler EntityHandler) error {
+		handler(&SchemaObjectEntity{Database: s, Schema: "Schema1", Name: "Landing", Comment: utils.Ptr("Raw files")})
+		handler(&SchemaObjectEntity{Database: s, Schema: "Schema1", Name: "Export"})
+		handler(&SchemaObjectEntity{Database: s, Schema: "NotCrawled", Name: "Other"})
+		return nil
+	}).Once()
+
+	repoMock.EXPECT().GetObjectsInDatabase(mock.Anything, "STREAMS", "DB1", mock.Anything).RunAndReturn(func(ctx context.Context, kind string, s string, handler EntityHandler) error {
+		handler(&SchemaObjectEntity{Database: s, Schema: "Schema1", Name: "OrderChanges"})
+		return nil
+	}).Once()
+
+	repoMock.EXPECT().GetObjectsInDatabase(mock.Anything, "TASKS", "DB1", mock.Anything).RunAndReturn(func(ctx context.Context, kind string, s string, handler EntityHandler) error {
+		handler(&SchemaObjectEntity{Database: s, Schema: "Schema1", Name: "MergeOrders"})
+		return nil
+	}).Once()
+
+	repoMock.EXPECT().GetObjectsInDatabase(mock.Anything, "PIPES", "DB1", mock.Anything).RunAndReturn(func(ctx context.Context, kind string, s string, handler EntityHandler) error {
+		handler(&SchemaObjectEntity{Database: s, Schema: "Schema1", Name: "LoadOrders"})
+		return nil
+	}).Once()
+
+	repoMock.EXPECT().GetObjectsInDatabase(mock.Anything, "SEQUENCES", "DB1", mock.Anything).RunAndReturn(func(ctx context.Context, kind string, s string, handler EntityHandler) error {
+		handler(&SchemaObjectEntity{Database: s, Schema: "Schema1", Name: "OrderIds"})
+		return nil
+	}).Once()
+
+	repoMock.EXPECT().GetObjectsInDatabase(mock.Anything, "FILE FORMATS", "DB1", mock.Anything).RunAndReturn(func(ctx context.Context, kind string, s string, handler EntityHandler) error {
+		handler(&SchemaObjectEntity{Database: s, Schema: "Schema1", Name: "Csv"})
+		return nil
+	}).Once()
+
+	repoMock.EXPECT().GetObjectsInDatabase(mock.Anything, "SECRETS", "DB1", mock.Anything).RunAndReturn(func(ctx context.Context, kind string, s string, handler EntityHandler) error {
+		handler(&SchemaObjectEntity{Database: s, Schema: "Schema1", Name: "ApiKey"})
+		return nil
+	}).Once()
+
+	repoMock.EXPECT().GetObjectsInDatabase(mock.Anything, "IMAGE REPOSITORIES", "DB1", mock.Anything).RunAndReturn(func(ctx context.Context, kind string, s string, handler EntityHandler) error {
+		handler(&SchemaObjectEntity{Database: s, Schema: "Schema1", Name: "Images"})
+		return nil
+	}).Once()
+
+	repoMock.EXPECT().GetObjectsInDatabase(mock.Anything, "SERVICES", "DB1", mock.Anything).RunAndReturn(func(ctx context.Context, kind string, s string, handler EntityHandler) error {
+		handler(&SchemaObjectEntity{Database: s, Schema: "Schema1", Name: "Echo"})
+		return nil
+	}).Once()
+
+	syncer := createSyncer(nil)
+	syncer.repo = repoMock
+	syncer.dataSourceHandler = dataSourceObjectHandlerMock
+	syncer.schemaObjectTypes = schemaObjectTypes
+
+	//When
+	err := syncer.readSchemaObjectsInDatabase(context.Background(), "DB1", []string{"INFORMATION_SCHEMA", "Schema1", "Schema2"}, map[string][]*tag.Tag{})
+
+	//Then
+	assert.NoError(t, err)
+	assert.Equal(t, []data_source.DataObject{
+		{
+			Name:             "Landing",
+			Type:             Stage,
+			FullName:         "DB1.Schema1.Landing",
+			ExternalId:       "DB1.Schema1.Landing",
+			ParentExternalId: "DB1.Schema1",
+			Description:      "Raw files",
+		},
+		{
+			Name:             "Export",
+			Type:             Stage,
+			FullName:         "DB1.Schema1.Export",
+			ExternalId:       "DB1.Schema1.Export",
+			ParentExternalId: "DB1.Schema1",
+		},
+		{
+			Name:             "OrderChanges",
+			Type:             Stream,
+			FullName:         "DB1.Schema1.OrderChanges",
+			ExternalId:       "DB1.Schema1.OrderChanges",
+			ParentExternalId: "DB1.Schema1",
+		},
+		{
+			Name:             "MergeOrders",
+			Type:             Task,
+			FullName:         "DB1.Schema1.MergeOrders",
+			ExternalId:       "DB1.Schema1.MergeOrders",
+			ParentExternalId: "DB1.Schema1",
+		},
+		{
+			Name:             "LoadOrders",
+			Type:             Pipe,
+			FullName:         "DB1.Schema1.LoadOrders",
+			ExternalId:       "DB1.Schema1.LoadOrders",
+			ParentExternalId: "DB1.Schema1",
+		},
+		{
+			Name:             "OrderIds",
+			Type:             Sequence,
+			FullName:         "DB1.Schema1.OrderIds",
+			ExternalId:       "DB1.Schema1.OrderIds",
+			ParentExternalId: "DB1.Schema1",
+		},
+		{
+			Name:             "Csv",
+			Type:             FileFormat,
+			FullName:         "DB1.Schema1.Csv",
+			ExternalId:       "DB1.Schema1.Csv",
+			ParentExternalId: "DB1.Schema1",
+		},
+		{
+			Name:             "ApiKey",
+			Type:             Secret,
+			FullName:         "DB1.Schema1.ApiKey",
+			ExternalId:       "DB1.Schema1.ApiKey",
+			ParentExternalId: "DB1.Schema1",
+		},
+		{
+			Name:             "Images",
+			Type:             ImageRepository,
+			FullName:         "DB1.Schema1.Images",
+			ExternalId:       "DB1.Schema1.Images",
+			ParentExternalId: "DB1.Schema1",
+		},
+		{
+			Name:             "Echo",
+			Type:             Service,
+			FullName:         "DB1.Schema1.Echo",
+			ExternalId:       "DB1.Schema1.Echo",
+			ParentExternalId: "DB1.Schema1",
+		},
+	}, dataSourceObjectHandlerMock.DataObjects)
+}
+
+func TestDataSourceSyncer_SyncDataSource_readSchemaObjectsInDatabase_configuredTypes(t *testing.T) {
+	//Given
+	repoMock := newMockDataSourceRepository(t)
+	dataSourceObjectHandlerMock := mocks.NewSimpleDataSourceObjectHandler(t, 1)
+
+	repoMock.EXPECT().GetObjectsInDatabase(mock.Anything, "STAGES", "DB1", mock.Anything).RunAndReturn(func(ctx context.Context, kind string, s string, handler EntityHandler) error {
+		handler(&SchemaObjectEntity{Database: s, Schema: "Schema1", Name: "Landing"})
+		return nil
+	}).Once()
+
+	repoMock.EXPECT().GetObjectsInDatabase(mock.Anything, "STREAMS", "DB1", mock.Anything).Return(errors.New("insufficient privileges")).Once()
+
+	objectTypes, err := getSchemaObjectTypes("stream, stage")
+	require.NoError(t, err)
+
+	syncer := createSyncer(nil)
+	syncer.repo = repoMock
+	syncer.dataSourceHandler = dataSourceObjectHandlerMock
+	syncer.schemaObjectTypes = objectTypes
+
+	//When
+	err = syncer.readSchemaObjectsInDatabase(context.Background(), "DB1", []string{"Schema1"}, map[string][]*tag.Tag{})
+
+	//Then
+	assert.ErrorContains(t, err, "reading streams in database DB1: insufficient privileges")
+	assert.Equal(t, []data_source.DataObject{
+		{
+			Name:             "Landing",
+			Type:             Stage,
+			FullName:         "DB1.Schema1.Landing",
+			ExternalId:       "DB1.Schema1.Landing",
+			ParentExternalId: "DB1.Schema1",
+		},
+	}, dataSourceObjectHandlerMock.DataObjects)
+}
+
+func TestDataSourceSyncer_SyncDataSource_readSchemaObjectsInDatabase_snowparkContainerServicesNotAvailable(t *testing.T) {
+	//Given
+	repoMock := newMockDataSourceRepository(t)
+	dataSourceObjectHandlerMock := mocks.NewSimpleDataSourceObjectHandler(t, 1)
+
+	unsupported := &sf.SnowflakeError{Number: 2040, SQLState: "0A000", Message: "SQL compilation error: Unsupported feature 'SNOWPARK CONTAINER SERVICES'."}
+
+	repoMock.EXPECT().GetObjectsInDatabase(mock.Anything, "IMAGE REPOSITORIES", "DB1", mock.Anything).Return(unsupported).Once()
+	repoMock.EXPECT().GetObjectsInDatabase(mock.Anything, "SERVICES", "DB1", mock.Anything).Return(unsupported).Once()
+	repoMock.EXPECT().GetObjectsInDatabase(mock.Anything, "STAGES", "DB1", mock.Anything).RunAndReturn(func(ctx context.Context, kind string, s string, handler EntityHandler) error {
+		handler(&SchemaObjectEntity{Database: s, Schema: "Schema1", Name: "Landing"})
+		return nil
+	}).Once()
+
+	objectTypes, err := getSchemaObjectTypes("image-repository,service,stage")
+	require.NoError(t, err)
+
+	syncer := createSyncer(nil)
+	syncer.repo = repoMock
+	syncer.dataSourceHandler = dataSourceObjectHandlerMock
+	syncer.schemaObjectTypes = objectTypes
+
+	//When
+	err = syncer.readSchemaObjectsInDatabase(context.Background(), "DB1", []string{"Schema1"}, map[string][]*tag.Tag{})
+
+	//Then
+	assert.NoError(t, err)
+	assert.Equal(t, []data_source.DataObject{
+		{
+			Name:             "Landing",
+			Type:             Stage,
+			FullName:         "DB1.Schema1.Landing",
+			ExternalId:       "DB1.Schema1.Landing",
+			ParentExternalId: "DB1.Schema1",
+		},
+	}, dataSourceObjectHandlerMock.DataObjects)
+}
+
+func TestGetSchemaObjectTypes(t *testing.T) {
+	objectTypes, err := getSchemaObjectTypes("")
+	require.NoError(t, err)
+	assert.Equal(t, schemaObjectTypes, objectTypes)
+
+	objectTypes, err = getSchemaObjectTypes("secret,file-format")
+	require.NoError(t, err)
+	assert.Equal(t, []schemaObjectType{{doType: FileFormat, showKind: "FILE FORMATS"}, {doType: Secret, showKind: "SECRETS"}}, objectTypes)
+
+	_, err = getSchemaObjectTypes("stage,table,warehouse")
+	assert.EqualError(t, err, "unsupported schema object types in sf-schema-object-types: table, warehouse")
+}
+
 func TestDataSourceSyncer_SyncDataSource_partial(t *testing.T) {
 	//Given
 	repoMock := newMockDataSourceRepository(t)
@@ -490,46 +829,56 @@ func TestDataSourceSyncer_SyncDataSource_partial(t *testing.T) {
 
 	repoMock.EXPECT().Close().Return(nil).Once()
 	repoMock.EXPECT().TotalQueryTime().Return(time.Minute).Once()
-	repoMock.EXPECT().GetSnowFlakeAccountName().Return("SnowflakeAccountName", nil).Once()
-	repoMock.EXPECT().GetWarehouses().Return([]DbEntity{
+	repoMock.EXPECT().TotalRetries().Return(0).Once()
+	repoMock.EXPECT().GetSnowFlakeAccountName(mock.Anything).Return("SnowflakeAccountName", nil).Once()
+	repoMock.EXPECT().GetWarehouses(mock.Anything).Return([]DbEntity{
 		{Name: "Warehouse1"},
 		{Name: "Warehouse2"},
 	}, nil).Once()
-	repoMock.EXPECT().GetIntegrations().Return([]DbEntity{}, nil).Once()
-	repoMock.EXPECT().GetInboundShares().Return([]DbEntity{
+	repoMock.EXPECT().GetComputePools(mock.Anything).Return([]DbEntity{}, nil).Once()
+	repoMock.EXPECT().GetIntegrations(mock.Anything).Return([]DbEntity{}, nil).Once()
+	repoMock.EXPECT().GetInboundShares(mock.Anything).Return([]DbEntity{
 		{Name: "Share1"},
 	}, nil).Once()
-	repoMock.EXPECT().GetDatabases().Return([]DbEntity{
+	repoMock.EXPECT().GetDatabases(mock.Anything).Return([]DbEntity{
 		{Name: "Database1"}, {Name: "Database2"},
 	}, nil).Once()
 
-	repoMock.EXPECT().GetTagsByDomain("WAREHOUSE").Return(nil, nil).Once()
-	repoMock.EXPECT().GetTagsByDomain("INTEGRATION").Return(nil, nil).Once()
-	repoMock.EXPECT().GetTagsLinkedToDatabaseName(mock.Anything).Return(map[string][]*tag.Tag{}, nil).Once()
+	repoMock.EXPECT().GetTagsByDomain(mock.Anything, "WAREHOUSE").Return(nil, nil).Once()
+	repoMock.EXPECT().GetTagsByDomain(mock.Anything, "COMPUTE POOL").Return(nil, nil).Once()
+	repoMock.EXPECT().GetTagsByDomain(mock.Anything, "INTEGRATION").Return(nil, nil).Once()
+	repoMock.EXPECT().GetTagsLinkedToDatabaseName(mock.Anything, mock.Anything).Return(map[string][]*tag.Tag{}, nil).Once()
 
-	repoMock.EXPECT().GetSchemasInDatabase("Database1", mock.Anything).RunAndReturn(func(s string, handler EntityHandler) error {
+	repoMock.EXPECT().GetSchemasInDatabase(mock.Anything, "Database1", mock.Anything).RunAndReturn(func(ctx context.Context, s string, handler EntityHandler) error {
 		handler(&SchemaEntity{Database: s, Name: "schema1"})
 		handler(&SchemaEntity{Database: s, Name: "schema2"})
 		return nil
 	}).Once()
 
-	repoMock.EXPECT().GetTablesInDatabase("Database1", "", mock.Anything).RunAndReturn(func(s string, s2 string, handler EntityHandler) error {
+	repoMock.EXPECT().GetTablesInDatabase(mock.Anything, "Database1", "", mock.Anything).RunAndReturn(func(ctx context.Context, s string, s2 string, handler EntityHandler) error {
 		handler(&TableEntity{Database: s, Schema: "schema1", Name: "Table1", TableType: "BASE TABLE"})
 		handler(&TableEntity{Database: s, Schema: "schema1", Name: "Table2", TableType: "BASE TABLE"})
 		handler(&TableEntity{Database: s, Schema: "schema1", Name: "View1", TableType: "VIEW"})
 		return nil
 	}).Once()
 
-	repoMock.EXPECT().GetFunctionsInDatabase("Database1", mock.Anything).RunAndReturn(func(s string, handler EntityHandler) error {
+	repoMock.EXPECT().GetFunctionsInDatabase(mock.Anything, "Database1", mock.Anything).RunAndReturn(func(ctx context.Context, s string, handler EntityHandler) error {
 		handler(&FunctionEntity{Database: &s, Schema: utils.Ptr("schema1"), Name: "Decrypt", ArgumentSignature: "(VARCHAR)"})
 		return nil
 	}).Once()
 
-	repoMock.EXPECT().GetProceduresInDatabase("Database1", mock.Anything).RunAndReturn(func(s string, handler EntityHandler) error {
+	repoMock.EXPECT().GetProceduresInDatabase(mock.Anything, "Database1", mock.Anything).RunAndReturn(func(ctx context.Context, s string, handler EntityHandler) error {
 		return nil
 	}).Once()
 
-	repoMock.EXPECT().GetColumnsInDatabase("Database1", mock.Anything).RunAndReturn(func(s string, handler EntityHandler) error {
+	repoMock.EXPECT().GetObjectsInDatabase(mock.Anything, "STAGES", "Database1", mock.Anything).RunAndReturn(func(ctx context.Context, kind string, s string, handler EntityHandler) error {
+		handler(&SchemaObjectEntity{Database: s, Schema: "schema1", Name: "Stage1"})
+		return nil
+	}).Once()
+
+	repoMock.EXPECT().GetObjectsInDatabase(mock.Anything, mock.Anything, "Database1", mock.Anything).Return(nil).Times(len(schemaObjectTypes) - 1)
+
+	repoMock.EXPECT().GetColumnsInDatabase(mock.Anything, "Database1", mock.Anything).RunAndReturn(func(ctx context.Context, s string, handler EntityHandler) error {
 		handler(&ColumnEntity{Database: s, Schema: "schema1", Table: "Table1", Name: "IDColumn"})
 		handler(&ColumnEntity{Database: s, Schema: "schema1", Table: "Table2", Name: "AnotherColumn"})
 		handler(&ColumnEntity{Database: s, Schema: "schema2", Table: "View1", Name: "ViewColumn"})
@@ -547,10 +896,11 @@ func TestDataSourceSyncer_SyncDataSource_partial(t *testing.T) {
 
 	//Then
 	assert.NoError(t, err)
-	assert.Len(t, dataSourceObjectHandlerMock.DataObjects, 3)
+	assert.Len(t, dataSourceObjectHandlerMock.DataObjects, 4)
 	assert.Equal(t, `Database1.schema1."Decrypt"(VARCHAR)`, dataSourceObjectHandlerMock.DataObjects[0].FullName)
-	assert.Equal(t, "Database1.schema1.Table1", dataSourceObjectHandlerMock.DataObjects[1].FullName)
-	assert.Equal(t, "Database1.schema1.Table1.IDColumn", dataSourceObjectHandlerMock.DataObjects[2].FullName)
+	assert.Equal(t, "Database1.schema1.Stage1", dataSourceObjectHandlerMock.DataObjects[1].FullName)
+	assert.Equal(t, "Database1.schema1.Table1", dataSourceObjectHandlerMock.DataObjects[2].FullName)
+	assert.Equal(t, "Database1.schema1.Table1.IDColumn", dataSourceObjectHandlerMock.DataObjects[3].FullName)
 }
 
 func createSyncer(repo dataSourceRepository) *DataSourceSyncer {
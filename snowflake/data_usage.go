@@ -24,6 +24,7 @@ import (
 type dataUsageRepository interface {
 	Close() error
 	TotalQueryTime() time.Duration
+	TotalRetries() int
 	GetDataUsage(ctx context.Context, minTime time.Time, maxTime *time.Time, excludedUsers set.Set[string]) <-chan stream.MaybeError[UsageQueryResult]
 }
 
@@ -36,22 +37,27 @@ func NewDataUsageSyncer() *DataUsageSyncer {
 }
 
 func newDataUsageSnowflakeRepo(params map[string]string, role string) (dataUsageRepository, error) {
-	return NewSnowflakeRepository(params, role)
+	return NewSnowflakeRepository(params, roleForSyncPhase(params, role, SyncPhaseUsage), WithSyncPhase(SyncPhaseUsage), WithWarehouse(warehouseForSyncPhase(params, SyncPhaseUsage)))
 }
 
-func (s *DataUsageSyncer) SyncDataUsage(ctx context.Context, fileCreator wrappers.DataUsageStatementHandler, configParams *config.ConfigMap) error {
+func (s *DataUsageSyncer) SyncDataUsage(ctx context.Context, fileCreator wrappers.DataUsageStatementHandler, configParams *config.ConfigMap) (err error) {
 	if configParams.GetBoolWithDefault(SfStandardEdition, false) {
 		return errors.New("data usage is not supported in standard edition. Please upgrade to enterprise edition or skip usage sync")
 	}
 
+	ctx, endSpan := startSyncPhaseSpan(ctx, configParams.Parameters, SyncPhaseUsage)
+	defer func() { endSpan(err) }()
+
 	repo, err := s.repoProvider(configParams.Parameters, "")
 	if err != nil {
 		return err
 	}
 
 	defer func() {
-		Logger.Info(fmt.Sprintf("Total snowflake query time:  %s", repo.TotalQueryTime()))
+		Logger.Info(fmt.Sprintf("Total snowflake query time:  %s (%d retries)", repo.TotalQueryTime(), repo.TotalRetries()))
 		repo.Close()
+
+		writeStatementMetrics(configParams.Parameters)
 	}()
 
 	numberOfDays := configParams.GetIntWithDefault(SfDataUsageWindow, 90)
@@ -192,11 +198,82 @@ func usageQueryResultToStatement(input *UsageQueryResult) (statement du.Statemen
 		return statement
 	}
 
-	statement.AccessedDataObjects = objects
+	statement.AccessedDataObjects = parseStageAccess(input, objects)
 
 	return statement
 }
 
+// stageQueryTypes maps the query types that move files from or to a stage onto the action on the stage
+var stageQueryTypes = map[string]du.ActionType{
+	"PUT_FILES": du.Write,
+	"GET_FILES": du.Read,
+	"COPY":      du.Read,  // COPY INTO <table> FROM @stage
+	"UNLOAD":    du.Write, // COPY INTO @stage FROM <table>
+}
+
+var stageIdentifierRegex = regexp.MustCompile(`"[^"]+"|[A-Za-z_][A-Za-z0-9_$]*`)
+var stageReferenceRegex = regexp.MustCompile(`@((?:` + stageIdentifierRegex.String() + `)(?:\.(?:` + stageIdentifierRegex.String() + `)){0,2})`)
+
+// parseStageAccess adds the named stages referenced in PUT, GET and COPY statements, as these are not part of the access history.
+// User stages (@~) and table stages (@%table) are ignored.
+func parseStageAccess(input *UsageQueryResult, objects []du.UsageDataObjectItem) []du.UsageDataObjectItem {
+	permission, found := stageQueryTypes[input.QueryType.String]
+	if !found || !input.Query.Valid {
+		return objects
+	}
+
+	handled := set.NewSet[string]()
+
+	for _, match := range stageReferenceRegex.FindAllStringSubmatch(input.Query.String, -1) {
+		fullName, ok := stageFullName(match[1], input.DatabaseName, input.SchemaName)
+		if !ok || handled.Contains(fullName) {
+			continue
+		}
+
+		handled.Add(fullName)
+
+		objects = append(objects, du.UsageDataObjectItem{
+			DataObject: du.UsageDataObjectReference{
+				FullName: fullName,
+				Type:     Stage,
+			},
+			GlobalPermission: permission,
+		})
+	}
+
+	return objects
+}
+
+// stageFullName resolves a stage reference in a query to database.schema.stage, using the database and schema of the session if not qualified
+func stageFullName(reference string, database NullString, schema NullString) (string, bool) {
+	var parts []string
+
+	for _, part := range stageIdentifierRegex.FindAllString(reference, -1) {
+		if strings.HasPrefix(part, `"`) {
+			parts = append(parts, strings.Trim(part, `"`))
+		} else {
+			parts = append(parts, strings.ToUpper(part))
+		}
+	}
+
+	switch len(parts) {
+	case 1:
+		if !database.Valid || !schema.Valid {
+			return "", false
+		}
+
+		parts = append([]string{database.String, schema.String}, parts...)
+	case 2:
+		if !database.Valid {
+			return "", false
+		}
+
+		parts = append([]string{database.String}, parts...)
+	}
+
+	return strings.Join(parts, "."), true
+}
+
 var typeParentMap = map[string]string{
 	"table":             "schema",
 	"external table":    "schema",
@@ -204,6 +281,7 @@ var typeParentMap = map[string]string{
 	"database":          "account",
 	"view":              "schema",
 	"materialized view": "schema",
+	"dynamic table":     "schema",
 }
 
 func parseDdlModifiedObject(objectString *NullString, objects []du.UsageDataObjectItem) ([]du.UsageDataObjectItem, error) {
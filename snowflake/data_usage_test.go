@@ -29,6 +29,7 @@ func TestDataUsageSyncer_SyncDataUsage(t *testing.T) {
 
 	repoMock.EXPECT().Close().Return(nil)
 	repoMock.EXPECT().TotalQueryTime().Return(time.Minute)
+	repoMock.EXPECT().TotalRetries().Return(0)
 	repoMock.EXPECT().GetDataUsage(mock.Anything, mock.AnythingOfType("time.Time"), mock.AnythingOfType("*time.Time"), mock.Anything).Return(stream.ArrayToChannel(context.Background(), []stream.MaybeError[UsageQueryResult]{
 		stream.NewMaybeErrorValue(UsageQueryResult{
 			ExternalId:           "queryId1",
@@ -188,3 +189,68 @@ func TestDataUsageSyncer_SyncDataUsage(t *testing.T) {
 		},
 	})
 }
+
+func TestParseStageAccess(t *testing.T) {
+	tests := []struct {
+		name      string
+		queryType string
+		query     string
+		expected  []data_usage.UsageDataObjectItem
+	}{
+		{
+			name:      "put to unqualified stage",
+			queryType: "PUT_FILES",
+			query:     "PUT file:///tmp/orders.csv @landing/2024/ AUTO_COMPRESS=TRUE",
+			expected: []data_usage.UsageDataObjectItem{
+				{DataObject: data_usage.UsageDataObjectReference{FullName: "SALES_DB.PUBLIC.LANDING", Type: Stage}, GlobalPermission: data_usage.Write},
+			},
+		},
+		{
+			name:      "get from qualified quoted stage",
+			queryType: "GET_FILES",
+			query:     `GET @RAW."Exports"/orders.csv file:///tmp/`,
+			expected: []data_usage.UsageDataObjectItem{
+				{DataObject: data_usage.UsageDataObjectReference{FullName: "SALES_DB.RAW.Exports", Type: Stage}, GlobalPermission: data_usage.Read},
+			},
+		},
+		{
+			name:      "copy into table",
+			queryType: "COPY",
+			query:     "COPY INTO orders FROM @other_db.raw.landing/orders/ FILE_FORMAT = (TYPE = CSV)",
+			expected: []data_usage.UsageDataObjectItem{
+				{DataObject: data_usage.UsageDataObjectReference{FullName: "OTHER_DB.RAW.LANDING", Type: Stage}, GlobalPermission: data_usage.Read},
+			},
+		},
+		{
+			name:      "unload to stage",
+			queryType: "UNLOAD",
+			query:     "COPY INTO @export/orders FROM (SELECT * FROM orders)",
+			expected: []data_usage.UsageDataObjectItem{
+				{DataObject: data_usage.UsageDataObjectReference{FullName: "SALES_DB.PUBLIC.EXPORT", Type: Stage}, GlobalPermission: data_usage.Write},
+			},
+		},
+		{
+			name:      "user and table stages",
+			queryType: "PUT_FILES",
+			query:     "PUT file:///tmp/orders.csv @~/staged; PUT file:///tmp/orders.csv @%orders",
+		},
+		{
+			name:      "select",
+			queryType: "SELECT",
+			query:     "SELECT 'alice@raito.io'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := &UsageQueryResult{
+				Query:        NullString{String: tt.query, Valid: true},
+				QueryType:    NullString{String: tt.queryType, Valid: true},
+				DatabaseName: NullString{String: "SALES_DB", Valid: true},
+				SchemaName:   NullString{String: "PUBLIC", Valid: true},
+			}
+
+			assert.Equal(t, tt.expected, parseStageAccess(input, nil))
+		})
+	}
+}
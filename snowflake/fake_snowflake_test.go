@@ -0,0 +1,1776 @@
+package snowflake
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/smithy-go/ptr"
+	"github.com/raito-io/cli/base/tag"
+
+	"github.com/raito-io/cli-plugin-snowflake/common"
+)
+
+const fakeSnowflakeOwner = "SYSADMIN"
+
+var (
+	_ dataAccessRepository    = (*fakeSnowflake)(nil)
+	_ dataSourceRepository    = (*fakeSnowflake)(nil)
+	_ identityStoreRepository = (*fakeSnowflake)(nil)
+)
+
+// fakeSnowflake is a stateful in-memory simulation of a Snowflake account.
+// It keeps track of the catalog, users, roles, grants, policies, shares and tags, so syncs can be run against it end-to-end.
+// Account and database role names are case-sensitive and objects are referenced by their unquoted names.
+//
+// The fake changes its state directly and never generates SQL statements, so it does not support the flows that go through statements:
+//   - ExecutionPlan returns nil, so a dry run (sf-dry-run-plan-file), applying a change plan (sf-apply-plan-file) and
+//     a sync with blast-radius limits fail with "not supported by the repository". These are tested with mocks in change_plan_test.go and blast_radius_test.go.
+//   - ExecutePlannedStatements and ExecutePlannedTransaction return an error, so restoring a snapshot (sf-restore-snapshot-file) is not supported either.
+//     It is tested with mocks in snapshot_test.go.
+type fakeSnowflake struct {
+	mu sync.Mutex
+
+	organisation string
+	account      string
+
+	databases     []DbEntity
+	inboundShares []DbEntity
+	warehouses    []DbEntity
+	computePools  []DbEntity
+	integrations  []DbEntity
+	applications  []ApplictionEntity
+
+	schemas    []SchemaEntity
+	tables     []TableEntity
+	columns    []ColumnEntity
+	functions  []FunctionEntity
+	procedures []ProcedureEntity
+	// schemaObjects are the objects of the schemaObjectTypes by their object type in GRANT statements (e.g. STAGE)
+	schemaObjects map[string][]SchemaObjectEntity
+
+	users []UserEntity
+
+	accountRoles     map[string]*fakeRole
+	databaseRoles    map[string]*fakeRole
+	applicationRoles map[string]*fakeRole
+
+	policies map[string]*fakePolicy
+	shares   map[string]*fakeShare
+	tags     []TagEntity
+}
+
+type fakeRole struct {
+	Name     string
+	Database string
+	Owner    string
+	Comment  string
+
+	// GrantsTo are the privileges granted to the role
+	GrantsTo []GrantToRole
+	// GrantsOf are the users, roles and shares the role is granted to
+	GrantsOf []GrantOfRole
+}
+
+type fakePolicy struct {
+	PolicyEntity
+	Description DescribePolicyEntity
+	References  []PolicyReferenceEntity
+}
+
+type fakeShare struct {
+	Name     string
+	Database string
+	Grants   []GrantToRole
+	Accounts []string
+}
+
+func newFakeSnowflake() *fakeSnowflake {
+	return &fakeSnowflake{
+		organisation:     "RAITO",
+		account:          "FAKE",
+		accountRoles:     make(map[string]*fakeRole),
+		databaseRoles:    make(map[string]*fakeRole),
+		applicationRoles: make(map[string]*fakeRole),
+		policies:         make(map[string]*fakePolicy),
+		shares:           make(map[string]*fakeShare),
+	}
+}
+
+// Seeding the account
+
+func (f *fakeSnowflake) addDatabase(database string) {
+	if f.databaseExists(database) {
+		return
+	}
+
+	f.databases = append(f.databases, DbEntity{Name: database, Kind: ptr.String("STANDARD")})
+}
+
+func (f *fakeSnowflake) addSchema(database, schema string) {
+	f.addDatabase(database)
+
+	if f.schemaExists(database, schema) {
+		return
+	}
+
+	f.schemas = append(f.schemas, SchemaEntity{Database: database, Name: schema})
+}
+
+// addTable adds a table of the given type (e.g. BASE TABLE or VIEW) with columns given as name and data type pairs.
+func (f *fakeSnowflake) addTable(database, schema, table, tableType string, columns ...string) {
+	f.addSchema(database, schema)
+
+	f.tables = append(f.tables, TableEntity{Database: database, Schema: schema, Name: table, TableType: tableType, IsIcebergStr: "NO", IsDynamicStr: "NO"})
+
+	for i := 0; i+1 < len(columns); i += 2 {
+		f.columns = append(f.columns, ColumnEntity{Database: database, Schema: schema, Table: table, Name: columns[i], DataType: columns[i+1]})
+	}
+}
+
+// addDynamicTable adds a dynamic table, which INFORMATION_SCHEMA.TABLES shows as a base table with IS_DYNAMIC set.
+func (f *fakeSnowflake) addDynamicTable(database, schema, table string, columns ...string) {
+	f.addTable(database, schema, table, "BASE TABLE", columns...)
+
+	f.tables[len(f.tables)-1].IsDynamicStr = "YES"
+}
+
+// addFunction adds a user defined function with its full signature, e.g. DECRYPT(VARCHAR) RETURN VARCHAR.
+func (f *fakeSnowflake) addFunction(database, schema, name, signature string) {
+	f.addSchema(database, schema)
+
+	f.functions = append(f.functions, FunctionEntity{Database: ptr.String(database), Schema: ptr.String(schema), Name: name, ArgumentSignature: signature, IsBuiltin: "N"})
+}
+
+// addSchemaObject adds an object of one of the schemaObjectTypes given its object type in GRANT statements (e.g. STAGE).
+func (f *fakeSnowflake) addSchemaObject(objectType, database, schema, name string) {
+	f.addSchema(database, schema)
+
+	if f.schemaObjects == nil {
+		f.schemaObjects = make(map[string][]SchemaObjectEntity)
+	}
+
+	f.schemaObjects[objectType] = append(f.schemaObjects[objectType], SchemaObjectEntity{Database: database, Schema: schema, Name: name})
+}
+
+func (f *fakeSnowflake) addInboundShare(share, database string) {
+	f.databases = append(f.databases, DbEntity{Name: database, Kind: ptr.String("IMPORTED DATABASE")})
+	f.inboundShares = append(f.inboundShares, DbEntity{Name: database, Kind: ptr.String("INBOUND"), ShareName: ptr.String(share)})
+}
+
+func (f *fakeSnowflake) addWarehouse(warehouse string) {
+	f.warehouses = append(f.warehouses, DbEntity{Name: warehouse})
+}
+
+func (f *fakeSnowflake) addComputePool(computePool string) {
+	f.computePools = append(f.computePools, DbEntity{Name: computePool})
+}
+
+func (f *fakeSnowflake) addUser(name, email string) {
+	f.users = append(f.users, UserEntity{Name: name, LoginName: ptr.String(name), DisplayName: ptr.String(name), Email: ptr.String(email), Owner: fakeSnowflakeOwner})
+}
+
+func (f *fakeSnowflake) addAccountRole(name, owner string) *fakeRole {
+	role := &fakeRole{Name: name, Owner: owner}
+	f.accountRoles[name] = role
+
+	return role
+}
+
+func (f *fakeSnowflake) addTag(tagEntity TagEntity) {
+	f.tags = append(f.tags, tagEntity)
+}
+
+// addPolicy adds a masking or row access policy (kind MASKING_POLICY or ROW_ACCESS_POLICY) that is not managed by Raito.
+func (f *fakeSnowflake) addPolicy(kind, database, schema, name, signature, returnType, body string) *fakePolicy {
+	policy := &fakePolicy{
+		PolicyEntity: PolicyEntity{Name: name, DatabaseName: database, SchemaName: schema, Kind: kind, Owner: fakeSnowflakeOwner},
+		Description:  DescribePolicyEntity{Name: name, Signature: signature, ReturnType: returnType, Body: body},
+	}
+
+	f.policies[policyKey(database, schema, name)] = policy
+
+	return policy
+}
+
+// attachPolicy attaches a policy to a column (masking policies) or a table (row access policies).
+func (f *fakeSnowflake) attachPolicy(policy *fakePolicy, database, schema, table string, column string, argColumns ...string) {
+	reference := PolicyReferenceEntity{
+		POLICY_DB:         policy.DatabaseName,
+		POLICY_SCHEMA:     policy.SchemaName,
+		POLICY_NAME:       policy.Name,
+		POLICY_KIND:       policy.Kind,
+		REF_DATABASE_NAME: database,
+		REF_SCHEMA_NAME:   schema,
+		REF_ENTITY_NAME:   table,
+		REF_ENTITY_DOMAIN: "TABLE",
+		POLICY_STATUS:     "ACTIVE",
+	}
+
+	if column != "" {
+		reference.REF_COLUMN_NAME = NullString{String: column, Valid: true}
+	}
+
+	if len(argColumns) > 0 {
+		argColumnsJson, _ := json.Marshal(argColumns)
+		reference.REF_ARG_COLUMN_NAMES = NullString{String: string(argColumnsJson), Valid: true}
+	}
+
+	policy.References = append(policy.References, reference)
+}
+
+// Inspecting the account
+
+func (f *fakeSnowflake) accountRole(name string) *fakeRole {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.accountRoles[name]
+}
+
+func (f *fakeSnowflake) databaseRole(database, name string) *fakeRole {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.databaseRoles[database+"."+name]
+}
+
+func (f *fakeSnowflake) policiesOfKind(kind string) []*fakePolicy {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var result []*fakePolicy
+
+	for _, key := range sortedKeys(f.policies) {
+		if f.policies[key].Kind == kind {
+			result = append(result, f.policies[key])
+		}
+	}
+
+	return result
+}
+
+func (f *fakeSnowflake) share(name string) *fakeShare {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.shares[name]
+}
+
+// usersOf returns the users the role is granted to
+func (r *fakeRole) usersOf() []string {
+	var users []string
+
+	for _, grantOfRole := range r.GrantsOf {
+		if grantOfRole.GrantedTo == "USER" {
+			users = append(users, grantOfRole.GranteeName)
+		}
+	}
+
+	return users
+}
+
+// privilegesOn returns the privileges of the role on the given object
+func (r *fakeRole) privilegesOn(grantedOn, name string) []string {
+	var privileges []string
+
+	for _, grantToRole := range r.GrantsTo {
+		if grantToRole.GrantedOn == grantedOn && grantToRole.Name == name {
+			privileges = append(privileges, grantToRole.Privilege)
+		}
+	}
+
+	return privileges
+}
+
+// Connection
+
+func (f *fakeSnowflake) Close() error {
+	return nil
+}
+
+func (f *fakeSnowflake) TotalQueryTime() time.Duration {
+	return 0
+}
+
+func (f *fakeSnowflake) TotalRetries() int {
+	return 0
+}
+
+func (f *fakeSnowflake) ExecutionPlan() *ExecutionPlan {
+	return nil
+}
+
+func (f *fakeSnowflake) ExecutePlannedStatements(_ context.Context, statements ...string) error {
+	return fmt.Errorf("executing statements is not supported by the fake: %s", strings.Join(statements, "; "))
+}
+
+func (f *fakeSnowflake) ExecutePlannedTransaction(_ context.Context, statements ...string) error {
+	return fmt.Errorf("executing statements is not supported by the fake: %s", strings.Join(statements, "; "))
+}
+
+func (f *fakeSnowflake) GetSnowFlakeAccountName(_ context.Context, ops ...func(options *GetSnowFlakeAccountNameOptions)) (string, error) {
+	options := GetSnowFlakeAccountNameOptions{
+		Delimiter: '-',
+	}
+
+	for _, op := range ops {
+		op(&options)
+	}
+
+	return f.organisation + string(options.Delimiter) + f.account, nil
+}
+
+// Catalog
+
+func (f *fakeSnowflake) GetDatabases(ctx context.Context) ([]DbEntity, error) {
+	return f.GetDatabasesByKind(ctx, "STANDARD")
+}
+
+func (f *fakeSnowflake) GetDatabasesByKind(_ context.Context, kind string) ([]DbEntity, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var result []DbEntity
+
+	for _, db := range f.databases {
+		if db.Kind != nil && strings.EqualFold(*db.Kind, kind) {
+			result = append(result, db)
+		}
+	}
+
+	return result, nil
+}
+
+func (f *fakeSnowflake) GetInboundShares(_ context.Context) ([]DbEntity, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return slices.Clone(f.inboundShares), nil
+}
+
+func (f *fakeSnowflake) GetWarehouses(_ context.Context) ([]DbEntity, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return slices.Clone(f.warehouses), nil
+}
+
+func (f *fakeSnowflake) GetComputePools(_ context.Context) ([]DbEntity, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return slices.Clone(f.computePools), nil
+}
+
+func (f *fakeSnowflake) GetIntegrations(_ context.Context) ([]DbEntity, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return slices.Clone(f.integrations), nil
+}
+
+func (f *fakeSnowflake) GetApplications(_ context.Context) ([]ApplictionEntity, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return slices.Clone(f.applications), nil
+}
+
+func (f *fakeSnowflake) GetSchemasInDatabase(_ context.Context, databaseName string, handleEntity EntityHandler) error {
+	for _, schema := range f.snapshotSchemas(databaseName) {
+		err := handleEntity(&schema)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (f *fakeSnowflake) GetTablesInDatabase(_ context.Context, databaseName string, schemaName string, handleEntity EntityHandler) error {
+	f.mu.Lock()
+
+	var tables []TableEntity
+
+	for _, table := range f.tables {
+		if table.Database == databaseName && (schemaName == "" || table.Schema == schemaName) {
+			tables = append(tables, table)
+		}
+	}
+
+	f.mu.Unlock()
+
+	for _, table := range tables {
+		err := handleEntity(&table)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (f *fakeSnowflake) GetColumnsInDatabase(_ context.Context, databaseName string, handleEntity EntityHandler) error {
+	f.mu.Lock()
+
+	var columns []ColumnEntity
+
+	for _, column := range f.columns {
+		if column.Database == databaseName {
+			columns = append(columns, column)
+		}
+	}
+
+	f.mu.Unlock()
+
+	for _, column := range columns {
+		err := handleEntity(&column)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (f *fakeSnowflake) GetFunctionsInDatabase(ctx context.Context, databaseName string, handleEntity EntityHandler) error {
+	return f.GetFunctionsInSchema(ctx, databaseName, "", handleEntity)
+}
+
+// GetFunctionsInSchema returns the functions of all schemas in the database when no schema is given
+func (f *fakeSnowflake) GetFunctionsInSchema(_ context.Context, databaseName string, schemaName string, handleEntity EntityHandler) error {
+	f.mu.Lock()
+
+	var functions []FunctionEntity
+
+	for _, function := range f.functions {
+		if *function.Database == databaseName && (schemaName == "" || *function.Schema == schemaName) {
+			functions = append(functions, function)
+		}
+	}
+
+	f.mu.Unlock()
+
+	for _, function := range functions {
+		err := handleFunctionEntity(&function, handleEntity)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (f *fakeSnowflake) GetProceduresInDatabase(ctx context.Context, databaseName string, handleEntity EntityHandler) error {
+	return f.GetProceduresInSchema(ctx, databaseName, "", handleEntity)
+}
+
+// GetProceduresInSchema returns the procedures of all schemas in the database when no schema is given
+func (f *fakeSnowflake) GetProceduresInSchema(_ context.Context, databaseName string, schemaName string, handleEntity EntityHandler) error {
+	f.mu.Lock()
+
+	var procedures []ProcedureEntity
+
+	for _, procedure := range f.procedures {
+		if *procedure.Database == databaseName && (schemaName == "" || *procedure.Schema == schemaName) {
+			procedures = append(procedures, procedure)
+		}
+	}
+
+	f.mu.Unlock()
+
+	for _, procedure := range procedures {
+		err := showProceduresHandler(&procedure, handleEntity)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (f *fakeSnowflake) GetObjectsInDatabase(_ context.Context, objectKind string, databaseName string, handleEntity EntityHandler) error {
+	f.mu.Lock()
+
+	if !f.databaseExists(databaseName) {
+		f.mu.Unlock()
+
+		return fakeDoesNotExistError("Database", databaseName)
+	}
+
+	var objects []SchemaObjectEntity
+
+	for _, object := range f.schemaObjects[schemaObjectGrantType(objectKind)] {
+		if object.Database == databaseName {
+			objects = append(objects, object)
+		}
+	}
+
+	f.mu.Unlock()
+
+	for _, object := range objects {
+		err := handleEntity(&object)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// schemaObjectGrantType converts the kind in a SHOW statement (e.g. FILE FORMATS or IMAGE REPOSITORIES) to the object type shown by SHOW GRANTS (e.g. FILE_FORMAT or IMAGE_REPOSITORY)
+func schemaObjectGrantType(objectKind string) string {
+	if strings.HasSuffix(objectKind, "IES") {
+		objectKind = strings.TrimSuffix(objectKind, "IES") + "Y"
+	} else {
+		objectKind = strings.TrimSuffix(objectKind, "S")
+	}
+
+	return strings.ReplaceAll(objectKind, " ", "_")
+}
+
+func (f *fakeSnowflake) GetUsers(_ context.Context) ([]UserEntity, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return slices.Clone(f.users), nil
+}
+
+// Tags
+
+func (f *fakeSnowflake) GetTagsByDomain(_ context.Context, domain string) (map[string][]*tag.Tag, error) {
+	return f.tagMap(func(tagEntity *TagEntity) bool {
+		return strings.EqualFold(tagEntity.Domain, domain)
+	}), nil
+}
+
+func (f *fakeSnowflake) GetTagsLinkedToDatabaseName(_ context.Context, databaseName string) (map[string][]*tag.Tag, error) {
+	return f.tagMap(func(tagEntity *TagEntity) bool {
+		return (tagEntity.Database != nil && *tagEntity.Database == databaseName) || (strings.EqualFold(tagEntity.Domain, "DATABASE") && tagEntity.Name == databaseName)
+	}), nil
+}
+
+func (f *fakeSnowflake) GetDatabaseRoleTags(_ context.Context, databaseName string, roleName string) (map[string][]*tag.Tag, error) {
+	return f.tagMap(func(tagEntity *TagEntity) bool {
+		return tagEntity.Domain == GrantTypeDatabaseRole && tagEntity.Database != nil && *tagEntity.Database == databaseName && tagEntity.Name == roleName
+	}), nil
+}
+
+func (f *fakeSnowflake) SetTagOnRole(_ context.Context, roleName, tagName, tagValue string, isDatabaseRole bool) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	sfObject := common.ParseFullName(tagName)
+	if sfObject.Database == nil || sfObject.Schema == nil || sfObject.Table == nil {
+		return fmt.Errorf("expected tagname %q to have 3 parts (database.schema.tagname)", tagName)
+	}
+
+	tagEntity := TagEntity{Domain: "ROLE", Name: roleName, TagName: *sfObject.Table, TagValue: strings.ReplaceAll(tagValue, "'", "")}
+
+	if isDatabaseRole {
+		database, parsedRoleName, err := parseNamespacedRoleRoleName(roleName)
+		if err != nil {
+			return err
+		}
+
+		if _, found := f.databaseRoles[roleName]; !found {
+			return fakeDoesNotExistError("Database role", roleName)
+		}
+
+		tagEntity.Domain = GrantTypeDatabaseRole
+		tagEntity.Database = ptr.String(database)
+		tagEntity.Name = parsedRoleName
+	} else if _, found := f.accountRoles[roleName]; !found {
+		return fakeDoesNotExistError("Role", roleName)
+	}
+
+	f.tags = slices.DeleteFunc(f.tags, func(existing TagEntity) bool {
+		return existing.Domain == tagEntity.Domain && existing.Name == tagEntity.Name && existing.TagName == tagEntity.TagName && equalPtr(existing.Database, tagEntity.Database)
+	})
+	f.tags = append(f.tags, tagEntity)
+
+	return nil
+}
+
+func (f *fakeSnowflake) tagMap(filter func(tagEntity *TagEntity) bool) map[string][]*tag.Tag {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	tagMap := make(map[string][]*tag.Tag)
+
+	for i := range f.tags {
+		if filter(&f.tags[i]) {
+			fullName := f.tags[i].GetFullName()
+			tagMap[fullName] = append(tagMap[fullName], f.tags[i].CreateTag())
+		}
+	}
+
+	return tagMap
+}
+
+// Account roles
+
+func (f *fakeSnowflake) GetAccountRoles(ctx context.Context) ([]RoleEntity, error) {
+	return f.GetAccountRolesWithPrefix(ctx, "")
+}
+
+func (f *fakeSnowflake) GetAccountRolesWithPrefix(_ context.Context, prefix string) ([]RoleEntity, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var result []RoleEntity
+
+	for _, name := range sortedKeys(f.accountRoles) {
+		if strings.HasPrefix(strings.ToUpper(name), strings.ToUpper(prefix)) {
+			result = append(result, f.roleEntity(f.accountRoles[name], "ROLE", name))
+		}
+	}
+
+	return result, nil
+}
+
+func (f *fakeSnowflake) CreateAccountRole(_ context.Context, roleName string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.createAccountRoleIfNotExists(roleName)
+
+	return nil
+}
+
+func (f *fakeSnowflake) CommentAccountRoleIfExists(_ context.Context, comment, objectName string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if role, found := f.accountRoles[objectName]; found {
+		role.Comment = comment
+	}
+
+	return nil
+}
+
+func (f *fakeSnowflake) DropAccountRole(_ context.Context, roleName string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, found := f.accountRoles[roleName]; !found {
+		return fakeDoesNotExistError("Role", roleName)
+	}
+
+	delete(f.accountRoles, roleName)
+	f.removeGrantee("ROLE", roleName)
+
+	return nil
+}
+
+func (f *fakeSnowflake) RenameAccountRole(_ context.Context, oldName, newName string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	role, found := f.accountRoles[oldName]
+	if !found {
+		return nil
+	}
+
+	if _, found = f.accountRoles[newName]; found {
+		return fmt.Errorf("SQL compilation error: Object '%s' already exists", newName)
+	}
+
+	delete(f.accountRoles, oldName)
+	role.Name = newName
+	f.accountRoles[newName] = role
+
+	f.renameGrantee("ROLE", oldName, newName)
+
+	for i := range f.tags {
+		if f.tags[i].Domain == "ROLE" && f.tags[i].Name == oldName {
+			f.tags[i].Name = newName
+		}
+	}
+
+	return nil
+}
+
+func (f *fakeSnowflake) GetGrantsOfAccountRole(_ context.Context, roleName string) ([]GrantOfRole, error) {
+	return f.grantsOf(f.accountRoles, "Role", roleName)
+}
+
+func (f *fakeSnowflake) GetGrantsToAccountRole(_ context.Context, roleName string) ([]GrantToRole, error) {
+	return f.grantsTo(f.accountRoles, "Role", roleName)
+}
+
+func (f *fakeSnowflake) GetAllGrantsToAccountRoles(_ context.Context) (map[string][]GrantToRole, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	grants := make(map[string][]GrantToRole)
+
+	for name, role := range f.accountRoles {
+		if len(role.GrantsTo) > 0 {
+			grants[name] = slices.Clone(role.GrantsTo)
+		}
+	}
+
+	return grants, nil
+}
+
+func (f *fakeSnowflake) GetAllGrantsOfAccountRoles(_ context.Context) (map[string][]GrantOfRole, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	grants := make(map[string][]GrantOfRole)
+
+	for name, role := range f.accountRoles {
+		if len(role.GrantsOf) > 0 {
+			grants[name] = slices.Clone(role.GrantsOf)
+		}
+	}
+
+	return grants, nil
+}
+
+func (f *fakeSnowflake) GrantAccountRolesToAccountRole(_ context.Context, role string, roles ...string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, otherRole := range roles {
+		f.createAccountRoleIfNotExists(otherRole)
+
+		err := f.grantRole(f.accountRoles, "Role", role, GrantOfRole{GrantedTo: "ROLE", GranteeName: otherRole})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (f *fakeSnowflake) RevokeAccountRolesFromAccountRole(_ context.Context, role string, roles ...string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, otherRole := range roles {
+		err := f.revokeRole(f.accountRoles, "Role", role, GrantOfRole{GrantedTo: "ROLE", GranteeName: otherRole})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (f *fakeSnowflake) GrantUsersToAccountRole(_ context.Context, role string, users ...string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, user := range users {
+		if !slices.ContainsFunc(f.users, func(u UserEntity) bool { return u.Name == user }) {
+			return fakeDoesNotExistError("User", user)
+		}
+
+		err := f.grantRole(f.accountRoles, "Role", role, GrantOfRole{GrantedTo: "USER", GranteeName: user})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (f *fakeSnowflake) RevokeUsersFromAccountRole(_ context.Context, role string, users ...string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, user := range users {
+		err := f.revokeRole(f.accountRoles, "Role", role, GrantOfRole{GrantedTo: "USER", GranteeName: user})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (f *fakeSnowflake) ExecuteGrantOnAccountRole(_ context.Context, perm, on, role string, _ bool) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.executeGrant(f.accountRoles, "Role", role, perm, on)
+}
+
+func (f *fakeSnowflake) ExecuteRevokeOnAccountRole(_ context.Context, perm, on, role string, _ bool) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.executeRevoke(f.accountRoles, "Role", role, perm, on)
+}
+
+// Database roles
+
+func (f *fakeSnowflake) GetDatabaseRoles(ctx context.Context, database string) ([]RoleEntity, error) {
+	return f.GetDatabaseRolesWithPrefix(ctx, database, "")
+}
+
+func (f *fakeSnowflake) GetDatabaseRolesWithPrefix(_ context.Context, database string, prefix string) ([]RoleEntity, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if !f.databaseExists(database) {
+		return nil, fakeDoesNotExistError("Database", database)
+	}
+
+	var result []RoleEntity
+
+	for _, key := range sortedKeys(f.databaseRoles) {
+		role := f.databaseRoles[key]
+
+		if role.Database == database && strings.HasPrefix(role.Name, prefix) {
+			result = append(result, f.roleEntity(role, GrantTypeDatabaseRole, key))
+		}
+	}
+
+	return result, nil
+}
+
+func (f *fakeSnowflake) CreateDatabaseRole(_ context.Context, database, roleName string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if !f.databaseExists(database) {
+		return fakeDoesNotExistError("Database", database)
+	}
+
+	if _, found := f.databaseRoles[database+"."+roleName]; !found {
+		f.databaseRoles[database+"."+roleName] = &fakeRole{Name: roleName, Database: database, Owner: fakeSnowflakeOwner}
+	}
+
+	return nil
+}
+
+func (f *fakeSnowflake) CommentDatabaseRoleIfExists(_ context.Context, comment, database, roleName string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if role, found := f.databaseRoles[database+"."+roleName]; found {
+		role.Comment = comment
+	}
+
+	return nil
+}
+
+func (f *fakeSnowflake) DropDatabaseRole(_ context.Context, database string, roleName string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	key := database + "." + roleName
+
+	if _, found := f.databaseRoles[key]; !found {
+		return fakeDoesNotExistError("Database role", key)
+	}
+
+	delete(f.databaseRoles, key)
+	f.removeGrantee(GrantTypeDatabaseRole, key)
+
+	return nil
+}
+
+func (f *fakeSnowflake) RenameDatabaseRole(_ context.Context, database, oldName, newName string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	oldKey, newKey := database+"."+oldName, database+"."+newName
+
+	role, found := f.databaseRoles[oldKey]
+	if !found {
+		return nil
+	}
+
+	if _, found = f.databaseRoles[newKey]; found {
+		return fmt.Errorf("SQL compilation error: Object '%s' already exists", newKey)
+	}
+
+	delete(f.databaseRoles, oldKey)
+	role.Name = newName
+	f.databaseRoles[newKey] = role
+
+	f.renameGrantee(GrantTypeDatabaseRole, oldKey, newKey)
+
+	return nil
+}
+
+func (f *fakeSnowflake) GetGrantsOfDatabaseRole(_ context.Context, database, roleName string) ([]GrantOfRole, error) {
+	return f.grantsOf(f.databaseRoles, "Database role", database+"."+roleName)
+}
+
+func (f *fakeSnowflake) GetGrantsToDatabaseRole(_ context.Context, database, roleName string) ([]GrantToRole, error) {
+	return f.grantsTo(f.databaseRoles, "Database role", database+"."+roleName)
+}
+
+func (f *fakeSnowflake) GrantAccountRolesToDatabaseRole(_ context.Context, database string, databaseRole string, accountRoles ...string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, accountRole := range accountRoles {
+		f.createAccountRoleIfNotExists(accountRole)
+
+		err := f.grantRole(f.databaseRoles, "Database role", database+"."+databaseRole, GrantOfRole{GrantedTo: "ROLE", GranteeName: accountRole})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (f *fakeSnowflake) RevokeAccountRolesFromDatabaseRole(_ context.Context, database string, databaseRole string, accountRoles ...string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, accountRole := range accountRoles {
+		err := f.revokeRole(f.databaseRoles, "Database role", database+"."+databaseRole, GrantOfRole{GrantedTo: "ROLE", GranteeName: accountRole})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (f *fakeSnowflake) GrantDatabaseRolesToDatabaseRole(_ context.Context, database string, databaseRole string, databaseRoles ...string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, otherRole := range databaseRoles {
+		if _, found := f.databaseRoles[database+"."+otherRole]; !found {
+			return fakeDoesNotExistError("Database role", database+"."+otherRole)
+		}
+
+		err := f.grantRole(f.databaseRoles, "Database role", database+"."+databaseRole, GrantOfRole{GrantedTo: GrantTypeDatabaseRole, GranteeName: database + "." + otherRole})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (f *fakeSnowflake) RevokeDatabaseRolesFromDatabaseRole(_ context.Context, database string, databaseRole string, databaseRoles ...string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, otherRole := range databaseRoles {
+		err := f.revokeRole(f.databaseRoles, "Database role", database+"."+databaseRole, GrantOfRole{GrantedTo: GrantTypeDatabaseRole, GranteeName: database + "." + otherRole})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (f *fakeSnowflake) GrantSharesToDatabaseRole(_ context.Context, database string, databaseRole string, shares ...string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, share := range shares {
+		if _, found := f.shares[share]; !found {
+			return fakeDoesNotExistError("Share", share)
+		}
+
+		err := f.grantRole(f.databaseRoles, "Database role", database+"."+databaseRole, GrantOfRole{GrantedTo: "SHARE", GranteeName: share})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (f *fakeSnowflake) RevokeSharesFromDatabaseRole(_ context.Context, database string, databaseRole string, shares ...string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, share := range shares {
+		err := f.revokeRole(f.databaseRoles, "Database role", database+"."+databaseRole, GrantOfRole{GrantedTo: "SHARE", GranteeName: share})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (f *fakeSnowflake) ExecuteGrantOnDatabaseRole(_ context.Context, perm, on, database, databaseRole string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.executeGrant(f.databaseRoles, "Database role", database+"."+databaseRole, perm, on)
+}
+
+func (f *fakeSnowflake) ExecuteRevokeOnDatabaseRole(_ context.Context, perm, on, database, databaseRole string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.executeRevoke(f.databaseRoles, "Database role", database+"."+databaseRole, perm, on)
+}
+
+// Application roles
+
+func (f *fakeSnowflake) GetApplicationRoles(_ context.Context, application string) ([]ApplicationRoleEntity, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var result []ApplicationRoleEntity
+
+	for _, key := range sortedKeys(f.applicationRoles) {
+		role := f.applicationRoles[key]
+
+		if role.Database == application {
+			result = append(result, ApplicationRoleEntity{Name: role.Name, Owner: ptr.String(application), OwnerRoleType: ptr.String("APPLICATION")})
+		}
+	}
+
+	return result, nil
+}
+
+func (f *fakeSnowflake) GetGrantsOfApplicationRole(_ context.Context, application, role string) ([]GrantOfRole, error) {
+	return f.grantsOf(f.applicationRoles, "Application role", application+"."+role)
+}
+
+func (f *fakeSnowflake) GrantAccountRolesToApplicationRole(_ context.Context, application string, applicationRole string, accountRoles ...string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, accountRole := range accountRoles {
+		f.createAccountRoleIfNotExists(accountRole)
+
+		err := f.grantRole(f.applicationRoles, "Application role", application+"."+applicationRole, GrantOfRole{GrantedTo: "ROLE", GranteeName: accountRole})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (f *fakeSnowflake) RevokeAccountRolesFromApplicationRole(_ context.Context, application string, applicationRole string, accountRoles ...string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, accountRole := range accountRoles {
+		err := f.revokeRole(f.applicationRoles, "Application role", application+"."+applicationRole, GrantOfRole{GrantedTo: "ROLE", GranteeName: accountRole})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (f *fakeSnowflake) GrantApplicationRolesToApplicationRole(_ context.Context, application string, applicationRole string, applicationRoles ...string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, otherRole := range applicationRoles {
+		err := f.grantRole(f.applicationRoles, "Application role", application+"."+applicationRole, GrantOfRole{GrantedTo: GrantTypeApplicationRole, GranteeName: otherRole})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (f *fakeSnowflake) RevokeApplicationRolesFromApplicationRole(_ context.Context, application string, applicationRole string, applicationRoles ...string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, otherRole := range applicationRoles {
+		err := f.revokeRole(f.applicationRoles, "Application role", application+"."+applicationRole, GrantOfRole{GrantedTo: GrantTypeApplicationRole, GranteeName: otherRole})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Masking and row access policies
+
+func (f *fakeSnowflake) GetPolicies(ctx context.Context, policy string) ([]PolicyEntity, error) {
+	return f.GetPoliciesLike(ctx, policy, "%")
+}
+
+func (f *fakeSnowflake) GetPoliciesLike(_ context.Context, policy string, like string) ([]PolicyEntity, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	kind := strings.ReplaceAll(strings.ToUpper(policy), " ", "_") + "_POLICY"
+	likeRegex := likePattern(like)
+
+	var result []PolicyEntity
+
+	for _, key := range sortedKeys(f.policies) {
+		p := f.policies[key]
+
+		if p.Kind == kind && likeRegex.MatchString(p.Name) {
+			result = append(result, p.PolicyEntity)
+		}
+	}
+
+	return result, nil
+}
+
+func (f *fakeSnowflake) DescribePolicy(_ context.Context, _, dbName, schema, policyName string) ([]DescribePolicyEntity, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	p, found := f.policies[policyKey(dbName, schema, policyName)]
+	if !found {
+		return nil, fakeDoesNotExistError("Policy", policyKey(dbName, schema, policyName))
+	}
+
+	return []DescribePolicyEntity{p.Description}, nil
+}
+
+func (f *fakeSnowflake) GetPolicyReferences(_ context.Context, dbName, schema, policyName string) ([]PolicyReferenceEntity, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	p, found := f.policies[policyKey(dbName, schema, policyName)]
+	if !found {
+		return nil, fakeDoesNotExistError("Policy", policyKey(dbName, schema, policyName))
+	}
+
+	return slices.Clone(p.References), nil
+}
+
+// CreateMaskPolicy creates a masking policy per data type of the columns and attaches it to the columns, replacing the masking policy they had
+func (f *fakeSnowflake) CreateMaskPolicy(_ context.Context, databaseName string, schema string, maskName string, columnsFullName []string, maskType *string, beneficiaries *MaskingBeneficiaries) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	columnsPerType := make(map[string][]ColumnEntity)
+
+	for _, columnFullName := range columnsFullName {
+		column := f.column(columnFullName)
+		if column == nil {
+			return fmt.Errorf("unable to load column details")
+		}
+
+		columnsPerType[column.DataType] = append(columnsPerType[column.DataType], *column)
+	}
+
+	for _, columnType := range sortedKeys(columnsPerType) {
+		policyName, maskingPolicy, err := NewMaskFactory(map[string]string{}).CreateMask(maskName, columnType, maskType, beneficiaries)
+		if err != nil {
+			return err
+		}
+
+		_, body, _ := strings.Cut(string(maskingPolicy), "->\n")
+
+		policy := f.addPolicy("MASKING_POLICY", databaseName, schema, policyName, fmt.Sprintf("(val %s)", columnType), columnType, strings.TrimSuffix(body, ";"))
+
+		for _, column := range columnsPerType[columnType] {
+			f.detachPolicies("MASKING_POLICY", column.Database, column.Schema, column.Table, column.Name)
+			f.attachPolicy(policy, column.Database, column.Schema, column.Table, column.Name)
+		}
+	}
+
+	return nil
+}
+
+func (f *fakeSnowflake) DropMaskingPolicy(_ context.Context, databaseName string, schema string, maskName string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	likeRegex := likePattern(maskName + "_%")
+
+	for _, key := range sortedKeys(f.policies) {
+		p := f.policies[key]
+
+		if p.Kind == "MASKING_POLICY" && p.DatabaseName == databaseName && p.SchemaName == schema && likeRegex.MatchString(p.Name) {
+			delete(f.policies, key)
+		}
+	}
+
+	return nil
+}
+
+// UpdateFilter creates a row access policy and attaches it to the table, replacing the row access policy the table had
+func (f *fakeSnowflake) UpdateFilter(_ context.Context, databaseName string, schema string, tableName string, filterName string, argumentNames []string, expression string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	functionArguments := make([]string, 0, len(argumentNames))
+
+	for _, argumentName := range argumentNames {
+		column := f.column(fmt.Sprintf("%s.%s.%s.%s", databaseName, schema, tableName, argumentName))
+		if column == nil {
+			return fmt.Errorf("number of function arguments (%d) does not match number of argument names (%d)", len(functionArguments), len(argumentNames))
+		}
+
+		functionArguments = append(functionArguments, fmt.Sprintf("%s %s", argumentName, column.DataType))
+	}
+
+	for _, existing := range f.detachPolicies("ROW_ACCESS_POLICY", databaseName, schema, tableName, "") {
+		delete(f.policies, existing)
+	}
+
+	policy := f.addPolicy("ROW_ACCESS_POLICY", databaseName, schema, filterName, fmt.Sprintf("(%s)", strings.Join(functionArguments, ", ")), "BOOLEAN", expression)
+	f.attachPolicy(policy, databaseName, schema, tableName, "", argumentNames...)
+
+	return nil
+}
+
+func (f *fakeSnowflake) DropFilter(_ context.Context, databaseName string, schema string, tableName string, filterName string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.detachPolicies("ROW_ACCESS_POLICY", databaseName, schema, tableName, "")
+	delete(f.policies, policyKey(databaseName, schema, filterName))
+
+	return nil
+}
+
+// Shares
+
+func (f *fakeSnowflake) GetOutboundShares(_ context.Context) ([]ShareEntity, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var result []ShareEntity
+
+	for _, name := range sortedKeys(f.shares) {
+		share := f.shares[name]
+
+		if len(share.Accounts) == 0 {
+			result = append(result, ShareEntity{Name: name, Owner: fakeSnowflakeOwner, DatabaseName: share.Database})
+		}
+
+		for _, account := range share.Accounts {
+			result = append(result, ShareEntity{Name: name, Owner: fakeSnowflakeOwner, To: account, DatabaseName: share.Database})
+		}
+	}
+
+	return result, nil
+}
+
+func (f *fakeSnowflake) GetGrantsToShare(_ context.Context, shareName string) ([]GrantToRole, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	share, found := f.shares[shareName]
+	if !found {
+		return nil, fakeDoesNotExistError("Share", shareName)
+	}
+
+	return slices.Clone(share.Grants), nil
+}
+
+func (f *fakeSnowflake) CreateShare(_ context.Context, shareName string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, found := f.shares[shareName]; !found {
+		f.shares[shareName] = &fakeShare{Name: shareName}
+	}
+
+	return nil
+}
+
+func (f *fakeSnowflake) SetShareAccounts(_ context.Context, shareName string, accounts []string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	share, found := f.shares[shareName]
+	if !found {
+		return fakeDoesNotExistError("Share", shareName)
+	}
+
+	if share.Database == "" {
+		return fmt.Errorf("SQL compilation error: share %q has no database granted", shareName)
+	}
+
+	share.Accounts = slices.Clone(accounts)
+
+	return nil
+}
+
+func (f *fakeSnowflake) DropShare(_ context.Context, shareName string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, found := f.shares[shareName]; !found {
+		return fakeDoesNotExistError("Share", shareName)
+	}
+
+	delete(f.shares, shareName)
+	f.removeGrantee("SHARE", shareName)
+
+	return nil
+}
+
+func (f *fakeSnowflake) ExecuteGrantOnShare(_ context.Context, perm, on, shareName string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	share, found := f.shares[shareName]
+	if !found {
+		return fakeDoesNotExistError("Share", shareName)
+	}
+
+	grants, err := f.resolveGrant(perm, on)
+	if err != nil {
+		return err
+	}
+
+	for _, grant := range grants {
+		if grant.GrantedOn == "DATABASE" {
+			share.Database = grant.Name
+		}
+
+		if !slices.Contains(share.Grants, grant) {
+			share.Grants = append(share.Grants, grant)
+		}
+	}
+
+	return nil
+}
+
+func (f *fakeSnowflake) ExecuteRevokeOnShare(_ context.Context, perm, on, shareName string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	share, found := f.shares[shareName]
+	if !found {
+		return fakeDoesNotExistError("Share", shareName)
+	}
+
+	grants, err := f.resolveGrant(perm, on)
+	if err != nil {
+		return err
+	}
+
+	share.Grants = slices.DeleteFunc(share.Grants, func(existing GrantToRole) bool {
+		return slices.Contains(grants, existing)
+	})
+
+	return nil
+}
+
+// Internal helpers, the caller must hold the lock
+
+func (f *fakeSnowflake) databaseExists(database string) bool {
+	return slices.ContainsFunc(f.databases, func(db DbEntity) bool { return db.Name == database })
+}
+
+func (f *fakeSnowflake) schemaExists(database, schema string) bool {
+	return slices.ContainsFunc(f.schemas, func(s SchemaEntity) bool { return s.Database == database && s.Name == schema })
+}
+
+func (f *fakeSnowflake) tableExists(database, schema, table string) bool {
+	return slices.ContainsFunc(f.tables, func(t TableEntity) bool { return t.Database == database && t.Schema == schema && t.Name == table })
+}
+
+func (f *fakeSnowflake) snapshotSchemas(database string) []SchemaEntity {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var schemas []SchemaEntity
+
+	for _, schema := range f.schemas {
+		if schema.Database == database {
+			schemas = append(schemas, schema)
+		}
+	}
+
+	return schemas
+}
+
+func (f *fakeSnowflake) column(fullName string) *ColumnEntity {
+	parts := strings.Split(fullName, ".")
+	if len(parts) != 4 {
+		return nil
+	}
+
+	for i := range f.columns {
+		c := &f.columns[i]
+
+		if c.Database == parts[0] && c.Schema == parts[1] && c.Table == parts[2] && c.Name == parts[3] {
+			return c
+		}
+	}
+
+	return nil
+}
+
+// detachPolicies removes the references of policies of the given kind to the table (or column) and returns the keys of these policies
+func (f *fakeSnowflake) detachPolicies(kind, database, schema, table, column string) []string {
+	var detached []string
+
+	for _, key := range sortedKeys(f.policies) {
+		p := f.policies[key]
+		if p.Kind != kind {
+			continue
+		}
+
+		before := len(p.References)
+
+		p.References = slices.DeleteFunc(p.References, func(reference PolicyReferenceEntity) bool {
+			return reference.REF_DATABASE_NAME == database && reference.REF_SCHEMA_NAME == schema && reference.REF_ENTITY_NAME == table && reference.REF_COLUMN_NAME.String == column
+		})
+
+		if len(p.References) != before {
+			detached = append(detached, key)
+		}
+	}
+
+	return detached
+}
+
+func (f *fakeSnowflake) createAccountRoleIfNotExists(roleName string) {
+	if _, found := f.accountRoles[roleName]; !found {
+		f.accountRoles[roleName] = &fakeRole{Name: roleName, Owner: fakeSnowflakeOwner}
+	}
+}
+
+func (f *fakeSnowflake) roleEntity(role *fakeRole, grantedTo, granteeName string) RoleEntity {
+	entity := RoleEntity{Name: role.Name, Owner: role.Owner}
+
+	for _, grantOfRole := range role.GrantsOf {
+		switch grantOfRole.GrantedTo {
+		case "USER":
+			entity.AssignedToUsers++
+		case "ROLE":
+			entity.GrantedToRoles++
+		}
+	}
+
+	for _, roles := range []map[string]*fakeRole{f.accountRoles, f.databaseRoles} {
+		for _, other := range roles {
+			if slices.Contains(other.GrantsOf, GrantOfRole{GrantedTo: grantedTo, GranteeName: granteeName}) {
+				entity.GrantedRoles++
+			}
+		}
+	}
+
+	return entity
+}
+
+func (f *fakeSnowflake) grantsOf(roles map[string]*fakeRole, roleType, key string) ([]GrantOfRole, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	role, found := roles[key]
+	if !found {
+		return nil, fakeDoesNotExistError(roleType, key)
+	}
+
+	return slices.Clone(role.GrantsOf), nil
+}
+
+func (f *fakeSnowflake) grantsTo(roles map[string]*fakeRole, roleType, key string) ([]GrantToRole, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	role, found := roles[key]
+	if !found {
+		return nil, fakeDoesNotExistError(roleType, key)
+	}
+
+	return slices.Clone(role.GrantsTo), nil
+}
+
+func (f *fakeSnowflake) grantRole(roles map[string]*fakeRole, roleType, key string, grantee GrantOfRole) error {
+	role, found := roles[key]
+	if !found {
+		return fakeDoesNotExistError(roleType, key)
+	}
+
+	if !slices.Contains(role.GrantsOf, grantee) {
+		role.GrantsOf = append(role.GrantsOf, grantee)
+	}
+
+	return nil
+}
+
+func (f *fakeSnowflake) revokeRole(roles map[string]*fakeRole, roleType, key string, grantee GrantOfRole) error {
+	role, found := roles[key]
+	if !found {
+		return fakeDoesNotExistError(roleType, key)
+	}
+
+	role.GrantsOf = slices.DeleteFunc(role.GrantsOf, func(existing GrantOfRole) bool {
+		return existing == grantee
+	})
+
+	return nil
+}
+
+// removeGrantee revokes all roles from a dropped role or share
+func (f *fakeSnowflake) removeGrantee(grantedTo, granteeName string) {
+	for _, roles := range []map[string]*fakeRole{f.accountRoles, f.databaseRoles, f.applicationRoles} {
+		for _, role := range roles {
+			role.GrantsOf = slices.DeleteFunc(role.GrantsOf, func(existing GrantOfRole) bool {
+				return existing.GrantedTo == grantedTo && existing.GranteeName == granteeName
+			})
+		}
+	}
+}
+
+// renameGrantee keeps the roles granted to a renamed role
+func (f *fakeSnowflake) renameGrantee(grantedTo, oldName, newName string) {
+	for _, roles := range []map[string]*fakeRole{f.accountRoles, f.databaseRoles, f.applicationRoles} {
+		for _, role := range roles {
+			for i := range role.GrantsOf {
+				if role.GrantsOf[i].GrantedTo == grantedTo && role.GrantsOf[i].GranteeName == oldName {
+					role.GrantsOf[i].GranteeName = newName
+				}
+			}
+		}
+	}
+}
+
+func (f *fakeSnowflake) executeGrant(roles map[string]*fakeRole, roleType, key, perm, on string) error {
+	role, found := roles[key]
+	if !found {
+		return fakeDoesNotExistError(roleType, key)
+	}
+
+	grants, err := f.resolveGrant(perm, on)
+	if err != nil {
+		return err
+	}
+
+	for _, grant := range grants {
+		if !slices.Contains(role.GrantsTo, grant) {
+			role.GrantsTo = append(role.GrantsTo, grant)
+		}
+	}
+
+	return nil
+}
+
+func (f *fakeSnowflake) executeRevoke(roles map[string]*fakeRole, roleType, key, perm, on string) error {
+	role, found := roles[key]
+	if !found {
+		return fakeDoesNotExistError(roleType, key)
+	}
+
+	grants, err := f.resolveGrant(perm, on)
+	if err != nil {
+		return err
+	}
+
+	role.GrantsTo = slices.DeleteFunc(role.GrantsTo, func(existing GrantToRole) bool {
+		if perm == "ALL" {
+			return slices.ContainsFunc(grants, func(grant GrantToRole) bool {
+				return grant.GrantedOn == existing.GrantedOn && grant.Name == existing.Name
+			})
+		}
+
+		return slices.Contains(grants, existing)
+	})
+
+	return nil
+}
+
+// resolveGrant converts the target of a GRANT statement (e.g. TABLE DB.SCHEMA.TABLE or ALL TABLES IN SCHEMA DB.SCHEMA) into the grants as shown by SHOW GRANTS.
+// Future grants are not shown by SHOW GRANTS, so they result in no grants.
+func (f *fakeSnowflake) resolveGrant(perm, on string) ([]GrantToRole, error) {
+	tokens := splitOutsideQuotes(on)
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("SQL compilation error: missing object in grant on %q", on)
+	}
+
+	if tokens[0] == "FUTURE" {
+		return nil, nil
+	}
+
+	if tokens[0] == "ALL" {
+		return f.resolveBulkGrant(perm, tokens)
+	}
+
+	objectType := strings.Join(tokens[:len(tokens)-1], "_")
+	name := strings.ReplaceAll(tokens[len(tokens)-1], `"`, "")
+
+	if len(tokens) == 1 {
+		objectType, name = tokens[0], ""
+	}
+
+	if objectType == "ACCOUNT" {
+		name = f.organisation + "-" + f.account
+	}
+
+	err := f.checkObjectExists(objectType, name)
+	if err != nil {
+		return nil, err
+	}
+
+	return []GrantToRole{{Privilege: perm, GrantedOn: objectType, Name: name}}, nil
+}
+
+// resolveBulkGrant resolves ALL SCHEMAS|TABLES|VIEWS IN DATABASE|SCHEMA x into the existing schemas, tables or views
+func (f *fakeSnowflake) resolveBulkGrant(perm string, tokens []string) ([]GrantToRole, error) {
+	if len(tokens) < 5 {
+		return nil, fmt.Errorf("SQL compilation error: unsupported bulk grant %q", strings.Join(tokens, " "))
+	}
+
+	objectType := strings.TrimSuffix(strings.Join(tokens[1:len(tokens)-3], "_"), "S")
+	scope := common.ParseFullName(strings.ReplaceAll(tokens[len(tokens)-1], `"`, ""))
+
+	var grants []GrantToRole
+
+	if objectType == "SCHEMA" {
+		for _, schema := range f.schemas {
+			if schema.Database == *scope.Database {
+				grants = append(grants, GrantToRole{Privilege: perm, GrantedOn: objectType, Name: fmt.Sprintf("%s.%s", schema.Database, schema.Name)})
+			}
+		}
+
+		return grants, nil
+	}
+
+	for _, table := range f.tables {
+		if table.Database != *scope.Database || (scope.Schema != nil && table.Schema != *scope.Schema) {
+			continue
+		}
+
+		if tableGrantType(&table) == objectType {
+			grants = append(grants, GrantToRole{Privilege: perm, GrantedOn: objectType, Name: fmt.Sprintf("%s.%s.%s", table.Database, table.Schema, table.Name)})
+		}
+	}
+
+	return grants, nil
+}
+
+func (f *fakeSnowflake) checkObjectExists(objectType, name string) error {
+	var exists bool
+
+	switch objectType {
+	case "DATABASE":
+		exists = f.databaseExists(name)
+	case "SCHEMA":
+		parts := strings.SplitN(name, ".", 2)
+		exists = len(parts) == 2 && f.schemaExists(parts[0], parts[1])
+	case "TABLE", "VIEW", "MATERIALIZED_VIEW", "EXTERNAL_TABLE", "DYNAMIC_TABLE":
+		parts := strings.SplitN(name, ".", 3)
+		exists = len(parts) == 3 && f.tableExists(parts[0], parts[1], parts[2])
+	case "STAGE", "STREAM", "TASK", "PIPE", "SEQUENCE", "FILE_FORMAT", "SECRET", "IMAGE_REPOSITORY", "SERVICE":
+		exists = slices.ContainsFunc(f.schemaObjects[objectType], func(o SchemaObjectEntity) bool { return o.Database+"."+o.Schema+"."+o.Name == name })
+	case "WAREHOUSE":
+		exists = slices.ContainsFunc(f.warehouses, func(w DbEntity) bool { return w.Name == name })
+	case "COMPUTE_POOL":
+		exists = slices.ContainsFunc(f.computePools, func(c DbEntity) bool { return c.Name == name })
+	default:
+		exists = true
+	}
+
+	if !exists {
+		return fmt.Errorf("SQL compilation error: %s '%s' does not exist or not authorized", strings.ReplaceAll(objectType, "_", " "), name)
+	}
+
+	return nil
+}
+
+func tableGrantType(table *TableEntity) string {
+	if table.IsDynamic() {
+		return "DYNAMIC_TABLE"
+	}
+
+	switch table.TableType {
+	case "VIEW":
+		return "VIEW"
+	case "MATERIALIZED VIEW":
+		return "MATERIALIZED_VIEW"
+	case "EXTERNAL TABLE":
+		return "EXTERNAL_TABLE"
+	default:
+		return "TABLE"
+	}
+}
+
+func fakeDoesNotExistError(objectType, name string) error {
+	return fmt.Errorf("SQL compilation error: %s '%s' does not exist or not authorized", objectType, name)
+}
+
+func policyKey(database, schema, name string) string {
+	return fmt.Sprintf("%s.%s.%s", database, schema, name)
+}
+
+// likePattern converts a pattern of a SHOW ... LIKE statement into a case-insensitive regular expression
+func likePattern(like string) *regexp.Regexp {
+	var pattern strings.Builder
+
+	for _, c := range like {
+		switch c {
+		case '%':
+			pattern.WriteString(".*")
+		case '_':
+			pattern.WriteString(".")
+		default:
+			pattern.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+
+	return regexp.MustCompile("(?i)^" + pattern.String() + "$")
+}
+
+// splitOutsideQuotes splits a statement on spaces, keeping quoted identifiers together
+func splitOutsideQuotes(s string) []string {
+	var tokens []string
+
+	var current strings.Builder
+
+	quoted := false
+
+	for _, c := range s {
+		switch {
+		case c == '"':
+			quoted = !quoted
+
+			current.WriteRune(c)
+		case c == ' ' && !quoted:
+			if current.Len() > 0 {
+				tokens = append(tokens, current.String())
+				current.Reset()
+			}
+		default:
+			current.WriteRune(c)
+		}
+	}
+
+	if current.Len() > 0 {
+		tokens = append(tokens, current.String())
+	}
+
+	return tokens
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+func equalPtr(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return *a == *b
+}
@@ -0,0 +1,681 @@
+package snowflake
+
+import (
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const fixtureArchiveVersion = 1
+
+const (
+	fixtureValueNull    = "null"
+	fixtureValueString  = "string"
+	fixtureValueBytes   = "bytes"
+	fixtureValueInt64   = "int64"
+	fixtureValueFloat64 = "float64"
+	fixtureValueBool    = "bool"
+	fixtureValueTime    = "time"
+)
+
+// FixtureArchive contains all statements sent to Snowflake during one or more syncs, together with their results.
+// It is written as gzip compressed JSON.
+type FixtureArchive struct {
+	Version      int                  `json:"version"`
+	RecordedAt   time.Time            `json:"recordedAt"`
+	Anonymised   bool                 `json:"anonymised"`
+	Interactions []FixtureInteraction `json:"interactions"`
+}
+
+// FixtureInteraction is a single statement and its result, in the order in which it was sent during the sync
+type FixtureInteraction struct {
+	Syncer       SyncPhase        `json:"syncer"`
+	Statement    string           `json:"statement"`
+	Args         []FixtureValue   `json:"args,omitempty"`
+	Columns      []string         `json:"columns,omitempty"`
+	Rows         [][]FixtureValue `json:"rows,omitempty"`
+	RowsAffected int64            `json:"rowsAffected,omitempty"`
+	Error        string           `json:"error,omitempty"`
+}
+
+// FixtureValue is a value returned by the Snowflake driver, together with its type so it is replayed as the same Go type
+type FixtureValue struct {
+	Type  string `json:"type"`
+	Value string `json:"value,omitempty"`
+}
+
+func newFixtureValue(value driver.Value) FixtureValue {
+	switch v := value.(type) {
+	case nil:
+		return FixtureValue{Type: fixtureValueNull}
+	case string:
+		return FixtureValue{Type: fixtureValueString, Value: v}
+	case []byte:
+		return FixtureValue{Type: fixtureValueBytes, Value: base64.StdEncoding.EncodeToString(v)}
+	case int64:
+		return FixtureValue{Type: fixtureValueInt64, Value: strconv.FormatInt(v, 10)}
+	case float64:
+		return FixtureValue{Type: fixtureValueFloat64, Value: strconv.FormatFloat(v, 'g', -1, 64)}
+	case bool:
+		return FixtureValue{Type: fixtureValueBool, Value: strconv.FormatBool(v)}
+	case time.Time:
+		return FixtureValue{Type: fixtureValueTime, Value: v.Format(time.RFC3339Nano)}
+	default:
+		// Other driver specific types are scanned from their string representation
+		return FixtureValue{Type: fixtureValueString, Value: fmt.Sprintf("%v", v)}
+	}
+}
+
+func (v FixtureValue) driverValue() (driver.Value, error) {
+	switch v.Type {
+	case fixtureValueNull:
+		return nil, nil
+	case fixtureValueString:
+		return v.Value, nil
+	case fixtureValueBytes:
+		return base64.StdEncoding.DecodeString(v.Value)
+	case fixtureValueInt64:
+		return strconv.ParseInt(v.Value, 10, 64)
+	case fixtureValueFloat64:
+		return strconv.ParseFloat(v.Value, 64)
+	case fixtureValueBool:
+		return strconv.ParseBool(v.Value)
+	case fixtureValueTime:
+		return time.Parse(time.RFC3339Nano, v.Value)
+	default:
+		return nil, fmt.Errorf("unknown fixture value type %q", v.Type)
+	}
+}
+
+// ReadFixtureArchive reads an archive written while recording fixtures
+func ReadFixtureArchive(path string) (*FixtureArchive, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open fixture archive: %w", err)
+	}
+
+	defer file.Close()
+
+	reader, err := gzip.NewReader(file)
+	if err != nil {
+		return nil, fmt.Errorf("read fixture archive %q: %w", path, err)
+	}
+
+	defer reader.Close()
+
+	var archive FixtureArchive
+
+	err = json.NewDecoder(reader).Decode(&archive)
+	if err != nil {
+		return nil, fmt.Errorf("parse fixture archive %q: %w", path, err)
+	}
+
+	if archive.Version != fixtureArchiveVersion {
+		return nil, fmt.Errorf("unsupported fixture archive version %d in %q", archive.Version, path)
+	}
+
+	return &archive, nil
+}
+
+func writeFixtureArchive(path string, archive *FixtureArchive) error {
+	// Write to a temporary file first, so a previous archive is never left half overwritten
+	file, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("create fixture archive: %w", err)
+	}
+
+	defer os.Remove(file.Name())
+
+	writer := gzip.NewWriter(file)
+
+	err = json.NewEncoder(writer).Encode(archive)
+	if err == nil {
+		err = writer.Close()
+	}
+
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		return fmt.Errorf("write fixture archive %q: %w", path, err)
+	}
+
+	return os.Rename(file.Name(), path)
+}
+
+// Anonymisation of recorded fixtures
+
+var (
+	fixtureEmailRegex = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)
+
+	// anonymisedFixtureColumns contain free text or personal data that is never used to build other statements
+	anonymisedFixtureColumns = map[string]struct{}{"COMMENT": {}, "DESCRIPTION": {}, "QUERY_TEXT": {}, "DISPLAY_NAME": {}, "FIRST_NAME": {}, "LAST_NAME": {}}
+)
+
+func fixtureHash(value string) string {
+	hash := sha256.Sum256([]byte(value))
+
+	return hex.EncodeToString(hash[:6])
+}
+
+// anonymiseFixtureText replaces all email addresses by a pseudonym. The same address always gets the same pseudonym, so the results still match.
+func anonymiseFixtureText(text string) string {
+	return fixtureEmailRegex.ReplaceAllStringFunc(text, func(email string) string {
+		return "user-" + fixtureHash(strings.ToLower(email)) + "@example.com"
+	})
+}
+
+// anonymiseFixtureValue anonymises the value of a column in a result set.
+// Object, role and user names are kept, as they are needed to build the statements of the sync.
+func anonymiseFixtureValue(column string, value FixtureValue) FixtureValue {
+	if value.Type != fixtureValueString || value.Value == "" {
+		return value
+	}
+
+	if _, found := anonymisedFixtureColumns[strings.ToUpper(column)]; found {
+		return FixtureValue{Type: fixtureValueString, Value: "anonymised-" + fixtureHash(value.Value)}
+	}
+
+	return FixtureValue{Type: fixtureValueString, Value: anonymiseFixtureText(value.Value)}
+}
+
+func anonymiseFixtureInteraction(interaction *FixtureInteraction) {
+	interaction.Statement = anonymiseFixtureText(interaction.Statement)
+	interaction.Error = anonymiseFixtureText(interaction.Error)
+
+	for i := range interaction.Args {
+		interaction.Args[i] = anonymiseFixtureValue("", interaction.Args[i])
+	}
+
+	for _, row := range interaction.Rows {
+		for i := range row {
+			if i < len(interaction.Columns) {
+				row[i] = anonymiseFixtureValue(interaction.Columns[i], row[i])
+			}
+		}
+	}
+}
+
+// Recording
+
+// fixtureRecorder collects the interactions of all repositories recording to the same archive and writes them when a repository is closed
+type fixtureRecorder struct {
+	mutex sync.Mutex
+
+	path      string
+	anonymise bool
+	archive   FixtureArchive
+}
+
+var (
+	fixtureRecordersMutex sync.Mutex
+	// fixtureRecorders are shared by all repositories recording to the same file, so the syncs of one run end up in the same archive
+	fixtureRecorders = make(map[string]*fixtureRecorder)
+)
+
+// fixtureRecorderFromParams returns the recorder configured in the parameters, or nil if no fixtures should be recorded
+func fixtureRecorderFromParams(params map[string]string) *fixtureRecorder {
+	path := params[SfRecordFixturesFile]
+	if path == "" {
+		return nil
+	}
+
+	fixtureRecordersMutex.Lock()
+	defer fixtureRecordersMutex.Unlock()
+
+	if recorder, found := fixtureRecorders[path]; found {
+		return recorder
+	}
+
+	anonymise := strings.EqualFold(params[SfRecordFixturesAnonymise], "true")
+
+	recorder := &fixtureRecorder{
+		path:      path,
+		anonymise: anonymise,
+		archive:   FixtureArchive{Version: fixtureArchiveVersion, RecordedAt: time.Now().UTC(), Anonymised: anonymise},
+	}
+
+	fixtureRecorders[path] = recorder
+
+	return recorder
+}
+
+func (r *fixtureRecorder) record(interaction FixtureInteraction) {
+	if r.anonymise {
+		anonymiseFixtureInteraction(&interaction)
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.archive.Interactions = append(r.archive.Interactions, interaction)
+}
+
+func (r *fixtureRecorder) save() error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	Logger.Info(fmt.Sprintf("Writing %d recorded Snowflake statements to %s", len(r.archive.Interactions), r.path))
+
+	return writeFixtureArchive(r.path, &r.archive)
+}
+
+// recordingConnector sends all statements to the Snowflake connection and records them with their results.
+// Result sets are read completely before they are returned.
+type recordingConnector struct {
+	db       *sql.DB
+	syncer   SyncPhase
+	recorder *fixtureRecorder
+}
+
+func newRecordingConnector(db *sql.DB, syncer SyncPhase, recorder *fixtureRecorder) *recordingConnector {
+	return &recordingConnector{db: db, syncer: syncer, recorder: recorder}
+}
+
+func (c *recordingConnector) Connect(_ context.Context) (driver.Conn, error) {
+	return &recordingConn{connector: c}, nil
+}
+
+func (c *recordingConnector) Driver() driver.Driver {
+	return fixtureDriver{}
+}
+
+// Close is called when the repository is closed. It closes the Snowflake connection and writes the archive.
+func (c *recordingConnector) Close() error {
+	err := c.db.Close()
+
+	saveErr := c.recorder.save()
+	if saveErr != nil {
+		return saveErr
+	}
+
+	return err
+}
+
+type recordingConn struct {
+	fixtureConn
+
+	connector *recordingConnector
+
+	// tx is the Snowflake transaction of this connection, if one is started
+	tx *sql.Tx
+}
+
+// sqlExecutor is implemented by *sql.DB and *sql.Tx
+type sqlExecutor interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// executor returns the Snowflake transaction if one is started, so the statements in it are sent to the same Snowflake session
+func (c *recordingConn) executor() sqlExecutor {
+	if c.tx != nil {
+		return c.tx
+	}
+
+	return c.connector.db
+}
+
+// Begin is only required by driver.Conn, database/sql always starts transactions with BeginTx so they use the context of the caller
+func (c *recordingConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("transactions of a recording connection must be started with BeginTx")
+}
+
+// BeginTx starts a transaction in Snowflake. The statements in the transaction are recorded like any other statement.
+func (c *recordingConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	tx, err := c.connector.db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.IsolationLevel(opts.Isolation), ReadOnly: opts.ReadOnly})
+	if err != nil {
+		return nil, err
+	}
+
+	c.tx = tx
+
+	return &recordingTx{conn: c}, nil
+}
+
+// recordingTx ends the Snowflake transaction of a recording connection
+type recordingTx struct {
+	conn *recordingConn
+}
+
+func (t *recordingTx) Commit() error {
+	tx := t.conn.tx
+	t.conn.tx = nil
+
+	return tx.Commit()
+}
+
+func (t *recordingTx) Rollback() error {
+	tx := t.conn.tx
+	t.conn.tx = nil
+
+	return tx.Rollback()
+}
+
+func (c *recordingConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	interaction := FixtureInteraction{Syncer: c.connector.syncer, Statement: query, Args: newFixtureArgs(args)}
+
+	rows, err := c.readRows(ctx, query, args, &interaction)
+	if err != nil {
+		interaction.Error = err.Error()
+	}
+
+	c.connector.recorder.record(interaction)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return rows, nil
+}
+
+func (c *recordingConn) readRows(ctx context.Context, query string, args []driver.NamedValue, interaction *FixtureInteraction) (*fixtureRows, error) {
+	rows, err := c.executor().QueryContext(ctx, query, namedValuesToArgs(args)...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	result := &fixtureRows{columns: columns}
+	interaction.Columns = columns
+
+	for rows.Next() {
+		values := make([]any, len(columns))
+		pointers := make([]any, len(columns))
+
+		for i := range values {
+			pointers[i] = &values[i]
+		}
+
+		err = rows.Scan(pointers...)
+		if err != nil {
+			return nil, err
+		}
+
+		row := make([]driver.Value, 0, len(values))
+		fixtureRow := make([]FixtureValue, 0, len(values))
+
+		for _, value := range values {
+			row = append(row, value)
+			fixtureRow = append(fixtureRow, newFixtureValue(value))
+		}
+
+		result.rows = append(result.rows, row)
+		interaction.Rows = append(interaction.Rows, fixtureRow)
+	}
+
+	return result, rows.Err()
+}
+
+func (c *recordingConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	interaction := FixtureInteraction{Syncer: c.connector.syncer, Statement: query, Args: newFixtureArgs(args)}
+
+	result, err := c.executor().ExecContext(ctx, query, namedValuesToArgs(args)...)
+	if err != nil {
+		interaction.Error = err.Error()
+	} else if rowsAffected, rowsErr := result.RowsAffected(); rowsErr == nil {
+		interaction.RowsAffected = rowsAffected
+	}
+
+	c.connector.recorder.record(interaction)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return driver.RowsAffected(interaction.RowsAffected), nil
+}
+
+func newFixtureArgs(args []driver.NamedValue) []FixtureValue {
+	if len(args) == 0 {
+		return nil
+	}
+
+	result := make([]FixtureValue, 0, len(args))
+	for _, arg := range args {
+		result = append(result, newFixtureValue(arg.Value))
+	}
+
+	return result
+}
+
+func namedValuesToArgs(args []driver.NamedValue) []any {
+	result := make([]any, 0, len(args))
+	for _, arg := range args {
+		result = append(result, arg.Value)
+	}
+
+	return result
+}
+
+// Replaying
+
+type fixtureKey struct {
+	syncer    SyncPhase
+	statement string
+}
+
+// fixturePlayer serves the recorded interactions. Arguments are not matched, as they can depend on the time of the sync.
+// A statement executed multiple times gets the recorded results in the same order. Once these are used up, the last result is repeated.
+type fixturePlayer struct {
+	mutex sync.Mutex
+
+	interactions map[fixtureKey][]*FixtureInteraction
+	served       map[fixtureKey]int
+}
+
+var (
+	fixturePlayersMutex sync.Mutex
+	// fixturePlayers are shared by all repositories replaying the same file, so every recorded result is served once
+	fixturePlayers = make(map[string]*fixturePlayer)
+)
+
+func fixturePlayerFromFile(path string) (*fixturePlayer, error) {
+	fixturePlayersMutex.Lock()
+	defer fixturePlayersMutex.Unlock()
+
+	if player, found := fixturePlayers[path]; found {
+		return player, nil
+	}
+
+	archive, err := ReadFixtureArchive(path)
+	if err != nil {
+		return nil, err
+	}
+
+	player := newFixturePlayer(archive)
+	fixturePlayers[path] = player
+
+	return player, nil
+}
+
+func newFixturePlayer(archive *FixtureArchive) *fixturePlayer {
+	player := &fixturePlayer{
+		interactions: make(map[fixtureKey][]*FixtureInteraction),
+		served:       make(map[fixtureKey]int),
+	}
+
+	for i := range archive.Interactions {
+		interaction := &archive.Interactions[i]
+		key := fixtureKey{syncer: interaction.Syncer, statement: interaction.Statement}
+
+		player.interactions[key] = append(player.interactions[key], interaction)
+	}
+
+	return player
+}
+
+func (p *fixturePlayer) next(syncer SyncPhase, statement string) *FixtureInteraction {
+	key := fixtureKey{syncer: syncer, statement: statement}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	interactions := p.interactions[key]
+	if len(interactions) == 0 {
+		return nil
+	}
+
+	i := min(p.served[key], len(interactions)-1)
+	p.served[key]++
+
+	return interactions[i]
+}
+
+// replayConnector serves the statements of a sync from a fixture archive instead of sending them to Snowflake.
+// Statements changing Snowflake that were not recorded succeed without effect. Queries that were not recorded fail.
+type replayConnector struct {
+	player *fixturePlayer
+	syncer SyncPhase
+}
+
+func newReplayConnector(player *fixturePlayer, syncer SyncPhase) *replayConnector {
+	return &replayConnector{player: player, syncer: syncer}
+}
+
+func (c *replayConnector) Connect(_ context.Context) (driver.Conn, error) {
+	return &replayConn{connector: c}, nil
+}
+
+func (c *replayConnector) Driver() driver.Driver {
+	return fixtureDriver{}
+}
+
+type replayConn struct {
+	fixtureConn
+
+	connector *replayConnector
+}
+
+// Begin starts a transaction without effect, as the statements in it are replayed one by one like any other statement
+func (c *replayConn) Begin() (driver.Tx, error) {
+	return replayTx{}, nil
+}
+
+type replayTx struct{}
+
+func (replayTx) Commit() error {
+	return nil
+}
+
+func (replayTx) Rollback() error {
+	return nil
+}
+
+func (c *replayConn) QueryContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Rows, error) {
+	interaction := c.connector.player.next(c.connector.syncer, query)
+	if interaction == nil {
+		if isReadOnlyStatement(query) {
+			return nil, fmt.Errorf("no recorded result for query %q", query)
+		}
+
+		Logger.Warn(fmt.Sprintf("Statement %q was not recorded and is skipped", query))
+
+		return &fixtureRows{}, nil
+	}
+
+	if interaction.Error != "" {
+		return nil, errors.New(interaction.Error)
+	}
+
+	rows := &fixtureRows{columns: interaction.Columns, rows: make([][]driver.Value, 0, len(interaction.Rows))}
+
+	for _, fixtureRow := range interaction.Rows {
+		row := make([]driver.Value, 0, len(fixtureRow))
+
+		for _, fixtureValue := range fixtureRow {
+			value, err := fixtureValue.driverValue()
+			if err != nil {
+				return nil, fmt.Errorf("recorded result for query %q: %w", query, err)
+			}
+
+			row = append(row, value)
+		}
+
+		rows.rows = append(rows.rows, row)
+	}
+
+	return rows, nil
+}
+
+func (c *replayConn) ExecContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Result, error) {
+	interaction := c.connector.player.next(c.connector.syncer, query)
+	if interaction == nil {
+		Logger.Warn(fmt.Sprintf("Statement %q was not recorded and is skipped", query))
+
+		return driver.RowsAffected(0), nil
+	}
+
+	if interaction.Error != "" {
+		return nil, errors.New(interaction.Error)
+	}
+
+	return driver.RowsAffected(interaction.RowsAffected), nil
+}
+
+// Shared driver implementation
+
+type fixtureDriver struct{}
+
+func (fixtureDriver) Open(_ string) (driver.Conn, error) {
+	return nil, errors.New("fixture connections can only be opened using a connector")
+}
+
+// fixtureConn implements the parts of driver.Conn that are not supported when recording or replaying.
+// Statements are always sent using QueryContext or ExecContext, also within a transaction.
+type fixtureConn struct{}
+
+func (fixtureConn) Prepare(_ string) (driver.Stmt, error) {
+	return nil, errors.New("prepared statements are not supported when recording or replaying fixtures")
+}
+
+func (fixtureConn) Close() error {
+	return nil
+}
+
+type fixtureRows struct {
+	columns []string
+	rows    [][]driver.Value
+	next    int
+}
+
+func (r *fixtureRows) Columns() []string {
+	return r.columns
+}
+
+func (r *fixtureRows) Close() error {
+	return nil
+}
+
+func (r *fixtureRows) Next(dest []driver.Value) error {
+	if r.next >= len(r.rows) {
+		return io.EOF
+	}
+
+	copy(dest, r.rows[r.next])
+	r.next++
+
+	return nil
+}
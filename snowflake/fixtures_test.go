@@ -0,0 +1,214 @@
+package snowflake
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func stringFixtureValues(values ...string) []FixtureValue {
+	result := make([]FixtureValue, 0, len(values))
+	for _, value := range values {
+		result = append(result, FixtureValue{Type: fixtureValueString, Value: value})
+	}
+
+	return result
+}
+
+func showRolesFixture() FixtureInteraction {
+	return FixtureInteraction{
+		Syncer:    SyncPhaseAccessFromTarget,
+		Statement: "SHOW ROLES LIMIT 1000",
+		Columns:   []string{"name", "assigned_to_users", "granted_to_roles", "granted_roles", "owner", "comment"},
+		Rows: [][]FixtureValue{
+			stringFixtureValues("ANALYST", "2", "0", "1", "SYSADMIN", "Owned by alice@raito.io"),
+			stringFixtureValues("SALES", "1", "1", "0", "SYSADMIN", ""),
+		},
+	}
+}
+
+func TestFixtureValue_RoundTrip(t *testing.T) {
+	timestamp := time.Date(2024, 3, 1, 12, 30, 0, 123, time.UTC)
+
+	for _, value := range []any{nil, "ROLE", []byte("bytes"), int64(42), 1.5, true, timestamp} {
+		result, err := newFixtureValue(value).driverValue()
+		require.NoError(t, err)
+
+		assert.Equal(t, value, result)
+	}
+}
+
+func TestFixtures_RecordAndReplay(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "fixtures.json.gz")
+
+	// Record from a replayed source, as there is no Snowflake connection in tests
+	source := sql.OpenDB(newReplayConnector(newFixturePlayer(&FixtureArchive{Interactions: []FixtureInteraction{showRolesFixture()}}), SyncPhaseAccessFromTarget))
+	recorder := fixtureRecorderFromParams(map[string]string{SfRecordFixturesFile: path})
+	recordingRepo := &SnowflakeRepository{conn: sql.OpenDB(newRecordingConnector(source, SyncPhaseAccessFromTarget, recorder)), phase: SyncPhaseAccessFromTarget}
+
+	recordedRoles, err := recordingRepo.GetAccountRoles(ctx)
+	require.NoError(t, err)
+	require.NoError(t, recordingRepo.CreateAccountRole(ctx, "NEW_ROLE"))
+	require.NoError(t, recordingRepo.Close())
+
+	archive, err := ReadFixtureArchive(path)
+	require.NoError(t, err)
+
+	assert.False(t, archive.Anonymised)
+	require.Len(t, archive.Interactions, 2)
+	assert.Equal(t, showRolesFixture(), archive.Interactions[0])
+	assert.Equal(t, "CREATE ROLE IF NOT EXISTS NEW_ROLE", archive.Interactions[1].Statement)
+
+	replayRepo, err := NewSnowflakeRepository(map[string]string{SfReplayFixturesFile: path}, "", WithSyncPhase(SyncPhaseAccessFromTarget))
+	require.NoError(t, err)
+
+	defer replayRepo.Close()
+
+	replayedRoles, err := replayRepo.GetAccountRoles(ctx)
+	require.NoError(t, err)
+
+	assert.Equal(t, []RoleEntity{
+		{Name: "ANALYST", AssignedToUsers: 2, GrantedToRoles: 0, GrantedRoles: 1, Owner: "SYSADMIN"},
+		{Name: "SALES", AssignedToUsers: 1, GrantedToRoles: 1, GrantedRoles: 0, Owner: "SYSADMIN"},
+	}, replayedRoles)
+	assert.Equal(t, recordedRoles, replayedRoles)
+}
+
+func TestFixtures_RecordAndReplayMaskingPolicyDrop(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "fixtures.json.gz")
+
+	dropStatement := "DROP MASKING POLICY DB.SCHEMA1.MASK1_TEXT"
+
+	// The masking policy is dropped in a transaction
+	source := sql.OpenDB(newReplayConnector(newFixturePlayer(&FixtureArchive{Interactions: []FixtureInteraction{
+		{
+			Syncer:    SyncPhaseAccessToTarget,
+			Statement: "SHOW MASKING POLICIES LIKE 'MASK1_%';",
+			Columns:   []string{"name", "database_name", "schema_name", "kind", "owner"},
+			Rows:      [][]FixtureValue{stringFixtureValues("MASK1_TEXT", "DB", "SCHEMA1", "MASKING_POLICY", "SYSADMIN")},
+		},
+		{
+			Syncer:    SyncPhaseAccessToTarget,
+			Statement: "select * from table(DB.information_schema.policy_references(policy_name => 'DB.SCHEMA1.MASK1_TEXT'))",
+			Columns:   []string{"POLICY_DB", "POLICY_SCHEMA", "POLICY_NAME", "POLICY_KIND", "REF_DATABASE_NAME", "REF_SCHEMA_NAME", "REF_ENTITY_NAME", "REF_ENTITY_DOMAIN", "REF_COLUMN_NAME", "POLICY_STATUS"},
+			Rows:      [][]FixtureValue{stringFixtureValues("DB", "SCHEMA1", "MASK1_TEXT", "MASKING_POLICY", "DB", "SCHEMA1", "TABLE1", "TABLE", "COL1", "ACTIVE")},
+		},
+	}}), SyncPhaseAccessToTarget))
+	recorder := fixtureRecorderFromParams(map[string]string{SfRecordFixturesFile: path})
+	recordingRepo := &SnowflakeRepository{conn: sql.OpenDB(newRecordingConnector(source, SyncPhaseAccessToTarget, recorder)), phase: SyncPhaseAccessToTarget, role: AccountAdminRole}
+
+	require.NoError(t, recordingRepo.DropMaskingPolicy(ctx, "DB", "SCHEMA1", "MASK1"))
+	require.NoError(t, recordingRepo.Close())
+
+	archive, err := ReadFixtureArchive(path)
+	require.NoError(t, err)
+
+	statements := make([]string, 0, len(archive.Interactions))
+	for _, interaction := range archive.Interactions {
+		statements = append(statements, interaction.Statement)
+	}
+
+	assert.Equal(t, []string{
+		"SHOW MASKING POLICIES LIKE 'MASK1_%';",
+		"select * from table(DB.information_schema.policy_references(policy_name => 'DB.SCHEMA1.MASK1_TEXT'))",
+		`ALTER TABLE DB.SCHEMA1.TABLE1 ALTER COLUMN "COL1" UNSET MASKING POLICY`,
+		dropStatement,
+	}, statements)
+
+	replayRepo, err := NewSnowflakeRepository(map[string]string{SfReplayFixturesFile: path}, AccountAdminRole, WithSyncPhase(SyncPhaseAccessToTarget))
+	require.NoError(t, err)
+
+	defer replayRepo.Close()
+
+	require.NoError(t, replayRepo.DropMaskingPolicy(ctx, "DB", "SCHEMA1", "MASK1"))
+
+	player, err := fixturePlayerFromFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, 1, player.served[fixtureKey{syncer: SyncPhaseAccessToTarget, statement: dropStatement}])
+}
+
+func TestFixtures_ReplayUnrecordedStatements(t *testing.T) {
+	ctx := context.Background()
+
+	player := newFixturePlayer(&FixtureArchive{Interactions: []FixtureInteraction{
+		showRolesFixture(),
+		{Syncer: SyncPhaseAccessToTarget, Statement: "SHOW WAREHOUSES", Error: "insufficient privileges"},
+	}})
+	repo := &SnowflakeRepository{conn: sql.OpenDB(newReplayConnector(player, SyncPhaseAccessToTarget)), phase: SyncPhaseAccessToTarget}
+
+	// Recorded for another syncer
+	_, err := repo.GetAccountRoles(ctx)
+	require.ErrorContains(t, err, `no recorded result for query "SHOW ROLES LIMIT 1000"`)
+
+	_, err = repo.GetWarehouses(ctx)
+	require.ErrorContains(t, err, "insufficient privileges")
+
+	// Changes that were not recorded are skipped
+	require.NoError(t, repo.CreateAccountRole(ctx, "NEW_ROLE"))
+	require.NoError(t, repo.GrantUsersToAccountRole(ctx, "NEW_ROLE", "ALICE"))
+}
+
+func TestFixtures_ReplayRepeatedStatements(t *testing.T) {
+	first := showRolesFixture()
+	second := showRolesFixture()
+	second.Rows = second.Rows[:1]
+
+	player := newFixturePlayer(&FixtureArchive{Interactions: []FixtureInteraction{first, second}})
+
+	assert.Len(t, player.next(SyncPhaseAccessFromTarget, first.Statement).Rows, 2)
+	assert.Len(t, player.next(SyncPhaseAccessFromTarget, first.Statement).Rows, 1)
+	assert.Len(t, player.next(SyncPhaseAccessFromTarget, first.Statement).Rows, 1)
+	assert.Nil(t, player.next(SyncPhaseAccessToTarget, first.Statement))
+}
+
+func TestFixtures_Anonymise(t *testing.T) {
+	interaction := FixtureInteraction{
+		Statement: "SHOW GRANTS OF ROLE ANALYST",
+		Columns:   []string{"name", "email", "display_name", "comment", "owner"},
+		Rows: [][]FixtureValue{
+			{{Type: fixtureValueString, Value: "ALICE"}, {Type: fixtureValueString, Value: "Alice@Raito.io"}, {Type: fixtureValueString, Value: "Alice Smith"}, {Type: fixtureValueString, Value: "Ask alice@raito.io"}, {Type: fixtureValueNull}},
+		},
+	}
+
+	anonymiseFixtureInteraction(&interaction)
+
+	email := anonymiseFixtureText("alice@raito.io")
+	assert.Regexp(t, `^user-[0-9a-f]{12}@example\.com$`, email)
+
+	assert.Equal(t, "SHOW GRANTS OF ROLE ANALYST", interaction.Statement)
+	assert.Equal(t, []FixtureValue{
+		{Type: fixtureValueString, Value: "ALICE"},
+		{Type: fixtureValueString, Value: email},
+		{Type: fixtureValueString, Value: "anonymised-" + fixtureHash("Alice Smith")},
+		{Type: fixtureValueString, Value: "anonymised-" + fixtureHash("Ask alice@raito.io")},
+		{Type: fixtureValueNull},
+	}, interaction.Rows[0])
+}
+
+func TestFixtures_RecordAnonymised(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "fixtures.json.gz")
+
+	source := sql.OpenDB(newReplayConnector(newFixturePlayer(&FixtureArchive{Interactions: []FixtureInteraction{showRolesFixture()}}), SyncPhaseAccessFromTarget))
+	recorder := fixtureRecorderFromParams(map[string]string{SfRecordFixturesFile: path, SfRecordFixturesAnonymise: "true"})
+	repo := &SnowflakeRepository{conn: sql.OpenDB(newRecordingConnector(source, SyncPhaseAccessFromTarget, recorder)), phase: SyncPhaseAccessFromTarget}
+
+	roles, err := repo.GetAccountRoles(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, roles, 2)
+	require.NoError(t, repo.Close())
+
+	archive, err := ReadFixtureArchive(path)
+	require.NoError(t, err)
+
+	assert.True(t, archive.Anonymised)
+	require.Len(t, archive.Interactions, 1)
+	assert.Equal(t, "ANALYST", archive.Interactions[0].Rows[0][0].Value)
+	assert.Equal(t, "anonymised-"+fixtureHash("Owned by alice@raito.io"), archive.Interactions[0].Rows[0][5].Value)
+}
@@ -0,0 +1,66 @@
+package snowflake
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/raito-io/cli/base/access_provider"
+)
+
+const (
+	// GrantLoadingShow reads the grants of every account role using SHOW GRANTS TO ROLE and SHOW GRANTS OF ROLE
+	GrantLoadingShow = "show"
+	// GrantLoadingAccountUsage reads the grants of all account roles at once from the SNOWFLAKE.ACCOUNT_USAGE schema
+	GrantLoadingAccountUsage = "account-usage"
+)
+
+// accountRoleGrants contains the grants of all account roles, loaded upfront in bulk
+type accountRoleGrants struct {
+	grantsTo map[string][]GrantToRole
+	grantsOf map[string][]GrantOfRole
+}
+
+// loadAccountRoleGrants loads the grants of all account roles if configured in sf-grant-loading.
+// It returns nil if the grants should be read per role.
+func loadAccountRoleGrants(ctx context.Context, repo dataAccessRepository, params map[string]string) (*accountRoleGrants, error) {
+	switch strategy := params[SfGrantLoading]; strategy {
+	case "", GrantLoadingShow:
+		return nil, nil //nolint:nilnil
+	case GrantLoadingAccountUsage:
+		Logger.Warn("Reading the grants of account roles from SNOWFLAKE.ACCOUNT_USAGE. Grants changed during the last 2 hours may not be included yet.")
+
+		grantsTo, err := repo.GetAllGrantsToAccountRoles(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("loading grants to account roles: %w", err)
+		}
+
+		grantsOf, err := repo.GetAllGrantsOfAccountRoles(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("loading grants of account roles: %w", err)
+		}
+
+		Logger.Info(fmt.Sprintf("Loaded the grants of %d account roles", len(grantsTo)))
+
+		return &accountRoleGrants{grantsTo: grantsTo, grantsOf: grantsOf}, nil
+	default:
+		return nil, fmt.Errorf("invalid value %q for %q parameter (must be %q or %q)", strategy, SfGrantLoading, GrantLoadingShow, GrantLoadingAccountUsage)
+	}
+}
+
+// getGrantsToRole returns the privileges granted to the role, from the grants loaded upfront if available
+func (s *AccessFromTargetSyncer) getGrantsToRole(ctx context.Context, externalId string, apType *string) ([]GrantToRole, error) {
+	if s.accountRoleGrants != nil && (apType == nil || *apType == access_provider.Role) {
+		return s.accountRoleGrants.grantsTo[externalId], nil
+	}
+
+	return s.accessSyncer.getGrantsToRole(ctx, externalId, apType)
+}
+
+// retrieveGrantsOfRole returns the users and roles the role is granted to, from the grants loaded upfront if available
+func (s *AccessFromTargetSyncer) retrieveGrantsOfRole(ctx context.Context, externalId string, apType string) ([]GrantOfRole, error) {
+	if s.accountRoleGrants != nil && apType == access_provider.Role {
+		return s.accountRoleGrants.grantsOf[externalId], nil
+	}
+
+	return s.accessSyncer.retrieveGrantsOfRole(ctx, externalId, apType)
+}
@@ -0,0 +1,42 @@
+package snowflake
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadAccountRoleGrants_Show(t *testing.T) {
+	repoMock := newMockDataAccessRepository(t)
+
+	for _, strategy := range []string{"", GrantLoadingShow} {
+		grants, err := loadAccountRoleGrants(context.Background(), repoMock, map[string]string{SfGrantLoading: strategy})
+		require.NoError(t, err)
+
+		assert.Nil(t, grants)
+	}
+}
+
+func TestLoadAccountRoleGrants_AccountUsage(t *testing.T) {
+	repoMock := newMockDataAccessRepository(t)
+
+	grantsTo := map[string][]GrantToRole{"SALES": {{Privilege: "SELECT", GrantedOn: "TABLE", Name: "SALES_DB.PUBLIC.ORDERS"}}}
+	grantsOf := map[string][]GrantOfRole{"SALES": {{GrantedTo: "USER", GranteeName: "ALICE"}}}
+
+	repoMock.EXPECT().GetAllGrantsToAccountRoles(mock.Anything).Return(grantsTo, nil).Once()
+	repoMock.EXPECT().GetAllGrantsOfAccountRoles(mock.Anything).Return(grantsOf, nil).Once()
+
+	grants, err := loadAccountRoleGrants(context.Background(), repoMock, map[string]string{SfGrantLoading: GrantLoadingAccountUsage})
+	require.NoError(t, err)
+
+	assert.Equal(t, &accountRoleGrants{grantsTo: grantsTo, grantsOf: grantsOf}, grants)
+}
+
+func TestLoadAccountRoleGrants_InvalidStrategy(t *testing.T) {
+	_, err := loadAccountRoleGrants(context.Background(), newMockDataAccessRepository(t), map[string]string{SfGrantLoading: "bulk"})
+
+	assert.ErrorContains(t, err, `invalid value "bulk" for "sf-grant-loading" parameter`)
+}
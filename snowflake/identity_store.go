@@ -17,8 +17,9 @@ import (
 type identityStoreRepository interface {
 	Close() error
 	TotalQueryTime() time.Duration
-	GetUsers() ([]UserEntity, error)
-	GetTagsByDomain(domain string) (map[string][]*tag.Tag, error)
+	TotalRetries() int
+	GetUsers(ctx context.Context) ([]UserEntity, error)
+	GetTagsByDomain(ctx context.Context, domain string) (map[string][]*tag.Tag, error)
 }
 
 type IdentityStoreSyncer struct {
@@ -30,7 +31,7 @@ func NewIdentityStoreSyncer() *IdentityStoreSyncer {
 }
 
 func newIdentityStoreSnowflakeRepo(params map[string]string, role string) (identityStoreRepository, error) {
-	return NewSnowflakeRepository(params, role)
+	return NewSnowflakeRepository(params, role, WithSyncPhase(SyncPhaseIdentityStore))
 }
 
 func (s *IdentityStoreSyncer) GetIdentityStoreMetaData(_ context.Context, _ *config.ConfigMap) (*is.MetaData, error) {
@@ -43,7 +44,7 @@ func (s *IdentityStoreSyncer) GetIdentityStoreMetaData(_ context.Context, _ *con
 	}, nil
 }
 
-func (s *IdentityStoreSyncer) retrieveAdditionalUserTags(repo identityStoreRepository, configMap *config.ConfigMap) (map[string][]*tag.Tag, error) {
+func (s *IdentityStoreSyncer) retrieveAdditionalUserTags(ctx context.Context, repo identityStoreRepository, configMap *config.ConfigMap) (map[string][]*tag.Tag, error) {
 	standard := configMap.GetBoolWithDefault(SfStandardEdition, false)
 	skipTags := configMap.GetBoolWithDefault(SfSkipTags, false)
 
@@ -52,7 +53,7 @@ func (s *IdentityStoreSyncer) retrieveAdditionalUserTags(repo identityStoreRepos
 		return nil, nil
 	}
 
-	allUserTags, err := repo.GetTagsByDomain("USER")
+	allUserTags, err := repo.GetTagsByDomain(ctx, "USER")
 	if err != nil {
 		return nil, err
 	}
@@ -60,25 +61,30 @@ func (s *IdentityStoreSyncer) retrieveAdditionalUserTags(repo identityStoreRepos
 	return allUserTags, nil
 }
 
-func (s *IdentityStoreSyncer) SyncIdentityStore(ctx context.Context, identityHandler wrappers.IdentityStoreIdentityHandler, configMap *config.ConfigMap) error {
+func (s *IdentityStoreSyncer) SyncIdentityStore(ctx context.Context, identityHandler wrappers.IdentityStoreIdentityHandler, configMap *config.ConfigMap) (err error) {
+	ctx, endSpan := startSyncPhaseSpan(ctx, configMap.Parameters, SyncPhaseIdentityStore)
+	defer func() { endSpan(err) }()
+
 	repo, err := s.repoProvider(configMap.Parameters, "")
 	if err != nil {
 		return err
 	}
 
 	defer func() {
-		Logger.Info(fmt.Sprintf("Total snowflake query time:  %s", repo.TotalQueryTime()))
+		Logger.Info(fmt.Sprintf("Total snowflake query time:  %s (%d retries)", repo.TotalQueryTime(), repo.TotalRetries()))
 		repo.Close()
+
+		writeStatementMetrics(configMap.Parameters)
 	}()
 
-	userRows, err := repo.GetUsers()
+	userRows, err := repo.GetUsers(ctx)
 	if err != nil {
 		return err
 	}
 
 	visitedEmailSet := set.NewSet[string]()
 
-	allUserTags, err := s.retrieveAdditionalUserTags(repo, configMap)
+	allUserTags, err := s.retrieveAdditionalUserTags(ctx, repo, configMap)
 	if err != nil {
 		return err
 	}
@@ -25,7 +25,8 @@ func TestIdentityStoreSyncer_SyncIdentityStore(t *testing.T) {
 
 	repoMock.EXPECT().Close().Return(nil)
 	repoMock.EXPECT().TotalQueryTime().Return(time.Second)
-	repoMock.EXPECT().GetUsers().Return([]UserEntity{
+	repoMock.EXPECT().TotalRetries().Return(0)
+	repoMock.EXPECT().GetUsers(mock.Anything).Return([]UserEntity{
 		{
 			Name:        "UserName1",
 			DisplayName: ptr.String("user1"),
@@ -41,7 +42,7 @@ func TestIdentityStoreSyncer_SyncIdentityStore(t *testing.T) {
 			Email: nil,
 		},
 	}, nil)
-	repoMock.EXPECT().GetTagsByDomain("USER").Return(map[string][]*tag.Tag{
+	repoMock.EXPECT().GetTagsByDomain(mock.Anything, "USER").Return(map[string][]*tag.Tag{
 		"UserName1": {
 			{Key: "a_key", Value: "override_name"},
 			{Key: "an_other_key", Value: "...."},
@@ -84,7 +85,8 @@ func TestNewIdentityStoreSyncer_RepoError(t *testing.T) {
 
 	repoMock.EXPECT().Close().Return(nil)
 	repoMock.EXPECT().TotalQueryTime().Return(time.Second)
-	repoMock.EXPECT().GetUsers().Return(nil, fmt.Errorf("boom"))
+	repoMock.EXPECT().TotalRetries().Return(0)
+	repoMock.EXPECT().GetUsers(mock.Anything).Return(nil, fmt.Errorf("boom"))
 
 	syncer := IdentityStoreSyncer{
 		repoProvider: func(params map[string]string, role string) (identityStoreRepository, error) {
@@ -116,8 +118,9 @@ func TestNewIdentityStoreSyncer_AddUserError(t *testing.T) {
 
 	repoMock.EXPECT().Close().Return(nil)
 	repoMock.EXPECT().TotalQueryTime().Return(time.Second)
-	repoMock.EXPECT().GetTagsByDomain("USER").Return(nil, nil)
-	repoMock.EXPECT().GetUsers().Return([]UserEntity{
+	repoMock.EXPECT().TotalRetries().Return(0)
+	repoMock.EXPECT().GetTagsByDomain(mock.Anything, "USER").Return(nil, nil)
+	repoMock.EXPECT().GetUsers(mock.Anything).Return([]UserEntity{
 		{
 			Name:        "UserName1",
 			DisplayName: ptr.String("user1"),
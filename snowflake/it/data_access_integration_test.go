@@ -297,7 +297,7 @@ func (s *DataAccessTestSuite) TestAccessSyncer_SyncAccessProvidersToTarget() {
 		},
 	}, accessProviderFeedback)
 
-	roles, err := s.sfRepo.GetAccountRoles()
+	roles, err := s.sfRepo.GetAccountRoles(context.Background())
 	s.NoError(err)
 	s.Contains(roles, snowflake.RoleEntity{
 		Name:            actualRoleName,
@@ -307,7 +307,7 @@ func (s *DataAccessTestSuite) TestAccessSyncer_SyncAccessProvidersToTarget() {
 		Owner:           "ACCOUNTADMIN",
 	})
 
-	grants, err := s.sfRepo.GetGrantsToAccountRole(actualRoleName)
+	grants, err := s.sfRepo.GetGrantsToAccountRole(context.Background(), actualRoleName)
 	s.NoError(err)
 	s.Len(grants, 7)
 	dbUsageFound := false
@@ -336,7 +336,7 @@ func (s *DataAccessTestSuite) TestAccessSyncer_SyncAccessProvidersToTarget() {
 	s.True(procedureUsageFound)
 	s.True(integrationUsageFound)
 
-	databaseRoles, err := s.sfRepo.GetDatabaseRoles("RAITO_DATABASE")
+	databaseRoles, err := s.sfRepo.GetDatabaseRoles(context.Background(), "RAITO_DATABASE")
 	s.NoError(err)
 	s.Contains(databaseRoles, snowflake.RoleEntity{
 		Name:            databaseRoleName1,
@@ -424,7 +424,7 @@ func (s *DataAccessTestSuite) TestAccessSyncer_SyncAccessProvidersToTarget() {
 		},
 	}, accessProviderFeedback)
 
-	roles, err = s.sfRepo.GetAccountRoles()
+	roles, err = s.sfRepo.GetAccountRoles(context.Background())
 	s.NoError(err)
 	s.Contains(roles, snowflake.RoleEntity{
 		Name:            actualRoleName,
@@ -434,7 +434,7 @@ func (s *DataAccessTestSuite) TestAccessSyncer_SyncAccessProvidersToTarget() {
 		Owner:           "ACCOUNTADMIN",
 	})
 
-	databaseRoles, err = s.sfRepo.GetDatabaseRoles("RAITO_DATABASE")
+	databaseRoles, err = s.sfRepo.GetDatabaseRoles(context.Background(), "RAITO_DATABASE")
 	s.NoError(err)
 	s.Contains(databaseRoles, snowflake.RoleEntity{
 		Name:            databaseRoleName1,
@@ -491,7 +491,7 @@ func (s *DataAccessTestSuite) TestAccessSyncer_SyncAccessProvidersToTarget() {
 		},
 	})
 
-	roles, err = s.sfRepo.GetAccountRoles()
+	roles, err = s.sfRepo.GetAccountRoles(context.Background())
 	s.NoError(err)
 	s.NotContains(roles, snowflake.RoleEntity{
 		Name:            actualRoleName,
@@ -575,7 +575,7 @@ func (s *DataAccessTestSuite) TestAccessSyncer_SyncAccessProvidersFunctionToTarg
 				}.Build(),
 			}, accessProviderFeedback)
 
-			roles, err := s.sfRepo.GetAccountRoles()
+			roles, err := s.sfRepo.GetAccountRoles(context.Background())
 			s.Require().NoError(err)
 			s.Contains(roles, snowflake.RoleEntity{
 				Name:            actualRoleName,
@@ -585,7 +585,7 @@ func (s *DataAccessTestSuite) TestAccessSyncer_SyncAccessProvidersFunctionToTarg
 				Owner:           "ACCOUNTADMIN",
 			})
 
-			grants, err := s.sfRepo.GetGrantsToAccountRole(actualRoleName)
+			grants, err := s.sfRepo.GetGrantsToAccountRole(context.Background(), actualRoleName)
 			s.Require().NoError(err)
 			s.Len(grants, 5)
 
@@ -679,7 +679,7 @@ func (s *DataAccessTestSuite) TestAccessSyncer_SyncAccessProvidersFunctionWithRe
 			}.Build(),
 		}, accessProviderFeedback)
 
-		roles, err := s.sfRepo.GetAccountRoles()
+		roles, err := s.sfRepo.GetAccountRoles(context.Background())
 		s.Require().NoError(err)
 		s.Contains(roles, snowflake.RoleEntity{
 			Name:            actualRoleName,
@@ -689,7 +689,7 @@ func (s *DataAccessTestSuite) TestAccessSyncer_SyncAccessProvidersFunctionWithRe
 			Owner:           "ACCOUNTADMIN",
 		})
 
-		grants, err := s.sfRepo.GetGrantsToAccountRole(actualRoleName)
+		grants, err := s.sfRepo.GetGrantsToAccountRole(context.Background(), actualRoleName)
 		s.Require().NoError(err)
 		s.Len(grants, 4)
 
@@ -773,7 +773,7 @@ func (s *DataAccessTestSuite) TestAccessSyncer_SyncAccessProvidersFunctionWithRe
 			}.Build(),
 		}, accessProviderFeedback)
 
-		roles, err := s.sfRepo.GetAccountRoles()
+		roles, err := s.sfRepo.GetAccountRoles(context.Background())
 		s.Require().NoError(err)
 		s.Contains(roles, snowflake.RoleEntity{
 			Name:            actualRoleName,
@@ -783,7 +783,7 @@ func (s *DataAccessTestSuite) TestAccessSyncer_SyncAccessProvidersFunctionWithRe
 			Owner:           "ACCOUNTADMIN",
 		})
 
-		grants, err := s.sfRepo.GetGrantsToAccountRole(actualRoleName)
+		grants, err := s.sfRepo.GetGrantsToAccountRole(context.Background(), actualRoleName)
 		s.Require().NoError(err)
 		s.Empty(grants)
 
@@ -859,7 +859,7 @@ func (s *DataAccessTestSuite) TestAccessSyncer_SyncAccessProviderMasksToTarget()
 
 	s.True(strings.HasPrefix(accessProviderFeedback[0].ActualName, fmt.Sprintf("RAITO_%s", strings.ToUpper(maskName))))
 
-	maskPolicies, err := s.sfRepo.GetPolicies("MASKING")
+	maskPolicies, err := s.sfRepo.GetPolicies(context.Background(), "MASKING")
 	s.NoError(err)
 	s.Contains(maskPolicies, snowflake.PolicyEntity{
 		Name:         fmt.Sprintf("%s_TEXT", strings.ToUpper(accessProviderFeedback[0].ActualName)),
@@ -884,7 +884,7 @@ func (s *DataAccessTestSuite) TestAccessSyncer_SyncAccessProviderMasksToTarget()
 
 	s.True(strings.HasPrefix(accessProviderFeedback[0].ActualName, fmt.Sprintf("RAITO_%s", strings.ToUpper(maskName))))
 
-	maskPolicies, err = s.sfRepo.GetPolicies("MASKING")
+	maskPolicies, err = s.sfRepo.GetPolicies(context.Background(), "MASKING")
 	s.NoError(err)
 	s.Contains(maskPolicies, snowflake.PolicyEntity{
 		Name:         fmt.Sprintf("%s_TEXT", strings.ToUpper(accessProviderFeedback[0].ActualName)),
@@ -910,7 +910,7 @@ func (s *DataAccessTestSuite) TestAccessSyncer_SyncAccessProviderMasksToTarget()
 
 	s.True(strings.HasPrefix(accessProviderFeedback[0].ActualName, fmt.Sprintf("RAITO_%s", strings.ToUpper(maskName))))
 
-	maskPolicies, err = s.sfRepo.GetPolicies("MASKING")
+	maskPolicies, err = s.sfRepo.GetPolicies(context.Background(), "MASKING")
 	s.NoError(err)
 	s.NotContains(maskPolicies, snowflake.PolicyEntity{
 		Name:         fmt.Sprintf("RAITO_%s_TEXT", strings.ToUpper(accessProviderFeedback[0].ActualName)),
@@ -974,7 +974,7 @@ func (s *DataAccessTestSuite) TestAccessSyncer_SyncAccessProviderMasksToTarget_O
 
 	s.True(strings.HasPrefix(accessProviderFeedback[0].ActualName, fmt.Sprintf("RAITO_%s", strings.ToUpper(maskName))))
 
-	maskPolicies, err := s.sfRepo.GetPolicies("MASKING")
+	maskPolicies, err := s.sfRepo.GetPolicies(context.Background(), "MASKING")
 	s.NoError(err)
 	s.Contains(maskPolicies, snowflake.PolicyEntity{
 		Name:         fmt.Sprintf("%s_NUMBER", strings.ToUpper(accessProviderFeedback[0].ActualName)),
@@ -999,7 +999,7 @@ func (s *DataAccessTestSuite) TestAccessSyncer_SyncAccessProviderMasksToTarget_O
 
 	s.True(strings.HasPrefix(accessProviderFeedback[0].ActualName, fmt.Sprintf("RAITO_%s", strings.ToUpper(maskName))))
 
-	maskPolicies, err = s.sfRepo.GetPolicies("MASKING")
+	maskPolicies, err = s.sfRepo.GetPolicies(context.Background(), "MASKING")
 	s.NoError(err)
 	s.Contains(maskPolicies, snowflake.PolicyEntity{
 		Name:         fmt.Sprintf("%s_NUMBER", strings.ToUpper(accessProviderFeedback[0].ActualName)),
@@ -1025,7 +1025,7 @@ func (s *DataAccessTestSuite) TestAccessSyncer_SyncAccessProviderMasksToTarget_O
 
 	s.True(strings.HasPrefix(accessProviderFeedback[0].ActualName, fmt.Sprintf("RAITO_%s", strings.ToUpper(maskName))))
 
-	maskPolicies, err = s.sfRepo.GetPolicies("MASKING")
+	maskPolicies, err = s.sfRepo.GetPolicies(context.Background(), "MASKING")
 	s.NoError(err)
 	s.NotContains(maskPolicies, snowflake.PolicyEntity{
 		Name:         fmt.Sprintf("RAITO_%s_TEXT", strings.ToUpper(accessProviderFeedback[0].ActualName)),
@@ -1089,7 +1089,7 @@ func (s *DataAccessTestSuite) TestAccessSyncer_SyncAccessProviderMasksToTarget_O
 
 	s.True(strings.HasPrefix(accessProviderFeedback[0].ActualName, fmt.Sprintf("RAITO_%s", strings.ToUpper(maskName))))
 
-	maskPolicies, err := s.sfRepo.GetPolicies("MASKING")
+	maskPolicies, err := s.sfRepo.GetPolicies(context.Background(), "MASKING")
 	s.NoError(err)
 	s.Contains(maskPolicies, snowflake.PolicyEntity{
 		Name:         fmt.Sprintf("%s_NUMBER", strings.ToUpper(accessProviderFeedback[0].ActualName)),
@@ -1114,7 +1114,7 @@ func (s *DataAccessTestSuite) TestAccessSyncer_SyncAccessProviderMasksToTarget_O
 
 	s.True(strings.HasPrefix(accessProviderFeedback[0].ActualName, fmt.Sprintf("RAITO_%s", strings.ToUpper(maskName))))
 
-	maskPolicies, err = s.sfRepo.GetPolicies("MASKING")
+	maskPolicies, err = s.sfRepo.GetPolicies(context.Background(), "MASKING")
 	s.NoError(err)
 	s.Contains(maskPolicies, snowflake.PolicyEntity{
 		Name:         fmt.Sprintf("%s_NUMBER", strings.ToUpper(accessProviderFeedback[0].ActualName)),
@@ -1140,7 +1140,7 @@ func (s *DataAccessTestSuite) TestAccessSyncer_SyncAccessProviderMasksToTarget_O
 
 	s.True(strings.HasPrefix(accessProviderFeedback[0].ActualName, fmt.Sprintf("RAITO_%s", strings.ToUpper(maskName))))
 
-	maskPolicies, err = s.sfRepo.GetPolicies("MASKING")
+	maskPolicies, err = s.sfRepo.GetPolicies(context.Background(), "MASKING")
 	s.NoError(err)
 	s.NotContains(maskPolicies, snowflake.PolicyEntity{
 		Name:         fmt.Sprintf("RAITO_%s_TEXT", strings.ToUpper(accessProviderFeedback[0].ActualName)),
@@ -1220,7 +1220,7 @@ func (s *DataAccessTestSuite) TestAccessSyncer_SyncAccessProvidersToTarget_Share
 		},
 	}, accessProviderFeedback)
 
-	shares, err := s.sfRepo.GetOutboundShares()
+	shares, err := s.sfRepo.GetOutboundShares(context.Background())
 	s.NoError(err)
 
 	shareNames := make([]string, 0, len(shares))
@@ -1247,10 +1247,10 @@ func (s *DataAccessTestSuite) TestAccessSyncer_SyncAccessProvidersToTarget_Digit
 	config := s.getConfig()
 
 	// Create both roles directly
-	err := s.sfRepo.CreateAccountRole(targetRoleName)
+	err := s.sfRepo.CreateAccountRole(context.Background(), targetRoleName)
 	s.Require().NoError(err)
 
-	err = s.sfRepo.CreateAccountRole(granteeRoleName)
+	err = s.sfRepo.CreateAccountRole(context.Background(), granteeRoleName)
 	s.Require().NoError(err)
 
 	// Grant targetRole to granteeRole so that granteeRole appears in SHOW GRANTS OF ROLE targetRole
@@ -1258,7 +1258,7 @@ func (s *DataAccessTestSuite) TestAccessSyncer_SyncAccessProvidersToTarget_Digit
 	s.Require().NoError(err)
 
 	// Verify pre-condition: granteeRole is a grantee of targetRole
-	grants, err := s.sfRepo.GetGrantsOfAccountRole(targetRoleName)
+	grants, err := s.sfRepo.GetGrantsOfAccountRole(context.Background(), targetRoleName)
 	s.Require().NoError(err)
 	s.Require().Len(grants, 1)
 
@@ -1289,7 +1289,7 @@ func (s *DataAccessTestSuite) TestAccessSyncer_SyncAccessProvidersToTarget_Digit
 	s.Len(accessProviderFeedback[0].Errors, 0)
 
 	// Verify the grant was revoked
-	grants, err = s.sfRepo.GetGrantsOfAccountRole(targetRoleName)
+	grants, err = s.sfRepo.GetGrantsOfAccountRole(context.Background(), targetRoleName)
 	s.Require().NoError(err)
 	s.Empty(grants)
 }
@@ -1336,7 +1336,7 @@ func (s *DataAccessTestSuite) TestAccessSyncer_SyncToTarget_SharedDatabase_Revok
 	s.Len(accessProviderFeedback[0].Errors, 0)
 
 	// Verify IMPORTED PRIVILEGES was granted.
-	grants, err := s.sfRepo.GetGrantsToAccountRole(roleName)
+	grants, err := s.sfRepo.GetGrantsToAccountRole(context.Background(), roleName)
 	s.Require().NoError(err)
 
 	importedPrivilegesFound := false
@@ -1380,7 +1380,7 @@ func (s *DataAccessTestSuite) TestAccessSyncer_SyncToTarget_SharedDatabase_Revok
 	s.Len(accessProviderFeedback[0].Errors, 0)
 
 	// Verify IMPORTED PRIVILEGES was revoked.
-	grants, err = s.sfRepo.GetGrantsToAccountRole(roleName)
+	grants, err = s.sfRepo.GetGrantsToAccountRole(context.Background(), roleName)
 	s.Require().NoError(err)
 
 	for _, grant := range grants {
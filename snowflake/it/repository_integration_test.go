@@ -40,7 +40,7 @@ func TestRepositoryTestSuite(t *testing.T) {
 
 func (s *RepositoryTestSuite) TestSnowflakeRepository_GetAccountRoles() {
 	//When
-	roles, err := s.repo.GetAccountRoles()
+	roles, err := s.repo.GetAccountRoles(context.Background())
 
 	//Then
 	s.NoError(err)
@@ -61,7 +61,7 @@ func (s *RepositoryTestSuite) TestSnowflakeRepository_GetAccountRoles() {
 
 func (s *RepositoryTestSuite) TestSnowflakeRepository_GetAccountRolesWithPrefix() {
 	//When
-	roles, err := s.repo.GetAccountRolesWithPrefix("SYS")
+	roles, err := s.repo.GetAccountRolesWithPrefix(context.Background(), "SYS")
 
 	//Then
 	s.NoError(err)
@@ -85,12 +85,12 @@ func (s *RepositoryTestSuite) TestSnowflakeRepository_CreateAccountRole() {
 		roleName = fmt.Sprintf("%s_%s", testId, roleName)
 
 		//When
-		err := s.repo.CreateAccountRole(roleName)
+		err := s.repo.CreateAccountRole(context.Background(), roleName)
 
 		//Then
 		s.NoError(err)
 
-		roles, err := s.repo.GetAccountRolesWithPrefix(testId)
+		roles, err := s.repo.GetAccountRolesWithPrefix(context.Background(), testId)
 		s.NoError(err)
 		s.Contains(roles, snowflake.RoleEntity{
 			Name:            roleName,
@@ -115,15 +115,15 @@ func (s *RepositoryTestSuite) TestSnowflakeRepository_CreateAccountRole_Owner()
 	roleName := strings.ToUpper(fmt.Sprintf("%s_%s", testId, "CreateAccountRole_Owner"))
 
 	//When
-	err := s.repo.CreateAccountRole(roleName)
+	err := s.repo.CreateAccountRole(context.Background(), roleName)
 
 	//Then
 	s.Require().NoError(err)
 
-	err = s.repo.SetTagOnRole(roleName, "RAITO_DATABASE.PUBLIC.\"raito.io:owner\"", "email:test@raito.io", false)
+	err = s.repo.SetTagOnRole(context.Background(), roleName, "RAITO_DATABASE.PUBLIC.\"raito.io:owner\"", "email:test@raito.io", false)
 	s.Require().NoError(err)
 
-	tags, err := s.repo.GetDirectObjectTagValues("RAITO_DATABASE.PUBLIC.\"raito.io:owner\"", roleName, "role")
+	tags, err := s.repo.GetDirectObjectTagValues(context.Background(), "RAITO_DATABASE.PUBLIC.\"raito.io:owner\"", roleName, "role")
 	s.Require().NoError(err)
 
 	s.Len(tags, 1)
@@ -135,15 +135,15 @@ func (s *RepositoryTestSuite) TestSnowflakeRepository_CreateDatabaseRole_Owner()
 	roleName := strings.ToUpper(fmt.Sprintf("%s_%s", testId, "CreateDatabaseRole_Owner"))
 
 	//When
-	err := s.repo.CreateDatabaseRole("RAITO_DATABASE", roleName)
+	err := s.repo.CreateDatabaseRole(context.Background(), "RAITO_DATABASE", roleName)
 
 	//Then
 	s.Require().NoError(err)
 
-	err = s.repo.SetTagOnRole("RAITO_DATABASE."+roleName, "RAITO_DATABASE.PUBLIC.\"raito.io:owner\"", "email:test@raito.io", true)
+	err = s.repo.SetTagOnRole(context.Background(), "RAITO_DATABASE."+roleName, "RAITO_DATABASE.PUBLIC.\"raito.io:owner\"", "email:test@raito.io", true)
 	s.Require().NoError(err)
 
-	tags, err := s.repo.GetDirectObjectTagValues("RAITO_DATABASE.PUBLIC.\"raito.io:owner\"", "RAITO_DATABASE."+roleName, "database role")
+	tags, err := s.repo.GetDirectObjectTagValues(context.Background(), "RAITO_DATABASE.PUBLIC.\"raito.io:owner\"", "RAITO_DATABASE."+roleName, "database role")
 	s.Require().NoError(err)
 
 	s.Len(tags, 1)
@@ -154,15 +154,15 @@ func (s *RepositoryTestSuite) TestSnowflakeRepository_DropAccountRole() {
 	test := func(roleName string) {
 		//Given
 		roleName = fmt.Sprintf("%s_%s", testId, roleName)
-		err := s.repo.CreateAccountRole(roleName)
+		err := s.repo.CreateAccountRole(context.Background(), roleName)
 		s.NoError(err)
 
 		//When
-		err = s.repo.DropAccountRole(roleName)
+		err = s.repo.DropAccountRole(context.Background(), roleName)
 
 		//Then
 		s.NoError(err)
-		roles, err := s.repo.GetAccountRolesWithPrefix(testId)
+		roles, err := s.repo.GetAccountRolesWithPrefix(context.Background(), testId)
 		s.NoError(err)
 
 		roleNames := make([]string, 0, len(roles))
@@ -189,15 +189,15 @@ func (s *RepositoryTestSuite) TestSnowflakeRepository_RenameAccountRole() {
 		originalRoleName = fmt.Sprintf("%s_%s", testId, originalRoleName)
 		newExpectedRoleName := fmt.Sprintf("%s_%s", testId, newRoleName)
 
-		err := s.repo.CreateAccountRole(originalRoleName)
+		err := s.repo.CreateAccountRole(context.Background(), originalRoleName)
 		s.NoError(err)
 
 		//When
-		err = s.repo.RenameAccountRole(originalRoleName, newExpectedRoleName)
+		err = s.repo.RenameAccountRole(context.Background(), originalRoleName, newExpectedRoleName)
 
 		//Then
 		s.NoError(err)
-		roles, err := s.repo.GetAccountRolesWithPrefix(testId)
+		roles, err := s.repo.GetAccountRolesWithPrefix(context.Background(), testId)
 		s.NoError(err)
 
 		roleNames := make([]string, 0, len(roles))
@@ -220,7 +220,7 @@ func (s *RepositoryTestSuite) TestSnowflakeRepository_RenameAccountRole() {
 
 func (s *RepositoryTestSuite) TestSnowflakeRepository_GetGrantsToAccountRole() {
 	//When
-	grantsToRole, err := s.repo.GetGrantsToAccountRole("DATA_ANALYST")
+	grantsToRole, err := s.repo.GetGrantsToAccountRole(context.Background(), "DATA_ANALYST")
 
 	//Then
 	s.NoError(err)
@@ -244,7 +244,7 @@ func (s *RepositoryTestSuite) TestSnowflakeRepository_GetGrantsToAccountRole() {
 
 func (s *RepositoryTestSuite) TestSnowflakeRepository_GetGrantsOfAccountRole() {
 	//When
-	grantsOfRolePublic, err := s.repo.GetGrantsOfAccountRole("ACCOUNTADMIN")
+	grantsOfRolePublic, err := s.repo.GetGrantsOfAccountRole(context.Background(), "ACCOUNTADMIN")
 
 	//Then
 	s.NoError(err)
@@ -265,7 +265,7 @@ func (s *RepositoryTestSuite) TestSnowflakeRepository_GrantAccountRolesToAccount
 			rolesToGrants = append(rolesToGrants, fmt.Sprintf("%s_%s_%d", testId, roleName, i))
 		}
 
-		err := s.repo.CreateAccountRole(originalRoleName)
+		err := s.repo.CreateAccountRole(context.Background(), originalRoleName)
 		s.NoError(err)
 
 		//When
@@ -274,7 +274,7 @@ func (s *RepositoryTestSuite) TestSnowflakeRepository_GrantAccountRolesToAccount
 		//Then
 		s.NoError(err)
 
-		grants, err := s.repo.GetGrantsOfAccountRole(originalRoleName)
+		grants, err := s.repo.GetGrantsOfAccountRole(context.Background(), originalRoleName)
 		s.NoError(err)
 
 		expectedGrants := make([]snowflake.GrantOfRole, 0, len(grants))
@@ -308,7 +308,7 @@ func (s *RepositoryTestSuite) TestSnowflakeRepository_RevokeAccountRolesFromAcco
 			rolesToGrants = append(rolesToGrants, fmt.Sprintf("%s_%s_%d", testId, roleName, i))
 		}
 
-		err := s.repo.CreateAccountRole(originalRoleName)
+		err := s.repo.CreateAccountRole(context.Background(), originalRoleName)
 		s.NoError(err)
 
 		err = s.repo.GrantAccountRolesToAccountRole(context.Background(), originalRoleName, rolesToGrants...)
@@ -320,7 +320,7 @@ func (s *RepositoryTestSuite) TestSnowflakeRepository_RevokeAccountRolesFromAcco
 		//Then
 		s.NoError(err)
 
-		grants, err := s.repo.GetGrantsOfAccountRole(originalRoleName)
+		grants, err := s.repo.GetGrantsOfAccountRole(context.Background(), originalRoleName)
 		s.NoError(err)
 		s.Empty(grants)
 	}
@@ -338,7 +338,7 @@ func (s *RepositoryTestSuite) TestSnowflakeRepository_GrantUsersToAccountRole()
 	test := func(roleName string) {
 		//Given
 		roleName = fmt.Sprintf("%s_%s", testId, roleName)
-		err := s.repo.CreateAccountRole(roleName)
+		err := s.repo.CreateAccountRole(context.Background(), roleName)
 
 		s.NoError(err)
 
@@ -348,7 +348,7 @@ func (s *RepositoryTestSuite) TestSnowflakeRepository_GrantUsersToAccountRole()
 		//Then
 		s.NoError(err)
 
-		grants, err := s.repo.GetGrantsOfAccountRole(roleName)
+		grants, err := s.repo.GetGrantsOfAccountRole(context.Background(), roleName)
 		s.NoError(err)
 
 		s.Equal(grants, []snowflake.GrantOfRole{
@@ -372,7 +372,7 @@ func (s *RepositoryTestSuite) TestSnowflakeRepository_RevokeUsersFromAccountRole
 	test := func(roleName string) {
 		//Given
 		roleName = fmt.Sprintf("%s_%s", testId, roleName)
-		err := s.repo.CreateAccountRole(roleName)
+		err := s.repo.CreateAccountRole(context.Background(), roleName)
 		s.NoError(err)
 
 		err = s.repo.GrantUsersToAccountRole(context.Background(), roleName, snowflakeUserName)
@@ -384,7 +384,7 @@ func (s *RepositoryTestSuite) TestSnowflakeRepository_RevokeUsersFromAccountRole
 		//Then
 		s.NoError(err)
 
-		grants, err := s.repo.GetGrantsOfAccountRole(roleName)
+		grants, err := s.repo.GetGrantsOfAccountRole(context.Background(), roleName)
 		s.NoError(err)
 		s.Empty(grants)
 	}
@@ -402,16 +402,16 @@ func (s *RepositoryTestSuite) TestSnowflakeRepository_ExecuteGrantOnAccountRole(
 	test := func(roleName string, on string, onType string) {
 		//Given
 		roleName = fmt.Sprintf("%s_%s", testId, roleName)
-		err := s.repo.CreateAccountRole(roleName)
+		err := s.repo.CreateAccountRole(context.Background(), roleName)
 		s.NoError(err)
 
 		//When
-		err = s.repo.ExecuteGrantOnAccountRole("SELECT", on, roleName, true)
+		err = s.repo.ExecuteGrantOnAccountRole(context.Background(), "SELECT", on, roleName, true)
 
 		//Then
 		s.NoError(err)
 
-		grantsTo, err := s.repo.GetGrantsToAccountRole(roleName)
+		grantsTo, err := s.repo.GetGrantsToAccountRole(context.Background(), roleName)
 		s.NoError(err)
 
 		s.Equal([]snowflake.GrantToRole{
@@ -436,18 +436,18 @@ func (s *RepositoryTestSuite) TestSnowflakeRepository_ExecuteRevokeOnAccountRole
 	test := func(roleName string, on string) {
 		//Given
 		roleName = fmt.Sprintf("%s_%s", testId, roleName)
-		err := s.repo.CreateAccountRole(roleName)
+		err := s.repo.CreateAccountRole(context.Background(), roleName)
 		s.NoError(err)
-		err = s.repo.ExecuteGrantOnAccountRole("SELECT", on, roleName, true)
+		err = s.repo.ExecuteGrantOnAccountRole(context.Background(), "SELECT", on, roleName, true)
 		s.NoError(err)
 
 		//When
-		err = s.repo.ExecuteRevokeOnAccountRole("SELECT", on, roleName, true)
+		err = s.repo.ExecuteRevokeOnAccountRole(context.Background(), "SELECT", on, roleName, true)
 
 		//Then
 		s.NoError(err)
 
-		grantsTo, err := s.repo.GetGrantsToAccountRole(roleName)
+		grantsTo, err := s.repo.GetGrantsToAccountRole(context.Background(), roleName)
 		s.NoError(err)
 		s.Empty(grantsTo)
 	}
@@ -463,7 +463,7 @@ func (s *RepositoryTestSuite) TestSnowflakeRepository_ExecuteRevokeOnAccountRole
 
 func (s *RepositoryTestSuite) TestSnowflakeRepository_GetDatabaseRolesWithPrefix() {
 	//When
-	roles, err := s.repo.GetDatabaseRolesWithPrefix("RAITO_DATABASE", "RAITO_DB_ROLE_")
+	roles, err := s.repo.GetDatabaseRolesWithPrefix(context.Background(), "RAITO_DATABASE", "RAITO_DB_ROLE_")
 
 	//Then
 	s.NoError(err)
@@ -488,12 +488,12 @@ func (s *RepositoryTestSuite) TestSnowflakeRepository_CreateDatabaseRole() {
 		database := "RAITO_DATABASE"
 
 		//When
-		err := s.repo.CreateDatabaseRole(database, roleName)
+		err := s.repo.CreateDatabaseRole(context.Background(), database, roleName)
 
 		//Then
 		s.NoError(err)
 
-		roles, err := s.repo.GetDatabaseRolesWithPrefix(database, testId)
+		roles, err := s.repo.GetDatabaseRolesWithPrefix(context.Background(), database, testId)
 		s.NoError(err)
 		s.Contains(roles, snowflake.RoleEntity{
 			Name:            roleName,
@@ -520,11 +520,11 @@ func (s *RepositoryTestSuite) TestSnowflakeRepository_DropDatabaseRole() {
 		database := "RAITO_DATABASE"
 
 		//When
-		err := s.repo.CreateDatabaseRole(database, roleName)
+		err := s.repo.CreateDatabaseRole(context.Background(), database, roleName)
 		s.NoError(err)
 
 		//Then
-		roles, err := s.repo.GetDatabaseRolesWithPrefix(database, testId)
+		roles, err := s.repo.GetDatabaseRolesWithPrefix(context.Background(), database, testId)
 		s.NoError(err)
 
 		roleNames := make([]string, 0, len(roles))
@@ -535,11 +535,11 @@ func (s *RepositoryTestSuite) TestSnowflakeRepository_DropDatabaseRole() {
 		s.Contains(roleNames, roleName)
 
 		//When
-		err = s.repo.DropDatabaseRole(database, roleName)
+		err = s.repo.DropDatabaseRole(context.Background(), database, roleName)
 
 		//Then
 		s.NoError(err)
-		roles, err = s.repo.GetDatabaseRolesWithPrefix(database, testId)
+		roles, err = s.repo.GetDatabaseRolesWithPrefix(context.Background(), database, testId)
 		s.NoError(err)
 
 		roleNames = make([]string, 0, len(roles))
@@ -565,15 +565,15 @@ func (s *RepositoryTestSuite) TestSnowflakeRepository_RenameDatabaseRole() {
 		originalRoleName = fmt.Sprintf("%s_%s", testId, originalRoleName)
 		newExpectedRoleName := fmt.Sprintf("%s_%s", testId, newRoleName)
 		database := "RAITO_DATABASE"
-		err := s.repo.CreateDatabaseRole(database, originalRoleName)
+		err := s.repo.CreateDatabaseRole(context.Background(), database, originalRoleName)
 		s.NoError(err)
 
 		//When
-		err = s.repo.RenameDatabaseRole(database, originalRoleName, newExpectedRoleName)
+		err = s.repo.RenameDatabaseRole(context.Background(), database, originalRoleName, newExpectedRoleName)
 
 		//Then
 		s.NoError(err)
-		roles, err := s.repo.GetDatabaseRolesWithPrefix(database, testId)
+		roles, err := s.repo.GetDatabaseRolesWithPrefix(context.Background(), database, testId)
 		s.NoError(err)
 
 		roleNames := make([]string, 0, len(roles))
@@ -596,7 +596,7 @@ func (s *RepositoryTestSuite) TestSnowflakeRepository_RenameDatabaseRole() {
 
 func (s *RepositoryTestSuite) TestSnowflakeRepository_GetUsers() {
 	//When
-	users, err := s.repo.GetUsers()
+	users, err := s.repo.GetUsers(context.Background())
 
 	//Then
 	s.NoError(err)
@@ -627,7 +627,7 @@ func (s *RepositoryTestSuite) TestSnowflakeRepository_GetGrantsToDatabaseRole()
 	database := "RAITO_DATABASE"
 
 	//When
-	grantsToRole, err := s.repo.GetGrantsToDatabaseRole(database, roleName)
+	grantsToRole, err := s.repo.GetGrantsToDatabaseRole(context.Background(), database, roleName)
 
 	//Then
 	s.NoError(err)
@@ -651,7 +651,7 @@ func (s *RepositoryTestSuite) TestSnowflakeRepository_GetGrantsOfDatabaseRole()
 	database := "RAITO_DATABASE"
 
 	//When
-	grantsOfRole, err := s.repo.GetGrantsOfDatabaseRole(database, roleName)
+	grantsOfRole, err := s.repo.GetGrantsOfDatabaseRole(context.Background(), database, roleName)
 
 	//Then
 	s.NoError(err)
@@ -674,7 +674,7 @@ func (s *RepositoryTestSuite) TestSnowflakeRepository_GrantAccountRolesToDatabas
 		accountRolesToGrant = append(accountRolesToGrant, fmt.Sprintf("%s_REPO_TEST_GRANT_R2DBR_%d", testId, i))
 	}
 
-	err := s.repo.CreateDatabaseRole(database, originalRoleName)
+	err := s.repo.CreateDatabaseRole(context.Background(), database, originalRoleName)
 	s.NoError(err)
 
 	//When
@@ -683,7 +683,7 @@ func (s *RepositoryTestSuite) TestSnowflakeRepository_GrantAccountRolesToDatabas
 	//Then
 	s.NoError(err)
 
-	grants, err := s.repo.GetGrantsOfDatabaseRole(database, originalRoleName)
+	grants, err := s.repo.GetGrantsOfDatabaseRole(context.Background(), database, originalRoleName)
 	s.NoError(err)
 
 	expectedGrants := make([]snowflake.GrantOfRole, 0, len(grants))
@@ -710,7 +710,7 @@ func (s *RepositoryTestSuite) TestSnowflakeRepository_GrantDatabaseRolesToDataba
 		databaseRolesToGrant = append(databaseRolesToGrant, fmt.Sprintf("%s_REPO_TEST_GRANT_DBR2DBR_%d", testId, i))
 	}
 
-	err := s.repo.CreateDatabaseRole(database, originalRoleName)
+	err := s.repo.CreateDatabaseRole(context.Background(), database, originalRoleName)
 	s.NoError(err)
 
 	//When
@@ -719,7 +719,7 @@ func (s *RepositoryTestSuite) TestSnowflakeRepository_GrantDatabaseRolesToDataba
 	//Then
 	s.NoError(err)
 
-	grants, err := s.repo.GetGrantsOfDatabaseRole(database, originalRoleName)
+	grants, err := s.repo.GetGrantsOfDatabaseRole(context.Background(), database, originalRoleName)
 	s.NoError(err)
 
 	expectedGrants := make([]snowflake.GrantOfRole, 0, len(grants))
@@ -744,7 +744,7 @@ func (s *RepositoryTestSuite) TestSnowflakeRepository_RevokeAccountRolesFromData
 		accountRolesToGrants = append(accountRolesToGrants, fmt.Sprintf("%s_REPO_TEST_REVOKE_R2DBR_%d", testId, i))
 	}
 
-	err := s.repo.CreateDatabaseRole(database, originalRoleName)
+	err := s.repo.CreateDatabaseRole(context.Background(), database, originalRoleName)
 	s.NoError(err)
 
 	err = s.repo.GrantAccountRolesToDatabaseRole(context.Background(), database, originalRoleName, accountRolesToGrants...)
@@ -756,7 +756,7 @@ func (s *RepositoryTestSuite) TestSnowflakeRepository_RevokeAccountRolesFromData
 	//Then
 	s.NoError(err)
 
-	grants, err := s.repo.GetGrantsOfDatabaseRole(database, originalRoleName)
+	grants, err := s.repo.GetGrantsOfDatabaseRole(context.Background(), database, originalRoleName)
 	s.NoError(err)
 	s.Empty(grants)
 }
@@ -771,7 +771,7 @@ func (s *RepositoryTestSuite) TestSnowflakeRepository_RevokeDatabaseRolesFromDat
 		databaseRolesToGrants = append(databaseRolesToGrants, fmt.Sprintf("%s_REPO_TEST_REVOKE_DBR2DBR_%d", testId, i))
 	}
 
-	err := s.repo.CreateDatabaseRole(database, originalRoleName)
+	err := s.repo.CreateDatabaseRole(context.Background(), database, originalRoleName)
 	s.NoError(err)
 
 	err = s.repo.GrantDatabaseRolesToDatabaseRole(context.Background(), database, originalRoleName, databaseRolesToGrants...)
@@ -783,7 +783,7 @@ func (s *RepositoryTestSuite) TestSnowflakeRepository_RevokeDatabaseRolesFromDat
 	//Then
 	s.NoError(err)
 
-	grants, err := s.repo.GetGrantsOfDatabaseRole(database, originalRoleName)
+	grants, err := s.repo.GetGrantsOfDatabaseRole(context.Background(), database, originalRoleName)
 	s.NoError(err)
 	s.Empty(grants)
 }
@@ -792,16 +792,16 @@ func (s *RepositoryTestSuite) TestSnowflakeRepository_ExecuteGrantOnDatabaseRole
 	//Given
 	database := "RAITO_DATABASE"
 	roleName := fmt.Sprintf("%s_REPO_TEST_EXECUTE_GRANT_DATABASEROLE_TEST", testId)
-	err := s.repo.CreateDatabaseRole(database, roleName)
+	err := s.repo.CreateDatabaseRole(context.Background(), database, roleName)
 	s.NoError(err)
 
 	//When
-	err = s.repo.ExecuteGrantOnDatabaseRole("SELECT", "RAITO_DATABASE.ORDERING.ORDERS", database, roleName)
+	err = s.repo.ExecuteGrantOnDatabaseRole(context.Background(), "SELECT", "RAITO_DATABASE.ORDERING.ORDERS", database, roleName)
 
 	//Then
 	s.NoError(err)
 
-	grantsTo, err := s.repo.GetGrantsToDatabaseRole(database, roleName)
+	grantsTo, err := s.repo.GetGrantsToDatabaseRole(context.Background(), database, roleName)
 	s.NoError(err)
 
 	s.Equal(grantsTo, []snowflake.GrantToRole{
@@ -822,18 +822,18 @@ func (s *RepositoryTestSuite) TestSnowflakeRepository_ExecuteRevokeOnDatabaseRol
 	//Given
 	database := "RAITO_DATABASE"
 	roleName := fmt.Sprintf("%s_REPO_TEST_EXECUTE_REVOKE_DATABASEROLE_TEST", testId)
-	err := s.repo.CreateDatabaseRole(database, roleName)
+	err := s.repo.CreateDatabaseRole(context.Background(), database, roleName)
 	s.NoError(err)
-	err = s.repo.ExecuteGrantOnDatabaseRole("SELECT", "RAITO_DATABASE.ORDERING.ORDERS", database, roleName)
+	err = s.repo.ExecuteGrantOnDatabaseRole(context.Background(), "SELECT", "RAITO_DATABASE.ORDERING.ORDERS", database, roleName)
 	s.NoError(err)
 
 	//When
-	err = s.repo.ExecuteRevokeOnDatabaseRole("SELECT", "RAITO_DATABASE.ORDERING.ORDERS", database, roleName)
+	err = s.repo.ExecuteRevokeOnDatabaseRole(context.Background(), "SELECT", "RAITO_DATABASE.ORDERING.ORDERS", database, roleName)
 
 	//Then
 	s.NoError(err)
 
-	grantsTo, err := s.repo.GetGrantsToDatabaseRole(database, roleName)
+	grantsTo, err := s.repo.GetGrantsToDatabaseRole(context.Background(), database, roleName)
 	s.NoError(err)
 	s.Equal(grantsTo, []snowflake.GrantToRole{
 		{
@@ -850,7 +850,7 @@ func (s *RepositoryTestSuite) TestSnowflakeRepository_GetPolicies() {
 	}
 
 	//When
-	policies, err := s.repo.GetPolicies("MASKING")
+	policies, err := s.repo.GetPolicies(context.Background(), "MASKING")
 
 	//Then
 	require.NoError(s.T(), err)
@@ -869,7 +869,7 @@ func (s *RepositoryTestSuite) TestSnowflakeRepository_DescribePolicy() {
 		s.T().Skip("Standard edition do not support masking policies")
 	}
 
-	entries, err := s.repo.DescribePolicy("MASKING", "RAITO_DATABASE", "ORDERING", "ORDERING_MASKING_POLICY")
+	entries, err := s.repo.DescribePolicy(context.Background(), "MASKING", "RAITO_DATABASE", "ORDERING", "ORDERING_MASKING_POLICY")
 	assert.NoError(s.T(), err)
 
 	assert.Len(s.T(), entries, 1)
@@ -881,7 +881,7 @@ func (s *RepositoryTestSuite) TestSnowflakeRepository_GetPolicyReferences() {
 		s.T().Skip("Standard edition do not support policy references")
 	}
 
-	entries, err := s.repo.GetPolicyReferences("RAITO_DATABASE", "ORDERING", "ORDERING_MASKING_POLICY")
+	entries, err := s.repo.GetPolicyReferences(context.Background(), "RAITO_DATABASE", "ORDERING", "ORDERING_MASKING_POLICY")
 	assert.NoError(s.T(), err)
 
 	assert.Len(s.T(), entries, 1)
@@ -906,7 +906,7 @@ func (s *RepositoryTestSuite) TestSnowflakeRepository_GetPolicyReferences() {
 
 func (s *RepositoryTestSuite) TestSnowflakeRepository_GetSnowFlakeAccountName() {
 	//When
-	accountName, err := s.repo.GetSnowFlakeAccountName()
+	accountName, err := s.repo.GetSnowFlakeAccountName(context.Background())
 
 	//Then
 	s.NoError(err)
@@ -915,7 +915,7 @@ func (s *RepositoryTestSuite) TestSnowflakeRepository_GetSnowFlakeAccountName()
 
 func (s *RepositoryTestSuite) TestSnowflakeRepository_GetWarehouses() {
 	//When
-	warehouses, err := s.repo.GetWarehouses()
+	warehouses, err := s.repo.GetWarehouses(context.Background())
 
 	//Then
 	s.NoError(err)
@@ -931,7 +931,7 @@ func (s *RepositoryTestSuite) TestSnowflakeRepository_GetWarehouses() {
 
 func (s *RepositoryTestSuite) TestSnowflakeRepository_GetShares() {
 	//When
-	shares, err := s.repo.GetInboundShares()
+	shares, err := s.repo.GetInboundShares(context.Background())
 
 	//Then
 	s.NoError(err)
@@ -950,7 +950,7 @@ func (s *RepositoryTestSuite) TestSnowflakeRepository_GetShares() {
 
 func (s *RepositoryTestSuite) TestSnowflakeRepository_GetDatabases() {
 	//When
-	databases, err := s.repo.GetDatabases()
+	databases, err := s.repo.GetDatabases(context.Background())
 
 	//Then
 	s.NoError(err)
@@ -975,7 +975,7 @@ func (s *RepositoryTestSuite) TestSnowflakeRepository_GetSchemasInDatabase() {
 
 	//When
 	schemas := make([]snowflake.SchemaEntity, 0)
-	err := s.repo.GetSchemasInDatabase(database, func(entity interface{}) error {
+	err := s.repo.GetSchemasInDatabase(context.Background(), database, func(entity interface{}) error {
 		schemas = append(schemas, *entity.(*snowflake.SchemaEntity))
 		return nil
 	})
@@ -1014,7 +1014,7 @@ func (s *RepositoryTestSuite) TestSnowflakeRepository_GetTablesInSchema() {
 
 	//When
 	tables := make([]snowflake.TableEntity, 0)
-	err := s.repo.GetTablesInDatabase(database, schema, func(entity interface{}) error {
+	err := s.repo.GetTablesInDatabase(context.Background(), database, schema, func(entity interface{}) error {
 		tables = append(tables, *entity.(*snowflake.TableEntity))
 		return nil
 	})
@@ -1074,7 +1074,7 @@ func (s *RepositoryTestSuite) TestSnowflakeRepository_GetColumnsInTable() {
 
 	//When
 	columns := make([]snowflake.ColumnEntity, 0)
-	err := s.repo.GetColumnsInDatabase(database, func(entity interface{}) error {
+	err := s.repo.GetColumnsInDatabase(context.Background(), database, func(entity interface{}) error {
 		column := entity.(*snowflake.ColumnEntity)
 		if column.Schema == schema && column.Table == table {
 			columns = append(columns, *column)
@@ -1167,7 +1167,7 @@ func (s *RepositoryTestSuite) TestSnowflakeRepository_CommentAccountRoleIfExists
 	roleName := fmt.Sprintf("%s_REPO_TEST_COMMENT_NON_EXISTING_ROLE", testId)
 
 	//When
-	err := s.repo.CommentAccountRoleIfExists("SomeComment", roleName)
+	err := s.repo.CommentAccountRoleIfExists(context.Background(), "SomeComment", roleName)
 
 	//Then
 	s.NoError(err)
@@ -1176,12 +1176,12 @@ func (s *RepositoryTestSuite) TestSnowflakeRepository_CommentAccountRoleIfExists
 func (s *RepositoryTestSuite) TestSnowflakeRepository_CommentAccountRoleIfExists_Role() {
 	//Given
 	roleName := fmt.Sprintf("%s_REPO_TEST_COMMENT_EXISTING_ROLE", testId)
-	err := s.repo.CreateAccountRole(roleName)
+	err := s.repo.CreateAccountRole(context.Background(), roleName)
 
 	comment := "Some comment"
 
 	//When
-	err = s.repo.CommentAccountRoleIfExists(comment, roleName)
+	err = s.repo.CommentAccountRoleIfExists(context.Background(), comment, roleName)
 
 	//Then
 	s.NoError(err)
@@ -1193,7 +1193,7 @@ func (s *RepositoryTestSuite) TestSnowflakeRepository_CommentDatabaseRoleIfExist
 	roleName := fmt.Sprintf("%s_REPO_TEST_COMMENT_NON_EXISTING_DB_ROLE", testId)
 
 	//When
-	err := s.repo.CommentDatabaseRoleIfExists("SomeComment", database, roleName)
+	err := s.repo.CommentDatabaseRoleIfExists(context.Background(), "SomeComment", database, roleName)
 
 	//Then
 	s.NoError(err)
@@ -1203,12 +1203,12 @@ func (s *RepositoryTestSuite) TestSnowflakeRepository_CommentDatabaseRoleIfExist
 	//Given
 	database := "SNOWFLAKE_INTEGRATION_TEST"
 	roleName := fmt.Sprintf("%s_REPO_TEST_COMMENT_EXISTING_DB_ROLE", testId)
-	err := s.repo.CreateDatabaseRole(database, roleName)
+	err := s.repo.CreateDatabaseRole(context.Background(), database, roleName)
 
 	comment := "Some comment"
 
 	//When
-	err = s.repo.CommentDatabaseRoleIfExists(comment, database, roleName)
+	err = s.repo.CommentDatabaseRoleIfExists(context.Background(), comment, database, roleName)
 
 	//Then
 	s.NoError(err)
@@ -1232,23 +1232,23 @@ func (s *RepositoryTestSuite) TestSnowflakeRepository_CreateMaskPolicy() {
 	maskName := strings.Join([]string{"MaskingTest", gonanoid.MustGenerate("0123456789ABCDEF", 8)}, "_")
 
 	// When
-	err := s.repo.CreateMaskPolicy(database, schema, maskName, []string{fmt.Sprintf("%s.%s.%s.%s", database, schema, table, column)}, ptr.String("NULL_MASK"), &beneficiary)
+	err := s.repo.CreateMaskPolicy(context.Background(), database, schema, maskName, []string{fmt.Sprintf("%s.%s.%s.%s", database, schema, table, column)}, ptr.String("NULL_MASK"), &beneficiary)
 
 	// Then
 	require.NoError(s.T(), err)
 
-	policyEntries, err := s.repo.GetPoliciesLike("MASKING", fmt.Sprintf("%s%s", maskName, "%"))
+	policyEntries, err := s.repo.GetPoliciesLike(context.Background(), "MASKING", fmt.Sprintf("%s%s", maskName, "%"))
 	require.NoError(s.T(), err)
 	require.Len(s.T(), policyEntries, 1)
 	assert.True(s.T(), strings.HasPrefix(policyEntries[0].Name, strings.ToUpper(maskName)))
 
 	// When
-	err = s.repo.DropMaskingPolicy(database, schema, maskName)
+	err = s.repo.DropMaskingPolicy(context.Background(), database, schema, maskName)
 
 	// Then
 	require.NoError(s.T(), err)
 
-	policyEntries, err = s.repo.GetPoliciesLike("MASKING", fmt.Sprintf("%s%s", maskName, "%"))
+	policyEntries, err = s.repo.GetPoliciesLike(context.Background(), "MASKING", fmt.Sprintf("%s%s", maskName, "%"))
 	require.NoError(s.T(), err)
 	assert.Len(s.T(), policyEntries, 0)
 }
@@ -0,0 +1,327 @@
+package snowflake
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	statementKindUsage = "USAGE QUERY"
+	statementKindMixed = "MIXED BATCH"
+
+	metricsPercentile = 0.95
+)
+
+// statementKindObjectTypes are the object types of DDL statements that consist of multiple words
+var statementKindObjectTypes = []string{"MASKING POLICY", "ROW ACCESS POLICY", "DATABASE ROLE", "APPLICATION ROLE"}
+
+// statementKindModifiers are skipped when determining the object type of DDL statements, e.g. COMMENT IF EXISTS ON ROLE ...
+var statementKindModifiers = map[string]struct{}{"IF": {}, "NOT": {}, "EXISTS": {}, "SECURE": {}, "ON": {}}
+
+// statementKindShowTerminators end the object type of SHOW statements, e.g. SHOW GRANTS TO ROLE ...
+var statementKindShowTerminators = map[string]struct{}{"IN": {}, "TO": {}, "OF": {}, "ON": {}, "LIKE": {}, "LIMIT": {}, "STARTS": {}, "FROM": {}}
+
+// statementMetrics are the metrics of all statements of one kind executed during a sync phase
+type statementMetrics struct {
+	count     int
+	errors    int
+	total     time.Duration
+	durations []time.Duration
+}
+
+// StatementMetricsCollector keeps track of the statements executed against Snowflake per sync phase and statement kind.
+type StatementMetricsCollector struct {
+	mutex   sync.Mutex
+	metrics map[SyncPhase]map[string]*statementMetrics
+}
+
+// statementMetricsCollector collects the metrics of all repositories created during this run of the plugin
+var statementMetricsCollector = NewStatementMetricsCollector()
+
+func NewStatementMetricsCollector() *StatementMetricsCollector {
+	return &StatementMetricsCollector{metrics: make(map[SyncPhase]map[string]*statementMetrics)}
+}
+
+func (c *StatementMetricsCollector) record(phase SyncPhase, statements []string, duration time.Duration, err error) {
+	kind := statementKindOfBatch(statements)
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	phaseMetrics, found := c.metrics[phase]
+	if !found {
+		phaseMetrics = make(map[string]*statementMetrics)
+		c.metrics[phase] = phaseMetrics
+	}
+
+	m, found := phaseMetrics[kind]
+	if !found {
+		m = &statementMetrics{}
+		phaseMetrics[kind] = m
+	}
+
+	m.count++
+	m.total += duration
+	m.durations = append(m.durations, duration)
+
+	if err != nil {
+		m.errors++
+	}
+}
+
+// StatementMetricsSummary is the summary of the metrics of one statement kind executed during a sync phase
+type StatementMetricsSummary struct {
+	Syncer       SyncPhase `json:"syncer"`
+	Statement    string    `json:"statement"`
+	Count        int       `json:"count"`
+	Errors       int       `json:"errors"`
+	TotalSeconds float64   `json:"total_seconds"`
+	P95Seconds   float64   `json:"p95_seconds"`
+}
+
+// Summary returns the summary of all collected metrics, sorted by syncer and statement kind.
+func (c *StatementMetricsCollector) Summary() []StatementMetricsSummary {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	result := make([]StatementMetricsSummary, 0)
+
+	for phase, phaseMetrics := range c.metrics {
+		for kind, m := range phaseMetrics {
+			result = append(result, StatementMetricsSummary{
+				Syncer:       phase,
+				Statement:    kind,
+				Count:        m.count,
+				Errors:       m.errors,
+				TotalSeconds: m.total.Seconds(),
+				P95Seconds:   percentile(m.durations, metricsPercentile).Seconds(),
+			})
+		}
+	}
+
+	slices.SortFunc(result, func(a, b StatementMetricsSummary) int {
+		if a.Syncer != b.Syncer {
+			return strings.Compare(string(a.Syncer), string(b.Syncer))
+		}
+
+		return strings.Compare(a.Statement, b.Statement)
+	})
+
+	return result
+}
+
+// percentile returns the nearest-rank percentile of the durations
+func percentile(durations []time.Duration, p float64) time.Duration {
+	if len(durations) == 0 {
+		return 0
+	}
+
+	sorted := slices.Clone(durations)
+	slices.Sort(sorted)
+
+	rank := int(math.Ceil(p*float64(len(sorted)))) - 1
+
+	return sorted[max(rank, 0)]
+}
+
+type statementMetricsReport struct {
+	RunId       string                    `json:"run_id"`
+	Version     string                    `json:"version"`
+	GeneratedAt time.Time                 `json:"generated_at"`
+	Statements  []StatementMetricsSummary `json:"statements"`
+}
+
+// WriteJSON writes the summary of the collected metrics as JSON to the given file.
+func (c *StatementMetricsCollector) WriteJSON(path string) error {
+	report := statementMetricsReport{
+		RunId:       runId,
+		Version:     PluginVersion,
+		GeneratedAt: time.Now().UTC(),
+		Statements:  c.Summary(),
+	}
+
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal metrics: %w", err)
+	}
+
+	return writeFileAtomically(path, data)
+}
+
+// WritePrometheus writes the collected metrics in the Prometheus text exposition format to the given file.
+func (c *StatementMetricsCollector) WritePrometheus(path string) error {
+	summary := c.Summary()
+
+	var sb strings.Builder
+
+	sb.WriteString("# HELP raito_snowflake_statement_duration_seconds Duration of the statements executed in Snowflake.\n")
+	sb.WriteString("# TYPE raito_snowflake_statement_duration_seconds summary\n")
+
+	for _, s := range summary {
+		labels := prometheusLabels(s)
+		sb.WriteString(fmt.Sprintf("raito_snowflake_statement_duration_seconds{%s,quantile=\"%g\"} %g\n", labels, metricsPercentile, s.P95Seconds))
+		sb.WriteString(fmt.Sprintf("raito_snowflake_statement_duration_seconds_sum{%s} %g\n", labels, s.TotalSeconds))
+		sb.WriteString(fmt.Sprintf("raito_snowflake_statement_duration_seconds_count{%s} %d\n", labels, s.Count))
+	}
+
+	sb.WriteString("# HELP raito_snowflake_statement_errors_total Number of statements that failed in Snowflake.\n")
+	sb.WriteString("# TYPE raito_snowflake_statement_errors_total counter\n")
+
+	for _, s := range summary {
+		sb.WriteString(fmt.Sprintf("raito_snowflake_statement_errors_total{%s} %d\n", prometheusLabels(s), s.Errors))
+	}
+
+	return writeFileAtomically(path, []byte(sb.String()))
+}
+
+func prometheusLabels(s StatementMetricsSummary) string {
+	return fmt.Sprintf("syncer=%q,statement=%q", s.Syncer, s.Statement)
+}
+
+// writeFileAtomically makes sure a scraper never reads a partially written file
+func writeFileAtomically(path string, data []byte) error {
+	tmpFile, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp*")
+	if err != nil {
+		return fmt.Errorf("create temporary file: %w", err)
+	}
+
+	defer os.Remove(tmpFile.Name())
+
+	_, err = tmpFile.Write(data)
+	if closeErr := tmpFile.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		return fmt.Errorf("write %q: %w", tmpFile.Name(), err)
+	}
+
+	err = os.Rename(tmpFile.Name(), path)
+	if err != nil {
+		return fmt.Errorf("rename to %q: %w", path, err)
+	}
+
+	return nil
+}
+
+// writeStatementMetrics writes the metrics collected so far to the configured files.
+// It is called at the end of every sync, so the files contain the metrics of all syncs of the current run once the last sync finished.
+func writeStatementMetrics(params map[string]string) {
+	if path := params[SfMetricsFile]; path != "" {
+		err := statementMetricsCollector.WriteJSON(path)
+		if err != nil {
+			Logger.Warn(fmt.Sprintf("Unable to write statement metrics to %q: %s", path, err.Error()))
+		}
+	}
+
+	if path := params[SfMetricsPrometheusFile]; path != "" {
+		err := statementMetricsCollector.WritePrometheus(path)
+		if err != nil {
+			Logger.Warn(fmt.Sprintf("Unable to write Prometheus metrics to %q: %s", path, err.Error()))
+		}
+	}
+}
+
+// statementKindOfBatch returns the kind of the statements executed together, or statementKindMixed if they are not all of the same kind
+func statementKindOfBatch(statements []string) string {
+	if len(statements) == 0 {
+		return statementKindMixed
+	}
+
+	kind := statementKind(statements[0])
+
+	for _, statement := range statements[1:] {
+		if statementKind(statement) != kind {
+			return statementKindMixed
+		}
+	}
+
+	return kind
+}
+
+// statementKind groups statements by their type, e.g. 'SHOW GRANTS', 'GRANT', 'CREATE MASKING POLICY' or 'USAGE QUERY'.
+func statementKind(statement string) string {
+	words := strings.Fields(strings.ToUpper(strings.TrimSuffix(strings.TrimSpace(statement), ";")))
+	if len(words) == 0 {
+		return "UNKNOWN"
+	}
+
+	switch words[0] {
+	case "SELECT", "WITH":
+		return selectStatementKind(strings.Join(words, " "))
+	case "SHOW":
+		kind := []string{"SHOW"}
+
+		for _, word := range words[1:] {
+			if _, terminator := statementKindShowTerminators[word]; terminator || len(kind) == 3 {
+				break
+			}
+
+			kind = append(kind, word)
+		}
+
+		return strings.Join(kind, " ")
+	case "GRANT", "REVOKE":
+		if len(words) > 1 && (words[1] == "ROLE" || words[1] == "OWNERSHIP") {
+			return words[0] + " " + words[1]
+		}
+
+		return words[0]
+	case "CREATE", "DROP", "ALTER", "COMMENT", "DESCRIBE", "DESC":
+		return ddlStatementKind(words)
+	default:
+		return words[0]
+	}
+}
+
+func selectStatementKind(normalized string) string {
+	switch {
+	case strings.Contains(normalized, "QUERY_HISTORY"):
+		return statementKindUsage
+	case strings.Contains(normalized, "RESULT_SCAN"):
+		return "SELECT RESULT_SCAN"
+	case strings.Contains(normalized, "INFORMATION_SCHEMA"):
+		return "SELECT INFORMATION_SCHEMA"
+	case strings.Contains(normalized, "ACCOUNT_USAGE"):
+		return "SELECT ACCOUNT_USAGE"
+	default:
+		return "SELECT"
+	}
+}
+
+func ddlStatementKind(words []string) string {
+	verb := words[0]
+	rest := words[1:]
+
+	// Skip modifiers that don't change the object type
+	for len(rest) > 0 {
+		if len(rest) > 1 && rest[0] == "OR" && rest[1] == "REPLACE" {
+			rest = rest[2:]
+		} else if _, modifier := statementKindModifiers[rest[0]]; modifier {
+			rest = rest[1:]
+		} else {
+			break
+		}
+	}
+
+	objectTypes := strings.Join(rest, " ")
+
+	for _, objectType := range statementKindObjectTypes {
+		if strings.HasPrefix(objectTypes, objectType+" ") || objectTypes == objectType {
+			return verb + " " + objectType
+		}
+	}
+
+	if len(rest) > 0 {
+		return verb + " " + rest[0]
+	}
+
+	return verb
+}
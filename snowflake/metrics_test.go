@@ -0,0 +1,102 @@
+package snowflake
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatementKind(t *testing.T) {
+	tests := []struct {
+		statement string
+		expected  string
+	}{
+		{statement: `SHOW GRANTS TO ROLE "ROLE1"`, expected: "SHOW GRANTS"},
+		{statement: `SHOW GRANTS OF DATABASE ROLE "DB"."ROLE1"`, expected: "SHOW GRANTS"},
+		{statement: `SHOW DATABASE ROLES IN DATABASE "DB"`, expected: "SHOW DATABASE ROLES"},
+		{statement: `SHOW ROLES LIMIT 1000`, expected: "SHOW ROLES"},
+		{statement: `GRANT SELECT ON TABLE "DB"."SCHEMA"."TABLE" TO ROLE "ROLE1";`, expected: "GRANT"},
+		{statement: `GRANT ROLE "ROLE1" TO ROLE "ROLE2"`, expected: "GRANT ROLE"},
+		{statement: `revoke usage on database "DB" from role "ROLE1"`, expected: "REVOKE"},
+		{statement: `CREATE OR REPLACE MASKING POLICY "DB"."SCHEMA"."MASK" AS (val STRING) RETURNS STRING -> val`, expected: "CREATE MASKING POLICY"},
+		{statement: `CREATE ROW ACCESS POLICY "DB"."SCHEMA"."FILTER" AS (a STRING) RETURNS BOOLEAN -> true`, expected: "CREATE ROW ACCESS POLICY"},
+		{statement: `CREATE DATABASE ROLE IF NOT EXISTS "DB"."ROLE1"`, expected: "CREATE DATABASE ROLE"},
+		{statement: `CREATE ROLE IF NOT EXISTS "ROLE1"`, expected: "CREATE ROLE"},
+		{statement: `DROP ROLE "ROLE1"`, expected: "DROP ROLE"},
+		{statement: `COMMENT IF EXISTS ON ROLE "ROLE1" IS 'Created by Raito'`, expected: "COMMENT ROLE"},
+		{statement: `WITH history AS (SELECT * FROM "SNOWFLAKE"."ACCOUNT_USAGE"."QUERY_HISTORY") SELECT * FROM history`, expected: statementKindUsage},
+		{statement: `SELECT * FROM "DB".INFORMATION_SCHEMA.TABLES`, expected: "SELECT INFORMATION_SCHEMA"},
+		{statement: `select "name" from table(result_scan(LAST_QUERY_ID()))`, expected: "SELECT RESULT_SCAN"},
+		{statement: ``, expected: "UNKNOWN"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.statement, func(t *testing.T) {
+			assert.Equal(t, tt.expected, statementKind(tt.statement))
+		})
+	}
+}
+
+func TestStatementKindOfBatch(t *testing.T) {
+	assert.Equal(t, "GRANT ROLE", statementKindOfBatch([]string{`GRANT ROLE "R1" TO ROLE "R2"`, `GRANT ROLE "R1" TO ROLE "R3"`}))
+	assert.Equal(t, statementKindMixed, statementKindOfBatch([]string{`CREATE ROLE IF NOT EXISTS "R2"`, `GRANT ROLE "R1" TO ROLE "R2"`}))
+}
+
+func TestPercentile(t *testing.T) {
+	durations := make([]time.Duration, 0, 100)
+	for i := 100; i > 0; i-- {
+		durations = append(durations, time.Duration(i)*time.Millisecond)
+	}
+
+	assert.Equal(t, 95*time.Millisecond, percentile(durations, 0.95))
+	assert.Equal(t, time.Second, percentile([]time.Duration{time.Second}, 0.95))
+	assert.Equal(t, time.Duration(0), percentile(nil, 0.95))
+}
+
+func TestStatementMetricsCollector(t *testing.T) {
+	collector := NewStatementMetricsCollector()
+
+	collector.record(SyncPhaseAccessToTarget, []string{`GRANT SELECT ON TABLE "DB"."S"."T" TO ROLE "R1"`}, time.Second, nil)
+	collector.record(SyncPhaseAccessToTarget, []string{`GRANT USAGE ON DATABASE "DB" TO ROLE "R1"`}, 3*time.Second, errors.New("boom"))
+	collector.record(SyncPhaseAccessFromTarget, []string{`SHOW GRANTS TO ROLE "R1"`}, 2*time.Second, nil)
+
+	assert.Equal(t, []StatementMetricsSummary{
+		{Syncer: SyncPhaseAccessFromTarget, Statement: "SHOW GRANTS", Count: 1, TotalSeconds: 2, P95Seconds: 2},
+		{Syncer: SyncPhaseAccessToTarget, Statement: "GRANT", Count: 2, Errors: 1, TotalSeconds: 4, P95Seconds: 3},
+	}, collector.Summary())
+
+	t.Run("JSON", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "metrics.json")
+
+		require.NoError(t, collector.WriteJSON(path))
+
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+
+		var report statementMetricsReport
+		require.NoError(t, json.Unmarshal(data, &report))
+
+		assert.Equal(t, runId, report.RunId)
+		assert.Len(t, report.Statements, 2)
+	})
+
+	t.Run("Prometheus", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "metrics.prom")
+
+		require.NoError(t, collector.WritePrometheus(path))
+
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+
+		assert.Contains(t, string(data), "# TYPE raito_snowflake_statement_duration_seconds summary\n")
+		assert.Contains(t, string(data), `raito_snowflake_statement_duration_seconds{syncer="access-to-target",statement="GRANT",quantile="0.95"} 3`+"\n")
+		assert.Contains(t, string(data), `raito_snowflake_statement_duration_seconds_count{syncer="access-to-target",statement="GRANT"} 2`+"\n")
+		assert.Contains(t, string(data), `raito_snowflake_statement_errors_total{syncer="access-to-target",statement="GRANT"} 1`+"\n")
+	})
+}
@@ -68,17 +68,17 @@ func (_c *mockDataAccessRepository_Close_Call) RunAndReturn(run func() error) *m
 	return _c
 }
 
-// CommentAccountRoleIfExists provides a mock function with given fields: comment, objectName
-func (_m *mockDataAccessRepository) CommentAccountRoleIfExists(comment string, objectName string) error {
-	ret := _m.Called(comment, objectName)
+// CommentAccountRoleIfExists provides a mock function with given fields: ctx, comment, objectName
+func (_m *mockDataAccessRepository) CommentAccountRoleIfExists(ctx context.Context, comment string, objectName string) error {
+	ret := _m.Called(ctx, comment, objectName)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CommentAccountRoleIfExists")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, string) error); ok {
-		r0 = rf(comment, objectName)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, comment, objectName)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -92,15 +92,16 @@ type mockDataAccessRepository_CommentAccountRoleIfExists_Call struct {
 }
 
 // CommentAccountRoleIfExists is a helper method to define mock.On call
+//   - ctx context.Context
 //   - comment string
 //   - objectName string
-func (_e *mockDataAccessRepository_Expecter) CommentAccountRoleIfExists(comment interface{}, objectName interface{}) *mockDataAccessRepository_CommentAccountRoleIfExists_Call {
-	return &mockDataAccessRepository_CommentAccountRoleIfExists_Call{Call: _e.mock.On("CommentAccountRoleIfExists", comment, objectName)}
+func (_e *mockDataAccessRepository_Expecter) CommentAccountRoleIfExists(ctx interface{}, comment interface{}, objectName interface{}) *mockDataAccessRepository_CommentAccountRoleIfExists_Call {
+	return &mockDataAccessRepository_CommentAccountRoleIfExists_Call{Call: _e.mock.On("CommentAccountRoleIfExists", ctx, comment, objectName)}
 }
 
-func (_c *mockDataAccessRepository_CommentAccountRoleIfExists_Call) Run(run func(comment string, objectName string)) *mockDataAccessRepository_CommentAccountRoleIfExists_Call {
+func (_c *mockDataAccessRepository_CommentAccountRoleIfExists_Call) Run(run func(ctx context.Context, comment string, objectName string)) *mockDataAccessRepository_CommentAccountRoleIfExists_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
 	})
 	return _c
 }
@@ -110,22 +111,22 @@ func (_c *mockDataAccessRepository_CommentAccountRoleIfExists_Call) Return(_a0 e
 	return _c
 }
 
-func (_c *mockDataAccessRepository_CommentAccountRoleIfExists_Call) RunAndReturn(run func(string, string) error) *mockDataAccessRepository_CommentAccountRoleIfExists_Call {
+func (_c *mockDataAccessRepository_CommentAccountRoleIfExists_Call) RunAndReturn(run func(context.Context, string, string) error) *mockDataAccessRepository_CommentAccountRoleIfExists_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CommentDatabaseRoleIfExists provides a mock function with given fields: comment, database, roleName
-func (_m *mockDataAccessRepository) CommentDatabaseRoleIfExists(comment string, database string, roleName string) error {
-	ret := _m.Called(comment, database, roleName)
+// CommentDatabaseRoleIfExists provides a mock function with given fields: ctx, comment, database, roleName
+func (_m *mockDataAccessRepository) CommentDatabaseRoleIfExists(ctx context.Context, comment string, database string, roleName string) error {
+	ret := _m.Called(ctx, comment, database, roleName)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CommentDatabaseRoleIfExists")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, string, string) error); ok {
-		r0 = rf(comment, database, roleName)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = rf(ctx, comment, database, roleName)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -139,16 +140,17 @@ type mockDataAccessRepository_CommentDatabaseRoleIfExists_Call struct {
 }
 
 // CommentDatabaseRoleIfExists is a helper method to define mock.On call
+//   - ctx context.Context
 //   - comment string
 //   - database string
 //   - roleName string
-func (_e *mockDataAccessRepository_Expecter) CommentDatabaseRoleIfExists(comment interface{}, database interface{}, roleName interface{}) *mockDataAccessRepository_CommentDatabaseRoleIfExists_Call {
-	return &mockDataAccessRepository_CommentDatabaseRoleIfExists_Call{Call: _e.mock.On("CommentDatabaseRoleIfExists", comment, database, roleName)}
+func (_e *mockDataAccessRepository_Expecter) CommentDatabaseRoleIfExists(ctx interface{}, comment interface{}, database interface{}, roleName interface{}) *mockDataAccessRepository_CommentDatabaseRoleIfExists_Call {
+	return &mockDataAccessRepository_CommentDatabaseRoleIfExists_Call{Call: _e.mock.On("CommentDatabaseRoleIfExists", ctx, comment, database, roleName)}
 }
 
-func (_c *mockDataAccessRepository_CommentDatabaseRoleIfExists_Call) Run(run func(comment string, database string, roleName string)) *mockDataAccessRepository_CommentDatabaseRoleIfExists_Call {
+func (_c *mockDataAccessRepository_CommentDatabaseRoleIfExists_Call) Run(run func(ctx context.Context, comment string, database string, roleName string)) *mockDataAccessRepository_CommentDatabaseRoleIfExists_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(string), args[2].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string))
 	})
 	return _c
 }
@@ -158,22 +160,22 @@ func (_c *mockDataAccessRepository_CommentDatabaseRoleIfExists_Call) Return(_a0
 	return _c
 }
 
-func (_c *mockDataAccessRepository_CommentDatabaseRoleIfExists_Call) RunAndReturn(run func(string, string, string) error) *mockDataAccessRepository_CommentDatabaseRoleIfExists_Call {
+func (_c *mockDataAccessRepository_CommentDatabaseRoleIfExists_Call) RunAndReturn(run func(context.Context, string, string, string) error) *mockDataAccessRepository_CommentDatabaseRoleIfExists_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreateAccountRole provides a mock function with given fields: roleName
-func (_m *mockDataAccessRepository) CreateAccountRole(roleName string) error {
-	ret := _m.Called(roleName)
+// CreateAccountRole provides a mock function with given fields: ctx, roleName
+func (_m *mockDataAccessRepository) CreateAccountRole(ctx context.Context, roleName string) error {
+	ret := _m.Called(ctx, roleName)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CreateAccountRole")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string) error); ok {
-		r0 = rf(roleName)
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, roleName)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -187,14 +189,15 @@ type mockDataAccessRepository_CreateAccountRole_Call struct {
 }
 
 // CreateAccountRole is a helper method to define mock.On call
+//   - ctx context.Context
 //   - roleName string
-func (_e *mockDataAccessRepository_Expecter) CreateAccountRole(roleName interface{}) *mockDataAccessRepository_CreateAccountRole_Call {
-	return &mockDataAccessRepository_CreateAccountRole_Call{Call: _e.mock.On("CreateAccountRole", roleName)}
+func (_e *mockDataAccessRepository_Expecter) CreateAccountRole(ctx interface{}, roleName interface{}) *mockDataAccessRepository_CreateAccountRole_Call {
+	return &mockDataAccessRepository_CreateAccountRole_Call{Call: _e.mock.On("CreateAccountRole", ctx, roleName)}
 }
 
-func (_c *mockDataAccessRepository_CreateAccountRole_Call) Run(run func(roleName string)) *mockDataAccessRepository_CreateAccountRole_Call {
+func (_c *mockDataAccessRepository_CreateAccountRole_Call) Run(run func(ctx context.Context, roleName string)) *mockDataAccessRepository_CreateAccountRole_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(context.Context), args[1].(string))
 	})
 	return _c
 }
@@ -204,22 +207,22 @@ func (_c *mockDataAccessRepository_CreateAccountRole_Call) Return(_a0 error) *mo
 	return _c
 }
 
-func (_c *mockDataAccessRepository_CreateAccountRole_Call) RunAndReturn(run func(string) error) *mockDataAccessRepository_CreateAccountRole_Call {
+func (_c *mockDataAccessRepository_CreateAccountRole_Call) RunAndReturn(run func(context.Context, string) error) *mockDataAccessRepository_CreateAccountRole_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreateDatabaseRole provides a mock function with given fields: database, roleName
-func (_m *mockDataAccessRepository) CreateDatabaseRole(database string, roleName string) error {
-	ret := _m.Called(database, roleName)
+// CreateDatabaseRole provides a mock function with given fields: ctx, database, roleName
+func (_m *mockDataAccessRepository) CreateDatabaseRole(ctx context.Context, database string, roleName string) error {
+	ret := _m.Called(ctx, database, roleName)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CreateDatabaseRole")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, string) error); ok {
-		r0 = rf(database, roleName)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, database, roleName)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -233,15 +236,16 @@ type mockDataAccessRepository_CreateDatabaseRole_Call struct {
 }
 
 // CreateDatabaseRole is a helper method to define mock.On call
+//   - ctx context.Context
 //   - database string
 //   - roleName string
-func (_e *mockDataAccessRepository_Expecter) CreateDatabaseRole(database interface{}, roleName interface{}) *mockDataAccessRepository_CreateDatabaseRole_Call {
-	return &mockDataAccessRepository_CreateDatabaseRole_Call{Call: _e.mock.On("CreateDatabaseRole", database, roleName)}
+func (_e *mockDataAccessRepository_Expecter) CreateDatabaseRole(ctx interface{}, database interface{}, roleName interface{}) *mockDataAccessRepository_CreateDatabaseRole_Call {
+	return &mockDataAccessRepository_CreateDatabaseRole_Call{Call: _e.mock.On("CreateDatabaseRole", ctx, database, roleName)}
 }
 
-func (_c *mockDataAccessRepository_CreateDatabaseRole_Call) Run(run func(database string, roleName string)) *mockDataAccessRepository_CreateDatabaseRole_Call {
+func (_c *mockDataAccessRepository_CreateDatabaseRole_Call) Run(run func(ctx context.Context, database string, roleName string)) *mockDataAccessRepository_CreateDatabaseRole_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
 	})
 	return _c
 }
@@ -251,22 +255,22 @@ func (_c *mockDataAccessRepository_CreateDatabaseRole_Call) Return(_a0 error) *m
 	return _c
 }
 
-func (_c *mockDataAccessRepository_CreateDatabaseRole_Call) RunAndReturn(run func(string, string) error) *mockDataAccessRepository_CreateDatabaseRole_Call {
+func (_c *mockDataAccessRepository_CreateDatabaseRole_Call) RunAndReturn(run func(context.Context, string, string) error) *mockDataAccessRepository_CreateDatabaseRole_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreateMaskPolicy provides a mock function with given fields: databaseName, schema, maskName, columnsFullName, maskType, beneficiaries
-func (_m *mockDataAccessRepository) CreateMaskPolicy(databaseName string, schema string, maskName string, columnsFullName []string, maskType *string, beneficiaries *MaskingBeneficiaries) error {
-	ret := _m.Called(databaseName, schema, maskName, columnsFullName, maskType, beneficiaries)
+// CreateMaskPolicy provides a mock function with given fields: ctx, databaseName, schema, maskName, columnsFullName, maskType, beneficiaries
+func (_m *mockDataAccessRepository) CreateMaskPolicy(ctx context.Context, databaseName string, schema string, maskName string, columnsFullName []string, maskType *string, beneficiaries *MaskingBeneficiaries) error {
+	ret := _m.Called(ctx, databaseName, schema, maskName, columnsFullName, maskType, beneficiaries)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CreateMaskPolicy")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, string, string, []string, *string, *MaskingBeneficiaries) error); ok {
-		r0 = rf(databaseName, schema, maskName, columnsFullName, maskType, beneficiaries)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, []string, *string, *MaskingBeneficiaries) error); ok {
+		r0 = rf(ctx, databaseName, schema, maskName, columnsFullName, maskType, beneficiaries)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -280,19 +284,20 @@ type mockDataAccessRepository_CreateMaskPolicy_Call struct {
 }
 
 // CreateMaskPolicy is a helper method to define mock.On call
+//   - ctx context.Context
 //   - databaseName string
 //   - schema string
 //   - maskName string
 //   - columnsFullName []string
 //   - maskType *string
 //   - beneficiaries *MaskingBeneficiaries
-func (_e *mockDataAccessRepository_Expecter) CreateMaskPolicy(databaseName interface{}, schema interface{}, maskName interface{}, columnsFullName interface{}, maskType interface{}, beneficiaries interface{}) *mockDataAccessRepository_CreateMaskPolicy_Call {
-	return &mockDataAccessRepository_CreateMaskPolicy_Call{Call: _e.mock.On("CreateMaskPolicy", databaseName, schema, maskName, columnsFullName, maskType, beneficiaries)}
+func (_e *mockDataAccessRepository_Expecter) CreateMaskPolicy(ctx interface{}, databaseName interface{}, schema interface{}, maskName interface{}, columnsFullName interface{}, maskType interface{}, beneficiaries interface{}) *mockDataAccessRepository_CreateMaskPolicy_Call {
+	return &mockDataAccessRepository_CreateMaskPolicy_Call{Call: _e.mock.On("CreateMaskPolicy", ctx, databaseName, schema, maskName, columnsFullName, maskType, beneficiaries)}
 }
 
-func (_c *mockDataAccessRepository_CreateMaskPolicy_Call) Run(run func(databaseName string, schema string, maskName string, columnsFullName []string, maskType *string, beneficiaries *MaskingBeneficiaries)) *mockDataAccessRepository_CreateMaskPolicy_Call {
+func (_c *mockDataAccessRepository_CreateMaskPolicy_Call) Run(run func(ctx context.Context, databaseName string, schema string, maskName string, columnsFullName []string, maskType *string, beneficiaries *MaskingBeneficiaries)) *mockDataAccessRepository_CreateMaskPolicy_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(string), args[2].(string), args[3].([]string), args[4].(*string), args[5].(*MaskingBeneficiaries))
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string), args[4].([]string), args[5].(*string), args[6].(*MaskingBeneficiaries))
 	})
 	return _c
 }
@@ -302,22 +307,22 @@ func (_c *mockDataAccessRepository_CreateMaskPolicy_Call) Return(_a0 error) *moc
 	return _c
 }
 
-func (_c *mockDataAccessRepository_CreateMaskPolicy_Call) RunAndReturn(run func(string, string, string, []string, *string, *MaskingBeneficiaries) error) *mockDataAccessRepository_CreateMaskPolicy_Call {
+func (_c *mockDataAccessRepository_CreateMaskPolicy_Call) RunAndReturn(run func(context.Context, string, string, string, []string, *string, *MaskingBeneficiaries) error) *mockDataAccessRepository_CreateMaskPolicy_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreateShare provides a mock function with given fields: shareName
-func (_m *mockDataAccessRepository) CreateShare(shareName string) error {
-	ret := _m.Called(shareName)
+// CreateShare provides a mock function with given fields: ctx, shareName
+func (_m *mockDataAccessRepository) CreateShare(ctx context.Context, shareName string) error {
+	ret := _m.Called(ctx, shareName)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CreateShare")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string) error); ok {
-		r0 = rf(shareName)
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, shareName)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -331,14 +336,15 @@ type mockDataAccessRepository_CreateShare_Call struct {
 }
 
 // CreateShare is a helper method to define mock.On call
+//   - ctx context.Context
 //   - shareName string
-func (_e *mockDataAccessRepository_Expecter) CreateShare(shareName interface{}) *mockDataAccessRepository_CreateShare_Call {
-	return &mockDataAccessRepository_CreateShare_Call{Call: _e.mock.On("CreateShare", shareName)}
+func (_e *mockDataAccessRepository_Expecter) CreateShare(ctx interface{}, shareName interface{}) *mockDataAccessRepository_CreateShare_Call {
+	return &mockDataAccessRepository_CreateShare_Call{Call: _e.mock.On("CreateShare", ctx, shareName)}
 }
 
-func (_c *mockDataAccessRepository_CreateShare_Call) Run(run func(shareName string)) *mockDataAccessRepository_CreateShare_Call {
+func (_c *mockDataAccessRepository_CreateShare_Call) Run(run func(ctx context.Context, shareName string)) *mockDataAccessRepository_CreateShare_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(context.Context), args[1].(string))
 	})
 	return _c
 }
@@ -348,14 +354,14 @@ func (_c *mockDataAccessRepository_CreateShare_Call) Return(err error) *mockData
 	return _c
 }
 
-func (_c *mockDataAccessRepository_CreateShare_Call) RunAndReturn(run func(string) error) *mockDataAccessRepository_CreateShare_Call {
+func (_c *mockDataAccessRepository_CreateShare_Call) RunAndReturn(run func(context.Context, string) error) *mockDataAccessRepository_CreateShare_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DescribePolicy provides a mock function with given fields: policyType, dbName, schema, policyName
-func (_m *mockDataAccessRepository) DescribePolicy(policyType string, dbName string, schema string, policyName string) ([]DescribePolicyEntity, error) {
-	ret := _m.Called(policyType, dbName, schema, policyName)
+// DescribePolicy provides a mock function with given fields: ctx, policyType, dbName, schema, policyName
+func (_m *mockDataAccessRepository) DescribePolicy(ctx context.Context, policyType string, dbName string, schema string, policyName string) ([]DescribePolicyEntity, error) {
+	ret := _m.Called(ctx, policyType, dbName, schema, policyName)
 
 	if len(ret) == 0 {
 		panic("no return value specified for DescribePolicy")
@@ -363,19 +369,19 @@ func (_m *mockDataAccessRepository) DescribePolicy(policyType string, dbName str
 
 	var r0 []DescribePolicyEntity
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, string, string, string) ([]DescribePolicyEntity, error)); ok {
-		return rf(policyType, dbName, schema, policyName)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string) ([]DescribePolicyEntity, error)); ok {
+		return rf(ctx, policyType, dbName, schema, policyName)
 	}
-	if rf, ok := ret.Get(0).(func(string, string, string, string) []DescribePolicyEntity); ok {
-		r0 = rf(policyType, dbName, schema, policyName)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string) []DescribePolicyEntity); ok {
+		r0 = rf(ctx, policyType, dbName, schema, policyName)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]DescribePolicyEntity)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string, string, string, string) error); ok {
-		r1 = rf(policyType, dbName, schema, policyName)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, string) error); ok {
+		r1 = rf(ctx, policyType, dbName, schema, policyName)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -389,17 +395,18 @@ type mockDataAccessRepository_DescribePolicy_Call struct {
 }
 
 // DescribePolicy is a helper method to define mock.On call
+//   - ctx context.Context
 //   - policyType string
 //   - dbName string
 //   - schema string
 //   - policyName string
-func (_e *mockDataAccessRepository_Expecter) DescribePolicy(policyType interface{}, dbName interface{}, schema interface{}, policyName interface{}) *mockDataAccessRepository_DescribePolicy_Call {
-	return &mockDataAccessRepository_DescribePolicy_Call{Call: _e.mock.On("DescribePolicy", policyType, dbName, schema, policyName)}
+func (_e *mockDataAccessRepository_Expecter) DescribePolicy(ctx interface{}, policyType interface{}, dbName interface{}, schema interface{}, policyName interface{}) *mockDataAccessRepository_DescribePolicy_Call {
+	return &mockDataAccessRepository_DescribePolicy_Call{Call: _e.mock.On("DescribePolicy", ctx, policyType, dbName, schema, policyName)}
 }
 
-func (_c *mockDataAccessRepository_DescribePolicy_Call) Run(run func(policyType string, dbName string, schema string, policyName string)) *mockDataAccessRepository_DescribePolicy_Call {
+func (_c *mockDataAccessRepository_DescribePolicy_Call) Run(run func(ctx context.Context, policyType string, dbName string, schema string, policyName string)) *mockDataAccessRepository_DescribePolicy_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(string), args[2].(string), args[3].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string), args[4].(string))
 	})
 	return _c
 }
@@ -409,22 +416,22 @@ func (_c *mockDataAccessRepository_DescribePolicy_Call) Return(_a0 []DescribePol
 	return _c
 }
 
-func (_c *mockDataAccessRepository_DescribePolicy_Call) RunAndReturn(run func(string, string, string, string) ([]DescribePolicyEntity, error)) *mockDataAccessRepository_DescribePolicy_Call {
+func (_c *mockDataAccessRepository_DescribePolicy_Call) RunAndReturn(run func(context.Context, string, string, string, string) ([]DescribePolicyEntity, error)) *mockDataAccessRepository_DescribePolicy_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DropAccountRole provides a mock function with given fields: roleName
-func (_m *mockDataAccessRepository) DropAccountRole(roleName string) error {
-	ret := _m.Called(roleName)
+// DropAccountRole provides a mock function with given fields: ctx, roleName
+func (_m *mockDataAccessRepository) DropAccountRole(ctx context.Context, roleName string) error {
+	ret := _m.Called(ctx, roleName)
 
 	if len(ret) == 0 {
 		panic("no return value specified for DropAccountRole")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string) error); ok {
-		r0 = rf(roleName)
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, roleName)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -438,14 +445,15 @@ type mockDataAccessRepository_DropAccountRole_Call struct {
 }
 
 // DropAccountRole is a helper method to define mock.On call
+//   - ctx context.Context
 //   - roleName string
-func (_e *mockDataAccessRepository_Expecter) DropAccountRole(roleName interface{}) *mockDataAccessRepository_DropAccountRole_Call {
-	return &mockDataAccessRepository_DropAccountRole_Call{Call: _e.mock.On("DropAccountRole", roleName)}
+func (_e *mockDataAccessRepository_Expecter) DropAccountRole(ctx interface{}, roleName interface{}) *mockDataAccessRepository_DropAccountRole_Call {
+	return &mockDataAccessRepository_DropAccountRole_Call{Call: _e.mock.On("DropAccountRole", ctx, roleName)}
 }
 
-func (_c *mockDataAccessRepository_DropAccountRole_Call) Run(run func(roleName string)) *mockDataAccessRepository_DropAccountRole_Call {
+func (_c *mockDataAccessRepository_DropAccountRole_Call) Run(run func(ctx context.Context, roleName string)) *mockDataAccessRepository_DropAccountRole_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(context.Context), args[1].(string))
 	})
 	return _c
 }
@@ -455,22 +463,22 @@ func (_c *mockDataAccessRepository_DropAccountRole_Call) Return(_a0 error) *mock
 	return _c
 }
 
-func (_c *mockDataAccessRepository_DropAccountRole_Call) RunAndReturn(run func(string) error) *mockDataAccessRepository_DropAccountRole_Call {
+func (_c *mockDataAccessRepository_DropAccountRole_Call) RunAndReturn(run func(context.Context, string) error) *mockDataAccessRepository_DropAccountRole_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DropDatabaseRole provides a mock function with given fields: database, roleName
-func (_m *mockDataAccessRepository) DropDatabaseRole(database string, roleName string) error {
-	ret := _m.Called(database, roleName)
+// DropDatabaseRole provides a mock function with given fields: ctx, database, roleName
+func (_m *mockDataAccessRepository) DropDatabaseRole(ctx context.Context, database string, roleName string) error {
+	ret := _m.Called(ctx, database, roleName)
 
 	if len(ret) == 0 {
 		panic("no return value specified for DropDatabaseRole")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, string) error); ok {
-		r0 = rf(database, roleName)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, database, roleName)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -484,15 +492,16 @@ type mockDataAccessRepository_DropDatabaseRole_Call struct {
 }
 
 // DropDatabaseRole is a helper method to define mock.On call
+//   - ctx context.Context
 //   - database string
 //   - roleName string
-func (_e *mockDataAccessRepository_Expecter) DropDatabaseRole(database interface{}, roleName interface{}) *mockDataAccessRepository_DropDatabaseRole_Call {
-	return &mockDataAccessRepository_DropDatabaseRole_Call{Call: _e.mock.On("DropDatabaseRole", database, roleName)}
+func (_e *mockDataAccessRepository_Expecter) DropDatabaseRole(ctx interface{}, database interface{}, roleName interface{}) *mockDataAccessRepository_DropDatabaseRole_Call {
+	return &mockDataAccessRepository_DropDatabaseRole_Call{Call: _e.mock.On("DropDatabaseRole", ctx, database, roleName)}
 }
 
-func (_c *mockDataAccessRepository_DropDatabaseRole_Call) Run(run func(database string, roleName string)) *mockDataAccessRepository_DropDatabaseRole_Call {
+func (_c *mockDataAccessRepository_DropDatabaseRole_Call) Run(run func(ctx context.Context, database string, roleName string)) *mockDataAccessRepository_DropDatabaseRole_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
 	})
 	return _c
 }
@@ -502,22 +511,22 @@ func (_c *mockDataAccessRepository_DropDatabaseRole_Call) Return(_a0 error) *moc
 	return _c
 }
 
-func (_c *mockDataAccessRepository_DropDatabaseRole_Call) RunAndReturn(run func(string, string) error) *mockDataAccessRepository_DropDatabaseRole_Call {
+func (_c *mockDataAccessRepository_DropDatabaseRole_Call) RunAndReturn(run func(context.Context, string, string) error) *mockDataAccessRepository_DropDatabaseRole_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DropFilter provides a mock function with given fields: databaseName, schema, tableName, filterName
-func (_m *mockDataAccessRepository) DropFilter(databaseName string, schema string, tableName string, filterName string) error {
-	ret := _m.Called(databaseName, schema, tableName, filterName)
+// DropFilter provides a mock function with given fields: ctx, databaseName, schema, tableName, filterName
+func (_m *mockDataAccessRepository) DropFilter(ctx context.Context, databaseName string, schema string, tableName string, filterName string) error {
+	ret := _m.Called(ctx, databaseName, schema, tableName, filterName)
 
 	if len(ret) == 0 {
 		panic("no return value specified for DropFilter")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, string, string, string) error); ok {
-		r0 = rf(databaseName, schema, tableName, filterName)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string) error); ok {
+		r0 = rf(ctx, databaseName, schema, tableName, filterName)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -531,17 +540,18 @@ type mockDataAccessRepository_DropFilter_Call struct {
 }
 
 // DropFilter is a helper method to define mock.On call
+//   - ctx context.Context
 //   - databaseName string
 //   - schema string
 //   - tableName string
 //   - filterName string
-func (_e *mockDataAccessRepository_Expecter) DropFilter(databaseName interface{}, schema interface{}, tableName interface{}, filterName interface{}) *mockDataAccessRepository_DropFilter_Call {
-	return &mockDataAccessRepository_DropFilter_Call{Call: _e.mock.On("DropFilter", databaseName, schema, tableName, filterName)}
+func (_e *mockDataAccessRepository_Expecter) DropFilter(ctx interface{}, databaseName interface{}, schema interface{}, tableName interface{}, filterName interface{}) *mockDataAccessRepository_DropFilter_Call {
+	return &mockDataAccessRepository_DropFilter_Call{Call: _e.mock.On("DropFilter", ctx, databaseName, schema, tableName, filterName)}
 }
 
-func (_c *mockDataAccessRepository_DropFilter_Call) Run(run func(databaseName string, schema string, tableName string, filterName string)) *mockDataAccessRepository_DropFilter_Call {
+func (_c *mockDataAccessRepository_DropFilter_Call) Run(run func(ctx context.Context, databaseName string, schema string, tableName string, filterName string)) *mockDataAccessRepository_DropFilter_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(string), args[2].(string), args[3].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string), args[4].(string))
 	})
 	return _c
 }
@@ -551,22 +561,22 @@ func (_c *mockDataAccessRepository_DropFilter_Call) Return(_a0 error) *mockDataA
 	return _c
 }
 
-func (_c *mockDataAccessRepository_DropFilter_Call) RunAndReturn(run func(string, string, string, string) error) *mockDataAccessRepository_DropFilter_Call {
+func (_c *mockDataAccessRepository_DropFilter_Call) RunAndReturn(run func(context.Context, string, string, string, string) error) *mockDataAccessRepository_DropFilter_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DropMaskingPolicy provides a mock function with given fields: databaseName, schema, maskName
-func (_m *mockDataAccessRepository) DropMaskingPolicy(databaseName string, schema string, maskName string) error {
-	ret := _m.Called(databaseName, schema, maskName)
+// DropMaskingPolicy provides a mock function with given fields: ctx, databaseName, schema, maskName
+func (_m *mockDataAccessRepository) DropMaskingPolicy(ctx context.Context, databaseName string, schema string, maskName string) error {
+	ret := _m.Called(ctx, databaseName, schema, maskName)
 
 	if len(ret) == 0 {
 		panic("no return value specified for DropMaskingPolicy")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, string, string) error); ok {
-		r0 = rf(databaseName, schema, maskName)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = rf(ctx, databaseName, schema, maskName)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -580,16 +590,17 @@ type mockDataAccessRepository_DropMaskingPolicy_Call struct {
 }
 
 // DropMaskingPolicy is a helper method to define mock.On call
+//   - ctx context.Context
 //   - databaseName string
 //   - schema string
 //   - maskName string
-func (_e *mockDataAccessRepository_Expecter) DropMaskingPolicy(databaseName interface{}, schema interface{}, maskName interface{}) *mockDataAccessRepository_DropMaskingPolicy_Call {
-	return &mockDataAccessRepository_DropMaskingPolicy_Call{Call: _e.mock.On("DropMaskingPolicy", databaseName, schema, maskName)}
+func (_e *mockDataAccessRepository_Expecter) DropMaskingPolicy(ctx interface{}, databaseName interface{}, schema interface{}, maskName interface{}) *mockDataAccessRepository_DropMaskingPolicy_Call {
+	return &mockDataAccessRepository_DropMaskingPolicy_Call{Call: _e.mock.On("DropMaskingPolicy", ctx, databaseName, schema, maskName)}
 }
 
-func (_c *mockDataAccessRepository_DropMaskingPolicy_Call) Run(run func(databaseName string, schema string, maskName string)) *mockDataAccessRepository_DropMaskingPolicy_Call {
+func (_c *mockDataAccessRepository_DropMaskingPolicy_Call) Run(run func(ctx context.Context, databaseName string, schema string, maskName string)) *mockDataAccessRepository_DropMaskingPolicy_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(string), args[2].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string))
 	})
 	return _c
 }
@@ -599,22 +610,22 @@ func (_c *mockDataAccessRepository_DropMaskingPolicy_Call) Return(err error) *mo
 	return _c
 }
 
-func (_c *mockDataAccessRepository_DropMaskingPolicy_Call) RunAndReturn(run func(string, string, string) error) *mockDataAccessRepository_DropMaskingPolicy_Call {
+func (_c *mockDataAccessRepository_DropMaskingPolicy_Call) RunAndReturn(run func(context.Context, string, string, string) error) *mockDataAccessRepository_DropMaskingPolicy_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DropShare provides a mock function with given fields: shareName
-func (_m *mockDataAccessRepository) DropShare(shareName string) error {
-	ret := _m.Called(shareName)
+// DropShare provides a mock function with given fields: ctx, shareName
+func (_m *mockDataAccessRepository) DropShare(ctx context.Context, shareName string) error {
+	ret := _m.Called(ctx, shareName)
 
 	if len(ret) == 0 {
 		panic("no return value specified for DropShare")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string) error); ok {
-		r0 = rf(shareName)
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, shareName)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -628,14 +639,15 @@ type mockDataAccessRepository_DropShare_Call struct {
 }
 
 // DropShare is a helper method to define mock.On call
+//   - ctx context.Context
 //   - shareName string
-func (_e *mockDataAccessRepository_Expecter) DropShare(shareName interface{}) *mockDataAccessRepository_DropShare_Call {
-	return &mockDataAccessRepository_DropShare_Call{Call: _e.mock.On("DropShare", shareName)}
+func (_e *mockDataAccessRepository_Expecter) DropShare(ctx interface{}, shareName interface{}) *mockDataAccessRepository_DropShare_Call {
+	return &mockDataAccessRepository_DropShare_Call{Call: _e.mock.On("DropShare", ctx, shareName)}
 }
 
-func (_c *mockDataAccessRepository_DropShare_Call) Run(run func(shareName string)) *mockDataAccessRepository_DropShare_Call {
+func (_c *mockDataAccessRepository_DropShare_Call) Run(run func(ctx context.Context, shareName string)) *mockDataAccessRepository_DropShare_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(context.Context), args[1].(string))
 	})
 	return _c
 }
@@ -645,22 +657,22 @@ func (_c *mockDataAccessRepository_DropShare_Call) Return(err error) *mockDataAc
 	return _c
 }
 
-func (_c *mockDataAccessRepository_DropShare_Call) RunAndReturn(run func(string) error) *mockDataAccessRepository_DropShare_Call {
+func (_c *mockDataAccessRepository_DropShare_Call) RunAndReturn(run func(context.Context, string) error) *mockDataAccessRepository_DropShare_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ExecuteGrantOnAccountRole provides a mock function with given fields: perm, on, role, isSystemGrant
-func (_m *mockDataAccessRepository) ExecuteGrantOnAccountRole(perm string, on string, role string, isSystemGrant bool) error {
-	ret := _m.Called(perm, on, role, isSystemGrant)
+// ExecuteGrantOnAccountRole provides a mock function with given fields: ctx, perm, on, role, isSystemGrant
+func (_m *mockDataAccessRepository) ExecuteGrantOnAccountRole(ctx context.Context, perm string, on string, role string, isSystemGrant bool) error {
+	ret := _m.Called(ctx, perm, on, role, isSystemGrant)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ExecuteGrantOnAccountRole")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, string, string, bool) error); ok {
-		r0 = rf(perm, on, role, isSystemGrant)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, bool) error); ok {
+		r0 = rf(ctx, perm, on, role, isSystemGrant)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -674,17 +686,18 @@ type mockDataAccessRepository_ExecuteGrantOnAccountRole_Call struct {
 }
 
 // ExecuteGrantOnAccountRole is a helper method to define mock.On call
+//   - ctx context.Context
 //   - perm string
 //   - on string
 //   - role string
 //   - isSystemGrant bool
-func (_e *mockDataAccessRepository_Expecter) ExecuteGrantOnAccountRole(perm interface{}, on interface{}, role interface{}, isSystemGrant interface{}) *mockDataAccessRepository_ExecuteGrantOnAccountRole_Call {
-	return &mockDataAccessRepository_ExecuteGrantOnAccountRole_Call{Call: _e.mock.On("ExecuteGrantOnAccountRole", perm, on, role, isSystemGrant)}
+func (_e *mockDataAccessRepository_Expecter) ExecuteGrantOnAccountRole(ctx interface{}, perm interface{}, on interface{}, role interface{}, isSystemGrant interface{}) *mockDataAccessRepository_ExecuteGrantOnAccountRole_Call {
+	return &mockDataAccessRepository_ExecuteGrantOnAccountRole_Call{Call: _e.mock.On("ExecuteGrantOnAccountRole", ctx, perm, on, role, isSystemGrant)}
 }
 
-func (_c *mockDataAccessRepository_ExecuteGrantOnAccountRole_Call) Run(run func(perm string, on string, role string, isSystemGrant bool)) *mockDataAccessRepository_ExecuteGrantOnAccountRole_Call {
+func (_c *mockDataAccessRepository_ExecuteGrantOnAccountRole_Call) Run(run func(ctx context.Context, perm string, on string, role string, isSystemGrant bool)) *mockDataAccessRepository_ExecuteGrantOnAccountRole_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(string), args[2].(string), args[3].(bool))
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string), args[4].(bool))
 	})
 	return _c
 }
@@ -694,22 +707,22 @@ func (_c *mockDataAccessRepository_ExecuteGrantOnAccountRole_Call) Return(_a0 er
 	return _c
 }
 
-func (_c *mockDataAccessRepository_ExecuteGrantOnAccountRole_Call) RunAndReturn(run func(string, string, string, bool) error) *mockDataAccessRepository_ExecuteGrantOnAccountRole_Call {
+func (_c *mockDataAccessRepository_ExecuteGrantOnAccountRole_Call) RunAndReturn(run func(context.Context, string, string, string, bool) error) *mockDataAccessRepository_ExecuteGrantOnAccountRole_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ExecuteGrantOnDatabaseRole provides a mock function with given fields: perm, on, database, databaseRole
-func (_m *mockDataAccessRepository) ExecuteGrantOnDatabaseRole(perm string, on string, database string, databaseRole string) error {
-	ret := _m.Called(perm, on, database, databaseRole)
+// ExecuteGrantOnDatabaseRole provides a mock function with given fields: ctx, perm, on, database, databaseRole
+func (_m *mockDataAccessRepository) ExecuteGrantOnDatabaseRole(ctx context.Context, perm string, on string, database string, databaseRole string) error {
+	ret := _m.Called(ctx, perm, on, database, databaseRole)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ExecuteGrantOnDatabaseRole")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, string, string, string) error); ok {
-		r0 = rf(perm, on, database, databaseRole)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string) error); ok {
+		r0 = rf(ctx, perm, on, database, databaseRole)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -723,17 +736,18 @@ type mockDataAccessRepository_ExecuteGrantOnDatabaseRole_Call struct {
 }
 
 // ExecuteGrantOnDatabaseRole is a helper method to define mock.On call
+//   - ctx context.Context
 //   - perm string
 //   - on string
 //   - database string
 //   - databaseRole string
-func (_e *mockDataAccessRepository_Expecter) ExecuteGrantOnDatabaseRole(perm interface{}, on interface{}, database interface{}, databaseRole interface{}) *mockDataAccessRepository_ExecuteGrantOnDatabaseRole_Call {
-	return &mockDataAccessRepository_ExecuteGrantOnDatabaseRole_Call{Call: _e.mock.On("ExecuteGrantOnDatabaseRole", perm, on, database, databaseRole)}
+func (_e *mockDataAccessRepository_Expecter) ExecuteGrantOnDatabaseRole(ctx interface{}, perm interface{}, on interface{}, database interface{}, databaseRole interface{}) *mockDataAccessRepository_ExecuteGrantOnDatabaseRole_Call {
+	return &mockDataAccessRepository_ExecuteGrantOnDatabaseRole_Call{Call: _e.mock.On("ExecuteGrantOnDatabaseRole", ctx, perm, on, database, databaseRole)}
 }
 
-func (_c *mockDataAccessRepository_ExecuteGrantOnDatabaseRole_Call) Run(run func(perm string, on string, database string, databaseRole string)) *mockDataAccessRepository_ExecuteGrantOnDatabaseRole_Call {
+func (_c *mockDataAccessRepository_ExecuteGrantOnDatabaseRole_Call) Run(run func(ctx context.Context, perm string, on string, database string, databaseRole string)) *mockDataAccessRepository_ExecuteGrantOnDatabaseRole_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(string), args[2].(string), args[3].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string), args[4].(string))
 	})
 	return _c
 }
@@ -743,22 +757,22 @@ func (_c *mockDataAccessRepository_ExecuteGrantOnDatabaseRole_Call) Return(_a0 e
 	return _c
 }
 
-func (_c *mockDataAccessRepository_ExecuteGrantOnDatabaseRole_Call) RunAndReturn(run func(string, string, string, string) error) *mockDataAccessRepository_ExecuteGrantOnDatabaseRole_Call {
+func (_c *mockDataAccessRepository_ExecuteGrantOnDatabaseRole_Call) RunAndReturn(run func(context.Context, string, string, string, string) error) *mockDataAccessRepository_ExecuteGrantOnDatabaseRole_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ExecuteGrantOnShare provides a mock function with given fields: perm, on, shareName
-func (_m *mockDataAccessRepository) ExecuteGrantOnShare(perm string, on string, shareName string) error {
-	ret := _m.Called(perm, on, shareName)
+// ExecuteGrantOnShare provides a mock function with given fields: ctx, perm, on, shareName
+func (_m *mockDataAccessRepository) ExecuteGrantOnShare(ctx context.Context, perm string, on string, shareName string) error {
+	ret := _m.Called(ctx, perm, on, shareName)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ExecuteGrantOnShare")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, string, string) error); ok {
-		r0 = rf(perm, on, shareName)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = rf(ctx, perm, on, shareName)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -772,16 +786,17 @@ type mockDataAccessRepository_ExecuteGrantOnShare_Call struct {
 }
 
 // ExecuteGrantOnShare is a helper method to define mock.On call
+//   - ctx context.Context
 //   - perm string
 //   - on string
 //   - shareName string
-func (_e *mockDataAccessRepository_Expecter) ExecuteGrantOnShare(perm interface{}, on interface{}, shareName interface{}) *mockDataAccessRepository_ExecuteGrantOnShare_Call {
-	return &mockDataAccessRepository_ExecuteGrantOnShare_Call{Call: _e.mock.On("ExecuteGrantOnShare", perm, on, shareName)}
+func (_e *mockDataAccessRepository_Expecter) ExecuteGrantOnShare(ctx interface{}, perm interface{}, on interface{}, shareName interface{}) *mockDataAccessRepository_ExecuteGrantOnShare_Call {
+	return &mockDataAccessRepository_ExecuteGrantOnShare_Call{Call: _e.mock.On("ExecuteGrantOnShare", ctx, perm, on, shareName)}
 }
 
-func (_c *mockDataAccessRepository_ExecuteGrantOnShare_Call) Run(run func(perm string, on string, shareName string)) *mockDataAccessRepository_ExecuteGrantOnShare_Call {
+func (_c *mockDataAccessRepository_ExecuteGrantOnShare_Call) Run(run func(ctx context.Context, perm string, on string, shareName string)) *mockDataAccessRepository_ExecuteGrantOnShare_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(string), args[2].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string))
 	})
 	return _c
 }
@@ -791,22 +806,144 @@ func (_c *mockDataAccessRepository_ExecuteGrantOnShare_Call) Return(_a0 error) *
 	return _c
 }
 
-func (_c *mockDataAccessRepository_ExecuteGrantOnShare_Call) RunAndReturn(run func(string, string, string) error) *mockDataAccessRepository_ExecuteGrantOnShare_Call {
+func (_c *mockDataAccessRepository_ExecuteGrantOnShare_Call) RunAndReturn(run func(context.Context, string, string, string) error) *mockDataAccessRepository_ExecuteGrantOnShare_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ExecutePlannedStatements provides a mock function with given fields: ctx, statements
+func (_m *mockDataAccessRepository) ExecutePlannedStatements(ctx context.Context, statements ...string) error {
+	_va := make([]interface{}, len(statements))
+	for _i := range statements {
+		_va[_i] = statements[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExecutePlannedStatements")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, ...string) error); ok {
+		r0 = rf(ctx, statements...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// mockDataAccessRepository_ExecutePlannedStatements_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExecutePlannedStatements'
+type mockDataAccessRepository_ExecutePlannedStatements_Call struct {
+	*mock.Call
+}
+
+// ExecutePlannedStatements is a helper method to define mock.On call
+//   - ctx context.Context
+//   - statements ...string
+func (_e *mockDataAccessRepository_Expecter) ExecutePlannedStatements(ctx interface{}, statements ...interface{}) *mockDataAccessRepository_ExecutePlannedStatements_Call {
+	return &mockDataAccessRepository_ExecutePlannedStatements_Call{Call: _e.mock.On("ExecutePlannedStatements",
+		append([]interface{}{ctx}, statements...)...)}
+}
+
+func (_c *mockDataAccessRepository_ExecutePlannedStatements_Call) Run(run func(ctx context.Context, statements ...string)) *mockDataAccessRepository_ExecutePlannedStatements_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]string, len(args)-1)
+		for i, a := range args[1:] {
+			if a != nil {
+				variadicArgs[i] = a.(string)
+			}
+		}
+		run(args[0].(context.Context), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *mockDataAccessRepository_ExecutePlannedStatements_Call) Return(_a0 error) *mockDataAccessRepository_ExecutePlannedStatements_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *mockDataAccessRepository_ExecutePlannedStatements_Call) RunAndReturn(run func(context.Context, ...string) error) *mockDataAccessRepository_ExecutePlannedStatements_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ExecutePlannedTransaction provides a mock function with given fields: ctx, statements
+func (_m *mockDataAccessRepository) ExecutePlannedTransaction(ctx context.Context, statements ...string) error {
+	_va := make([]interface{}, len(statements))
+	for _i := range statements {
+		_va[_i] = statements[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExecutePlannedTransaction")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, ...string) error); ok {
+		r0 = rf(ctx, statements...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// mockDataAccessRepository_ExecutePlannedTransaction_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExecutePlannedTransaction'
+type mockDataAccessRepository_ExecutePlannedTransaction_Call struct {
+	*mock.Call
+}
+
+// ExecutePlannedTransaction is a helper method to define mock.On call
+//   - ctx context.Context
+//   - statements ...string
+func (_e *mockDataAccessRepository_Expecter) ExecutePlannedTransaction(ctx interface{}, statements ...interface{}) *mockDataAccessRepository_ExecutePlannedTransaction_Call {
+	return &mockDataAccessRepository_ExecutePlannedTransaction_Call{Call: _e.mock.On("ExecutePlannedTransaction",
+		append([]interface{}{ctx}, statements...)...)}
+}
+
+func (_c *mockDataAccessRepository_ExecutePlannedTransaction_Call) Run(run func(ctx context.Context, statements ...string)) *mockDataAccessRepository_ExecutePlannedTransaction_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]string, len(args)-1)
+		for i, a := range args[1:] {
+			if a != nil {
+				variadicArgs[i] = a.(string)
+			}
+		}
+		run(args[0].(context.Context), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *mockDataAccessRepository_ExecutePlannedTransaction_Call) Return(_a0 error) *mockDataAccessRepository_ExecutePlannedTransaction_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *mockDataAccessRepository_ExecutePlannedTransaction_Call) RunAndReturn(run func(context.Context, ...string) error) *mockDataAccessRepository_ExecutePlannedTransaction_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ExecuteRevokeOnAccountRole provides a mock function with given fields: perm, on, role, isSystemGrant
-func (_m *mockDataAccessRepository) ExecuteRevokeOnAccountRole(perm string, on string, role string, isSystemGrant bool) error {
-	ret := _m.Called(perm, on, role, isSystemGrant)
+// ExecuteRevokeOnAccountRole provides a mock function with given fields: ctx, perm, on, role, isSystemGrant
+func (_m *mockDataAccessRepository) ExecuteRevokeOnAccountRole(ctx context.Context, perm string, on string, role string, isSystemGrant bool) error {
+	ret := _m.Called(ctx, perm, on, role, isSystemGrant)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ExecuteRevokeOnAccountRole")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, string, string, bool) error); ok {
-		r0 = rf(perm, on, role, isSystemGrant)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, bool) error); ok {
+		r0 = rf(ctx, perm, on, role, isSystemGrant)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -820,17 +957,18 @@ type mockDataAccessRepository_ExecuteRevokeOnAccountRole_Call struct {
 }
 
 // ExecuteRevokeOnAccountRole is a helper method to define mock.On call
+//   - ctx context.Context
 //   - perm string
 //   - on string
 //   - role string
 //   - isSystemGrant bool
-func (_e *mockDataAccessRepository_Expecter) ExecuteRevokeOnAccountRole(perm interface{}, on interface{}, role interface{}, isSystemGrant interface{}) *mockDataAccessRepository_ExecuteRevokeOnAccountRole_Call {
-	return &mockDataAccessRepository_ExecuteRevokeOnAccountRole_Call{Call: _e.mock.On("ExecuteRevokeOnAccountRole", perm, on, role, isSystemGrant)}
+func (_e *mockDataAccessRepository_Expecter) ExecuteRevokeOnAccountRole(ctx interface{}, perm interface{}, on interface{}, role interface{}, isSystemGrant interface{}) *mockDataAccessRepository_ExecuteRevokeOnAccountRole_Call {
+	return &mockDataAccessRepository_ExecuteRevokeOnAccountRole_Call{Call: _e.mock.On("ExecuteRevokeOnAccountRole", ctx, perm, on, role, isSystemGrant)}
 }
 
-func (_c *mockDataAccessRepository_ExecuteRevokeOnAccountRole_Call) Run(run func(perm string, on string, role string, isSystemGrant bool)) *mockDataAccessRepository_ExecuteRevokeOnAccountRole_Call {
+func (_c *mockDataAccessRepository_ExecuteRevokeOnAccountRole_Call) Run(run func(ctx context.Context, perm string, on string, role string, isSystemGrant bool)) *mockDataAccessRepository_ExecuteRevokeOnAccountRole_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(string), args[2].(string), args[3].(bool))
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string), args[4].(bool))
 	})
 	return _c
 }
@@ -840,22 +978,22 @@ func (_c *mockDataAccessRepository_ExecuteRevokeOnAccountRole_Call) Return(_a0 e
 	return _c
 }
 
-func (_c *mockDataAccessRepository_ExecuteRevokeOnAccountRole_Call) RunAndReturn(run func(string, string, string, bool) error) *mockDataAccessRepository_ExecuteRevokeOnAccountRole_Call {
+func (_c *mockDataAccessRepository_ExecuteRevokeOnAccountRole_Call) RunAndReturn(run func(context.Context, string, string, string, bool) error) *mockDataAccessRepository_ExecuteRevokeOnAccountRole_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ExecuteRevokeOnDatabaseRole provides a mock function with given fields: perm, on, database, databaseRole
-func (_m *mockDataAccessRepository) ExecuteRevokeOnDatabaseRole(perm string, on string, database string, databaseRole string) error {
-	ret := _m.Called(perm, on, database, databaseRole)
+// ExecuteRevokeOnDatabaseRole provides a mock function with given fields: ctx, perm, on, database, databaseRole
+func (_m *mockDataAccessRepository) ExecuteRevokeOnDatabaseRole(ctx context.Context, perm string, on string, database string, databaseRole string) error {
+	ret := _m.Called(ctx, perm, on, database, databaseRole)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ExecuteRevokeOnDatabaseRole")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, string, string, string) error); ok {
-		r0 = rf(perm, on, database, databaseRole)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string) error); ok {
+		r0 = rf(ctx, perm, on, database, databaseRole)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -869,17 +1007,18 @@ type mockDataAccessRepository_ExecuteRevokeOnDatabaseRole_Call struct {
 }
 
 // ExecuteRevokeOnDatabaseRole is a helper method to define mock.On call
+//   - ctx context.Context
 //   - perm string
 //   - on string
 //   - database string
 //   - databaseRole string
-func (_e *mockDataAccessRepository_Expecter) ExecuteRevokeOnDatabaseRole(perm interface{}, on interface{}, database interface{}, databaseRole interface{}) *mockDataAccessRepository_ExecuteRevokeOnDatabaseRole_Call {
-	return &mockDataAccessRepository_ExecuteRevokeOnDatabaseRole_Call{Call: _e.mock.On("ExecuteRevokeOnDatabaseRole", perm, on, database, databaseRole)}
+func (_e *mockDataAccessRepository_Expecter) ExecuteRevokeOnDatabaseRole(ctx interface{}, perm interface{}, on interface{}, database interface{}, databaseRole interface{}) *mockDataAccessRepository_ExecuteRevokeOnDatabaseRole_Call {
+	return &mockDataAccessRepository_ExecuteRevokeOnDatabaseRole_Call{Call: _e.mock.On("ExecuteRevokeOnDatabaseRole", ctx, perm, on, database, databaseRole)}
 }
 
-func (_c *mockDataAccessRepository_ExecuteRevokeOnDatabaseRole_Call) Run(run func(perm string, on string, database string, databaseRole string)) *mockDataAccessRepository_ExecuteRevokeOnDatabaseRole_Call {
+func (_c *mockDataAccessRepository_ExecuteRevokeOnDatabaseRole_Call) Run(run func(ctx context.Context, perm string, on string, database string, databaseRole string)) *mockDataAccessRepository_ExecuteRevokeOnDatabaseRole_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(string), args[2].(string), args[3].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string), args[4].(string))
 	})
 	return _c
 }
@@ -889,22 +1028,22 @@ func (_c *mockDataAccessRepository_ExecuteRevokeOnDatabaseRole_Call) Return(_a0
 	return _c
 }
 
-func (_c *mockDataAccessRepository_ExecuteRevokeOnDatabaseRole_Call) RunAndReturn(run func(string, string, string, string) error) *mockDataAccessRepository_ExecuteRevokeOnDatabaseRole_Call {
+func (_c *mockDataAccessRepository_ExecuteRevokeOnDatabaseRole_Call) RunAndReturn(run func(context.Context, string, string, string, string) error) *mockDataAccessRepository_ExecuteRevokeOnDatabaseRole_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ExecuteRevokeOnShare provides a mock function with given fields: perm, on, shareName
-func (_m *mockDataAccessRepository) ExecuteRevokeOnShare(perm string, on string, shareName string) error {
-	ret := _m.Called(perm, on, shareName)
+// ExecuteRevokeOnShare provides a mock function with given fields: ctx, perm, on, shareName
+func (_m *mockDataAccessRepository) ExecuteRevokeOnShare(ctx context.Context, perm string, on string, shareName string) error {
+	ret := _m.Called(ctx, perm, on, shareName)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ExecuteRevokeOnShare")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, string, string) error); ok {
-		r0 = rf(perm, on, shareName)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = rf(ctx, perm, on, shareName)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -918,16 +1057,17 @@ type mockDataAccessRepository_ExecuteRevokeOnShare_Call struct {
 }
 
 // ExecuteRevokeOnShare is a helper method to define mock.On call
+//   - ctx context.Context
 //   - perm string
 //   - on string
 //   - shareName string
-func (_e *mockDataAccessRepository_Expecter) ExecuteRevokeOnShare(perm interface{}, on interface{}, shareName interface{}) *mockDataAccessRepository_ExecuteRevokeOnShare_Call {
-	return &mockDataAccessRepository_ExecuteRevokeOnShare_Call{Call: _e.mock.On("ExecuteRevokeOnShare", perm, on, shareName)}
+func (_e *mockDataAccessRepository_Expecter) ExecuteRevokeOnShare(ctx interface{}, perm interface{}, on interface{}, shareName interface{}) *mockDataAccessRepository_ExecuteRevokeOnShare_Call {
+	return &mockDataAccessRepository_ExecuteRevokeOnShare_Call{Call: _e.mock.On("ExecuteRevokeOnShare", ctx, perm, on, shareName)}
 }
 
-func (_c *mockDataAccessRepository_ExecuteRevokeOnShare_Call) Run(run func(perm string, on string, shareName string)) *mockDataAccessRepository_ExecuteRevokeOnShare_Call {
+func (_c *mockDataAccessRepository_ExecuteRevokeOnShare_Call) Run(run func(ctx context.Context, perm string, on string, shareName string)) *mockDataAccessRepository_ExecuteRevokeOnShare_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(string), args[2].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string))
 	})
 	return _c
 }
@@ -937,34 +1077,81 @@ func (_c *mockDataAccessRepository_ExecuteRevokeOnShare_Call) Return(_a0 error)
 	return _c
 }
 
-func (_c *mockDataAccessRepository_ExecuteRevokeOnShare_Call) RunAndReturn(run func(string, string, string) error) *mockDataAccessRepository_ExecuteRevokeOnShare_Call {
+func (_c *mockDataAccessRepository_ExecuteRevokeOnShare_Call) RunAndReturn(run func(context.Context, string, string, string) error) *mockDataAccessRepository_ExecuteRevokeOnShare_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetAccountRoles provides a mock function with no fields
-func (_m *mockDataAccessRepository) GetAccountRoles() ([]RoleEntity, error) {
+// ExecutionPlan provides a mock function with no fields
+func (_m *mockDataAccessRepository) ExecutionPlan() *ExecutionPlan {
 	ret := _m.Called()
 
+	if len(ret) == 0 {
+		panic("no return value specified for ExecutionPlan")
+	}
+
+	var r0 *ExecutionPlan
+	if rf, ok := ret.Get(0).(func() *ExecutionPlan); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*ExecutionPlan)
+		}
+	}
+
+	return r0
+}
+
+// mockDataAccessRepository_ExecutionPlan_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExecutionPlan'
+type mockDataAccessRepository_ExecutionPlan_Call struct {
+	*mock.Call
+}
+
+// ExecutionPlan is a helper method to define mock.On call
+func (_e *mockDataAccessRepository_Expecter) ExecutionPlan() *mockDataAccessRepository_ExecutionPlan_Call {
+	return &mockDataAccessRepository_ExecutionPlan_Call{Call: _e.mock.On("ExecutionPlan")}
+}
+
+func (_c *mockDataAccessRepository_ExecutionPlan_Call) Run(run func()) *mockDataAccessRepository_ExecutionPlan_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *mockDataAccessRepository_ExecutionPlan_Call) Return(_a0 *ExecutionPlan) *mockDataAccessRepository_ExecutionPlan_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *mockDataAccessRepository_ExecutionPlan_Call) RunAndReturn(run func() *ExecutionPlan) *mockDataAccessRepository_ExecutionPlan_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAccountRoles provides a mock function with given fields: ctx
+func (_m *mockDataAccessRepository) GetAccountRoles(ctx context.Context) ([]RoleEntity, error) {
+	ret := _m.Called(ctx)
+
 	if len(ret) == 0 {
 		panic("no return value specified for GetAccountRoles")
 	}
 
 	var r0 []RoleEntity
 	var r1 error
-	if rf, ok := ret.Get(0).(func() ([]RoleEntity, error)); ok {
-		return rf()
+	if rf, ok := ret.Get(0).(func(context.Context) ([]RoleEntity, error)); ok {
+		return rf(ctx)
 	}
-	if rf, ok := ret.Get(0).(func() []RoleEntity); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(context.Context) []RoleEntity); ok {
+		r0 = rf(ctx)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]RoleEntity)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -978,13 +1165,14 @@ type mockDataAccessRepository_GetAccountRoles_Call struct {
 }
 
 // GetAccountRoles is a helper method to define mock.On call
-func (_e *mockDataAccessRepository_Expecter) GetAccountRoles() *mockDataAccessRepository_GetAccountRoles_Call {
-	return &mockDataAccessRepository_GetAccountRoles_Call{Call: _e.mock.On("GetAccountRoles")}
+//   - ctx context.Context
+func (_e *mockDataAccessRepository_Expecter) GetAccountRoles(ctx interface{}) *mockDataAccessRepository_GetAccountRoles_Call {
+	return &mockDataAccessRepository_GetAccountRoles_Call{Call: _e.mock.On("GetAccountRoles", ctx)}
 }
 
-func (_c *mockDataAccessRepository_GetAccountRoles_Call) Run(run func()) *mockDataAccessRepository_GetAccountRoles_Call {
+func (_c *mockDataAccessRepository_GetAccountRoles_Call) Run(run func(ctx context.Context)) *mockDataAccessRepository_GetAccountRoles_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		run(args[0].(context.Context))
 	})
 	return _c
 }
@@ -994,14 +1182,14 @@ func (_c *mockDataAccessRepository_GetAccountRoles_Call) Return(_a0 []RoleEntity
 	return _c
 }
 
-func (_c *mockDataAccessRepository_GetAccountRoles_Call) RunAndReturn(run func() ([]RoleEntity, error)) *mockDataAccessRepository_GetAccountRoles_Call {
+func (_c *mockDataAccessRepository_GetAccountRoles_Call) RunAndReturn(run func(context.Context) ([]RoleEntity, error)) *mockDataAccessRepository_GetAccountRoles_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetAccountRolesWithPrefix provides a mock function with given fields: prefix
-func (_m *mockDataAccessRepository) GetAccountRolesWithPrefix(prefix string) ([]RoleEntity, error) {
-	ret := _m.Called(prefix)
+// GetAccountRolesWithPrefix provides a mock function with given fields: ctx, prefix
+func (_m *mockDataAccessRepository) GetAccountRolesWithPrefix(ctx context.Context, prefix string) ([]RoleEntity, error) {
+	ret := _m.Called(ctx, prefix)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetAccountRolesWithPrefix")
@@ -1009,19 +1197,19 @@ func (_m *mockDataAccessRepository) GetAccountRolesWithPrefix(prefix string) ([]
 
 	var r0 []RoleEntity
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) ([]RoleEntity, error)); ok {
-		return rf(prefix)
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]RoleEntity, error)); ok {
+		return rf(ctx, prefix)
 	}
-	if rf, ok := ret.Get(0).(func(string) []RoleEntity); ok {
-		r0 = rf(prefix)
+	if rf, ok := ret.Get(0).(func(context.Context, string) []RoleEntity); ok {
+		r0 = rf(ctx, prefix)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]RoleEntity)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(prefix)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, prefix)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1035,14 +1223,15 @@ type mockDataAccessRepository_GetAccountRolesWithPrefix_Call struct {
 }
 
 // GetAccountRolesWithPrefix is a helper method to define mock.On call
+//   - ctx context.Context
 //   - prefix string
-func (_e *mockDataAccessRepository_Expecter) GetAccountRolesWithPrefix(prefix interface{}) *mockDataAccessRepository_GetAccountRolesWithPrefix_Call {
-	return &mockDataAccessRepository_GetAccountRolesWithPrefix_Call{Call: _e.mock.On("GetAccountRolesWithPrefix", prefix)}
+func (_e *mockDataAccessRepository_Expecter) GetAccountRolesWithPrefix(ctx interface{}, prefix interface{}) *mockDataAccessRepository_GetAccountRolesWithPrefix_Call {
+	return &mockDataAccessRepository_GetAccountRolesWithPrefix_Call{Call: _e.mock.On("GetAccountRolesWithPrefix", ctx, prefix)}
 }
 
-func (_c *mockDataAccessRepository_GetAccountRolesWithPrefix_Call) Run(run func(prefix string)) *mockDataAccessRepository_GetAccountRolesWithPrefix_Call {
+func (_c *mockDataAccessRepository_GetAccountRolesWithPrefix_Call) Run(run func(ctx context.Context, prefix string)) *mockDataAccessRepository_GetAccountRolesWithPrefix_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(context.Context), args[1].(string))
 	})
 	return _c
 }
@@ -1052,14 +1241,130 @@ func (_c *mockDataAccessRepository_GetAccountRolesWithPrefix_Call) Return(_a0 []
 	return _c
 }
 
-func (_c *mockDataAccessRepository_GetAccountRolesWithPrefix_Call) RunAndReturn(run func(string) ([]RoleEntity, error)) *mockDataAccessRepository_GetAccountRolesWithPrefix_Call {
+func (_c *mockDataAccessRepository_GetAccountRolesWithPrefix_Call) RunAndReturn(run func(context.Context, string) ([]RoleEntity, error)) *mockDataAccessRepository_GetAccountRolesWithPrefix_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAllGrantsOfAccountRoles provides a mock function with given fields: ctx
+func (_m *mockDataAccessRepository) GetAllGrantsOfAccountRoles(ctx context.Context) (map[string][]GrantOfRole, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllGrantsOfAccountRoles")
+	}
+
+	var r0 map[string][]GrantOfRole
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (map[string][]GrantOfRole, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) map[string][]GrantOfRole); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string][]GrantOfRole)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// mockDataAccessRepository_GetAllGrantsOfAccountRoles_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllGrantsOfAccountRoles'
+type mockDataAccessRepository_GetAllGrantsOfAccountRoles_Call struct {
+	*mock.Call
+}
+
+// GetAllGrantsOfAccountRoles is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *mockDataAccessRepository_Expecter) GetAllGrantsOfAccountRoles(ctx interface{}) *mockDataAccessRepository_GetAllGrantsOfAccountRoles_Call {
+	return &mockDataAccessRepository_GetAllGrantsOfAccountRoles_Call{Call: _e.mock.On("GetAllGrantsOfAccountRoles", ctx)}
+}
+
+func (_c *mockDataAccessRepository_GetAllGrantsOfAccountRoles_Call) Run(run func(ctx context.Context)) *mockDataAccessRepository_GetAllGrantsOfAccountRoles_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *mockDataAccessRepository_GetAllGrantsOfAccountRoles_Call) Return(_a0 map[string][]GrantOfRole, _a1 error) *mockDataAccessRepository_GetAllGrantsOfAccountRoles_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *mockDataAccessRepository_GetAllGrantsOfAccountRoles_Call) RunAndReturn(run func(context.Context) (map[string][]GrantOfRole, error)) *mockDataAccessRepository_GetAllGrantsOfAccountRoles_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAllGrantsToAccountRoles provides a mock function with given fields: ctx
+func (_m *mockDataAccessRepository) GetAllGrantsToAccountRoles(ctx context.Context) (map[string][]GrantToRole, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllGrantsToAccountRoles")
+	}
+
+	var r0 map[string][]GrantToRole
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (map[string][]GrantToRole, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) map[string][]GrantToRole); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string][]GrantToRole)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// mockDataAccessRepository_GetAllGrantsToAccountRoles_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllGrantsToAccountRoles'
+type mockDataAccessRepository_GetAllGrantsToAccountRoles_Call struct {
+	*mock.Call
+}
+
+// GetAllGrantsToAccountRoles is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *mockDataAccessRepository_Expecter) GetAllGrantsToAccountRoles(ctx interface{}) *mockDataAccessRepository_GetAllGrantsToAccountRoles_Call {
+	return &mockDataAccessRepository_GetAllGrantsToAccountRoles_Call{Call: _e.mock.On("GetAllGrantsToAccountRoles", ctx)}
+}
+
+func (_c *mockDataAccessRepository_GetAllGrantsToAccountRoles_Call) Run(run func(ctx context.Context)) *mockDataAccessRepository_GetAllGrantsToAccountRoles_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *mockDataAccessRepository_GetAllGrantsToAccountRoles_Call) Return(_a0 map[string][]GrantToRole, _a1 error) *mockDataAccessRepository_GetAllGrantsToAccountRoles_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *mockDataAccessRepository_GetAllGrantsToAccountRoles_Call) RunAndReturn(run func(context.Context) (map[string][]GrantToRole, error)) *mockDataAccessRepository_GetAllGrantsToAccountRoles_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetApplicationRoles provides a mock function with given fields: application
-func (_m *mockDataAccessRepository) GetApplicationRoles(application string) ([]ApplicationRoleEntity, error) {
-	ret := _m.Called(application)
+// GetApplicationRoles provides a mock function with given fields: ctx, application
+func (_m *mockDataAccessRepository) GetApplicationRoles(ctx context.Context, application string) ([]ApplicationRoleEntity, error) {
+	ret := _m.Called(ctx, application)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetApplicationRoles")
@@ -1067,19 +1372,19 @@ func (_m *mockDataAccessRepository) GetApplicationRoles(application string) ([]A
 
 	var r0 []ApplicationRoleEntity
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) ([]ApplicationRoleEntity, error)); ok {
-		return rf(application)
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]ApplicationRoleEntity, error)); ok {
+		return rf(ctx, application)
 	}
-	if rf, ok := ret.Get(0).(func(string) []ApplicationRoleEntity); ok {
-		r0 = rf(application)
+	if rf, ok := ret.Get(0).(func(context.Context, string) []ApplicationRoleEntity); ok {
+		r0 = rf(ctx, application)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]ApplicationRoleEntity)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(application)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, application)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1093,14 +1398,15 @@ type mockDataAccessRepository_GetApplicationRoles_Call struct {
 }
 
 // GetApplicationRoles is a helper method to define mock.On call
+//   - ctx context.Context
 //   - application string
-func (_e *mockDataAccessRepository_Expecter) GetApplicationRoles(application interface{}) *mockDataAccessRepository_GetApplicationRoles_Call {
-	return &mockDataAccessRepository_GetApplicationRoles_Call{Call: _e.mock.On("GetApplicationRoles", application)}
+func (_e *mockDataAccessRepository_Expecter) GetApplicationRoles(ctx interface{}, application interface{}) *mockDataAccessRepository_GetApplicationRoles_Call {
+	return &mockDataAccessRepository_GetApplicationRoles_Call{Call: _e.mock.On("GetApplicationRoles", ctx, application)}
 }
 
-func (_c *mockDataAccessRepository_GetApplicationRoles_Call) Run(run func(application string)) *mockDataAccessRepository_GetApplicationRoles_Call {
+func (_c *mockDataAccessRepository_GetApplicationRoles_Call) Run(run func(ctx context.Context, application string)) *mockDataAccessRepository_GetApplicationRoles_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(context.Context), args[1].(string))
 	})
 	return _c
 }
@@ -1110,14 +1416,14 @@ func (_c *mockDataAccessRepository_GetApplicationRoles_Call) Return(_a0 []Applic
 	return _c
 }
 
-func (_c *mockDataAccessRepository_GetApplicationRoles_Call) RunAndReturn(run func(string) ([]ApplicationRoleEntity, error)) *mockDataAccessRepository_GetApplicationRoles_Call {
+func (_c *mockDataAccessRepository_GetApplicationRoles_Call) RunAndReturn(run func(context.Context, string) ([]ApplicationRoleEntity, error)) *mockDataAccessRepository_GetApplicationRoles_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetApplications provides a mock function with no fields
-func (_m *mockDataAccessRepository) GetApplications() ([]ApplictionEntity, error) {
-	ret := _m.Called()
+// GetApplications provides a mock function with given fields: ctx
+func (_m *mockDataAccessRepository) GetApplications(ctx context.Context) ([]ApplictionEntity, error) {
+	ret := _m.Called(ctx)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetApplications")
@@ -1125,19 +1431,19 @@ func (_m *mockDataAccessRepository) GetApplications() ([]ApplictionEntity, error
 
 	var r0 []ApplictionEntity
 	var r1 error
-	if rf, ok := ret.Get(0).(func() ([]ApplictionEntity, error)); ok {
-		return rf()
+	if rf, ok := ret.Get(0).(func(context.Context) ([]ApplictionEntity, error)); ok {
+		return rf(ctx)
 	}
-	if rf, ok := ret.Get(0).(func() []ApplictionEntity); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(context.Context) []ApplictionEntity); ok {
+		r0 = rf(ctx)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]ApplictionEntity)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1151,13 +1457,14 @@ type mockDataAccessRepository_GetApplications_Call struct {
 }
 
 // GetApplications is a helper method to define mock.On call
-func (_e *mockDataAccessRepository_Expecter) GetApplications() *mockDataAccessRepository_GetApplications_Call {
-	return &mockDataAccessRepository_GetApplications_Call{Call: _e.mock.On("GetApplications")}
+//   - ctx context.Context
+func (_e *mockDataAccessRepository_Expecter) GetApplications(ctx interface{}) *mockDataAccessRepository_GetApplications_Call {
+	return &mockDataAccessRepository_GetApplications_Call{Call: _e.mock.On("GetApplications", ctx)}
 }
 
-func (_c *mockDataAccessRepository_GetApplications_Call) Run(run func()) *mockDataAccessRepository_GetApplications_Call {
+func (_c *mockDataAccessRepository_GetApplications_Call) Run(run func(ctx context.Context)) *mockDataAccessRepository_GetApplications_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		run(args[0].(context.Context))
 	})
 	return _c
 }
@@ -1167,14 +1474,14 @@ func (_c *mockDataAccessRepository_GetApplications_Call) Return(_a0 []Appliction
 	return _c
 }
 
-func (_c *mockDataAccessRepository_GetApplications_Call) RunAndReturn(run func() ([]ApplictionEntity, error)) *mockDataAccessRepository_GetApplications_Call {
+func (_c *mockDataAccessRepository_GetApplications_Call) RunAndReturn(run func(context.Context) ([]ApplictionEntity, error)) *mockDataAccessRepository_GetApplications_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetDatabaseRoleTags provides a mock function with given fields: databaseName, roleName
-func (_m *mockDataAccessRepository) GetDatabaseRoleTags(databaseName string, roleName string) (map[string][]*tag.Tag, error) {
-	ret := _m.Called(databaseName, roleName)
+// GetDatabaseRoleTags provides a mock function with given fields: ctx, databaseName, roleName
+func (_m *mockDataAccessRepository) GetDatabaseRoleTags(ctx context.Context, databaseName string, roleName string) (map[string][]*tag.Tag, error) {
+	ret := _m.Called(ctx, databaseName, roleName)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetDatabaseRoleTags")
@@ -1182,19 +1489,19 @@ func (_m *mockDataAccessRepository) GetDatabaseRoleTags(databaseName string, rol
 
 	var r0 map[string][]*tag.Tag
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, string) (map[string][]*tag.Tag, error)); ok {
-		return rf(databaseName, roleName)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (map[string][]*tag.Tag, error)); ok {
+		return rf(ctx, databaseName, roleName)
 	}
-	if rf, ok := ret.Get(0).(func(string, string) map[string][]*tag.Tag); ok {
-		r0 = rf(databaseName, roleName)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) map[string][]*tag.Tag); ok {
+		r0 = rf(ctx, databaseName, roleName)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(map[string][]*tag.Tag)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string, string) error); ok {
-		r1 = rf(databaseName, roleName)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, databaseName, roleName)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1208,15 +1515,16 @@ type mockDataAccessRepository_GetDatabaseRoleTags_Call struct {
 }
 
 // GetDatabaseRoleTags is a helper method to define mock.On call
+//   - ctx context.Context
 //   - databaseName string
 //   - roleName string
-func (_e *mockDataAccessRepository_Expecter) GetDatabaseRoleTags(databaseName interface{}, roleName interface{}) *mockDataAccessRepository_GetDatabaseRoleTags_Call {
-	return &mockDataAccessRepository_GetDatabaseRoleTags_Call{Call: _e.mock.On("GetDatabaseRoleTags", databaseName, roleName)}
+func (_e *mockDataAccessRepository_Expecter) GetDatabaseRoleTags(ctx interface{}, databaseName interface{}, roleName interface{}) *mockDataAccessRepository_GetDatabaseRoleTags_Call {
+	return &mockDataAccessRepository_GetDatabaseRoleTags_Call{Call: _e.mock.On("GetDatabaseRoleTags", ctx, databaseName, roleName)}
 }
 
-func (_c *mockDataAccessRepository_GetDatabaseRoleTags_Call) Run(run func(databaseName string, roleName string)) *mockDataAccessRepository_GetDatabaseRoleTags_Call {
+func (_c *mockDataAccessRepository_GetDatabaseRoleTags_Call) Run(run func(ctx context.Context, databaseName string, roleName string)) *mockDataAccessRepository_GetDatabaseRoleTags_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
 	})
 	return _c
 }
@@ -1226,14 +1534,14 @@ func (_c *mockDataAccessRepository_GetDatabaseRoleTags_Call) Return(_a0 map[stri
 	return _c
 }
 
-func (_c *mockDataAccessRepository_GetDatabaseRoleTags_Call) RunAndReturn(run func(string, string) (map[string][]*tag.Tag, error)) *mockDataAccessRepository_GetDatabaseRoleTags_Call {
+func (_c *mockDataAccessRepository_GetDatabaseRoleTags_Call) RunAndReturn(run func(context.Context, string, string) (map[string][]*tag.Tag, error)) *mockDataAccessRepository_GetDatabaseRoleTags_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetDatabaseRoles provides a mock function with given fields: database
-func (_m *mockDataAccessRepository) GetDatabaseRoles(database string) ([]RoleEntity, error) {
-	ret := _m.Called(database)
+// GetDatabaseRoles provides a mock function with given fields: ctx, database
+func (_m *mockDataAccessRepository) GetDatabaseRoles(ctx context.Context, database string) ([]RoleEntity, error) {
+	ret := _m.Called(ctx, database)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetDatabaseRoles")
@@ -1241,19 +1549,19 @@ func (_m *mockDataAccessRepository) GetDatabaseRoles(database string) ([]RoleEnt
 
 	var r0 []RoleEntity
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) ([]RoleEntity, error)); ok {
-		return rf(database)
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]RoleEntity, error)); ok {
+		return rf(ctx, database)
 	}
-	if rf, ok := ret.Get(0).(func(string) []RoleEntity); ok {
-		r0 = rf(database)
+	if rf, ok := ret.Get(0).(func(context.Context, string) []RoleEntity); ok {
+		r0 = rf(ctx, database)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]RoleEntity)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(database)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, database)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1267,14 +1575,15 @@ type mockDataAccessRepository_GetDatabaseRoles_Call struct {
 }
 
 // GetDatabaseRoles is a helper method to define mock.On call
+//   - ctx context.Context
 //   - database string
-func (_e *mockDataAccessRepository_Expecter) GetDatabaseRoles(database interface{}) *mockDataAccessRepository_GetDatabaseRoles_Call {
-	return &mockDataAccessRepository_GetDatabaseRoles_Call{Call: _e.mock.On("GetDatabaseRoles", database)}
+func (_e *mockDataAccessRepository_Expecter) GetDatabaseRoles(ctx interface{}, database interface{}) *mockDataAccessRepository_GetDatabaseRoles_Call {
+	return &mockDataAccessRepository_GetDatabaseRoles_Call{Call: _e.mock.On("GetDatabaseRoles", ctx, database)}
 }
 
-func (_c *mockDataAccessRepository_GetDatabaseRoles_Call) Run(run func(database string)) *mockDataAccessRepository_GetDatabaseRoles_Call {
+func (_c *mockDataAccessRepository_GetDatabaseRoles_Call) Run(run func(ctx context.Context, database string)) *mockDataAccessRepository_GetDatabaseRoles_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(context.Context), args[1].(string))
 	})
 	return _c
 }
@@ -1284,14 +1593,14 @@ func (_c *mockDataAccessRepository_GetDatabaseRoles_Call) Return(_a0 []RoleEntit
 	return _c
 }
 
-func (_c *mockDataAccessRepository_GetDatabaseRoles_Call) RunAndReturn(run func(string) ([]RoleEntity, error)) *mockDataAccessRepository_GetDatabaseRoles_Call {
+func (_c *mockDataAccessRepository_GetDatabaseRoles_Call) RunAndReturn(run func(context.Context, string) ([]RoleEntity, error)) *mockDataAccessRepository_GetDatabaseRoles_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetDatabaseRolesWithPrefix provides a mock function with given fields: database, prefix
-func (_m *mockDataAccessRepository) GetDatabaseRolesWithPrefix(database string, prefix string) ([]RoleEntity, error) {
-	ret := _m.Called(database, prefix)
+// GetDatabaseRolesWithPrefix provides a mock function with given fields: ctx, database, prefix
+func (_m *mockDataAccessRepository) GetDatabaseRolesWithPrefix(ctx context.Context, database string, prefix string) ([]RoleEntity, error) {
+	ret := _m.Called(ctx, database, prefix)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetDatabaseRolesWithPrefix")
@@ -1299,19 +1608,19 @@ func (_m *mockDataAccessRepository) GetDatabaseRolesWithPrefix(database string,
 
 	var r0 []RoleEntity
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, string) ([]RoleEntity, error)); ok {
-		return rf(database, prefix)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) ([]RoleEntity, error)); ok {
+		return rf(ctx, database, prefix)
 	}
-	if rf, ok := ret.Get(0).(func(string, string) []RoleEntity); ok {
-		r0 = rf(database, prefix)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) []RoleEntity); ok {
+		r0 = rf(ctx, database, prefix)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]RoleEntity)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string, string) error); ok {
-		r1 = rf(database, prefix)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, database, prefix)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1325,15 +1634,16 @@ type mockDataAccessRepository_GetDatabaseRolesWithPrefix_Call struct {
 }
 
 // GetDatabaseRolesWithPrefix is a helper method to define mock.On call
+//   - ctx context.Context
 //   - database string
 //   - prefix string
-func (_e *mockDataAccessRepository_Expecter) GetDatabaseRolesWithPrefix(database interface{}, prefix interface{}) *mockDataAccessRepository_GetDatabaseRolesWithPrefix_Call {
-	return &mockDataAccessRepository_GetDatabaseRolesWithPrefix_Call{Call: _e.mock.On("GetDatabaseRolesWithPrefix", database, prefix)}
+func (_e *mockDataAccessRepository_Expecter) GetDatabaseRolesWithPrefix(ctx interface{}, database interface{}, prefix interface{}) *mockDataAccessRepository_GetDatabaseRolesWithPrefix_Call {
+	return &mockDataAccessRepository_GetDatabaseRolesWithPrefix_Call{Call: _e.mock.On("GetDatabaseRolesWithPrefix", ctx, database, prefix)}
 }
 
-func (_c *mockDataAccessRepository_GetDatabaseRolesWithPrefix_Call) Run(run func(database string, prefix string)) *mockDataAccessRepository_GetDatabaseRolesWithPrefix_Call {
+func (_c *mockDataAccessRepository_GetDatabaseRolesWithPrefix_Call) Run(run func(ctx context.Context, database string, prefix string)) *mockDataAccessRepository_GetDatabaseRolesWithPrefix_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
 	})
 	return _c
 }
@@ -1343,14 +1653,14 @@ func (_c *mockDataAccessRepository_GetDatabaseRolesWithPrefix_Call) Return(_a0 [
 	return _c
 }
 
-func (_c *mockDataAccessRepository_GetDatabaseRolesWithPrefix_Call) RunAndReturn(run func(string, string) ([]RoleEntity, error)) *mockDataAccessRepository_GetDatabaseRolesWithPrefix_Call {
+func (_c *mockDataAccessRepository_GetDatabaseRolesWithPrefix_Call) RunAndReturn(run func(context.Context, string, string) ([]RoleEntity, error)) *mockDataAccessRepository_GetDatabaseRolesWithPrefix_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetDatabases provides a mock function with no fields
-func (_m *mockDataAccessRepository) GetDatabases() ([]DbEntity, error) {
-	ret := _m.Called()
+// GetDatabases provides a mock function with given fields: ctx
+func (_m *mockDataAccessRepository) GetDatabases(ctx context.Context) ([]DbEntity, error) {
+	ret := _m.Called(ctx)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetDatabases")
@@ -1358,19 +1668,19 @@ func (_m *mockDataAccessRepository) GetDatabases() ([]DbEntity, error) {
 
 	var r0 []DbEntity
 	var r1 error
-	if rf, ok := ret.Get(0).(func() ([]DbEntity, error)); ok {
-		return rf()
+	if rf, ok := ret.Get(0).(func(context.Context) ([]DbEntity, error)); ok {
+		return rf(ctx)
 	}
-	if rf, ok := ret.Get(0).(func() []DbEntity); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(context.Context) []DbEntity); ok {
+		r0 = rf(ctx)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]DbEntity)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1384,13 +1694,14 @@ type mockDataAccessRepository_GetDatabases_Call struct {
 }
 
 // GetDatabases is a helper method to define mock.On call
-func (_e *mockDataAccessRepository_Expecter) GetDatabases() *mockDataAccessRepository_GetDatabases_Call {
-	return &mockDataAccessRepository_GetDatabases_Call{Call: _e.mock.On("GetDatabases")}
+//   - ctx context.Context
+func (_e *mockDataAccessRepository_Expecter) GetDatabases(ctx interface{}) *mockDataAccessRepository_GetDatabases_Call {
+	return &mockDataAccessRepository_GetDatabases_Call{Call: _e.mock.On("GetDatabases", ctx)}
 }
 
-func (_c *mockDataAccessRepository_GetDatabases_Call) Run(run func()) *mockDataAccessRepository_GetDatabases_Call {
+func (_c *mockDataAccessRepository_GetDatabases_Call) Run(run func(ctx context.Context)) *mockDataAccessRepository_GetDatabases_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		run(args[0].(context.Context))
 	})
 	return _c
 }
@@ -1400,14 +1711,14 @@ func (_c *mockDataAccessRepository_GetDatabases_Call) Return(_a0 []DbEntity, _a1
 	return _c
 }
 
-func (_c *mockDataAccessRepository_GetDatabases_Call) RunAndReturn(run func() ([]DbEntity, error)) *mockDataAccessRepository_GetDatabases_Call {
+func (_c *mockDataAccessRepository_GetDatabases_Call) RunAndReturn(run func(context.Context) ([]DbEntity, error)) *mockDataAccessRepository_GetDatabases_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetDatabasesByKind provides a mock function with given fields: kind
-func (_m *mockDataAccessRepository) GetDatabasesByKind(kind string) ([]DbEntity, error) {
-	ret := _m.Called(kind)
+// GetDatabasesByKind provides a mock function with given fields: ctx, kind
+func (_m *mockDataAccessRepository) GetDatabasesByKind(ctx context.Context, kind string) ([]DbEntity, error) {
+	ret := _m.Called(ctx, kind)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetDatabasesByKind")
@@ -1415,19 +1726,19 @@ func (_m *mockDataAccessRepository) GetDatabasesByKind(kind string) ([]DbEntity,
 
 	var r0 []DbEntity
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) ([]DbEntity, error)); ok {
-		return rf(kind)
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]DbEntity, error)); ok {
+		return rf(ctx, kind)
 	}
-	if rf, ok := ret.Get(0).(func(string) []DbEntity); ok {
-		r0 = rf(kind)
+	if rf, ok := ret.Get(0).(func(context.Context, string) []DbEntity); ok {
+		r0 = rf(ctx, kind)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]DbEntity)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(kind)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, kind)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1441,14 +1752,15 @@ type mockDataAccessRepository_GetDatabasesByKind_Call struct {
 }
 
 // GetDatabasesByKind is a helper method to define mock.On call
+//   - ctx context.Context
 //   - kind string
-func (_e *mockDataAccessRepository_Expecter) GetDatabasesByKind(kind interface{}) *mockDataAccessRepository_GetDatabasesByKind_Call {
-	return &mockDataAccessRepository_GetDatabasesByKind_Call{Call: _e.mock.On("GetDatabasesByKind", kind)}
+func (_e *mockDataAccessRepository_Expecter) GetDatabasesByKind(ctx interface{}, kind interface{}) *mockDataAccessRepository_GetDatabasesByKind_Call {
+	return &mockDataAccessRepository_GetDatabasesByKind_Call{Call: _e.mock.On("GetDatabasesByKind", ctx, kind)}
 }
 
-func (_c *mockDataAccessRepository_GetDatabasesByKind_Call) Run(run func(kind string)) *mockDataAccessRepository_GetDatabasesByKind_Call {
+func (_c *mockDataAccessRepository_GetDatabasesByKind_Call) Run(run func(ctx context.Context, kind string)) *mockDataAccessRepository_GetDatabasesByKind_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(context.Context), args[1].(string))
 	})
 	return _c
 }
@@ -1458,22 +1770,22 @@ func (_c *mockDataAccessRepository_GetDatabasesByKind_Call) Return(_a0 []DbEntit
 	return _c
 }
 
-func (_c *mockDataAccessRepository_GetDatabasesByKind_Call) RunAndReturn(run func(string) ([]DbEntity, error)) *mockDataAccessRepository_GetDatabasesByKind_Call {
+func (_c *mockDataAccessRepository_GetDatabasesByKind_Call) RunAndReturn(run func(context.Context, string) ([]DbEntity, error)) *mockDataAccessRepository_GetDatabasesByKind_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetFunctionsInDatabase provides a mock function with given fields: databaseName, handleEntity
-func (_m *mockDataAccessRepository) GetFunctionsInDatabase(databaseName string, handleEntity EntityHandler) error {
-	ret := _m.Called(databaseName, handleEntity)
+// GetFunctionsInDatabase provides a mock function with given fields: ctx, databaseName, handleEntity
+func (_m *mockDataAccessRepository) GetFunctionsInDatabase(ctx context.Context, databaseName string, handleEntity EntityHandler) error {
+	ret := _m.Called(ctx, databaseName, handleEntity)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetFunctionsInDatabase")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, EntityHandler) error); ok {
-		r0 = rf(databaseName, handleEntity)
+	if rf, ok := ret.Get(0).(func(context.Context, string, EntityHandler) error); ok {
+		r0 = rf(ctx, databaseName, handleEntity)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -1487,15 +1799,16 @@ type mockDataAccessRepository_GetFunctionsInDatabase_Call struct {
 }
 
 // GetFunctionsInDatabase is a helper method to define mock.On call
+//   - ctx context.Context
 //   - databaseName string
 //   - handleEntity EntityHandler
-func (_e *mockDataAccessRepository_Expecter) GetFunctionsInDatabase(databaseName interface{}, handleEntity interface{}) *mockDataAccessRepository_GetFunctionsInDatabase_Call {
-	return &mockDataAccessRepository_GetFunctionsInDatabase_Call{Call: _e.mock.On("GetFunctionsInDatabase", databaseName, handleEntity)}
+func (_e *mockDataAccessRepository_Expecter) GetFunctionsInDatabase(ctx interface{}, databaseName interface{}, handleEntity interface{}) *mockDataAccessRepository_GetFunctionsInDatabase_Call {
+	return &mockDataAccessRepository_GetFunctionsInDatabase_Call{Call: _e.mock.On("GetFunctionsInDatabase", ctx, databaseName, handleEntity)}
 }
 
-func (_c *mockDataAccessRepository_GetFunctionsInDatabase_Call) Run(run func(databaseName string, handleEntity EntityHandler)) *mockDataAccessRepository_GetFunctionsInDatabase_Call {
+func (_c *mockDataAccessRepository_GetFunctionsInDatabase_Call) Run(run func(ctx context.Context, databaseName string, handleEntity EntityHandler)) *mockDataAccessRepository_GetFunctionsInDatabase_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(EntityHandler))
+		run(args[0].(context.Context), args[1].(string), args[2].(EntityHandler))
 	})
 	return _c
 }
@@ -1505,22 +1818,22 @@ func (_c *mockDataAccessRepository_GetFunctionsInDatabase_Call) Return(_a0 error
 	return _c
 }
 
-func (_c *mockDataAccessRepository_GetFunctionsInDatabase_Call) RunAndReturn(run func(string, EntityHandler) error) *mockDataAccessRepository_GetFunctionsInDatabase_Call {
+func (_c *mockDataAccessRepository_GetFunctionsInDatabase_Call) RunAndReturn(run func(context.Context, string, EntityHandler) error) *mockDataAccessRepository_GetFunctionsInDatabase_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetFunctionsInSchema provides a mock function with given fields: databaseName, schemaName, handleEntity
-func (_m *mockDataAccessRepository) GetFunctionsInSchema(databaseName string, schemaName string, handleEntity EntityHandler) error {
-	ret := _m.Called(databaseName, schemaName, handleEntity)
+// GetFunctionsInSchema provides a mock function with given fields: ctx, databaseName, schemaName, handleEntity
+func (_m *mockDataAccessRepository) GetFunctionsInSchema(ctx context.Context, databaseName string, schemaName string, handleEntity EntityHandler) error {
+	ret := _m.Called(ctx, databaseName, schemaName, handleEntity)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetFunctionsInSchema")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, string, EntityHandler) error); ok {
-		r0 = rf(databaseName, schemaName, handleEntity)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, EntityHandler) error); ok {
+		r0 = rf(ctx, databaseName, schemaName, handleEntity)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -1534,16 +1847,17 @@ type mockDataAccessRepository_GetFunctionsInSchema_Call struct {
 }
 
 // GetFunctionsInSchema is a helper method to define mock.On call
+//   - ctx context.Context
 //   - databaseName string
 //   - schemaName string
 //   - handleEntity EntityHandler
-func (_e *mockDataAccessRepository_Expecter) GetFunctionsInSchema(databaseName interface{}, schemaName interface{}, handleEntity interface{}) *mockDataAccessRepository_GetFunctionsInSchema_Call {
-	return &mockDataAccessRepository_GetFunctionsInSchema_Call{Call: _e.mock.On("GetFunctionsInSchema", databaseName, schemaName, handleEntity)}
+func (_e *mockDataAccessRepository_Expecter) GetFunctionsInSchema(ctx interface{}, databaseName interface{}, schemaName interface{}, handleEntity interface{}) *mockDataAccessRepository_GetFunctionsInSchema_Call {
+	return &mockDataAccessRepository_GetFunctionsInSchema_Call{Call: _e.mock.On("GetFunctionsInSchema", ctx, databaseName, schemaName, handleEntity)}
 }
 
-func (_c *mockDataAccessRepository_GetFunctionsInSchema_Call) Run(run func(databaseName string, schemaName string, handleEntity EntityHandler)) *mockDataAccessRepository_GetFunctionsInSchema_Call {
+func (_c *mockDataAccessRepository_GetFunctionsInSchema_Call) Run(run func(ctx context.Context, databaseName string, schemaName string, handleEntity EntityHandler)) *mockDataAccessRepository_GetFunctionsInSchema_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(string), args[2].(EntityHandler))
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(EntityHandler))
 	})
 	return _c
 }
@@ -1553,14 +1867,14 @@ func (_c *mockDataAccessRepository_GetFunctionsInSchema_Call) Return(_a0 error)
 	return _c
 }
 
-func (_c *mockDataAccessRepository_GetFunctionsInSchema_Call) RunAndReturn(run func(string, string, EntityHandler) error) *mockDataAccessRepository_GetFunctionsInSchema_Call {
+func (_c *mockDataAccessRepository_GetFunctionsInSchema_Call) RunAndReturn(run func(context.Context, string, string, EntityHandler) error) *mockDataAccessRepository_GetFunctionsInSchema_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetGrantsOfAccountRole provides a mock function with given fields: roleName
-func (_m *mockDataAccessRepository) GetGrantsOfAccountRole(roleName string) ([]GrantOfRole, error) {
-	ret := _m.Called(roleName)
+// GetGrantsOfAccountRole provides a mock function with given fields: ctx, roleName
+func (_m *mockDataAccessRepository) GetGrantsOfAccountRole(ctx context.Context, roleName string) ([]GrantOfRole, error) {
+	ret := _m.Called(ctx, roleName)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetGrantsOfAccountRole")
@@ -1568,19 +1882,19 @@ func (_m *mockDataAccessRepository) GetGrantsOfAccountRole(roleName string) ([]G
 
 	var r0 []GrantOfRole
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) ([]GrantOfRole, error)); ok {
-		return rf(roleName)
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]GrantOfRole, error)); ok {
+		return rf(ctx, roleName)
 	}
-	if rf, ok := ret.Get(0).(func(string) []GrantOfRole); ok {
-		r0 = rf(roleName)
+	if rf, ok := ret.Get(0).(func(context.Context, string) []GrantOfRole); ok {
+		r0 = rf(ctx, roleName)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]GrantOfRole)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(roleName)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, roleName)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1594,14 +1908,15 @@ type mockDataAccessRepository_GetGrantsOfAccountRole_Call struct {
 }
 
 // GetGrantsOfAccountRole is a helper method to define mock.On call
+//   - ctx context.Context
 //   - roleName string
-func (_e *mockDataAccessRepository_Expecter) GetGrantsOfAccountRole(roleName interface{}) *mockDataAccessRepository_GetGrantsOfAccountRole_Call {
-	return &mockDataAccessRepository_GetGrantsOfAccountRole_Call{Call: _e.mock.On("GetGrantsOfAccountRole", roleName)}
+func (_e *mockDataAccessRepository_Expecter) GetGrantsOfAccountRole(ctx interface{}, roleName interface{}) *mockDataAccessRepository_GetGrantsOfAccountRole_Call {
+	return &mockDataAccessRepository_GetGrantsOfAccountRole_Call{Call: _e.mock.On("GetGrantsOfAccountRole", ctx, roleName)}
 }
 
-func (_c *mockDataAccessRepository_GetGrantsOfAccountRole_Call) Run(run func(roleName string)) *mockDataAccessRepository_GetGrantsOfAccountRole_Call {
+func (_c *mockDataAccessRepository_GetGrantsOfAccountRole_Call) Run(run func(ctx context.Context, roleName string)) *mockDataAccessRepository_GetGrantsOfAccountRole_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(context.Context), args[1].(string))
 	})
 	return _c
 }
@@ -1611,14 +1926,14 @@ func (_c *mockDataAccessRepository_GetGrantsOfAccountRole_Call) Return(_a0 []Gra
 	return _c
 }
 
-func (_c *mockDataAccessRepository_GetGrantsOfAccountRole_Call) RunAndReturn(run func(string) ([]GrantOfRole, error)) *mockDataAccessRepository_GetGrantsOfAccountRole_Call {
+func (_c *mockDataAccessRepository_GetGrantsOfAccountRole_Call) RunAndReturn(run func(context.Context, string) ([]GrantOfRole, error)) *mockDataAccessRepository_GetGrantsOfAccountRole_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetGrantsOfApplicationRole provides a mock function with given fields: application, role
-func (_m *mockDataAccessRepository) GetGrantsOfApplicationRole(application string, role string) ([]GrantOfRole, error) {
-	ret := _m.Called(application, role)
+// GetGrantsOfApplicationRole provides a mock function with given fields: ctx, application, role
+func (_m *mockDataAccessRepository) GetGrantsOfApplicationRole(ctx context.Context, application string, role string) ([]GrantOfRole, error) {
+	ret := _m.Called(ctx, application, role)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetGrantsOfApplicationRole")
@@ -1626,19 +1941,19 @@ func (_m *mockDataAccessRepository) GetGrantsOfApplicationRole(application strin
 
 	var r0 []GrantOfRole
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, string) ([]GrantOfRole, error)); ok {
-		return rf(application, role)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) ([]GrantOfRole, error)); ok {
+		return rf(ctx, application, role)
 	}
-	if rf, ok := ret.Get(0).(func(string, string) []GrantOfRole); ok {
-		r0 = rf(application, role)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) []GrantOfRole); ok {
+		r0 = rf(ctx, application, role)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]GrantOfRole)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string, string) error); ok {
-		r1 = rf(application, role)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, application, role)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1652,15 +1967,16 @@ type mockDataAccessRepository_GetGrantsOfApplicationRole_Call struct {
 }
 
 // GetGrantsOfApplicationRole is a helper method to define mock.On call
+//   - ctx context.Context
 //   - application string
 //   - role string
-func (_e *mockDataAccessRepository_Expecter) GetGrantsOfApplicationRole(application interface{}, role interface{}) *mockDataAccessRepository_GetGrantsOfApplicationRole_Call {
-	return &mockDataAccessRepository_GetGrantsOfApplicationRole_Call{Call: _e.mock.On("GetGrantsOfApplicationRole", application, role)}
+func (_e *mockDataAccessRepository_Expecter) GetGrantsOfApplicationRole(ctx interface{}, application interface{}, role interface{}) *mockDataAccessRepository_GetGrantsOfApplicationRole_Call {
+	return &mockDataAccessRepository_GetGrantsOfApplicationRole_Call{Call: _e.mock.On("GetGrantsOfApplicationRole", ctx, application, role)}
 }
 
-func (_c *mockDataAccessRepository_GetGrantsOfApplicationRole_Call) Run(run func(application string, role string)) *mockDataAccessRepository_GetGrantsOfApplicationRole_Call {
+func (_c *mockDataAccessRepository_GetGrantsOfApplicationRole_Call) Run(run func(ctx context.Context, application string, role string)) *mockDataAccessRepository_GetGrantsOfApplicationRole_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
 	})
 	return _c
 }
@@ -1670,14 +1986,14 @@ func (_c *mockDataAccessRepository_GetGrantsOfApplicationRole_Call) Return(_a0 [
 	return _c
 }
 
-func (_c *mockDataAccessRepository_GetGrantsOfApplicationRole_Call) RunAndReturn(run func(string, string) ([]GrantOfRole, error)) *mockDataAccessRepository_GetGrantsOfApplicationRole_Call {
+func (_c *mockDataAccessRepository_GetGrantsOfApplicationRole_Call) RunAndReturn(run func(context.Context, string, string) ([]GrantOfRole, error)) *mockDataAccessRepository_GetGrantsOfApplicationRole_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetGrantsOfDatabaseRole provides a mock function with given fields: database, roleName
-func (_m *mockDataAccessRepository) GetGrantsOfDatabaseRole(database string, roleName string) ([]GrantOfRole, error) {
-	ret := _m.Called(database, roleName)
+// GetGrantsOfDatabaseRole provides a mock function with given fields: ctx, database, roleName
+func (_m *mockDataAccessRepository) GetGrantsOfDatabaseRole(ctx context.Context, database string, roleName string) ([]GrantOfRole, error) {
+	ret := _m.Called(ctx, database, roleName)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetGrantsOfDatabaseRole")
@@ -1685,19 +2001,19 @@ func (_m *mockDataAccessRepository) GetGrantsOfDatabaseRole(database string, rol
 
 	var r0 []GrantOfRole
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, string) ([]GrantOfRole, error)); ok {
-		return rf(database, roleName)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) ([]GrantOfRole, error)); ok {
+		return rf(ctx, database, roleName)
 	}
-	if rf, ok := ret.Get(0).(func(string, string) []GrantOfRole); ok {
-		r0 = rf(database, roleName)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) []GrantOfRole); ok {
+		r0 = rf(ctx, database, roleName)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]GrantOfRole)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string, string) error); ok {
-		r1 = rf(database, roleName)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, database, roleName)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1711,15 +2027,16 @@ type mockDataAccessRepository_GetGrantsOfDatabaseRole_Call struct {
 }
 
 // GetGrantsOfDatabaseRole is a helper method to define mock.On call
+//   - ctx context.Context
 //   - database string
 //   - roleName string
-func (_e *mockDataAccessRepository_Expecter) GetGrantsOfDatabaseRole(database interface{}, roleName interface{}) *mockDataAccessRepository_GetGrantsOfDatabaseRole_Call {
-	return &mockDataAccessRepository_GetGrantsOfDatabaseRole_Call{Call: _e.mock.On("GetGrantsOfDatabaseRole", database, roleName)}
+func (_e *mockDataAccessRepository_Expecter) GetGrantsOfDatabaseRole(ctx interface{}, database interface{}, roleName interface{}) *mockDataAccessRepository_GetGrantsOfDatabaseRole_Call {
+	return &mockDataAccessRepository_GetGrantsOfDatabaseRole_Call{Call: _e.mock.On("GetGrantsOfDatabaseRole", ctx, database, roleName)}
 }
 
-func (_c *mockDataAccessRepository_GetGrantsOfDatabaseRole_Call) Run(run func(database string, roleName string)) *mockDataAccessRepository_GetGrantsOfDatabaseRole_Call {
+func (_c *mockDataAccessRepository_GetGrantsOfDatabaseRole_Call) Run(run func(ctx context.Context, database string, roleName string)) *mockDataAccessRepository_GetGrantsOfDatabaseRole_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
 	})
 	return _c
 }
@@ -1729,14 +2046,14 @@ func (_c *mockDataAccessRepository_GetGrantsOfDatabaseRole_Call) Return(_a0 []Gr
 	return _c
 }
 
-func (_c *mockDataAccessRepository_GetGrantsOfDatabaseRole_Call) RunAndReturn(run func(string, string) ([]GrantOfRole, error)) *mockDataAccessRepository_GetGrantsOfDatabaseRole_Call {
+func (_c *mockDataAccessRepository_GetGrantsOfDatabaseRole_Call) RunAndReturn(run func(context.Context, string, string) ([]GrantOfRole, error)) *mockDataAccessRepository_GetGrantsOfDatabaseRole_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetGrantsToAccountRole provides a mock function with given fields: roleName
-func (_m *mockDataAccessRepository) GetGrantsToAccountRole(roleName string) ([]GrantToRole, error) {
-	ret := _m.Called(roleName)
+// GetGrantsToAccountRole provides a mock function with given fields: ctx, roleName
+func (_m *mockDataAccessRepository) GetGrantsToAccountRole(ctx context.Context, roleName string) ([]GrantToRole, error) {
+	ret := _m.Called(ctx, roleName)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetGrantsToAccountRole")
@@ -1744,19 +2061,19 @@ func (_m *mockDataAccessRepository) GetGrantsToAccountRole(roleName string) ([]G
 
 	var r0 []GrantToRole
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) ([]GrantToRole, error)); ok {
-		return rf(roleName)
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]GrantToRole, error)); ok {
+		return rf(ctx, roleName)
 	}
-	if rf, ok := ret.Get(0).(func(string) []GrantToRole); ok {
-		r0 = rf(roleName)
+	if rf, ok := ret.Get(0).(func(context.Context, string) []GrantToRole); ok {
+		r0 = rf(ctx, roleName)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]GrantToRole)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(roleName)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, roleName)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1770,14 +2087,15 @@ type mockDataAccessRepository_GetGrantsToAccountRole_Call struct {
 }
 
 // GetGrantsToAccountRole is a helper method to define mock.On call
+//   - ctx context.Context
 //   - roleName string
-func (_e *mockDataAccessRepository_Expecter) GetGrantsToAccountRole(roleName interface{}) *mockDataAccessRepository_GetGrantsToAccountRole_Call {
-	return &mockDataAccessRepository_GetGrantsToAccountRole_Call{Call: _e.mock.On("GetGrantsToAccountRole", roleName)}
+func (_e *mockDataAccessRepository_Expecter) GetGrantsToAccountRole(ctx interface{}, roleName interface{}) *mockDataAccessRepository_GetGrantsToAccountRole_Call {
+	return &mockDataAccessRepository_GetGrantsToAccountRole_Call{Call: _e.mock.On("GetGrantsToAccountRole", ctx, roleName)}
 }
 
-func (_c *mockDataAccessRepository_GetGrantsToAccountRole_Call) Run(run func(roleName string)) *mockDataAccessRepository_GetGrantsToAccountRole_Call {
+func (_c *mockDataAccessRepository_GetGrantsToAccountRole_Call) Run(run func(ctx context.Context, roleName string)) *mockDataAccessRepository_GetGrantsToAccountRole_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(context.Context), args[1].(string))
 	})
 	return _c
 }
@@ -1787,14 +2105,14 @@ func (_c *mockDataAccessRepository_GetGrantsToAccountRole_Call) Return(_a0 []Gra
 	return _c
 }
 
-func (_c *mockDataAccessRepository_GetGrantsToAccountRole_Call) RunAndReturn(run func(string) ([]GrantToRole, error)) *mockDataAccessRepository_GetGrantsToAccountRole_Call {
+func (_c *mockDataAccessRepository_GetGrantsToAccountRole_Call) RunAndReturn(run func(context.Context, string) ([]GrantToRole, error)) *mockDataAccessRepository_GetGrantsToAccountRole_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetGrantsToDatabaseRole provides a mock function with given fields: database, roleName
-func (_m *mockDataAccessRepository) GetGrantsToDatabaseRole(database string, roleName string) ([]GrantToRole, error) {
-	ret := _m.Called(database, roleName)
+// GetGrantsToDatabaseRole provides a mock function with given fields: ctx, database, roleName
+func (_m *mockDataAccessRepository) GetGrantsToDatabaseRole(ctx context.Context, database string, roleName string) ([]GrantToRole, error) {
+	ret := _m.Called(ctx, database, roleName)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetGrantsToDatabaseRole")
@@ -1802,19 +2120,19 @@ func (_m *mockDataAccessRepository) GetGrantsToDatabaseRole(database string, rol
 
 	var r0 []GrantToRole
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, string) ([]GrantToRole, error)); ok {
-		return rf(database, roleName)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) ([]GrantToRole, error)); ok {
+		return rf(ctx, database, roleName)
 	}
-	if rf, ok := ret.Get(0).(func(string, string) []GrantToRole); ok {
-		r0 = rf(database, roleName)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) []GrantToRole); ok {
+		r0 = rf(ctx, database, roleName)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]GrantToRole)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string, string) error); ok {
-		r1 = rf(database, roleName)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, database, roleName)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1828,15 +2146,16 @@ type mockDataAccessRepository_GetGrantsToDatabaseRole_Call struct {
 }
 
 // GetGrantsToDatabaseRole is a helper method to define mock.On call
+//   - ctx context.Context
 //   - database string
 //   - roleName string
-func (_e *mockDataAccessRepository_Expecter) GetGrantsToDatabaseRole(database interface{}, roleName interface{}) *mockDataAccessRepository_GetGrantsToDatabaseRole_Call {
-	return &mockDataAccessRepository_GetGrantsToDatabaseRole_Call{Call: _e.mock.On("GetGrantsToDatabaseRole", database, roleName)}
+func (_e *mockDataAccessRepository_Expecter) GetGrantsToDatabaseRole(ctx interface{}, database interface{}, roleName interface{}) *mockDataAccessRepository_GetGrantsToDatabaseRole_Call {
+	return &mockDataAccessRepository_GetGrantsToDatabaseRole_Call{Call: _e.mock.On("GetGrantsToDatabaseRole", ctx, database, roleName)}
 }
 
-func (_c *mockDataAccessRepository_GetGrantsToDatabaseRole_Call) Run(run func(database string, roleName string)) *mockDataAccessRepository_GetGrantsToDatabaseRole_Call {
+func (_c *mockDataAccessRepository_GetGrantsToDatabaseRole_Call) Run(run func(ctx context.Context, database string, roleName string)) *mockDataAccessRepository_GetGrantsToDatabaseRole_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
 	})
 	return _c
 }
@@ -1846,14 +2165,14 @@ func (_c *mockDataAccessRepository_GetGrantsToDatabaseRole_Call) Return(_a0 []Gr
 	return _c
 }
 
-func (_c *mockDataAccessRepository_GetGrantsToDatabaseRole_Call) RunAndReturn(run func(string, string) ([]GrantToRole, error)) *mockDataAccessRepository_GetGrantsToDatabaseRole_Call {
+func (_c *mockDataAccessRepository_GetGrantsToDatabaseRole_Call) RunAndReturn(run func(context.Context, string, string) ([]GrantToRole, error)) *mockDataAccessRepository_GetGrantsToDatabaseRole_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetGrantsToShare provides a mock function with given fields: shareName
-func (_m *mockDataAccessRepository) GetGrantsToShare(shareName string) ([]GrantToRole, error) {
-	ret := _m.Called(shareName)
+// GetGrantsToShare provides a mock function with given fields: ctx, shareName
+func (_m *mockDataAccessRepository) GetGrantsToShare(ctx context.Context, shareName string) ([]GrantToRole, error) {
+	ret := _m.Called(ctx, shareName)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetGrantsToShare")
@@ -1861,19 +2180,19 @@ func (_m *mockDataAccessRepository) GetGrantsToShare(shareName string) ([]GrantT
 
 	var r0 []GrantToRole
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) ([]GrantToRole, error)); ok {
-		return rf(shareName)
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]GrantToRole, error)); ok {
+		return rf(ctx, shareName)
 	}
-	if rf, ok := ret.Get(0).(func(string) []GrantToRole); ok {
-		r0 = rf(shareName)
+	if rf, ok := ret.Get(0).(func(context.Context, string) []GrantToRole); ok {
+		r0 = rf(ctx, shareName)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]GrantToRole)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(shareName)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, shareName)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1887,14 +2206,15 @@ type mockDataAccessRepository_GetGrantsToShare_Call struct {
 }
 
 // GetGrantsToShare is a helper method to define mock.On call
+//   - ctx context.Context
 //   - shareName string
-func (_e *mockDataAccessRepository_Expecter) GetGrantsToShare(shareName interface{}) *mockDataAccessRepository_GetGrantsToShare_Call {
-	return &mockDataAccessRepository_GetGrantsToShare_Call{Call: _e.mock.On("GetGrantsToShare", shareName)}
+func (_e *mockDataAccessRepository_Expecter) GetGrantsToShare(ctx interface{}, shareName interface{}) *mockDataAccessRepository_GetGrantsToShare_Call {
+	return &mockDataAccessRepository_GetGrantsToShare_Call{Call: _e.mock.On("GetGrantsToShare", ctx, shareName)}
 }
 
-func (_c *mockDataAccessRepository_GetGrantsToShare_Call) Run(run func(shareName string)) *mockDataAccessRepository_GetGrantsToShare_Call {
+func (_c *mockDataAccessRepository_GetGrantsToShare_Call) Run(run func(ctx context.Context, shareName string)) *mockDataAccessRepository_GetGrantsToShare_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(context.Context), args[1].(string))
 	})
 	return _c
 }
@@ -1904,14 +2224,14 @@ func (_c *mockDataAccessRepository_GetGrantsToShare_Call) Return(_a0 []GrantToRo
 	return _c
 }
 
-func (_c *mockDataAccessRepository_GetGrantsToShare_Call) RunAndReturn(run func(string) ([]GrantToRole, error)) *mockDataAccessRepository_GetGrantsToShare_Call {
+func (_c *mockDataAccessRepository_GetGrantsToShare_Call) RunAndReturn(run func(context.Context, string) ([]GrantToRole, error)) *mockDataAccessRepository_GetGrantsToShare_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetInboundShares provides a mock function with no fields
-func (_m *mockDataAccessRepository) GetInboundShares() ([]DbEntity, error) {
-	ret := _m.Called()
+// GetInboundShares provides a mock function with given fields: ctx
+func (_m *mockDataAccessRepository) GetInboundShares(ctx context.Context) ([]DbEntity, error) {
+	ret := _m.Called(ctx)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetInboundShares")
@@ -1919,19 +2239,19 @@ func (_m *mockDataAccessRepository) GetInboundShares() ([]DbEntity, error) {
 
 	var r0 []DbEntity
 	var r1 error
-	if rf, ok := ret.Get(0).(func() ([]DbEntity, error)); ok {
-		return rf()
+	if rf, ok := ret.Get(0).(func(context.Context) ([]DbEntity, error)); ok {
+		return rf(ctx)
 	}
-	if rf, ok := ret.Get(0).(func() []DbEntity); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(context.Context) []DbEntity); ok {
+		r0 = rf(ctx)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]DbEntity)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1945,13 +2265,14 @@ type mockDataAccessRepository_GetInboundShares_Call struct {
 }
 
 // GetInboundShares is a helper method to define mock.On call
-func (_e *mockDataAccessRepository_Expecter) GetInboundShares() *mockDataAccessRepository_GetInboundShares_Call {
-	return &mockDataAccessRepository_GetInboundShares_Call{Call: _e.mock.On("GetInboundShares")}
+//   - ctx context.Context
+func (_e *mockDataAccessRepository_Expecter) GetInboundShares(ctx interface{}) *mockDataAccessRepository_GetInboundShares_Call {
+	return &mockDataAccessRepository_GetInboundShares_Call{Call: _e.mock.On("GetInboundShares", ctx)}
 }
 
-func (_c *mockDataAccessRepository_GetInboundShares_Call) Run(run func()) *mockDataAccessRepository_GetInboundShares_Call {
+func (_c *mockDataAccessRepository_GetInboundShares_Call) Run(run func(ctx context.Context)) *mockDataAccessRepository_GetInboundShares_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		run(args[0].(context.Context))
 	})
 	return _c
 }
@@ -1961,14 +2282,14 @@ func (_c *mockDataAccessRepository_GetInboundShares_Call) Return(_a0 []DbEntity,
 	return _c
 }
 
-func (_c *mockDataAccessRepository_GetInboundShares_Call) RunAndReturn(run func() ([]DbEntity, error)) *mockDataAccessRepository_GetInboundShares_Call {
+func (_c *mockDataAccessRepository_GetInboundShares_Call) RunAndReturn(run func(context.Context) ([]DbEntity, error)) *mockDataAccessRepository_GetInboundShares_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetIntegrations provides a mock function with no fields
-func (_m *mockDataAccessRepository) GetIntegrations() ([]DbEntity, error) {
-	ret := _m.Called()
+// GetIntegrations provides a mock function with given fields: ctx
+func (_m *mockDataAccessRepository) GetIntegrations(ctx context.Context) ([]DbEntity, error) {
+	ret := _m.Called(ctx)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetIntegrations")
@@ -1976,19 +2297,19 @@ func (_m *mockDataAccessRepository) GetIntegrations() ([]DbEntity, error) {
 
 	var r0 []DbEntity
 	var r1 error
-	if rf, ok := ret.Get(0).(func() ([]DbEntity, error)); ok {
-		return rf()
+	if rf, ok := ret.Get(0).(func(context.Context) ([]DbEntity, error)); ok {
+		return rf(ctx)
 	}
-	if rf, ok := ret.Get(0).(func() []DbEntity); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(context.Context) []DbEntity); ok {
+		r0 = rf(ctx)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]DbEntity)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -2002,13 +2323,14 @@ type mockDataAccessRepository_GetIntegrations_Call struct {
 }
 
 // GetIntegrations is a helper method to define mock.On call
-func (_e *mockDataAccessRepository_Expecter) GetIntegrations() *mockDataAccessRepository_GetIntegrations_Call {
-	return &mockDataAccessRepository_GetIntegrations_Call{Call: _e.mock.On("GetIntegrations")}
+//   - ctx context.Context
+func (_e *mockDataAccessRepository_Expecter) GetIntegrations(ctx interface{}) *mockDataAccessRepository_GetIntegrations_Call {
+	return &mockDataAccessRepository_GetIntegrations_Call{Call: _e.mock.On("GetIntegrations", ctx)}
 }
 
-func (_c *mockDataAccessRepository_GetIntegrations_Call) Run(run func()) *mockDataAccessRepository_GetIntegrations_Call {
+func (_c *mockDataAccessRepository_GetIntegrations_Call) Run(run func(ctx context.Context)) *mockDataAccessRepository_GetIntegrations_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		run(args[0].(context.Context))
 	})
 	return _c
 }
@@ -2018,14 +2340,14 @@ func (_c *mockDataAccessRepository_GetIntegrations_Call) Return(_a0 []DbEntity,
 	return _c
 }
 
-func (_c *mockDataAccessRepository_GetIntegrations_Call) RunAndReturn(run func() ([]DbEntity, error)) *mockDataAccessRepository_GetIntegrations_Call {
+func (_c *mockDataAccessRepository_GetIntegrations_Call) RunAndReturn(run func(context.Context) ([]DbEntity, error)) *mockDataAccessRepository_GetIntegrations_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetOutboundShares provides a mock function with no fields
-func (_m *mockDataAccessRepository) GetOutboundShares() ([]ShareEntity, error) {
-	ret := _m.Called()
+// GetOutboundShares provides a mock function with given fields: ctx
+func (_m *mockDataAccessRepository) GetOutboundShares(ctx context.Context) ([]ShareEntity, error) {
+	ret := _m.Called(ctx)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetOutboundShares")
@@ -2033,19 +2355,19 @@ func (_m *mockDataAccessRepository) GetOutboundShares() ([]ShareEntity, error) {
 
 	var r0 []ShareEntity
 	var r1 error
-	if rf, ok := ret.Get(0).(func() ([]ShareEntity, error)); ok {
-		return rf()
+	if rf, ok := ret.Get(0).(func(context.Context) ([]ShareEntity, error)); ok {
+		return rf(ctx)
 	}
-	if rf, ok := ret.Get(0).(func() []ShareEntity); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(context.Context) []ShareEntity); ok {
+		r0 = rf(ctx)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]ShareEntity)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -2059,13 +2381,14 @@ type mockDataAccessRepository_GetOutboundShares_Call struct {
 }
 
 // GetOutboundShares is a helper method to define mock.On call
-func (_e *mockDataAccessRepository_Expecter) GetOutboundShares() *mockDataAccessRepository_GetOutboundShares_Call {
-	return &mockDataAccessRepository_GetOutboundShares_Call{Call: _e.mock.On("GetOutboundShares")}
+//   - ctx context.Context
+func (_e *mockDataAccessRepository_Expecter) GetOutboundShares(ctx interface{}) *mockDataAccessRepository_GetOutboundShares_Call {
+	return &mockDataAccessRepository_GetOutboundShares_Call{Call: _e.mock.On("GetOutboundShares", ctx)}
 }
 
-func (_c *mockDataAccessRepository_GetOutboundShares_Call) Run(run func()) *mockDataAccessRepository_GetOutboundShares_Call {
+func (_c *mockDataAccessRepository_GetOutboundShares_Call) Run(run func(ctx context.Context)) *mockDataAccessRepository_GetOutboundShares_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		run(args[0].(context.Context))
 	})
 	return _c
 }
@@ -2075,14 +2398,14 @@ func (_c *mockDataAccessRepository_GetOutboundShares_Call) Return(_a0 []ShareEnt
 	return _c
 }
 
-func (_c *mockDataAccessRepository_GetOutboundShares_Call) RunAndReturn(run func() ([]ShareEntity, error)) *mockDataAccessRepository_GetOutboundShares_Call {
+func (_c *mockDataAccessRepository_GetOutboundShares_Call) RunAndReturn(run func(context.Context) ([]ShareEntity, error)) *mockDataAccessRepository_GetOutboundShares_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetPolicies provides a mock function with given fields: policy
-func (_m *mockDataAccessRepository) GetPolicies(policy string) ([]PolicyEntity, error) {
-	ret := _m.Called(policy)
+// GetPolicies provides a mock function with given fields: ctx, policy
+func (_m *mockDataAccessRepository) GetPolicies(ctx context.Context, policy string) ([]PolicyEntity, error) {
+	ret := _m.Called(ctx, policy)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetPolicies")
@@ -2090,19 +2413,19 @@ func (_m *mockDataAccessRepository) GetPolicies(policy string) ([]PolicyEntity,
 
 	var r0 []PolicyEntity
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) ([]PolicyEntity, error)); ok {
-		return rf(policy)
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]PolicyEntity, error)); ok {
+		return rf(ctx, policy)
 	}
-	if rf, ok := ret.Get(0).(func(string) []PolicyEntity); ok {
-		r0 = rf(policy)
+	if rf, ok := ret.Get(0).(func(context.Context, string) []PolicyEntity); ok {
+		r0 = rf(ctx, policy)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]PolicyEntity)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(policy)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, policy)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -2116,14 +2439,15 @@ type mockDataAccessRepository_GetPolicies_Call struct {
 }
 
 // GetPolicies is a helper method to define mock.On call
+//   - ctx context.Context
 //   - policy string
-func (_e *mockDataAccessRepository_Expecter) GetPolicies(policy interface{}) *mockDataAccessRepository_GetPolicies_Call {
-	return &mockDataAccessRepository_GetPolicies_Call{Call: _e.mock.On("GetPolicies", policy)}
+func (_e *mockDataAccessRepository_Expecter) GetPolicies(ctx interface{}, policy interface{}) *mockDataAccessRepository_GetPolicies_Call {
+	return &mockDataAccessRepository_GetPolicies_Call{Call: _e.mock.On("GetPolicies", ctx, policy)}
 }
 
-func (_c *mockDataAccessRepository_GetPolicies_Call) Run(run func(policy string)) *mockDataAccessRepository_GetPolicies_Call {
+func (_c *mockDataAccessRepository_GetPolicies_Call) Run(run func(ctx context.Context, policy string)) *mockDataAccessRepository_GetPolicies_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(context.Context), args[1].(string))
 	})
 	return _c
 }
@@ -2133,14 +2457,14 @@ func (_c *mockDataAccessRepository_GetPolicies_Call) Return(_a0 []PolicyEntity,
 	return _c
 }
 
-func (_c *mockDataAccessRepository_GetPolicies_Call) RunAndReturn(run func(string) ([]PolicyEntity, error)) *mockDataAccessRepository_GetPolicies_Call {
+func (_c *mockDataAccessRepository_GetPolicies_Call) RunAndReturn(run func(context.Context, string) ([]PolicyEntity, error)) *mockDataAccessRepository_GetPolicies_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetPoliciesLike provides a mock function with given fields: policy, like
-func (_m *mockDataAccessRepository) GetPoliciesLike(policy string, like string) ([]PolicyEntity, error) {
-	ret := _m.Called(policy, like)
+// GetPoliciesLike provides a mock function with given fields: ctx, policy, like
+func (_m *mockDataAccessRepository) GetPoliciesLike(ctx context.Context, policy string, like string) ([]PolicyEntity, error) {
+	ret := _m.Called(ctx, policy, like)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetPoliciesLike")
@@ -2148,19 +2472,19 @@ func (_m *mockDataAccessRepository) GetPoliciesLike(policy string, like string)
 
 	var r0 []PolicyEntity
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, string) ([]PolicyEntity, error)); ok {
-		return rf(policy, like)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) ([]PolicyEntity, error)); ok {
+		return rf(ctx, policy, like)
 	}
-	if rf, ok := ret.Get(0).(func(string, string) []PolicyEntity); ok {
-		r0 = rf(policy, like)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) []PolicyEntity); ok {
+		r0 = rf(ctx, policy, like)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]PolicyEntity)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string, string) error); ok {
-		r1 = rf(policy, like)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, policy, like)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -2174,15 +2498,16 @@ type mockDataAccessRepository_GetPoliciesLike_Call struct {
 }
 
 // GetPoliciesLike is a helper method to define mock.On call
+//   - ctx context.Context
 //   - policy string
 //   - like string
-func (_e *mockDataAccessRepository_Expecter) GetPoliciesLike(policy interface{}, like interface{}) *mockDataAccessRepository_GetPoliciesLike_Call {
-	return &mockDataAccessRepository_GetPoliciesLike_Call{Call: _e.mock.On("GetPoliciesLike", policy, like)}
+func (_e *mockDataAccessRepository_Expecter) GetPoliciesLike(ctx interface{}, policy interface{}, like interface{}) *mockDataAccessRepository_GetPoliciesLike_Call {
+	return &mockDataAccessRepository_GetPoliciesLike_Call{Call: _e.mock.On("GetPoliciesLike", ctx, policy, like)}
 }
 
-func (_c *mockDataAccessRepository_GetPoliciesLike_Call) Run(run func(policy string, like string)) *mockDataAccessRepository_GetPoliciesLike_Call {
+func (_c *mockDataAccessRepository_GetPoliciesLike_Call) Run(run func(ctx context.Context, policy string, like string)) *mockDataAccessRepository_GetPoliciesLike_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
 	})
 	return _c
 }
@@ -2192,14 +2517,14 @@ func (_c *mockDataAccessRepository_GetPoliciesLike_Call) Return(_a0 []PolicyEnti
 	return _c
 }
 
-func (_c *mockDataAccessRepository_GetPoliciesLike_Call) RunAndReturn(run func(string, string) ([]PolicyEntity, error)) *mockDataAccessRepository_GetPoliciesLike_Call {
+func (_c *mockDataAccessRepository_GetPoliciesLike_Call) RunAndReturn(run func(context.Context, string, string) ([]PolicyEntity, error)) *mockDataAccessRepository_GetPoliciesLike_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetPolicyReferences provides a mock function with given fields: dbName, schema, policyName
-func (_m *mockDataAccessRepository) GetPolicyReferences(dbName string, schema string, policyName string) ([]PolicyReferenceEntity, error) {
-	ret := _m.Called(dbName, schema, policyName)
+// GetPolicyReferences provides a mock function with given fields: ctx, dbName, schema, policyName
+func (_m *mockDataAccessRepository) GetPolicyReferences(ctx context.Context, dbName string, schema string, policyName string) ([]PolicyReferenceEntity, error) {
+	ret := _m.Called(ctx, dbName, schema, policyName)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetPolicyReferences")
@@ -2207,19 +2532,19 @@ func (_m *mockDataAccessRepository) GetPolicyReferences(dbName string, schema st
 
 	var r0 []PolicyReferenceEntity
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, string, string) ([]PolicyReferenceEntity, error)); ok {
-		return rf(dbName, schema, policyName)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) ([]PolicyReferenceEntity, error)); ok {
+		return rf(ctx, dbName, schema, policyName)
 	}
-	if rf, ok := ret.Get(0).(func(string, string, string) []PolicyReferenceEntity); ok {
-		r0 = rf(dbName, schema, policyName)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) []PolicyReferenceEntity); ok {
+		r0 = rf(ctx, dbName, schema, policyName)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]PolicyReferenceEntity)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string, string, string) error); ok {
-		r1 = rf(dbName, schema, policyName)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, dbName, schema, policyName)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -2233,16 +2558,17 @@ type mockDataAccessRepository_GetPolicyReferences_Call struct {
 }
 
 // GetPolicyReferences is a helper method to define mock.On call
+//   - ctx context.Context
 //   - dbName string
 //   - schema string
 //   - policyName string
-func (_e *mockDataAccessRepository_Expecter) GetPolicyReferences(dbName interface{}, schema interface{}, policyName interface{}) *mockDataAccessRepository_GetPolicyReferences_Call {
-	return &mockDataAccessRepository_GetPolicyReferences_Call{Call: _e.mock.On("GetPolicyReferences", dbName, schema, policyName)}
+func (_e *mockDataAccessRepository_Expecter) GetPolicyReferences(ctx interface{}, dbName interface{}, schema interface{}, policyName interface{}) *mockDataAccessRepository_GetPolicyReferences_Call {
+	return &mockDataAccessRepository_GetPolicyReferences_Call{Call: _e.mock.On("GetPolicyReferences", ctx, dbName, schema, policyName)}
 }
 
-func (_c *mockDataAccessRepository_GetPolicyReferences_Call) Run(run func(dbName string, schema string, policyName string)) *mockDataAccessRepository_GetPolicyReferences_Call {
+func (_c *mockDataAccessRepository_GetPolicyReferences_Call) Run(run func(ctx context.Context, dbName string, schema string, policyName string)) *mockDataAccessRepository_GetPolicyReferences_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(string), args[2].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string))
 	})
 	return _c
 }
@@ -2252,22 +2578,22 @@ func (_c *mockDataAccessRepository_GetPolicyReferences_Call) Return(_a0 []Policy
 	return _c
 }
 
-func (_c *mockDataAccessRepository_GetPolicyReferences_Call) RunAndReturn(run func(string, string, string) ([]PolicyReferenceEntity, error)) *mockDataAccessRepository_GetPolicyReferences_Call {
+func (_c *mockDataAccessRepository_GetPolicyReferences_Call) RunAndReturn(run func(context.Context, string, string, string) ([]PolicyReferenceEntity, error)) *mockDataAccessRepository_GetPolicyReferences_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetProceduresInDatabase provides a mock function with given fields: databaseName, handleEntity
-func (_m *mockDataAccessRepository) GetProceduresInDatabase(databaseName string, handleEntity EntityHandler) error {
-	ret := _m.Called(databaseName, handleEntity)
+// GetProceduresInDatabase provides a mock function with given fields: ctx, databaseName, handleEntity
+func (_m *mockDataAccessRepository) GetProceduresInDatabase(ctx context.Context, databaseName string, handleEntity EntityHandler) error {
+	ret := _m.Called(ctx, databaseName, handleEntity)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetProceduresInDatabase")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, EntityHandler) error); ok {
-		r0 = rf(databaseName, handleEntity)
+	if rf, ok := ret.Get(0).(func(context.Context, string, EntityHandler) error); ok {
+		r0 = rf(ctx, databaseName, handleEntity)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -2281,15 +2607,16 @@ type mockDataAccessRepository_GetProceduresInDatabase_Call struct {
 }
 
 // GetProceduresInDatabase is a helper method to define mock.On call
+//   - ctx context.Context
 //   - databaseName string
 //   - handleEntity EntityHandler
-func (_e *mockDataAccessRepository_Expecter) GetProceduresInDatabase(databaseName interface{}, handleEntity interface{}) *mockDataAccessRepository_GetProceduresInDatabase_Call {
-	return &mockDataAccessRepository_GetProceduresInDatabase_Call{Call: _e.mock.On("GetProceduresInDatabase", databaseName, handleEntity)}
+func (_e *mockDataAccessRepository_Expecter) GetProceduresInDatabase(ctx interface{}, databaseName interface{}, handleEntity interface{}) *mockDataAccessRepository_GetProceduresInDatabase_Call {
+	return &mockDataAccessRepository_GetProceduresInDatabase_Call{Call: _e.mock.On("GetProceduresInDatabase", ctx, databaseName, handleEntity)}
 }
 
-func (_c *mockDataAccessRepository_GetProceduresInDatabase_Call) Run(run func(databaseName string, handleEntity EntityHandler)) *mockDataAccessRepository_GetProceduresInDatabase_Call {
+func (_c *mockDataAccessRepository_GetProceduresInDatabase_Call) Run(run func(ctx context.Context, databaseName string, handleEntity EntityHandler)) *mockDataAccessRepository_GetProceduresInDatabase_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(EntityHandler))
+		run(args[0].(context.Context), args[1].(string), args[2].(EntityHandler))
 	})
 	return _c
 }
@@ -2299,22 +2626,22 @@ func (_c *mockDataAccessRepository_GetProceduresInDatabase_Call) Return(_a0 erro
 	return _c
 }
 
-func (_c *mockDataAccessRepository_GetProceduresInDatabase_Call) RunAndReturn(run func(string, EntityHandler) error) *mockDataAccessRepository_GetProceduresInDatabase_Call {
+func (_c *mockDataAccessRepository_GetProceduresInDatabase_Call) RunAndReturn(run func(context.Context, string, EntityHandler) error) *mockDataAccessRepository_GetProceduresInDatabase_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetProceduresInSchema provides a mock function with given fields: databaseName, schemaName, handleEntity
-func (_m *mockDataAccessRepository) GetProceduresInSchema(databaseName string, schemaName string, handleEntity EntityHandler) error {
-	ret := _m.Called(databaseName, schemaName, handleEntity)
+// GetProceduresInSchema provides a mock function with given fields: ctx, databaseName, schemaName, handleEntity
+func (_m *mockDataAccessRepository) GetProceduresInSchema(ctx context.Context, databaseName string, schemaName string, handleEntity EntityHandler) error {
+	ret := _m.Called(ctx, databaseName, schemaName, handleEntity)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetProceduresInSchema")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, string, EntityHandler) error); ok {
-		r0 = rf(databaseName, schemaName, handleEntity)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, EntityHandler) error); ok {
+		r0 = rf(ctx, databaseName, schemaName, handleEntity)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -2328,16 +2655,17 @@ type mockDataAccessRepository_GetProceduresInSchema_Call struct {
 }
 
 // GetProceduresInSchema is a helper method to define mock.On call
+//   - ctx context.Context
 //   - databaseName string
 //   - schemaName string
 //   - handleEntity EntityHandler
-func (_e *mockDataAccessRepository_Expecter) GetProceduresInSchema(databaseName interface{}, schemaName interface{}, handleEntity interface{}) *mockDataAccessRepository_GetProceduresInSchema_Call {
-	return &mockDataAccessRepository_GetProceduresInSchema_Call{Call: _e.mock.On("GetProceduresInSchema", databaseName, schemaName, handleEntity)}
+func (_e *mockDataAccessRepository_Expecter) GetProceduresInSchema(ctx interface{}, databaseName interface{}, schemaName interface{}, handleEntity interface{}) *mockDataAccessRepository_GetProceduresInSchema_Call {
+	return &mockDataAccessRepository_GetProceduresInSchema_Call{Call: _e.mock.On("GetProceduresInSchema", ctx, databaseName, schemaName, handleEntity)}
 }
 
-func (_c *mockDataAccessRepository_GetProceduresInSchema_Call) Run(run func(databaseName string, schemaName string, handleEntity EntityHandler)) *mockDataAccessRepository_GetProceduresInSchema_Call {
+func (_c *mockDataAccessRepository_GetProceduresInSchema_Call) Run(run func(ctx context.Context, databaseName string, schemaName string, handleEntity EntityHandler)) *mockDataAccessRepository_GetProceduresInSchema_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(string), args[2].(EntityHandler))
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(EntityHandler))
 	})
 	return _c
 }
@@ -2347,22 +2675,22 @@ func (_c *mockDataAccessRepository_GetProceduresInSchema_Call) Return(_a0 error)
 	return _c
 }
 
-func (_c *mockDataAccessRepository_GetProceduresInSchema_Call) RunAndReturn(run func(string, string, EntityHandler) error) *mockDataAccessRepository_GetProceduresInSchema_Call {
+func (_c *mockDataAccessRepository_GetProceduresInSchema_Call) RunAndReturn(run func(context.Context, string, string, EntityHandler) error) *mockDataAccessRepository_GetProceduresInSchema_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetSchemasInDatabase provides a mock function with given fields: databaseName, handleEntity
-func (_m *mockDataAccessRepository) GetSchemasInDatabase(databaseName string, handleEntity EntityHandler) error {
-	ret := _m.Called(databaseName, handleEntity)
+// GetSchemasInDatabase provides a mock function with given fields: ctx, databaseName, handleEntity
+func (_m *mockDataAccessRepository) GetSchemasInDatabase(ctx context.Context, databaseName string, handleEntity EntityHandler) error {
+	ret := _m.Called(ctx, databaseName, handleEntity)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetSchemasInDatabase")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, EntityHandler) error); ok {
-		r0 = rf(databaseName, handleEntity)
+	if rf, ok := ret.Get(0).(func(context.Context, string, EntityHandler) error); ok {
+		r0 = rf(ctx, databaseName, handleEntity)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -2376,15 +2704,16 @@ type mockDataAccessRepository_GetSchemasInDatabase_Call struct {
 }
 
 // GetSchemasInDatabase is a helper method to define mock.On call
+//   - ctx context.Context
 //   - databaseName string
 //   - handleEntity EntityHandler
-func (_e *mockDataAccessRepository_Expecter) GetSchemasInDatabase(databaseName interface{}, handleEntity interface{}) *mockDataAccessRepository_GetSchemasInDatabase_Call {
-	return &mockDataAccessRepository_GetSchemasInDatabase_Call{Call: _e.mock.On("GetSchemasInDatabase", databaseName, handleEntity)}
+func (_e *mockDataAccessRepository_Expecter) GetSchemasInDatabase(ctx interface{}, databaseName interface{}, handleEntity interface{}) *mockDataAccessRepository_GetSchemasInDatabase_Call {
+	return &mockDataAccessRepository_GetSchemasInDatabase_Call{Call: _e.mock.On("GetSchemasInDatabase", ctx, databaseName, handleEntity)}
 }
 
-func (_c *mockDataAccessRepository_GetSchemasInDatabase_Call) Run(run func(databaseName string, handleEntity EntityHandler)) *mockDataAccessRepository_GetSchemasInDatabase_Call {
+func (_c *mockDataAccessRepository_GetSchemasInDatabase_Call) Run(run func(ctx context.Context, databaseName string, handleEntity EntityHandler)) *mockDataAccessRepository_GetSchemasInDatabase_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(EntityHandler))
+		run(args[0].(context.Context), args[1].(string), args[2].(EntityHandler))
 	})
 	return _c
 }
@@ -2394,18 +2723,19 @@ func (_c *mockDataAccessRepository_GetSchemasInDatabase_Call) Return(_a0 error)
 	return _c
 }
 
-func (_c *mockDataAccessRepository_GetSchemasInDatabase_Call) RunAndReturn(run func(string, EntityHandler) error) *mockDataAccessRepository_GetSchemasInDatabase_Call {
+func (_c *mockDataAccessRepository_GetSchemasInDatabase_Call) RunAndReturn(run func(context.Context, string, EntityHandler) error) *mockDataAccessRepository_GetSchemasInDatabase_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetSnowFlakeAccountName provides a mock function with given fields: ops
-func (_m *mockDataAccessRepository) GetSnowFlakeAccountName(ops ...func(*GetSnowFlakeAccountNameOptions)) (string, error) {
+// GetSnowFlakeAccountName provides a mock function with given fields: ctx, ops
+func (_m *mockDataAccessRepository) GetSnowFlakeAccountName(ctx context.Context, ops ...func(*GetSnowFlakeAccountNameOptions)) (string, error) {
 	_va := make([]interface{}, len(ops))
 	for _i := range ops {
 		_va[_i] = ops[_i]
 	}
 	var _ca []interface{}
+	_ca = append(_ca, ctx)
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
@@ -2415,17 +2745,17 @@ func (_m *mockDataAccessRepository) GetSnowFlakeAccountName(ops ...func(*GetSnow
 
 	var r0 string
 	var r1 error
-	if rf, ok := ret.Get(0).(func(...func(*GetSnowFlakeAccountNameOptions)) (string, error)); ok {
-		return rf(ops...)
+	if rf, ok := ret.Get(0).(func(context.Context, ...func(*GetSnowFlakeAccountNameOptions)) (string, error)); ok {
+		return rf(ctx, ops...)
 	}
-	if rf, ok := ret.Get(0).(func(...func(*GetSnowFlakeAccountNameOptions)) string); ok {
-		r0 = rf(ops...)
+	if rf, ok := ret.Get(0).(func(context.Context, ...func(*GetSnowFlakeAccountNameOptions)) string); ok {
+		r0 = rf(ctx, ops...)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
-	if rf, ok := ret.Get(1).(func(...func(*GetSnowFlakeAccountNameOptions)) error); ok {
-		r1 = rf(ops...)
+	if rf, ok := ret.Get(1).(func(context.Context, ...func(*GetSnowFlakeAccountNameOptions)) error); ok {
+		r1 = rf(ctx, ops...)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -2439,21 +2769,22 @@ type mockDataAccessRepository_GetSnowFlakeAccountName_Call struct {
 }
 
 // GetSnowFlakeAccountName is a helper method to define mock.On call
+//   - ctx context.Context
 //   - ops ...func(*GetSnowFlakeAccountNameOptions)
-func (_e *mockDataAccessRepository_Expecter) GetSnowFlakeAccountName(ops ...interface{}) *mockDataAccessRepository_GetSnowFlakeAccountName_Call {
+func (_e *mockDataAccessRepository_Expecter) GetSnowFlakeAccountName(ctx interface{}, ops ...interface{}) *mockDataAccessRepository_GetSnowFlakeAccountName_Call {
 	return &mockDataAccessRepository_GetSnowFlakeAccountName_Call{Call: _e.mock.On("GetSnowFlakeAccountName",
-		append([]interface{}{}, ops...)...)}
+		append([]interface{}{ctx}, ops...)...)}
 }
 
-func (_c *mockDataAccessRepository_GetSnowFlakeAccountName_Call) Run(run func(ops ...func(*GetSnowFlakeAccountNameOptions))) *mockDataAccessRepository_GetSnowFlakeAccountName_Call {
+func (_c *mockDataAccessRepository_GetSnowFlakeAccountName_Call) Run(run func(ctx context.Context, ops ...func(*GetSnowFlakeAccountNameOptions))) *mockDataAccessRepository_GetSnowFlakeAccountName_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		variadicArgs := make([]func(*GetSnowFlakeAccountNameOptions), len(args)-0)
-		for i, a := range args[0:] {
+		variadicArgs := make([]func(*GetSnowFlakeAccountNameOptions), len(args)-1)
+		for i, a := range args[1:] {
 			if a != nil {
 				variadicArgs[i] = a.(func(*GetSnowFlakeAccountNameOptions))
 			}
 		}
-		run(variadicArgs...)
+		run(args[0].(context.Context), variadicArgs...)
 	})
 	return _c
 }
@@ -2463,22 +2794,22 @@ func (_c *mockDataAccessRepository_GetSnowFlakeAccountName_Call) Return(_a0 stri
 	return _c
 }
 
-func (_c *mockDataAccessRepository_GetSnowFlakeAccountName_Call) RunAndReturn(run func(...func(*GetSnowFlakeAccountNameOptions)) (string, error)) *mockDataAccessRepository_GetSnowFlakeAccountName_Call {
+func (_c *mockDataAccessRepository_GetSnowFlakeAccountName_Call) RunAndReturn(run func(context.Context, ...func(*GetSnowFlakeAccountNameOptions)) (string, error)) *mockDataAccessRepository_GetSnowFlakeAccountName_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetTablesInDatabase provides a mock function with given fields: databaseName, schemaName, handleEntity
-func (_m *mockDataAccessRepository) GetTablesInDatabase(databaseName string, schemaName string, handleEntity EntityHandler) error {
-	ret := _m.Called(databaseName, schemaName, handleEntity)
+// GetTablesInDatabase provides a mock function with given fields: ctx, databaseName, schemaName, handleEntity
+func (_m *mockDataAccessRepository) GetTablesInDatabase(ctx context.Context, databaseName string, schemaName string, handleEntity EntityHandler) error {
+	ret := _m.Called(ctx, databaseName, schemaName, handleEntity)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetTablesInDatabase")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, string, EntityHandler) error); ok {
-		r0 = rf(databaseName, schemaName, handleEntity)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, EntityHandler) error); ok {
+		r0 = rf(ctx, databaseName, schemaName, handleEntity)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -2492,16 +2823,17 @@ type mockDataAccessRepository_GetTablesInDatabase_Call struct {
 }
 
 // GetTablesInDatabase is a helper method to define mock.On call
+//   - ctx context.Context
 //   - databaseName string
 //   - schemaName string
 //   - handleEntity EntityHandler
-func (_e *mockDataAccessRepository_Expecter) GetTablesInDatabase(databaseName interface{}, schemaName interface{}, handleEntity interface{}) *mockDataAccessRepository_GetTablesInDatabase_Call {
-	return &mockDataAccessRepository_GetTablesInDatabase_Call{Call: _e.mock.On("GetTablesInDatabase", databaseName, schemaName, handleEntity)}
+func (_e *mockDataAccessRepository_Expecter) GetTablesInDatabase(ctx interface{}, databaseName interface{}, schemaName interface{}, handleEntity interface{}) *mockDataAccessRepository_GetTablesInDatabase_Call {
+	return &mockDataAccessRepository_GetTablesInDatabase_Call{Call: _e.mock.On("GetTablesInDatabase", ctx, databaseName, schemaName, handleEntity)}
 }
 
-func (_c *mockDataAccessRepository_GetTablesInDatabase_Call) Run(run func(databaseName string, schemaName string, handleEntity EntityHandler)) *mockDataAccessRepository_GetTablesInDatabase_Call {
+func (_c *mockDataAccessRepository_GetTablesInDatabase_Call) Run(run func(ctx context.Context, databaseName string, schemaName string, handleEntity EntityHandler)) *mockDataAccessRepository_GetTablesInDatabase_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(string), args[2].(EntityHandler))
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(EntityHandler))
 	})
 	return _c
 }
@@ -2511,14 +2843,14 @@ func (_c *mockDataAccessRepository_GetTablesInDatabase_Call) Return(_a0 error) *
 	return _c
 }
 
-func (_c *mockDataAccessRepository_GetTablesInDatabase_Call) RunAndReturn(run func(string, string, EntityHandler) error) *mockDataAccessRepository_GetTablesInDatabase_Call {
+func (_c *mockDataAccessRepository_GetTablesInDatabase_Call) RunAndReturn(run func(context.Context, string, string, EntityHandler) error) *mockDataAccessRepository_GetTablesInDatabase_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetTagsByDomain provides a mock function with given fields: domain
-func (_m *mockDataAccessRepository) GetTagsByDomain(domain string) (map[string][]*tag.Tag, error) {
-	ret := _m.Called(domain)
+// GetTagsByDomain provides a mock function with given fields: ctx, domain
+func (_m *mockDataAccessRepository) GetTagsByDomain(ctx context.Context, domain string) (map[string][]*tag.Tag, error) {
+	ret := _m.Called(ctx, domain)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetTagsByDomain")
@@ -2526,19 +2858,19 @@ func (_m *mockDataAccessRepository) GetTagsByDomain(domain string) (map[string][
 
 	var r0 map[string][]*tag.Tag
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (map[string][]*tag.Tag, error)); ok {
-		return rf(domain)
+	if rf, ok := ret.Get(0).(func(context.Context, string) (map[string][]*tag.Tag, error)); ok {
+		return rf(ctx, domain)
 	}
-	if rf, ok := ret.Get(0).(func(string) map[string][]*tag.Tag); ok {
-		r0 = rf(domain)
+	if rf, ok := ret.Get(0).(func(context.Context, string) map[string][]*tag.Tag); ok {
+		r0 = rf(ctx, domain)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(map[string][]*tag.Tag)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(domain)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, domain)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -2552,14 +2884,15 @@ type mockDataAccessRepository_GetTagsByDomain_Call struct {
 }
 
 // GetTagsByDomain is a helper method to define mock.On call
+//   - ctx context.Context
 //   - domain string
-func (_e *mockDataAccessRepository_Expecter) GetTagsByDomain(domain interface{}) *mockDataAccessRepository_GetTagsByDomain_Call {
-	return &mockDataAccessRepository_GetTagsByDomain_Call{Call: _e.mock.On("GetTagsByDomain", domain)}
+func (_e *mockDataAccessRepository_Expecter) GetTagsByDomain(ctx interface{}, domain interface{}) *mockDataAccessRepository_GetTagsByDomain_Call {
+	return &mockDataAccessRepository_GetTagsByDomain_Call{Call: _e.mock.On("GetTagsByDomain", ctx, domain)}
 }
 
-func (_c *mockDataAccessRepository_GetTagsByDomain_Call) Run(run func(domain string)) *mockDataAccessRepository_GetTagsByDomain_Call {
+func (_c *mockDataAccessRepository_GetTagsByDomain_Call) Run(run func(ctx context.Context, domain string)) *mockDataAccessRepository_GetTagsByDomain_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(context.Context), args[1].(string))
 	})
 	return _c
 }
@@ -2569,14 +2902,14 @@ func (_c *mockDataAccessRepository_GetTagsByDomain_Call) Return(_a0 map[string][
 	return _c
 }
 
-func (_c *mockDataAccessRepository_GetTagsByDomain_Call) RunAndReturn(run func(string) (map[string][]*tag.Tag, error)) *mockDataAccessRepository_GetTagsByDomain_Call {
+func (_c *mockDataAccessRepository_GetTagsByDomain_Call) RunAndReturn(run func(context.Context, string) (map[string][]*tag.Tag, error)) *mockDataAccessRepository_GetTagsByDomain_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetWarehouses provides a mock function with no fields
-func (_m *mockDataAccessRepository) GetWarehouses() ([]DbEntity, error) {
-	ret := _m.Called()
+// GetWarehouses provides a mock function with given fields: ctx
+func (_m *mockDataAccessRepository) GetWarehouses(ctx context.Context) ([]DbEntity, error) {
+	ret := _m.Called(ctx)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetWarehouses")
@@ -2584,19 +2917,19 @@ func (_m *mockDataAccessRepository) GetWarehouses() ([]DbEntity, error) {
 
 	var r0 []DbEntity
 	var r1 error
-	if rf, ok := ret.Get(0).(func() ([]DbEntity, error)); ok {
-		return rf()
+	if rf, ok := ret.Get(0).(func(context.Context) ([]DbEntity, error)); ok {
+		return rf(ctx)
 	}
-	if rf, ok := ret.Get(0).(func() []DbEntity); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(context.Context) []DbEntity); ok {
+		r0 = rf(ctx)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]DbEntity)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -2610,13 +2943,14 @@ type mockDataAccessRepository_GetWarehouses_Call struct {
 }
 
 // GetWarehouses is a helper method to define mock.On call
-func (_e *mockDataAccessRepository_Expecter) GetWarehouses() *mockDataAccessRepository_GetWarehouses_Call {
-	return &mockDataAccessRepository_GetWarehouses_Call{Call: _e.mock.On("GetWarehouses")}
+//   - ctx context.Context
+func (_e *mockDataAccessRepository_Expecter) GetWarehouses(ctx interface{}) *mockDataAccessRepository_GetWarehouses_Call {
+	return &mockDataAccessRepository_GetWarehouses_Call{Call: _e.mock.On("GetWarehouses", ctx)}
 }
 
-func (_c *mockDataAccessRepository_GetWarehouses_Call) Run(run func()) *mockDataAccessRepository_GetWarehouses_Call {
+func (_c *mockDataAccessRepository_GetWarehouses_Call) Run(run func(ctx context.Context)) *mockDataAccessRepository_GetWarehouses_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		run(args[0].(context.Context))
 	})
 	return _c
 }
@@ -2626,7 +2960,7 @@ func (_c *mockDataAccessRepository_GetWarehouses_Call) Return(_a0 []DbEntity, _a
 	return _c
 }
 
-func (_c *mockDataAccessRepository_GetWarehouses_Call) RunAndReturn(run func() ([]DbEntity, error)) *mockDataAccessRepository_GetWarehouses_Call {
+func (_c *mockDataAccessRepository_GetWarehouses_Call) RunAndReturn(run func(context.Context) ([]DbEntity, error)) *mockDataAccessRepository_GetWarehouses_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -3070,17 +3404,17 @@ func (_c *mockDataAccessRepository_GrantUsersToAccountRole_Call) RunAndReturn(ru
 	return _c
 }
 
-// RenameAccountRole provides a mock function with given fields: oldName, newName
-func (_m *mockDataAccessRepository) RenameAccountRole(oldName string, newName string) error {
-	ret := _m.Called(oldName, newName)
+// RenameAccountRole provides a mock function with given fields: ctx, oldName, newName
+func (_m *mockDataAccessRepository) RenameAccountRole(ctx context.Context, oldName string, newName string) error {
+	ret := _m.Called(ctx, oldName, newName)
 
 	if len(ret) == 0 {
 		panic("no return value specified for RenameAccountRole")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, string) error); ok {
-		r0 = rf(oldName, newName)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, oldName, newName)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -3094,15 +3428,16 @@ type mockDataAccessRepository_RenameAccountRole_Call struct {
 }
 
 // RenameAccountRole is a helper method to define mock.On call
+//   - ctx context.Context
 //   - oldName string
 //   - newName string
-func (_e *mockDataAccessRepository_Expecter) RenameAccountRole(oldName interface{}, newName interface{}) *mockDataAccessRepository_RenameAccountRole_Call {
-	return &mockDataAccessRepository_RenameAccountRole_Call{Call: _e.mock.On("RenameAccountRole", oldName, newName)}
+func (_e *mockDataAccessRepository_Expecter) RenameAccountRole(ctx interface{}, oldName interface{}, newName interface{}) *mockDataAccessRepository_RenameAccountRole_Call {
+	return &mockDataAccessRepository_RenameAccountRole_Call{Call: _e.mock.On("RenameAccountRole", ctx, oldName, newName)}
 }
 
-func (_c *mockDataAccessRepository_RenameAccountRole_Call) Run(run func(oldName string, newName string)) *mockDataAccessRepository_RenameAccountRole_Call {
+func (_c *mockDataAccessRepository_RenameAccountRole_Call) Run(run func(ctx context.Context, oldName string, newName string)) *mockDataAccessRepository_RenameAccountRole_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
 	})
 	return _c
 }
@@ -3112,22 +3447,22 @@ func (_c *mockDataAccessRepository_RenameAccountRole_Call) Return(_a0 error) *mo
 	return _c
 }
 
-func (_c *mockDataAccessRepository_RenameAccountRole_Call) RunAndReturn(run func(string, string) error) *mockDataAccessRepository_RenameAccountRole_Call {
+func (_c *mockDataAccessRepository_RenameAccountRole_Call) RunAndReturn(run func(context.Context, string, string) error) *mockDataAccessRepository_RenameAccountRole_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// RenameDatabaseRole provides a mock function with given fields: database, oldName, newName
-func (_m *mockDataAccessRepository) RenameDatabaseRole(database string, oldName string, newName string) error {
-	ret := _m.Called(database, oldName, newName)
+// RenameDatabaseRole provides a mock function with given fields: ctx, database, oldName, newName
+func (_m *mockDataAccessRepository) RenameDatabaseRole(ctx context.Context, database string, oldName string, newName string) error {
+	ret := _m.Called(ctx, database, oldName, newName)
 
 	if len(ret) == 0 {
 		panic("no return value specified for RenameDatabaseRole")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, string, string) error); ok {
-		r0 = rf(database, oldName, newName)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = rf(ctx, database, oldName, newName)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -3141,16 +3476,17 @@ type mockDataAccessRepository_RenameDatabaseRole_Call struct {
 }
 
 // RenameDatabaseRole is a helper method to define mock.On call
+//   - ctx context.Context
 //   - database string
 //   - oldName string
 //   - newName string
-func (_e *mockDataAccessRepository_Expecter) RenameDatabaseRole(database interface{}, oldName interface{}, newName interface{}) *mockDataAccessRepository_RenameDatabaseRole_Call {
-	return &mockDataAccessRepository_RenameDatabaseRole_Call{Call: _e.mock.On("RenameDatabaseRole", database, oldName, newName)}
+func (_e *mockDataAccessRepository_Expecter) RenameDatabaseRole(ctx interface{}, database interface{}, oldName interface{}, newName interface{}) *mockDataAccessRepository_RenameDatabaseRole_Call {
+	return &mockDataAccessRepository_RenameDatabaseRole_Call{Call: _e.mock.On("RenameDatabaseRole", ctx, database, oldName, newName)}
 }
 
-func (_c *mockDataAccessRepository_RenameDatabaseRole_Call) Run(run func(database string, oldName string, newName string)) *mockDataAccessRepository_RenameDatabaseRole_Call {
+func (_c *mockDataAccessRepository_RenameDatabaseRole_Call) Run(run func(ctx context.Context, database string, oldName string, newName string)) *mockDataAccessRepository_RenameDatabaseRole_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(string), args[2].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string))
 	})
 	return _c
 }
@@ -3160,7 +3496,7 @@ func (_c *mockDataAccessRepository_RenameDatabaseRole_Call) Return(_a0 error) *m
 	return _c
 }
 
-func (_c *mockDataAccessRepository_RenameDatabaseRole_Call) RunAndReturn(run func(string, string, string) error) *mockDataAccessRepository_RenameDatabaseRole_Call {
+func (_c *mockDataAccessRepository_RenameDatabaseRole_Call) RunAndReturn(run func(context.Context, string, string, string) error) *mockDataAccessRepository_RenameDatabaseRole_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -3604,17 +3940,17 @@ func (_c *mockDataAccessRepository_RevokeUsersFromAccountRole_Call) RunAndReturn
 	return _c
 }
 
-// SetShareAccounts provides a mock function with given fields: shareName, accounts
-func (_m *mockDataAccessRepository) SetShareAccounts(shareName string, accounts []string) error {
-	ret := _m.Called(shareName, accounts)
+// SetShareAccounts provides a mock function with given fields: ctx, shareName, accounts
+func (_m *mockDataAccessRepository) SetShareAccounts(ctx context.Context, shareName string, accounts []string) error {
+	ret := _m.Called(ctx, shareName, accounts)
 
 	if len(ret) == 0 {
 		panic("no return value specified for SetShareAccounts")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, []string) error); ok {
-		r0 = rf(shareName, accounts)
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) error); ok {
+		r0 = rf(ctx, shareName, accounts)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -3628,15 +3964,16 @@ type mockDataAccessRepository_SetShareAccounts_Call struct {
 }
 
 // SetShareAccounts is a helper method to define mock.On call
+//   - ctx context.Context
 //   - shareName string
 //   - accounts []string
-func (_e *mockDataAccessRepository_Expecter) SetShareAccounts(shareName interface{}, accounts interface{}) *mockDataAccessRepository_SetShareAccounts_Call {
-	return &mockDataAccessRepository_SetShareAccounts_Call{Call: _e.mock.On("SetShareAccounts", shareName, accounts)}
+func (_e *mockDataAccessRepository_Expecter) SetShareAccounts(ctx interface{}, shareName interface{}, accounts interface{}) *mockDataAccessRepository_SetShareAccounts_Call {
+	return &mockDataAccessRepository_SetShareAccounts_Call{Call: _e.mock.On("SetShareAccounts", ctx, shareName, accounts)}
 }
 
-func (_c *mockDataAccessRepository_SetShareAccounts_Call) Run(run func(shareName string, accounts []string)) *mockDataAccessRepository_SetShareAccounts_Call {
+func (_c *mockDataAccessRepository_SetShareAccounts_Call) Run(run func(ctx context.Context, shareName string, accounts []string)) *mockDataAccessRepository_SetShareAccounts_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].([]string))
+		run(args[0].(context.Context), args[1].(string), args[2].([]string))
 	})
 	return _c
 }
@@ -3646,22 +3983,22 @@ func (_c *mockDataAccessRepository_SetShareAccounts_Call) Return(err error) *moc
 	return _c
 }
 
-func (_c *mockDataAccessRepository_SetShareAccounts_Call) RunAndReturn(run func(string, []string) error) *mockDataAccessRepository_SetShareAccounts_Call {
+func (_c *mockDataAccessRepository_SetShareAccounts_Call) RunAndReturn(run func(context.Context, string, []string) error) *mockDataAccessRepository_SetShareAccounts_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// SetTagOnRole provides a mock function with given fields: roleName, tagName, tagValue, isDatabaseRole
-func (_m *mockDataAccessRepository) SetTagOnRole(roleName string, tagName string, tagValue string, isDatabaseRole bool) error {
-	ret := _m.Called(roleName, tagName, tagValue, isDatabaseRole)
+// SetTagOnRole provides a mock function with given fields: ctx, roleName, tagName, tagValue, isDatabaseRole
+func (_m *mockDataAccessRepository) SetTagOnRole(ctx context.Context, roleName string, tagName string, tagValue string, isDatabaseRole bool) error {
+	ret := _m.Called(ctx, roleName, tagName, tagValue, isDatabaseRole)
 
 	if len(ret) == 0 {
 		panic("no return value specified for SetTagOnRole")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, string, string, bool) error); ok {
-		r0 = rf(roleName, tagName, tagValue, isDatabaseRole)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, bool) error); ok {
+		r0 = rf(ctx, roleName, tagName, tagValue, isDatabaseRole)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -3675,17 +4012,18 @@ type mockDataAccessRepository_SetTagOnRole_Call struct {
 }
 
 // SetTagOnRole is a helper method to define mock.On call
+//   - ctx context.Context
 //   - roleName string
 //   - tagName string
 //   - tagValue string
 //   - isDatabaseRole bool
-func (_e *mockDataAccessRepository_Expecter) SetTagOnRole(roleName interface{}, tagName interface{}, tagValue interface{}, isDatabaseRole interface{}) *mockDataAccessRepository_SetTagOnRole_Call {
-	return &mockDataAccessRepository_SetTagOnRole_Call{Call: _e.mock.On("SetTagOnRole", roleName, tagName, tagValue, isDatabaseRole)}
+func (_e *mockDataAccessRepository_Expecter) SetTagOnRole(ctx interface{}, roleName interface{}, tagName interface{}, tagValue interface{}, isDatabaseRole interface{}) *mockDataAccessRepository_SetTagOnRole_Call {
+	return &mockDataAccessRepository_SetTagOnRole_Call{Call: _e.mock.On("SetTagOnRole", ctx, roleName, tagName, tagValue, isDatabaseRole)}
 }
 
-func (_c *mockDataAccessRepository_SetTagOnRole_Call) Run(run func(roleName string, tagName string, tagValue string, isDatabaseRole bool)) *mockDataAccessRepository_SetTagOnRole_Call {
+func (_c *mockDataAccessRepository_SetTagOnRole_Call) Run(run func(ctx context.Context, roleName string, tagName string, tagValue string, isDatabaseRole bool)) *mockDataAccessRepository_SetTagOnRole_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(string), args[2].(string), args[3].(bool))
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string), args[4].(bool))
 	})
 	return _c
 }
@@ -3695,7 +4033,7 @@ func (_c *mockDataAccessRepository_SetTagOnRole_Call) Return(_a0 error) *mockDat
 	return _c
 }
 
-func (_c *mockDataAccessRepository_SetTagOnRole_Call) RunAndReturn(run func(string, string, string, bool) error) *mockDataAccessRepository_SetTagOnRole_Call {
+func (_c *mockDataAccessRepository_SetTagOnRole_Call) RunAndReturn(run func(context.Context, string, string, string, bool) error) *mockDataAccessRepository_SetTagOnRole_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -3745,17 +4083,62 @@ func (_c *mockDataAccessRepository_TotalQueryTime_Call) RunAndReturn(run func()
 	return _c
 }
 
-// UpdateFilter provides a mock function with given fields: databaseName, schema, tableName, filterName, argumentNames, expression
-func (_m *mockDataAccessRepository) UpdateFilter(databaseName string, schema string, tableName string, filterName string, argumentNames []string, expression string) error {
-	ret := _m.Called(databaseName, schema, tableName, filterName, argumentNames, expression)
+// TotalRetries provides a mock function with no fields
+func (_m *mockDataAccessRepository) TotalRetries() int {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for TotalRetries")
+	}
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func() int); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	return r0
+}
+
+// mockDataAccessRepository_TotalRetries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TotalRetries'
+type mockDataAccessRepository_TotalRetries_Call struct {
+	*mock.Call
+}
+
+// TotalRetries is a helper method to define mock.On call
+func (_e *mockDataAccessRepository_Expecter) TotalRetries() *mockDataAccessRepository_TotalRetries_Call {
+	return &mockDataAccessRepository_TotalRetries_Call{Call: _e.mock.On("TotalRetries")}
+}
+
+func (_c *mockDataAccessRepository_TotalRetries_Call) Run(run func()) *mockDataAccessRepository_TotalRetries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *mockDataAccessRepository_TotalRetries_Call) Return(_a0 int) *mockDataAccessRepository_TotalRetries_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *mockDataAccessRepository_TotalRetries_Call) RunAndReturn(run func() int) *mockDataAccessRepository_TotalRetries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateFilter provides a mock function with given fields: ctx, databaseName, schema, tableName, filterName, argumentNames, expression
+func (_m *mockDataAccessRepository) UpdateFilter(ctx context.Context, databaseName string, schema string, tableName string, filterName string, argumentNames []string, expression string) error {
+	ret := _m.Called(ctx, databaseName, schema, tableName, filterName, argumentNames, expression)
 
 	if len(ret) == 0 {
 		panic("no return value specified for UpdateFilter")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, string, string, string, []string, string) error); ok {
-		r0 = rf(databaseName, schema, tableName, filterName, argumentNames, expression)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string, []string, string) error); ok {
+		r0 = rf(ctx, databaseName, schema, tableName, filterName, argumentNames, expression)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -3769,19 +4152,20 @@ type mockDataAccessRepository_UpdateFilter_Call struct {
 }
 
 // UpdateFilter is a helper method to define mock.On call
+//   - ctx context.Context
 //   - databaseName string
 //   - schema string
 //   - tableName string
 //   - filterName string
 //   - argumentNames []string
 //   - expression string
-func (_e *mockDataAccessRepository_Expecter) UpdateFilter(databaseName interface{}, schema interface{}, tableName interface{}, filterName interface{}, argumentNames interface{}, expression interface{}) *mockDataAccessRepository_UpdateFilter_Call {
-	return &mockDataAccessRepository_UpdateFilter_Call{Call: _e.mock.On("UpdateFilter", databaseName, schema, tableName, filterName, argumentNames, expression)}
+func (_e *mockDataAccessRepository_Expecter) UpdateFilter(ctx interface{}, databaseName interface{}, schema interface{}, tableName interface{}, filterName interface{}, argumentNames interface{}, expression interface{}) *mockDataAccessRepository_UpdateFilter_Call {
+	return &mockDataAccessRepository_UpdateFilter_Call{Call: _e.mock.On("UpdateFilter", ctx, databaseName, schema, tableName, filterName, argumentNames, expression)}
 }
 
-func (_c *mockDataAccessRepository_UpdateFilter_Call) Run(run func(databaseName string, schema string, tableName string, filterName string, argumentNames []string, expression string)) *mockDataAccessRepository_UpdateFilter_Call {
+func (_c *mockDataAccessRepository_UpdateFilter_Call) Run(run func(ctx context.Context, databaseName string, schema string, tableName string, filterName string, argumentNames []string, expression string)) *mockDataAccessRepository_UpdateFilter_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(string), args[2].(string), args[3].(string), args[4].([]string), args[5].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string), args[4].(string), args[5].([]string), args[6].(string))
 	})
 	return _c
 }
@@ -3791,7 +4175,7 @@ func (_c *mockDataAccessRepository_UpdateFilter_Call) Return(_a0 error) *mockDat
 	return _c
 }
 
-func (_c *mockDataAccessRepository_UpdateFilter_Call) RunAndReturn(run func(string, string, string, string, []string, string) error) *mockDataAccessRepository_UpdateFilter_Call {
+func (_c *mockDataAccessRepository_UpdateFilter_Call) RunAndReturn(run func(context.Context, string, string, string, string, []string, string) error) *mockDataAccessRepository_UpdateFilter_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -3,6 +3,7 @@
 package snowflake
 
 import (
+	context "context"
 	time "time"
 
 	tag "github.com/raito-io/cli/base/tag"
@@ -67,17 +68,17 @@ func (_c *mockDataSourceRepository_Close_Call) RunAndReturn(run func() error) *m
 	return _c
 }
 
-// ExecuteGrantOnAccountRole provides a mock function with given fields: perm, on, role, isSystemGrant
-func (_m *mockDataSourceRepository) ExecuteGrantOnAccountRole(perm string, on string, role string, isSystemGrant bool) error {
-	ret := _m.Called(perm, on, role, isSystemGrant)
+// ExecuteGrantOnAccountRole provides a mock function with given fields: ctx, perm, on, role, isSystemGrant
+func (_m *mockDataSourceRepository) ExecuteGrantOnAccountRole(ctx context.Context, perm string, on string, role string, isSystemGrant bool) error {
+	ret := _m.Called(ctx, perm, on, role, isSystemGrant)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ExecuteGrantOnAccountRole")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, string, string, bool) error); ok {
-		r0 = rf(perm, on, role, isSystemGrant)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, bool) error); ok {
+		r0 = rf(ctx, perm, on, role, isSystemGrant)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -91,17 +92,18 @@ type mockDataSourceRepository_ExecuteGrantOnAccountRole_Call struct {
 }
 
 // ExecuteGrantOnAccountRole is a helper method to define mock.On call
+//   - ctx context.Context
 //   - perm string
 //   - on string
 //   - role string
 //   - isSystemGrant bool
-func (_e *mockDataSourceRepository_Expecter) ExecuteGrantOnAccountRole(perm interface{}, on interface{}, role interface{}, isSystemGrant interface{}) *mockDataSourceRepository_ExecuteGrantOnAccountRole_Call {
-	return &mockDataSourceRepository_ExecuteGrantOnAccountRole_Call{Call: _e.mock.On("ExecuteGrantOnAccountRole", perm, on, role, isSystemGrant)}
+func (_e *mockDataSourceRepository_Expecter) ExecuteGrantOnAccountRole(ctx interface{}, perm interface{}, on interface{}, role interface{}, isSystemGrant interface{}) *mockDataSourceRepository_ExecuteGrantOnAccountRole_Call {
+	return &mockDataSourceRepository_ExecuteGrantOnAccountRole_Call{Call: _e.mock.On("ExecuteGrantOnAccountRole", ctx, perm, on, role, isSystemGrant)}
 }
 
-func (_c *mockDataSourceRepository_ExecuteGrantOnAccountRole_Call) Run(run func(perm string, on string, role string, isSystemGrant bool)) *mockDataSourceRepository_ExecuteGrantOnAccountRole_Call {
+func (_c *mockDataSourceRepository_ExecuteGrantOnAccountRole_Call) Run(run func(ctx context.Context, perm string, on string, role string, isSystemGrant bool)) *mockDataSourceRepository_ExecuteGrantOnAccountRole_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(string), args[2].(string), args[3].(bool))
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string), args[4].(bool))
 	})
 	return _c
 }
@@ -111,14 +113,14 @@ func (_c *mockDataSourceRepository_ExecuteGrantOnAccountRole_Call) Return(_a0 er
 	return _c
 }
 
-func (_c *mockDataSourceRepository_ExecuteGrantOnAccountRole_Call) RunAndReturn(run func(string, string, string, bool) error) *mockDataSourceRepository_ExecuteGrantOnAccountRole_Call {
+func (_c *mockDataSourceRepository_ExecuteGrantOnAccountRole_Call) RunAndReturn(run func(context.Context, string, string, string, bool) error) *mockDataSourceRepository_ExecuteGrantOnAccountRole_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetApplications provides a mock function with no fields
-func (_m *mockDataSourceRepository) GetApplications() ([]ApplictionEntity, error) {
-	ret := _m.Called()
+// GetApplications provides a mock function with given fields: ctx
+func (_m *mockDataSourceRepository) GetApplications(ctx context.Context) ([]ApplictionEntity, error) {
+	ret := _m.Called(ctx)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetApplications")
@@ -126,19 +128,19 @@ func (_m *mockDataSourceRepository) GetApplications() ([]ApplictionEntity, error
 
 	var r0 []ApplictionEntity
 	var r1 error
-	if rf, ok := ret.Get(0).(func() ([]ApplictionEntity, error)); ok {
-		return rf()
+	if rf, ok := ret.Get(0).(func(context.Context) ([]ApplictionEntity, error)); ok {
+		return rf(ctx)
 	}
-	if rf, ok := ret.Get(0).(func() []ApplictionEntity); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(context.Context) []ApplictionEntity); ok {
+		r0 = rf(ctx)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]ApplictionEntity)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -152,13 +154,14 @@ type mockDataSourceRepository_GetApplications_Call struct {
 }
 
 // GetApplications is a helper method to define mock.On call
-func (_e *mockDataSourceRepository_Expecter) GetApplications() *mockDataSourceRepository_GetApplications_Call {
-	return &mockDataSourceRepository_GetApplications_Call{Call: _e.mock.On("GetApplications")}
+//   - ctx context.Context
+func (_e *mockDataSourceRepository_Expecter) GetApplications(ctx interface{}) *mockDataSourceRepository_GetApplications_Call {
+	return &mockDataSourceRepository_GetApplications_Call{Call: _e.mock.On("GetApplications", ctx)}
 }
 
-func (_c *mockDataSourceRepository_GetApplications_Call) Run(run func()) *mockDataSourceRepository_GetApplications_Call {
+func (_c *mockDataSourceRepository_GetApplications_Call) Run(run func(ctx context.Context)) *mockDataSourceRepository_GetApplications_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		run(args[0].(context.Context))
 	})
 	return _c
 }
@@ -168,22 +171,22 @@ func (_c *mockDataSourceRepository_GetApplications_Call) Return(_a0 []Appliction
 	return _c
 }
 
-func (_c *mockDataSourceRepository_GetApplications_Call) RunAndReturn(run func() ([]ApplictionEntity, error)) *mockDataSourceRepository_GetApplications_Call {
+func (_c *mockDataSourceRepository_GetApplications_Call) RunAndReturn(run func(context.Context) ([]ApplictionEntity, error)) *mockDataSourceRepository_GetApplications_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetColumnsInDatabase provides a mock function with given fields: databaseName, handleEntity
-func (_m *mockDataSourceRepository) GetColumnsInDatabase(databaseName string, handleEntity EntityHandler) error {
-	ret := _m.Called(databaseName, handleEntity)
+// GetColumnsInDatabase provides a mock function with given fields: ctx, databaseName, handleEntity
+func (_m *mockDataSourceRepository) GetColumnsInDatabase(ctx context.Context, databaseName string, handleEntity EntityHandler) error {
+	ret := _m.Called(ctx, databaseName, handleEntity)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetColumnsInDatabase")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, EntityHandler) error); ok {
-		r0 = rf(databaseName, handleEntity)
+	if rf, ok := ret.Get(0).(func(context.Context, string, EntityHandler) error); ok {
+		r0 = rf(ctx, databaseName, handleEntity)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -197,15 +200,16 @@ type mockDataSourceRepository_GetColumnsInDatabase_Call struct {
 }
 
 // GetColumnsInDatabase is a helper method to define mock.On call
+//   - ctx context.Context
 //   - databaseName string
 //   - handleEntity EntityHandler
-func (_e *mockDataSourceRepository_Expecter) GetColumnsInDatabase(databaseName interface{}, handleEntity interface{}) *mockDataSourceRepository_GetColumnsInDatabase_Call {
-	return &mockDataSourceRepository_GetColumnsInDatabase_Call{Call: _e.mock.On("GetColumnsInDatabase", databaseName, handleEntity)}
+func (_e *mockDataSourceRepository_Expecter) GetColumnsInDatabase(ctx interface{}, databaseName interface{}, handleEntity interface{}) *mockDataSourceRepository_GetColumnsInDatabase_Call {
+	return &mockDataSourceRepository_GetColumnsInDatabase_Call{Call: _e.mock.On("GetColumnsInDatabase", ctx, databaseName, handleEntity)}
 }
 
-func (_c *mockDataSourceRepository_GetColumnsInDatabase_Call) Run(run func(databaseName string, handleEntity EntityHandler)) *mockDataSourceRepository_GetColumnsInDatabase_Call {
+func (_c *mockDataSourceRepository_GetColumnsInDatabase_Call) Run(run func(ctx context.Context, databaseName string, handleEntity EntityHandler)) *mockDataSourceRepository_GetColumnsInDatabase_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(EntityHandler))
+		run(args[0].(context.Context), args[1].(string), args[2].(EntityHandler))
 	})
 	return _c
 }
@@ -215,14 +219,72 @@ func (_c *mockDataSourceRepository_GetColumnsInDatabase_Call) Return(_a0 error)
 	return _c
 }
 
-func (_c *mockDataSourceRepository_GetColumnsInDatabase_Call) RunAndReturn(run func(string, EntityHandler) error) *mockDataSourceRepository_GetColumnsInDatabase_Call {
+func (_c *mockDataSourceRepository_GetColumnsInDatabase_Call) RunAndReturn(run func(context.Context, string, EntityHandler) error) *mockDataSourceRepository_GetColumnsInDatabase_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetDatabases provides a mock function with no fields
-func (_m *mockDataSourceRepository) GetDatabases() ([]DbEntity, error) {
-	ret := _m.Called()
+// GetComputePools provides a mock function with given fields: ctx
+func (_m *mockDataSourceRepository) GetComputePools(ctx context.Context) ([]DbEntity, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetComputePools")
+	}
+
+	var r0 []DbEntity
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]DbEntity, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []DbEntity); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]DbEntity)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// mockDataSourceRepository_GetComputePools_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetComputePools'
+type mockDataSourceRepository_GetComputePools_Call struct {
+	*mock.Call
+}
+
+// GetComputePools is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *mockDataSourceRepository_Expecter) GetComputePools(ctx interface{}) *mockDataSourceRepository_GetComputePools_Call {
+	return &mockDataSourceRepository_GetComputePools_Call{Call: _e.mock.On("GetComputePools", ctx)}
+}
+
+func (_c *mockDataSourceRepository_GetComputePools_Call) Run(run func(ctx context.Context)) *mockDataSourceRepository_GetComputePools_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *mockDataSourceRepository_GetComputePools_Call) Return(_a0 []DbEntity, _a1 error) *mockDataSourceRepository_GetComputePools_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *mockDataSourceRepository_GetComputePools_Call) RunAndReturn(run func(context.Context) ([]DbEntity, error)) *mockDataSourceRepository_GetComputePools_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetDatabases provides a mock function with given fields: ctx
+func (_m *mockDataSourceRepository) GetDatabases(ctx context.Context) ([]DbEntity, error) {
+	ret := _m.Called(ctx)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetDatabases")
@@ -230,19 +292,19 @@ func (_m *mockDataSourceRepository) GetDatabases() ([]DbEntity, error) {
 
 	var r0 []DbEntity
 	var r1 error
-	if rf, ok := ret.Get(0).(func() ([]DbEntity, error)); ok {
-		return rf()
+	if rf, ok := ret.Get(0).(func(context.Context) ([]DbEntity, error)); ok {
+		return rf(ctx)
 	}
-	if rf, ok := ret.Get(0).(func() []DbEntity); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(context.Context) []DbEntity); ok {
+		r0 = rf(ctx)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]DbEntity)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -256,13 +318,14 @@ type mockDataSourceRepository_GetDatabases_Call struct {
 }
 
 // GetDatabases is a helper method to define mock.On call
-func (_e *mockDataSourceRepository_Expecter) GetDatabases() *mockDataSourceRepository_GetDatabases_Call {
-	return &mockDataSourceRepository_GetDatabases_Call{Call: _e.mock.On("GetDatabases")}
+//   - ctx context.Context
+func (_e *mockDataSourceRepository_Expecter) GetDatabases(ctx interface{}) *mockDataSourceRepository_GetDatabases_Call {
+	return &mockDataSourceRepository_GetDatabases_Call{Call: _e.mock.On("GetDatabases", ctx)}
 }
 
-func (_c *mockDataSourceRepository_GetDatabases_Call) Run(run func()) *mockDataSourceRepository_GetDatabases_Call {
+func (_c *mockDataSourceRepository_GetDatabases_Call) Run(run func(ctx context.Context)) *mockDataSourceRepository_GetDatabases_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		run(args[0].(context.Context))
 	})
 	return _c
 }
@@ -272,22 +335,22 @@ func (_c *mockDataSourceRepository_GetDatabases_Call) Return(_a0 []DbEntity, _a1
 	return _c
 }
 
-func (_c *mockDataSourceRepository_GetDatabases_Call) RunAndReturn(run func() ([]DbEntity, error)) *mockDataSourceRepository_GetDatabases_Call {
+func (_c *mockDataSourceRepository_GetDatabases_Call) RunAndReturn(run func(context.Context) ([]DbEntity, error)) *mockDataSourceRepository_GetDatabases_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetFunctionsInDatabase provides a mock function with given fields: databaseName, handleEntity
-func (_m *mockDataSourceRepository) GetFunctionsInDatabase(databaseName string, handleEntity EntityHandler) error {
-	ret := _m.Called(databaseName, handleEntity)
+// GetFunctionsInDatabase provides a mock function with given fields: ctx, databaseName, handleEntity
+func (_m *mockDataSourceRepository) GetFunctionsInDatabase(ctx context.Context, databaseName string, handleEntity EntityHandler) error {
+	ret := _m.Called(ctx, databaseName, handleEntity)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetFunctionsInDatabase")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, EntityHandler) error); ok {
-		r0 = rf(databaseName, handleEntity)
+	if rf, ok := ret.Get(0).(func(context.Context, string, EntityHandler) error); ok {
+		r0 = rf(ctx, databaseName, handleEntity)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -301,15 +364,16 @@ type mockDataSourceRepository_GetFunctionsInDatabase_Call struct {
 }
 
 // GetFunctionsInDatabase is a helper method to define mock.On call
+//   - ctx context.Context
 //   - databaseName string
 //   - handleEntity EntityHandler
-func (_e *mockDataSourceRepository_Expecter) GetFunctionsInDatabase(databaseName interface{}, handleEntity interface{}) *mockDataSourceRepository_GetFunctionsInDatabase_Call {
-	return &mockDataSourceRepository_GetFunctionsInDatabase_Call{Call: _e.mock.On("GetFunctionsInDatabase", databaseName, handleEntity)}
+func (_e *mockDataSourceRepository_Expecter) GetFunctionsInDatabase(ctx interface{}, databaseName interface{}, handleEntity interface{}) *mockDataSourceRepository_GetFunctionsInDatabase_Call {
+	return &mockDataSourceRepository_GetFunctionsInDatabase_Call{Call: _e.mock.On("GetFunctionsInDatabase", ctx, databaseName, handleEntity)}
 }
 
-func (_c *mockDataSourceRepository_GetFunctionsInDatabase_Call) Run(run func(databaseName string, handleEntity EntityHandler)) *mockDataSourceRepository_GetFunctionsInDatabase_Call {
+func (_c *mockDataSourceRepository_GetFunctionsInDatabase_Call) Run(run func(ctx context.Context, databaseName string, handleEntity EntityHandler)) *mockDataSourceRepository_GetFunctionsInDatabase_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(EntityHandler))
+		run(args[0].(context.Context), args[1].(string), args[2].(EntityHandler))
 	})
 	return _c
 }
@@ -319,14 +383,14 @@ func (_c *mockDataSourceRepository_GetFunctionsInDatabase_Call) Return(_a0 error
 	return _c
 }
 
-func (_c *mockDataSourceRepository_GetFunctionsInDatabase_Call) RunAndReturn(run func(string, EntityHandler) error) *mockDataSourceRepository_GetFunctionsInDatabase_Call {
+func (_c *mockDataSourceRepository_GetFunctionsInDatabase_Call) RunAndReturn(run func(context.Context, string, EntityHandler) error) *mockDataSourceRepository_GetFunctionsInDatabase_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetInboundShares provides a mock function with no fields
-func (_m *mockDataSourceRepository) GetInboundShares() ([]DbEntity, error) {
-	ret := _m.Called()
+// GetInboundShares provides a mock function with given fields: ctx
+func (_m *mockDataSourceRepository) GetInboundShares(ctx context.Context) ([]DbEntity, error) {
+	ret := _m.Called(ctx)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetInboundShares")
@@ -334,19 +398,19 @@ func (_m *mockDataSourceRepository) GetInboundShares() ([]DbEntity, error) {
 
 	var r0 []DbEntity
 	var r1 error
-	if rf, ok := ret.Get(0).(func() ([]DbEntity, error)); ok {
-		return rf()
+	if rf, ok := ret.Get(0).(func(context.Context) ([]DbEntity, error)); ok {
+		return rf(ctx)
 	}
-	if rf, ok := ret.Get(0).(func() []DbEntity); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(context.Context) []DbEntity); ok {
+		r0 = rf(ctx)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]DbEntity)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -360,13 +424,14 @@ type mockDataSourceRepository_GetInboundShares_Call struct {
 }
 
 // GetInboundShares is a helper method to define mock.On call
-func (_e *mockDataSourceRepository_Expecter) GetInboundShares() *mockDataSourceRepository_GetInboundShares_Call {
-	return &mockDataSourceRepository_GetInboundShares_Call{Call: _e.mock.On("GetInboundShares")}
+//   - ctx context.Context
+func (_e *mockDataSourceRepository_Expecter) GetInboundShares(ctx interface{}) *mockDataSourceRepository_GetInboundShares_Call {
+	return &mockDataSourceRepository_GetInboundShares_Call{Call: _e.mock.On("GetInboundShares", ctx)}
 }
 
-func (_c *mockDataSourceRepository_GetInboundShares_Call) Run(run func()) *mockDataSourceRepository_GetInboundShares_Call {
+func (_c *mockDataSourceRepository_GetInboundShares_Call) Run(run func(ctx context.Context)) *mockDataSourceRepository_GetInboundShares_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		run(args[0].(context.Context))
 	})
 	return _c
 }
@@ -376,14 +441,14 @@ func (_c *mockDataSourceRepository_GetInboundShares_Call) Return(_a0 []DbEntity,
 	return _c
 }
 
-func (_c *mockDataSourceRepository_GetInboundShares_Call) RunAndReturn(run func() ([]DbEntity, error)) *mockDataSourceRepository_GetInboundShares_Call {
+func (_c *mockDataSourceRepository_GetInboundShares_Call) RunAndReturn(run func(context.Context) ([]DbEntity, error)) *mockDataSourceRepository_GetInboundShares_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetIntegrations provides a mock function with no fields
-func (_m *mockDataSourceRepository) GetIntegrations() ([]DbEntity, error) {
-	ret := _m.Called()
+// GetIntegrations provides a mock function with given fields: ctx
+func (_m *mockDataSourceRepository) GetIntegrations(ctx context.Context) ([]DbEntity, error) {
+	ret := _m.Called(ctx)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetIntegrations")
@@ -391,19 +456,19 @@ func (_m *mockDataSourceRepository) GetIntegrations() ([]DbEntity, error) {
 
 	var r0 []DbEntity
 	var r1 error
-	if rf, ok := ret.Get(0).(func() ([]DbEntity, error)); ok {
-		return rf()
+	if rf, ok := ret.Get(0).(func(context.Context) ([]DbEntity, error)); ok {
+		return rf(ctx)
 	}
-	if rf, ok := ret.Get(0).(func() []DbEntity); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(context.Context) []DbEntity); ok {
+		r0 = rf(ctx)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]DbEntity)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -417,13 +482,14 @@ type mockDataSourceRepository_GetIntegrations_Call struct {
 }
 
 // GetIntegrations is a helper method to define mock.On call
-func (_e *mockDataSourceRepository_Expecter) GetIntegrations() *mockDataSourceRepository_GetIntegrations_Call {
-	return &mockDataSourceRepository_GetIntegrations_Call{Call: _e.mock.On("GetIntegrations")}
+//   - ctx context.Context
+func (_e *mockDataSourceRepository_Expecter) GetIntegrations(ctx interface{}) *mockDataSourceRepository_GetIntegrations_Call {
+	return &mockDataSourceRepository_GetIntegrations_Call{Call: _e.mock.On("GetIntegrations", ctx)}
 }
 
-func (_c *mockDataSourceRepository_GetIntegrations_Call) Run(run func()) *mockDataSourceRepository_GetIntegrations_Call {
+func (_c *mockDataSourceRepository_GetIntegrations_Call) Run(run func(ctx context.Context)) *mockDataSourceRepository_GetIntegrations_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		run(args[0].(context.Context))
 	})
 	return _c
 }
@@ -433,22 +499,71 @@ func (_c *mockDataSourceRepository_GetIntegrations_Call) Return(_a0 []DbEntity,
 	return _c
 }
 
-func (_c *mockDataSourceRepository_GetIntegrations_Call) RunAndReturn(run func() ([]DbEntity, error)) *mockDataSourceRepository_GetIntegrations_Call {
+func (_c *mockDataSourceRepository_GetIntegrations_Call) RunAndReturn(run func(context.Context) ([]DbEntity, error)) *mockDataSourceRepository_GetIntegrations_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetObjectsInDatabase provides a mock function with given fields: ctx, objectKind, databaseName, handleEntity
+func (_m *mockDataSourceRepository) GetObjectsInDatabase(ctx context.Context, objectKind string, databaseName string, handleEntity EntityHandler) error {
+	ret := _m.Called(ctx, objectKind, databaseName, handleEntity)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetObjectsInDatabase")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, EntityHandler) error); ok {
+		r0 = rf(ctx, objectKind, databaseName, handleEntity)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// mockDataSourceRepository_GetObjectsInDatabase_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetObjectsInDatabase'
+type mockDataSourceRepository_GetObjectsInDatabase_Call struct {
+	*mock.Call
+}
+
+// GetObjectsInDatabase is a helper method to define mock.On call
+//   - ctx context.Context
+//   - objectKind string
+//   - databaseName string
+//   - handleEntity EntityHandler
+func (_e *mockDataSourceRepository_Expecter) GetObjectsInDatabase(ctx interface{}, objectKind interface{}, databaseName interface{}, handleEntity interface{}) *mockDataSourceRepository_GetObjectsInDatabase_Call {
+	return &mockDataSourceRepository_GetObjectsInDatabase_Call{Call: _e.mock.On("GetObjectsInDatabase", ctx, objectKind, databaseName, handleEntity)}
+}
+
+func (_c *mockDataSourceRepository_GetObjectsInDatabase_Call) Run(run func(ctx context.Context, objectKind string, databaseName string, handleEntity EntityHandler)) *mockDataSourceRepository_GetObjectsInDatabase_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(EntityHandler))
+	})
+	return _c
+}
+
+func (_c *mockDataSourceRepository_GetObjectsInDatabase_Call) Return(_a0 error) *mockDataSourceRepository_GetObjectsInDatabase_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *mockDataSourceRepository_GetObjectsInDatabase_Call) RunAndReturn(run func(context.Context, string, string, EntityHandler) error) *mockDataSourceRepository_GetObjectsInDatabase_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetProceduresInDatabase provides a mock function with given fields: databaseName, handleEntity
-func (_m *mockDataSourceRepository) GetProceduresInDatabase(databaseName string, handleEntity EntityHandler) error {
-	ret := _m.Called(databaseName, handleEntity)
+// GetProceduresInDatabase provides a mock function with given fields: ctx, databaseName, handleEntity
+func (_m *mockDataSourceRepository) GetProceduresInDatabase(ctx context.Context, databaseName string, handleEntity EntityHandler) error {
+	ret := _m.Called(ctx, databaseName, handleEntity)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetProceduresInDatabase")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, EntityHandler) error); ok {
-		r0 = rf(databaseName, handleEntity)
+	if rf, ok := ret.Get(0).(func(context.Context, string, EntityHandler) error); ok {
+		r0 = rf(ctx, databaseName, handleEntity)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -462,15 +577,16 @@ type mockDataSourceRepository_GetProceduresInDatabase_Call struct {
 }
 
 // GetProceduresInDatabase is a helper method to define mock.On call
+//   - ctx context.Context
 //   - databaseName string
 //   - handleEntity EntityHandler
-func (_e *mockDataSourceRepository_Expecter) GetProceduresInDatabase(databaseName interface{}, handleEntity interface{}) *mockDataSourceRepository_GetProceduresInDatabase_Call {
-	return &mockDataSourceRepository_GetProceduresInDatabase_Call{Call: _e.mock.On("GetProceduresInDatabase", databaseName, handleEntity)}
+func (_e *mockDataSourceRepository_Expecter) GetProceduresInDatabase(ctx interface{}, databaseName interface{}, handleEntity interface{}) *mockDataSourceRepository_GetProceduresInDatabase_Call {
+	return &mockDataSourceRepository_GetProceduresInDatabase_Call{Call: _e.mock.On("GetProceduresInDatabase", ctx, databaseName, handleEntity)}
 }
 
-func (_c *mockDataSourceRepository_GetProceduresInDatabase_Call) Run(run func(databaseName string, handleEntity EntityHandler)) *mockDataSourceRepository_GetProceduresInDatabase_Call {
+func (_c *mockDataSourceRepository_GetProceduresInDatabase_Call) Run(run func(ctx context.Context, databaseName string, handleEntity EntityHandler)) *mockDataSourceRepository_GetProceduresInDatabase_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(EntityHandler))
+		run(args[0].(context.Context), args[1].(string), args[2].(EntityHandler))
 	})
 	return _c
 }
@@ -480,22 +596,22 @@ func (_c *mockDataSourceRepository_GetProceduresInDatabase_Call) Return(_a0 erro
 	return _c
 }
 
-func (_c *mockDataSourceRepository_GetProceduresInDatabase_Call) RunAndReturn(run func(string, EntityHandler) error) *mockDataSourceRepository_GetProceduresInDatabase_Call {
+func (_c *mockDataSourceRepository_GetProceduresInDatabase_Call) RunAndReturn(run func(context.Context, string, EntityHandler) error) *mockDataSourceRepository_GetProceduresInDatabase_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetSchemasInDatabase provides a mock function with given fields: databaseName, handleEntity
-func (_m *mockDataSourceRepository) GetSchemasInDatabase(databaseName string, handleEntity EntityHandler) error {
-	ret := _m.Called(databaseName, handleEntity)
+// GetSchemasInDatabase provides a mock function with given fields: ctx, databaseName, handleEntity
+func (_m *mockDataSourceRepository) GetSchemasInDatabase(ctx context.Context, databaseName string, handleEntity EntityHandler) error {
+	ret := _m.Called(ctx, databaseName, handleEntity)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetSchemasInDatabase")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, EntityHandler) error); ok {
-		r0 = rf(databaseName, handleEntity)
+	if rf, ok := ret.Get(0).(func(context.Context, string, EntityHandler) error); ok {
+		r0 = rf(ctx, databaseName, handleEntity)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -509,15 +625,16 @@ type mockDataSourceRepository_GetSchemasInDatabase_Call struct {
 }
 
 // GetSchemasInDatabase is a helper method to define mock.On call
+//   - ctx context.Context
 //   - databaseName string
 //   - handleEntity EntityHandler
-func (_e *mockDataSourceRepository_Expecter) GetSchemasInDatabase(databaseName interface{}, handleEntity interface{}) *mockDataSourceRepository_GetSchemasInDatabase_Call {
-	return &mockDataSourceRepository_GetSchemasInDatabase_Call{Call: _e.mock.On("GetSchemasInDatabase", databaseName, handleEntity)}
+func (_e *mockDataSourceRepository_Expecter) GetSchemasInDatabase(ctx interface{}, databaseName interface{}, handleEntity interface{}) *mockDataSourceRepository_GetSchemasInDatabase_Call {
+	return &mockDataSourceRepository_GetSchemasInDatabase_Call{Call: _e.mock.On("GetSchemasInDatabase", ctx, databaseName, handleEntity)}
 }
 
-func (_c *mockDataSourceRepository_GetSchemasInDatabase_Call) Run(run func(databaseName string, handleEntity EntityHandler)) *mockDataSourceRepository_GetSchemasInDatabase_Call {
+func (_c *mockDataSourceRepository_GetSchemasInDatabase_Call) Run(run func(ctx context.Context, databaseName string, handleEntity EntityHandler)) *mockDataSourceRepository_GetSchemasInDatabase_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(EntityHandler))
+		run(args[0].(context.Context), args[1].(string), args[2].(EntityHandler))
 	})
 	return _c
 }
@@ -527,18 +644,19 @@ func (_c *mockDataSourceRepository_GetSchemasInDatabase_Call) Return(_a0 error)
 	return _c
 }
 
-func (_c *mockDataSourceRepository_GetSchemasInDatabase_Call) RunAndReturn(run func(string, EntityHandler) error) *mockDataSourceRepository_GetSchemasInDatabase_Call {
+func (_c *mockDataSourceRepository_GetSchemasInDatabase_Call) RunAndReturn(run func(context.Context, string, EntityHandler) error) *mockDataSourceRepository_GetSchemasInDatabase_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetSnowFlakeAccountName provides a mock function with given fields: ops
-func (_m *mockDataSourceRepository) GetSnowFlakeAccountName(ops ...func(*GetSnowFlakeAccountNameOptions)) (string, error) {
+// GetSnowFlakeAccountName provides a mock function with given fields: ctx, ops
+func (_m *mockDataSourceRepository) GetSnowFlakeAccountName(ctx context.Context, ops ...func(*GetSnowFlakeAccountNameOptions)) (string, error) {
 	_va := make([]interface{}, len(ops))
 	for _i := range ops {
 		_va[_i] = ops[_i]
 	}
 	var _ca []interface{}
+	_ca = append(_ca, ctx)
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
@@ -548,17 +666,17 @@ func (_m *mockDataSourceRepository) GetSnowFlakeAccountName(ops ...func(*GetSnow
 
 	var r0 string
 	var r1 error
-	if rf, ok := ret.Get(0).(func(...func(*GetSnowFlakeAccountNameOptions)) (string, error)); ok {
-		return rf(ops...)
+	if rf, ok := ret.Get(0).(func(context.Context, ...func(*GetSnowFlakeAccountNameOptions)) (string, error)); ok {
+		return rf(ctx, ops...)
 	}
-	if rf, ok := ret.Get(0).(func(...func(*GetSnowFlakeAccountNameOptions)) string); ok {
-		r0 = rf(ops...)
+	if rf, ok := ret.Get(0).(func(context.Context, ...func(*GetSnowFlakeAccountNameOptions)) string); ok {
+		r0 = rf(ctx, ops...)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
-	if rf, ok := ret.Get(1).(func(...func(*GetSnowFlakeAccountNameOptions)) error); ok {
-		r1 = rf(ops...)
+	if rf, ok := ret.Get(1).(func(context.Context, ...func(*GetSnowFlakeAccountNameOptions)) error); ok {
+		r1 = rf(ctx, ops...)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -572,21 +690,22 @@ type mockDataSourceRepository_GetSnowFlakeAccountName_Call struct {
 }
 
 // GetSnowFlakeAccountName is a helper method to define mock.On call
+//   - ctx context.Context
 //   - ops ...func(*GetSnowFlakeAccountNameOptions)
-func (_e *mockDataSourceRepository_Expecter) GetSnowFlakeAccountName(ops ...interface{}) *mockDataSourceRepository_GetSnowFlakeAccountName_Call {
+func (_e *mockDataSourceRepository_Expecter) GetSnowFlakeAccountName(ctx interface{}, ops ...interface{}) *mockDataSourceRepository_GetSnowFlakeAccountName_Call {
 	return &mockDataSourceRepository_GetSnowFlakeAccountName_Call{Call: _e.mock.On("GetSnowFlakeAccountName",
-		append([]interface{}{}, ops...)...)}
+		append([]interface{}{ctx}, ops...)...)}
 }
 
-func (_c *mockDataSourceRepository_GetSnowFlakeAccountName_Call) Run(run func(ops ...func(*GetSnowFlakeAccountNameOptions))) *mockDataSourceRepository_GetSnowFlakeAccountName_Call {
+func (_c *mockDataSourceRepository_GetSnowFlakeAccountName_Call) Run(run func(ctx context.Context, ops ...func(*GetSnowFlakeAccountNameOptions))) *mockDataSourceRepository_GetSnowFlakeAccountName_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		variadicArgs := make([]func(*GetSnowFlakeAccountNameOptions), len(args)-0)
-		for i, a := range args[0:] {
+		variadicArgs := make([]func(*GetSnowFlakeAccountNameOptions), len(args)-1)
+		for i, a := range args[1:] {
 			if a != nil {
 				variadicArgs[i] = a.(func(*GetSnowFlakeAccountNameOptions))
 			}
 		}
-		run(variadicArgs...)
+		run(args[0].(context.Context), variadicArgs...)
 	})
 	return _c
 }
@@ -596,22 +715,22 @@ func (_c *mockDataSourceRepository_GetSnowFlakeAccountName_Call) Return(_a0 stri
 	return _c
 }
 
-func (_c *mockDataSourceRepository_GetSnowFlakeAccountName_Call) RunAndReturn(run func(...func(*GetSnowFlakeAccountNameOptions)) (string, error)) *mockDataSourceRepository_GetSnowFlakeAccountName_Call {
+func (_c *mockDataSourceRepository_GetSnowFlakeAccountName_Call) RunAndReturn(run func(context.Context, ...func(*GetSnowFlakeAccountNameOptions)) (string, error)) *mockDataSourceRepository_GetSnowFlakeAccountName_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetTablesInDatabase provides a mock function with given fields: databaseName, schemaName, handleEntity
-func (_m *mockDataSourceRepository) GetTablesInDatabase(databaseName string, schemaName string, handleEntity EntityHandler) error {
-	ret := _m.Called(databaseName, schemaName, handleEntity)
+// GetTablesInDatabase provides a mock function with given fields: ctx, databaseName, schemaName, handleEntity
+func (_m *mockDataSourceRepository) GetTablesInDatabase(ctx context.Context, databaseName string, schemaName string, handleEntity EntityHandler) error {
+	ret := _m.Called(ctx, databaseName, schemaName, handleEntity)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetTablesInDatabase")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, string, EntityHandler) error); ok {
-		r0 = rf(databaseName, schemaName, handleEntity)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, EntityHandler) error); ok {
+		r0 = rf(ctx, databaseName, schemaName, handleEntity)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -625,16 +744,17 @@ type mockDataSourceRepository_GetTablesInDatabase_Call struct {
 }
 
 // GetTablesInDatabase is a helper method to define mock.On call
+//   - ctx context.Context
 //   - databaseName string
 //   - schemaName string
 //   - handleEntity EntityHandler
-func (_e *mockDataSourceRepository_Expecter) GetTablesInDatabase(databaseName interface{}, schemaName interface{}, handleEntity interface{}) *mockDataSourceRepository_GetTablesInDatabase_Call {
-	return &mockDataSourceRepository_GetTablesInDatabase_Call{Call: _e.mock.On("GetTablesInDatabase", databaseName, schemaName, handleEntity)}
+func (_e *mockDataSourceRepository_Expecter) GetTablesInDatabase(ctx interface{}, databaseName interface{}, schemaName interface{}, handleEntity interface{}) *mockDataSourceRepository_GetTablesInDatabase_Call {
+	return &mockDataSourceRepository_GetTablesInDatabase_Call{Call: _e.mock.On("GetTablesInDatabase", ctx, databaseName, schemaName, handleEntity)}
 }
 
-func (_c *mockDataSourceRepository_GetTablesInDatabase_Call) Run(run func(databaseName string, schemaName string, handleEntity EntityHandler)) *mockDataSourceRepository_GetTablesInDatabase_Call {
+func (_c *mockDataSourceRepository_GetTablesInDatabase_Call) Run(run func(ctx context.Context, databaseName string, schemaName string, handleEntity EntityHandler)) *mockDataSourceRepository_GetTablesInDatabase_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(string), args[2].(EntityHandler))
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(EntityHandler))
 	})
 	return _c
 }
@@ -644,14 +764,14 @@ func (_c *mockDataSourceRepository_GetTablesInDatabase_Call) Return(_a0 error) *
 	return _c
 }
 
-func (_c *mockDataSourceRepository_GetTablesInDatabase_Call) RunAndReturn(run func(string, string, EntityHandler) error) *mockDataSourceRepository_GetTablesInDatabase_Call {
+func (_c *mockDataSourceRepository_GetTablesInDatabase_Call) RunAndReturn(run func(context.Context, string, string, EntityHandler) error) *mockDataSourceRepository_GetTablesInDatabase_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetTagsByDomain provides a mock function with given fields: domain
-func (_m *mockDataSourceRepository) GetTagsByDomain(domain string) (map[string][]*tag.Tag, error) {
-	ret := _m.Called(domain)
+// GetTagsByDomain provides a mock function with given fields: ctx, domain
+func (_m *mockDataSourceRepository) GetTagsByDomain(ctx context.Context, domain string) (map[string][]*tag.Tag, error) {
+	ret := _m.Called(ctx, domain)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetTagsByDomain")
@@ -659,19 +779,19 @@ func (_m *mockDataSourceRepository) GetTagsByDomain(domain string) (map[string][
 
 	var r0 map[string][]*tag.Tag
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (map[string][]*tag.Tag, error)); ok {
-		return rf(domain)
+	if rf, ok := ret.Get(0).(func(context.Context, string) (map[string][]*tag.Tag, error)); ok {
+		return rf(ctx, domain)
 	}
-	if rf, ok := ret.Get(0).(func(string) map[string][]*tag.Tag); ok {
-		r0 = rf(domain)
+	if rf, ok := ret.Get(0).(func(context.Context, string) map[string][]*tag.Tag); ok {
+		r0 = rf(ctx, domain)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(map[string][]*tag.Tag)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(domain)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, domain)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -685,14 +805,15 @@ type mockDataSourceRepository_GetTagsByDomain_Call struct {
 }
 
 // GetTagsByDomain is a helper method to define mock.On call
+//   - ctx context.Context
 //   - domain string
-func (_e *mockDataSourceRepository_Expecter) GetTagsByDomain(domain interface{}) *mockDataSourceRepository_GetTagsByDomain_Call {
-	return &mockDataSourceRepository_GetTagsByDomain_Call{Call: _e.mock.On("GetTagsByDomain", domain)}
+func (_e *mockDataSourceRepository_Expecter) GetTagsByDomain(ctx interface{}, domain interface{}) *mockDataSourceRepository_GetTagsByDomain_Call {
+	return &mockDataSourceRepository_GetTagsByDomain_Call{Call: _e.mock.On("GetTagsByDomain", ctx, domain)}
 }
 
-func (_c *mockDataSourceRepository_GetTagsByDomain_Call) Run(run func(domain string)) *mockDataSourceRepository_GetTagsByDomain_Call {
+func (_c *mockDataSourceRepository_GetTagsByDomain_Call) Run(run func(ctx context.Context, domain string)) *mockDataSourceRepository_GetTagsByDomain_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(context.Context), args[1].(string))
 	})
 	return _c
 }
@@ -702,14 +823,14 @@ func (_c *mockDataSourceRepository_GetTagsByDomain_Call) Return(_a0 map[string][
 	return _c
 }
 
-func (_c *mockDataSourceRepository_GetTagsByDomain_Call) RunAndReturn(run func(string) (map[string][]*tag.Tag, error)) *mockDataSourceRepository_GetTagsByDomain_Call {
+func (_c *mockDataSourceRepository_GetTagsByDomain_Call) RunAndReturn(run func(context.Context, string) (map[string][]*tag.Tag, error)) *mockDataSourceRepository_GetTagsByDomain_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetTagsLinkedToDatabaseName provides a mock function with given fields: databaseName
-func (_m *mockDataSourceRepository) GetTagsLinkedToDatabaseName(databaseName string) (map[string][]*tag.Tag, error) {
-	ret := _m.Called(databaseName)
+// GetTagsLinkedToDatabaseName provides a mock function with given fields: ctx, databaseName
+func (_m *mockDataSourceRepository) GetTagsLinkedToDatabaseName(ctx context.Context, databaseName string) (map[string][]*tag.Tag, error) {
+	ret := _m.Called(ctx, databaseName)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetTagsLinkedToDatabaseName")
@@ -717,19 +838,19 @@ func (_m *mockDataSourceRepository) GetTagsLinkedToDatabaseName(databaseName str
 
 	var r0 map[string][]*tag.Tag
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (map[string][]*tag.Tag, error)); ok {
-		return rf(databaseName)
+	if rf, ok := ret.Get(0).(func(context.Context, string) (map[string][]*tag.Tag, error)); ok {
+		return rf(ctx, databaseName)
 	}
-	if rf, ok := ret.Get(0).(func(string) map[string][]*tag.Tag); ok {
-		r0 = rf(databaseName)
+	if rf, ok := ret.Get(0).(func(context.Context, string) map[string][]*tag.Tag); ok {
+		r0 = rf(ctx, databaseName)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(map[string][]*tag.Tag)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(databaseName)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, databaseName)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -743,14 +864,15 @@ type mockDataSourceRepository_GetTagsLinkedToDatabaseName_Call struct {
 }
 
 // GetTagsLinkedToDatabaseName is a helper method to define mock.On call
+//   - ctx context.Context
 //   - databaseName string
-func (_e *mockDataSourceRepository_Expecter) GetTagsLinkedToDatabaseName(databaseName interface{}) *mockDataSourceRepository_GetTagsLinkedToDatabaseName_Call {
-	return &mockDataSourceRepository_GetTagsLinkedToDatabaseName_Call{Call: _e.mock.On("GetTagsLinkedToDatabaseName", databaseName)}
+func (_e *mockDataSourceRepository_Expecter) GetTagsLinkedToDatabaseName(ctx interface{}, databaseName interface{}) *mockDataSourceRepository_GetTagsLinkedToDatabaseName_Call {
+	return &mockDataSourceRepository_GetTagsLinkedToDatabaseName_Call{Call: _e.mock.On("GetTagsLinkedToDatabaseName", ctx, databaseName)}
 }
 
-func (_c *mockDataSourceRepository_GetTagsLinkedToDatabaseName_Call) Run(run func(databaseName string)) *mockDataSourceRepository_GetTagsLinkedToDatabaseName_Call {
+func (_c *mockDataSourceRepository_GetTagsLinkedToDatabaseName_Call) Run(run func(ctx context.Context, databaseName string)) *mockDataSourceRepository_GetTagsLinkedToDatabaseName_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(context.Context), args[1].(string))
 	})
 	return _c
 }
@@ -760,14 +882,14 @@ func (_c *mockDataSourceRepository_GetTagsLinkedToDatabaseName_Call) Return(_a0
 	return _c
 }
 
-func (_c *mockDataSourceRepository_GetTagsLinkedToDatabaseName_Call) RunAndReturn(run func(string) (map[string][]*tag.Tag, error)) *mockDataSourceRepository_GetTagsLinkedToDatabaseName_Call {
+func (_c *mockDataSourceRepository_GetTagsLinkedToDatabaseName_Call) RunAndReturn(run func(context.Context, string) (map[string][]*tag.Tag, error)) *mockDataSourceRepository_GetTagsLinkedToDatabaseName_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetWarehouses provides a mock function with no fields
-func (_m *mockDataSourceRepository) GetWarehouses() ([]DbEntity, error) {
-	ret := _m.Called()
+// GetWarehouses provides a mock function with given fields: ctx
+func (_m *mockDataSourceRepository) GetWarehouses(ctx context.Context) ([]DbEntity, error) {
+	ret := _m.Called(ctx)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetWarehouses")
@@ -775,19 +897,19 @@ func (_m *mockDataSourceRepository) GetWarehouses() ([]DbEntity, error) {
 
 	var r0 []DbEntity
 	var r1 error
-	if rf, ok := ret.Get(0).(func() ([]DbEntity, error)); ok {
-		return rf()
+	if rf, ok := ret.Get(0).(func(context.Context) ([]DbEntity, error)); ok {
+		return rf(ctx)
 	}
-	if rf, ok := ret.Get(0).(func() []DbEntity); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(context.Context) []DbEntity); ok {
+		r0 = rf(ctx)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]DbEntity)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -801,13 +923,14 @@ type mockDataSourceRepository_GetWarehouses_Call struct {
 }
 
 // GetWarehouses is a helper method to define mock.On call
-func (_e *mockDataSourceRepository_Expecter) GetWarehouses() *mockDataSourceRepository_GetWarehouses_Call {
-	return &mockDataSourceRepository_GetWarehouses_Call{Call: _e.mock.On("GetWarehouses")}
+//   - ctx context.Context
+func (_e *mockDataSourceRepository_Expecter) GetWarehouses(ctx interface{}) *mockDataSourceRepository_GetWarehouses_Call {
+	return &mockDataSourceRepository_GetWarehouses_Call{Call: _e.mock.On("GetWarehouses", ctx)}
 }
 
-func (_c *mockDataSourceRepository_GetWarehouses_Call) Run(run func()) *mockDataSourceRepository_GetWarehouses_Call {
+func (_c *mockDataSourceRepository_GetWarehouses_Call) Run(run func(ctx context.Context)) *mockDataSourceRepository_GetWarehouses_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		run(args[0].(context.Context))
 	})
 	return _c
 }
@@ -817,7 +940,7 @@ func (_c *mockDataSourceRepository_GetWarehouses_Call) Return(_a0 []DbEntity, _a
 	return _c
 }
 
-func (_c *mockDataSourceRepository_GetWarehouses_Call) RunAndReturn(run func() ([]DbEntity, error)) *mockDataSourceRepository_GetWarehouses_Call {
+func (_c *mockDataSourceRepository_GetWarehouses_Call) RunAndReturn(run func(context.Context) ([]DbEntity, error)) *mockDataSourceRepository_GetWarehouses_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -867,6 +990,51 @@ func (_c *mockDataSourceRepository_TotalQueryTime_Call) RunAndReturn(run func()
 	return _c
 }
 
+// TotalRetries provides a mock function with no fields
+func (_m *mockDataSourceRepository) TotalRetries() int {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for TotalRetries")
+	}
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func() int); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	return r0
+}
+
+// mockDataSourceRepository_TotalRetries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TotalRetries'
+type mockDataSourceRepository_TotalRetries_Call struct {
+	*mock.Call
+}
+
+// TotalRetries is a helper method to define mock.On call
+func (_e *mockDataSourceRepository_Expecter) TotalRetries() *mockDataSourceRepository_TotalRetries_Call {
+	return &mockDataSourceRepository_TotalRetries_Call{Call: _e.mock.On("TotalRetries")}
+}
+
+func (_c *mockDataSourceRepository_TotalRetries_Call) Run(run func()) *mockDataSourceRepository_TotalRetries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *mockDataSourceRepository_TotalRetries_Call) Return(_a0 int) *mockDataSourceRepository_TotalRetries_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *mockDataSourceRepository_TotalRetries_Call) RunAndReturn(run func() int) *mockDataSourceRepository_TotalRetries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // newMockDataSourceRepository creates a new instance of mockDataSourceRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func newMockDataSourceRepository(t interface {
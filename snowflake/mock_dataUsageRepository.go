@@ -165,6 +165,51 @@ func (_c *mockDataUsageRepository_TotalQueryTime_Call) RunAndReturn(run func() t
 	return _c
 }
 
+// TotalRetries provides a mock function with no fields
+func (_m *mockDataUsageRepository) TotalRetries() int {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for TotalRetries")
+	}
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func() int); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	return r0
+}
+
+// mockDataUsageRepository_TotalRetries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TotalRetries'
+type mockDataUsageRepository_TotalRetries_Call struct {
+	*mock.Call
+}
+
+// TotalRetries is a helper method to define mock.On call
+func (_e *mockDataUsageRepository_Expecter) TotalRetries() *mockDataUsageRepository_TotalRetries_Call {
+	return &mockDataUsageRepository_TotalRetries_Call{Call: _e.mock.On("TotalRetries")}
+}
+
+func (_c *mockDataUsageRepository_TotalRetries_Call) Run(run func()) *mockDataUsageRepository_TotalRetries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *mockDataUsageRepository_TotalRetries_Call) Return(_a0 int) *mockDataUsageRepository_TotalRetries_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *mockDataUsageRepository_TotalRetries_Call) RunAndReturn(run func() int) *mockDataUsageRepository_TotalRetries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // newMockDataUsageRepository creates a new instance of mockDataUsageRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func newMockDataUsageRepository(t interface {
@@ -3,6 +3,7 @@
 package snowflake
 
 import (
+	context "context"
 	time "time"
 
 	tag "github.com/raito-io/cli/base/tag"
@@ -67,9 +68,9 @@ func (_c *mockIdentityStoreRepository_Close_Call) RunAndReturn(run func() error)
 	return _c
 }
 
-// GetTagsByDomain provides a mock function with given fields: domain
-func (_m *mockIdentityStoreRepository) GetTagsByDomain(domain string) (map[string][]*tag.Tag, error) {
-	ret := _m.Called(domain)
+// GetTagsByDomain provides a mock function with given fields: ctx, domain
+func (_m *mockIdentityStoreRepository) GetTagsByDomain(ctx context.Context, domain string) (map[string][]*tag.Tag, error) {
+	ret := _m.Called(ctx, domain)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetTagsByDomain")
@@ -77,19 +78,19 @@ func (_m *mockIdentityStoreRepository) GetTagsByDomain(domain string) (map[strin
 
 	var r0 map[string][]*tag.Tag
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (map[string][]*tag.Tag, error)); ok {
-		return rf(domain)
+	if rf, ok := ret.Get(0).(func(context.Context, string) (map[string][]*tag.Tag, error)); ok {
+		return rf(ctx, domain)
 	}
-	if rf, ok := ret.Get(0).(func(string) map[string][]*tag.Tag); ok {
-		r0 = rf(domain)
+	if rf, ok := ret.Get(0).(func(context.Context, string) map[string][]*tag.Tag); ok {
+		r0 = rf(ctx, domain)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(map[string][]*tag.Tag)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(domain)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, domain)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -103,14 +104,15 @@ type mockIdentityStoreRepository_GetTagsByDomain_Call struct {
 }
 
 // GetTagsByDomain is a helper method to define mock.On call
+//   - ctx context.Context
 //   - domain string
-func (_e *mockIdentityStoreRepository_Expecter) GetTagsByDomain(domain interface{}) *mockIdentityStoreRepository_GetTagsByDomain_Call {
-	return &mockIdentityStoreRepository_GetTagsByDomain_Call{Call: _e.mock.On("GetTagsByDomain", domain)}
+func (_e *mockIdentityStoreRepository_Expecter) GetTagsByDomain(ctx interface{}, domain interface{}) *mockIdentityStoreRepository_GetTagsByDomain_Call {
+	return &mockIdentityStoreRepository_GetTagsByDomain_Call{Call: _e.mock.On("GetTagsByDomain", ctx, domain)}
 }
 
-func (_c *mockIdentityStoreRepository_GetTagsByDomain_Call) Run(run func(domain string)) *mockIdentityStoreRepository_GetTagsByDomain_Call {
+func (_c *mockIdentityStoreRepository_GetTagsByDomain_Call) Run(run func(ctx context.Context, domain string)) *mockIdentityStoreRepository_GetTagsByDomain_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(context.Context), args[1].(string))
 	})
 	return _c
 }
@@ -120,14 +122,14 @@ func (_c *mockIdentityStoreRepository_GetTagsByDomain_Call) Return(_a0 map[strin
 	return _c
 }
 
-func (_c *mockIdentityStoreRepository_GetTagsByDomain_Call) RunAndReturn(run func(string) (map[string][]*tag.Tag, error)) *mockIdentityStoreRepository_GetTagsByDomain_Call {
+func (_c *mockIdentityStoreRepository_GetTagsByDomain_Call) RunAndReturn(run func(context.Context, string) (map[string][]*tag.Tag, error)) *mockIdentityStoreRepository_GetTagsByDomain_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetUsers provides a mock function with no fields
-func (_m *mockIdentityStoreRepository) GetUsers() ([]UserEntity, error) {
-	ret := _m.Called()
+// GetUsers provides a mock function with given fields: ctx
+func (_m *mockIdentityStoreRepository) GetUsers(ctx context.Context) ([]UserEntity, error) {
+	ret := _m.Called(ctx)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetUsers")
@@ -135,19 +137,19 @@ func (_m *mockIdentityStoreRepository) GetUsers() ([]UserEntity, error) {
 
 	var r0 []UserEntity
 	var r1 error
-	if rf, ok := ret.Get(0).(func() ([]UserEntity, error)); ok {
-		return rf()
+	if rf, ok := ret.Get(0).(func(context.Context) ([]UserEntity, error)); ok {
+		return rf(ctx)
 	}
-	if rf, ok := ret.Get(0).(func() []UserEntity); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(context.Context) []UserEntity); ok {
+		r0 = rf(ctx)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]UserEntity)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -161,13 +163,14 @@ type mockIdentityStoreRepository_GetUsers_Call struct {
 }
 
 // GetUsers is a helper method to define mock.On call
-func (_e *mockIdentityStoreRepository_Expecter) GetUsers() *mockIdentityStoreRepository_GetUsers_Call {
-	return &mockIdentityStoreRepository_GetUsers_Call{Call: _e.mock.On("GetUsers")}
+//   - ctx context.Context
+func (_e *mockIdentityStoreRepository_Expecter) GetUsers(ctx interface{}) *mockIdentityStoreRepository_GetUsers_Call {
+	return &mockIdentityStoreRepository_GetUsers_Call{Call: _e.mock.On("GetUsers", ctx)}
 }
 
-func (_c *mockIdentityStoreRepository_GetUsers_Call) Run(run func()) *mockIdentityStoreRepository_GetUsers_Call {
+func (_c *mockIdentityStoreRepository_GetUsers_Call) Run(run func(ctx context.Context)) *mockIdentityStoreRepository_GetUsers_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		run(args[0].(context.Context))
 	})
 	return _c
 }
@@ -177,7 +180,7 @@ func (_c *mockIdentityStoreRepository_GetUsers_Call) Return(_a0 []UserEntity, _a
 	return _c
 }
 
-func (_c *mockIdentityStoreRepository_GetUsers_Call) RunAndReturn(run func() ([]UserEntity, error)) *mockIdentityStoreRepository_GetUsers_Call {
+func (_c *mockIdentityStoreRepository_GetUsers_Call) RunAndReturn(run func(context.Context) ([]UserEntity, error)) *mockIdentityStoreRepository_GetUsers_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -227,6 +230,51 @@ func (_c *mockIdentityStoreRepository_TotalQueryTime_Call) RunAndReturn(run func
 	return _c
 }
 
+// TotalRetries provides a mock function with no fields
+func (_m *mockIdentityStoreRepository) TotalRetries() int {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for TotalRetries")
+	}
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func() int); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	return r0
+}
+
+// mockIdentityStoreRepository_TotalRetries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TotalRetries'
+type mockIdentityStoreRepository_TotalRetries_Call struct {
+	*mock.Call
+}
+
+// TotalRetries is a helper method to define mock.On call
+func (_e *mockIdentityStoreRepository_Expecter) TotalRetries() *mockIdentityStoreRepository_TotalRetries_Call {
+	return &mockIdentityStoreRepository_TotalRetries_Call{Call: _e.mock.On("TotalRetries")}
+}
+
+func (_c *mockIdentityStoreRepository_TotalRetries_Call) Run(run func()) *mockIdentityStoreRepository_TotalRetries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *mockIdentityStoreRepository_TotalRetries_Call) Return(_a0 int) *mockIdentityStoreRepository_TotalRetries_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *mockIdentityStoreRepository_TotalRetries_Call) RunAndReturn(run func() int) *mockIdentityStoreRepository_TotalRetries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // newMockIdentityStoreRepository creates a new instance of mockIdentityStoreRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func newMockIdentityStoreRepository(t interface {
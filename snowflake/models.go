@@ -8,6 +8,8 @@ import (
 
 	"github.com/raito-io/cli/base/tag"
 	"github.com/raito-io/golang-set/set"
+
+	"github.com/raito-io/cli-plugin-snowflake/common"
 )
 
 // Implementation of Scanner interface for NullString
@@ -51,6 +53,14 @@ type FunctionEntity struct {
 	IsBuiltin         string  `db:"is_builtin"`
 }
 
+// SchemaObjectEntity contains the columns shared by the SHOW statements for objects in a schema (e.g. SHOW STAGES)
+type SchemaObjectEntity struct {
+	Database string  `db:"database_name"`
+	Schema   string  `db:"schema_name"`
+	Name     string  `db:"name"`
+	Comment  *string `db:"comment"`
+}
+
 type ProcedureEntity struct {
 	Database          *string `db:"catalog_name"`
 	Schema            *string `db:"schema_name"`
@@ -112,12 +122,17 @@ type TableEntity struct {
 	TableType    string  `db:"TABLE_TYPE"`
 	Comment      *string `db:"COMMENT"`
 	IsIcebergStr string  `db:"IS_ICEBERG"`
+	IsDynamicStr string  `db:"IS_DYNAMIC"`
 }
 
 func (t *TableEntity) IsIceberg() bool {
 	return t.IsIcebergStr == "YES"
 }
 
+func (t *TableEntity) IsDynamic() bool {
+	return t.IsDynamicStr == "YES"
+}
+
 type ColumnEntity struct {
 	Database string  `db:"TABLE_CATALOG"`
 	Schema   string  `db:"TABLE_SCHEMA"`
@@ -218,14 +233,45 @@ type ShareEntity struct {
 }
 
 type GrantOfRole struct {
-	GrantedTo   string `db:"granted_to"`
-	GranteeName string `db:"grantee_name"`
+	GrantedTo   string `db:"granted_to" json:"grantedTo"`
+	GranteeName string `db:"grantee_name" json:"granteeName"`
 }
 
 type GrantToRole struct {
-	Privilege string `db:"privilege"`
-	GrantedOn string `db:"granted_on"`
-	Name      string `db:"name"`
+	Privilege string `db:"privilege" json:"privilege"`
+	GrantedOn string `db:"granted_on" json:"grantedOn"`
+	Name      string `db:"name" json:"name"`
+}
+
+// AccountUsageGrantToRole is a row of SNOWFLAKE.ACCOUNT_USAGE.GRANTS_TO_ROLES
+type AccountUsageGrantToRole struct {
+	GranteeName string     `db:"GRANTEE_NAME"`
+	Privilege   string     `db:"PRIVILEGE"`
+	GrantedOn   string     `db:"GRANTED_ON"`
+	Database    NullString `db:"TABLE_CATALOG"`
+	Schema      NullString `db:"TABLE_SCHEMA"`
+	Name        string     `db:"NAME"`
+}
+
+// toGrantToRole converts the row to the format of SHOW GRANTS TO ROLE, which uses underscores in the object types and fully qualified object names
+func (g AccountUsageGrantToRole) toGrantToRole() GrantToRole {
+	grantedOn := strings.ReplaceAll(g.GrantedOn, " ", "_")
+	name := common.FormatQuery("%s", g.Name)
+
+	if g.Schema.Valid && g.Schema.String != "" {
+		name = common.FormatQuery("%s.%s.%s", g.Database.String, g.Schema.String, g.Name)
+	} else if g.Database.Valid && g.Database.String != "" && (grantedOn == "SCHEMA" || grantedOn == GrantTypeDatabaseRole) {
+		name = common.FormatQuery("%s.%s", g.Database.String, g.Name)
+	}
+
+	return GrantToRole{Privilege: g.Privilege, GrantedOn: grantedOn, Name: name}
+}
+
+// AccountUsageGrantOfRole is a role granted to a user (SNOWFLAKE.ACCOUNT_USAGE.GRANTS_TO_USERS) or to another role (SNOWFLAKE.ACCOUNT_USAGE.GRANTS_TO_ROLES)
+type AccountUsageGrantOfRole struct {
+	Role        string `db:"ROLE"`
+	GrantedTo   string `db:"GRANTED_TO"`
+	GranteeName string `db:"GRANTEE_NAME"`
 }
 
 type Grant struct {
@@ -258,8 +304,10 @@ type PolicyEntity struct {
 }
 
 type DescribePolicyEntity struct {
-	Name string `db:"name"`
-	Body string `db:"body"`
+	Name       string `db:"name"`
+	Signature  string `db:"signature"`
+	ReturnType string `db:"return_type"`
+	Body       string `db:"body"`
 }
 
 type PolicyReferenceEntity struct {
@@ -279,6 +327,17 @@ type PolicyReferenceEntity struct {
 	POLICY_STATUS        string     `db:"POLICY_STATUS"`
 }
 
+func (p *PolicyReferenceEntity) IsDynamicTable() bool {
+	return isDynamicTableDomain(p.REF_ENTITY_DOMAIN)
+}
+
+// isDynamicTableDomain returns true if the REF_ENTITY_DOMAIN of a policy reference is a dynamic table
+func isDynamicTableDomain(domain string) bool {
+	domain = strings.ToUpper(domain)
+
+	return domain == "DYNAMIC TABLE" || domain == "DYNAMIC_TABLE"
+}
+
 type GrantSet struct {
 	grants map[string]set.Set[Grant]
 }
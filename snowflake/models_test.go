@@ -257,3 +257,55 @@ func TestGrantSet_Iterator(t *testing.T) {
 		})
 	}
 }
+
+func TestAccountUsageGrantToRole_toGrantToRole(t *testing.T) {
+	valid := func(s string) NullString { return NullString{String: s, Valid: true} }
+
+	tests := []struct {
+		name  string
+		grant AccountUsageGrantToRole
+		want  GrantToRole
+	}{
+		{
+			name:  "account",
+			grant: AccountUsageGrantToRole{Privilege: "CREATE DATABASE", GrantedOn: "ACCOUNT", Name: "XY12345"},
+			want:  GrantToRole{Privilege: "CREATE DATABASE", GrantedOn: "ACCOUNT", Name: "XY12345"},
+		},
+		{
+			name:  "database",
+			grant: AccountUsageGrantToRole{Privilege: "USAGE", GrantedOn: "DATABASE", Database: valid("SALES_DB"), Name: "SALES_DB"},
+			want:  GrantToRole{Privilege: "USAGE", GrantedOn: "DATABASE", Name: "SALES_DB"},
+		},
+		{
+			name:  "schema",
+			grant: AccountUsageGrantToRole{Privilege: "USAGE", GrantedOn: "SCHEMA", Database: valid("SALES_DB"), Name: "PUBLIC"},
+			want:  GrantToRole{Privilege: "USAGE", GrantedOn: "SCHEMA", Name: "SALES_DB.PUBLIC"},
+		},
+		{
+			name:  "table with special characters",
+			grant: AccountUsageGrantToRole{Privilege: "SELECT", GrantedOn: "TABLE", Database: valid("SALES_DB"), Schema: valid("PUBLIC"), Name: "Order Lines"},
+			want:  GrantToRole{Privilege: "SELECT", GrantedOn: "TABLE", Name: `SALES_DB.PUBLIC."Order Lines"`},
+		},
+		{
+			name:  "materialized view",
+			grant: AccountUsageGrantToRole{Privilege: "SELECT", GrantedOn: "MATERIALIZED VIEW", Database: valid("SALES_DB"), Schema: valid("PUBLIC"), Name: "TOTALS"},
+			want:  GrantToRole{Privilege: "SELECT", GrantedOn: "MATERIALIZED_VIEW", Name: "SALES_DB.PUBLIC.TOTALS"},
+		},
+		{
+			name:  "database role",
+			grant: AccountUsageGrantToRole{Privilege: "USAGE", GrantedOn: "DATABASE_ROLE", Database: valid("SALES_DB"), Name: "READER"},
+			want:  GrantToRole{Privilege: "USAGE", GrantedOn: "DATABASE_ROLE", Name: "SALES_DB.READER"},
+		},
+		{
+			name:  "warehouse",
+			grant: AccountUsageGrantToRole{Privilege: "USAGE", GrantedOn: "WAREHOUSE", Name: "COMPUTE_WH"},
+			want:  GrantToRole{Privilege: "USAGE", GrantedOn: "WAREHOUSE", Name: "COMPUTE_WH"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.grant.toGrantToRole())
+		})
+	}
+}
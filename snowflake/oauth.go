@@ -0,0 +1,217 @@
+package snowflake
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	sf "github.com/snowflakedb/gosnowflake"
+)
+
+const (
+	oauthTokenRefreshMargin = time.Minute
+	oauthHttpTimeout        = 30 * time.Second
+)
+
+type oauthToken struct {
+	AccessToken string
+	ExpiresAt   time.Time // Zero when the expiry is unknown
+}
+
+// oauthTokenFetcher retrieves a fresh OAuth access token.
+type oauthTokenFetcher func(ctx context.Context) (oauthToken, error)
+
+// oauthTokenProvider caches an OAuth access token and refreshes it shortly before it expires.
+// Tokens without a known expiry (read from a file or environment variable) are fetched again on every call,
+// so an externally rotated token is picked up the next time a connection is opened.
+type oauthTokenProvider struct {
+	fetch oauthTokenFetcher
+	now   func() time.Time
+
+	mutex   sync.Mutex
+	current *oauthToken
+}
+
+func newOAuthTokenProvider(fetch oauthTokenFetcher) *oauthTokenProvider {
+	return &oauthTokenProvider{
+		fetch: fetch,
+		now:   time.Now,
+	}
+}
+
+// newOAuthTokenProviderFromParams returns a token provider if OAuth authentication is configured. If not, nil is returned.
+func newOAuthTokenProviderFromParams(params map[string]string) (*oauthTokenProvider, error) {
+	tokenFile := params[SfOAuthTokenFile]
+	tokenEnv := params[SfOAuthTokenEnv]
+	tokenEndpoint := params[SfOAuthTokenEndpoint]
+
+	configured := 0
+
+	for _, v := range []string{tokenFile, tokenEnv, tokenEndpoint} {
+		if v != "" {
+			configured++
+		}
+	}
+
+	if configured == 0 {
+		return nil, nil
+	} else if configured > 1 {
+		return nil, fmt.Errorf("only one of the parameters %q, %q or %q can be specified", SfOAuthTokenFile, SfOAuthTokenEnv, SfOAuthTokenEndpoint)
+	}
+
+	switch {
+	case tokenFile != "":
+		return newOAuthTokenProvider(oauthTokenFromFile(tokenFile)), nil
+	case tokenEnv != "":
+		return newOAuthTokenProvider(oauthTokenFromEnv(tokenEnv)), nil
+	default:
+		clientId := params[SfOAuthClientId]
+		if clientId == "" {
+			return nil, fmt.Errorf("parameter %q is required when %q is specified", SfOAuthClientId, SfOAuthTokenEndpoint)
+		}
+
+		clientSecret := params[SfOAuthClientSecret]
+		if clientSecret == "" {
+			return nil, fmt.Errorf("parameter %q is required when %q is specified", SfOAuthClientSecret, SfOAuthTokenEndpoint)
+		}
+
+		if _, err := url.ParseRequestURI(tokenEndpoint); err != nil {
+			return nil, fmt.Errorf("invalid OAuth token endpoint %q: %w", tokenEndpoint, err)
+		}
+
+		client := &http.Client{Timeout: oauthHttpTimeout}
+
+		return newOAuthTokenProvider(oauthTokenFromClientCredentials(client, tokenEndpoint, clientId, clientSecret, params[SfOAuthScope])), nil
+	}
+}
+
+// Token returns a valid access token, fetching a new one if the cached token is (almost) expired.
+func (p *oauthTokenProvider) Token(ctx context.Context) (string, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.current != nil && !p.current.ExpiresAt.IsZero() && p.now().Add(oauthTokenRefreshMargin).Before(p.current.ExpiresAt) {
+		return p.current.AccessToken, nil
+	}
+
+	token, err := p.fetch(ctx)
+	if err != nil {
+		return "", fmt.Errorf("fetch OAuth access token: %w", err)
+	}
+
+	if token.AccessToken == "" {
+		return "", errors.New("fetch OAuth access token: empty access token received")
+	}
+
+	p.current = &token
+
+	return token.AccessToken, nil
+}
+
+func oauthTokenFromFile(file string) oauthTokenFetcher {
+	return func(_ context.Context) (oauthToken, error) {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return oauthToken{}, fmt.Errorf("opening file %q: %w", file, err)
+		}
+
+		return oauthToken{AccessToken: strings.TrimSpace(string(content))}, nil
+	}
+}
+
+func oauthTokenFromEnv(envVar string) oauthTokenFetcher {
+	return func(_ context.Context) (oauthToken, error) {
+		token, found := os.LookupEnv(envVar)
+		if !found {
+			return oauthToken{}, fmt.Errorf("environment variable %q is not set", envVar)
+		}
+
+		return oauthToken{AccessToken: strings.TrimSpace(token)}, nil
+	}
+}
+
+type oauthTokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int64  `json:"expires_in"`
+}
+
+func oauthTokenFromClientCredentials(client *http.Client, endpoint, clientId, clientSecret, scope string) oauthTokenFetcher {
+	return func(ctx context.Context) (oauthToken, error) {
+		form := url.Values{}
+		form.Set("grant_type", "client_credentials")
+
+		if scope != "" {
+			form.Set("scope", scope)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+		if err != nil {
+			return oauthToken{}, fmt.Errorf("create token request: %w", err)
+		}
+
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.Header.Set("Accept", "application/json")
+		req.SetBasicAuth(url.QueryEscape(clientId), url.QueryEscape(clientSecret))
+
+		requestTime := time.Now()
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return oauthToken{}, fmt.Errorf("request token from %q: %w", endpoint, err)
+		}
+
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			return oauthToken{}, fmt.Errorf("request token from %q: unexpected status %s", endpoint, resp.Status)
+		}
+
+		var tokenResponse oauthTokenResponse
+
+		err = json.NewDecoder(resp.Body).Decode(&tokenResponse)
+		if err != nil {
+			return oauthToken{}, fmt.Errorf("decode token response: %w", err)
+		}
+
+		token := oauthToken{AccessToken: tokenResponse.AccessToken}
+
+		if tokenResponse.ExpiresIn > 0 {
+			token.ExpiresAt = requestTime.Add(time.Duration(tokenResponse.ExpiresIn) * time.Second)
+		}
+
+		return token, nil
+	}
+}
+
+// oauthConnector opens new Snowflake connections with a fresh OAuth access token.
+// Open sessions are kept alive by the driver, but connections added to the pool later on (e.g. during long usage syncs)
+// need a token that is still valid at that time.
+type oauthConnector struct {
+	config        sf.Config
+	tokenProvider *oauthTokenProvider
+}
+
+func (c *oauthConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	token, err := c.tokenProvider.Token(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	config := c.config
+	config.Token = token
+
+	return sf.NewConnector(&sf.SnowflakeDriver{}, config).Connect(ctx)
+}
+
+func (c *oauthConnector) Driver() driver.Driver {
+	return &sf.SnowflakeDriver{}
+}
@@ -0,0 +1,110 @@
+package snowflake
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOAuthTokenProvider_FromFile(t *testing.T) {
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(tokenFile, []byte("token-1\n"), 0600))
+
+	provider, err := newOAuthTokenProviderFromParams(map[string]string{SfOAuthTokenFile: tokenFile})
+	require.NoError(t, err)
+	require.NotNil(t, provider)
+
+	token, err := provider.Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "token-1", token)
+
+	// Rotated tokens are picked up as the expiry is unknown
+	require.NoError(t, os.WriteFile(tokenFile, []byte("token-2"), 0600))
+
+	token, err = provider.Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "token-2", token)
+}
+
+func TestOAuthTokenProvider_FromEnv(t *testing.T) {
+	t.Setenv("RAITO_TEST_OAUTH_TOKEN", "env-token")
+
+	provider, err := newOAuthTokenProviderFromParams(map[string]string{SfOAuthTokenEnv: "RAITO_TEST_OAUTH_TOKEN"})
+	require.NoError(t, err)
+
+	token, err := provider.Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "env-token", token)
+
+	_, err = newOAuthTokenProvider(oauthTokenFromEnv("RAITO_TEST_OAUTH_TOKEN_NOT_SET")).Token(context.Background())
+	require.Error(t, err)
+}
+
+func TestOAuthTokenProvider_ClientCredentials(t *testing.T) {
+	calls := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+
+		clientId, clientSecret, ok := r.BasicAuth()
+		assert.True(t, ok)
+		assert.Equal(t, "client", clientId)
+		assert.Equal(t, "secret", clientSecret)
+		assert.NoError(t, r.ParseForm())
+		assert.Equal(t, "client_credentials", r.PostForm.Get("grant_type"))
+		assert.Equal(t, "session:role:RAITO", r.PostForm.Get("scope"))
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"access_token":"access-token","token_type":"Bearer","expires_in":600}`))
+	}))
+	defer server.Close()
+
+	provider, err := newOAuthTokenProviderFromParams(map[string]string{
+		SfOAuthTokenEndpoint: server.URL,
+		SfOAuthClientId:      "client",
+		SfOAuthClientSecret:  "secret",
+		SfOAuthScope:         "session:role:RAITO",
+	})
+	require.NoError(t, err)
+
+	now := time.Now()
+	provider.now = func() time.Time { return now }
+
+	token, err := provider.Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "access-token", token)
+
+	// Cached while valid
+	_, err = provider.Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, calls)
+
+	// Refreshed when about to expire
+	now = now.Add(10 * time.Minute)
+
+	_, err = provider.Token(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, calls)
+}
+
+func TestOAuthTokenProvider_InvalidConfiguration(t *testing.T) {
+	provider, err := newOAuthTokenProviderFromParams(map[string]string{})
+	require.NoError(t, err)
+	assert.Nil(t, provider)
+
+	_, err = newOAuthTokenProviderFromParams(map[string]string{SfOAuthTokenFile: "/tmp/token", SfOAuthTokenEnv: "TOKEN"})
+	require.Error(t, err)
+
+	_, err = newOAuthTokenProviderFromParams(map[string]string{SfOAuthTokenEndpoint: "https://idp.example.com/token"})
+	require.Error(t, err)
+
+	_, err = newOAuthTokenProviderFromParams(map[string]string{SfOAuthTokenEndpoint: "https://idp.example.com/token", SfOAuthClientId: "client"})
+	require.Error(t, err)
+}
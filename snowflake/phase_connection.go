@@ -0,0 +1,88 @@
+package snowflake
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+
+	"github.com/raito-io/cli-plugin-snowflake/common"
+)
+
+// syncPhaseConnectionParameters are the parameters that override sf-role and sf-warehouse for a sync phase
+type syncPhaseConnectionParameters struct {
+	role      string
+	warehouse string
+}
+
+var syncPhaseConnectionOverrides = map[SyncPhase]syncPhaseConnectionParameters{
+	SyncPhaseUsage:            {role: SfUsageRole, warehouse: SfUsageWarehouse},
+	SyncPhaseAccessFromTarget: {role: SfAccessRole, warehouse: SfAccessWarehouse},
+	SyncPhaseAccessToTarget:   {role: SfAccessRole, warehouse: SfAccessWarehouse},
+}
+
+// roleForSyncPhase returns the role to connect with during the sync phase.
+// An explicitly requested role takes precedence. If no phase specific role is configured, an empty string is returned so sf-role is used.
+func roleForSyncPhase(params map[string]string, role string, phase SyncPhase) string {
+	if role != "" {
+		return role
+	}
+
+	if overrides, found := syncPhaseConnectionOverrides[phase]; found {
+		return params[overrides.role]
+	}
+
+	return ""
+}
+
+// warehouseForSyncPhase returns the warehouse configured for the sync phase, or an empty string if the phase uses sf-warehouse
+func warehouseForSyncPhase(params map[string]string, phase SyncPhase) string {
+	if overrides, found := syncPhaseConnectionOverrides[phase]; found {
+		return params[overrides.warehouse]
+	}
+
+	return ""
+}
+
+// useWarehouseConnector switches every new session to the warehouse using USE WAREHOUSE.
+// Snowflake silently ignores a warehouse at login if the role is not allowed to use it, while USE WAREHOUSE fails.
+type useWarehouseConnector struct {
+	connector driver.Connector
+	warehouse string
+}
+
+// withUseWarehouse wraps the connector so all sessions use the warehouse. The connector is returned as is if warehouse is empty.
+func withUseWarehouse(connector driver.Connector, warehouse string) driver.Connector {
+	if warehouse == "" {
+		return connector
+	}
+
+	return &useWarehouseConnector{connector: connector, warehouse: warehouse}
+}
+
+func (c *useWarehouseConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := c.connector.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	execer, ok := conn.(driver.ExecerContext)
+	if !ok {
+		conn.Close()
+
+		return nil, errors.New("snowflake connection does not support executing statements")
+	}
+
+	_, err = execer.ExecContext(ctx, common.FormatQuery("USE WAREHOUSE %s", c.warehouse), nil)
+	if err != nil {
+		conn.Close()
+
+		return nil, fmt.Errorf("use warehouse %q: %w", c.warehouse, err)
+	}
+
+	return conn, nil
+}
+
+func (c *useWarehouseConnector) Driver() driver.Driver {
+	return c.connector.Driver()
+}
@@ -0,0 +1,91 @@
+package snowflake
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoleForSyncPhase(t *testing.T) {
+	params := map[string]string{SfRole: "RAITO", SfUsageRole: "RAITO_USAGE", SfAccessRole: "RAITO_ACCESS"}
+
+	assert.Equal(t, "RAITO_USAGE", roleForSyncPhase(params, "", SyncPhaseUsage))
+	assert.Equal(t, "RAITO_ACCESS", roleForSyncPhase(params, "", SyncPhaseAccessFromTarget))
+	assert.Equal(t, "RAITO_ACCESS", roleForSyncPhase(params, "", SyncPhaseAccessToTarget))
+	assert.Equal(t, "", roleForSyncPhase(params, "", SyncPhaseDataSource))
+	assert.Equal(t, "OTHER", roleForSyncPhase(params, "OTHER", SyncPhaseUsage))
+	assert.Equal(t, "", roleForSyncPhase(map[string]string{SfRole: "RAITO"}, "", SyncPhaseUsage))
+}
+
+func TestWarehouseForSyncPhase(t *testing.T) {
+	params := map[string]string{SfWarehouse: "WH", SfUsageWarehouse: "WH_USAGE", SfAccessWarehouse: "WH_ACCESS"}
+
+	assert.Equal(t, "WH_USAGE", warehouseForSyncPhase(params, SyncPhaseUsage))
+	assert.Equal(t, "WH_ACCESS", warehouseForSyncPhase(params, SyncPhaseAccessToTarget))
+	assert.Equal(t, "", warehouseForSyncPhase(params, SyncPhaseIdentityStore))
+	assert.Equal(t, "", warehouseForSyncPhase(map[string]string{SfWarehouse: "WH"}, SyncPhaseUsage))
+}
+
+type fakeConn struct {
+	driver.Conn
+	statements []string
+	execErr    error
+	closed     bool
+}
+
+func (c *fakeConn) ExecContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Result, error) {
+	c.statements = append(c.statements, query)
+
+	return driver.RowsAffected(0), c.execErr
+}
+
+func (c *fakeConn) Close() error {
+	c.closed = true
+
+	return nil
+}
+
+type fakeConnector struct {
+	conn *fakeConn
+}
+
+func (c *fakeConnector) Connect(context.Context) (driver.Conn, error) {
+	return c.conn, nil
+}
+
+func (c *fakeConnector) Driver() driver.Driver {
+	return nil
+}
+
+func TestWithUseWarehouse(t *testing.T) {
+	t.Run("No warehouse", func(t *testing.T) {
+		connector := &fakeConnector{conn: &fakeConn{}}
+
+		assert.Same(t, connector, withUseWarehouse(connector, ""))
+	})
+
+	t.Run("Switch warehouse", func(t *testing.T) {
+		conn := &fakeConn{}
+
+		result, err := withUseWarehouse(&fakeConnector{conn: conn}, "usage wh").Connect(context.Background())
+		require.NoError(t, err)
+
+		assert.Same(t, conn, result)
+		assert.Equal(t, []string{`USE WAREHOUSE "usage wh"`}, conn.statements)
+		assert.False(t, conn.closed)
+	})
+
+	t.Run("Warehouse not usable", func(t *testing.T) {
+		conn := &fakeConn{execErr: errors.New("Object does not exist, or operation cannot be performed.")}
+
+		_, err := withUseWarehouse(&fakeConnector{conn: conn}, "USAGE_WH").Connect(context.Background())
+		require.Error(t, err)
+
+		assert.Contains(t, err.Error(), `use warehouse "USAGE_WH"`)
+		assert.True(t, conn.closed)
+	})
+}
@@ -0,0 +1,326 @@
+package snowflake
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	importer "github.com/raito-io/cli/base/access_provider/sync_to_target"
+	"github.com/raito-io/cli/base/wrappers"
+)
+
+// readOnlyStatementTypes are executed in dry-run mode, as the current state of Snowflake is needed to calculate the changes
+var readOnlyStatementTypes = map[string]struct{}{"SELECT": {}, "WITH": {}, "SHOW": {}, "DESCRIBE": {}, "DESC": {}, "USE": {}, "LIST": {}}
+
+func isDryRun(params map[string]string) bool {
+	return strings.EqualFold(params[SfDryRun], "true")
+}
+
+func isReadOnlyStatement(statement string) bool {
+	firstWord, _, _ := strings.Cut(strings.TrimSpace(statement), " ")
+	_, readOnly := readOnlyStatementTypes[strings.ToUpper(firstWord)]
+
+	return readOnly
+}
+
+// PlannedAccessProvider identifies the access provider(s) for which statements are planned.
+// Filters on the same table are handled together, so their statements belong to multiple access providers.
+type PlannedAccessProvider struct {
+	Id   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// AccessProviderPlan contains the statements planned for one (group of) access provider(s), in the order they would be executed.
+// Transactions holds the transaction of every statement (0 if it isn't executed in a transaction) and is nil if there are no transactions.
+type AccessProviderPlan struct {
+	AccessProviders []PlannedAccessProvider `json:"accessProviders"`
+	Statements      []string                `json:"statements"`
+	Transactions    []int                   `json:"transactions,omitempty"`
+}
+
+// ExecutionPlan collects the statements that would change Snowflake instead of executing them.
+type ExecutionPlan struct {
+	mutex sync.Mutex
+
+	plans        []*AccessProviderPlan
+	plansByKey   map[string]*AccessProviderPlan
+	transactions int
+}
+
+func NewExecutionPlan() *ExecutionPlan {
+	return &ExecutionPlan{plansByKey: make(map[string]*AccessProviderPlan)}
+}
+
+type accessProvidersContextKey struct{}
+
+// withAccessProviders marks the statements executed with the returned context as changes for the given access providers
+func withAccessProviders(ctx context.Context, aps ...*importer.AccessProvider) context.Context {
+	planned := make([]PlannedAccessProvider, 0, len(aps))
+
+	for _, ap := range aps {
+		if ap != nil {
+			planned = append(planned, PlannedAccessProvider{Id: ap.Id, Name: ap.Name})
+		}
+	}
+
+	return withPlannedAccessProviders(ctx, planned)
+}
+
+func withPlannedAccessProviders(ctx context.Context, aps []PlannedAccessProvider) context.Context {
+	return context.WithValue(ctx, accessProvidersContextKey{}, aps)
+}
+
+func accessProvidersFromContext(ctx context.Context) []PlannedAccessProvider {
+	aps, _ := ctx.Value(accessProvidersContextKey{}).([]PlannedAccessProvider)
+
+	return aps
+}
+
+func (p *ExecutionPlan) add(ctx context.Context, statements ...string) {
+	p.addToTransaction(ctx, 0, statements...)
+}
+
+// newTransaction returns the identifier of a new transaction in the plan
+func (p *ExecutionPlan) newTransaction() int {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.transactions++
+
+	return p.transactions
+}
+
+// addToTransaction adds the statements to the plan as part of the transaction, or as separate statements if transaction is 0
+func (p *ExecutionPlan) addToTransaction(ctx context.Context, transaction int, statements ...string) {
+	aps := accessProvidersFromContext(ctx)
+
+	ids := make([]string, 0, len(aps))
+	for _, ap := range aps {
+		ids = append(ids, ap.Id)
+	}
+
+	key := strings.Join(ids, ",")
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	plan, found := p.plansByKey[key]
+	if !found {
+		plan = &AccessProviderPlan{AccessProviders: aps}
+		p.plansByKey[key] = plan
+		p.plans = append(p.plans, plan)
+	}
+
+	if transaction != 0 && plan.Transactions == nil {
+		plan.Transactions = make([]int, len(plan.Statements))
+	}
+
+	for _, statement := range statements {
+		plan.Statements = append(plan.Statements, strings.TrimSuffix(strings.TrimSpace(statement), ";"))
+
+		if plan.Transactions != nil {
+			plan.Transactions = append(plan.Transactions, transaction)
+		}
+	}
+}
+
+// AccessProviderPlans returns the planned statements grouped per access provider, in the order the access providers were handled
+func (p *ExecutionPlan) AccessProviderPlans() []AccessProviderPlan {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	result := make([]AccessProviderPlan, 0, len(p.plans))
+	for _, plan := range p.plans {
+		result = append(result, AccessProviderPlan{AccessProviders: plan.AccessProviders, Statements: append([]string(nil), plan.Statements...), Transactions: append([]int(nil), plan.Transactions...)})
+	}
+
+	return result
+}
+
+// StatementCount returns the number of statements planned for the access provider
+func (p *ExecutionPlan) StatementCount(accessProviderId string) int {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	count := 0
+
+	for _, plan := range p.plans {
+		for _, ap := range plan.AccessProviders {
+			if ap.Id == accessProviderId {
+				count += len(plan.Statements)
+
+				break
+			}
+		}
+	}
+
+	return count
+}
+
+// WriteSQL writes all planned statements to the file, preceded by a comment identifying the access provider they belong to
+func (p *ExecutionPlan) WriteSQL(path string) error {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("-- Dry run of the Snowflake access sync (run %s) generated at %s\n", runId, time.Now().UTC().Format(time.RFC3339)))
+	sb.WriteString("-- None of these statements were executed.\n")
+
+	for _, plan := range p.AccessProviderPlans() {
+		sb.WriteString("\n")
+
+		if len(plan.AccessProviders) == 0 {
+			sb.WriteString("-- Not linked to an access provider\n")
+		}
+
+		for _, ap := range plan.AccessProviders {
+			sb.WriteString(fmt.Sprintf("-- Access provider %q (id %s)\n", ap.Name, ap.Id))
+		}
+
+		for _, statement := range plan.Statements {
+			sb.WriteString(statement)
+			sb.WriteString(";\n")
+		}
+	}
+
+	return writeFileAtomically(path, []byte(sb.String()))
+}
+
+// planned adds the statements to the execution plan if the repository is in dry-run mode and they would change Snowflake.
+// If true is returned, the statements must not be executed.
+func (repo *SnowflakeRepository) planned(ctx context.Context, statements ...string) bool {
+	return repo.plannedInTransaction(ctx, 0, statements...)
+}
+
+// plannedInTransaction is like planned, but adds the statements to the execution plan as part of the transaction
+func (repo *SnowflakeRepository) plannedInTransaction(ctx context.Context, transaction int, statements ...string) bool {
+	if repo.plan == nil {
+		return false
+	}
+
+	readOnly := true
+
+	for _, statement := range statements {
+		if !isReadOnlyStatement(statement) {
+			readOnly = false
+
+			break
+		}
+	}
+
+	if readOnly {
+		return false
+	}
+
+	Logger.Debug(fmt.Sprintf("Dry run, not executing: %v", statements))
+
+	repo.plan.addToTransaction(ctx, transaction, statements...)
+
+	return true
+}
+
+// ExecutionPlan returns the statements planned instead of executed, or nil if the repository isn't in dry-run mode
+func (repo *SnowflakeRepository) ExecutionPlan() *ExecutionPlan {
+	return repo.plan
+}
+
+// transaction is implemented by auditedTransaction and by plannedTransaction in dry-run mode
+type transaction interface {
+	Exec(query string, args ...any) (sql.Result, error)
+	Commit() error
+	Rollback() error
+}
+
+func (repo *SnowflakeRepository) beginTransaction(ctx context.Context) (transaction, error) {
+	if repo.plan != nil {
+		return &plannedTransaction{ctx: ctx, repo: repo, id: repo.plan.newTransaction()}, nil
+	}
+
+	return repo.beginSnowflakeTransaction(ctx)
+}
+
+// beginSnowflakeTransaction starts a transaction in Snowflake, also if the repository is in dry-run mode
+func (repo *SnowflakeRepository) beginSnowflakeTransaction(ctx context.Context) (*auditedTransaction, error) {
+	tx, err := repo.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &auditedTransaction{ctx: ctx, repo: repo, tx: tx}, nil
+}
+
+// plannedTransaction adds the statements executed in the transaction to the execution plan, so they are applied together later on
+type plannedTransaction struct {
+	ctx  context.Context //nolint:containedctx
+	repo *SnowflakeRepository
+	id   int
+}
+
+func (t *plannedTransaction) Exec(query string, _ ...any) (sql.Result, error) {
+	t.repo.plannedInTransaction(t.ctx, t.id, query)
+
+	return driverRowsAffected(0), nil
+}
+
+func (t *plannedTransaction) Commit() error {
+	return nil
+}
+
+func (t *plannedTransaction) Rollback() error {
+	return nil
+}
+
+// auditedTransaction executes the statements of a Snowflake transaction one by one and records each of them like any other statement,
+// so they are included in the query time, the statement metrics, the audit log and the traces
+type auditedTransaction struct {
+	ctx  context.Context //nolint:containedctx
+	repo *SnowflakeRepository
+	tx   *sql.Tx
+}
+
+func (t *auditedTransaction) Exec(query string, args ...any) (sql.Result, error) {
+	execCtx, span := startStatementSpan(t.ctx, []string{query})
+	execCtx, done := t.repo.cancelOnContextDone(execCtx)
+
+	startQuery := time.Now()
+	result, err := t.tx.ExecContext(execCtx, query, args...)
+	sec := time.Since(startQuery).Round(time.Millisecond)
+	queryId := done()
+	t.repo.recordExecution(execCtx, []string{query}, sec, queryId, err)
+	endStatementSpan(span, queryId, err)
+
+	return result, err
+}
+
+func (t *auditedTransaction) Commit() error {
+	return t.tx.Commit()
+}
+
+func (t *auditedTransaction) Rollback() error {
+	return t.tx.Rollback()
+}
+
+type driverRowsAffected int64
+
+func (r driverRowsAffected) LastInsertId() (int64, error) {
+	return 0, nil
+}
+
+func (r driverRowsAffected) RowsAffected() (int64, error) {
+	return int64(r), nil
+}
+
+// dryRunFeedbackHandler adds a warning to the feedback of every access provider, so it is clear in Raito that nothing was changed in Snowflake
+type dryRunFeedbackHandler struct {
+	wrappers.AccessProviderFeedbackHandler
+
+	plan     *ExecutionPlan
+	planFile string
+}
+
+func (h *dryRunFeedbackHandler) AddAccessProviderFeedback(feedback importer.AccessProviderSyncFeedback) error {
+	feedback.Warnings = append(feedback.Warnings, fmt.Sprintf("Dry run: %d statement(s) planned in %q, nothing was changed in Snowflake", h.plan.StatementCount(feedback.AccessProvider), h.planFile))
+
+	return h.AccessProviderFeedbackHandler.AddAccessProviderFeedback(feedback)
+}
@@ -0,0 +1,131 @@
+package snowflake
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"testing"
+
+	importer "github.com/raito-io/cli/base/access_provider/sync_to_target"
+	"github.com/raito-io/cli/base/wrappers/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsReadOnlyStatement(t *testing.T) {
+	assert.True(t, isReadOnlyStatement(`SHOW GRANTS TO ROLE "R1"`))
+	assert.True(t, isReadOnlyStatement(`  select * from table(result_scan(LAST_QUERY_ID()))`))
+	assert.True(t, isReadOnlyStatement(`USE DATABASE "DB";`))
+	assert.True(t, isReadOnlyStatement(`DESCRIBE MASKING POLICY "DB"."S"."M"`))
+	assert.False(t, isReadOnlyStatement(`GRANT ROLE "R1" TO ROLE "R2"`))
+	assert.False(t, isReadOnlyStatement(`CREATE OR REPLACE MASKING POLICY "DB"."S"."M" AS (val STRING) RETURNS STRING -> val`))
+	assert.False(t, isReadOnlyStatement(`ALTER ROLE "R1" RENAME TO "R2"`))
+}
+
+func TestExecutionPlan(t *testing.T) {
+	plan := NewExecutionPlan()
+	repo := &SnowflakeRepository{plan: plan}
+
+	ap1 := &importer.AccessProvider{Id: "ap1", Name: "Sales"}
+	ap2 := &importer.AccessProvider{Id: "ap2", Name: "Marketing"}
+
+	ctx := context.Background()
+
+	rows, _, err := repo.query(withAccessProviders(ctx, ap1), `CREATE ROLE IF NOT EXISTS "SALES"`)
+	require.NoError(t, err)
+	assert.Nil(t, rows)
+
+	require.NoError(t, repo.execute(withAccessProviders(ctx, ap2), `GRANT ROLE "MARKETING" TO USER "JOHN";`))
+
+	_, err = repo.execContext(withAccessProviders(ctx, ap1), []string{`GRANT ROLE "SALES" TO USER "JANE"`, `GRANT ROLE "SALES" TO USER "JOHN"`})
+	require.NoError(t, err)
+
+	tx, err := repo.beginTransaction(withAccessProviders(ctx, ap1, ap2))
+	require.NoError(t, err)
+
+	_, err = tx.Exec(`ALTER TABLE "DB"."S"."T" ADD ROW ACCESS POLICY "DB"."S"."F" ON ("A")`)
+	require.NoError(t, err)
+	require.NoError(t, tx.Commit())
+
+	require.NoError(t, repo.execute(withAccessProviders(ctx), `DROP ROLE "OLD"`))
+
+	assert.Equal(t, []AccessProviderPlan{
+		{AccessProviders: []PlannedAccessProvider{{Id: "ap1", Name: "Sales"}}, Statements: []string{`CREATE ROLE IF NOT EXISTS "SALES"`, `GRANT ROLE "SALES" TO USER "JANE"`, `GRANT ROLE "SALES" TO USER "JOHN"`}},
+		{AccessProviders: []PlannedAccessProvider{{Id: "ap2", Name: "Marketing"}}, Statements: []string{`GRANT ROLE "MARKETING" TO USER "JOHN"`}},
+		{AccessProviders: []PlannedAccessProvider{{Id: "ap1", Name: "Sales"}, {Id: "ap2", Name: "Marketing"}}, Statements: []string{`ALTER TABLE "DB"."S"."T" ADD ROW ACCESS POLICY "DB"."S"."F" ON ("A")`}, Transactions: []int{1}},
+		{AccessProviders: []PlannedAccessProvider{}, Statements: []string{`DROP ROLE "OLD"`}},
+	}, plan.AccessProviderPlans())
+
+	assert.Equal(t, 4, plan.StatementCount("ap1"))
+	assert.Equal(t, 2, plan.StatementCount("ap2"))
+	assert.Equal(t, 0, plan.StatementCount("ap3"))
+
+	t.Run("SQL file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "plan.sql")
+
+		require.NoError(t, plan.WriteSQL(path))
+
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+
+		assert.Contains(t, string(data), "-- None of these statements were executed.\n")
+		assert.Contains(t, string(data), "\n-- Access provider \"Sales\" (id ap1)\nCREATE ROLE IF NOT EXISTS \"SALES\";\nGRANT ROLE \"SALES\" TO USER \"JANE\";\nGRANT ROLE \"SALES\" TO USER \"JOHN\";\n")
+		assert.Contains(t, string(data), "\n-- Access provider \"Sales\" (id ap1)\n-- Access provider \"Marketing\" (id ap2)\nALTER TABLE")
+		assert.Contains(t, string(data), "\n-- Not linked to an access provider\nDROP ROLE \"OLD\";\n")
+	})
+}
+
+func TestDryRunFeedbackHandler(t *testing.T) {
+	plan := NewExecutionPlan()
+	plan.add(withAccessProviders(context.Background(), &importer.AccessProvider{Id: "ap1", Name: "Sales"}), `CREATE ROLE IF NOT EXISTS "SALES"`)
+
+	feedbackHandler := mocks.NewSimpleAccessProviderFeedbackHandler(t)
+	handler := &dryRunFeedbackHandler{AccessProviderFeedbackHandler: feedbackHandler, plan: plan, planFile: "plan.sql"}
+
+	require.NoError(t, handler.AddAccessProviderFeedback(importer.AccessProviderSyncFeedback{AccessProvider: "ap1", ActualName: "SALES", Errors: []string{"boom"}}))
+
+	assert.Equal(t, []importer.AccessProviderSyncFeedback{{
+		AccessProvider: "ap1",
+		ActualName:     "SALES",
+		Errors:         []string{"boom"},
+		Warnings:       []string{`Dry run: 1 statement(s) planned in "plan.sql", nothing was changed in Snowflake`},
+	}}, feedbackHandler.AccessProviderFeedback)
+}
+
+func TestSnowflakeRepository_ExecutePlannedTransaction(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	plan := NewExecutionPlan()
+
+	repo := &SnowflakeRepository{
+		conn:     sql.OpenDB(newReplayConnector(newFixturePlayer(&FixtureArchive{}), SyncPhaseAccessToTarget)),
+		phase:    SyncPhaseAccessToTarget,
+		plan:     plan,
+		auditLog: &AuditLog{path: path},
+	}
+
+	defer repo.Close()
+
+	statements := []string{`CREATE MASKING POLICY "DB"."S"."M_TEXT" AS (val STRING) RETURNS STRING -> NULL`, `ALTER TABLE "DB"."S"."T" MODIFY COLUMN "C" SET MASKING POLICY "DB"."S"."M_TEXT" FORCE`}
+
+	// The reviewed statements are executed, also if the repository plans the changes
+	require.NoError(t, repo.ExecutePlannedTransaction(context.Background(), statements...))
+	assert.Empty(t, plan.AccessProviderPlans())
+
+	entries := readAuditEntries(t, path)
+	require.Len(t, entries, 2)
+	assert.Equal(t, statements[:1], entries[0].Statements)
+	assert.Equal(t, statements[1:], entries[1].Statements)
+}
+
+func TestSnowflakeRepository_beginSnowflakeTransaction_canceled(t *testing.T) {
+	repo := &SnowflakeRepository{conn: sql.OpenDB(newReplayConnector(newFixturePlayer(&FixtureArchive{}), SyncPhaseAccessToTarget))}
+
+	defer repo.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := repo.beginSnowflakeTransaction(ctx)
+	require.ErrorIs(t, err, context.Canceled)
+}
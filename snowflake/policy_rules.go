@@ -0,0 +1,296 @@
+package snowflake
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/raito-io/cli/base/tag"
+	"gopkg.in/yaml.v3"
+
+	"github.com/raito-io/cli-plugin-snowflake/common"
+)
+
+const (
+	policyActionGrant  = "grant"
+	policyActionRevoke = "revoke"
+)
+
+// writePrivileges are the privileges matched by the WRITE privilege in a policy rule
+var writePrivileges = []string{"INSERT", "UPDATE", "DELETE", "TRUNCATE"}
+
+// PolicyRule is a guardrail for the changes made by the access sync to target.
+// A change violates the rule if it matches all conditions that are set in the rule.
+// A rule that only has roles as condition forbids any change to the matching roles.
+type PolicyRule struct {
+	Name        string `yaml:"name"`
+	Description string `yaml:"description"`
+
+	// Actions is a list of 'grant' and/or 'revoke'. All actions match if not set.
+	Actions []string `yaml:"actions"`
+	// Privileges is a list of Snowflake privileges. WRITE matches INSERT, UPDATE, DELETE and TRUNCATE.
+	Privileges []string `yaml:"privileges"`
+	// ObjectTypes is a list of Raito data object types, e.g. account, database, table or role.
+	ObjectTypes []string `yaml:"objectTypes"`
+	// Roles is a list of regular expressions matching the role of the access provider.
+	Roles []string `yaml:"roles"`
+	// Grantees is a list of regular expressions matching the role that receives the grant.
+	Grantees []string `yaml:"grantees"`
+	// DatabaseTags matches objects in a database with all the given tag values.
+	DatabaseTags map[string]string `yaml:"databaseTags"`
+
+	roles    []*regexp.Regexp
+	grantees []*regexp.Regexp
+}
+
+type PolicyRules struct {
+	Rules []*PolicyRule `yaml:"rules"`
+}
+
+// policyChange is a single change to a role that is checked against the policy rules before it is executed
+type policyChange struct {
+	action     string
+	privilege  string
+	objectType string
+	object     string
+	role       string
+	grantee    string
+}
+
+func (c *policyChange) String() string {
+	on := c.objectType
+	if c.object != "" {
+		on = fmt.Sprintf("%s %s", c.objectType, c.object)
+	}
+
+	switch c.action {
+	case policyActionGrant:
+		return fmt.Sprintf("granting %s on %s to role %q", c.privilege, on, c.grantee)
+	case policyActionRevoke:
+		return fmt.Sprintf("revoking %s on %s from role %q", c.privilege, on, c.grantee)
+	default:
+		return fmt.Sprintf("changing role %q", c.role)
+	}
+}
+
+// PolicyViolationError is returned for a change that violates one of the policy rules
+type PolicyViolationError struct {
+	Rule        string
+	Description string
+	Change      string
+}
+
+func (e *PolicyViolationError) Error() string {
+	if e.Description != "" {
+		return fmt.Sprintf("policy rule %q (%s) does not allow %s", e.Rule, e.Description, e.Change)
+	}
+
+	return fmt.Sprintf("policy rule %q does not allow %s", e.Rule, e.Change)
+}
+
+// loadPolicyRules reads the policy rules from a YAML or JSON file
+func loadPolicyRules(path string) (*PolicyRules, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read policy rules %q: %w", path, err)
+	}
+
+	var rules PolicyRules
+
+	err = yaml.Unmarshal(data, &rules)
+	if err != nil {
+		return nil, fmt.Errorf("parse policy rules %q: %w", path, err)
+	}
+
+	for i, rule := range rules.Rules {
+		err = rule.compile()
+		if err != nil {
+			return nil, fmt.Errorf("invalid policy rule %d in %q: %w", i+1, path, err)
+		}
+	}
+
+	return &rules, nil
+}
+
+func (r *PolicyRule) compile() error {
+	if r.Name == "" {
+		return errors.New("name is required")
+	}
+
+	if len(r.Actions)+len(r.Privileges)+len(r.ObjectTypes)+len(r.Roles)+len(r.Grantees)+len(r.DatabaseTags) == 0 {
+		return fmt.Errorf("rule %q has no conditions", r.Name)
+	}
+
+	for _, action := range r.Actions {
+		if !strings.EqualFold(action, policyActionGrant) && !strings.EqualFold(action, policyActionRevoke) {
+			return fmt.Errorf("rule %q has an invalid action %q (must be %q or %q)", r.Name, action, policyActionGrant, policyActionRevoke)
+		}
+	}
+
+	compilePatterns := func(patterns []string) ([]*regexp.Regexp, error) {
+		result := make([]*regexp.Regexp, 0, len(patterns))
+
+		for _, pattern := range patterns {
+			re, err := regexp.Compile("(?i)^(?:" + pattern + ")$")
+			if err != nil {
+				return nil, fmt.Errorf("rule %q has an invalid pattern %q: %w", r.Name, pattern, err)
+			}
+
+			result = append(result, re)
+		}
+
+		return result, nil
+	}
+
+	var err error
+
+	r.roles, err = compilePatterns(r.Roles)
+	if err != nil {
+		return err
+	}
+
+	r.grantees, err = compilePatterns(r.Grantees)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *PolicyRule) matchesPrivilege(privilege string) bool {
+	for _, p := range r.Privileges {
+		if strings.EqualFold(p, privilege) || (strings.EqualFold(p, "WRITE") && slices.Contains(writePrivileges, strings.ToUpper(privilege))) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func matchesAnyPattern(patterns []*regexp.Regexp, value string) bool {
+	value = cleanDoubleQuotes(value)
+
+	for _, pattern := range patterns {
+		if pattern.MatchString(value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// matches checks all conditions of the rule, except the database tags
+func (r *PolicyRule) matches(change *policyChange) bool {
+	if len(r.Actions) > 0 && !slices.ContainsFunc(r.Actions, func(action string) bool { return strings.EqualFold(action, change.action) }) {
+		return false
+	}
+
+	if len(r.Privileges) > 0 && !r.matchesPrivilege(change.privilege) {
+		return false
+	}
+
+	if len(r.ObjectTypes) > 0 && !slices.ContainsFunc(r.ObjectTypes, func(objectType string) bool { return strings.EqualFold(objectType, change.objectType) }) {
+		return false
+	}
+
+	if len(r.roles) > 0 && !matchesAnyPattern(r.roles, change.role) {
+		return false
+	}
+
+	if len(r.grantees) > 0 && !matchesAnyPattern(r.grantees, change.grantee) {
+		return false
+	}
+
+	return true
+}
+
+func (r *PolicyRule) matchesDatabaseTags(tags []*tag.Tag) bool {
+	for key, value := range r.DatabaseTags {
+		if !slices.ContainsFunc(tags, func(t *tag.Tag) bool { return strings.EqualFold(t.Key, key) && strings.EqualFold(t.Value, value) }) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// checkPolicyRules returns a PolicyViolationError for the first change that violates a policy rule
+func (s *AccessToTargetSyncer) checkPolicyRules(ctx context.Context, changes ...policyChange) error {
+	if s.policyRules == nil {
+		return nil
+	}
+
+	for i := range changes {
+		change := &changes[i]
+
+		for _, rule := range s.policyRules.Rules {
+			if !rule.matches(change) {
+				continue
+			}
+
+			if len(rule.DatabaseTags) > 0 {
+				tags, err := s.databaseTagsOfObject(ctx, change)
+				if err != nil {
+					return err
+				}
+
+				if !rule.matchesDatabaseTags(tags) {
+					continue
+				}
+			}
+
+			return &PolicyViolationError{Rule: rule.Name, Description: rule.Description, Change: change.String()}
+		}
+	}
+
+	return nil
+}
+
+func (s *AccessToTargetSyncer) checkPolicyRulesOnGrants(ctx context.Context, action string, roleName string, grants []Grant) error {
+	changes := make([]policyChange, 0, len(grants))
+	for _, grant := range grants {
+		changes = append(changes, policyChange{action: action, privilege: grant.Permissions, objectType: grant.OnType, object: grant.On, role: roleName, grantee: roleName})
+	}
+
+	return s.checkPolicyRules(ctx, changes...)
+}
+
+func (s *AccessToTargetSyncer) checkPolicyRulesOnRoleGrants(ctx context.Context, action string, roleName string, grantees []string) error {
+	changes := make([]policyChange, 0, len(grantees))
+	for _, grantee := range grantees {
+		changes = append(changes, policyChange{action: action, privilege: USAGE, objectType: "role", object: roleName, role: roleName, grantee: grantee})
+	}
+
+	return s.checkPolicyRules(ctx, changes...)
+}
+
+// databaseTagsOfObject returns the tags of the database the object of the change belongs to
+func (s *AccessToTargetSyncer) databaseTagsOfObject(ctx context.Context, change *policyChange) ([]*tag.Tag, error) {
+	if change.object == "" || strings.EqualFold(change.objectType, "account") || strings.EqualFold(change.objectType, "role") ||
+		strings.EqualFold(change.objectType, "warehouse") || strings.EqualFold(change.objectType, Integration) {
+		return nil, nil
+	}
+
+	if s.databaseTagsCache == nil {
+		databaseTags, err := s.repo.GetTagsByDomain(ctx, "DATABASE")
+		if err != nil {
+			return nil, fmt.Errorf("get database tags for policy rules: %w", err)
+		}
+
+		s.databaseTagsCache = databaseTags
+		if s.databaseTagsCache == nil {
+			s.databaseTagsCache = make(map[string][]*tag.Tag)
+		}
+	}
+
+	database := common.ParseFullName(change.object).Database
+	if database == nil {
+		return nil, nil
+	}
+
+	return s.databaseTagsCache[*database], nil
+}
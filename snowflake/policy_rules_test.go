@@ -0,0 +1,206 @@
+package snowflake
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	importer "github.com/raito-io/cli/base/access_provider/sync_to_target"
+	"github.com/raito-io/cli/base/data_source"
+	"github.com/raito-io/cli/base/tag"
+	"github.com/raito-io/cli/base/util/config"
+	"github.com/raito-io/cli/base/wrappers/mocks"
+	"github.com/raito-io/golang-set/set"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const testPolicyRules = `
+rules:
+  - name: no-ownership
+    description: Ownership is managed by the platform team
+    actions: [grant]
+    privileges: [OWNERSHIP]
+  - name: no-public
+    grantees: [PUBLIC]
+  - name: no-account-privileges
+    privileges: [MANAGE GRANTS]
+    objectTypes: [account]
+  - name: protected-roles
+    roles: ["SYSADMIN", "DBA_.*"]
+  - name: no-write-on-prod
+    actions: [grant]
+    privileges: [WRITE]
+    databaseTags:
+      ENVIRONMENT: PROD
+`
+
+func writePolicyRules(t *testing.T, name, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+
+	return path
+}
+
+func TestLoadPolicyRules(t *testing.T) {
+	rules, err := loadPolicyRules(writePolicyRules(t, "rules.yaml", testPolicyRules))
+	require.NoError(t, err)
+	require.Len(t, rules.Rules, 5)
+	assert.Equal(t, "no-ownership", rules.Rules[0].Name)
+	assert.Equal(t, map[string]string{"ENVIRONMENT": "PROD"}, rules.Rules[4].DatabaseTags)
+
+	rules, err = loadPolicyRules(writePolicyRules(t, "rules.json", `{"rules": [{"name": "no-public", "grantees": ["PUBLIC"]}]}`))
+	require.NoError(t, err)
+	require.Len(t, rules.Rules, 1)
+	assert.Equal(t, []string{"PUBLIC"}, rules.Rules[0].Grantees)
+
+	for name, content := range map[string]string{
+		"no name":         `rules: [{privileges: [OWNERSHIP]}]`,
+		"no conditions":   `rules: [{name: empty}]`,
+		"invalid action":  `rules: [{name: invalid, actions: [drop]}]`,
+		"invalid pattern": `rules: [{name: invalid, roles: ["(DBA"]}]`,
+		"invalid file":    `rules: {`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := loadPolicyRules(writePolicyRules(t, "rules.yaml", content))
+			require.Error(t, err)
+		})
+	}
+
+	_, err = loadPolicyRules(filepath.Join(t.TempDir(), "missing.yaml"))
+	require.Error(t, err)
+}
+
+func TestAccessToTargetSyncer_checkPolicyRules(t *testing.T) {
+	rules, err := loadPolicyRules(writePolicyRules(t, "rules.yaml", testPolicyRules))
+	require.NoError(t, err)
+
+	repoMock := newMockDataAccessRepository(t)
+	repoMock.EXPECT().GetTagsByDomain(mock.Anything, "DATABASE").Return(map[string][]*tag.Tag{
+		"SALES": {{Key: "ENVIRONMENT", Value: "PROD"}},
+	}, nil).Once()
+
+	syncer := createBasicToTargetSyncer(repoMock, nil, &dummyFeedbackHandler{}, &config.ConfigMap{})
+	syncer.policyRules = rules
+
+	tests := []struct {
+		name   string
+		change policyChange
+		want   string
+	}{
+		{
+			name:   "Ownership",
+			change: policyChange{action: policyActionGrant, privilege: "OWNERSHIP", objectType: "table", object: `"DEV"."S"."T"`, role: "R1", grantee: "R1"},
+			want:   `policy rule "no-ownership" (Ownership is managed by the platform team) does not allow granting OWNERSHIP on table "DEV"."S"."T" to role "R1"`,
+		},
+		{
+			name:   "Revoke ownership",
+			change: policyChange{action: policyActionRevoke, privilege: "OWNERSHIP", objectType: "table", object: `"DEV"."S"."T"`, role: "R1", grantee: "R1"},
+		},
+		{
+			name:   "Role granted to public",
+			change: policyChange{action: policyActionGrant, privilege: USAGE, objectType: "role", object: "R1", role: "R1", grantee: "public"},
+			want:   `policy rule "no-public" does not allow granting USAGE on role R1 to role "public"`,
+		},
+		{
+			name:   "Account privilege",
+			change: policyChange{action: policyActionGrant, privilege: "MANAGE GRANTS", objectType: "account", role: "R1", grantee: "R1"},
+			want:   `policy rule "no-account-privileges" does not allow granting MANAGE GRANTS on account to role "R1"`,
+		},
+		{
+			name:   "Protected role",
+			change: policyChange{role: `"DBA_ADMINS"`},
+			want:   `policy rule "protected-roles" does not allow changing role "\"DBA_ADMINS\""`,
+		},
+		{
+			name:   "Role matching part of a pattern",
+			change: policyChange{role: "MY_DBA_ADMINS"},
+		},
+		{
+			name:   "Write on production database",
+			change: policyChange{action: policyActionGrant, privilege: "insert", objectType: "table", object: `"SALES"."S"."T"`, role: "R1", grantee: "R1"},
+			want:   `policy rule "no-write-on-prod" does not allow granting insert on table "SALES"."S"."T" to role "R1"`,
+		},
+		{
+			name:   "Read on production database",
+			change: policyChange{action: policyActionGrant, privilege: "SELECT", objectType: "table", object: `"SALES"."S"."T"`, role: "R1", grantee: "R1"},
+		},
+		{
+			name:   "Write on other database",
+			change: policyChange{action: policyActionGrant, privilege: "UPDATE", objectType: "table", object: `"DEV"."S"."T"`, role: "R1", grantee: "R1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := syncer.checkPolicyRules(context.Background(), tt.change)
+
+			if tt.want == "" {
+				assert.NoError(t, err)
+
+				return
+			}
+
+			var violation *PolicyViolationError
+			require.True(t, errors.As(err, &violation))
+			assert.Equal(t, tt.want, err.Error())
+		})
+	}
+}
+
+func TestAccessSyncer_generateAccessControls_policyRules(t *testing.T) {
+	repoMock := newMockDataAccessRepository(t)
+
+	repoMock.EXPECT().GetTagsByDomain(mock.Anything, "DATABASE").Return(map[string][]*tag.Tag{
+		"PROD_DB": {{Key: "environment", Value: "prod"}},
+	}, nil).Once()
+
+	repoMock.EXPECT().CreateAccountRole(mock.Anything, "RoleName1").Return(nil).Once()
+	repoMock.EXPECT().CommentAccountRoleIfExists(mock.Anything, mock.Anything, "RoleName1").Return(nil).Once()
+	repoMock.EXPECT().GrantAccountRolesToAccountRole(mock.Anything, "RoleName1").Return(nil).Once()
+	repoMock.EXPECT().ExecuteGrantOnAccountRole(mock.Anything, "USAGE", "DATABASE PROD_DB", "RoleName1", false).Return(nil).Once()
+	repoMock.EXPECT().ExecuteGrantOnAccountRole(mock.Anything, "USAGE", "SCHEMA PROD_DB.Schema1", "RoleName1", false).Return(nil).Once()
+	repoMock.EXPECT().ExecuteGrantOnAccountRole(mock.Anything, "SELECT", "TABLE PROD_DB.Schema1.Table1", "RoleName1", false).Return(nil).Once()
+
+	access := map[string]*importer.AccessProvider{
+		"RoleName1": {
+			Id:   "AccessProviderId1",
+			Name: "AccessProvider1",
+			What: []importer.WhatItem{
+				{DataObject: &data_source.DataObjectReference{FullName: "PROD_DB.Schema1.Table1", Type: "table"}, Permissions: []string{"SELECT"}},
+			},
+		},
+		"RoleName2": {
+			Id:   "AccessProviderId2",
+			Name: "AccessProvider2",
+			What: []importer.WhatItem{
+				{DataObject: &data_source.DataObjectReference{FullName: "PROD_DB.Schema1.Table1", Type: "table"}, Permissions: []string{"SELECT", "INSERT"}},
+			},
+		},
+	}
+
+	feedbackHandler := mocks.NewSimpleAccessProviderFeedbackHandler(t)
+
+	syncer := createBasicToTargetSyncer(repoMock, nil, feedbackHandler, &config.ConfigMap{})
+	syncer.policyRules, _ = loadPolicyRules(writePolicyRules(t, "rules.yaml", testPolicyRules))
+
+	// When
+	err := syncer.generateAccessControls(context.Background(), access, set.NewSet[string](), map[string]string{})
+
+	// Then
+	require.NoError(t, err)
+	require.Len(t, feedbackHandler.AccessProviderFeedback, 2)
+
+	for _, feedback := range feedbackHandler.AccessProviderFeedback {
+		if feedback.AccessProvider == "AccessProviderId1" {
+			assert.Empty(t, feedback.Errors)
+		} else {
+			assert.Equal(t, []string{`policy rule "no-write-on-prod" does not allow granting INSERT on table PROD_DB.Schema1.Table1 to role "RoleName2"`}, feedback.Errors)
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package snowflake
+
+import (
+	"encoding/json"
+	"fmt"
+
+	gonanoid "github.com/matoous/go-nanoid/v2"
+)
+
+// SyncPhase identifies the sync for which a Snowflake connection is opened
+type SyncPhase string
+
+const (
+	SyncPhaseDataSource       SyncPhase = "data-source"
+	SyncPhaseIdentityStore    SyncPhase = "identity-store"
+	SyncPhaseAccessFromTarget SyncPhase = "access-from-target"
+	SyncPhaseAccessToTarget   SyncPhase = "access-to-target"
+	SyncPhaseUsage            SyncPhase = "usage"
+)
+
+const queryTagSessionParameter = "QUERY_TAG"
+
+// PluginVersion is the version of the plugin, added to the query tag of every session. It is set by the main package.
+var PluginVersion = "0.0.0"
+
+// runId identifies all queries executed during a single run of the CLI, as the plugin is started once per run.
+var runId = gonanoid.MustGenerate(idAlphabet, 12)
+
+type queryTag struct {
+	Application string    `json:"application"`
+	Phase       SyncPhase `json:"phase,omitempty"`
+	RunId       string    `json:"run_id"`
+	Version     string    `json:"version"`
+}
+
+// queryTagPrefix is the start of every query tag set by the plugin, used to recognise the plugin's own queries in the query history
+var queryTagPrefix = fmt.Sprintf(`{"application":%q`, ConnectionStringIdentifier)
+
+func generateQueryTag(phase SyncPhase) string {
+	tag, err := json.Marshal(queryTag{
+		Application: ConnectionStringIdentifier,
+		Phase:       phase,
+		RunId:       runId,
+		Version:     PluginVersion,
+	})
+	if err != nil {
+		// Cannot happen as the struct only contains strings
+		return ConnectionStringIdentifier
+	}
+
+	return string(tag)
+}
@@ -0,0 +1,27 @@
+package snowflake
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateQueryTag(t *testing.T) {
+	tag := generateQueryTag(SyncPhaseAccessToTarget)
+
+	var parsedTag map[string]string
+	require.NoError(t, json.Unmarshal([]byte(tag), &parsedTag))
+
+	assert.Equal(t, map[string]string{
+		"application": ConnectionStringIdentifier,
+		"phase":       "access-to-target",
+		"run_id":      runId,
+		"version":     PluginVersion,
+	}, parsedTag)
+
+	assert.True(t, strings.HasPrefix(tag, queryTagPrefix))
+	assert.True(t, strings.HasPrefix(generateQueryTag(SyncPhaseUsage), queryTagPrefix))
+}
@@ -50,13 +50,55 @@ type SnowflakeRepository struct {
 	workerPoolSize int
 	queryTimeLock  sync.Mutex
 
+	phase             SyncPhase
+	excludeOwnQueries bool
+
 	accountNamesPerDelimiterMutex sync.Mutex
 	accountNamesPerDelimiter      map[rune]string
 
 	maskFactory *MaskFactory
+	retryPolicy *retryPolicy
+
+	// plan is set in dry-run mode. Statements changing Snowflake are added to it instead of being executed.
+	plan *ExecutionPlan
+
+	auditLog *AuditLog
+}
+
+type SnowflakeRepositoryOptions struct {
+	Phase         SyncPhase
+	Warehouse     string
+	ExecutionPlan *ExecutionPlan
+}
+
+// WithSyncPhase sets the sync phase that is added to the query tag of all queries executed by the repository
+func WithSyncPhase(phase SyncPhase) func(options *SnowflakeRepositoryOptions) {
+	return func(options *SnowflakeRepositoryOptions) {
+		options.Phase = phase
+	}
 }
 
-func NewSnowflakeRepository(params map[string]string, role string) (*SnowflakeRepository, error) {
+// WithWarehouse switches all sessions of the repository to the warehouse if it differs from the warehouse in sf-warehouse
+func WithWarehouse(warehouse string) func(options *SnowflakeRepositoryOptions) {
+	return func(options *SnowflakeRepositoryOptions) {
+		options.Warehouse = warehouse
+	}
+}
+
+// WithExecutionPlan puts the repository in dry-run mode: statements that would change Snowflake are added to the plan instead of being executed
+func WithExecutionPlan(plan *ExecutionPlan) func(options *SnowflakeRepositoryOptions) {
+	return func(options *SnowflakeRepositoryOptions) {
+		options.ExecutionPlan = plan
+	}
+}
+
+func NewSnowflakeRepository(params map[string]string, role string, ops ...func(options *SnowflakeRepositoryOptions)) (*SnowflakeRepository, error) {
+	options := SnowflakeRepositoryOptions{}
+
+	for _, op := range ops {
+		op(&options)
+	}
+
 	if v, f := params[SfDriverDebug]; f && strings.EqualFold(v, "true") {
 		err := sf.GetLogger().SetLogLevel("debug")
 
@@ -76,7 +118,32 @@ func NewSnowflakeRepository(params map[string]string, role string) (*SnowflakeRe
 		}
 	}
 
-	conn, role, err := ConnectToSnowflake(params, role)
+	params, err := resolveSecretParameters(context.Background(), params)
+	if err != nil {
+		return nil, err
+	}
+
+	retryPolicy, err := newRetryPolicy(params)
+	if err != nil {
+		return nil, err
+	}
+
+	statementTimeout, err := statementTimeoutFromParams(params)
+	if err != nil {
+		return nil, err
+	}
+
+	auditLog, err := auditLogFromParams(params)
+	if err != nil {
+		return nil, err
+	}
+
+	sessionParameters := map[string]string{queryTagSessionParameter: generateQueryTag(options.Phase)}
+	if statementTimeout > 0 {
+		sessionParameters[statementTimeoutSessionParameter] = strconv.Itoa(statementTimeout)
+	}
+
+	conn, role, err := openRepositoryConnection(params, role, options, sessionParameters)
 	if err != nil {
 		return nil, err
 	}
@@ -100,10 +167,43 @@ func NewSnowflakeRepository(params map[string]string, role string) (*SnowflakeRe
 		workerPoolSize:           workerPoolSize,
 		accountNamesPerDelimiter: make(map[rune]string),
 
+		phase:             options.Phase,
+		excludeOwnQueries: !strings.EqualFold(params[SfUsageIncludeOwnQueries], "true"),
+
 		maskFactory: NewMaskFactory(params),
+		retryPolicy: retryPolicy,
+
+		plan:     options.ExecutionPlan,
+		auditLog: auditLog,
 	}, nil
 }
 
+// openRepositoryConnection connects to Snowflake, unless the statements are replayed from a fixture archive.
+// If fixtures are recorded, all statements sent over the connection are recorded.
+func openRepositoryConnection(params map[string]string, role string, options SnowflakeRepositoryOptions, sessionParameters map[string]string) (*sql.DB, string, error) {
+	if path := params[SfReplayFixturesFile]; path != "" {
+		player, err := fixturePlayerFromFile(path)
+		if err != nil {
+			return nil, "", err
+		}
+
+		Logger.Info(fmt.Sprintf("Replaying Snowflake statements from %s", path))
+
+		return sql.OpenDB(newReplayConnector(player, options.Phase)), connectionRole(params, role), nil
+	}
+
+	conn, role, err := connectToSnowflake(params, role, options.Warehouse, sessionParameters)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if recorder := fixtureRecorderFromParams(params); recorder != nil {
+		conn = sql.OpenDB(newRecordingConnector(conn, options.Phase, recorder))
+	}
+
+	return conn, role, nil
+}
+
 func (repo *SnowflakeRepository) Close() error {
 	return repo.conn.Close()
 }
@@ -112,6 +212,15 @@ func (repo *SnowflakeRepository) TotalQueryTime() time.Duration {
 	return repo.queryTime
 }
 
+// TotalRetries returns the number of times a statement was re-executed after a transient error
+func (repo *SnowflakeRepository) TotalRetries() int {
+	if repo.retryPolicy == nil {
+		return 0
+	}
+
+	return repo.retryPolicy.totalRetries()
+}
+
 func (repo *SnowflakeRepository) isProtectedRoleName(rn string) bool {
 	// if sync role is not account admin, we protect this role both on import & export
 	return !strings.EqualFold(repo.role, AccountAdminRole) && strings.EqualFold(repo.role, rn)
@@ -126,7 +235,11 @@ func escapeSingleQuote(s string) string {
 func (repo *SnowflakeRepository) GetDataUsage(ctx context.Context, minTime time.Time, maxTime *time.Time, excludedUsers set.Set[string]) <-chan stream.MaybeError[UsageQueryResult] {
 	outputChannel := make(chan stream.MaybeError[UsageQueryResult], 10000)
 
+	// The span ends once all usage is fetched, not when the channel is returned
+	ctx, span := startSpan(ctx, "SnowflakeRepository.GetDataUsage")
+
 	go func() {
+		defer span.End()
 		defer close(outputChannel)
 
 		defer func() {
@@ -144,7 +257,7 @@ func (repo *SnowflakeRepository) GetDataUsage(ctx context.Context, minTime time.
 
 		queryGen := func(startTime time.Time) (string, []any) {
 			strBuilder := strings.Builder{}
-			args := make([]any, 0, 3)
+			args := make([]any, 0, 4)
 
 			// First query only the QUERY_HISTORY (to avoid a join without LIMIT)
 			strBuilder.WriteString("WITH history as (\n")
@@ -167,6 +280,12 @@ func (repo *SnowflakeRepository) GetDataUsage(ctx context.Context, minTime time.
 				}
 			}
 
+			if repo.excludeOwnQueries {
+				strBuilder.WriteString(" AND (QUERY_TAG IS NULL OR NOT STARTSWITH(QUERY_TAG, ?))")
+
+				args = append(args, queryTagPrefix)
+			}
+
 			if repo.usageBatchSize > 0 {
 				strBuilder.WriteString(" ORDER BY START_TIME asc LIMIT ?")
 
@@ -295,9 +414,12 @@ func (repo *SnowflakeRepository) dataUsageBatch(ctx context.Context, outputChann
 	return newMostRecentQueryStartTime, i, sec, repo.usageBatchSize != 0 && i >= repo.usageBatchSize
 }
 
-func (repo *SnowflakeRepository) GetOutboundShares() ([]ShareEntity, error) {
+func (repo *SnowflakeRepository) GetOutboundShares(ctx context.Context) ([]ShareEntity, error) {
+	ctx, span := startSpan(ctx, "SnowflakeRepository.GetOutboundShares")
+	defer span.End()
+
 	q := "SHOW SHARES"
-	_, err := repo.getDbEntities(q)
+	_, err := repo.getDbEntities(ctx, q)
 
 	if err != nil {
 		return nil, err
@@ -305,7 +427,7 @@ func (repo *SnowflakeRepository) GetOutboundShares() ([]ShareEntity, error) {
 
 	q = `select "name", "owner", "to", "database_name" from table(result_scan(LAST_QUERY_ID())) WHERE "kind" = 'OUTBOUND'`
 
-	rows, _, err := repo.query(q)
+	rows, _, err := repo.query(ctx, q)
 	if err != nil {
 		return nil, err
 	}
@@ -325,11 +447,17 @@ func (repo *SnowflakeRepository) GetOutboundShares() ([]ShareEntity, error) {
 	return shareEntities, nil
 }
 
-func (repo *SnowflakeRepository) GetAccountRoles() ([]RoleEntity, error) {
-	return repo.GetAccountRolesWithPrefix("")
+func (repo *SnowflakeRepository) GetAccountRoles(ctx context.Context) ([]RoleEntity, error) {
+	ctx, span := startSpan(ctx, "SnowflakeRepository.GetAccountRoles")
+	defer span.End()
+
+	return repo.GetAccountRolesWithPrefix(ctx, "")
 }
 
-func (repo *SnowflakeRepository) GetAccountRolesWithPrefix(prefix string) ([]RoleEntity, error) {
+func (repo *SnowflakeRepository) GetAccountRolesWithPrefix(ctx context.Context, prefix string) ([]RoleEntity, error) {
+	ctx, span := startSpan(ctx, "SnowflakeRepository.GetAccountRolesWithPrefix")
+	defer span.End()
+
 	q := "SHOW ROLES"
 
 	if prefix != "" {
@@ -351,7 +479,7 @@ func (repo *SnowflakeRepository) GetAccountRolesWithPrefix(prefix string) ([]Rol
 			query = fmt.Sprintf("%s FROM '%s'", query, escapedFrom)
 		}
 
-		rows, _, err := repo.query(query)
+		rows, _, err := repo.query(ctx, query)
 		if err != nil {
 			return nil, err
 		}
@@ -382,7 +510,10 @@ func (repo *SnowflakeRepository) GetAccountRolesWithPrefix(prefix string) ([]Rol
 	return roleEntities, nil
 }
 
-func (repo *SnowflakeRepository) CreateAccountRole(roleName string) error {
+func (repo *SnowflakeRepository) CreateAccountRole(ctx context.Context, roleName string) error {
+	ctx, span := startSpan(ctx, "SnowflakeRepository.CreateAccountRole")
+	defer span.End()
+
 	if repo.isProtectedRoleName(roleName) {
 		Logger.Warn(fmt.Sprintf("skipping mutation of protected role %s", roleName))
 		return nil
@@ -390,61 +521,141 @@ func (repo *SnowflakeRepository) CreateAccountRole(roleName string) error {
 
 	q := common.FormatQuery(`CREATE ROLE IF NOT EXISTS %s`, roleName)
 
-	_, _, err := repo.query(q)
+	_, _, err := repo.query(ctx, q)
 
 	return err
 }
 
-func (repo *SnowflakeRepository) DropAccountRole(roleName string) error {
+func (repo *SnowflakeRepository) DropAccountRole(ctx context.Context, roleName string) error {
+	ctx, span := startSpan(ctx, "SnowflakeRepository.DropAccountRole")
+	defer span.End()
+
 	if repo.isProtectedRoleName(roleName) {
 		Logger.Warn(fmt.Sprintf("skipping mutation of protected role %s", roleName))
 		return nil
 	}
 
 	q := common.FormatQuery(`GRANT OWNERSHIP ON ROLE %s TO ROLE %s`, roleName, repo.role)
-	_, _, err := repo.query(q)
+	_, _, err := repo.query(ctx, q)
 
 	if err != nil {
 		return err
 	}
 
 	q = common.FormatQuery(`DROP ROLE %s`, roleName)
-	_, _, err = repo.query(q)
+	_, _, err = repo.query(ctx, q)
 
 	return err
 }
 
-func (repo *SnowflakeRepository) RenameAccountRole(oldName, newName string) error {
+func (repo *SnowflakeRepository) RenameAccountRole(ctx context.Context, oldName, newName string) error {
+	ctx, span := startSpan(ctx, "SnowflakeRepository.RenameAccountRole")
+	defer span.End()
+
 	if repo.isProtectedRoleName(oldName) {
 		Logger.Warn(fmt.Sprintf("skipping mutation of protected role %s", oldName))
 		return nil
 	}
 
 	q := common.FormatQuery(`ALTER ROLE IF EXISTS %s RENAME TO %s`, oldName, newName)
-	_, _, err := repo.query(q)
+	_, _, err := repo.query(ctx, q)
 
 	return err
 }
 
-func (repo *SnowflakeRepository) GetGrantsOfAccountRole(roleName string) ([]GrantOfRole, error) {
+func (repo *SnowflakeRepository) GetGrantsOfAccountRole(ctx context.Context, roleName string) ([]GrantOfRole, error) {
+	ctx, span := startSpan(ctx, "SnowflakeRepository.GetGrantsOfAccountRole")
+	defer span.End()
+
 	q := common.FormatQuery(`SHOW GRANTS OF ROLE %s`, roleName)
 
-	return repo.grantsOfRoleMapper(q)
+	return repo.grantsOfRoleMapper(ctx, q)
 }
 
-func (repo *SnowflakeRepository) GetGrantsToAccountRole(roleName string) ([]GrantToRole, error) {
+func (repo *SnowflakeRepository) GetGrantsToAccountRole(ctx context.Context, roleName string) ([]GrantToRole, error) {
+	ctx, span := startSpan(ctx, "SnowflakeRepository.GetGrantsToAccountRole")
+	defer span.End()
+
 	q := common.FormatQuery(`SHOW GRANTS TO ROLE %s`, roleName)
 
-	return repo.grantsToRoleMapper(q)
+	return repo.grantsToRoleMapper(ctx, q)
+}
+
+// GetAllGrantsToAccountRoles returns the privileges granted to all account roles, read from SNOWFLAKE.ACCOUNT_USAGE.GRANTS_TO_ROLES, by role name.
+// The objects are named as in the output of SHOW GRANTS TO ROLE.
+func (repo *SnowflakeRepository) GetAllGrantsToAccountRoles(ctx context.Context) (map[string][]GrantToRole, error) {
+	ctx, span := startSpan(ctx, "SnowflakeRepository.GetAllGrantsToAccountRoles")
+	defer span.End()
+
+	q := `SELECT GRANTEE_NAME, PRIVILEGE, GRANTED_ON, TABLE_CATALOG, TABLE_SCHEMA, NAME FROM SNOWFLAKE.ACCOUNT_USAGE.GRANTS_TO_ROLES WHERE DELETED_ON IS NULL AND GRANTED_TO = 'ROLE' ORDER BY GRANTEE_NAME, TABLE_CATALOG, TABLE_SCHEMA, NAME, PRIVILEGE`
+
+	rows, _, err := repo.query(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	var grantEntities []AccountUsageGrantToRole
+
+	err = scan.Rows(&grantEntities, rows)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching grants to roles: %s", err.Error())
+	}
+
+	grants := make(map[string][]GrantToRole)
+
+	for _, grantEntity := range grantEntities {
+		grants[grantEntity.GranteeName] = append(grants[grantEntity.GranteeName], grantEntity.toGrantToRole())
+	}
+
+	return grants, nil
+}
+
+// GetAllGrantsOfAccountRoles returns the users and roles all account roles are granted to, read from SNOWFLAKE.ACCOUNT_USAGE.GRANTS_TO_USERS and GRANTS_TO_ROLES, by role name
+func (repo *SnowflakeRepository) GetAllGrantsOfAccountRoles(ctx context.Context) (map[string][]GrantOfRole, error) {
+	ctx, span := startSpan(ctx, "SnowflakeRepository.GetAllGrantsOfAccountRoles")
+	defer span.End()
+
+	queries := []string{
+		`SELECT ROLE, GRANTED_TO, GRANTEE_NAME FROM SNOWFLAKE.ACCOUNT_USAGE.GRANTS_TO_USERS WHERE DELETED_ON IS NULL ORDER BY ROLE, GRANTEE_NAME`,
+		`SELECT NAME AS ROLE, GRANTED_TO, GRANTEE_NAME FROM SNOWFLAKE.ACCOUNT_USAGE.GRANTS_TO_ROLES WHERE DELETED_ON IS NULL AND GRANTED_ON = 'ROLE' ORDER BY NAME, GRANTEE_NAME`,
+	}
+
+	grants := make(map[string][]GrantOfRole)
+
+	for _, q := range queries {
+		rows, _, err := repo.query(ctx, q)
+		if err != nil {
+			return nil, err
+		}
+
+		var grantEntities []AccountUsageGrantOfRole
+
+		err = scan.Rows(&grantEntities, rows)
+		if err != nil {
+			return nil, fmt.Errorf("error fetching grants of roles: %s", err.Error())
+		}
+
+		for _, grantEntity := range grantEntities {
+			grants[grantEntity.Role] = append(grants[grantEntity.Role], GrantOfRole{GrantedTo: grantEntity.GrantedTo, GranteeName: grantEntity.GranteeName})
+		}
+	}
+
+	return grants, nil
 }
 
-func (repo *SnowflakeRepository) GetGrantsToShare(shareName string) ([]GrantToRole, error) {
+func (repo *SnowflakeRepository) GetGrantsToShare(ctx context.Context, shareName string) ([]GrantToRole, error) {
+	ctx, span := startSpan(ctx, "SnowflakeRepository.GetGrantsToShare")
+	defer span.End()
+
 	q := common.FormatQuery(`SHOW GRANTS TO SHARE %s`, shareName)
 
-	return repo.grantsToRoleMapper(q)
+	return repo.grantsToRoleMapper(ctx, q)
 }
 
 func (repo *SnowflakeRepository) GrantAccountRolesToAccountRole(ctx context.Context, role string, roles ...string) error {
+	ctx, span := startSpan(ctx, "SnowflakeRepository.GrantAccountRolesToAccountRole")
+	defer span.End()
+
 	statementChan, done := repo.execMultiStatements(ctx)
 
 	for _, otherRole := range roles {
@@ -461,6 +672,9 @@ func (repo *SnowflakeRepository) GrantAccountRolesToAccountRole(ctx context.Cont
 }
 
 func (repo *SnowflakeRepository) RevokeAccountRolesFromAccountRole(ctx context.Context, accountRole string, accountRoles ...string) error {
+	ctx, span := startSpan(ctx, "SnowflakeRepository.RevokeAccountRolesFromAccountRole")
+	defer span.End()
+
 	if repo.isProtectedRoleName(accountRole) {
 		Logger.Warn(fmt.Sprintf("skipping mutation of protected role %s", accountRole))
 		return nil
@@ -479,6 +693,9 @@ func (repo *SnowflakeRepository) RevokeAccountRolesFromAccountRole(ctx context.C
 }
 
 func (repo *SnowflakeRepository) GrantUsersToAccountRole(ctx context.Context, role string, users ...string) error {
+	ctx, span := startSpan(ctx, "SnowflakeRepository.GrantUsersToAccountRole")
+	defer span.End()
+
 	statementChan, done := repo.execMultiStatements(ctx)
 
 	for _, user := range users {
@@ -492,6 +709,9 @@ func (repo *SnowflakeRepository) GrantUsersToAccountRole(ctx context.Context, ro
 }
 
 func (repo *SnowflakeRepository) RevokeUsersFromAccountRole(ctx context.Context, role string, users ...string) error {
+	ctx, span := startSpan(ctx, "SnowflakeRepository.RevokeUsersFromAccountRole")
+	defer span.End()
+
 	if repo.isProtectedRoleName(role) {
 		Logger.Warn(fmt.Sprintf("skipping mutation of protected role %s", role))
 		return nil
@@ -509,14 +729,17 @@ func (repo *SnowflakeRepository) RevokeUsersFromAccountRole(ctx context.Context,
 	return <-done
 }
 
-func (repo *SnowflakeRepository) ExecuteGrantOnAccountRole(perm, on, accountRole string, isSystemGrant bool) error {
+func (repo *SnowflakeRepository) ExecuteGrantOnAccountRole(ctx context.Context, perm, on, accountRole string, isSystemGrant bool) error {
+	ctx, span := startSpan(ctx, "SnowflakeRepository.ExecuteGrantOnAccountRole")
+	defer span.End()
+
 	if repo.isProtectedRoleName(accountRole) && !isSystemGrant {
 		Logger.Warn(fmt.Sprintf("skipping mutation of protected role %s", accountRole))
 		return nil
 	}
 
 	// TODO: parse the `on` string correctly, usually it is something like: SCHEMA "db.schema.table"
-	err := repo.executeGrant(perm, on, accountRole, "ROLE")
+	err := repo.executeGrant(ctx, perm, on, accountRole, "ROLE")
 	if err != nil {
 		return fmt.Errorf("error while executing grant query on Snowflake for role %q: %s", accountRole, err.Error())
 	}
@@ -524,14 +747,17 @@ func (repo *SnowflakeRepository) ExecuteGrantOnAccountRole(perm, on, accountRole
 	return nil
 }
 
-func (repo *SnowflakeRepository) ExecuteRevokeOnAccountRole(perm, on, accountRole string, isSystemRevoke bool) error {
+func (repo *SnowflakeRepository) ExecuteRevokeOnAccountRole(ctx context.Context, perm, on, accountRole string, isSystemRevoke bool) error {
+	ctx, span := startSpan(ctx, "SnowflakeRepository.ExecuteRevokeOnAccountRole")
+	defer span.End()
+
 	if repo.isProtectedRoleName(accountRole) && !isSystemRevoke {
 		Logger.Warn(fmt.Sprintf("skipping mutation of protected role %s", accountRole))
 		return nil
 	}
 
 	// TODO: parse the `on` string correctly, usually it is something like: SCHEMA "db.schema.table"
-	err := repo.revokeGrant(perm, on, accountRole, "ROLE")
+	err := repo.revokeGrant(ctx, perm, on, accountRole, "ROLE")
 	if err != nil {
 		return fmt.Errorf("error while executing revoke query on Snowflake for role %q: %s", accountRole, err.Error())
 	}
@@ -539,8 +765,11 @@ func (repo *SnowflakeRepository) ExecuteRevokeOnAccountRole(perm, on, accountRol
 	return nil
 }
 
-func (repo *SnowflakeRepository) ExecuteGrantOnShare(perm, on, shareName string) error {
-	err := repo.executeGrant(perm, on, shareName, "SHARE")
+func (repo *SnowflakeRepository) ExecuteGrantOnShare(ctx context.Context, perm, on, shareName string) error {
+	ctx, span := startSpan(ctx, "SnowflakeRepository.ExecuteGrantOnShare")
+	defer span.End()
+
+	err := repo.executeGrant(ctx, perm, on, shareName, "SHARE")
 	if err != nil {
 		return fmt.Errorf("error while executing grant query on Snowflake for share %q: %s", shareName, err.Error())
 	}
@@ -548,8 +777,11 @@ func (repo *SnowflakeRepository) ExecuteGrantOnShare(perm, on, shareName string)
 	return nil
 }
 
-func (repo *SnowflakeRepository) ExecuteRevokeOnShare(perm, on, shareName string) error {
-	err := repo.revokeGrant(perm, on, shareName, "SHARE")
+func (repo *SnowflakeRepository) ExecuteRevokeOnShare(ctx context.Context, perm, on, shareName string) error {
+	ctx, span := startSpan(ctx, "SnowflakeRepository.ExecuteRevokeOnShare")
+	defer span.End()
+
+	err := repo.revokeGrant(ctx, perm, on, shareName, "SHARE")
 	if err != nil {
 		return fmt.Errorf("error while executing revoke query on Snowflake for share %q: %s", shareName, err.Error())
 	}
@@ -557,11 +789,11 @@ func (repo *SnowflakeRepository) ExecuteRevokeOnShare(perm, on, shareName string
 	return nil
 }
 
-func (repo *SnowflakeRepository) executeGrant(perm, on, objectId, objectType string) error {
+func (repo *SnowflakeRepository) executeGrant(ctx context.Context, perm, on, objectId, objectType string) error {
 	q := fmt.Sprintf(`GRANT %s ON %s TO %s %s`, perm, on, objectType, common.FormatQuery("%s", objectId))
 	Logger.Debug("Executing grant query", "query", q)
 
-	_, _, err := repo.query(q)
+	_, _, err := repo.query(ctx, q)
 	if err != nil {
 		return fmt.Errorf("error while executing grant query on Snowflake: %s", err.Error())
 	}
@@ -569,11 +801,11 @@ func (repo *SnowflakeRepository) executeGrant(perm, on, objectId, objectType str
 	return nil
 }
 
-func (repo *SnowflakeRepository) revokeGrant(perm, on, objectId, objectType string) error {
+func (repo *SnowflakeRepository) revokeGrant(ctx context.Context, perm, on, objectId, objectType string) error {
 	q := fmt.Sprintf(`REVOKE %s ON %s FROM %s %s`, perm, on, objectType, common.FormatQuery("%s", objectId))
 	Logger.Debug("Executing revoke query", "query", q)
 
-	_, _, err := repo.query(q)
+	_, _, err := repo.query(ctx, q)
 	if err != nil {
 		return fmt.Errorf("error while executing revoke query on Snowflake: %s", err.Error())
 	}
@@ -581,17 +813,23 @@ func (repo *SnowflakeRepository) revokeGrant(perm, on, objectId, objectType stri
 	return nil
 }
 
-func (repo *SnowflakeRepository) GetDatabaseRoles(database string) ([]RoleEntity, error) {
-	return repo.GetDatabaseRolesWithPrefix(database, "")
+func (repo *SnowflakeRepository) GetDatabaseRoles(ctx context.Context, database string) ([]RoleEntity, error) {
+	ctx, span := startSpan(ctx, "SnowflakeRepository.GetDatabaseRoles")
+	defer span.End()
+
+	return repo.GetDatabaseRolesWithPrefix(ctx, database, "")
 }
 
-func (repo *SnowflakeRepository) GetDatabaseRolesWithPrefix(database string, prefix string) ([]RoleEntity, error) {
+func (repo *SnowflakeRepository) GetDatabaseRolesWithPrefix(ctx context.Context, database string, prefix string) ([]RoleEntity, error) {
+	ctx, span := startSpan(ctx, "SnowflakeRepository.GetDatabaseRolesWithPrefix")
+	defer span.End()
+
 	var roleEntities []RoleEntity
 
 	q := common.FormatQuery(`SHOW DATABASE ROLES IN DATABASE %s`, database)
 
 	if prefix != "" {
-		orgQuery, _, err := repo.query(q)
+		orgQuery, _, err := repo.query(ctx, q)
 		if err != nil {
 			return nil, err
 		}
@@ -600,7 +838,7 @@ func (repo *SnowflakeRepository) GetDatabaseRolesWithPrefix(database string, pre
 		q = fmt.Sprintf(`SELECT * FROM table(RESULT_SCAN(LAST_QUERY_ID())) WHERE "name" like '%s' ORDER BY "created_on" DESC;`, prefix+"%")
 	}
 
-	rows, _, err := repo.query(q)
+	rows, _, err := repo.query(ctx, q)
 	if err != nil {
 		return nil, err
 	}
@@ -626,12 +864,15 @@ func (repo *SnowflakeRepository) GetDatabaseRolesWithPrefix(database string, pre
 	return roleEntities, nil
 }
 
-func (repo *SnowflakeRepository) GetApplicationRoles(application string) ([]ApplicationRoleEntity, error) {
+func (repo *SnowflakeRepository) GetApplicationRoles(ctx context.Context, application string) ([]ApplicationRoleEntity, error) {
+	ctx, span := startSpan(ctx, "SnowflakeRepository.GetApplicationRoles")
+	defer span.End()
+
 	var result []ApplicationRoleEntity
 
 	q := common.FormatQuery("SHOW APPLICATION ROLES IN APPLICATION %s", application)
 
-	rows, _, err := repo.query(q)
+	rows, _, err := repo.query(ctx, q)
 	if err != nil {
 		return nil, err
 	}
@@ -644,15 +885,21 @@ func (repo *SnowflakeRepository) GetApplicationRoles(application string) ([]Appl
 	return result, nil
 }
 
-func (repo *SnowflakeRepository) GetGrantsOfApplicationRole(application, role string) ([]GrantOfRole, error) {
+func (repo *SnowflakeRepository) GetGrantsOfApplicationRole(ctx context.Context, application, role string) ([]GrantOfRole, error) {
+	ctx, span := startSpan(ctx, "SnowflakeRepository.GetGrantsOfApplicationRole")
+	defer span.End()
+
 	q := fmt.Sprintf("SHOW GRANTS OF APPLICATION ROLE %s", common.FormatQuery("%s.%s", application, role))
 
 	Logger.Info(fmt.Sprintf("Executing query: %s", q))
 
-	return repo.grantsOfRoleMapper(q)
+	return repo.grantsOfRoleMapper(ctx, q)
 }
 
-func (repo *SnowflakeRepository) CreateDatabaseRole(database string, roleName string) error {
+func (repo *SnowflakeRepository) CreateDatabaseRole(ctx context.Context, database string, roleName string) error {
+	ctx, span := startSpan(ctx, "SnowflakeRepository.CreateDatabaseRole")
+	defer span.End()
+
 	if repo.isProtectedRoleName(roleName) {
 		Logger.Warn(fmt.Sprintf("skipping mutation of protected role %s.%s", database, roleName))
 		return nil
@@ -660,49 +907,64 @@ func (repo *SnowflakeRepository) CreateDatabaseRole(database string, roleName st
 
 	q := common.FormatQuery(`CREATE DATABASE ROLE IF NOT EXISTS %s.%s`, database, roleName)
 
-	_, _, err := repo.query(q)
+	_, _, err := repo.query(ctx, q)
 
 	return err
 }
 
-func (repo *SnowflakeRepository) DropDatabaseRole(database string, roleName string) error {
+func (repo *SnowflakeRepository) DropDatabaseRole(ctx context.Context, database string, roleName string) error {
+	ctx, span := startSpan(ctx, "SnowflakeRepository.DropDatabaseRole")
+	defer span.End()
+
 	q := common.FormatQuery(`GRANT OWNERSHIP ON DATABASE ROLE %s.%s TO ROLE %s`, database, roleName, repo.role)
-	_, _, err := repo.query(q)
+	_, _, err := repo.query(ctx, q)
 
 	if err != nil {
 		return err
 	}
 
 	q = common.FormatQuery(`DROP DATABASE ROLE %s.%s`, database, roleName)
-	_, _, err = repo.query(q)
+	_, _, err = repo.query(ctx, q)
 
 	return err
 }
-func (repo *SnowflakeRepository) RenameDatabaseRole(database, oldName, newName string) error {
+func (repo *SnowflakeRepository) RenameDatabaseRole(ctx context.Context, database, oldName, newName string) error {
+	ctx, span := startSpan(ctx, "SnowflakeRepository.RenameDatabaseRole")
+	defer span.End()
+
 	if repo.isProtectedRoleName(oldName) {
 		Logger.Warn(fmt.Sprintf("skipping mutation of protected role %q.%q", database, oldName))
 		return nil
 	}
 
 	q := common.FormatQuery(`ALTER DATABASE ROLE IF EXISTS %s.%s RENAME TO %s.%s`, database, oldName, database, newName)
-	_, _, err := repo.query(q)
+	_, _, err := repo.query(ctx, q)
 
 	return err
 }
 
-func (repo *SnowflakeRepository) GetGrantsOfDatabaseRole(database, roleName string) ([]GrantOfRole, error) {
+func (repo *SnowflakeRepository) GetGrantsOfDatabaseRole(ctx context.Context, database, roleName string) ([]GrantOfRole, error) {
+	ctx, span := startSpan(ctx, "SnowflakeRepository.GetGrantsOfDatabaseRole")
+	defer span.End()
+
 	q := common.FormatQuery(`SHOW GRANTS OF DATABASE ROLE %s.%s`, database, roleName)
 
-	return repo.grantsOfRoleMapper(q)
+	return repo.grantsOfRoleMapper(ctx, q)
 }
 
-func (repo *SnowflakeRepository) GetGrantsToDatabaseRole(database, roleName string) ([]GrantToRole, error) {
+func (repo *SnowflakeRepository) GetGrantsToDatabaseRole(ctx context.Context, database, roleName string) ([]GrantToRole, error) {
+	ctx, span := startSpan(ctx, "SnowflakeRepository.GetGrantsToDatabaseRole")
+	defer span.End()
+
 	q := common.FormatQuery(`SHOW GRANTS TO DATABASE ROLE %s.%s`, database, roleName)
 
-	return repo.grantsToRoleMapper(q)
+	return repo.grantsToRoleMapper(ctx, q)
 }
 
 func (repo *SnowflakeRepository) GrantAccountRolesToDatabaseRole(ctx context.Context, database string, databaseRole string, accountRoles ...string) error {
+	ctx, span := startSpan(ctx, "SnowflakeRepository.GrantAccountRolesToDatabaseRole")
+	defer span.End()
+
 	statementChan, done := repo.execMultiStatements(ctx)
 
 	for _, otherAccountRole := range accountRoles {
@@ -719,6 +981,9 @@ func (repo *SnowflakeRepository) GrantAccountRolesToDatabaseRole(ctx context.Con
 }
 
 func (repo *SnowflakeRepository) GrantDatabaseRolesToDatabaseRole(ctx context.Context, database string, databaseRole string, databaseRoles ...string) error {
+	ctx, span := startSpan(ctx, "SnowflakeRepository.GrantDatabaseRolesToDatabaseRole")
+	defer span.End()
+
 	statementChan, done := repo.execMultiStatements(ctx)
 
 	for _, otherDatabaseRole := range databaseRoles {
@@ -735,6 +1000,9 @@ func (repo *SnowflakeRepository) GrantDatabaseRolesToDatabaseRole(ctx context.Co
 }
 
 func (repo *SnowflakeRepository) GrantSharesToDatabaseRole(ctx context.Context, database string, databaseRole string, shares ...string) error {
+	ctx, span := startSpan(ctx, "SnowflakeRepository.GrantSharesToDatabaseRole")
+	defer span.End()
+
 	statementChan, done := repo.execMultiStatements(ctx)
 
 	for _, share := range shares {
@@ -751,6 +1019,9 @@ func (repo *SnowflakeRepository) GrantSharesToDatabaseRole(ctx context.Context,
 }
 
 func (repo *SnowflakeRepository) GrantAccountRolesToApplicationRole(ctx context.Context, application string, applicationRole string, accountRoles ...string) error {
+	ctx, span := startSpan(ctx, "SnowflakeRepository.GrantAccountRolesToApplicationRole")
+	defer span.End()
+
 	statementChan, done := repo.execMultiStatements(ctx)
 
 	for _, otherAccountRole := range accountRoles {
@@ -767,6 +1038,9 @@ func (repo *SnowflakeRepository) GrantAccountRolesToApplicationRole(ctx context.
 }
 
 func (repo *SnowflakeRepository) GrantApplicationRolesToApplicationRole(ctx context.Context, application string, applicationRole string, applicationRoles ...string) error {
+	ctx, span := startSpan(ctx, "SnowflakeRepository.GrantApplicationRolesToApplicationRole")
+	defer span.End()
+
 	statementChan, done := repo.execMultiStatements(ctx)
 
 	for _, otherApplicationRole := range applicationRoles {
@@ -783,6 +1057,9 @@ func (repo *SnowflakeRepository) GrantApplicationRolesToApplicationRole(ctx cont
 }
 
 func (repo *SnowflakeRepository) RevokeAccountRolesFromDatabaseRole(ctx context.Context, database string, databaseRole string, accountRoles ...string) error {
+	ctx, span := startSpan(ctx, "SnowflakeRepository.RevokeAccountRolesFromDatabaseRole")
+	defer span.End()
+
 	if repo.isProtectedRoleName(databaseRole) {
 		Logger.Warn(fmt.Sprintf("skipping mutation of protected role %q.%q", database, databaseRole))
 		return nil
@@ -801,6 +1078,9 @@ func (repo *SnowflakeRepository) RevokeAccountRolesFromDatabaseRole(ctx context.
 }
 
 func (repo *SnowflakeRepository) RevokeSharesFromDatabaseRole(ctx context.Context, database string, databaseRole string, shares ...string) error {
+	ctx, span := startSpan(ctx, "SnowflakeRepository.RevokeSharesFromDatabaseRole")
+	defer span.End()
+
 	if repo.isProtectedRoleName(databaseRole) {
 		Logger.Warn(fmt.Sprintf("skipping mutation of protected role %q.%q", database, databaseRole))
 		return nil
@@ -819,6 +1099,9 @@ func (repo *SnowflakeRepository) RevokeSharesFromDatabaseRole(ctx context.Contex
 }
 
 func (repo *SnowflakeRepository) RevokeDatabaseRolesFromDatabaseRole(ctx context.Context, database string, databaseRole string, databaseRoles ...string) error {
+	ctx, span := startSpan(ctx, "SnowflakeRepository.RevokeDatabaseRolesFromDatabaseRole")
+	defer span.End()
+
 	if repo.isProtectedRoleName(databaseRole) {
 		Logger.Warn(fmt.Sprintf("skipping mutation of protected role %q.%q", database, databaseRole))
 		return nil
@@ -837,6 +1120,9 @@ func (repo *SnowflakeRepository) RevokeDatabaseRolesFromDatabaseRole(ctx context
 }
 
 func (repo *SnowflakeRepository) RevokeAccountRolesFromApplicationRole(ctx context.Context, application string, applicationRole string, accountRoles ...string) error {
+	ctx, span := startSpan(ctx, "SnowflakeRepository.RevokeAccountRolesFromApplicationRole")
+	defer span.End()
+
 	if repo.isProtectedRoleName(applicationRole) {
 		Logger.Warn(fmt.Sprintf("skipping mutation of protected role %q.%q", application, applicationRole))
 		return nil
@@ -855,6 +1141,9 @@ func (repo *SnowflakeRepository) RevokeAccountRolesFromApplicationRole(ctx conte
 }
 
 func (repo *SnowflakeRepository) RevokeApplicationRolesFromApplicationRole(ctx context.Context, application string, applicationRole string, applicationRoles ...string) error {
+	ctx, span := startSpan(ctx, "SnowflakeRepository.RevokeApplicationRolesFromApplicationRole")
+	defer span.End()
+
 	if repo.isProtectedRoleName(applicationRole) {
 		Logger.Warn(fmt.Sprintf("skipping mutation of protected role %q.%q", application, applicationRole))
 		return nil
@@ -872,7 +1161,10 @@ func (repo *SnowflakeRepository) RevokeApplicationRolesFromApplicationRole(ctx c
 	return <-done
 }
 
-func (repo *SnowflakeRepository) ExecuteGrantOnDatabaseRole(perm, on, database, databaseRole string) error {
+func (repo *SnowflakeRepository) ExecuteGrantOnDatabaseRole(ctx context.Context, perm, on, database, databaseRole string) error {
+	ctx, span := startSpan(ctx, "SnowflakeRepository.ExecuteGrantOnDatabaseRole")
+	defer span.End()
+
 	if repo.isProtectedRoleName(databaseRole) && !strings.EqualFold(perm, "USAGE") && !strings.EqualFold(perm, "IMPORTED PRIVILEGES") && !strings.EqualFold(perm, "REFERENCES") {
 		Logger.Warn(fmt.Sprintf("skipping mutation of protected role %s.%s", database, databaseRole))
 		return nil
@@ -882,7 +1174,7 @@ func (repo *SnowflakeRepository) ExecuteGrantOnDatabaseRole(perm, on, database,
 	q := fmt.Sprintf(`GRANT %s ON %s TO DATABASE ROLE %s`, perm, on, common.FormatQuery("%s.%s", database, databaseRole))
 	Logger.Debug("Executing grant query", "query", q)
 
-	_, _, err := repo.query(q)
+	_, _, err := repo.query(ctx, q)
 
 	if err != nil {
 		return fmt.Errorf("error while executing grant query on Snowflake for role %s.%s: %s", database, databaseRole, err.Error())
@@ -891,7 +1183,10 @@ func (repo *SnowflakeRepository) ExecuteGrantOnDatabaseRole(perm, on, database,
 	return nil
 }
 
-func (repo *SnowflakeRepository) ExecuteRevokeOnDatabaseRole(perm, on, database, databaseRole string) error {
+func (repo *SnowflakeRepository) ExecuteRevokeOnDatabaseRole(ctx context.Context, perm, on, database, databaseRole string) error {
+	ctx, span := startSpan(ctx, "SnowflakeRepository.ExecuteRevokeOnDatabaseRole")
+	defer span.End()
+
 	if repo.isProtectedRoleName(databaseRole) && !strings.EqualFold(perm, "USAGE") && !strings.EqualFold(perm, "IMPORTED PRIVILEGES") && !strings.EqualFold(perm, "SELECT") {
 		Logger.Warn(fmt.Sprintf("skipping mutation of protected role %s.%s", database, databaseRole))
 		return nil
@@ -902,7 +1197,7 @@ func (repo *SnowflakeRepository) ExecuteRevokeOnDatabaseRole(perm, on, database,
 	q := fmt.Sprintf(`REVOKE %s ON %s FROM DATABASE ROLE %s`, perm, on, common.FormatQuery("%s.%s", database, databaseRole))
 	Logger.Debug(fmt.Sprintf("Executing revoke query: %s", q))
 
-	_, _, err := repo.query(q)
+	_, _, err := repo.query(ctx, q)
 	if err != nil {
 		return fmt.Errorf("error while executing revoke query on Snowflake for role %s.%s: %s", database, databaseRole, err.Error())
 	}
@@ -910,8 +1205,8 @@ func (repo *SnowflakeRepository) ExecuteRevokeOnDatabaseRole(perm, on, database,
 	return nil
 }
 
-func (repo *SnowflakeRepository) grantsOfRoleMapper(query string) ([]GrantOfRole, error) {
-	rows, _, err := repo.query(query)
+func (repo *SnowflakeRepository) grantsOfRoleMapper(ctx context.Context, query string) ([]GrantOfRole, error) {
+	rows, _, err := repo.query(ctx, query)
 	if err != nil {
 		return nil, err
 	}
@@ -928,8 +1223,8 @@ func (repo *SnowflakeRepository) grantsOfRoleMapper(query string) ([]GrantOfRole
 	return grantOfEntities, nil
 }
 
-func (repo *SnowflakeRepository) grantsToRoleMapper(query string) ([]GrantToRole, error) {
-	rows, _, err := repo.query(query)
+func (repo *SnowflakeRepository) grantsToRoleMapper(ctx context.Context, query string) ([]GrantToRole, error) {
+	rows, _, err := repo.query(ctx, query)
 	if err != nil {
 		return nil, err
 	}
@@ -946,10 +1241,13 @@ func (repo *SnowflakeRepository) grantsToRoleMapper(query string) ([]GrantToRole
 	return grantToEntities, nil
 }
 
-func (repo *SnowflakeRepository) GetUsers() ([]UserEntity, error) {
+func (repo *SnowflakeRepository) GetUsers(ctx context.Context) ([]UserEntity, error) {
+	ctx, span := startSpan(ctx, "SnowflakeRepository.GetUsers")
+	defer span.End()
+
 	q := "SHOW USERS"
 
-	rows, _, err := repo.query(q)
+	rows, _, err := repo.query(ctx, q)
 	if err != nil {
 		return nil, err
 	}
@@ -974,7 +1272,7 @@ func (repo *SnowflakeRepository) GetUsers() ([]UserEntity, error) {
 		}
 
 		wp.Submit(func() {
-			describeRows, _, err := repo.query(fmt.Sprintf(`DESCRIBE USER "%s"`, userRow.Name)) //nolint:gocritic
+			describeRows, _, err := repo.query(ctx, fmt.Sprintf(`DESCRIBE USER "%s"`, userRow.Name)) //nolint:gocritic
 			if err != nil {
 				Logger.Warn(fmt.Sprintf("Unable to fetch user details for %q: %s", userRow.Name, err.Error()))
 				return
@@ -1008,10 +1306,13 @@ type UserDetails struct {
 	Value    string `db:"value"`
 }
 
-func (repo *SnowflakeRepository) GetPolicies(policy string) ([]PolicyEntity, error) {
+func (repo *SnowflakeRepository) GetPolicies(ctx context.Context, policy string) ([]PolicyEntity, error) {
+	ctx, span := startSpan(ctx, "SnowflakeRepository.GetPolicies")
+	defer span.End()
+
 	q := fmt.Sprintf("SHOW %s POLICIES", policy)
 
-	rows, _, err := repo.query(q)
+	rows, _, err := repo.query(ctx, q)
 	if err != nil {
 		return nil, err
 	}
@@ -1028,10 +1329,13 @@ func (repo *SnowflakeRepository) GetPolicies(policy string) ([]PolicyEntity, err
 	return policyEntities, nil
 }
 
-func (repo *SnowflakeRepository) DescribePolicy(policyType, dbName, schema, policyName string) ([]DescribePolicyEntity, error) {
+func (repo *SnowflakeRepository) DescribePolicy(ctx context.Context, policyType, dbName, schema, policyName string) ([]DescribePolicyEntity, error) {
+	ctx, span := startSpan(ctx, "SnowflakeRepository.DescribePolicy")
+	defer span.End()
+
 	q := common.FormatQuery("DESCRIBE "+policyType+" POLICY %s.%s.%s", dbName, schema, policyName)
 
-	rows, _, err := repo.query(q)
+	rows, _, err := repo.query(ctx, q)
 	if err != nil {
 		return nil, err
 	}
@@ -1046,16 +1350,19 @@ func (repo *SnowflakeRepository) DescribePolicy(policyType, dbName, schema, poli
 	return desribeMaskingPolicyEntities, nil
 }
 
-func (repo *SnowflakeRepository) GetPolicyReferences(dbName, schema, policyName string) ([]PolicyReferenceEntity, error) {
+func (repo *SnowflakeRepository) GetPolicyReferences(ctx context.Context, dbName, schema, policyName string) ([]PolicyReferenceEntity, error) {
+	ctx, span := startSpan(ctx, "SnowflakeRepository.GetPolicyReferences")
+	defer span.End()
+
 	// to fetch policy references we need to have USAGE on dbName and schema
 	if !strings.EqualFold(repo.role, AccountAdminRole) && repo.role != "" {
-		err := repo.ExecuteGrantOnAccountRole("USAGE", common.FormatQuery("DATABASE %s", dbName), repo.role, true)
+		err := repo.ExecuteGrantOnAccountRole(ctx, "USAGE", common.FormatQuery("DATABASE %s", dbName), repo.role, true)
 
 		if err != nil {
 			return nil, err
 		}
 
-		err = repo.ExecuteGrantOnAccountRole("USAGE", common.FormatQuery("SCHEMA %s.%s", dbName, schema), repo.role, true)
+		err = repo.ExecuteGrantOnAccountRole(ctx, "USAGE", common.FormatQuery("SCHEMA %s.%s", dbName, schema), repo.role, true)
 
 		if err != nil {
 			return nil, err
@@ -1064,7 +1371,7 @@ func (repo *SnowflakeRepository) GetPolicyReferences(dbName, schema, policyName
 
 	q := fmt.Sprintf(`select * from table(%s.information_schema.policy_references(policy_name => '%s'))`, dbName, common.FormatQuery(`%s.%s.%s`, dbName, schema, policyName))
 
-	rows, _, err := repo.query(q)
+	rows, _, err := repo.query(ctx, q)
 	if err != nil {
 		return nil, err
 	}
@@ -1083,7 +1390,10 @@ type GetSnowFlakeAccountNameOptions struct {
 	Delimiter rune
 }
 
-func (repo *SnowflakeRepository) GetSnowFlakeAccountName(ops ...func(options *GetSnowFlakeAccountNameOptions)) (string, error) {
+func (repo *SnowflakeRepository) GetSnowFlakeAccountName(ctx context.Context, ops ...func(options *GetSnowFlakeAccountNameOptions)) (string, error) {
+	ctx, span := startSpan(ctx, "SnowflakeRepository.GetSnowFlakeAccountName")
+	defer span.End()
+
 	options := GetSnowFlakeAccountNameOptions{
 		Delimiter: '-',
 	}
@@ -1099,7 +1409,7 @@ func (repo *SnowflakeRepository) GetSnowFlakeAccountName(ops ...func(options *Ge
 		return accountName, nil
 	}
 
-	rows, _, err := repo.query(fmt.Sprintf(`select CONCAT(CURRENT_ORGANIZATION_NAME(), '%s', CURRENT_ACCOUNT_NAME())`, string(options.Delimiter)))
+	rows, _, err := repo.query(ctx, fmt.Sprintf(`select CONCAT(CURRENT_ORGANIZATION_NAME(), '%s', CURRENT_ACCOUNT_NAME())`, string(options.Delimiter)))
 	if err != nil {
 		return "", err
 	}
@@ -1120,15 +1430,24 @@ func (repo *SnowflakeRepository) GetSnowFlakeAccountName(ops ...func(options *Ge
 	return r[0], nil
 }
 
-func (repo *SnowflakeRepository) GetTagsByDomain(domain string) (map[string][]*tag.Tag, error) {
-	return repo.getTags(ptr.String(domain), nil)
+func (repo *SnowflakeRepository) GetTagsByDomain(ctx context.Context, domain string) (map[string][]*tag.Tag, error) {
+	ctx, span := startSpan(ctx, "SnowflakeRepository.GetTagsByDomain")
+	defer span.End()
+
+	return repo.getTags(ctx, ptr.String(domain), nil)
 }
 
-func (repo *SnowflakeRepository) GetTagsLinkedToDatabaseName(databaseName string) (map[string][]*tag.Tag, error) {
-	return repo.getTags(nil, ptr.String(databaseName))
+func (repo *SnowflakeRepository) GetTagsLinkedToDatabaseName(ctx context.Context, databaseName string) (map[string][]*tag.Tag, error) {
+	ctx, span := startSpan(ctx, "SnowflakeRepository.GetTagsLinkedToDatabaseName")
+	defer span.End()
+
+	return repo.getTags(ctx, nil, ptr.String(databaseName))
 }
 
-func (repo *SnowflakeRepository) GetDirectObjectTagValues(tagName, objectName, objectDomain string) ([]string, error) {
+func (repo *SnowflakeRepository) GetDirectObjectTagValues(ctx context.Context, tagName, objectName, objectDomain string) ([]string, error) {
+	ctx, span := startSpan(ctx, "SnowflakeRepository.GetDirectObjectTagValues")
+	defer span.End()
+
 	tagObject := common.ParseFullName(tagName)
 	if tagObject.Database == nil || tagObject.Schema == nil || tagObject.Table == nil {
 		return nil, fmt.Errorf("expected tagname %q to have 3 parts (database.schema.tagname)", tagName)
@@ -1145,7 +1464,7 @@ func (repo *SnowflakeRepository) GetDirectObjectTagValues(tagName, objectName, o
 
 	query := fmt.Sprintf("SELECT SYSTEM$GET_TAG('%s', '%s', '%s') as value;", tagName, objectName, objectDomain)
 
-	rows, _, err := repo.query(query)
+	rows, _, err := repo.query(ctx, query)
 	if err != nil {
 		return nil, err
 	}
@@ -1166,7 +1485,7 @@ func (repo *SnowflakeRepository) GetDirectObjectTagValues(tagName, objectName, o
 	return tagValues, nil
 }
 
-func (repo *SnowflakeRepository) getTags(domain *string, databaseName *string) (map[string][]*tag.Tag, error) {
+func (repo *SnowflakeRepository) getTags(ctx context.Context, domain *string, databaseName *string) (map[string][]*tag.Tag, error) {
 	tagMap := make(map[string][]*tag.Tag)
 
 	query := []string{}
@@ -1184,7 +1503,7 @@ func (repo *SnowflakeRepository) getTags(domain *string, databaseName *string) (
 		additionalWhereItems = fmt.Sprintf("AND %s", strings.Join(query, " AND "))
 	}
 
-	rows, _, err := repo.query(fmt.Sprintf("select column_name, object_database, object_schema, object_name, domain, tag_name, tag_value from SNOWFLAKE.ACCOUNT_USAGE.tag_references where object_deleted is null %s;", additionalWhereItems))
+	rows, _, err := repo.query(ctx, fmt.Sprintf("select column_name, object_database, object_schema, object_name, domain, tag_name, tag_value from SNOWFLAKE.ACCOUNT_USAGE.tag_references where object_deleted is null %s;", additionalWhereItems))
 	if err != nil {
 		return nil, err
 	}
@@ -1208,10 +1527,13 @@ func (repo *SnowflakeRepository) getTags(domain *string, databaseName *string) (
 	return tagMap, nil
 }
 
-func (repo *SnowflakeRepository) GetDatabaseRoleTags(databaseName string, roleName string) (map[string][]*tag.Tag, error) {
+func (repo *SnowflakeRepository) GetDatabaseRoleTags(ctx context.Context, databaseName string, roleName string) (map[string][]*tag.Tag, error) {
+	ctx, span := startSpan(ctx, "SnowflakeRepository.GetDatabaseRoleTags")
+	defer span.End()
+
 	tagMap := make(map[string][]*tag.Tag)
 
-	rows, _, err := repo.query(fmt.Sprintf(`
+	rows, _, err := repo.query(ctx, fmt.Sprintf(`
 		select column_name, object_database, object_schema, object_name, domain, tag_name, tag_value
 		FROM TABLE(%[1]s.INFORMATION_SCHEMA.TAG_REFERENCES('%[1]s.%[2]s','DATABASE ROLE'));`, databaseName, roleName))
 	if err != nil {
@@ -1237,7 +1559,10 @@ func (repo *SnowflakeRepository) GetDatabaseRoleTags(databaseName string, roleNa
 	return tagMap, nil
 }
 
-func (repo *SnowflakeRepository) SetTagOnRole(roleName, tagName, tagValue string, isDatabaseRole bool) error {
+func (repo *SnowflakeRepository) SetTagOnRole(ctx context.Context, roleName, tagName, tagValue string, isDatabaseRole bool) error {
+	ctx, span := startSpan(ctx, "SnowflakeRepository.SetTagOnRole")
+	defer span.End()
+
 	sfObject := common.ParseFullName(tagName)
 	if sfObject.Database == nil || sfObject.Schema == nil || sfObject.Table == nil {
 		return fmt.Errorf("expected tagname %q to have 3 parts (database.schema.tagname)", tagName)
@@ -1259,7 +1584,7 @@ func (repo *SnowflakeRepository) SetTagOnRole(roleName, tagName, tagValue string
 
 	q := fmt.Sprintf(`ALTER %s %s SET TAG %s = '%s'`, entityName, roleName, common.FormatQuery(`%s.%s.%s`, *sfObject.Database, *sfObject.Schema, *sfObject.Table), strings.ReplaceAll(tagValue, "'", ""))
 
-	_, _, err := repo.query(q)
+	_, _, err := repo.query(ctx, q)
 
 	if err != nil {
 		return fmt.Errorf("setting tag %q on role %q: %s", tagName, roleName, err.Error())
@@ -1268,14 +1593,28 @@ func (repo *SnowflakeRepository) SetTagOnRole(roleName, tagName, tagValue string
 	return nil
 }
 
-func (repo *SnowflakeRepository) GetWarehouses() ([]DbEntity, error) {
+func (repo *SnowflakeRepository) GetWarehouses(ctx context.Context) ([]DbEntity, error) {
+	ctx, span := startSpan(ctx, "SnowflakeRepository.GetWarehouses")
+	defer span.End()
+
 	q := "SHOW WAREHOUSES"
-	return repo.getDbEntities(q)
+	return repo.getDbEntities(ctx, q)
+}
+
+func (repo *SnowflakeRepository) GetComputePools(ctx context.Context) ([]DbEntity, error) {
+	ctx, span := startSpan(ctx, "SnowflakeRepository.GetComputePools")
+	defer span.End()
+
+	q := "SHOW COMPUTE POOLS"
+	return repo.getDbEntities(ctx, q)
 }
 
-func (repo *SnowflakeRepository) GetInboundShares() ([]DbEntity, error) {
+func (repo *SnowflakeRepository) GetInboundShares(ctx context.Context) ([]DbEntity, error) {
+	ctx, span := startSpan(ctx, "SnowflakeRepository.GetInboundShares")
+	defer span.End()
+
 	q := "SHOW SHARES"
-	_, err := repo.getDbEntities(q)
+	_, err := repo.getDbEntities(ctx, q)
 
 	if err != nil {
 		return nil, err
@@ -1283,14 +1622,20 @@ func (repo *SnowflakeRepository) GetInboundShares() ([]DbEntity, error) {
 
 	q = "select \"database_name\" as \"name\", \"kind\", \"owner_account\", \"name\" as \"share_name\" from table(result_scan(LAST_QUERY_ID())) WHERE \"kind\" = 'INBOUND' AND \"database_name\" != ''"
 
-	return repo.getDbEntities(q)
+	return repo.getDbEntities(ctx, q)
 }
 
-func (repo *SnowflakeRepository) GetDatabases() ([]DbEntity, error) {
-	return repo.GetDatabasesByKind("STANDARD")
+func (repo *SnowflakeRepository) GetDatabases(ctx context.Context) ([]DbEntity, error) {
+	ctx, span := startSpan(ctx, "SnowflakeRepository.GetDatabases")
+	defer span.End()
+
+	return repo.GetDatabasesByKind(ctx, "STANDARD")
 }
 
-func (repo *SnowflakeRepository) GetDatabasesByKind(kind string) ([]DbEntity, error) {
+func (repo *SnowflakeRepository) GetDatabasesByKind(ctx context.Context, kind string) ([]DbEntity, error) {
+	ctx, span := startSpan(ctx, "SnowflakeRepository.GetDatabasesByKind")
+	defer span.End()
+
 	const pageSize = 1000
 	var allDbs []DbEntity
 	var lastDbName string
@@ -1302,7 +1647,7 @@ func (repo *SnowflakeRepository) GetDatabasesByKind(kind string) ([]DbEntity, er
 			q += fmt.Sprintf(" FROM '%s'", escapeSingleQuote(lastDbName))
 		}
 
-		rows, _, err := repo.query(q)
+		rows, _, err := repo.query(ctx, q)
 		if err != nil {
 			return nil, fmt.Errorf("fetching databases: %w", err)
 		}
@@ -1333,10 +1678,13 @@ func (repo *SnowflakeRepository) GetDatabasesByKind(kind string) ([]DbEntity, er
 	return allDbs, nil
 }
 
-func (repo *SnowflakeRepository) GetApplications() ([]ApplictionEntity, error) {
+func (repo *SnowflakeRepository) GetApplications(ctx context.Context) ([]ApplictionEntity, error) {
+	ctx, span := startSpan(ctx, "SnowflakeRepository.GetApplications")
+	defer span.End()
+
 	q := "SHOW APPLICATIONS IN ACCOUNT"
 
-	apps, err := getDbRows[ApplictionEntity](repo, q)
+	apps, err := getDbRows[ApplictionEntity](ctx, repo, q)
 	if err != nil {
 		return nil, fmt.Errorf("fetching applications: %w", err)
 	}
@@ -1344,10 +1692,13 @@ func (repo *SnowflakeRepository) GetApplications() ([]ApplictionEntity, error) {
 	return apps, nil
 }
 
-func (repo *SnowflakeRepository) GetSchemasInDatabase(databaseName string, handleEntity EntityHandler) error {
+func (repo *SnowflakeRepository) GetSchemasInDatabase(ctx context.Context, databaseName string, handleEntity EntityHandler) error {
+	ctx, span := startSpan(ctx, "SnowflakeRepository.GetSchemasInDatabase")
+	defer span.End()
+
 	q := getSchemasInDatabaseQuery(databaseName)
 
-	return handleDbEntities(repo, q, func() interface{} {
+	return handleDbEntities(ctx, repo, q, func() interface{} {
 		return &SchemaEntity{}
 	}, handleEntity)
 }
@@ -1376,20 +1727,26 @@ func handleFunctionEntity(entity any, handleEntity EntityHandler) error {
 	return handleEntity(functionEntity)
 }
 
-func (repo *SnowflakeRepository) GetFunctionsInSchema(databaseName string, schema string, handleEntity EntityHandler) error {
+func (repo *SnowflakeRepository) GetFunctionsInSchema(ctx context.Context, databaseName string, schema string, handleEntity EntityHandler) error {
+	ctx, span := startSpan(ctx, "SnowflakeRepository.GetFunctionsInSchema")
+	defer span.End()
+
 	q := common.FormatQuery("SHOW FUNCTIONS IN SCHEMA %s.%s LIMIT 10000", databaseName, schema)
 
-	return handleDbEntities(repo, q, func() any {
+	return handleDbEntities(ctx, repo, q, func() any {
 		return &FunctionEntity{}
 	}, func(entity any) error {
 		return handleFunctionEntity(entity, handleEntity)
 	})
 }
 
-func (repo *SnowflakeRepository) GetFunctionsInDatabase(databaseName string, handleEntity EntityHandler) error {
+func (repo *SnowflakeRepository) GetFunctionsInDatabase(ctx context.Context, databaseName string, handleEntity EntityHandler) error {
+	ctx, span := startSpan(ctx, "SnowflakeRepository.GetFunctionsInDatabase")
+	defer span.End()
+
 	q := getFunctionsInDatabaseQuery(databaseName)
 
-	return handleDbEntities(repo, q, func() any {
+	return handleDbEntities(ctx, repo, q, func() any {
 		return &FunctionEntity{}
 	}, func(entity any) error {
 		return handleFunctionEntity(entity, handleEntity)
@@ -1434,45 +1791,72 @@ func showProceduresHandler(entity any, handleEntity EntityHandler) error {
 	return handleEntity(procedureEntity)
 }
 
-func (repo *SnowflakeRepository) GetProceduresInSchema(databaseName string, schema string, handleEntity EntityHandler) error {
+func (repo *SnowflakeRepository) GetProceduresInSchema(ctx context.Context, databaseName string, schema string, handleEntity EntityHandler) error {
+	ctx, span := startSpan(ctx, "SnowflakeRepository.GetProceduresInSchema")
+	defer span.End()
+
 	q := common.FormatQuery("SHOW PROCEDURES IN SCHEMA %s.%s LIMIT 10000", databaseName, schema)
 
-	return handleDbEntities(repo, q, func() any {
+	return handleDbEntities(ctx, repo, q, func() any {
 		return &ProcedureEntity{}
 	}, func(entity any) error {
 		return showProceduresHandler(entity, handleEntity)
 	})
 }
 
-func (repo *SnowflakeRepository) GetProceduresInDatabase(databaseName string, handleEntity EntityHandler) error {
+func (repo *SnowflakeRepository) GetProceduresInDatabase(ctx context.Context, databaseName string, handleEntity EntityHandler) error {
+	ctx, span := startSpan(ctx, "SnowflakeRepository.GetProceduresInDatabase")
+	defer span.End()
+
 	q := getProceduresInDatabaseQuery(databaseName)
 
-	return handleDbEntities(repo, q, func() any {
+	return handleDbEntities(ctx, repo, q, func() any {
 		return &ProcedureEntity{}
 	}, func(entity any) error {
 		return showProceduresHandler(entity, handleEntity)
 	})
 }
 
-func (repo *SnowflakeRepository) GetTablesInDatabase(databaseName string, schemaName string, handleEntity EntityHandler) error {
+// GetObjectsInDatabase lists the objects of the given kind in all schemas of the database, using the plural Snowflake name of the kind (e.g. STAGES)
+func (repo *SnowflakeRepository) GetObjectsInDatabase(ctx context.Context, objectKind string, databaseName string, handleEntity EntityHandler) error {
+	ctx, span := startSpan(ctx, "SnowflakeRepository.GetObjectsInDatabase")
+	defer span.End()
+
+	q := fmt.Sprintf("SHOW %s IN DATABASE %s", objectKind, common.FormatQuery("%s", databaseName))
+
+	return handleDbEntities(ctx, repo, q, func() any {
+		return &SchemaObjectEntity{}
+	}, handleEntity)
+}
+
+func (repo *SnowflakeRepository) GetTablesInDatabase(ctx context.Context, databaseName string, schemaName string, handleEntity EntityHandler) error {
+	ctx, span := startSpan(ctx, "SnowflakeRepository.GetTablesInDatabase")
+	defer span.End()
+
 	q := getTablesInDatabaseQuery(databaseName, schemaName)
 
-	return handleDbEntities(repo, q, func() interface{} {
+	return handleDbEntities(ctx, repo, q, func() interface{} {
 		return &TableEntity{}
 	}, handleEntity)
 }
 
-func (repo *SnowflakeRepository) GetColumnsInDatabase(databaseName string, handleEntity EntityHandler) error {
+func (repo *SnowflakeRepository) GetColumnsInDatabase(ctx context.Context, databaseName string, handleEntity EntityHandler) error {
+	ctx, span := startSpan(ctx, "SnowflakeRepository.GetColumnsInDatabase")
+	defer span.End()
+
 	q := getColumnsInDatabaseQuery(databaseName)
 
-	return handleDbEntities(repo, q, func() interface{} {
+	return handleDbEntities(ctx, repo, q, func() interface{} {
 		return &ColumnEntity{}
 	}, handleEntity)
 }
 
-func (repo *SnowflakeRepository) CommentAccountRoleIfExists(comment, objectName string) error {
+func (repo *SnowflakeRepository) CommentAccountRoleIfExists(ctx context.Context, comment, objectName string) error {
+	ctx, span := startSpan(ctx, "SnowflakeRepository.CommentAccountRoleIfExists")
+	defer span.End()
+
 	q := fmt.Sprintf(`COMMENT IF EXISTS ON ROLE %s IS '%s'`, common.FormatQuery("%s", objectName), strings.Replace(comment, "'", "", -1))
-	_, _, err := repo.query(q)
+	_, _, err := repo.query(ctx, q)
 
 	if err != nil {
 		Logger.Warn(fmt.Sprintf("unable to update comment on role %s, possibly because not owning it. Ignoring: %s ", objectName, err.Error()))
@@ -1480,9 +1864,12 @@ func (repo *SnowflakeRepository) CommentAccountRoleIfExists(comment, objectName
 
 	return nil
 }
-func (repo *SnowflakeRepository) CommentDatabaseRoleIfExists(comment, database, roleName string) error {
+func (repo *SnowflakeRepository) CommentDatabaseRoleIfExists(ctx context.Context, comment, database, roleName string) error {
+	ctx, span := startSpan(ctx, "SnowflakeRepository.CommentDatabaseRoleIfExists")
+	defer span.End()
+
 	q := fmt.Sprintf(`COMMENT IF EXISTS ON DATABASE ROLE %s IS '%s'`, common.FormatQuery("%s.%s", database, roleName), strings.Replace(comment, "'", "", -1))
-	_, _, err := repo.query(q)
+	_, _, err := repo.query(ctx, q)
 
 	if err != nil {
 		Logger.Warn(fmt.Sprintf("unable to update comment on database role %s.%s, possibly because not owning it. Ignoring: %s ", database, roleName, err.Error()))
@@ -1491,10 +1878,13 @@ func (repo *SnowflakeRepository) CommentDatabaseRoleIfExists(comment, database,
 	return nil
 }
 
-func (repo *SnowflakeRepository) CreateShare(shareName string) (err error) {
+func (repo *SnowflakeRepository) CreateShare(ctx context.Context, shareName string) (err error) {
+	ctx, span := startSpan(ctx, "SnowflakeRepository.CreateShare")
+	defer span.End()
+
 	q := common.FormatQuery("CREATE SHARE IF NOT EXISTS %s", shareName)
 
-	_, _, err = repo.query(q)
+	_, _, err = repo.query(ctx, q)
 	if err != nil {
 		return err
 	}
@@ -1502,10 +1892,13 @@ func (repo *SnowflakeRepository) CreateShare(shareName string) (err error) {
 	return nil
 }
 
-func (repo *SnowflakeRepository) SetShareAccounts(shareName string, accounts []string) (err error) {
+func (repo *SnowflakeRepository) SetShareAccounts(ctx context.Context, shareName string, accounts []string) (err error) {
+	ctx, span := startSpan(ctx, "SnowflakeRepository.SetShareAccounts")
+	defer span.End()
+
 	q := common.FormatQuery("ALTER SHARE %s SET ACCOUNTS=%s", shareName, strings.Join(accounts, ","))
 
-	_, _, err = repo.query(q)
+	_, _, err = repo.query(ctx, q)
 	if err != nil {
 		return err
 	}
@@ -1513,17 +1906,23 @@ func (repo *SnowflakeRepository) SetShareAccounts(shareName string, accounts []s
 	return nil
 }
 
-func (repo *SnowflakeRepository) DropShare(shareName string) (err error) {
+func (repo *SnowflakeRepository) DropShare(ctx context.Context, shareName string) (err error) {
+	ctx, span := startSpan(ctx, "SnowflakeRepository.DropShare")
+	defer span.End()
+
 	q := common.FormatQuery("DROP SHARE %s", shareName)
-	_, _, err = repo.query(q)
+	_, _, err = repo.query(ctx, q)
 
 	return err
 }
 
-func (repo *SnowflakeRepository) CreateMaskPolicy(databaseName string, schema string, maskName string, columnsFullName []string, maskType *string, beneficiaries *MaskingBeneficiaries) (err error) {
+func (repo *SnowflakeRepository) CreateMaskPolicy(ctx context.Context, databaseName string, schema string, maskName string, columnsFullName []string, maskType *string, beneficiaries *MaskingBeneficiaries) (err error) {
+	ctx, span := startSpan(ctx, "SnowflakeRepository.CreateMaskPolicy")
+	defer span.End()
+
 	// Ensure we have permission to create masks
 	if repo.role != AccountAdminRole {
-		err = repo.ExecuteGrantOnAccountRole("CREATE MASKING POLICY", common.FormatQuery("SCHEMA %s.%s", databaseName, schema), repo.role, true)
+		err = repo.ExecuteGrantOnAccountRole(ctx, "CREATE MASKING POLICY", common.FormatQuery("SCHEMA %s.%s", databaseName, schema), repo.role, true)
 		if err != nil {
 			return err
 		}
@@ -1534,7 +1933,7 @@ func (repo *SnowflakeRepository) CreateMaskPolicy(databaseName string, schema st
 
 	foundDataTypeCounter := 0
 
-	err = repo.getColumnInformation(databaseName, columnsFullName, func(columnName string, dataType string) error {
+	err = repo.getColumnInformation(ctx, databaseName, columnsFullName, func(columnName string, dataType string) error {
 		dataObjectTypeMap[dataType] = append(dataObjectTypeMap[dataType], columnName)
 		columnTypes.Add(dataType)
 
@@ -1554,7 +1953,7 @@ func (repo *SnowflakeRepository) CreateMaskPolicy(databaseName string, schema st
 		return errors.New("unable to load column details")
 	}
 
-	tx, err := repo.conn.Begin()
+	tx, err := repo.beginTransaction(ctx)
 	if err != nil {
 		return err
 	}
@@ -1596,7 +1995,7 @@ func (repo *SnowflakeRepository) CreateMaskPolicy(databaseName string, schema st
 
 			tableDetails, ok := tableDetailCache[tableName]
 			if !ok {
-				tableDetail, err2 := repo.getTableDetails(fullnameSplit[0], fullnameSplit[1], fullnameSplit[2])
+				tableDetail, err2 := repo.getTableDetails(ctx, fullnameSplit[0], fullnameSplit[1], fullnameSplit[2])
 				if err2 != nil {
 					return fmt.Errorf("get table %s: %w", tableName, err2)
 				} else if tableDetail == nil {
@@ -1625,12 +2024,15 @@ func (repo *SnowflakeRepository) CreateMaskPolicy(databaseName string, schema st
 	return nil
 }
 
-func (repo *SnowflakeRepository) GetIntegrations() ([]DbEntity, error) {
+func (repo *SnowflakeRepository) GetIntegrations(ctx context.Context) ([]DbEntity, error) {
+	ctx, span := startSpan(ctx, "SnowflakeRepository.GetIntegrations")
+	defer span.End()
+
 	q := "SHOW INTEGRATIONS"
 
 	var integrationEntities []DbEntity
 
-	err := handleDbEntities(repo, q, func() interface{} {
+	err := handleDbEntities(ctx, repo, q, func() interface{} {
 		return &DbEntity{}
 	}, func(entity interface{}) error {
 		pEntry := entity.(*DbEntity)
@@ -1647,12 +2049,15 @@ func (repo *SnowflakeRepository) GetIntegrations() ([]DbEntity, error) {
 	return integrationEntities, nil
 }
 
-func (repo *SnowflakeRepository) GetPoliciesLike(policy string, like string) ([]PolicyEntity, error) {
+func (repo *SnowflakeRepository) GetPoliciesLike(ctx context.Context, policy string, like string) ([]PolicyEntity, error) {
+	ctx, span := startSpan(ctx, "SnowflakeRepository.GetPoliciesLike")
+	defer span.End()
+
 	q := fmt.Sprintf("SHOW %s POLICIES LIKE '%s';", common.FormatQuery("%s", policy), strings.ToUpper(like))
 
 	var policyEntities []PolicyEntity
 
-	err := handleDbEntities(repo, q, func() interface{} {
+	err := handleDbEntities(ctx, repo, q, func() interface{} {
 		return &PolicyEntity{}
 	}, func(entity interface{}) error {
 		pEntry := entity.(*PolicyEntity)
@@ -1669,8 +2074,11 @@ func (repo *SnowflakeRepository) GetPoliciesLike(policy string, like string) ([]
 	return policyEntities, nil
 }
 
-func (repo *SnowflakeRepository) DropMaskingPolicy(databaseName string, schema string, maskName string) (err error) {
-	policies, err := repo.GetPoliciesLike("MASKING", fmt.Sprintf("%s_%s", maskName, "%"))
+func (repo *SnowflakeRepository) DropMaskingPolicy(ctx context.Context, databaseName string, schema string, maskName string) (err error) {
+	ctx, span := startSpan(ctx, "SnowflakeRepository.DropMaskingPolicy")
+	defer span.End()
+
+	policies, err := repo.GetPoliciesLike(ctx, "MASKING", fmt.Sprintf("%s_%s", maskName, "%"))
 	if err != nil {
 		return err
 	}
@@ -1680,7 +2088,7 @@ func (repo *SnowflakeRepository) DropMaskingPolicy(databaseName string, schema s
 	var policyEntries []PolicyReferenceEntity
 
 	for _, policy := range policies {
-		entities, err2 := repo.GetPolicyReferences(databaseName, schema, policy.Name)
+		entities, err2 := repo.GetPolicyReferences(ctx, databaseName, schema, policy.Name)
 		if err2 != nil {
 			return err2
 		}
@@ -1690,7 +2098,7 @@ func (repo *SnowflakeRepository) DropMaskingPolicy(databaseName string, schema s
 
 	Logger.Info(fmt.Sprintf("Found %d policy references for mask %s.%s.%s", len(policyEntries), databaseName, schema, maskName))
 
-	tx, err := repo.conn.Begin()
+	tx, err := repo.beginTransaction(ctx)
 	if err != nil {
 		return err
 	}
@@ -1709,9 +2117,15 @@ func (repo *SnowflakeRepository) DropMaskingPolicy(databaseName string, schema s
 
 		tableFullName := common.FormatQuery("%s.%s.%s", policyEntries[i].REF_DATABASE_NAME, policyEntries[i].REF_SCHEMA_NAME, policyEntries[i].REF_ENTITY_NAME)
 
-		if repo.role != AccountAdminRole {
+		tableType := "TABLE"
+		if policyEntries[i].IsDynamicTable() {
+			tableType = "DYNAMIC TABLE"
+		}
+
+		// Dynamic tables have no REFERENCES privilege
+		if repo.role != AccountAdminRole && !policyEntries[i].IsDynamicTable() {
 			if !referencedTables.Contains(tableFullName) {
-				err = repo.ExecuteGrantOnAccountRole("REFERENCES", fmt.Sprintf("TABLE %s", tableFullName), repo.role, true)
+				err = repo.ExecuteGrantOnAccountRole(ctx, "REFERENCES", fmt.Sprintf("TABLE %s", tableFullName), repo.role, true)
 				if err != nil {
 					return fmt.Errorf("enable reference on table: \"%s.%s.%s\": %w", databaseName, schema, policyEntries[i].REF_ENTITY_NAME, err)
 				}
@@ -1720,7 +2134,7 @@ func (repo *SnowflakeRepository) DropMaskingPolicy(databaseName string, schema s
 			}
 		}
 
-		query := fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %q UNSET MASKING POLICY", tableFullName, policyEntries[i].REF_COLUMN_NAME.String)
+		query := fmt.Sprintf("ALTER %s %s ALTER COLUMN %q UNSET MASKING POLICY", tableType, tableFullName, policyEntries[i].REF_COLUMN_NAME.String)
 		Logger.Debug(fmt.Sprintf("Unset masking policy %s from column %s in table %s: %s", policyEntries[i].POLICY_NAME, policyEntries[i].REF_COLUMN_NAME.String, policyEntries[i].REF_ENTITY_NAME, query))
 
 		_, err = tx.Exec(query)
@@ -1741,7 +2155,10 @@ func (repo *SnowflakeRepository) DropMaskingPolicy(databaseName string, schema s
 	return nil
 }
 
-func (repo *SnowflakeRepository) UpdateFilter(databaseName string, schema string, tableName string, filterName string, argumentNames []string, expression string) error {
+func (repo *SnowflakeRepository) UpdateFilter(ctx context.Context, databaseName string, schema string, tableName string, filterName string, argumentNames []string, expression string) error {
+	ctx, span := startSpan(ctx, "SnowflakeRepository.UpdateFilter")
+	defer span.End()
+
 	columnNames := make([]string, 0, len(argumentNames))
 
 	for _, argumentName := range argumentNames {
@@ -1750,7 +2167,7 @@ func (repo *SnowflakeRepository) UpdateFilter(databaseName string, schema string
 
 	functionArguments := make([]string, 0, len(argumentNames))
 
-	err := repo.getColumnInformation(databaseName, columnNames, func(columnName string, dataType string) error {
+	err := repo.getColumnInformation(ctx, databaseName, columnNames, func(columnName string, dataType string) error {
 		argumentName := strings.Split(columnName, ".")
 		functionArguments = append(functionArguments, fmt.Sprintf("%s %s", argumentName[3], dataType))
 
@@ -1764,11 +2181,17 @@ func (repo *SnowflakeRepository) UpdateFilter(databaseName string, schema string
 		return fmt.Errorf("number of function arguments (%d) does not match number of argument names (%d)", len(functionArguments), len(argumentNames))
 	}
 
-	existingPolicy, err := repo.getRowFilterForTableIfExists(databaseName, schema, tableName)
+	existingPolicy, err := repo.getRowFilterForTableIfExists(ctx, databaseName, schema, tableName)
 	if err != nil {
 		return fmt.Errorf("load possible existing row filter: %w", err)
 	}
 
+	if existingPolicy != nil && strings.EqualFold(*existingPolicy, filterName) {
+		Logger.Info(fmt.Sprintf("Row access policy %s on table %s.%s.%s is up-to-date", filterName, databaseName, schema, tableName))
+
+		return nil
+	}
+
 	var dropOldPolicy string
 	var deleteOldPolicy *string
 
@@ -1778,23 +2201,28 @@ func (repo *SnowflakeRepository) UpdateFilter(databaseName string, schema string
 	}
 
 	if repo.role != AccountAdminRole {
-		err = repo.ExecuteGrantOnAccountRole("CREATE ROW ACCESS POLICY", common.FormatQuery("SCHEMA %s.%s", databaseName, schema), repo.role, true)
+		err = repo.ExecuteGrantOnAccountRole(ctx, "CREATE ROW ACCESS POLICY", common.FormatQuery("SCHEMA %s.%s", databaseName, schema), repo.role, true)
 		if err != nil {
 			return err
 		}
 	}
 
+	tableType, err := repo.getAlterTableType(ctx, databaseName, schema, tableName)
+	if err != nil {
+		return err
+	}
+
 	q := make([]string, 0, 3)
 	q = append(q, fmt.Sprintf(`CREATE ROW ACCESS POLICY %s AS (%s) returns boolean ->
 			%s;`, common.FormatQuery("%s.%s.%s", databaseName, schema, filterName), strings.Join(functionArguments, ", "), expression),
-		fmt.Sprintf("ALTER TABLE %[1]s %[2]s ADD ROW ACCESS POLICY %[3]s on (%[4]s);", common.FormatQuery("%s.%s.%s", databaseName, schema, tableName), dropOldPolicy,
+		fmt.Sprintf("ALTER %[1]s %[2]s %[3]s ADD ROW ACCESS POLICY %[4]s on (%[5]s);", tableType, common.FormatQuery("%s.%s.%s", databaseName, schema, tableName), dropOldPolicy,
 			common.FormatQuery("%s.%s.%s", databaseName, schema, filterName), strings.Join(argumentNames, ", ")))
 
 	if deleteOldPolicy != nil {
 		q = append(q, *deleteOldPolicy)
 	}
 
-	err = repo.execute(q...)
+	err = repo.execute(ctx, q...)
 	if err != nil {
 		return err
 	}
@@ -1802,8 +2230,11 @@ func (repo *SnowflakeRepository) UpdateFilter(databaseName string, schema string
 	return nil
 }
 
-func (repo *SnowflakeRepository) DropFilter(databaseName string, schema string, tableName string, filterName string) error {
-	existingPolicy, err := repo.getRowFilterForTableIfExists(databaseName, schema, tableName)
+func (repo *SnowflakeRepository) DropFilter(ctx context.Context, databaseName string, schema string, tableName string, filterName string) error {
+	ctx, span := startSpan(ctx, "SnowflakeRepository.DropFilter")
+	defer span.End()
+
+	existingPolicy, err := repo.getRowFilterForTableIfExists(ctx, databaseName, schema, tableName)
 	if err != nil {
 		return fmt.Errorf("load possible existing row filter: %w", err)
 	}
@@ -1811,20 +2242,28 @@ func (repo *SnowflakeRepository) DropFilter(databaseName string, schema string,
 	if existingPolicy != nil {
 		tableFullName := common.FormatQuery("%s.%s.%s", databaseName, schema, tableName)
 
-		if repo.role != AccountAdminRole {
-			err = repo.ExecuteGrantOnAccountRole("REFERENCES", fmt.Sprintf("TABLE %s", tableFullName), repo.role, true)
+		var tableType string
+
+		tableType, err = repo.getAlterTableType(ctx, databaseName, schema, tableName)
+		if err != nil {
+			return err
+		}
+
+		// Dynamic tables have no REFERENCES privilege
+		if repo.role != AccountAdminRole && tableType != "DYNAMIC TABLE" {
+			err = repo.ExecuteGrantOnAccountRole(ctx, "REFERENCES", fmt.Sprintf("TABLE %s", tableFullName), repo.role, true)
 			if err != nil {
 				return fmt.Errorf("enable reference on table: \"%s.%s.%s\": %w", databaseName, schema, tableFullName, err)
 			}
 		}
 
-		err = repo.execute(common.FormatQuery("ALTER TABLE %[1]s.%[2]s.%[3]s DROP ROW ACCESS POLICY %[1]s.%[2]s.%[4]s;", databaseName, schema, tableName, *existingPolicy))
+		err = repo.execute(ctx, fmt.Sprintf("ALTER %s %s;", tableType, common.FormatQuery("%[1]s.%[2]s.%[3]s DROP ROW ACCESS POLICY %[1]s.%[2]s.%[4]s", databaseName, schema, tableName, *existingPolicy)))
 		if err != nil {
 			return err
 		}
 	}
 
-	err = repo.execute(
+	err = repo.execute(ctx,
 		common.FormatQuery(`DROP ROW ACCESS POLICY IF EXISTS %s.%s.%s;`, databaseName, schema, filterName),
 	)
 	if err != nil {
@@ -1834,15 +2273,15 @@ func (repo *SnowflakeRepository) DropFilter(databaseName string, schema string,
 	return nil
 }
 
-func (repo *SnowflakeRepository) getRowFilterForTableIfExists(databaseName string, schema string, tableName string) (*string, error) {
-	_, _, err := repo.query(common.FormatQuery("USE DATABASE %s;", databaseName))
+func (repo *SnowflakeRepository) getRowFilterForTableIfExists(ctx context.Context, databaseName string, schema string, tableName string) (*string, error) {
+	_, _, err := repo.query(ctx, common.FormatQuery("USE DATABASE %s;", databaseName))
 	if err != nil {
 		return nil, fmt.Errorf("connect to database %q: %w", databaseName, err)
 	}
 
 	q := common.FormatQuery(`select POLICY_NAME from table(%s.information_schema.policy_references(REF_ENTITY_NAME => '%s.%s.%s', REF_ENTITY_DOMAIN => 'table')) WHERE POLICY_KIND = 'ROW_ACCESS_POLICY'`, databaseName, databaseName, schema, tableName)
 
-	rows, _, err := repo.query(q)
+	rows, _, err := repo.query(ctx, q)
 	if err != nil {
 		return nil, fmt.Errorf("query error: %w", err)
 	}
@@ -1861,12 +2300,12 @@ func (repo *SnowflakeRepository) getRowFilterForTableIfExists(databaseName strin
 	return &policyName, nil
 }
 
-func (repo *SnowflakeRepository) getDbEntities(query string) ([]DbEntity, error) {
-	return getDbRows[DbEntity](repo, query)
+func (repo *SnowflakeRepository) getDbEntities(ctx context.Context, query string) ([]DbEntity, error) {
+	return getDbRows[DbEntity](ctx, repo, query)
 }
 
-func getDbRows[T any](repo *SnowflakeRepository, query string) ([]T, error) {
-	rows, _, err := repo.query(query)
+func getDbRows[T any](ctx context.Context, repo *SnowflakeRepository, query string) ([]T, error) {
+	rows, _, err := repo.query(ctx, query)
 	if err != nil {
 		return nil, err
 	}
@@ -1888,36 +2327,89 @@ func getDbRows[T any](repo *SnowflakeRepository, query string) ([]T, error) {
 
 func (repo *SnowflakeRepository) queryContext(ctx context.Context, query string, args ...any) (*sql.Rows, time.Duration, error) {
 	Logger.Debug(fmt.Sprintf("Sending query: %s", query))
-	startQuery := time.Now()
-	result, err := repo.conn.QueryContext(ctx, query, args...)
-	sec := time.Since(startQuery).Round(time.Millisecond)
-	repo.addToQueryTime(sec)
 
-	Logger.Debug(fmt.Sprintf("Query took %s", time.Since(startQuery)))
+	var result *sql.Rows
+	var sec time.Duration
+
+	err := repo.withRetry(ctx, []string{query}, func() error {
+		startQuery := time.Now()
+
+		queryCtx, span := startStatementSpan(ctx, []string{query})
+		queryCtx, done := repo.cancelOnContextDone(queryCtx)
+
+		var queryErr error
+		result, queryErr = repo.conn.QueryContext(queryCtx, query, args...)
+		sec = time.Since(startQuery).Round(time.Millisecond)
+		queryId := done()
+		repo.recordExecution(queryCtx, []string{query}, sec, queryId, queryErr)
+		endStatementSpan(span, queryId, queryErr)
+
+		return queryErr
+	})
+
+	Logger.Debug(fmt.Sprintf("Query took %s", sec))
 
 	return result, sec, err
 }
 
-func (repo *SnowflakeRepository) query(query string) (*sql.Rows, time.Duration, error) { //nolint:unparam
+func (repo *SnowflakeRepository) query(ctx context.Context, query string) (*sql.Rows, time.Duration, error) { //nolint:unparam
+	if repo.planned(ctx, query) {
+		return nil, 0, nil
+	}
+
 	Logger.Debug(fmt.Sprintf("Sending query: %s", query))
-	startQuery := time.Now()
-	result, err := QuerySnowflake(repo.conn, query)
-	sec := time.Since(startQuery).Round(time.Millisecond)
 
-	repo.addToQueryTime(sec)
+	var result *sql.Rows
+	var sec time.Duration
+
+	err := repo.withRetry(ctx, []string{query}, func() error {
+		queryCtx, span := startStatementSpan(ctx, []string{query})
+		queryCtx, done := repo.cancelOnContextDone(queryCtx)
+
+		startQuery := time.Now()
 
-	Logger.Debug(fmt.Sprintf("Query took %s", time.Since(startQuery)))
+		var queryErr error
+		result, queryErr = QuerySnowflakeContext(queryCtx, repo.conn, query)
+		sec = time.Since(startQuery).Round(time.Millisecond)
+		queryId := done()
+		repo.recordExecution(queryCtx, []string{query}, sec, queryId, queryErr)
+		endStatementSpan(span, queryId, queryErr)
+
+		return queryErr
+	})
+
+	Logger.Debug(fmt.Sprintf("Query took %s", sec))
 
 	return result, sec, err
 }
 
+// withRetry executes fn, retrying it on transient errors if all statements are idempotent
+func (repo *SnowflakeRepository) withRetry(ctx context.Context, statements []string, fn func() error) error {
+	if repo.retryPolicy == nil {
+		return fn()
+	}
+
+	return repo.retryPolicy.run(ctx, areIdempotentStatements(statements), fn)
+}
+
 func (repo *SnowflakeRepository) addToQueryTime(duration time.Duration) {
 	repo.queryTimeLock.Lock()
 	repo.queryTime += duration
 	repo.queryTimeLock.Unlock()
 }
 
-func (repo *SnowflakeRepository) execute(query ...string) error {
+// recordExecution registers the duration and outcome of a single execution of the statements
+func (repo *SnowflakeRepository) recordExecution(ctx context.Context, statements []string, duration time.Duration, queryId string, err error) {
+	repo.addToQueryTime(duration)
+	statementMetricsCollector.record(repo.phase, statements, duration, err)
+	repo.audit(ctx, statements, duration, queryId, err)
+}
+
+func (repo *SnowflakeRepository) execute(ctx context.Context, query ...string) error {
+	if repo.planned(ctx, query...) {
+		return nil
+	}
+
 	Logger.Debug(fmt.Sprintf("Sending query execution: %v", query))
 
 	for i := range query {
@@ -1926,22 +2418,29 @@ func (repo *SnowflakeRepository) execute(query ...string) error {
 		}
 	}
 
-	ctx, err := sf.WithMultiStatement(context.Background(), len(query))
+	multiContext, err := sf.WithMultiStatement(ctx, len(query))
 	if err != nil {
 		return err
 	}
 
-	startQuery := time.Now()
-	err = ExecuteSnowflake(ctx, repo.conn, strings.Join(query, "\n"))
-	sec := time.Since(startQuery).Round(time.Millisecond)
-	repo.addToQueryTime(sec)
+	return repo.withRetry(ctx, query, func() error {
+		execCtx, span := startStatementSpan(multiContext, query)
+		execCtx, done := repo.cancelOnContextDone(execCtx)
 
-	return err
+		startQuery := time.Now()
+		execErr := ExecuteSnowflake(execCtx, repo.conn, strings.Join(query, "\n"))
+		sec := time.Since(startQuery).Round(time.Millisecond)
+		queryId := done()
+		repo.recordExecution(execCtx, query, sec, queryId, execErr)
+		endStatementSpan(span, queryId, execErr)
+
+		return execErr
+	})
 }
 
-func (repo *SnowflakeRepository) execMultiStatements(ctx context.Context) (chan string, chan error) {
-	maxStatementsPerTransaction := 200
+const maxStatementsPerTransaction = 200
 
+func (repo *SnowflakeRepository) execMultiStatements(ctx context.Context) (chan string, chan error) {
 	statementChannel := make(chan string, maxStatementsPerTransaction)
 	done := make(chan error)
 
@@ -1990,15 +2489,35 @@ func (repo *SnowflakeRepository) execMultiStatements(ctx context.Context) (chan
 }
 
 func (repo *SnowflakeRepository) execContext(ctx context.Context, statements []string) (time.Duration, error) {
+	if repo.planned(ctx, statements...) {
+		return 0, nil
+	}
+
+	return repo.execStatements(ctx, statements)
+}
+
+// execStatements executes the statements in a single request, also if the repository is in dry-run mode
+func (repo *SnowflakeRepository) execStatements(ctx context.Context, statements []string) (time.Duration, error) {
 	multiContext, _ := sf.WithMultiStatement(ctx, len(statements))
 
 	query := strings.Join(statements, "; ")
 	Logger.Debug(fmt.Sprintf("Sending queries: %s", query))
 
-	startQuery := time.Now()
-	_, err := repo.conn.ExecContext(multiContext, query)
-	sec := time.Since(startQuery).Round(time.Millisecond)
-	repo.addToQueryTime(sec)
+	var sec time.Duration
+
+	err := repo.withRetry(ctx, statements, func() error {
+		execCtx, span := startStatementSpan(multiContext, statements)
+		execCtx, done := repo.cancelOnContextDone(execCtx)
+
+		startQuery := time.Now()
+		_, execErr := repo.conn.ExecContext(execCtx, query)
+		sec = time.Since(startQuery).Round(time.Millisecond)
+		queryId := done()
+		repo.recordExecution(execCtx, statements, sec, queryId, execErr)
+		endStatementSpan(span, queryId, execErr)
+
+		return execErr
+	})
 
 	if err != nil {
 		return sec, fmt.Errorf("error while executing queries: %s: %w", query, err)
@@ -2007,7 +2526,51 @@ func (repo *SnowflakeRepository) execContext(ctx context.Context, statements []s
 	return sec, nil
 }
 
-func (repo *SnowflakeRepository) getColumnInformation(databaseName string, columnFullNames []string, fn func(columnName string, dataType string) error) error {
+// ExecutePlannedStatements executes the statements of a reviewed execution plan in batches, in the given order.
+// The statements are executed even if the repository is in dry-run mode.
+func (repo *SnowflakeRepository) ExecutePlannedStatements(ctx context.Context, statements ...string) error {
+	ctx, span := startSpan(ctx, "SnowflakeRepository.ExecutePlannedStatements")
+	defer span.End()
+
+	for start := 0; start < len(statements); start += maxStatementsPerTransaction {
+		_, err := repo.execStatements(ctx, statements[start:min(start+maxStatementsPerTransaction, len(statements))])
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ExecutePlannedTransaction executes the statements of a reviewed execution plan in a single transaction, in the given order.
+// The statements are executed even if the repository is in dry-run mode.
+func (repo *SnowflakeRepository) ExecutePlannedTransaction(ctx context.Context, statements ...string) (err error) {
+	ctx, span := startSpan(ctx, "SnowflakeRepository.ExecutePlannedTransaction")
+	defer span.End()
+
+	tx, err := repo.beginSnowflakeTransaction(ctx)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+
+	for _, statement := range statements {
+		_, err = tx.Exec(statement)
+		if err != nil {
+			tx.Rollback() //nolint
+
+			return fmt.Errorf("error while executing query in transaction: %s: %w", statement, err)
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func (repo *SnowflakeRepository) getColumnInformation(ctx context.Context, databaseName string, columnFullNames []string, fn func(columnName string, dataType string) error) error {
 	if len(columnFullNames) == 0 {
 		return nil
 	}
@@ -2019,7 +2582,7 @@ func (repo *SnowflakeRepository) getColumnInformation(databaseName string, colum
 
 	q := fmt.Sprintf("SELECT * FROM %s.INFORMATION_SCHEMA.COLUMNS WHERE CONCAT_WS('.', TABLE_CATALOG, TABLE_SCHEMA, TABLE_NAME, COLUMN_NAME) IN (%s)", databaseName, strings.Join(columnLiterats, ", "))
 
-	err := handleDbEntities(repo, q, func() interface{} { return &ColumnEntity{} }, func(entity interface{}) error {
+	err := handleDbEntities(ctx, repo, q, func() interface{} { return &ColumnEntity{} }, func(entity interface{}) error {
 		columnEntity := entity.(*ColumnEntity)
 		fullName := strings.Join([]string{columnEntity.Database, columnEntity.Schema, columnEntity.Table, columnEntity.Name}, ".")
 
@@ -2032,10 +2595,10 @@ func (repo *SnowflakeRepository) getColumnInformation(databaseName string, colum
 	return nil
 }
 
-func (repo *SnowflakeRepository) getTableDetails(dbName string, schemaName string, tableNAme string) (*TableEntity, error) {
+func (repo *SnowflakeRepository) getTableDetails(ctx context.Context, dbName string, schemaName string, tableNAme string) (*TableEntity, error) {
 	q := fmt.Sprintf(`SELECT * FROM %s.INFORMATION_SCHEMA.TABLES WHERE TABLE_NAME = '%s' AND TABLE_SCHEMA = '%s'`, common.FormatQuery("%s", dbName), tableNAme, schemaName)
 
-	results, err := getDbRows[TableEntity](repo, q)
+	results, err := getDbRows[TableEntity](ctx, repo, q)
 	if err != nil {
 		return nil, fmt.Errorf("fetching table details: %s", err.Error())
 	} else if len(results) > 1 {
@@ -2047,8 +2610,27 @@ func (repo *SnowflakeRepository) getTableDetails(dbName string, schemaName strin
 	return &results[0], nil
 }
 
-func handleDbEntities(repo *SnowflakeRepository, query string, createEntity EntityCreator, handleEntity EntityHandler) error {
-	rows, _, err := repo.query(query)
+// getAlterTableType returns the object type to use in ALTER statements on the table, e.g. DYNAMIC TABLE for a dynamic table.
+// TABLE is returned if the table is not found.
+func (repo *SnowflakeRepository) getAlterTableType(ctx context.Context, dbName string, schemaName string, tableName string) (string, error) {
+	tableDetails, err := repo.getTableDetails(ctx, dbName, schemaName, tableName)
+	if err != nil {
+		return "", fmt.Errorf("get table %s.%s.%s: %w", dbName, schemaName, tableName, err)
+	}
+
+	if tableDetails == nil {
+		return "TABLE", nil
+	}
+
+	if tableType, found := raitoTypeToSnowflakeGrantType[convertSnowflakeTableTypeToRaito(tableDetails)]; found {
+		return tableType, nil
+	}
+
+	return "TABLE", nil
+}
+
+func handleDbEntities(ctx context.Context, repo *SnowflakeRepository, query string, createEntity EntityCreator, handleEntity EntityHandler) error {
+	rows, _, err := repo.query(ctx, query)
 	if err != nil {
 		return err
 	}
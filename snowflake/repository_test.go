@@ -1,6 +1,8 @@
 package snowflake
 
 import (
+	"context"
+	"database/sql"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -70,3 +72,67 @@ func TestParseFunctionOrProcedureSignature(t *testing.T) {
 		})
 	}
 }
+
+func TestSnowflakeRepository_FilterOnDynamicTable(t *testing.T) {
+	newRepo := func() (*SnowflakeRepository, *ExecutionPlan) {
+		player := newFixturePlayer(&FixtureArchive{Interactions: []FixtureInteraction{
+			{Syncer: SyncPhaseAccessToTarget, Statement: "USE DATABASE DB;"},
+			{
+				Syncer:    SyncPhaseAccessToTarget,
+				Statement: "SELECT * FROM DB.INFORMATION_SCHEMA.COLUMNS WHERE CONCAT_WS('.', TABLE_CATALOG, TABLE_SCHEMA, TABLE_NAME, COLUMN_NAME) IN ('DB.S.DT.STATE')",
+				Columns:   []string{"TABLE_CATALOG", "TABLE_SCHEMA", "TABLE_NAME", "COLUMN_NAME", "DATA_TYPE"},
+				Rows:      [][]FixtureValue{stringFixtureValues("DB", "S", "DT", "STATE", "TEXT")},
+			},
+			{
+				Syncer:    SyncPhaseAccessToTarget,
+				Statement: "select POLICY_NAME from table(DB.information_schema.policy_references(REF_ENTITY_NAME => 'DB.S.DT', REF_ENTITY_DOMAIN => 'table')) WHERE POLICY_KIND = 'ROW_ACCESS_POLICY'",
+				Columns:   []string{"POLICY_NAME"},
+				Rows:      [][]FixtureValue{stringFixtureValues("OLD_FILTER")},
+			},
+			{
+				Syncer:    SyncPhaseAccessToTarget,
+				Statement: "SELECT * FROM DB.INFORMATION_SCHEMA.TABLES WHERE TABLE_NAME = 'DT' AND TABLE_SCHEMA = 'S'",
+				Columns:   []string{"TABLE_CATALOG", "TABLE_SCHEMA", "TABLE_NAME", "TABLE_TYPE", "IS_DYNAMIC"},
+				Rows:      [][]FixtureValue{stringFixtureValues("DB", "S", "DT", "BASE TABLE", "YES")},
+			},
+		}})
+
+		plan := NewExecutionPlan()
+
+		return &SnowflakeRepository{conn: sql.OpenDB(newReplayConnector(player, SyncPhaseAccessToTarget)), phase: SyncPhaseAccessToTarget, role: AccountAdminRole, plan: plan}, plan
+	}
+
+	t.Run("update", func(t *testing.T) {
+		repo, plan := newRepo()
+		defer repo.Close()
+
+		require.NoError(t, repo.UpdateFilter(context.Background(), "DB", "S", "DT", "FILTER", []string{"STATE"}, "STATE = 'NJ'"))
+
+		require.Len(t, plan.AccessProviderPlans(), 1)
+		statements := plan.AccessProviderPlans()[0].Statements
+		require.Len(t, statements, 3)
+		assert.Equal(t, "ALTER DYNAMIC TABLE DB.S.DT DROP ROW ACCESS POLICY DB.S.OLD_FILTER, ADD ROW ACCESS POLICY DB.S.FILTER on (STATE)", statements[1])
+	})
+
+	t.Run("up-to-date", func(t *testing.T) {
+		repo, plan := newRepo()
+		defer repo.Close()
+
+		require.NoError(t, repo.UpdateFilter(context.Background(), "DB", "S", "DT", "OLD_FILTER", []string{"STATE"}, "STATE = 'NJ'"))
+
+		assert.Empty(t, plan.AccessProviderPlans())
+	})
+
+	t.Run("drop", func(t *testing.T) {
+		repo, plan := newRepo()
+		defer repo.Close()
+
+		require.NoError(t, repo.DropFilter(context.Background(), "DB", "S", "DT", "FILTER"))
+
+		require.Len(t, plan.AccessProviderPlans(), 1)
+		assert.Equal(t, []string{
+			"ALTER DYNAMIC TABLE DB.S.DT DROP ROW ACCESS POLICY DB.S.OLD_FILTER",
+			"DROP ROW ACCESS POLICY IF EXISTS DB.S.FILTER",
+		}, plan.AccessProviderPlans()[0].Statements)
+	})
+}
@@ -0,0 +1,175 @@
+package snowflake
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	sf "github.com/snowflakedb/gosnowflake"
+)
+
+const (
+	defaultRetryMaxAttempts    = 3
+	defaultRetryInitialBackoff = time.Second
+	retryMaxBackoff            = 30 * time.Second
+)
+
+// Snowflake and gosnowflake error codes that indicate a transient issue
+var retryableSnowflakeErrorCodes = map[int]struct{}{
+	604:    {}, // Statement canceled by the system (e.g. during a warehouse resize or suspension)
+	608:    {}, // Warehouse suspended or not ready
+	390503: {}, // Service temporarily unavailable
+	261000: {}, // Driver: failed to post the query (HTTP errors like 503)
+	261001: {}, // Driver: failed to renew the session
+	261008: {}, // Driver: failed to parse the response
+	261010: {}, // Driver: failed to send the heartbeat
+}
+
+var retryableErrorMessages = regexp.MustCompile(`(?i)(connection reset|broken pipe|503 service unavailable|502 bad gateway|504 gateway timeout|429 too many requests|warehouse .* (is|has been) suspended|statement .* queued)`)
+
+// statementTimeoutErrorCode is returned both when a statement waited too long in the queue of the warehouse and when its execution took too long.
+// Only the former is retried, as executing the same statement again would most likely exceed the timeout again.
+const statementTimeoutErrorCode = 630
+
+var queuedTimeoutMessage = regexp.MustCompile(`(?i)queued timeout`)
+
+// retryPolicy re-executes idempotent statements that failed with a transient error, using an exponential backoff.
+type retryPolicy struct {
+	maxAttempts    int
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+
+	retries atomic.Int64
+}
+
+func newRetryPolicy(params map[string]string) (*retryPolicy, error) {
+	policy := &retryPolicy{
+		maxAttempts:    defaultRetryMaxAttempts,
+		initialBackoff: defaultRetryInitialBackoff,
+		maxBackoff:     retryMaxBackoff,
+	}
+
+	if v, f := params[SfRetryMaxAttempts]; f && v != "" {
+		maxAttempts, err := strconv.Atoi(v)
+		if err != nil || maxAttempts < 1 {
+			return nil, fmt.Errorf("invalid value %q for %q parameter (must be a positive number)", v, SfRetryMaxAttempts)
+		}
+
+		policy.maxAttempts = maxAttempts
+	}
+
+	if v, f := params[SfRetryInitialBackoff]; f && v != "" {
+		backoff, err := time.ParseDuration(v)
+		if err != nil || backoff <= 0 {
+			return nil, fmt.Errorf("invalid value %q for %q parameter (must be a positive duration, e.g. '2s')", v, SfRetryInitialBackoff)
+		}
+
+		policy.initialBackoff = backoff
+	}
+
+	return policy, nil
+}
+
+// run executes fn and retries it while it returns a retryable error, as long as the statements are idempotent.
+func (p *retryPolicy) run(ctx context.Context, idempotent bool, fn func() error) error {
+	backoff := p.initialBackoff
+
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil || !idempotent || attempt >= p.maxAttempts || !isRetryableError(err) {
+			return err
+		}
+
+		p.retries.Add(1)
+
+		Logger.Warn(fmt.Sprintf("Transient Snowflake error on attempt %d of %d, retrying in %s: %s", attempt, p.maxAttempts, backoff, err.Error()))
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(backoff):
+		}
+
+		backoff = min(2*backoff, p.maxBackoff)
+	}
+}
+
+func (p *retryPolicy) totalRetries() int {
+	return int(p.retries.Load())
+}
+
+func isRetryableError(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var sfErr *sf.SnowflakeError
+	if errors.As(err, &sfErr) {
+		if sfErr.Number == statementTimeoutErrorCode {
+			return queuedTimeoutMessage.MatchString(sfErr.Message)
+		}
+
+		if _, retryable := retryableSnowflakeErrorCodes[sfErr.Number]; retryable {
+			return true
+		}
+
+		return retryableErrorMessages.MatchString(sfErr.Message)
+	}
+
+	if errors.Is(err, driver.ErrBadConn) || errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.EPIPE) {
+		return true
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+
+	return retryableErrorMessages.MatchString(err.Error())
+}
+
+// isIdempotentStatement returns true if executing the statement a second time has the same effect as executing it once.
+// Grants and revokes are idempotent in Snowflake, creates and drops only if they are guarded by IF (NOT) EXISTS or OR REPLACE.
+// Statements referring to LAST_QUERY_ID() are not, as a retry could run in another session or after the failed attempt, which changes the last query.
+func isIdempotentStatement(statement string) bool {
+	normalized := strings.ToUpper(strings.Join(strings.Fields(statement), " "))
+	normalized = strings.TrimSuffix(normalized, ";")
+
+	if strings.Contains(normalized, "LAST_QUERY_ID(") {
+		return false
+	}
+
+	firstWord, _, _ := strings.Cut(normalized, " ")
+
+	switch firstWord {
+	case "SELECT", "SHOW", "DESCRIBE", "DESC", "WITH", "GRANT", "REVOKE", "USE", "COMMENT":
+		return true
+	case "CREATE":
+		return strings.HasPrefix(normalized, "CREATE OR REPLACE ") || strings.Contains(normalized, " IF NOT EXISTS ")
+	case "DROP":
+		return strings.Contains(normalized, " IF EXISTS ")
+	case "ALTER":
+		return !strings.Contains(normalized, " RENAME ") && (strings.Contains(normalized, " SET ") || strings.Contains(normalized, " UNSET "))
+	default:
+		return false
+	}
+}
+
+func areIdempotentStatements(statements []string) bool {
+	for _, statement := range statements {
+		if !isIdempotentStatement(statement) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,253 @@
+package snowflake
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"testing"
+	"time"
+
+	sf "github.com/snowflakedb/gosnowflake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsIdempotentStatement(t *testing.T) {
+	tests := []struct {
+		statement string
+		expected  bool
+	}{
+		{statement: "SHOW GRANTS TO ROLE \"ROLE1\"", expected: true},
+		{statement: "select * from table", expected: true},
+		{statement: "select * from table(result_scan(LAST_QUERY_ID()))", expected: false},
+		{statement: "SELECT \"name\" FROM TABLE(RESULT_SCAN(last_query_id(-1)))", expected: false},
+		{statement: "GRANT SELECT ON TABLE DB.SCHEMA.TABLE TO ROLE \"ROLE1\";", expected: true},
+		{statement: "REVOKE ROLE \"ROLE1\" FROM ROLE \"ROLE2\"", expected: true},
+		{statement: "CREATE ROLE IF NOT EXISTS \"ROLE1\"", expected: true},
+		{statement: "CREATE OR REPLACE MASKING POLICY DB.SCHEMA.MASK AS (val STRING) RETURNS STRING -> val", expected: true},
+		{statement: "CREATE ROLE \"ROLE1\"", expected: false},
+		{statement: "DROP ROLE IF EXISTS \"ROLE1\"", expected: true},
+		{statement: "DROP ROLE \"ROLE1\"", expected: false},
+		{statement: "ALTER SHARE \"SHARE1\" SET ACCOUNTS = ACC1", expected: true},
+		{statement: "ALTER ROLE IF EXISTS \"ROLE1\" RENAME TO \"ROLE2\"", expected: false},
+		{statement: "INSERT INTO TABLE VALUES (1)", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.statement, func(t *testing.T) {
+			assert.Equal(t, tt.expected, isIdempotentStatement(tt.statement))
+		})
+	}
+}
+
+func TestIsRetryableError(t *testing.T) {
+	assert.True(t, isRetryableError(&sf.SnowflakeError{Number: 261000, Message: "failed to post query"}))
+	assert.True(t, isRetryableError(errors.New("read tcp 10.0.0.1:443: connection reset by peer")))
+	assert.True(t, isRetryableError(errors.New("503 Service Unavailable")))
+
+	assert.False(t, isRetryableError(&sf.SnowflakeError{Number: 2003, Message: "SQL compilation error: Object does not exist"}))
+	assert.False(t, isRetryableError(&sf.SnowflakeError{Number: 630, Message: "Statement reached its statement or warehouse timeout of 10 second(s) and was canceled."}))
+	assert.True(t, isRetryableError(&sf.SnowflakeError{Number: 630, Message: "Statement reached its queued timeout of 60 second(s) and was canceled."}))
+	assert.False(t, isRetryableError(context.Canceled))
+	assert.False(t, isRetryableError(errors.New("insufficient privileges")))
+}
+
+// failingConnector returns the given errors for the first statements it executes and succeeds afterwards
+type failingConnector struct {
+	errs  []error
+	calls int
+}
+
+func (c *failingConnector) Connect(context.Context) (driver.Conn, error) {
+	return &failingConn{connector: c}, nil
+}
+
+func (c *failingConnector) Driver() driver.Driver {
+	return nil
+}
+
+func (c *failingConnector) next() error {
+	c.calls++
+
+	if c.calls <= len(c.errs) {
+		return c.errs[c.calls-1]
+	}
+
+	return nil
+}
+
+type failingConn struct {
+	connector *failingConnector
+}
+
+func (c *failingConn) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("prepared statements are not supported")
+}
+
+func (c *failingConn) Close() error {
+	return nil
+}
+
+func (c *failingConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("transactions are not supported")
+}
+
+func (c *failingConn) QueryContext(context.Context, string, []driver.NamedValue) (driver.Rows, error) {
+	if err := c.connector.next(); err != nil {
+		return nil, err
+	}
+
+	return &fixtureRows{}, nil
+}
+
+func (c *failingConn) ExecContext(context.Context, string, []driver.NamedValue) (driver.Result, error) {
+	if err := c.connector.next(); err != nil {
+		return nil, err
+	}
+
+	return driver.RowsAffected(0), nil
+}
+
+func TestSnowflakeRepository_retry(t *testing.T) {
+	newRepo := func(errs ...error) (*SnowflakeRepository, *failingConnector) {
+		connector := &failingConnector{errs: errs}
+
+		return &SnowflakeRepository{
+			conn:        sql.OpenDB(connector),
+			retryPolicy: &retryPolicy{maxAttempts: 3, initialBackoff: time.Millisecond, maxBackoff: time.Millisecond},
+		}, connector
+	}
+
+	t.Run("query retries transient Snowflake errors", func(t *testing.T) {
+		repo, connector := newRepo(&sf.SnowflakeError{Number: 390503, Message: "Service temporarily unavailable"})
+
+		rows, _, err := repo.query(context.Background(), "SHOW ROLES")
+		require.NoError(t, err)
+		require.NoError(t, rows.Close())
+
+		assert.Equal(t, 2, connector.calls)
+		assert.Equal(t, 1, repo.retryPolicy.totalRetries())
+	})
+
+	t.Run("execute retries transient Snowflake errors", func(t *testing.T) {
+		repo, connector := newRepo(&sf.SnowflakeError{Number: 608, Message: "Warehouse not ready"}, &sf.SnowflakeError{Number: 604, Message: "Statement canceled"})
+
+		require.NoError(t, repo.execute(context.Background(), `GRANT ROLE "ROLE1" TO ROLE "ROLE2"`))
+
+		assert.Equal(t, 3, connector.calls)
+		assert.Equal(t, 2, repo.retryPolicy.totalRetries())
+	})
+
+	t.Run("execute does not retry statement timeouts", func(t *testing.T) {
+		timeoutErr := &sf.SnowflakeError{Number: 630, Message: "Statement reached its statement or warehouse timeout of 10 second(s) and was canceled."}
+		repo, connector := newRepo(timeoutErr)
+
+		err := repo.execute(context.Background(), `GRANT ROLE "ROLE1" TO ROLE "ROLE2"`)
+
+		var sfErr *sf.SnowflakeError
+		require.ErrorAs(t, err, &sfErr)
+		assert.Equal(t, 630, sfErr.Number)
+		assert.Equal(t, 1, connector.calls)
+		assert.Equal(t, 0, repo.retryPolicy.totalRetries())
+	})
+
+	t.Run("execute retries queued timeouts", func(t *testing.T) {
+		repo, connector := newRepo(&sf.SnowflakeError{Number: 630, Message: "Statement reached its queued timeout of 60 second(s) and was canceled."})
+
+		require.NoError(t, repo.execute(context.Background(), `GRANT ROLE "ROLE1" TO ROLE "ROLE2"`))
+
+		assert.Equal(t, 2, connector.calls)
+		assert.Equal(t, 1, repo.retryPolicy.totalRetries())
+	})
+
+	t.Run("query does not retry the result of the last query", func(t *testing.T) {
+		repo, connector := newRepo(&sf.SnowflakeError{Number: 390503, Message: "Service temporarily unavailable"})
+
+		_, _, err := repo.query(context.Background(), "select * from table(result_scan(LAST_QUERY_ID()))")
+		require.Error(t, err)
+
+		assert.Equal(t, 1, connector.calls)
+		assert.Equal(t, 0, repo.retryPolicy.totalRetries())
+	})
+}
+
+func TestRetryPolicy_Run(t *testing.T) {
+	transientErr := errors.New("503 Service Unavailable")
+
+	t.Run("retries transient errors of idempotent statements", func(t *testing.T) {
+		policy := &retryPolicy{maxAttempts: 3, initialBackoff: time.Millisecond, maxBackoff: time.Millisecond}
+		calls := 0
+
+		err := policy.run(context.Background(), true, func() error {
+			calls++
+			if calls < 3 {
+				return transientErr
+			}
+
+			return nil
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, 3, calls)
+		assert.Equal(t, 2, policy.totalRetries())
+	})
+
+	t.Run("gives up after max attempts", func(t *testing.T) {
+		policy := &retryPolicy{maxAttempts: 2, initialBackoff: time.Millisecond, maxBackoff: time.Millisecond}
+		calls := 0
+
+		err := policy.run(context.Background(), true, func() error {
+			calls++
+			return transientErr
+		})
+
+		require.ErrorIs(t, err, transientErr)
+		assert.Equal(t, 2, calls)
+	})
+
+	t.Run("does not retry non-idempotent statements", func(t *testing.T) {
+		policy := &retryPolicy{maxAttempts: 3, initialBackoff: time.Millisecond, maxBackoff: time.Millisecond}
+		calls := 0
+
+		err := policy.run(context.Background(), false, func() error {
+			calls++
+			return transientErr
+		})
+
+		require.ErrorIs(t, err, transientErr)
+		assert.Equal(t, 1, calls)
+		assert.Equal(t, 0, policy.totalRetries())
+	})
+
+	t.Run("does not retry fatal errors", func(t *testing.T) {
+		policy := &retryPolicy{maxAttempts: 3, initialBackoff: time.Millisecond, maxBackoff: time.Millisecond}
+		calls := 0
+
+		err := policy.run(context.Background(), true, func() error {
+			calls++
+			return errors.New("SQL compilation error")
+		})
+
+		require.Error(t, err)
+		assert.Equal(t, 1, calls)
+	})
+}
+
+func TestNewRetryPolicy(t *testing.T) {
+	policy, err := newRetryPolicy(map[string]string{})
+	require.NoError(t, err)
+	assert.Equal(t, defaultRetryMaxAttempts, policy.maxAttempts)
+	assert.Equal(t, defaultRetryInitialBackoff, policy.initialBackoff)
+
+	policy, err = newRetryPolicy(map[string]string{SfRetryMaxAttempts: "5", SfRetryInitialBackoff: "500ms"})
+	require.NoError(t, err)
+	assert.Equal(t, 5, policy.maxAttempts)
+	assert.Equal(t, 500*time.Millisecond, policy.initialBackoff)
+
+	_, err = newRetryPolicy(map[string]string{SfRetryMaxAttempts: "0"})
+	require.Error(t, err)
+
+	_, err = newRetryPolicy(map[string]string{SfRetryInitialBackoff: "soon"})
+	require.Error(t, err)
+}
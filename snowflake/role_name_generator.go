@@ -7,6 +7,8 @@
 package snowflake
 
 import (
+	"context"
+
 	"fmt"
 	"strconv"
 	"strings"
@@ -22,9 +24,9 @@ import (
 // It is used to load current role names so the generator can avoid collisions
 // and detect renames.
 type RoleNameGeneratorRepository interface {
-	GetAccountRoles() ([]RoleEntity, error)
-	GetDatabaseRoles(database string) ([]RoleEntity, error)
-	GetApplicationRoles(application string) ([]ApplicationRoleEntity, error)
+	GetAccountRoles(ctx context.Context) ([]RoleEntity, error)
+	GetDatabaseRoles(ctx context.Context, database string) ([]RoleEntity, error)
+	GetApplicationRoles(ctx context.Context, application string) ([]ApplicationRoleEntity, error)
 }
 
 // RoleNameGenerationResultType indicates the outcome of a role name generation.
@@ -83,26 +85,26 @@ func NewRoleNameGenerator(namingConstraints *naming_hint.NamingConstraints, repo
 // it returns the existing name with RoleNameGenerationResultExisting.
 // If the base name changed, it generates a new unique name and returns RoleNameGenerationResultRename.
 // For new roles, it returns RoleNameGenerationResultNew.
-func (g *RoleNameGenerator) GenerateAccountRole(ap *importer.AccessProvider) (string, RoleNameGenerationResultType, error) {
-	return g.generateUniqueRoleName(ap, roleGenScope{"", ""})
+func (g *RoleNameGenerator) GenerateAccountRole(ctx context.Context, ap *importer.AccessProvider) (string, RoleNameGenerationResultType, error) {
+	return g.generateUniqueRoleName(ctx, ap, roleGenScope{"", ""})
 }
 
 // GenerateDatabaseRole generates a unique database-scoped role name for the given access control.
 // The database parameter specifies which Snowflake database the role belongs to.
 // Roles in different databases are tracked independently and cannot collide.
-func (g *RoleNameGenerator) GenerateDatabaseRole(ap *importer.AccessProvider, database string) (string, RoleNameGenerationResultType, error) {
-	return g.generateUniqueRoleName(ap, roleGenScope{"db", database})
+func (g *RoleNameGenerator) GenerateDatabaseRole(ctx context.Context, ap *importer.AccessProvider, database string) (string, RoleNameGenerationResultType, error) {
+	return g.generateUniqueRoleName(ctx, ap, roleGenScope{"db", database})
 }
 
 // GenerateApplicationRole generates a unique application-scoped role name for the given access control.
 // The application parameter specifies which Snowflake application the role belongs to.
 // Roles in different applications are tracked independently and cannot collide.
-func (g *RoleNameGenerator) GenerateApplicationRole(ap *importer.AccessProvider, application string) (string, RoleNameGenerationResultType, error) {
-	return g.generateUniqueRoleName(ap, roleGenScope{"app", application})
+func (g *RoleNameGenerator) GenerateApplicationRole(ctx context.Context, ap *importer.AccessProvider, application string) (string, RoleNameGenerationResultType, error) {
+	return g.generateUniqueRoleName(ctx, ap, roleGenScope{"app", application})
 }
 
-func (g *RoleNameGenerator) generateUniqueRoleName(ap *importer.AccessProvider, scope roleGenScope) (string, RoleNameGenerationResultType, error) {
-	roleName, genType, err := g.generateScopedRoleName(ap, scope)
+func (g *RoleNameGenerator) generateUniqueRoleName(ctx context.Context, ap *importer.AccessProvider, scope roleGenScope) (string, RoleNameGenerationResultType, error) {
+	roleName, genType, err := g.generateScopedRoleName(ctx, ap, scope)
 	if err != nil {
 		return "", 0, fmt.Errorf("generate role name: %w", err)
 	}
@@ -112,7 +114,7 @@ func (g *RoleNameGenerator) generateUniqueRoleName(ap *importer.AccessProvider,
 
 // generateScopedRoleName generates a unique role name for the given access control within a scope.
 // It acquires the scope-level mutex internally and lazily loads existing roles from the repository.
-func (g *RoleNameGenerator) generateScopedRoleName(ap *importer.AccessProvider, scope roleGenScope) (string, RoleNameGenerationResultType, error) {
+func (g *RoleNameGenerator) generateScopedRoleName(ctx context.Context, ap *importer.AccessProvider, scope roleGenScope) (string, RoleNameGenerationResultType, error) {
 	mapKey := scope.FullScope()
 
 	// 1. Generate basename
@@ -141,7 +143,7 @@ func (g *RoleNameGenerator) generateScopedRoleName(ap *importer.AccessProvider,
 	mutex.Lock()
 	defer mutex.Unlock()
 
-	existingRoles, err := g.loadExistingRolesForScope(scope)
+	existingRoles, err := g.loadExistingRolesForScope(ctx, scope)
 	if err != nil {
 		return "", 0, fmt.Errorf("load existing roles: %w", err)
 	}
@@ -198,7 +200,7 @@ func (g *RoleNameGenerator) generateBaseName(ap *importer.AccessProvider) (strin
 // loadExistingRolesForScope returns the existing roles map for a given scope
 // The method is expecting that the scope mutex is already locked.
 // The method may return the map from cache (if exists) or load it by reading all roles
-func (g *RoleNameGenerator) loadExistingRolesForScope(scope roleGenScope) (map[string]uint32, error) {
+func (g *RoleNameGenerator) loadExistingRolesForScope(ctx context.Context, scope roleGenScope) (map[string]uint32, error) {
 	mapKey := scope.FullScope()
 
 	// Check if roles are already populated
@@ -226,14 +228,16 @@ func (g *RoleNameGenerator) loadExistingRolesForScope(scope roleGenScope) (map[s
 	switch scope.scope {
 	case "":
 		loadRoles = func() ([]string, error) {
-			return roleNameMapArray(g.repository.GetAccountRoles, func(e RoleEntity) string {
+			return roleNameMapArray(func() ([]RoleEntity, error) {
+				return g.repository.GetAccountRoles(ctx)
+			}, func(e RoleEntity) string {
 				return e.Name
 			})
 		}
 	case "db":
 		loadRoles = func() ([]string, error) {
 			return roleNameMapArray(func() ([]RoleEntity, error) {
-				return g.repository.GetDatabaseRoles(scope.entity)
+				return g.repository.GetDatabaseRoles(ctx, scope.entity)
 			}, func(e RoleEntity) string {
 				return e.Name
 			})
@@ -241,7 +245,7 @@ func (g *RoleNameGenerator) loadExistingRolesForScope(scope roleGenScope) (map[s
 	case "app":
 		loadRoles = func() ([]string, error) {
 			return roleNameMapArray(func() ([]ApplicationRoleEntity, error) {
-				return g.repository.GetApplicationRoles(scope.entity)
+				return g.repository.GetApplicationRoles(ctx, scope.entity)
 			}, func(e ApplicationRoleEntity) string {
 				return e.Name
 			})
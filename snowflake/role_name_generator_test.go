@@ -1,12 +1,15 @@
 package snowflake
 
 import (
+	"context"
+
 	"sync"
 	"testing"
 
 	importer "github.com/raito-io/cli/base/access_provider/sync_to_target"
 	"github.com/raito-io/cli/base/access_provider/sync_to_target/naming_hint"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -42,7 +45,7 @@ func buildRoleGenAP(name, namingHint, actualName string) *importer.AccessProvide
 func TestGenerateAccountRole_NewRole_NoExistingRoles(t *testing.T) {
 	// Given: no existing roles in Snowflake
 	repoMock := NewMockRoleNameGeneratorRepository(t)
-	repoMock.EXPECT().GetAccountRoles().Return([]RoleEntity{}, nil).Once()
+	repoMock.EXPECT().GetAccountRoles(mock.Anything).Return([]RoleEntity{}, nil).Once()
 
 	gen, err := NewRoleNameGenerator(roleGenTestConstraints, repoMock)
 	require.NoError(t, err)
@@ -51,7 +54,7 @@ func TestGenerateAccountRole_NewRole_NoExistingRoles(t *testing.T) {
 	ap := buildRoleGenAP("MyRole", "", "")
 
 	// When: generating a new account role
-	roleName, resultType, err := gen.GenerateAccountRole(ap)
+	roleName, resultType, err := gen.GenerateAccountRole(context.Background(), ap)
 
 	// Then: name is translated (uppercased with word separators), result is New
 	require.NoError(t, err)
@@ -62,7 +65,7 @@ func TestGenerateAccountRole_NewRole_NoExistingRoles(t *testing.T) {
 func TestGenerateAccountRole_NewRole_WithPostfix(t *testing.T) {
 	// Given: "MY_ROLE" already exists in Snowflake
 	repoMock := NewMockRoleNameGeneratorRepository(t)
-	repoMock.EXPECT().GetAccountRoles().Return([]RoleEntity{
+	repoMock.EXPECT().GetAccountRoles(mock.Anything).Return([]RoleEntity{
 		{Name: "MY_ROLE"},
 	}, nil).Once()
 
@@ -70,7 +73,7 @@ func TestGenerateAccountRole_NewRole_WithPostfix(t *testing.T) {
 	require.NoError(t, err)
 
 	// When: generating first role with same base name
-	roleName1, resultType1, err := gen.GenerateAccountRole(buildRoleGenAP("MyRole", "", ""))
+	roleName1, resultType1, err := gen.GenerateAccountRole(context.Background(), buildRoleGenAP("MyRole", "", ""))
 
 	// Then: postfix __1 appended
 	require.NoError(t, err)
@@ -78,7 +81,7 @@ func TestGenerateAccountRole_NewRole_WithPostfix(t *testing.T) {
 	assert.Equal(t, RoleNameGenerationResultNew, resultType1)
 
 	// When: generating second role with same base name
-	roleName2, resultType2, err := gen.GenerateAccountRole(buildRoleGenAP("MyRole", "", ""))
+	roleName2, resultType2, err := gen.GenerateAccountRole(context.Background(), buildRoleGenAP("MyRole", "", ""))
 
 	// Then: postfix __2 appended, repository was only called once (cache reused)
 	require.NoError(t, err)
@@ -89,7 +92,7 @@ func TestGenerateAccountRole_NewRole_WithPostfix(t *testing.T) {
 func TestGenerateAccountRole_NewRole_ContinuesFromHighestPostfix(t *testing.T) {
 	// Given: roles with gaps in postfix numbering exist
 	repoMock := NewMockRoleNameGeneratorRepository(t)
-	repoMock.EXPECT().GetAccountRoles().Return([]RoleEntity{
+	repoMock.EXPECT().GetAccountRoles(mock.Anything).Return([]RoleEntity{
 		{Name: "ROLE"},
 		{Name: "ROLE__3"},
 	}, nil).Once()
@@ -100,7 +103,7 @@ func TestGenerateAccountRole_NewRole_ContinuesFromHighestPostfix(t *testing.T) {
 	ap := buildRoleGenAP("Role", "", "")
 
 	// When: generating a new role with the same base name
-	roleName, resultType, err := gen.GenerateAccountRole(ap)
+	roleName, resultType, err := gen.GenerateAccountRole(context.Background(), ap)
 
 	// Then: continues from highest existing postfix (3) -> __4
 	require.NoError(t, err)
@@ -111,14 +114,14 @@ func TestGenerateAccountRole_NewRole_ContinuesFromHighestPostfix(t *testing.T) {
 func TestGenerateAccountRole_NamingHintTakesPrecedence(t *testing.T) {
 	// Given: no existing roles
 	repoMock := NewMockRoleNameGeneratorRepository(t)
-	repoMock.EXPECT().GetAccountRoles().Return([]RoleEntity{}, nil).Once()
+	repoMock.EXPECT().GetAccountRoles(mock.Anything).Return([]RoleEntity{}, nil).Once()
 
 	gen, err := NewRoleNameGenerator(roleGenTestConstraints, repoMock)
 	require.NoError(t, err)
 
 	// When: naming hint differs from the name
 	ap := buildRoleGenAP("OriginalName", "HINTED", "")
-	roleName, _, err := gen.GenerateAccountRole(ap)
+	roleName, _, err := gen.GenerateAccountRole(context.Background(), ap)
 
 	// Then: naming hint is used, not the name
 	require.NoError(t, err)
@@ -136,7 +139,7 @@ func TestGenerateAccountRole_NameTruncatedToMaxLength(t *testing.T) {
 	}
 
 	repoMock := NewMockRoleNameGeneratorRepository(t)
-	repoMock.EXPECT().GetAccountRoles().Return([]RoleEntity{}, nil).Once()
+	repoMock.EXPECT().GetAccountRoles(mock.Anything).Return([]RoleEntity{}, nil).Once()
 
 	gen, err := NewRoleNameGenerator(shortConstraints, repoMock)
 	require.NoError(t, err)
@@ -144,7 +147,7 @@ func TestGenerateAccountRole_NameTruncatedToMaxLength(t *testing.T) {
 	// When: translated name exceeds the max base length (9 chars)
 	// "VERYLONGROLENAME" -> "VERYLONGROLENAME" (16 chars, already uppercase) -> truncated to 9
 	ap := buildRoleGenAP("VERYLONGROLENAME", "", "")
-	roleName, _, err := gen.GenerateAccountRole(ap)
+	roleName, _, err := gen.GenerateAccountRole(context.Background(), ap)
 
 	// Then: name is truncated to 9 characters (maxLength 15 - postFixLength 6)
 	require.NoError(t, err)
@@ -165,7 +168,7 @@ func TestGenerateAccountRole_ExistingRole_NoRenameNeeded(t *testing.T) {
 	ap := buildRoleGenAP("MyRole", "", "MY_ROLE")
 
 	// When: generating for an existing role whose base name hasn't changed
-	roleName, resultType, err := gen.GenerateAccountRole(ap)
+	roleName, resultType, err := gen.GenerateAccountRole(context.Background(), ap)
 
 	// Then: returns the existing name unchanged, without loading roles from repo
 	require.NoError(t, err)
@@ -184,7 +187,7 @@ func TestGenerateAccountRole_ExistingRole_WithPostfix_NoRenameNeeded(t *testing.
 	ap := buildRoleGenAP("READER", "", "READER__2")
 
 	// When: the base name still matches (READER == READER)
-	roleName, resultType, err := gen.GenerateAccountRole(ap)
+	roleName, resultType, err := gen.GenerateAccountRole(context.Background(), ap)
 
 	// Then: returns the actual name unchanged, including its postfix
 	require.NoError(t, err)
@@ -195,7 +198,7 @@ func TestGenerateAccountRole_ExistingRole_WithPostfix_NoRenameNeeded(t *testing.
 func TestGenerateAccountRole_ExistingRole_RenameRequired(t *testing.T) {
 	// Given: role "OLDROLE" exists but the desired name is now "NEWROLE"
 	repoMock := NewMockRoleNameGeneratorRepository(t)
-	repoMock.EXPECT().GetAccountRoles().Return([]RoleEntity{
+	repoMock.EXPECT().GetAccountRoles(mock.Anything).Return([]RoleEntity{
 		{Name: "OLDROLE"},
 	}, nil).Once()
 
@@ -205,7 +208,7 @@ func TestGenerateAccountRole_ExistingRole_RenameRequired(t *testing.T) {
 	ap := buildRoleGenAP("NEWROLE", "", "OLDROLE")
 
 	// When: base name changed from OLDROLE to NEWROLE
-	roleName, resultType, err := gen.GenerateAccountRole(ap)
+	roleName, resultType, err := gen.GenerateAccountRole(context.Background(), ap)
 
 	// Then: generates new name, result is Rename
 	require.NoError(t, err)
@@ -216,7 +219,7 @@ func TestGenerateAccountRole_ExistingRole_RenameRequired(t *testing.T) {
 func TestGenerateAccountRole_ExistingRole_RenameWithConflict(t *testing.T) {
 	// Given: "NEWROLE" already exists and "OLDROLE" needs to be renamed to "NEWROLE"
 	repoMock := NewMockRoleNameGeneratorRepository(t)
-	repoMock.EXPECT().GetAccountRoles().Return([]RoleEntity{
+	repoMock.EXPECT().GetAccountRoles(mock.Anything).Return([]RoleEntity{
 		{Name: "NEWROLE"},
 		{Name: "OLDROLE"},
 	}, nil).Once()
@@ -227,7 +230,7 @@ func TestGenerateAccountRole_ExistingRole_RenameWithConflict(t *testing.T) {
 	ap := buildRoleGenAP("NEWROLE", "", "OLDROLE")
 
 	// When: rename target conflicts with an existing role
-	roleName, resultType, err := gen.GenerateAccountRole(ap)
+	roleName, resultType, err := gen.GenerateAccountRole(context.Background(), ap)
 
 	// Then: appends postfix to make the rename unique
 	require.NoError(t, err)
@@ -240,7 +243,7 @@ func TestGenerateAccountRole_ExistingRole_RenameWithConflict(t *testing.T) {
 func TestGenerateDatabaseRole_NewRole(t *testing.T) {
 	// Given: no existing database roles in MYDB
 	repoMock := NewMockRoleNameGeneratorRepository(t)
-	repoMock.EXPECT().GetDatabaseRoles("MYDB").Return([]RoleEntity{}, nil).Once()
+	repoMock.EXPECT().GetDatabaseRoles(mock.Anything, "MYDB").Return([]RoleEntity{}, nil).Once()
 
 	gen, err := NewRoleNameGenerator(roleGenTestConstraints, repoMock)
 	require.NoError(t, err)
@@ -248,7 +251,7 @@ func TestGenerateDatabaseRole_NewRole(t *testing.T) {
 	ap := buildRoleGenAP("READER", "", "")
 
 	// When: generating a database role
-	roleName, resultType, err := gen.GenerateDatabaseRole(ap, "MYDB")
+	roleName, resultType, err := gen.GenerateDatabaseRole(context.Background(), ap, "MYDB")
 
 	// Then: generates a new role name scoped to the database
 	require.NoError(t, err)
@@ -259,7 +262,7 @@ func TestGenerateDatabaseRole_NewRole(t *testing.T) {
 func TestGenerateApplicationRole_NewRole(t *testing.T) {
 	// Given: no existing application roles in MYAPP
 	repoMock := NewMockRoleNameGeneratorRepository(t)
-	repoMock.EXPECT().GetApplicationRoles("MYAPP").Return([]ApplicationRoleEntity{}, nil).Once()
+	repoMock.EXPECT().GetApplicationRoles(mock.Anything, "MYAPP").Return([]ApplicationRoleEntity{}, nil).Once()
 
 	gen, err := NewRoleNameGenerator(roleGenTestConstraints, repoMock)
 	require.NoError(t, err)
@@ -267,7 +270,7 @@ func TestGenerateApplicationRole_NewRole(t *testing.T) {
 	ap := buildRoleGenAP("READER", "", "")
 
 	// When: generating an application role
-	roleName, resultType, err := gen.GenerateApplicationRole(ap, "MYAPP")
+	roleName, resultType, err := gen.GenerateApplicationRole(context.Background(), ap, "MYAPP")
 
 	// Then: generates a new role name scoped to the application
 	require.NoError(t, err)
@@ -278,10 +281,10 @@ func TestGenerateApplicationRole_NewRole(t *testing.T) {
 func TestDifferentScopes_IndependentCaches(t *testing.T) {
 	// Given: account roles and database roles both contain "READER"
 	repoMock := NewMockRoleNameGeneratorRepository(t)
-	repoMock.EXPECT().GetAccountRoles().Return([]RoleEntity{
+	repoMock.EXPECT().GetAccountRoles(mock.Anything).Return([]RoleEntity{
 		{Name: "READER"},
 	}, nil).Once()
-	repoMock.EXPECT().GetDatabaseRoles("MYDB").Return([]RoleEntity{
+	repoMock.EXPECT().GetDatabaseRoles(mock.Anything, "MYDB").Return([]RoleEntity{
 		{Name: "READER"},
 	}, nil).Once()
 
@@ -289,11 +292,11 @@ func TestDifferentScopes_IndependentCaches(t *testing.T) {
 	require.NoError(t, err)
 
 	// When: generating in account scope (conflict -> postfix)
-	accountName, _, err := gen.GenerateAccountRole(buildRoleGenAP("READER", "", ""))
+	accountName, _, err := gen.GenerateAccountRole(context.Background(), buildRoleGenAP("READER", "", ""))
 	require.NoError(t, err)
 
 	// When: generating in database scope (independent conflict -> same postfix)
-	dbName, _, err := gen.GenerateDatabaseRole(buildRoleGenAP("READER", "", ""), "MYDB")
+	dbName, _, err := gen.GenerateDatabaseRole(context.Background(), buildRoleGenAP("READER", "", ""), "MYDB")
 	require.NoError(t, err)
 
 	// Then: both get __1 because scopes are independent
@@ -306,7 +309,7 @@ func TestDifferentScopes_IndependentCaches(t *testing.T) {
 func TestGenerateAccountRole_RepositoryError(t *testing.T) {
 	// Given: repository returns an error
 	repoMock := NewMockRoleNameGeneratorRepository(t)
-	repoMock.EXPECT().GetAccountRoles().Return(nil, assert.AnError).Once()
+	repoMock.EXPECT().GetAccountRoles(mock.Anything).Return(nil, assert.AnError).Once()
 
 	gen, err := NewRoleNameGenerator(roleGenTestConstraints, repoMock)
 	require.NoError(t, err)
@@ -314,7 +317,7 @@ func TestGenerateAccountRole_RepositoryError(t *testing.T) {
 	ap := buildRoleGenAP("ROLE", "", "")
 
 	// When: generating a role
-	_, _, err = gen.GenerateAccountRole(ap)
+	_, _, err = gen.GenerateAccountRole(context.Background(), ap)
 
 	// Then: error is propagated
 	require.Error(t, err)
@@ -324,7 +327,7 @@ func TestGenerateAccountRole_RepositoryError(t *testing.T) {
 func TestGenerateDatabaseRole_RepositoryError(t *testing.T) {
 	// Given: repository returns an error for database roles
 	repoMock := NewMockRoleNameGeneratorRepository(t)
-	repoMock.EXPECT().GetDatabaseRoles("MYDB").Return(nil, assert.AnError).Once()
+	repoMock.EXPECT().GetDatabaseRoles(mock.Anything, "MYDB").Return(nil, assert.AnError).Once()
 
 	gen, err := NewRoleNameGenerator(roleGenTestConstraints, repoMock)
 	require.NoError(t, err)
@@ -332,7 +335,7 @@ func TestGenerateDatabaseRole_RepositoryError(t *testing.T) {
 	ap := buildRoleGenAP("ROLE", "", "")
 
 	// When: generating a database role
-	_, _, err = gen.GenerateDatabaseRole(ap, "MYDB")
+	_, _, err = gen.GenerateDatabaseRole(context.Background(), ap, "MYDB")
 
 	// Then: error is propagated
 	require.Error(t, err)
@@ -344,16 +347,16 @@ func TestGenerateDatabaseRole_RepositoryError(t *testing.T) {
 func TestGenerateAccountRole_CacheIsReused(t *testing.T) {
 	// Given: repository returns roles
 	repoMock := NewMockRoleNameGeneratorRepository(t)
-	repoMock.EXPECT().GetAccountRoles().Return([]RoleEntity{}, nil).Once()
+	repoMock.EXPECT().GetAccountRoles(mock.Anything).Return([]RoleEntity{}, nil).Once()
 
 	gen, err := NewRoleNameGenerator(roleGenTestConstraints, repoMock)
 	require.NoError(t, err)
 
 	// When: generating two different roles
-	name1, _, err := gen.GenerateAccountRole(buildRoleGenAP("ALPHA", "", ""))
+	name1, _, err := gen.GenerateAccountRole(context.Background(), buildRoleGenAP("ALPHA", "", ""))
 	require.NoError(t, err)
 
-	name2, _, err := gen.GenerateAccountRole(buildRoleGenAP("BETA", "", ""))
+	name2, _, err := gen.GenerateAccountRole(context.Background(), buildRoleGenAP("BETA", "", ""))
 	require.NoError(t, err)
 
 	// Then: both succeed and repository was called only once (.Once() enforces this)
@@ -366,7 +369,7 @@ func TestGenerateAccountRole_CacheIsReused(t *testing.T) {
 func TestGenerateAccountRole_ConcurrentAccess(t *testing.T) {
 	// Given: repository returns an empty role list
 	repoMock := NewMockRoleNameGeneratorRepository(t)
-	repoMock.EXPECT().GetAccountRoles().Return([]RoleEntity{}, nil).Once()
+	repoMock.EXPECT().GetAccountRoles(mock.Anything).Return([]RoleEntity{}, nil).Once()
 
 	gen, err := NewRoleNameGenerator(roleGenTestConstraints, repoMock)
 	require.NoError(t, err)
@@ -384,7 +387,7 @@ func TestGenerateAccountRole_ConcurrentAccess(t *testing.T) {
 		go func(idx int) {
 			defer wg.Done()
 
-			name, resultType, genErr := gen.GenerateAccountRole(buildRoleGenAP("SHARED", "", ""))
+			name, resultType, genErr := gen.GenerateAccountRole(context.Background(), buildRoleGenAP("SHARED", "", ""))
 			assert.NoError(t, genErr)
 			assert.Equal(t, RoleNameGenerationResultNew, resultType)
 
@@ -408,8 +411,8 @@ func TestGenerateAccountRole_ConcurrentAccess(t *testing.T) {
 func TestGenerateRoles_ConcurrentDifferentScopes(t *testing.T) {
 	// Given: repository returns empty lists for both scopes
 	repoMock := NewMockRoleNameGeneratorRepository(t)
-	repoMock.EXPECT().GetAccountRoles().Return([]RoleEntity{}, nil).Once()
-	repoMock.EXPECT().GetDatabaseRoles("DB1").Return([]RoleEntity{}, nil).Once()
+	repoMock.EXPECT().GetAccountRoles(mock.Anything).Return([]RoleEntity{}, nil).Once()
+	repoMock.EXPECT().GetDatabaseRoles(mock.Anything, "DB1").Return([]RoleEntity{}, nil).Once()
 
 	gen, err := NewRoleNameGenerator(roleGenTestConstraints, repoMock)
 	require.NoError(t, err)
@@ -425,14 +428,14 @@ func TestGenerateRoles_ConcurrentDifferentScopes(t *testing.T) {
 		go func() {
 			defer wg.Done()
 
-			_, _, genErr := gen.GenerateAccountRole(buildRoleGenAP("ROLE", "", ""))
+			_, _, genErr := gen.GenerateAccountRole(context.Background(), buildRoleGenAP("ROLE", "", ""))
 			assert.NoError(t, genErr)
 		}()
 
 		go func() {
 			defer wg.Done()
 
-			_, _, genErr := gen.GenerateDatabaseRole(buildRoleGenAP("ROLE", "", ""), "DB1")
+			_, _, genErr := gen.GenerateDatabaseRole(context.Background(), buildRoleGenAP("ROLE", "", ""), "DB1")
 			assert.NoError(t, genErr)
 		}()
 	}
@@ -445,7 +448,7 @@ func TestGenerateRoles_ConcurrentDifferentScopes(t *testing.T) {
 func TestGenerateAccountRole_MixedScenario(t *testing.T) {
 	// Given: some roles already exist in Snowflake
 	repoMock := NewMockRoleNameGeneratorRepository(t)
-	repoMock.EXPECT().GetAccountRoles().Return([]RoleEntity{
+	repoMock.EXPECT().GetAccountRoles(mock.Anything).Return([]RoleEntity{
 		{Name: "ALPHA"},
 		{Name: "BETA"},
 		{Name: "BETA__1"},
@@ -455,25 +458,25 @@ func TestGenerateAccountRole_MixedScenario(t *testing.T) {
 	require.NoError(t, err)
 
 	// When/Then: existing role "ALPHA" with no rename needed
-	name, resultType, err := gen.GenerateAccountRole(buildRoleGenAP("Alpha", "", "ALPHA"))
+	name, resultType, err := gen.GenerateAccountRole(context.Background(), buildRoleGenAP("Alpha", "", "ALPHA"))
 	require.NoError(t, err)
 	assert.Equal(t, "ALPHA", name)
 	assert.Equal(t, RoleNameGenerationResultExisting, resultType)
 
 	// When/Then: new role "GAMMA" with no conflicts
-	name, resultType, err = gen.GenerateAccountRole(buildRoleGenAP("Gamma", "", ""))
+	name, resultType, err = gen.GenerateAccountRole(context.Background(), buildRoleGenAP("Gamma", "", ""))
 	require.NoError(t, err)
 	assert.Equal(t, "GAMMA", name)
 	assert.Equal(t, RoleNameGenerationResultNew, resultType)
 
 	// When/Then: new role "BETA" conflicts with existing -> gets postfix __2
-	name, resultType, err = gen.GenerateAccountRole(buildRoleGenAP("Beta", "", ""))
+	name, resultType, err = gen.GenerateAccountRole(context.Background(), buildRoleGenAP("Beta", "", ""))
 	require.NoError(t, err)
 	assert.Equal(t, "BETA__2", name)
 	assert.Equal(t, RoleNameGenerationResultNew, resultType)
 
 	// When/Then: rename from ALPHA to BETA -> conflicts -> gets postfix __3
-	name, resultType, err = gen.GenerateAccountRole(buildRoleGenAP("Beta", "", "ALPHA"))
+	name, resultType, err = gen.GenerateAccountRole(context.Background(), buildRoleGenAP("Beta", "", "ALPHA"))
 	require.NoError(t, err)
 	assert.Equal(t, "BETA__3", name)
 	assert.Equal(t, RoleNameGenerationResultRename, resultType)
@@ -0,0 +1,686 @@
+package snowflake
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/smithy-go/ptr"
+	"github.com/raito-io/cli/base/access_provider"
+	"github.com/raito-io/cli/base/access_provider/sync_from_target"
+	importer "github.com/raito-io/cli/base/access_provider/sync_to_target"
+	"github.com/raito-io/cli/base/access_provider/types"
+	"github.com/raito-io/cli/base/data_source"
+	"github.com/raito-io/cli/base/util/config"
+	"github.com/raito-io/cli/base/wrappers/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoundTrip_Roles(t *testing.T) {
+	// Given
+	fake := newRoundTripFake()
+	configMap := &config.ConfigMap{Parameters: map[string]string{SfDatabaseRoles: "true"}}
+
+	sales := &importer.AccessProvider{
+		Id:         "ap-sales",
+		Action:     types.Grant,
+		Type:       ptr.String(access_provider.Role),
+		Name:       "Sales",
+		NamingHint: "Sales",
+		Who: importer.WhoItem{
+			Users: []string{"ALICE", "BOB"},
+		},
+		What: []importer.WhatItem{
+			{DataObject: &data_source.DataObjectReference{FullName: "SALES_DB.PUBLIC.ORDERS", Type: data_source.Table}, Permissions: []string{"SELECT"}},
+		},
+	}
+
+	reporting := &importer.AccessProvider{
+		Id:         "ap-reporting",
+		Action:     types.Grant,
+		Type:       ptr.String(apTypeDatabaseRole),
+		Name:       "Reporting",
+		NamingHint: "Reporting",
+		Who: importer.WhoItem{
+			InheritFrom: []string{"ID:ap-sales"},
+		},
+		What: []importer.WhatItem{
+			{DataObject: &data_source.DataObjectReference{FullName: "SALES_DB.PUBLIC.CUSTOMERS", Type: data_source.Table}, Permissions: []string{"SELECT"}},
+		},
+	}
+
+	// When
+	feedback := syncToFake(t, fake, configMap, sales, reporting)
+
+	// Then
+	require.Len(t, feedback, 2)
+
+	salesRole := fake.accountRole(feedback["ap-sales"].ActualName)
+	require.NotNil(t, salesRole)
+	assert.ElementsMatch(t, []string{"ALICE", "BOB"}, salesRole.usersOf())
+	assert.Equal(t, []string{"SELECT"}, salesRole.privilegesOn("TABLE", "SALES_DB.PUBLIC.ORDERS"))
+	assert.Equal(t, []string{"USAGE"}, salesRole.privilegesOn("SCHEMA", "SALES_DB.PUBLIC"))
+	assert.Equal(t, []string{"USAGE"}, salesRole.privilegesOn("DATABASE", "SALES_DB"))
+
+	reportingRole := fake.databaseRole("SALES_DB", feedback["ap-reporting"].ActualName)
+	require.NotNil(t, reportingRole)
+	assert.Equal(t, []GrantOfRole{{GrantedTo: "ROLE", GranteeName: salesRole.Name}}, reportingRole.GrantsOf)
+	assert.Equal(t, []string{"SELECT"}, reportingRole.privilegesOn("TABLE", "SALES_DB.PUBLIC.CUSTOMERS"))
+
+	// When
+	aps := syncFromFake(t, fake, configMap)
+
+	// Then
+	salesAp := aps[*feedback["ap-sales"].ExternalId]
+	require.NotNil(t, salesAp)
+	assert.ElementsMatch(t, []string{"ALICE", "BOB"}, salesAp.Who.Users)
+	assert.ElementsMatch(t, []sync_from_target.WhatItem{
+		{DataObject: &data_source.DataObjectReference{FullName: "SALES_DB"}, Permissions: []string{"USAGE on DATABASE"}},
+		{DataObject: &data_source.DataObjectReference{FullName: "SALES_DB.PUBLIC"}, Permissions: []string{"USAGE on SCHEMA"}},
+		{DataObject: &data_source.DataObjectReference{FullName: "SALES_DB.PUBLIC.ORDERS"}, Permissions: []string{"SELECT"}},
+	}, salesAp.What)
+
+	reportingAp := aps[*feedback["ap-reporting"].ExternalId]
+	require.NotNil(t, reportingAp)
+	assert.Equal(t, []string{*feedback["ap-sales"].ExternalId}, reportingAp.Who.AccessProviders)
+	assert.Contains(t, reportingAp.What, sync_from_target.WhatItem{DataObject: &data_source.DataObjectReference{FullName: "SALES_DB.PUBLIC.CUSTOMERS"}, Permissions: []string{"SELECT"}})
+}
+
+func TestRoundTrip_AccountUsageGrantLoading(t *testing.T) {
+	// Given
+	fake := newRoundTripFake()
+	configMap := &config.ConfigMap{Parameters: map[string]string{}}
+
+	sales := &importer.AccessProvider{
+		Id:         "ap-sales",
+		Action:     types.Grant,
+		Type:       ptr.String(access_provider.Role),
+		Name:       "Sales",
+		NamingHint: "Sales",
+		Who: importer.WhoItem{
+			Users: []string{"ALICE", "BOB"},
+		},
+		What: []importer.WhatItem{
+			{DataObject: &data_source.DataObjectReference{FullName: "SALES_DB.PUBLIC.ORDERS", Type: data_source.Table}, Permissions: []string{"SELECT"}},
+		},
+	}
+
+	managers := &importer.AccessProvider{
+		Id:         "ap-managers",
+		Action:     types.Grant,
+		Type:       ptr.String(access_provider.Role),
+		Name:       "Managers",
+		NamingHint: "Managers",
+		Who: importer.WhoItem{
+			Users:       []string{"CAROL"},
+			InheritFrom: []string{"ID:ap-sales"},
+		},
+	}
+
+	feedback := syncToFake(t, fake, configMap, sales, managers)
+
+	// When
+	showAps := syncFromFake(t, fake, configMap)
+	accountUsageAps := syncFromFake(t, fake, &config.ConfigMap{Parameters: map[string]string{SfGrantLoading: GrantLoadingAccountUsage}})
+
+	// Then
+	salesAp := accountUsageAps[*feedback["ap-sales"].ExternalId]
+	require.NotNil(t, salesAp)
+	assert.ElementsMatch(t, []string{"ALICE", "BOB"}, salesAp.Who.Users)
+	assert.Contains(t, salesAp.What, sync_from_target.WhatItem{DataObject: &data_source.DataObjectReference{FullName: "SALES_DB.PUBLIC.ORDERS"}, Permissions: []string{"SELECT"}})
+
+	managersAp := accountUsageAps[*feedback["ap-managers"].ExternalId]
+	require.NotNil(t, managersAp)
+	assert.Equal(t, []string{"CAROL"}, managersAp.Who.Users)
+	assert.Equal(t, []string{*feedback["ap-sales"].ExternalId}, managersAp.Who.AccessProviders)
+
+	assert.Equal(t, showAps, accountUsageAps)
+}
+
+func TestRoundTrip_UpdateAndDeleteRoles(t *testing.T) {
+	// Given
+	fake := newRoundTripFake()
+	configMap := &config.ConfigMap{Parameters: map[string]string{}}
+
+	sales := &importer.AccessProvider{
+		Id:         "ap-sales",
+		Action:     types.Grant,
+		Type:       ptr.String(access_provider.Role),
+		Name:       "Sales",
+		NamingHint: "Sales",
+		Who: importer.WhoItem{
+			Users: []string{"ALICE", "BOB"},
+		},
+		What: []importer.WhatItem{
+			{DataObject: &data_source.DataObjectReference{FullName: "SALES_DB.PUBLIC.ORDERS", Type: data_source.Table}, Permissions: []string{"SELECT"}},
+		},
+	}
+
+	support := &importer.AccessProvider{
+		Id:         "ap-support",
+		Action:     types.Grant,
+		Type:       ptr.String(access_provider.Role),
+		Name:       "Support",
+		NamingHint: "Support",
+		Who: importer.WhoItem{
+			Users: []string{"CAROL"},
+		},
+		What: []importer.WhatItem{
+			{DataObject: &data_source.DataObjectReference{FullName: "SALES_DB.PUBLIC.CUSTOMERS", Type: data_source.Table}, Permissions: []string{"SELECT"}},
+		},
+	}
+
+	feedback := syncToFake(t, fake, configMap, sales, support)
+	require.Len(t, feedback, 2)
+
+	salesRoleName := feedback["ap-sales"].ActualName
+	supportRoleName := feedback["ap-support"].ActualName
+
+	sales.ExternalId = feedback["ap-sales"].ExternalId
+	sales.ActualName = ptr.String(salesRoleName)
+	sales.Who.Users = []string{"ALICE"}
+	sales.What = []importer.WhatItem{
+		{DataObject: &data_source.DataObjectReference{FullName: "SALES_DB.PUBLIC.CUSTOMERS", Type: data_source.Table}, Permissions: []string{"SELECT", "INSERT"}},
+	}
+
+	support.ExternalId = feedback["ap-support"].ExternalId
+	support.ActualName = ptr.String(supportRoleName)
+	support.Delete = true
+
+	// When
+	syncToFake(t, fake, configMap, sales, support)
+
+	// Then
+	assert.Nil(t, fake.accountRole(supportRoleName))
+
+	salesRole := fake.accountRole(salesRoleName)
+	require.NotNil(t, salesRole)
+	assert.Equal(t, []string{"ALICE"}, salesRole.usersOf())
+	assert.Empty(t, salesRole.privilegesOn("TABLE", "SALES_DB.PUBLIC.ORDERS"))
+	assert.ElementsMatch(t, []string{"SELECT", "INSERT"}, salesRole.privilegesOn("TABLE", "SALES_DB.PUBLIC.CUSTOMERS"))
+
+	// When
+	aps := syncFromFake(t, fake, configMap)
+
+	// Then
+	assert.NotContains(t, aps, supportRoleName)
+
+	salesAp := aps[salesRoleName]
+	require.NotNil(t, salesAp)
+	assert.Equal(t, []string{"ALICE"}, salesAp.Who.Users)
+	assert.ElementsMatch(t, []string{"SELECT", "INSERT"}, whatPermissions(salesAp.What, "SALES_DB.PUBLIC.CUSTOMERS"))
+	assert.NotContains(t, salesAp.What, sync_from_target.WhatItem{DataObject: &data_source.DataObjectReference{FullName: "SALES_DB.PUBLIC.ORDERS"}, Permissions: []string{"SELECT"}})
+}
+
+func TestRoundTrip_MasksAndFilters(t *testing.T) {
+	// Given
+	fake := newRoundTripFake()
+	configMap := &config.ConfigMap{Parameters: map[string]string{SfSkipTags: "true"}}
+
+	existingMask := fake.addPolicy("MASKING_POLICY", "SALES_DB", "PUBLIC", "EMAIL_MASK", "(val VARCHAR)", "VARCHAR", "'***'")
+	fake.attachPolicy(existingMask, "SALES_DB", "PUBLIC", "CUSTOMERS", "EMAIL")
+
+	mask := &importer.AccessProvider{
+		Id:     "ap-mask",
+		Name:   "Mask",
+		Action: types.Mask,
+		Type:   ptr.String(SHA256MaskId),
+		Who: importer.WhoItem{
+			Users: []string{"ALICE"},
+		},
+		What: []importer.WhatItem{
+			{DataObject: &data_source.DataObjectReference{FullName: "SALES_DB.PUBLIC.ORDERS.NOTE", Type: data_source.Column}},
+		},
+	}
+
+	filter := &importer.AccessProvider{
+		Id:     "ap-filter",
+		Name:   "Filter",
+		Action: types.Filtered,
+		Who: importer.WhoItem{
+			Users: []string{"BOB"},
+		},
+		What: []importer.WhatItem{
+			{DataObject: &data_source.DataObjectReference{FullName: "SALES_DB.PUBLIC.ORDERS", Type: data_source.Table}},
+		},
+		PolicyRule: ptr.String("{COUNTRY} = 'BE'"),
+	}
+
+	// When
+	syncToFake(t, fake, configMap, mask, filter)
+
+	// Then
+	masks := fake.policiesOfKind("MASKING_POLICY")
+	require.Len(t, masks, 2)
+	assert.Equal(t, "EMAIL_MASK", masks[0].Name)
+	assert.Equal(t, "VARCHAR", masks[1].Description.ReturnType)
+	assert.Equal(t, "NOTE", masks[1].References[0].REF_COLUMN_NAME.String)
+
+	filters := fake.policiesOfKind("ROW_ACCESS_POLICY")
+	require.Len(t, filters, 1)
+	assert.Equal(t, "(COUNTRY VARCHAR)", filters[0].Description.Signature)
+	assert.Equal(t, "ORDERS", filters[0].References[0].REF_ENTITY_NAME)
+
+	// When
+	aps := syncFromFake(t, fake, configMap)
+
+	// Then the masks created by Raito are not imported
+	existingMaskAp := aps["SALES_DB-PUBLIC-EMAIL_MASK"]
+	require.NotNil(t, existingMaskAp)
+	assert.Equal(t, types.Mask, existingMaskAp.Action)
+	assert.Equal(t, "'***'", existingMaskAp.Policy)
+	assert.Equal(t, []sync_from_target.WhatItem{{DataObject: &data_source.DataObjectReference{FullName: "SALES_DB.PUBLIC.CUSTOMERS.EMAIL", Type: "COLUMN"}, Permissions: []string{}}}, existingMaskAp.What)
+
+	filterAp := aps["SALES_DB-PUBLIC-"+filters[0].Name]
+	require.NotNil(t, filterAp)
+	assert.Equal(t, types.Filtered, filterAp.Action)
+	assert.Equal(t, []sync_from_target.WhatItem{{DataObject: &data_source.DataObjectReference{FullName: "SALES_DB.PUBLIC.ORDERS", Type: "TABLE"}, Permissions: []string{}}}, filterAp.What)
+}
+
+func TestRoundTrip_DynamicTables(t *testing.T) {
+	// Given
+	fake := newRoundTripFake()
+	fake.addDynamicTable("SALES_DB", "PUBLIC", "DAILY_REVENUE", "DAY", "DATE", "REVENUE", "NUMBER", "BEST_CUSTOMER", "VARCHAR")
+
+	configMap := &config.ConfigMap{Parameters: map[string]string{SfSkipTags: "true"}}
+
+	analyst := &importer.AccessProvider{
+		Id:         "ap-analyst",
+		Action:     types.Grant,
+		Type:       ptr.String(access_provider.Role),
+		Name:       "Analyst",
+		NamingHint: "Analyst",
+		Who: importer.WhoItem{
+			Users: []string{"ALICE"},
+		},
+		What: []importer.WhatItem{
+			{DataObject: &data_source.DataObjectReference{FullName: "SALES_DB.PUBLIC.DAILY_REVENUE", Type: DynamicTable}, Permissions: []string{"SELECT", "MONITOR", "INSERT"}},
+		},
+	}
+
+	mask := &importer.AccessProvider{
+		Id:     "ap-mask",
+		Name:   "Mask",
+		Action: types.Mask,
+		Type:   ptr.String(SHA256MaskId),
+		Who: importer.WhoItem{
+			Users: []string{"BOB"},
+		},
+		What: []importer.WhatItem{
+			{DataObject: &data_source.DataObjectReference{FullName: "SALES_DB.PUBLIC.DAILY_REVENUE.BEST_CUSTOMER", Type: data_source.Column}},
+		},
+	}
+
+	// When
+	feedback := syncToFake(t, fake, configMap, analyst, mask)
+
+	// Then INSERT is not granted as it does not apply to dynamic tables
+	analystRole := fake.accountRole(feedback["ap-analyst"].ActualName)
+	require.NotNil(t, analystRole)
+	assert.ElementsMatch(t, []string{"SELECT", "MONITOR"}, analystRole.privilegesOn("DYNAMIC_TABLE", "SALES_DB.PUBLIC.DAILY_REVENUE"))
+	assert.Equal(t, []string{"USAGE"}, analystRole.privilegesOn("SCHEMA", "SALES_DB.PUBLIC"))
+
+	masks := fake.policiesOfKind("MASKING_POLICY")
+	require.Len(t, masks, 1)
+	assert.Equal(t, "DAILY_REVENUE", masks[0].References[0].REF_ENTITY_NAME)
+	assert.Equal(t, "BEST_CUSTOMER", masks[0].References[0].REF_COLUMN_NAME.String)
+
+	// When
+	aps := syncFromFake(t, fake, configMap)
+
+	// Then
+	analystAp := aps[*feedback["ap-analyst"].ExternalId]
+	require.NotNil(t, analystAp)
+	assert.ElementsMatch(t, []string{"SELECT", "MONITOR"}, whatPermissions(analystAp.What, "SALES_DB.PUBLIC.DAILY_REVENUE"))
+}
+
+func TestRoundTrip_Stages(t *testing.T) {
+	// Given
+	fake := newRoundTripFake()
+	fake.addSchemaObject("STAGE", "SALES_DB", "PUBLIC", "LANDING")
+	fake.addSchemaObject("STAGE", "SALES_DB", "PUBLIC", "EXPORT")
+
+	configMap := &config.ConfigMap{Parameters: map[string]string{SfSkipTags: "true"}}
+
+	loader := &importer.AccessProvider{
+		Id:         "ap-loader",
+		Action:     types.Grant,
+		Type:       ptr.String(access_provider.Role),
+		Name:       "Loader",
+		NamingHint: "Loader",
+		Who: importer.WhoItem{
+			Users: []string{"ALICE"},
+		},
+		What: []importer.WhatItem{
+			{DataObject: &data_source.DataObjectReference{FullName: "SALES_DB.PUBLIC.LANDING", Type: Stage}, Permissions: []string{"WRITE"}},
+			{DataObject: &data_source.DataObjectReference{FullName: "SALES_DB.PUBLIC.EXPORT", Type: Stage}, Permissions: []string{"USAGE", "SELECT"}},
+		},
+	}
+
+	// When
+	feedback := syncToFake(t, fake, configMap, loader)
+
+	// Then READ is granted along with WRITE and SELECT is skipped as it does not apply to stages
+	loaderRole := fake.accountRole(feedback["ap-loader"].ActualName)
+	require.NotNil(t, loaderRole)
+	assert.ElementsMatch(t, []string{"READ", "WRITE"}, loaderRole.privilegesOn("STAGE", "SALES_DB.PUBLIC.LANDING"))
+	assert.Equal(t, []string{"USAGE"}, loaderRole.privilegesOn("STAGE", "SALES_DB.PUBLIC.EXPORT"))
+	assert.Equal(t, []string{"USAGE"}, loaderRole.privilegesOn("SCHEMA", "SALES_DB.PUBLIC"))
+	assert.Equal(t, []string{"USAGE"}, loaderRole.privilegesOn("DATABASE", "SALES_DB"))
+
+	// When
+	aps := syncFromFake(t, fake, configMap)
+
+	// Then
+	loaderAp := aps[*feedback["ap-loader"].ExternalId]
+	require.NotNil(t, loaderAp)
+	assert.ElementsMatch(t, []string{"READ", "WRITE"}, whatPermissions(loaderAp.What, "SALES_DB.PUBLIC.LANDING"))
+	assert.Equal(t, []string{"USAGE"}, whatPermissions(loaderAp.What, "SALES_DB.PUBLIC.EXPORT"))
+}
+
+func TestRoundTrip_StreamsTasksAndPipes(t *testing.T) {
+	// Given
+	fake := newRoundTripFake()
+	fake.addSchemaObject("STREAM", "SALES_DB", "PUBLIC", "ORDER_CHANGES")
+	fake.addSchemaObject("TASK", "SALES_DB", "PUBLIC", "MERGE_ORDERS")
+	fake.addSchemaObject("PIPE", "SALES_DB", "PUBLIC", "LOAD_ORDERS")
+
+	configMap := &config.ConfigMap{Parameters: map[string]string{SfSkipTags: "true"}}
+
+	engineer := &importer.AccessProvider{
+		Id:         "ap-engineer",
+		Action:     types.Grant,
+		Type:       ptr.String(access_provider.Role),
+		Name:       "Engineer",
+		NamingHint: "Engineer",
+		Who: importer.WhoItem{
+			Users: []string{"BOB"},
+		},
+		What: []importer.WhatItem{
+			{DataObject: &data_source.DataObjectReference{FullName: "SALES_DB.PUBLIC.ORDER_CHANGES", Type: Stream}, Permissions: []string{"SELECT"}},
+			{DataObject: &data_source.DataObjectReference{FullName: "SALES_DB.PUBLIC.MERGE_ORDERS", Type: Task}, Permissions: []string{"MONITOR", "OPERATE"}},
+			{DataObject: &data_source.DataObjectReference{FullName: "SALES_DB.PUBLIC.LOAD_ORDERS", Type: Pipe}, Permissions: []string{"MONITOR", "SELECT"}},
+		},
+	}
+
+	// When
+	feedback := syncToFake(t, fake, configMap, engineer)
+
+	// Then
+	engineerRole := fake.accountRole(feedback["ap-engineer"].ActualName)
+	require.NotNil(t, engineerRole)
+	assert.Equal(t, []string{"SELECT"}, engineerRole.privilegesOn("STREAM", "SALES_DB.PUBLIC.ORDER_CHANGES"))
+	assert.ElementsMatch(t, []string{"MONITOR", "OPERATE"}, engineerRole.privilegesOn("TASK", "SALES_DB.PUBLIC.MERGE_ORDERS"))
+	assert.Equal(t, []string{"MONITOR"}, engineerRole.privilegesOn("PIPE", "SALES_DB.PUBLIC.LOAD_ORDERS"))
+	assert.Equal(t, []string{"USAGE"}, engineerRole.privilegesOn("SCHEMA", "SALES_DB.PUBLIC"))
+
+	// When
+	aps := syncFromFake(t, fake, configMap)
+
+	// Then
+	engineerAp := aps[*feedback["ap-engineer"].ExternalId]
+	require.NotNil(t, engineerAp)
+	assert.Equal(t, []string{"SELECT"}, whatPermissions(engineerAp.What, "SALES_DB.PUBLIC.ORDER_CHANGES"))
+	assert.ElementsMatch(t, []string{"MONITOR", "OPERATE"}, whatPermissions(engineerAp.What, "SALES_DB.PUBLIC.MERGE_ORDERS"))
+	assert.Equal(t, []string{"MONITOR"}, whatPermissions(engineerAp.What, "SALES_DB.PUBLIC.LOAD_ORDERS"))
+}
+
+func TestRoundTrip_SequencesFileFormatsAndSecrets(t *testing.T) {
+	// Given
+	fake := newRoundTripFake()
+	fake.addSchemaObject("SEQUENCE", "SALES_DB", "PUBLIC", "ORDER_IDS")
+	fake.addSchemaObject("FILE_FORMAT", "SALES_DB", "PUBLIC", "CSV")
+	fake.addSchemaObject("SECRET", "SALES_DB", "PUBLIC", "API_KEY")
+
+	configMap := &config.ConfigMap{Parameters: map[string]string{SfSkipTags: "true"}}
+
+	service := &importer.AccessProvider{
+		Id:         "ap-service",
+		Action:     types.Grant,
+		Type:       ptr.String(access_provider.Role),
+		Name:       "Service",
+		NamingHint: "Service",
+		Who: importer.WhoItem{
+			Users: []string{"CAROL"},
+		},
+		What: []importer.WhatItem{
+			{DataObject: &data_source.DataObjectReference{FullName: "SALES_DB.PUBLIC.ORDER_IDS", Type: Sequence}, Permissions: []string{"USAGE"}},
+			{DataObject: &data_source.DataObjectReference{FullName: "SALES_DB.PUBLIC.CSV", Type: FileFormat}, Permissions: []string{"USAGE"}},
+			{DataObject: &data_source.DataObjectReference{FullName: "SALES_DB.PUBLIC.API_KEY", Type: Secret}, Permissions: []string{"USAGE", "READ"}},
+		},
+	}
+
+	// When
+	feedback := syncToFake(t, fake, configMap, service)
+
+	// Then
+	serviceRole := fake.accountRole(feedback["ap-service"].ActualName)
+	require.NotNil(t, serviceRole)
+	assert.Equal(t, []string{"USAGE"}, serviceRole.privilegesOn("SEQUENCE", "SALES_DB.PUBLIC.ORDER_IDS"))
+	assert.Equal(t, []string{"USAGE"}, serviceRole.privilegesOn("FILE_FORMAT", "SALES_DB.PUBLIC.CSV"))
+	assert.ElementsMatch(t, []string{"USAGE", "READ"}, serviceRole.privilegesOn("SECRET", "SALES_DB.PUBLIC.API_KEY"))
+
+	// When the secret is no longer needed
+	service.What = service.What[:2]
+
+	feedback = syncToFake(t, fake, configMap, service)
+
+	// Then its grants are revoked
+	serviceRole = fake.accountRole(feedback["ap-service"].ActualName)
+	require.NotNil(t, serviceRole)
+	assert.Empty(t, serviceRole.privilegesOn("SECRET", "SALES_DB.PUBLIC.API_KEY"))
+	assert.Equal(t, []string{"USAGE"}, serviceRole.privilegesOn("FILE_FORMAT", "SALES_DB.PUBLIC.CSV"))
+
+	// When
+	aps := syncFromFake(t, fake, configMap)
+
+	// Then
+	serviceAp := aps[*feedback["ap-service"].ExternalId]
+	require.NotNil(t, serviceAp)
+	assert.Equal(t, []string{"USAGE"}, whatPermissions(serviceAp.What, "SALES_DB.PUBLIC.ORDER_IDS"))
+	assert.Equal(t, []string{"USAGE"}, whatPermissions(serviceAp.What, "SALES_DB.PUBLIC.CSV"))
+	assert.Empty(t, whatPermissions(serviceAp.What, "SALES_DB.PUBLIC.API_KEY"))
+}
+
+func TestRoundTrip_SnowparkContainerServices(t *testing.T) {
+	// Given
+	fake := newRoundTripFake()
+	fake.addComputePool("GPU_POOL")
+	fake.addSchemaObject("IMAGE_REPOSITORY", "SALES_DB", "PUBLIC", "IMAGES")
+	fake.addSchemaObject("SERVICE", "SALES_DB", "PUBLIC", "SCORING")
+
+	configMap := &config.ConfigMap{Parameters: map[string]string{SfSkipTags: "true"}}
+
+	mlEngineers := &importer.AccessProvider{
+		Id:         "ap-ml",
+		Action:     types.Grant,
+		Type:       ptr.String(access_provider.Role),
+		Name:       "ML Engineers",
+		NamingHint: "ML_ENGINEERS",
+		Who: importer.WhoItem{
+			Users: []string{"ALICE"},
+		},
+		What: []importer.WhatItem{
+			{DataObject: &data_source.DataObjectReference{FullName: "GPU_POOL", Type: ComputePool}, Permissions: []string{"USAGE", "MONITOR", "OPERATE", "DELETE"}},
+			{DataObject: &data_source.DataObjectReference{FullName: "SALES_DB.PUBLIC.IMAGES", Type: ImageRepository}, Permissions: []string{"READ", "WRITE"}},
+			{DataObject: &data_source.DataObjectReference{FullName: "SALES_DB.PUBLIC.SCORING", Type: Service}, Permissions: []string{"USAGE"}},
+		},
+	}
+
+	// When
+	feedback := syncToFake(t, fake, configMap, mlEngineers)
+
+	// Then
+	mlRole := fake.accountRole(feedback["ap-ml"].ActualName)
+	require.NotNil(t, mlRole)
+	assert.ElementsMatch(t, []string{"USAGE", "MONITOR", "OPERATE"}, mlRole.privilegesOn("COMPUTE_POOL", "GPU_POOL"))
+	assert.ElementsMatch(t, []string{"READ", "WRITE"}, mlRole.privilegesOn("IMAGE_REPOSITORY", "SALES_DB.PUBLIC.IMAGES"))
+	assert.Equal(t, []string{"USAGE"}, mlRole.privilegesOn("SERVICE", "SALES_DB.PUBLIC.SCORING"))
+	assert.Equal(t, []string{"USAGE"}, mlRole.privilegesOn("SCHEMA", "SALES_DB.PUBLIC"))
+
+	// When
+	aps := syncFromFake(t, fake, configMap)
+
+	// Then
+	mlAp := aps[*feedback["ap-ml"].ExternalId]
+	require.NotNil(t, mlAp)
+	assert.ElementsMatch(t, []string{"USAGE", "MONITOR", "OPERATE"}, whatPermissions(mlAp.What, "GPU_POOL"))
+	assert.ElementsMatch(t, []string{"READ", "WRITE"}, whatPermissions(mlAp.What, "SALES_DB.PUBLIC.IMAGES"))
+	assert.Equal(t, []string{"USAGE"}, whatPermissions(mlAp.What, "SALES_DB.PUBLIC.SCORING"))
+}
+
+func TestRoundTrip_Shares(t *testing.T) {
+	// Given
+	fake := newRoundTripFake()
+	configMap := &config.ConfigMap{Parameters: map[string]string{}}
+
+	share := &importer.AccessProvider{
+		Id:         "ap-share",
+		Name:       "Partners",
+		NamingHint: "Partners",
+		Action:     types.Share,
+		Who: importer.WhoItem{
+			Recipients: []string{"PARTNER.ACCOUNT1"},
+		},
+		What: []importer.WhatItem{
+			{DataObject: &data_source.DataObjectReference{FullName: "SALES_DB.PUBLIC.ORDERS", Type: data_source.Table}, Permissions: []string{"SELECT"}},
+		},
+	}
+
+	// When
+	feedback := syncToFake(t, fake, configMap, share)
+
+	// Then
+	sfShare := fake.share(feedback["ap-share"].ActualName)
+	require.NotNil(t, sfShare)
+	assert.Equal(t, "SALES_DB", sfShare.Database)
+	assert.Equal(t, []string{"PARTNER.ACCOUNT1"}, sfShare.Accounts)
+	assert.Contains(t, sfShare.Grants, GrantToRole{Privilege: "SELECT", GrantedOn: "TABLE", Name: "SALES_DB.PUBLIC.ORDERS"})
+
+	// When
+	aps := syncFromFake(t, fake, configMap)
+
+	// Then
+	shareAp := aps[*feedback["ap-share"].ExternalId]
+	require.NotNil(t, shareAp)
+	assert.Equal(t, types.Share, shareAp.Action)
+	assert.Equal(t, []string{"PARTNER.ACCOUNT1"}, shareAp.Who.Recipients)
+	assert.Equal(t, ptr.String("SALES_DB"), shareAp.CommonWhatDataObject)
+	assert.Contains(t, shareAp.What, sync_from_target.WhatItem{DataObject: &data_source.DataObjectReference{FullName: "SALES_DB.PUBLIC.ORDERS"}, Permissions: []string{"SELECT"}})
+}
+
+func TestRoundTrip_OwnerTags(t *testing.T) {
+	// Given
+	fake := newRoundTripFake()
+	configMap := &config.ConfigMap{Parameters: map[string]string{SfRoleOwnerEmailTag: "GOVERNANCE.TAGS.OWNER_EMAIL"}}
+
+	sales := &importer.AccessProvider{
+		Id:         "ap-sales",
+		Action:     types.Grant,
+		Type:       ptr.String(access_provider.Role),
+		Name:       "Sales",
+		NamingHint: "Sales",
+		Who: importer.WhoItem{
+			Users: []string{"ALICE"},
+		},
+		Owners: []importer.Owner{{Email: ptr.String("alice@raito.io")}},
+	}
+
+	// When
+	feedback := syncToFake(t, fake, configMap, sales)
+	aps := syncFromFake(t, fake, configMap)
+
+	// Then
+	salesAp := aps[*feedback["ap-sales"].ExternalId]
+	require.NotNil(t, salesAp)
+	require.Len(t, salesAp.Tags, 1)
+	assert.Equal(t, "OWNER_EMAIL", salesAp.Tags[0].Key)
+	assert.Equal(t, "email:alice@raito.io", salesAp.Tags[0].Value)
+}
+
+func TestRoundTrip_PlannedSyncNotSupported(t *testing.T) {
+	// Given
+	fake := newRoundTripFake()
+	configMap := &config.ConfigMap{Parameters: map[string]string{SfMaxRevokes: "10"}}
+
+	sales := &importer.AccessProvider{
+		Id:         "ap-sales",
+		Action:     types.Grant,
+		Type:       ptr.String(access_provider.Role),
+		Name:       "Sales",
+		NamingHint: "Sales",
+		Who: importer.WhoItem{
+			Users: []string{"ALICE"},
+		},
+	}
+
+	// When
+	err := createAccessSyncer(fake).SyncAccessProviderToTarget(context.Background(), &importer.AccessProviderImport{AccessProviders: []*importer.AccessProvider{sales}}, mocks.NewSimpleAccessProviderFeedbackHandler(t), configMap)
+
+	// Then
+	require.ErrorContains(t, err, "planning the changes is not supported by the repository")
+	assert.Empty(t, fake.accountRoles)
+}
+
+func newRoundTripFake() *fakeSnowflake {
+	fake := newFakeSnowflake()
+
+	fake.addTable("SALES_DB", "PUBLIC", "ORDERS", "BASE TABLE", "ID", "NUMBER", "AMOUNT", "NUMBER", "COUNTRY", "VARCHAR", "NOTE", "VARCHAR")
+	fake.addTable("SALES_DB", "PUBLIC", "CUSTOMERS", "BASE TABLE", "ID", "NUMBER", "EMAIL", "VARCHAR")
+	fake.addUser("ALICE", "alice@raito.io")
+	fake.addUser("BOB", "bob@raito.io")
+	fake.addUser("CAROL", "carol@raito.io")
+
+	return fake
+}
+
+// syncToFake exports the access providers to the fake and returns the feedback by access provider id
+func syncToFake(t *testing.T, fake *fakeSnowflake, configMap *config.ConfigMap, accessProviders ...*importer.AccessProvider) map[string]importer.AccessProviderSyncFeedback {
+	t.Helper()
+
+	feedbackHandler := mocks.NewSimpleAccessProviderFeedbackHandler(t)
+
+	err := createAccessSyncer(fake).SyncAccessProviderToTarget(context.Background(), &importer.AccessProviderImport{AccessProviders: accessProviders}, feedbackHandler, configMap)
+	require.NoError(t, err)
+
+	feedback := make(map[string]importer.AccessProviderSyncFeedback)
+
+	for _, apFeedback := range feedbackHandler.AccessProviderFeedback {
+		assert.Empty(t, apFeedback.Errors, "feedback for %s", apFeedback.AccessProvider)
+
+		feedback[apFeedback.AccessProvider] = apFeedback
+	}
+
+	return feedback
+}
+
+// syncFromFake imports the access providers from the fake and returns them by external id
+func syncFromFake(t *testing.T, fake *fakeSnowflake, configMap *config.ConfigMap) map[string]*sync_from_target.AccessProvider {
+	t.Helper()
+
+	accessProviderHandler := mocks.NewSimpleAccessProviderHandler(t, 100)
+
+	err := createAccessSyncer(fake).SyncAccessProvidersFromTarget(context.Background(), accessProviderHandler, configMap)
+	require.NoError(t, err)
+
+	aps := make(map[string]*sync_from_target.AccessProvider)
+
+	for i := range accessProviderHandler.AccessProviders {
+		aps[accessProviderHandler.AccessProviders[i].ExternalId] = &accessProviderHandler.AccessProviders[i]
+	}
+
+	return aps
+}
+
+// whatPermissions returns the permissions of the what items on the data object with the given full name, as their order is not deterministic
+func whatPermissions(what []sync_from_target.WhatItem, fullName string) []string {
+	var permissions []string
+
+	for _, item := range what {
+		if item.DataObject.FullName == fullName {
+			permissions = append(permissions, item.Permissions...)
+		}
+	}
+
+	return permissions
+}
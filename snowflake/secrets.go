@@ -0,0 +1,184 @@
+package snowflake
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+const (
+	secretReferenceFilePrefix = "file:"
+	secretReferenceEnvPrefix  = "env:"
+	secretReferenceExecPrefix = "exec:"
+
+	secretExecTimeout = 30 * time.Second
+)
+
+// sensitiveParameters are the plugin parameters that support secret references.
+var sensitiveParameters = []string{
+	SfPassword,
+	SfPrivateKey,
+	SfPrivateKeyPassphrase,
+	SfPrivateKeySecondary,
+	SfPrivateKeySecondaryPassphrase,
+	SfPat,
+	SfOAuthClientSecret,
+}
+
+// resolveSecretParameters returns a copy of the parameters in which all secret references of the sensitive parameters are replaced by their actual value.
+// A secret reference has one of the following formats:
+//   - file:/path/to/file: the content of the file
+//   - env:NAME: the value of the environment variable
+//   - exec:command args: the output of the command, e.g. a local helper binary fetching the secret from a secret manager.
+//     Arguments are split like a shell does, so they can contain spaces or quotes when quoted or escaped (e.g. exec:helper --name 'my secret')
+//
+// The resolved values are never logged.
+func resolveSecretParameters(ctx context.Context, params map[string]string) (map[string]string, error) {
+	resolvedParams := maps.Clone(params)
+
+	for _, param := range sensitiveParameters {
+		value, found := params[param]
+		if !found || value == "" {
+			continue
+		}
+
+		resolvedValue, err := resolveSecretReference(ctx, value)
+		if err != nil {
+			return nil, fmt.Errorf("resolve secret reference of parameter %q: %w", param, err)
+		}
+
+		resolvedParams[param] = resolvedValue
+	}
+
+	return resolvedParams, nil
+}
+
+func resolveSecretReference(ctx context.Context, value string) (string, error) {
+	if file, found := strings.CutPrefix(value, secretReferenceFilePrefix); found {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return "", fmt.Errorf("opening file %q: %w", file, err)
+		}
+
+		return strings.TrimRight(string(content), "\r\n"), nil
+	}
+
+	if envVar, found := strings.CutPrefix(value, secretReferenceEnvPrefix); found {
+		envValue, exists := os.LookupEnv(envVar)
+		if !exists {
+			return "", fmt.Errorf("environment variable %q is not set", envVar)
+		}
+
+		return envValue, nil
+	}
+
+	if command, found := strings.CutPrefix(value, secretReferenceExecPrefix); found {
+		return executeSecretCommand(ctx, command)
+	}
+
+	return value, nil
+}
+
+func executeSecretCommand(ctx context.Context, command string) (string, error) {
+	args, err := splitCommandArguments(command)
+	if err != nil {
+		return "", err
+	}
+
+	if len(args) == 0 {
+		return "", errors.New("no command specified")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, secretExecTimeout)
+	defer cancel()
+
+	// The output is not included in any error, as it could contain (parts of) the secret
+	output, err := exec.CommandContext(ctx, args[0], args[1:]...).Output() //nolint:gosec
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return "", fmt.Errorf("command %q exited with code %d", args[0], exitErr.ExitCode())
+		}
+
+		return "", fmt.Errorf("execute command %q: %w", args[0], err)
+	}
+
+	return strings.TrimRight(string(output), "\r\n"), nil
+}
+
+// splitCommandArguments splits the command in its arguments like a POSIX shell does, without expanding variables or globs.
+// Single quotes preserve every character, within double quotes a backslash only escapes \, ", $ and `, outside quotes it escapes any character.
+func splitCommandArguments(command string) ([]string, error) {
+	var args []string
+
+	var current strings.Builder
+
+	inArg := false
+
+	runes := []rune(command)
+
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+
+		switch {
+		case r == ' ' || r == '\t' || r == '\n':
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+
+				inArg = false
+			}
+		case r == '\\':
+			inArg = true
+
+			if i+1 < len(runes) {
+				i++
+				current.WriteRune(runes[i])
+			}
+		case r == '\'':
+			inArg = true
+
+			end := i + 1
+			for end < len(runes) && runes[end] != '\'' {
+				end++
+			}
+
+			if end == len(runes) {
+				return nil, errors.New("unterminated single quote in command")
+			}
+
+			current.WriteString(string(runes[i+1 : end]))
+			i = end
+		case r == '"':
+			inArg = true
+
+			i++
+			for ; i < len(runes) && runes[i] != '"'; i++ {
+				if runes[i] == '\\' && i+1 < len(runes) && strings.ContainsRune(`\"$`+"`", runes[i+1]) {
+					i++
+				}
+
+				current.WriteRune(runes[i])
+			}
+
+			if i == len(runes) {
+				return nil, errors.New("unterminated double quote in command")
+			}
+		default:
+			inArg = true
+
+			current.WriteRune(r)
+		}
+	}
+
+	if inArg {
+		args = append(args, current.String())
+	}
+
+	return args, nil
+}
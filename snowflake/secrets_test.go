@@ -0,0 +1,96 @@
+package snowflake
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveSecretParameters(t *testing.T) {
+	secretFile := filepath.Join(t.TempDir(), "password")
+	require.NoError(t, os.WriteFile(secretFile, []byte("file-secret\n"), 0600))
+
+	t.Setenv("RAITO_TEST_SECRET", "env-secret")
+
+	params := map[string]string{
+		SfUser:                 "env:RAITO_TEST_SECRET",
+		SfPassword:             "file:" + secretFile,
+		SfPrivateKeyPassphrase: "env:RAITO_TEST_SECRET",
+		SfPat:                  "exec:echo exec-secret",
+		SfOAuthClientSecret:    "plain-secret",
+	}
+
+	resolvedParams, err := resolveSecretParameters(context.Background(), params)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]string{
+		SfUser:                 "env:RAITO_TEST_SECRET",
+		SfPassword:             "file-secret",
+		SfPrivateKeyPassphrase: "env-secret",
+		SfPat:                  "exec-secret",
+		SfOAuthClientSecret:    "plain-secret",
+	}, resolvedParams)
+
+	// The original parameters are left untouched
+	assert.Equal(t, "file:"+secretFile, params[SfPassword])
+}
+
+func TestResolveSecretParameters_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+	}{
+		{name: "missing file", value: "file:" + filepath.Join(t.TempDir(), "does-not-exist")},
+		{name: "missing environment variable", value: "env:RAITO_TEST_SECRET_NOT_SET"},
+		{name: "failing command", value: "exec:false"},
+		{name: "empty command", value: "exec:"},
+		{name: "unterminated quote", value: "exec:echo 'secret"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := resolveSecretParameters(context.Background(), map[string]string{SfPassword: tt.value})
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), SfPassword)
+		})
+	}
+}
+
+func TestSplitCommandArguments(t *testing.T) {
+	tests := []struct {
+		command  string
+		expected []string
+	}{
+		{command: "helper --name secret", expected: []string{"helper", "--name", "secret"}},
+		{command: "  helper\t--name  ", expected: []string{"helper", "--name"}},
+		{command: `helper --name 'my secret'`, expected: []string{"helper", "--name", "my secret"}},
+		{command: `helper --name "my \"quoted\" secret"`, expected: []string{"helper", "--name", `my "quoted" secret`}},
+		{command: `helper --name my\ secret`, expected: []string{"helper", "--name", "my secret"}},
+		{command: `helper --path 'C:\secrets'`, expected: []string{"helper", "--path", `C:\secrets`}},
+		{command: `helper --name=prefix"'quoted'"`, expected: []string{"helper", "--name=prefix'quoted'"}},
+		{command: `helper ''`, expected: []string{"helper", ""}},
+		{command: "", expected: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.command, func(t *testing.T) {
+			args, err := splitCommandArguments(tt.command)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, args)
+		})
+	}
+
+	_, err := splitCommandArguments(`helper "secret`)
+	require.Error(t, err)
+}
+
+func TestResolveSecretParameters_QuotedCommandArguments(t *testing.T) {
+	resolvedParams, err := resolveSecretParameters(context.Background(), map[string]string{SfPassword: `exec:printf '%s|%s' "my secret" 'it''s'`})
+	require.NoError(t, err)
+
+	assert.Equal(t, "my secret|its", resolvedParams[SfPassword])
+}
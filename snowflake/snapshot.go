@@ -0,0 +1,658 @@
+package snowflake
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/raito-io/cli/base/access_provider"
+	"github.com/raito-io/cli/base/util/slice"
+	"github.com/raito-io/golang-set/set"
+
+	"github.com/raito-io/cli-plugin-snowflake/common"
+)
+
+const (
+	maskingPolicyKind   = "MASKING"
+	rowAccessPolicyKind = "ROW ACCESS"
+)
+
+// Snapshot is the state of the roles, masks, filters and shares before they were changed by the access sync to target.
+// It is written to the sf-snapshot-file and can be restored with the sf-restore-snapshot-file parameter.
+type Snapshot struct {
+	RunId     string            `json:"runId"`
+	CreatedAt time.Time         `json:"createdAt"`
+	Roles     []*RoleSnapshot   `json:"roles"`
+	Policies  []*PolicySnapshot `json:"policies"`
+	Shares    []*ShareSnapshot  `json:"shares"`
+
+	captured set.Set[string]
+}
+
+type RoleSnapshot struct {
+	ExternalId string `json:"externalId"`
+	Type       string `json:"type"`
+	// Exists is false for a role that was created by the sync
+	Exists bool `json:"exists"`
+	// RenamedTo is the new name of a role that was renamed by the sync
+	RenamedTo string        `json:"renamedTo,omitempty"`
+	GrantsTo  []GrantToRole `json:"grantsTo"`
+	GrantsOf  []GrantOfRole `json:"grantsOf"`
+}
+
+type PolicySnapshot struct {
+	Kind     string `json:"kind"`
+	Database string `json:"database"`
+	Schema   string `json:"schema"`
+	Name     string `json:"name"`
+	// Table is the table of a filter that was created by the sync
+	Table string `json:"table,omitempty"`
+	// Exists is false for a policy that was created by the sync
+	Exists     bool              `json:"exists"`
+	Signature  string            `json:"signature,omitempty"`
+	ReturnType string            `json:"returnType,omitempty"`
+	Body       string            `json:"body,omitempty"`
+	References []PolicyReference `json:"references,omitempty"`
+}
+
+type PolicyReference struct {
+	Database   string   `json:"database"`
+	Schema     string   `json:"schema"`
+	Entity     string   `json:"entity"`
+	Domain     string   `json:"domain"`
+	Column     string   `json:"column,omitempty"`
+	ArgColumns []string `json:"argColumns,omitempty"`
+}
+
+type ShareSnapshot struct {
+	Name string `json:"name"`
+	// Exists is false for a share that was created by the sync
+	Exists   bool          `json:"exists"`
+	Grants   []GrantToRole `json:"grants"`
+	Accounts []string      `json:"accounts"`
+}
+
+func newSnapshot() *Snapshot {
+	return &Snapshot{RunId: runId, CreatedAt: time.Now().UTC(), captured: set.NewSet[string]()}
+}
+
+// shouldCapture returns true the first time it is called for a key, as only the state before the first change must be kept
+func (s *Snapshot) shouldCapture(key string) bool {
+	if s == nil || s.captured.Contains(key) {
+		return false
+	}
+
+	s.captured.Add(key)
+
+	return true
+}
+
+func (s *Snapshot) write(path string) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal snapshot: %w", err)
+	}
+
+	return writeFileAtomically(path, data)
+}
+
+func readSnapshot(path string) (*Snapshot, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read snapshot %q: %w", path, err)
+	}
+
+	var snapshot Snapshot
+
+	err = json.Unmarshal(data, &snapshot)
+	if err != nil {
+		return nil, fmt.Errorf("parse snapshot %q: %w", path, err)
+	}
+
+	return &snapshot, nil
+}
+
+func isDoesNotExistError(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "does not exist")
+}
+
+func roleTypeOfExternalId(externalId string) string {
+	switch {
+	case isDatabaseRoleByExternalId(externalId):
+		return apTypeDatabaseRole
+	case isApplicationRoleByExternalId(externalId):
+		return apTypeApplicationRole
+	default:
+		return access_provider.Role
+	}
+}
+
+func policyName(database, schema, name string) string {
+	return common.FormatQuery("%s.%s.%s", database, schema, name)
+}
+
+//
+// Capturing the state before the changes
+//
+
+// captureRole adds the current grants to and of a role to the snapshot, before the role is changed for the first time
+func (s *AccessToTargetSyncer) captureRole(ctx context.Context, externalId string) error {
+	if !s.snapshot.shouldCapture("role:" + externalId) {
+		return nil
+	}
+
+	roleSnapshot := RoleSnapshot{ExternalId: externalId, Type: roleTypeOfExternalId(externalId)}
+
+	grantsTo, exists, err := s.currentGrantsToRole(ctx, externalId, roleSnapshot.Type)
+	if err != nil {
+		return fmt.Errorf("snapshot grants to role %q: %w", externalId, err)
+	}
+
+	roleSnapshot.Exists = exists
+	roleSnapshot.GrantsTo = grantsTo
+
+	if exists {
+		roleSnapshot.GrantsOf, err = s.accessSyncer.retrieveGrantsOfRole(ctx, externalId, roleSnapshot.Type)
+		if err != nil {
+			return fmt.Errorf("snapshot grants of role %q: %w", externalId, err)
+		}
+	}
+
+	s.snapshot.Roles = append(s.snapshot.Roles, &roleSnapshot)
+
+	return nil
+}
+
+// captureRoleRename registers that the sync renames a role, so it can be renamed back on restore
+func (s *AccessToTargetSyncer) captureRoleRename(oldExternalId, newExternalId string) {
+	if s.snapshot == nil {
+		return
+	}
+
+	for _, roleSnapshot := range s.snapshot.Roles {
+		if roleSnapshot.ExternalId == oldExternalId {
+			roleSnapshot.RenamedTo = newExternalId
+		}
+	}
+}
+
+// capturePolicy adds the body and references of an existing masking or row access policy to the snapshot
+func (s *AccessToTargetSyncer) capturePolicy(ctx context.Context, kind, database, schema, name string) error {
+	if !s.snapshot.shouldCapture(fmt.Sprintf("policy:%s:%s.%s.%s", kind, database, schema, name)) {
+		return nil
+	}
+
+	descriptions, err := s.repo.DescribePolicy(ctx, kind, database, schema, name)
+	if isDoesNotExistError(err) || (err == nil && len(descriptions) == 0) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("snapshot %s policy %s: %w", strings.ToLower(kind), policyName(database, schema, name), err)
+	}
+
+	references, err := s.repo.GetPolicyReferences(ctx, database, schema, name)
+	if err != nil {
+		return fmt.Errorf("snapshot references of %s policy %s: %w", strings.ToLower(kind), policyName(database, schema, name), err)
+	}
+
+	s.snapshot.Policies = append(s.snapshot.Policies, &PolicySnapshot{
+		Kind:       kind,
+		Database:   database,
+		Schema:     schema,
+		Name:       name,
+		Exists:     true,
+		Signature:  descriptions[0].Signature,
+		ReturnType: descriptions[0].ReturnType,
+		Body:       descriptions[0].Body,
+		References: convertPolicyReferences(references),
+	})
+
+	return nil
+}
+
+// captureCreatedPolicy registers a masking or row access policy created by the sync, so it can be dropped on restore
+func (s *AccessToTargetSyncer) captureCreatedPolicy(kind, database, schema, table, name string) {
+	if !s.snapshot.shouldCapture(fmt.Sprintf("policy:%s:%s.%s.%s", kind, database, schema, name)) {
+		return
+	}
+
+	s.snapshot.Policies = append(s.snapshot.Policies, &PolicySnapshot{Kind: kind, Database: database, Schema: schema, Table: table, Name: name})
+}
+
+// captureShare adds the current grants and accounts of a share to the snapshot
+func (s *AccessToTargetSyncer) captureShare(ctx context.Context, shareName string) error {
+	if !s.snapshot.shouldCapture("share:" + shareName) {
+		return nil
+	}
+
+	shareSnapshot := ShareSnapshot{Name: shareName, Exists: true}
+
+	grants, err := s.repo.GetGrantsToShare(ctx, shareName)
+	if isDoesNotExistError(err) {
+		shareSnapshot.Exists = false
+	} else if err != nil {
+		return fmt.Errorf("snapshot grants to share %q: %w", shareName, err)
+	}
+
+	shareSnapshot.Grants = grants
+
+	if shareSnapshot.Exists {
+		shareSnapshot.Accounts, err = s.currentShareAccounts(ctx, shareName)
+		if err != nil {
+			return err
+		}
+	}
+
+	s.snapshot.Shares = append(s.snapshot.Shares, &shareSnapshot)
+
+	return nil
+}
+
+// currentGrantsToRole returns the grants to a role and whether the role exists.
+// The grants to application roles are managed by the application, so they are not returned.
+func (s *AccessToTargetSyncer) currentGrantsToRole(ctx context.Context, externalId string, roleType string) ([]GrantToRole, bool, error) {
+	if isApplicationRole(&roleType) {
+		return nil, true, nil
+	}
+
+	grantsTo, err := s.accessSyncer.getGrantsToRole(ctx, externalId, &roleType)
+	if isDoesNotExistError(err) {
+		return nil, false, nil
+	} else if err != nil {
+		return nil, false, err
+	}
+
+	return grantsTo, true, nil
+}
+
+func (s *AccessToTargetSyncer) currentShareAccounts(ctx context.Context, shareName string) ([]string, error) {
+	shares, err := s.repo.GetOutboundShares(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get accounts of share %q: %w", shareName, err)
+	}
+
+	var accounts []string
+
+	for _, share := range shares {
+		if share.Name == shareName && strings.TrimSpace(share.To) != "" {
+			accounts = append(accounts, strings.TrimSpace(share.To))
+		}
+	}
+
+	return accounts, nil
+}
+
+func convertPolicyReferences(references []PolicyReferenceEntity) []PolicyReference {
+	result := make([]PolicyReference, 0, len(references))
+
+	for i := range references {
+		reference := PolicyReference{
+			Database: references[i].REF_DATABASE_NAME,
+			Schema:   references[i].REF_SCHEMA_NAME,
+			Entity:   references[i].REF_ENTITY_NAME,
+			Domain:   references[i].REF_ENTITY_DOMAIN,
+			Column:   references[i].REF_COLUMN_NAME.String,
+		}
+
+		// The argument columns are returned as a JSON array, e.g. [ "NAME", "COUNTRY" ]
+		if references[i].REF_ARG_COLUMN_NAMES.Valid && references[i].REF_ARG_COLUMN_NAMES.String != "" {
+			err := json.Unmarshal([]byte(references[i].REF_ARG_COLUMN_NAMES.String), &reference.ArgColumns)
+			if err != nil {
+				Logger.Warn(fmt.Sprintf("Unable to parse the argument columns %q of policy %s: %s", references[i].REF_ARG_COLUMN_NAMES.String, references[i].POLICY_NAME, err.Error()))
+			}
+		}
+
+		result = append(result, reference)
+	}
+
+	return result
+}
+
+func (r *PolicyReference) key() string {
+	return strings.ToUpper(fmt.Sprintf("%s.%s.%s.%s", r.Database, r.Schema, r.Entity, r.Column))
+}
+
+//
+// Restoring a snapshot
+//
+
+// restoreSnapshot puts the shares, policies and roles of the snapshot back in the state they had before the sync.
+// All objects are restored, even if restoring one of them fails.
+func (s *AccessToTargetSyncer) restoreSnapshot(ctx context.Context, snapshot *Snapshot) error {
+	var err error
+
+	for _, shareSnapshot := range snapshot.Shares {
+		restoreErr := s.restoreShare(ctx, shareSnapshot)
+		if restoreErr != nil {
+			err = multierror.Append(err, fmt.Errorf("restore share %q: %w", shareSnapshot.Name, restoreErr))
+		}
+	}
+
+	// Policies created by the sync are dropped first, so the original policies can be attached again
+	policies := slices.Clone(snapshot.Policies)
+	slices.SortStableFunc(policies, func(a, b *PolicySnapshot) int {
+		return compareBool(a.Exists, b.Exists)
+	})
+
+	for _, policySnapshot := range policies {
+		restoreErr := s.restorePolicy(ctx, policySnapshot)
+		if restoreErr != nil {
+			err = multierror.Append(err, fmt.Errorf("restore %s policy %s: %w", strings.ToLower(policySnapshot.Kind), policyName(policySnapshot.Database, policySnapshot.Schema, policySnapshot.Name), restoreErr))
+		}
+	}
+
+	// Renamed roles are renamed back first, so their new name is no longer in use
+	roles := slices.Clone(snapshot.Roles)
+	slices.SortStableFunc(roles, func(a, b *RoleSnapshot) int {
+		return compareBool(a.RenamedTo == "", b.RenamedTo == "")
+	})
+
+	for _, roleSnapshot := range roles {
+		restoreErr := s.restoreRole(ctx, roleSnapshot)
+		if restoreErr != nil {
+			err = multierror.Append(err, fmt.Errorf("restore role %q: %w", roleSnapshot.ExternalId, restoreErr))
+		}
+	}
+
+	return err
+}
+
+func compareBool(a, b bool) int {
+	switch {
+	case a == b:
+		return 0
+	case !a:
+		return -1
+	default:
+		return 1
+	}
+}
+
+func (s *AccessToTargetSyncer) restoreRole(ctx context.Context, roleSnapshot *RoleSnapshot) error {
+	externalId := roleSnapshot.ExternalId
+	roleType := roleSnapshot.Type
+
+	currentGrantsTo, exists, err := s.currentGrantsToRole(ctx, externalId, roleType)
+	if err != nil {
+		return err
+	}
+
+	if !roleSnapshot.Exists {
+		if !exists {
+			return nil
+		}
+
+		Logger.Info(fmt.Sprintf("Dropping role %q as it was created by the sync", externalId))
+
+		err = s.dropRole(ctx, externalId, isDatabaseRole(&roleType))
+		if isDoesNotExistError(err) {
+			return nil
+		}
+
+		return err
+	}
+
+	if !exists {
+		err = s.recreateRole(ctx, roleSnapshot)
+		if err != nil {
+			return err
+		}
+
+		currentGrantsTo, _, err = s.currentGrantsToRole(ctx, externalId, roleType)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = s.restoreGrantsToRole(ctx, roleSnapshot, currentGrantsTo)
+	if err != nil {
+		return err
+	}
+
+	return s.restoreGrantsOfRole(ctx, roleSnapshot)
+}
+
+// recreateRole renames a renamed role back to its original name, or creates a dropped role again
+func (s *AccessToTargetSyncer) recreateRole(ctx context.Context, roleSnapshot *RoleSnapshot) error {
+	if roleSnapshot.RenamedTo != "" {
+		_, renamedExists, err := s.currentGrantsToRole(ctx, roleSnapshot.RenamedTo, roleSnapshot.Type)
+		if err != nil {
+			return err
+		}
+
+		if renamedExists {
+			Logger.Info(fmt.Sprintf("Renaming role %q back to %q", roleSnapshot.RenamedTo, roleSnapshot.ExternalId))
+
+			return s.renameRole(ctx, roleSnapshot.RenamedTo, roleSnapshot.ExternalId, &roleSnapshot.Type)
+		}
+	}
+
+	Logger.Info(fmt.Sprintf("Creating role %q again as it was dropped by the sync", roleSnapshot.ExternalId))
+
+	return s.createRole(ctx, roleSnapshot.ExternalId, &roleSnapshot.Type)
+}
+
+func (s *AccessToTargetSyncer) restoreGrantsToRole(ctx context.Context, roleSnapshot *RoleSnapshot, currentGrantsTo []GrantToRole) error {
+	if isApplicationRole(&roleSnapshot.Type) {
+		return nil
+	}
+
+	expected, err := s.convertGrantsToRole(ctx, roleSnapshot.ExternalId, roleSnapshot.GrantsTo)
+	if err != nil {
+		return err
+	}
+
+	found, err := s.convertGrantsToRole(ctx, roleSnapshot.ExternalId, currentGrantsTo)
+	if err != nil {
+		return err
+	}
+
+	toAdd := slice.SliceDifference(expected, found)
+	toRemove := slice.SliceDifference(found, expected)
+
+	Logger.Info(fmt.Sprintf("Restoring %d grants and revoking %d grants for role %q", len(toAdd), len(toRemove), roleSnapshot.ExternalId))
+
+	for _, grant := range toAdd {
+		err = s.executeGrantOnRole(ctx, grant.Permissions, grant.OnWithType(), roleSnapshot.ExternalId, &roleSnapshot.Type)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, grant := range toRemove {
+		err = s.executeRevokeOnRole(ctx, grant.Permissions, grant.OnWithType(), roleSnapshot.ExternalId, &roleSnapshot.Type)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *AccessToTargetSyncer) restoreGrantsOfRole(ctx context.Context, roleSnapshot *RoleSnapshot) error {
+	currentGrantsOf, err := s.accessSyncer.retrieveGrantsOfRole(ctx, roleSnapshot.ExternalId, roleSnapshot.Type)
+	if err != nil {
+		return err
+	}
+
+	expectedUsers, expectedRoles, err := splitGrantsOfRole(roleSnapshot.GrantsOf)
+	if err != nil {
+		return err
+	}
+
+	currentUsers, currentRoles, err := splitGrantsOfRole(currentGrantsOf)
+	if err != nil {
+		return err
+	}
+
+	if usersToAdd := slice.StringSliceDifference(expectedUsers, currentUsers, false); len(usersToAdd) > 0 {
+		err = s.repo.GrantUsersToAccountRole(ctx, roleSnapshot.ExternalId, usersToAdd...)
+		if err != nil {
+			return err
+		}
+	}
+
+	if usersToRemove := slice.StringSliceDifference(currentUsers, expectedUsers, false); len(usersToRemove) > 0 {
+		err = s.repo.RevokeUsersFromAccountRole(ctx, roleSnapshot.ExternalId, usersToRemove...)
+		if err != nil {
+			return err
+		}
+	}
+
+	if rolesToAdd := slice.StringSliceDifference(expectedRoles, currentRoles, false); len(rolesToAdd) > 0 {
+		err = s.grantRolesToRole(ctx, roleSnapshot.ExternalId, &roleSnapshot.Type, rolesToAdd...)
+		if err != nil {
+			return err
+		}
+	}
+
+	if rolesToRemove := slice.StringSliceDifference(currentRoles, expectedRoles, false); len(rolesToRemove) > 0 {
+		err = s.revokeRolesFromRole(ctx, roleSnapshot.ExternalId, &roleSnapshot.Type, rolesToRemove...)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *AccessToTargetSyncer) restorePolicy(ctx context.Context, policySnapshot *PolicySnapshot) error {
+	if !policySnapshot.Exists {
+		Logger.Info(fmt.Sprintf("Dropping %s policy %s as it was created by the sync", strings.ToLower(policySnapshot.Kind), policyName(policySnapshot.Database, policySnapshot.Schema, policySnapshot.Name)))
+
+		if policySnapshot.Kind == rowAccessPolicyKind {
+			return s.repo.DropFilter(ctx, policySnapshot.Database, policySnapshot.Schema, policySnapshot.Table, policySnapshot.Name)
+		}
+
+		return s.repo.DropMaskingPolicy(ctx, policySnapshot.Database, policySnapshot.Schema, policySnapshot.Name)
+	}
+
+	fullName := policyName(policySnapshot.Database, policySnapshot.Schema, policySnapshot.Name)
+
+	signature := policySnapshot.Signature
+	if !strings.HasPrefix(signature, "(") {
+		signature = "(" + signature + ")"
+	}
+
+	err := s.repo.ExecutePlannedStatements(ctx, fmt.Sprintf("CREATE %s POLICY IF NOT EXISTS %s AS %s RETURNS %s -> %s", policySnapshot.Kind, fullName, signature, policySnapshot.ReturnType, policySnapshot.Body))
+	if err != nil {
+		return err
+	}
+
+	currentReferences, err := s.repo.GetPolicyReferences(ctx, policySnapshot.Database, policySnapshot.Schema, policySnapshot.Name)
+	if err != nil {
+		return err
+	}
+
+	attached := set.NewSet[string]()
+
+	for _, reference := range convertPolicyReferences(currentReferences) {
+		attached.Add(reference.key())
+	}
+
+	statements := make([]string, 0, len(policySnapshot.References))
+
+	for _, reference := range policySnapshot.References {
+		if attached.Contains(reference.key()) {
+			continue
+		}
+
+		statements = append(statements, attachPolicyStatement(policySnapshot.Kind, fullName, &reference))
+	}
+
+	Logger.Info(fmt.Sprintf("Attaching %s policy %s to %d objects again", strings.ToLower(policySnapshot.Kind), fullName, len(statements)))
+
+	if len(statements) == 0 {
+		return nil
+	}
+
+	return s.repo.ExecutePlannedStatements(ctx, statements...)
+}
+
+func attachPolicyStatement(kind, fullName string, reference *PolicyReference) string {
+	entityType := "TABLE"
+	if strings.Contains(strings.ToUpper(reference.Domain), "VIEW") {
+		entityType = "VIEW"
+	} else if isDynamicTableDomain(reference.Domain) {
+		entityType = "DYNAMIC TABLE"
+	}
+
+	entity := common.FormatQuery("%s.%s.%s", reference.Database, reference.Schema, reference.Entity)
+
+	// Column names are returned in their exact case, so they are always quoted
+	argColumns := make([]string, 0, len(reference.ArgColumns))
+	for _, column := range reference.ArgColumns {
+		argColumns = append(argColumns, fmt.Sprintf("%q", column))
+	}
+
+	if kind == rowAccessPolicyKind {
+		return fmt.Sprintf("ALTER %s %s ADD ROW ACCESS POLICY %s ON (%s)", entityType, entity, fullName, strings.Join(argColumns, ", "))
+	}
+
+	column := fmt.Sprintf("%q", reference.Column)
+
+	if len(argColumns) > 0 {
+		return fmt.Sprintf("ALTER %s %s MODIFY COLUMN %s SET MASKING POLICY %s USING (%s, %s) FORCE", entityType, entity, column, fullName, column, strings.Join(argColumns, ", "))
+	}
+
+	return fmt.Sprintf("ALTER %s %s MODIFY COLUMN %s SET MASKING POLICY %s FORCE", entityType, entity, column, fullName)
+}
+
+func (s *AccessToTargetSyncer) restoreShare(ctx context.Context, shareSnapshot *ShareSnapshot) error {
+	if !shareSnapshot.Exists {
+		Logger.Info(fmt.Sprintf("Dropping share %q as it was created by the sync", shareSnapshot.Name))
+
+		err := s.repo.DropShare(ctx, shareSnapshot.Name)
+		if isDoesNotExistError(err) {
+			return nil
+		}
+
+		return err
+	}
+
+	err := s.repo.CreateShare(ctx, shareSnapshot.Name)
+	if err != nil {
+		return err
+	}
+
+	currentGrants, err := s.repo.GetGrantsToShare(ctx, shareSnapshot.Name)
+	if err != nil {
+		return err
+	}
+
+	expected := s.convertGrantsToShare(ctx, shareSnapshot.Name, shareSnapshot.Grants)
+	found := s.convertGrantsToShare(ctx, shareSnapshot.Name, currentGrants)
+
+	for _, grant := range slice.SliceDifference(expected, found) {
+		err = s.repo.ExecuteGrantOnShare(ctx, grant.Permissions, grant.OnWithType(), shareSnapshot.Name)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, grant := range slice.SliceDifference(found, expected) {
+		err = s.repo.ExecuteRevokeOnShare(ctx, grant.Permissions, grant.OnWithType(), shareSnapshot.Name)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(shareSnapshot.Accounts) > 0 {
+		return s.repo.SetShareAccounts(ctx, shareSnapshot.Name, shareSnapshot.Accounts)
+	}
+
+	currentAccounts, err := s.currentShareAccounts(ctx, shareSnapshot.Name)
+	if err != nil {
+		return err
+	}
+
+	if len(currentAccounts) == 0 {
+		return nil
+	}
+
+	return s.repo.ExecutePlannedStatements(ctx, common.FormatQuery("ALTER SHARE %s REMOVE ACCOUNTS=", shareSnapshot.Name)+strings.Join(currentAccounts, ","))
+}
@@ -0,0 +1,281 @@
+package snowflake
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/aws/smithy-go/ptr"
+	"github.com/raito-io/cli/base/access_provider/sync_to_target"
+	"github.com/raito-io/cli/base/access_provider/types"
+	"github.com/raito-io/cli/base/util/config"
+	"github.com/raito-io/cli/base/wrappers/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccessSyncer_SyncAccessProviderToTarget_SnapshotAndRestore(t *testing.T) {
+	snapshotFile := filepath.Join(t.TempDir(), "snapshot.json")
+
+	accessProviders := &sync_to_target.AccessProviderImport{AccessProviders: []*sync_to_target.AccessProvider{
+		{Id: "ap1", Name: "Old role", Action: types.Grant, Delete: true, ExternalId: ptr.String("OLD_ROLE")},
+	}}
+
+	setup := func(t *testing.T) (*mockDataAccessRepository, *AccessSyncer) {
+		t.Helper()
+
+		repo := newMockDataAccessRepository(t)
+		repo.EXPECT().TotalQueryTime().Return(0)
+		repo.EXPECT().TotalRetries().Return(0)
+		repo.EXPECT().Close().Return(nil)
+
+		syncer := &AccessSyncer{
+			namingConstraints: RoleNameConstraints,
+			repoProvider: func(params map[string]string, role string, phase SyncPhase) (dataAccessRepository, error) {
+				return repo, nil
+			},
+		}
+
+		return repo, syncer
+	}
+
+	// Sync: the role is dropped after its state is written to the snapshot
+	repo, syncer := setup(t)
+	repo.EXPECT().GetAccountRolesWithPrefix(mock.Anything, "").Return([]RoleEntity{{Name: "OLD_ROLE"}}, nil).Once()
+	repo.EXPECT().GetGrantsToAccountRole(mock.Anything, "OLD_ROLE").Return([]GrantToRole{
+		{Privilege: "OWNERSHIP", GrantedOn: "TABLE", Name: "DB.S.T1"},
+		{Privilege: "SELECT", GrantedOn: "TABLE", Name: "DB.S.T1"},
+		{Privilege: "CREATE DATABASE", GrantedOn: "ACCOUNT", Name: "ACC"},
+	}, nil).Once()
+	repo.EXPECT().GetGrantsOfAccountRole(mock.Anything, "OLD_ROLE").Return([]GrantOfRole{
+		{GrantedTo: "USER", GranteeName: "USER1"},
+		{GrantedTo: "ROLE", GranteeName: "PARENT_ROLE"},
+	}, nil).Once()
+	repo.EXPECT().DropAccountRole(mock.Anything, "OLD_ROLE").Return(nil).Once()
+
+	err := syncer.SyncAccessProviderToTarget(context.Background(), accessProviders, mocks.NewSimpleAccessProviderFeedbackHandler(t), &config.ConfigMap{Parameters: map[string]string{SfSnapshotFile: snapshotFile}})
+	require.NoError(t, err)
+
+	snapshot, err := readSnapshot(snapshotFile)
+	require.NoError(t, err)
+	require.Len(t, snapshot.Roles, 1)
+	assert.Equal(t, "OLD_ROLE", snapshot.Roles[0].ExternalId)
+	assert.True(t, snapshot.Roles[0].Exists)
+	assert.Len(t, snapshot.Roles[0].GrantsTo, 3)
+	assert.Len(t, snapshot.Roles[0].GrantsOf, 2)
+
+	// Restore: the role is created again with its grants
+	repo, syncer = setup(t)
+	repo.EXPECT().GetGrantsToAccountRole(mock.Anything, "OLD_ROLE").Return(nil, errors.New("Role 'OLD_ROLE' does not exist or not authorized.")).Once()
+	repo.EXPECT().CreateAccountRole(mock.Anything, "OLD_ROLE").Return(nil).Once()
+	repo.EXPECT().GetGrantsToAccountRole(mock.Anything, "OLD_ROLE").Return([]GrantToRole{}, nil).Once()
+	repo.EXPECT().ExecuteGrantOnAccountRole(mock.Anything, "SELECT", "TABLE DB.S.T1", "OLD_ROLE", false).Return(nil).Once()
+	repo.EXPECT().ExecuteGrantOnAccountRole(mock.Anything, "CREATE DATABASE", "ACCOUNT ", "OLD_ROLE", false).Return(nil).Once()
+	repo.EXPECT().GetGrantsOfAccountRole(mock.Anything, "OLD_ROLE").Return([]GrantOfRole{}, nil).Once()
+	repo.EXPECT().GrantUsersToAccountRole(mock.Anything, "OLD_ROLE", "USER1").Return(nil).Once()
+	repo.EXPECT().GrantAccountRolesToAccountRole(mock.Anything, "OLD_ROLE", "PARENT_ROLE").Return(nil).Once()
+
+	feedbackHandler := mocks.NewSimpleAccessProviderFeedbackHandler(t)
+
+	err = syncer.SyncAccessProviderToTarget(context.Background(), accessProviders, feedbackHandler, &config.ConfigMap{Parameters: map[string]string{SfRestoreSnapshotFile: snapshotFile}})
+	require.NoError(t, err)
+
+	assert.Equal(t, []sync_to_target.AccessProviderSyncFeedback{
+		{AccessProvider: "ap1", ExternalId: ptr.String("OLD_ROLE"), Errors: []string{`not exported because snapshot "` + snapshotFile + `" was restored in this run`}},
+	}, feedbackHandler.AccessProviderFeedback)
+}
+
+func TestAccessSyncer_SyncAccessProviderToTarget_RestoreSnapshotCombinedWithDryRun(t *testing.T) {
+	syncer := NewDataAccessSyncer(RoleNameConstraints)
+
+	err := syncer.SyncAccessProviderToTarget(context.Background(), &sync_to_target.AccessProviderImport{}, mocks.NewSimpleAccessProviderFeedbackHandler(t), &config.ConfigMap{Parameters: map[string]string{
+		SfRestoreSnapshotFile: "snapshot.json",
+		SfDryRun:              "true",
+		SfDryRunFile:          "plan.sql",
+	}})
+	require.EqualError(t, err, `parameter "sf-restore-snapshot-file" can not be combined with "sf-dry-run" or "sf-apply-plan-file"`)
+}
+
+func TestAccessSyncer_SyncAccessProviderToTarget_RestoreSnapshotWithLimits(t *testing.T) {
+	// Given
+	snapshotFile := filepath.Join(t.TempDir(), "snapshot.json")
+
+	snapshot := newSnapshot()
+	snapshot.Roles = []*RoleSnapshot{
+		{ExternalId: "ROLE1", Type: "role", Exists: true, GrantsTo: []GrantToRole{{Privilege: "SELECT", GrantedOn: "TABLE", Name: "DB.S.T1"}}},
+	}
+	require.NoError(t, snapshot.write(snapshotFile))
+
+	params := map[string]string{SfRestoreSnapshotFile: snapshotFile, SfMaxRevokes: "0"}
+
+	// The revoke is executed directly, although it exceeds the limit
+	repo := newMockDataAccessRepository(t)
+	repo.EXPECT().GetGrantsToAccountRole(mock.Anything, "ROLE1").Return([]GrantToRole{
+		{Privilege: "SELECT", GrantedOn: "TABLE", Name: "DB.S.T1"},
+		{Privilege: "SELECT", GrantedOn: "TABLE", Name: "DB.S.T2"},
+	}, nil).Once()
+	repo.EXPECT().ExecuteRevokeOnAccountRole(mock.Anything, "SELECT", "TABLE DB.S.T2", "ROLE1", false).Return(nil).Once()
+	repo.EXPECT().GetGrantsOfAccountRole(mock.Anything, "ROLE1").Return([]GrantOfRole{}, nil).Once()
+	repo.EXPECT().TotalQueryTime().Return(0)
+	repo.EXPECT().TotalRetries().Return(0)
+	repo.EXPECT().Close().Return(nil)
+
+	syncer := &AccessSyncer{
+		namingConstraints: RoleNameConstraints,
+		repoProvider: func(params map[string]string, role string, phase SyncPhase) (dataAccessRepository, error) {
+			// The repository must execute the restore, instead of only planning it
+			assert.False(t, planChangesFirst(params))
+
+			return repo, nil
+		},
+	}
+
+	// When
+	err := syncer.SyncAccessProviderToTarget(context.Background(), &sync_to_target.AccessProviderImport{}, mocks.NewSimpleAccessProviderFeedbackHandler(t), &config.ConfigMap{Parameters: params})
+
+	// Then
+	require.NoError(t, err)
+	assert.True(t, planChangesFirst(map[string]string{SfMaxRevokes: "0"}))
+}
+
+func TestAccessToTargetSyncer_captureRole(t *testing.T) {
+	repoMock := newMockDataAccessRepository(t)
+	repoMock.EXPECT().GetGrantsToDatabaseRole(mock.Anything, "DB1", "ROLE1").Return([]GrantToRole{{Privilege: "USAGE", GrantedOn: "SCHEMA", Name: "DB1.S1"}}, nil).Once()
+	repoMock.EXPECT().GetGrantsOfDatabaseRole(mock.Anything, "DB1", "ROLE1").Return([]GrantOfRole{{GrantedTo: "ROLE", GranteeName: "PARENT"}}, nil).Once()
+	repoMock.EXPECT().GetGrantsToAccountRole(mock.Anything, "NEW_ROLE").Return(nil, errors.New("Role 'NEW_ROLE' does not exist or not authorized.")).Once()
+
+	syncer := createBasicToTargetSyncer(repoMock, nil, &dummyFeedbackHandler{}, &config.ConfigMap{})
+	syncer.snapshot = newSnapshot()
+
+	databaseRole := databaseRoleExternalIdGenerator("DB1", "ROLE1")
+
+	require.NoError(t, syncer.captureRole(context.Background(), databaseRole))
+	require.NoError(t, syncer.captureRole(context.Background(), "NEW_ROLE"))
+
+	// Only the state before the first change is kept
+	require.NoError(t, syncer.captureRole(context.Background(), databaseRole))
+
+	syncer.captureRoleRename(databaseRole, databaseRoleExternalIdGenerator("DB1", "ROLE2"))
+
+	assert.Equal(t, []*RoleSnapshot{
+		{
+			ExternalId: databaseRole,
+			Type:       apTypeDatabaseRole,
+			Exists:     true,
+			RenamedTo:  databaseRoleExternalIdGenerator("DB1", "ROLE2"),
+			GrantsTo:   []GrantToRole{{Privilege: "USAGE", GrantedOn: "SCHEMA", Name: "DB1.S1"}},
+			GrantsOf:   []GrantOfRole{{GrantedTo: "ROLE", GranteeName: "PARENT"}},
+		},
+		{
+			ExternalId: "NEW_ROLE",
+			Type:       "role",
+		},
+	}, syncer.snapshot.Roles)
+
+	// Nothing is captured without a snapshot
+	syncer.snapshot = nil
+	require.NoError(t, syncer.captureRole(context.Background(), "OTHER_ROLE"))
+}
+
+func TestAccessToTargetSyncer_restoreSnapshot(t *testing.T) {
+	repoMock := newMockDataAccessRepository(t)
+
+	// The share is restored with its original grants and accounts
+	repoMock.EXPECT().CreateShare(mock.Anything, "SHARE1").Return(nil).Once()
+	repoMock.EXPECT().GetGrantsToShare(mock.Anything, "SHARE1").Return([]GrantToRole{
+		{Privilege: "USAGE", GrantedOn: "DATABASE", Name: "DB1"},
+		{Privilege: "SELECT", GrantedOn: "TABLE", Name: "DB1.S1.T2"},
+	}, nil).Once()
+	repoMock.EXPECT().ExecuteGrantOnShare(mock.Anything, "SELECT", "TABLE DB1.S1.T1", "SHARE1").Return(nil).Once()
+	repoMock.EXPECT().ExecuteRevokeOnShare(mock.Anything, "SELECT", "TABLE DB1.S1.T2", "SHARE1").Return(nil).Once()
+	repoMock.EXPECT().SetShareAccounts(mock.Anything, "SHARE1", []string{"ORG.ACC1"}).Return(nil).Once()
+	repoMock.EXPECT().DropShare(mock.Anything, "NEW_SHARE").Return(errors.New("Share 'NEW_SHARE' does not exist")).Once()
+
+	// The created mask is dropped before the original one is created and attached again
+	dropCall := repoMock.EXPECT().DropMaskingPolicy(mock.Anything, "DB1", "S1", "RAITO_MASK_NEW").Return(nil).Once()
+	repoMock.EXPECT().ExecutePlannedStatements(mock.Anything, "CREATE MASKING POLICY IF NOT EXISTS DB1.S1.RAITO_MASK_OLD_TEXT AS (val VARCHAR) RETURNS VARCHAR -> '***'").Return(nil).Once().NotBefore(dropCall)
+	repoMock.EXPECT().GetPolicyReferences(mock.Anything, "DB1", "S1", "RAITO_MASK_OLD_TEXT").Return([]PolicyReferenceEntity{
+		{REF_DATABASE_NAME: "DB1", REF_SCHEMA_NAME: "S1", REF_ENTITY_NAME: "T1", REF_ENTITY_DOMAIN: "TABLE", REF_COLUMN_NAME: NullString{String: "NAME", Valid: true}},
+	}, nil).Once()
+	repoMock.EXPECT().ExecutePlannedStatements(mock.Anything, "ALTER TABLE DB1.S1.T1 MODIFY COLUMN \"EMAIL\" SET MASKING POLICY DB1.S1.RAITO_MASK_OLD_TEXT FORCE").Return(nil).Once()
+
+	// The created role is dropped and the changed role gets its original grants back
+	repoMock.EXPECT().GetGrantsToAccountRole(mock.Anything, "NEW_ROLE").Return([]GrantToRole{}, nil).Once()
+	repoMock.EXPECT().DropAccountRole(mock.Anything, "NEW_ROLE").Return(nil).Once()
+	repoMock.EXPECT().GetGrantsToAccountRole(mock.Anything, "ROLE1").Return([]GrantToRole{{Privilege: "INSERT", GrantedOn: "TABLE", Name: "DB1.S1.T1"}}, nil).Once()
+	repoMock.EXPECT().ExecuteGrantOnAccountRole(mock.Anything, "SELECT", "TABLE DB1.S1.T1", "ROLE1", false).Return(nil).Once()
+	repoMock.EXPECT().ExecuteRevokeOnAccountRole(mock.Anything, "INSERT", "TABLE DB1.S1.T1", "ROLE1", false).Return(nil).Once()
+	repoMock.EXPECT().GetGrantsOfAccountRole(mock.Anything, "ROLE1").Return([]GrantOfRole{{GrantedTo: "USER", GranteeName: "USER2"}}, nil).Once()
+	repoMock.EXPECT().GrantUsersToAccountRole(mock.Anything, "ROLE1", "USER1").Return(nil).Once()
+	repoMock.EXPECT().RevokeUsersFromAccountRole(mock.Anything, "ROLE1", "USER2").Return(nil).Once()
+
+	syncer := createBasicToTargetSyncer(repoMock, nil, &dummyFeedbackHandler{}, &config.ConfigMap{})
+
+	// When
+	err := syncer.restoreSnapshot(context.Background(), &Snapshot{
+		Shares: []*ShareSnapshot{
+			{Name: "SHARE1", Exists: true, Grants: []GrantToRole{{Privilege: "USAGE", GrantedOn: "DATABASE", Name: "DB1"}, {Privilege: "SELECT", GrantedOn: "TABLE", Name: "DB1.S1.T1"}}, Accounts: []string{"ORG.ACC1"}},
+			{Name: "NEW_SHARE"},
+		},
+		Policies: []*PolicySnapshot{
+			{Kind: maskingPolicyKind, Database: "DB1", Schema: "S1", Name: "RAITO_MASK_OLD_TEXT", Exists: true, Signature: "(val VARCHAR)", ReturnType: "VARCHAR", Body: "'***'", References: []PolicyReference{
+				{Database: "DB1", Schema: "S1", Entity: "T1", Domain: "TABLE", Column: "NAME"},
+				{Database: "DB1", Schema: "S1", Entity: "T1", Domain: "TABLE", Column: "EMAIL"},
+			}},
+			{Kind: maskingPolicyKind, Database: "DB1", Schema: "S1", Name: "RAITO_MASK_NEW"},
+		},
+		Roles: []*RoleSnapshot{
+			{ExternalId: "NEW_ROLE", Type: "role"},
+			{ExternalId: "ROLE1", Type: "role", Exists: true, GrantsTo: []GrantToRole{{Privilege: "SELECT", GrantedOn: "TABLE", Name: "DB1.S1.T1"}}, GrantsOf: []GrantOfRole{{GrantedTo: "USER", GranteeName: "USER1"}}},
+		},
+	})
+
+	// Then
+	require.NoError(t, err)
+}
+
+func TestAccessToTargetSyncer_restoreSnapshot_renamedRole(t *testing.T) {
+	repoMock := newMockDataAccessRepository(t)
+
+	// The renamed role is renamed back before the role with the new name is handled
+	repoMock.EXPECT().GetGrantsToAccountRole(mock.Anything, "OLD_NAME").Return(nil, errors.New("Role 'OLD_NAME' does not exist or not authorized.")).Once()
+	repoMock.EXPECT().GetGrantsToAccountRole(mock.Anything, "NEW_NAME").Return([]GrantToRole{}, nil).Once()
+	repoMock.EXPECT().RenameAccountRole(mock.Anything, "NEW_NAME", "OLD_NAME").Return(nil).Once()
+	repoMock.EXPECT().GetGrantsToAccountRole(mock.Anything, "OLD_NAME").Return([]GrantToRole{}, nil).Once()
+	repoMock.EXPECT().GetGrantsOfAccountRole(mock.Anything, "OLD_NAME").Return([]GrantOfRole{}, nil).Once()
+	repoMock.EXPECT().GetGrantsToAccountRole(mock.Anything, "NEW_NAME").Return(nil, errors.New("Role 'NEW_NAME' does not exist or not authorized.")).Once()
+
+	syncer := createBasicToTargetSyncer(repoMock, nil, &dummyFeedbackHandler{}, &config.ConfigMap{})
+
+	// When
+	err := syncer.restoreSnapshot(context.Background(), &Snapshot{
+		Roles: []*RoleSnapshot{
+			{ExternalId: "NEW_NAME", Type: "role"},
+			{ExternalId: "OLD_NAME", Type: "role", Exists: true, RenamedTo: "NEW_NAME"},
+		},
+	})
+
+	// Then
+	require.NoError(t, err)
+}
+
+func TestAttachPolicyStatement(t *testing.T) {
+	assert.Equal(t, `ALTER VIEW DB1.S1.V1 MODIFY COLUMN "NAME" SET MASKING POLICY DB1.S1.MASK USING ("NAME", "COUNTRY") FORCE`,
+		attachPolicyStatement(maskingPolicyKind, "DB1.S1.MASK", &PolicyReference{Database: "DB1", Schema: "S1", Entity: "V1", Domain: "VIEW", Column: "NAME", ArgColumns: []string{"COUNTRY"}}))
+	assert.Equal(t, `ALTER TABLE DB1.S1.T1 ADD ROW ACCESS POLICY DB1.S1.FILTER ON ("COUNTRY", "city")`,
+		attachPolicyStatement(rowAccessPolicyKind, "DB1.S1.FILTER", &PolicyReference{Database: "DB1", Schema: "S1", Entity: "T1", Domain: "TABLE", ArgColumns: []string{"COUNTRY", "city"}}))
+	assert.Equal(t, `ALTER DYNAMIC TABLE DB1.S1.DT1 MODIFY COLUMN "NAME" SET MASKING POLICY DB1.S1.MASK FORCE`,
+		attachPolicyStatement(maskingPolicyKind, "DB1.S1.MASK", &PolicyReference{Database: "DB1", Schema: "S1", Entity: "DT1", Domain: "DYNAMIC_TABLE", Column: "NAME"}))
+	assert.Equal(t, `ALTER DYNAMIC TABLE DB1.S1.DT1 ADD ROW ACCESS POLICY DB1.S1.FILTER ON ("COUNTRY")`,
+		attachPolicyStatement(rowAccessPolicyKind, "DB1.S1.FILTER", &PolicyReference{Database: "DB1", Schema: "S1", Entity: "DT1", Domain: "DYNAMIC TABLE", ArgColumns: []string{"COUNTRY"}}))
+}
+
+func TestConvertPolicyReferences(t *testing.T) {
+	references := convertPolicyReferences([]PolicyReferenceEntity{
+		{REF_DATABASE_NAME: "DB1", REF_SCHEMA_NAME: "S1", REF_ENTITY_NAME: "T1", REF_ENTITY_DOMAIN: "TABLE", REF_ARG_COLUMN_NAMES: NullString{String: `[ "COUNTRY", "CITY" ]`, Valid: true}},
+	})
+
+	assert.Equal(t, []PolicyReference{{Database: "DB1", Schema: "S1", Entity: "T1", Domain: "TABLE", ArgColumns: []string{"COUNTRY", "CITY"}}}, references)
+}
@@ -2,7 +2,9 @@ package snowflake
 
 import (
 	"context"
+	"crypto/rsa"
 	"database/sql"
+	"errors"
 	"fmt"
 	"strings"
 
@@ -13,7 +15,19 @@ import (
 const SfLimit = 10000
 const ConnectionStringIdentifier = "Raito_CLI"
 
+// Snowflake error returned when the JWT, signed with the configured private key, does not match any of the public keys of the user
+const sfErrorJwtTokenInvalid = 390144
+
+// SQL state returned by Snowflake when a statement uses a feature that is not available on the account
+const sqlStateFeatureNotSupported = "0A000"
+
 func ConnectToSnowflake(params map[string]string, role string) (*sql.DB, string, error) {
+	return connectToSnowflake(params, role, "", nil)
+}
+
+// connectToSnowflake opens a connection pool of which every session is initialised with the given session parameters.
+// If warehouse is set, every session switches to that warehouse after login.
+func connectToSnowflake(params map[string]string, role string, warehouse string, sessionParameters map[string]string) (*sql.DB, string, error) {
 	sfUser, found := params[SfUser]
 	if !found {
 		return nil, "", e.CreateMissingInputParameterError(SfUser)
@@ -22,9 +36,20 @@ func ConnectToSnowflake(params map[string]string, role string) (*sql.DB, string,
 	sfPassword, foundPassword := params[SfPassword]
 	sfPrivateKey, foundPrivateKey := params[SfPrivateKey]
 	sfPrivateKeyPassphrase := params[SfPrivateKeyPassphrase]
+	sfPat := params[SfPat]
+
+	oauthTokenProvider, err := newOAuthTokenProviderFromParams(params)
+	if err != nil {
+		return nil, "", fmt.Errorf("invalid OAuth configuration: %w", err)
+	}
 
-	if (!foundPassword || sfPassword == "") && (!foundPrivateKey || sfPrivateKey == "") {
-		return nil, "", fmt.Errorf("either parameter %q or %q need to be specified", SfPassword, SfPrivateKey)
+	if sfPat != "" {
+		err = validateProgrammaticAccessToken(sfPat, (foundPassword && sfPassword != "") || (foundPrivateKey && sfPrivateKey != "") || oauthTokenProvider != nil)
+		if err != nil {
+			return nil, "", err
+		}
+	} else if (!foundPassword || sfPassword == "") && (!foundPrivateKey || sfPrivateKey == "") && oauthTokenProvider == nil {
+		return nil, "", fmt.Errorf("either parameter %q, %q, %q or an OAuth token source (%q, %q or %q) need to be specified", SfPassword, SfPrivateKey, SfPat, SfOAuthTokenFile, SfOAuthTokenEnv, SfOAuthTokenEndpoint)
 	}
 
 	snowflakeAccount, found := params[SfAccount]
@@ -32,21 +57,15 @@ func ConnectToSnowflake(params map[string]string, role string) (*sql.DB, string,
 		return nil, "", e.CreateMissingInputParameterError(SfAccount)
 	}
 
-	if role == "" {
-		if v, ok := params[SfRole]; ok {
-			role = v
-		}
-	}
-
-	if role == "" {
-		role = "ACCOUNTADMIN"
-	}
+	role = connectionRole(params, role)
 
 	insecure := false
 	if v, ok := params[SfDriverInsecureMode]; ok && strings.EqualFold(v, "true") {
 		insecure = true
 	}
 
+	var secondaryPrivateKey *rsa.PrivateKey
+
 	dsnConfig := sf.Config{
 		Account:      snowflakeAccount,
 		User:         sfUser,
@@ -55,14 +74,34 @@ func ConnectToSnowflake(params map[string]string, role string) (*sql.DB, string,
 		InsecureMode: insecure,
 	}
 
-	if foundPrivateKey && sfPrivateKey != "" {
-		privateKey, err := LoadPrivateKeyFromFile(sfPrivateKey, sfPrivateKeyPassphrase)
+	if len(sessionParameters) > 0 {
+		dsnConfig.Params = make(map[string]*string, len(sessionParameters))
+
+		for k, v := range sessionParameters {
+			dsnConfig.Params[k] = &v
+		}
+	}
+
+	if sfPat != "" {
+		// Snowflake accepts a programmatic access token in place of the password of the (service) user
+		dsnConfig.Password = sfPat
+	} else if oauthTokenProvider != nil {
+		dsnConfig.Authenticator = sf.AuthTypeOAuth
+	} else if foundPrivateKey && sfPrivateKey != "" {
+		privateKey, err := LoadPrivateKeyFromParameter(sfPrivateKey, sfPrivateKeyPassphrase)
 		if err != nil {
-			return nil, "", e.CreateBadInputParameterError(SfPrivateKey, sfPrivateKey, fmt.Sprintf("Failed to parse private key: %s", err.Error()))
+			return nil, "", e.CreateBadInputParameterError(SfPrivateKey, censorPrivateKeyParameter(sfPrivateKey), fmt.Sprintf("Failed to parse private key: %s", err.Error()))
 		}
 
 		dsnConfig.PrivateKey = privateKey
 		dsnConfig.Authenticator = sf.AuthTypeJwt
+
+		if sfPrivateKeySecondary := params[SfPrivateKeySecondary]; sfPrivateKeySecondary != "" {
+			secondaryPrivateKey, err = LoadPrivateKeyFromParameter(sfPrivateKeySecondary, params[SfPrivateKeySecondaryPassphrase])
+			if err != nil {
+				return nil, "", e.CreateBadInputParameterError(SfPrivateKeySecondary, censorPrivateKeyParameter(sfPrivateKeySecondary), fmt.Sprintf("Failed to parse private key: %s", err.Error()))
+			}
+		}
 	} else {
 		dsnConfig.Password = sfPassword
 	}
@@ -73,26 +112,142 @@ func ConnectToSnowflake(params map[string]string, role string) (*sql.DB, string,
 		}
 	}
 
-	dsn, err := sf.DSN(&dsnConfig)
-	if err != nil {
-		return nil, "", fmt.Errorf("snowflake DSN: %w", err)
+	// Only switch warehouse if it differs from the one the session is opened with
+	if strings.EqualFold(warehouse, dsnConfig.Warehouse) {
+		warehouse = ""
 	}
 
 	censoredConnectionString := fmt.Sprintf("%s:%s@%s?role=%s", sfUser, "**censured**", snowflakeAccount, role)
+
+	if oauthTokenProvider != nil {
+		Logger.Debug(fmt.Sprintf("Using OAuth authentication with connection string: %s", censoredConnectionString))
+
+		// Validate the token source upfront so configuration issues are reported before the first query
+		_, err = oauthTokenProvider.Token(context.Background())
+		if err != nil {
+			return nil, "", e.CreateSourceConnectionError(censoredConnectionString, err.Error())
+		}
+
+		return sql.OpenDB(withUseWarehouse(&oauthConnector{config: dsnConfig, tokenProvider: oauthTokenProvider}, warehouse)), role, nil
+	}
+
+	conn, err := openSnowflakeConnection(&dsnConfig, warehouse, censoredConnectionString)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if secondaryPrivateKey != nil {
+		conn, err = switchToSecondaryKeyIfRejected(conn, &dsnConfig, secondaryPrivateKey, warehouse, censoredConnectionString)
+		if err != nil {
+			return nil, "", err
+		}
+	}
+
+	return conn, role, nil
+}
+
+// connectionRole returns the role to connect with: the given role, the role in sf-role or ACCOUNTADMIN
+func connectionRole(params map[string]string, role string) string {
+	if role == "" {
+		if v, ok := params[SfRole]; ok {
+			role = v
+		}
+	}
+
+	if role == "" {
+		role = "ACCOUNTADMIN"
+	}
+
+	return role
+}
+
+func openSnowflakeConnection(dsnConfig *sf.Config, warehouse string, censoredConnectionString string) (*sql.DB, error) {
+	if warehouse != "" {
+		Logger.Debug(fmt.Sprintf("Using connection string: %s (warehouse %s)", censoredConnectionString, warehouse))
+
+		return sql.OpenDB(withUseWarehouse(sf.NewConnector(&sf.SnowflakeDriver{}, *dsnConfig), warehouse)), nil
+	}
+
+	dsn, err := sf.DSN(dsnConfig)
+	if err != nil {
+		return nil, fmt.Errorf("snowflake DSN: %w", err)
+	}
+
 	Logger.Debug(fmt.Sprintf("Using connection string: %s", censoredConnectionString))
 	conn, err := sql.Open("snowflake", dsn)
 
 	if err != nil {
-		return nil, "", e.CreateSourceConnectionError(censoredConnectionString, err.Error())
+		return nil, e.CreateSourceConnectionError(censoredConnectionString, err.Error())
 	}
 
-	return conn, role, nil
+	return conn, nil
+}
+
+// switchToSecondaryKeyIfRejected verifies the primary private key by opening a session.
+// During a key rotation the primary key can already be replaced in Snowflake, in which case the secondary key (RSA_PUBLIC_KEY_2) is used instead.
+func switchToSecondaryKeyIfRejected(conn *sql.DB, dsnConfig *sf.Config, secondaryPrivateKey *rsa.PrivateKey, warehouse string, censoredConnectionString string) (*sql.DB, error) {
+	err := conn.Ping()
+	if err == nil || !isPrivateKeyRejectedError(err) {
+		return conn, nil
+	}
+
+	Logger.Warn("The primary private key was rejected by Snowflake. Retrying with the secondary private key.")
+
+	conn.Close()
+
+	dsnConfig.PrivateKey = secondaryPrivateKey
+
+	return openSnowflakeConnection(dsnConfig, warehouse, censoredConnectionString)
+}
+
+func isPrivateKeyRejectedError(err error) bool {
+	var sfErr *sf.SnowflakeError
+
+	return errors.As(err, &sfErr) && sfErr.Number == sfErrorJwtTokenInvalid
+}
+
+// isUnsupportedFeatureError checks if Snowflake rejected the statement because the feature is not available on the account (e.g. Snowpark Container Services)
+func isUnsupportedFeatureError(err error) bool {
+	var sfErr *sf.SnowflakeError
+
+	if !errors.As(err, &sfErr) {
+		return false
+	}
+
+	return sfErr.SQLState == sqlStateFeatureNotSupported || strings.Contains(strings.ToLower(sfErr.Message), "unsupported feature")
+}
+
+// censorPrivateKeyParameter hides the private key parameter value unless it refers to a file or environment variable
+func censorPrivateKeyParameter(value string) string {
+	if _, isPem := privateKeyPemData(value); isPem {
+		return "**censured**"
+	}
+
+	return value
+}
+
+func validateProgrammaticAccessToken(pat string, otherAuthenticationConfigured bool) error {
+	const censoredPat = "**censured**"
+
+	if otherAuthenticationConfigured {
+		return e.CreateBadInputParameterError(SfPat, censoredPat, fmt.Sprintf("A programmatic access token cannot be combined with %q, %q or OAuth authentication", SfPassword, SfPrivateKey))
+	}
+
+	if strings.ContainsAny(pat, " \t\r\n") {
+		return e.CreateBadInputParameterError(SfPat, censoredPat, "The programmatic access token should not contain whitespace")
+	}
+
+	return nil
 }
 
 func QuerySnowflake(conn *sql.DB, query string, args ...any) (*sql.Rows, error) {
-	rows, err := conn.Query(query, args...)
+	return QuerySnowflakeContext(context.Background(), conn, query, args...)
+}
+
+func QuerySnowflakeContext(ctx context.Context, conn *sql.DB, query string, args ...any) (*sql.Rows, error) {
+	rows, err := conn.QueryContext(ctx, query, args...)
 	if err != nil {
-		return nil, fmt.Errorf("error while querying Snowflake with query '%s': %s", query, err.Error())
+		return nil, fmt.Errorf("error while querying Snowflake with query '%s': %w", query, err)
 	}
 
 	return rows, nil
@@ -101,7 +256,7 @@ func QuerySnowflake(conn *sql.DB, query string, args ...any) (*sql.Rows, error)
 func ExecuteSnowflake(ctx context.Context, conn *sql.DB, query string, args ...any) error {
 	_, err := conn.ExecContext(ctx, query, args...)
 	if err != nil {
-		return fmt.Errorf("error while executing Snowflake with query '%s': %s", query, err.Error())
+		return fmt.Errorf("error while executing Snowflake with query '%s': %w", query, err)
 	}
 
 	return nil
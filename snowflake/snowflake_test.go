@@ -0,0 +1,54 @@
+package snowflake
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConnectToSnowflake_ProgrammaticAccessToken(t *testing.T) {
+	tests := []struct {
+		name        string
+		params      map[string]string
+		expectedErr string
+	}{
+		{
+			name:        "PAT combined with password",
+			params:      map[string]string{SfUser: "user", SfAccount: "account", SfPat: "pat", SfPassword: "password"},
+			expectedErr: "cannot be combined",
+		},
+		{
+			name:        "PAT combined with private key",
+			params:      map[string]string{SfUser: "user", SfAccount: "account", SfPat: "pat", SfPrivateKey: "/path/to/key"},
+			expectedErr: "cannot be combined",
+		},
+		{
+			name:        "PAT with whitespace",
+			params:      map[string]string{SfUser: "user", SfAccount: "account", SfPat: "pat with spaces"},
+			expectedErr: "should not contain whitespace",
+		},
+		{
+			name:        "No authentication",
+			params:      map[string]string{SfUser: "user", SfAccount: "account"},
+			expectedErr: SfPat,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := ConnectToSnowflake(tt.params, "")
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.expectedErr)
+		})
+	}
+}
+
+func TestConnectToSnowflake_ProgrammaticAccessTokenValid(t *testing.T) {
+	conn, role, err := ConnectToSnowflake(map[string]string{SfUser: "user", SfAccount: "account", SfPat: "pat"}, "")
+	require.NoError(t, err)
+
+	defer conn.Close()
+
+	assert.Equal(t, AccountAdminRole, role)
+}
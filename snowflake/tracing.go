@@ -0,0 +1,157 @@
+package snowflake
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	tracerName = "github.com/raito-io/cli-plugin-snowflake/snowflake"
+
+	TracingExporterOtlp = "otlp"
+	TracingExporterFile = "file"
+
+	defaultTracingOtlpEndpoint = "http://localhost:4318"
+
+	tracingShutdownTimeout = 10 * time.Second
+
+	// maxSpanStatementLength limits the size of the statement attribute, as a single span can contain a batch of 200 statements
+	maxSpanStatementLength = 4096
+)
+
+// tracingExporter creates the span exporter configured in the parameters. The returned closer must be called after the exporter is shut down.
+func tracingExporter(ctx context.Context, params map[string]string) (sdktrace.SpanExporter, func() error, error) {
+	noClose := func() error { return nil }
+
+	switch exporter := strings.ToLower(params[SfTracingExporter]); exporter {
+	case TracingExporterOtlp:
+		endpoint := params[SfTracingOtlpEndpoint]
+		if endpoint == "" {
+			endpoint = defaultTracingOtlpEndpoint
+		}
+
+		spanExporter, err := otlptracehttp.New(ctx, otlptracehttp.WithEndpointURL(endpoint))
+		if err != nil {
+			return nil, nil, fmt.Errorf("create OTLP exporter for %q: %w", endpoint, err)
+		}
+
+		return spanExporter, noClose, nil
+	case TracingExporterFile:
+		path := params[SfTracingFile]
+		if path == "" {
+			return nil, nil, fmt.Errorf("parameter %q is required when %q is set to %q", SfTracingFile, SfTracingExporter, TracingExporterFile)
+		}
+
+		// Appending, so the spans of all syncs of a run end up in the same file
+		file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+		if err != nil {
+			return nil, nil, fmt.Errorf("open trace file %q: %w", path, err)
+		}
+
+		spanExporter, err := stdouttrace.New(stdouttrace.WithWriter(file))
+		if err != nil {
+			file.Close()
+
+			return nil, nil, fmt.Errorf("create file exporter: %w", err)
+		}
+
+		return spanExporter, file.Close, nil
+	default:
+		return nil, nil, fmt.Errorf("invalid value %q for %q parameter (must be %q or %q)", exporter, SfTracingExporter, TracingExporterOtlp, TracingExporterFile)
+	}
+}
+
+// startSyncPhaseSpan starts the root span of a sync if tracing is configured.
+// The spans of the repository calls and statements are started as children of this span, so nothing is traced if tracing isn't configured.
+// The returned function ends the span and flushes all spans of the sync to the exporter.
+func startSyncPhaseSpan(ctx context.Context, params map[string]string, phase SyncPhase) (context.Context, func(err error)) {
+	if params[SfTracingExporter] == "" {
+		return ctx, func(error) {}
+	}
+
+	exporter, closeExporter, err := tracingExporter(ctx, params)
+	if err != nil {
+		Logger.Warn(fmt.Sprintf("Tracing is disabled: %s", err.Error()))
+
+		return ctx, func(error) {}
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewSchemaless(
+			semconv.ServiceName(ConnectionStringIdentifier),
+			semconv.ServiceVersion(PluginVersion),
+			attribute.String("raito.run_id", runId),
+		)),
+	)
+
+	ctx, span := provider.Tracer(tracerName).Start(ctx, "sync "+string(phase), trace.WithAttributes(attribute.String("raito.sync_phase", string(phase))))
+
+	return ctx, func(err error) {
+		endSpan(span, err)
+
+		// The context of the sync could already be cancelled, but the spans should still be exported
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), tracingShutdownTimeout)
+		defer cancel()
+
+		err = provider.Shutdown(shutdownCtx)
+		if err != nil {
+			Logger.Warn(fmt.Sprintf("Unable to export traces: %s", err.Error()))
+		}
+
+		err = closeExporter()
+		if err != nil {
+			Logger.Warn(fmt.Sprintf("Unable to close trace file: %s", err.Error()))
+		}
+	}
+}
+
+// startSpan starts a child span of the span in ctx. If ctx doesn't contain a span, a non-recording span is returned.
+func startSpan(ctx context.Context, name string, attributes ...attribute.KeyValue) (context.Context, trace.Span) {
+	return trace.SpanFromContext(ctx).TracerProvider().Tracer(tracerName).Start(ctx, name, trace.WithAttributes(attributes...))
+}
+
+// endSpan marks the span as failed if err is not nil and ends it
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	span.End()
+}
+
+// startStatementSpan starts the span of a single execution of the statements in Snowflake
+func startStatementSpan(ctx context.Context, statements []string) (context.Context, trace.Span) {
+	statement := strings.Join(statements, "\n")
+	if len(statement) > maxSpanStatementLength {
+		statement = statement[:maxSpanStatementLength] + "..."
+	}
+
+	return startSpan(ctx, statementKindOfBatch(statements),
+		semconv.DBSystemKey.String("snowflake"),
+		semconv.DBQueryText(statement),
+		attribute.Int("db.snowflake.statement_count", len(statements)),
+	)
+}
+
+// endStatementSpan adds the id of the Snowflake query, if known, to the span and ends it
+func endStatementSpan(span trace.Span, queryId string, err error) {
+	if queryId != "" {
+		span.SetAttributes(attribute.String("db.snowflake.query_id", queryId))
+	}
+
+	endSpan(span, err)
+}
@@ -0,0 +1,128 @@
+package snowflake
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type exportedSpan struct {
+	Name        string
+	SpanContext struct {
+		TraceID string
+		SpanID  string
+	}
+	Parent struct {
+		SpanID string
+	}
+	Attributes []struct {
+		Key   string
+		Value struct {
+			Value any
+		}
+	}
+	Status struct {
+		Code string
+	}
+}
+
+func (s exportedSpan) attribute(key string) any {
+	for _, a := range s.Attributes {
+		if a.Key == key {
+			return a.Value.Value
+		}
+	}
+
+	return nil
+}
+
+func readExportedSpans(t *testing.T, path string) map[string]exportedSpan {
+	t.Helper()
+
+	file, err := os.Open(path)
+	require.NoError(t, err)
+
+	defer file.Close()
+
+	spans := make(map[string]exportedSpan)
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var span exportedSpan
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &span))
+
+		spans[span.Name] = span
+	}
+
+	require.NoError(t, scanner.Err())
+
+	return spans
+}
+
+func TestStartSyncPhaseSpan_FileExporter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "trace.json")
+
+	ctx, endSyncSpan := startSyncPhaseSpan(context.Background(), map[string]string{SfTracingExporter: "file", SfTracingFile: path}, SyncPhaseAccessToTarget)
+
+	repoCtx, repoSpan := startSpan(ctx, "SnowflakeRepository.GetGrantsToAccountRole")
+	_, statementSpan := startStatementSpan(repoCtx, []string{`SHOW GRANTS TO ROLE "R1"`})
+	endStatementSpan(statementSpan, "01b2c3d4-0000-1111-0000-000000000001", nil)
+	repoSpan.End()
+
+	_, failedSpan := startStatementSpan(ctx, []string{`GRANT ROLE "R1" TO ROLE "R2"`})
+	endStatementSpan(failedSpan, "", errors.New("boom"))
+
+	endSyncSpan(nil)
+
+	spans := readExportedSpans(t, path)
+	require.Len(t, spans, 4)
+
+	syncSpan := spans["sync access-to-target"]
+	assert.Equal(t, "access-to-target", syncSpan.attribute("raito.sync_phase"))
+
+	assert.Equal(t, syncSpan.SpanContext.SpanID, spans["SnowflakeRepository.GetGrantsToAccountRole"].Parent.SpanID)
+	assert.Equal(t, syncSpan.SpanContext.TraceID, spans["SnowflakeRepository.GetGrantsToAccountRole"].SpanContext.TraceID)
+
+	showGrants := spans["SHOW GRANTS"]
+	assert.Equal(t, spans["SnowflakeRepository.GetGrantsToAccountRole"].SpanContext.SpanID, showGrants.Parent.SpanID)
+	assert.Equal(t, "01b2c3d4-0000-1111-0000-000000000001", showGrants.attribute("db.snowflake.query_id"))
+	assert.Equal(t, `SHOW GRANTS TO ROLE "R1"`, showGrants.attribute("db.query.text"))
+	assert.Equal(t, "Unset", showGrants.Status.Code)
+
+	grantRole := spans["GRANT ROLE"]
+	assert.Nil(t, grantRole.attribute("db.snowflake.query_id"))
+	assert.Equal(t, "Error", grantRole.Status.Code)
+}
+
+func TestStartSyncPhaseSpan_NotConfigured(t *testing.T) {
+	tests := []struct {
+		name   string
+		params map[string]string
+	}{
+		{name: "no exporter", params: map[string]string{}},
+		{name: "invalid exporter", params: map[string]string{SfTracingExporter: "jaeger"}},
+		{name: "file exporter without file", params: map[string]string{SfTracingExporter: "file"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, endSyncSpan := startSyncPhaseSpan(context.Background(), tt.params, SyncPhaseDataSource)
+			defer endSyncSpan(nil)
+
+			assert.False(t, trace.SpanFromContext(ctx).IsRecording())
+
+			_, span := startSpan(ctx, "SnowflakeRepository.GetDatabases")
+			defer span.End()
+
+			assert.False(t, span.IsRecording())
+		})
+	}
+}
@@ -1,6 +1,8 @@
 package snowflake
 
 import (
+	"fmt"
+	"sort"
 	"strings"
 
 	ds "github.com/raito-io/cli/base/data_source"
@@ -12,14 +14,75 @@ var raitoTypeToSnowflakeGrantType = map[string]string{
 	ds.View:           "VIEW",
 	MaterializedView:  "VIEW",
 	ExternalTable:     "EXTERNAL TABLE",
+	DynamicTable:      "DYNAMIC TABLE",
+	FileFormat:        "FILE FORMAT",
+	ComputePool:       "COMPUTE POOL",
+	ImageRepository:   "IMAGE REPOSITORY",
 	"shared-database": "DATABASE",
 	"shared-table":    "TABLE",
 	"shared-view":     "VIEW",
 	"shared-schema":   "SCHEMA",
 }
 
+// schemaObjectType links the Raito data object type of an object in a schema, other than tables and routines, to the kind used in the SHOW statement to list them.
+// Kinds that are part of Snowpark Container Services are not available on all accounts.
+type schemaObjectType struct {
+	doType                    string
+	showKind                  string
+	snowparkContainerServices bool
+}
+
+var schemaObjectTypes = []schemaObjectType{
+	{doType: Stage, showKind: "STAGES"},
+	{doType: Stream, showKind: "STREAMS"},
+	{doType: Task, showKind: "TASKS"},
+	{doType: Pipe, showKind: "PIPES"},
+	{doType: Sequence, showKind: "SEQUENCES"},
+	{doType: FileFormat, showKind: "FILE FORMATS"},
+	{doType: Secret, showKind: "SECRETS"},
+	{doType: ImageRepository, showKind: "IMAGE REPOSITORIES", snowparkContainerServices: true},
+	{doType: Service, showKind: "SERVICES", snowparkContainerServices: true},
+}
+
+// getSchemaObjectTypes returns the schema object types for the given comma-separated list of Raito data object types (e.g. "stage,stream").
+// If the list is empty, all supported schema object types are returned.
+func getSchemaObjectTypes(list string) ([]schemaObjectType, error) {
+	if strings.TrimSpace(list) == "" {
+		return schemaObjectTypes, nil
+	}
+
+	configured := parseCommaSeparatedList(list)
+
+	var objectTypes []schemaObjectType
+
+	for _, objectType := range schemaObjectTypes {
+		if configured.Remove(objectType.doType) {
+			objectTypes = append(objectTypes, objectType)
+		}
+	}
+
+	if len(configured) > 0 {
+		unsupported := configured.Slice()
+		sort.Strings(unsupported)
+
+		return nil, fmt.Errorf("unsupported schema object types in %s: %s", SfSchemaObjectTypes, strings.Join(unsupported, ", "))
+	}
+
+	return objectTypes, nil
+}
+
+func isSchemaObjectType(t string) bool {
+	for _, objectType := range schemaObjectTypes {
+		if objectType.doType == t {
+			return true
+		}
+	}
+
+	return false
+}
+
 func isTableType(t string) bool {
-	return t == ds.Table || t == ds.View || t == MaterializedView || t == ExternalTable || t == IcebergTable
+	return t == ds.Table || t == ds.View || t == MaterializedView || t == ExternalTable || t == IcebergTable || t == DynamicTable
 }
 
 // convertSnowflakeTableTypeToRaito maps the Snowflake types coming from the INFORMATION_SCHEMA views to the corresponding Raito type
@@ -30,6 +93,10 @@ func convertSnowflakeTableTypeToRaito(entity *TableEntity) string {
 			return IcebergTable
 		}
 
+		if raitoType == ds.Table && entity.IsDynamic() {
+			return DynamicTable
+		}
+
 		return raitoType
 	}
 
@@ -61,4 +128,15 @@ var snowflakeGrantTypeToRaito = map[string]string{
 	"WAREHOUSE":         "warehouse",
 	"MATERIALIZED_VIEW": MaterializedView,
 	"EXTERNAL_TABLE":    ExternalTable,
+	"DYNAMIC_TABLE":     DynamicTable,
+	"STAGE":             Stage,
+	"STREAM":            Stream,
+	"TASK":              Task,
+	"PIPE":              Pipe,
+	"SEQUENCE":          Sequence,
+	"FILE_FORMAT":       FileFormat,
+	"SECRET":            Secret,
+	"COMPUTE_POOL":      ComputePool,
+	"IMAGE_REPOSITORY":  ImageRepository,
+	"SERVICE":           Service,
 }
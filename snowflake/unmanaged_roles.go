@@ -0,0 +1,144 @@
+package snowflake
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/smithy-go/ptr"
+	exporter "github.com/raito-io/cli/base/access_provider/sync_from_target"
+	"github.com/raito-io/cli/base/tag"
+	"github.com/raito-io/cli/base/util/config"
+	"github.com/raito-io/cli/base/util/match"
+	"github.com/raito-io/cli/base/util/slice"
+	"github.com/raito-io/golang-set/set"
+)
+
+const unmanagedRoleLockedReason = "This Snowflake role is not managed by Raito because %s"
+
+// unmanagedRoles are the roles that are imported read-only and never changed by the access sync to target.
+// A role is unmanaged if its name matches one of the protected role patterns or if it has the unmanaged tag set to true.
+// All methods can be called on a nil unmanagedRoles, which is the case if nothing is configured.
+type unmanagedRoles struct {
+	patterns []string
+	tagName  string
+
+	taggedRoles set.Set[string]
+}
+
+func newUnmanagedRoles(configMap *config.ConfigMap) (*unmanagedRoles, error) {
+	var patterns []string
+
+	if protectedRoles := configMap.GetString(SfProtectedRoles); protectedRoles != "" {
+		patterns = slice.ParseCommaSeparatedList(protectedRoles)
+
+		_, err := match.MatchesAny("", patterns)
+		if err != nil {
+			return nil, fmt.Errorf("parsing regular expressions in parameter %q: %w", SfProtectedRoles, err)
+		}
+	}
+
+	tagName := strings.TrimSpace(configMap.GetString(SfUnmanagedRoleTag))
+
+	if len(patterns) == 0 && tagName == "" {
+		return nil, nil //nolint:nilnil
+	}
+
+	return &unmanagedRoles{patterns: patterns, tagName: tagName, taggedRoles: set.NewSet[string]()}, nil
+}
+
+func (u *unmanagedRoles) usesTag() bool {
+	return u != nil && u.tagName != ""
+}
+
+// addRoleTags registers the roles having the unmanaged tag set to true
+func (u *unmanagedRoles) addRoleTags(roleTags map[string][]*tag.Tag) {
+	if !u.usesTag() {
+		return
+	}
+
+	for roleName, tags := range roleTags {
+		for _, t := range tags {
+			if strings.EqualFold(t.Key, u.tagName) && strings.EqualFold(strings.TrimSpace(t.Value), "true") {
+				u.taggedRoles.Add(strings.ToUpper(roleName))
+			}
+		}
+	}
+}
+
+// loadRoleTags fetches the tags of all account roles if the unmanaged tag is configured
+func (u *unmanagedRoles) loadRoleTags(ctx context.Context, repo dataAccessRepository) error {
+	if !u.usesTag() {
+		return nil
+	}
+
+	roleTags, err := repo.GetTagsByDomain(ctx, "ROLE")
+	if err != nil {
+		return fmt.Errorf("get role tags to find unmanaged roles: %w", err)
+	}
+
+	u.addRoleTags(roleTags)
+
+	return nil
+}
+
+// unmanagedReason returns why the role is not managed by Raito, or an empty string if it is.
+// Database and application roles are matched against the patterns as <database>.<role>.
+func (u *unmanagedRoles) unmanagedReason(externalId string) string {
+	if u == nil {
+		return ""
+	}
+
+	roleName := cleanDoubleQuotes(externalId)
+
+	switch {
+	case isDatabaseRoleByExternalId(externalId):
+		if database, parsedRoleName, err := parseDatabaseRoleExternalId(externalId); err == nil {
+			roleName = database + "." + parsedRoleName
+		}
+	case isApplicationRoleByExternalId(externalId):
+		if application, parsedRoleName, err := parseApplicationRoleExternalId(externalId); err == nil {
+			roleName = application + "." + parsedRoleName
+		}
+	}
+
+	for _, pattern := range u.patterns {
+		// The patterns are validated when the unmanaged roles are created
+		if matched, _ := match.MatchesAny(roleName, []string{pattern}); matched {
+			return fmt.Sprintf("it matches the protected role pattern %q", pattern)
+		}
+	}
+
+	if u.taggedRoles.Contains(strings.ToUpper(roleName)) {
+		return fmt.Sprintf("it has tag %s set to true", u.tagName)
+	}
+
+	return ""
+}
+
+// checkRoles returns an error for the first of the roles that is not managed by Raito
+func (u *unmanagedRoles) checkRoles(action string, roleNames ...string) error {
+	for _, roleName := range roleNames {
+		if reason := u.unmanagedReason(roleName); reason != "" {
+			return fmt.Errorf("refusing to %s role %q because %s", action, cleanDoubleQuotes(roleName), reason)
+		}
+	}
+
+	return nil
+}
+
+// lockUnmanagedRole makes the imported access provider of an unmanaged role read-only
+func lockUnmanagedRole(ap *exporter.AccessProvider, reason string) {
+	lockedReason := ptr.String(fmt.Sprintf(unmanagedRoleLockedReason, reason))
+
+	ap.NameLocked = ptr.Bool(true)
+	ap.NameLockedReason = lockedReason
+	ap.DeleteLocked = ptr.Bool(true)
+	ap.DeleteLockedReason = lockedReason
+	ap.WhoLocked = ptr.Bool(true)
+	ap.WhoLockedReason = lockedReason
+	ap.InheritanceLocked = ptr.Bool(true)
+	ap.InheritanceLockedReason = lockedReason
+	ap.WhatLocked = ptr.Bool(true)
+	ap.WhatLockedReason = lockedReason
+}
@@ -0,0 +1,121 @@
+package snowflake
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/smithy-go/ptr"
+	importer "github.com/raito-io/cli/base/access_provider/sync_to_target"
+	"github.com/raito-io/cli/base/tag"
+	"github.com/raito-io/cli/base/util/config"
+	"github.com/raito-io/cli/base/wrappers/mocks"
+	"github.com/raito-io/golang-set/set"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnmanagedRoles(t *testing.T) {
+	roles, err := newUnmanagedRoles(&config.ConfigMap{Parameters: map[string]string{}})
+	require.NoError(t, err)
+	assert.Nil(t, roles)
+	assert.Empty(t, roles.unmanagedReason("SYSADMIN"))
+	assert.NoError(t, roles.checkRoles("drop", "SYSADMIN"))
+
+	_, err = newUnmanagedRoles(&config.ConfigMap{Parameters: map[string]string{SfProtectedRoles: "SYS_(.*"}})
+	require.Error(t, err)
+
+	roles, err = newUnmanagedRoles(&config.ConfigMap{Parameters: map[string]string{SfProtectedRoles: "SYS_.*, DB1\\.ADMIN", SfUnmanagedRoleTag: "RAITO_UNMANAGED"}})
+	require.NoError(t, err)
+
+	roles.addRoleTags(map[string][]*tag.Tag{
+		"TAGGED":     {{Key: "raito_unmanaged", Value: "true"}},
+		"NOT_TAGGED": {{Key: "RAITO_UNMANAGED", Value: "false"}, {Key: "OTHER", Value: "true"}},
+	})
+
+	assert.Equal(t, `it matches the protected role pattern "SYS_.*"`, roles.unmanagedReason(`"SYS_ADMIN"`))
+	assert.Equal(t, `it matches the protected role pattern "DB1\\.ADMIN"`, roles.unmanagedReason("DATABASEROLE###DATABASE:DB1###ROLE:ADMIN"))
+	assert.Equal(t, "it has tag RAITO_UNMANAGED set to true", roles.unmanagedReason("Tagged"))
+	assert.Empty(t, roles.unmanagedReason("NOT_TAGGED"))
+	assert.Empty(t, roles.unmanagedReason("MY_SYS_ADMIN"))
+
+	assert.EqualError(t, roles.checkRoles("grant to", "ROLE1", "TAGGED"), `refusing to grant to role "TAGGED" because it has tag RAITO_UNMANAGED set to true`)
+}
+
+func TestAccessSyncer_generateAccessControls_unmanagedRoles(t *testing.T) {
+	repoMock := newMockDataAccessRepository(t)
+
+	repoMock.EXPECT().CreateAccountRole(mock.Anything, "RoleName1").Return(nil).Once()
+	repoMock.EXPECT().CommentAccountRoleIfExists(mock.Anything, mock.Anything, "RoleName1").Return(nil).Once()
+
+	access := map[string]*importer.AccessProvider{
+		"SYS_ADMIN": {
+			Id:   "AccessProviderId1",
+			Name: "AccessProvider1",
+		},
+		"RoleName1": {
+			Id:   "AccessProviderId2",
+			Name: "AccessProvider2",
+			Who: importer.WhoItem{
+				InheritFrom: []string{"SYS_ADMIN"},
+			},
+		},
+	}
+
+	feedbackHandler := mocks.NewSimpleAccessProviderFeedbackHandler(t)
+
+	syncer := createBasicToTargetSyncer(repoMock, nil, feedbackHandler, &config.ConfigMap{})
+	syncer.unmanagedRoles = &unmanagedRoles{patterns: []string{"SYS_.*"}, taggedRoles: set.NewSet[string]()}
+
+	// When
+	err := syncer.generateAccessControls(context.Background(), access, set.NewSet[string]("SYS_ADMIN"), map[string]string{})
+
+	// Then
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []importer.AccessProviderSyncFeedback{
+		{
+			AccessProvider: "AccessProviderId1",
+			ExternalId:     ptr.String("SYS_ADMIN"),
+			Type:           ptr.String("role"),
+			Errors:         []string{`refusing to change role "SYS_ADMIN" because it matches the protected role pattern "SYS_.*"`},
+		},
+		{
+			AccessProvider: "AccessProviderId2",
+			ExternalId:     ptr.String("RoleName1"),
+			Type:           ptr.String("role"),
+			ActualName:     "RoleName1",
+			Errors:         []string{`error while assigning roles to role "RoleName1": refusing to grant to role "SYS_ADMIN" because it matches the protected role pattern "SYS_.*"`},
+		},
+	}, feedbackHandler.AccessProviderFeedback)
+}
+
+func TestAccessSyncer_removeRolesToRemove_unmanagedRoles(t *testing.T) {
+	repoMock := newMockDataAccessRepository(t)
+	repoMock.EXPECT().DropAccountRole(mock.Anything, "RoleName1").Return(nil).Once()
+
+	feedbackHandler := mocks.NewSimpleAccessProviderFeedbackHandler(t)
+
+	syncer := createBasicToTargetSyncer(repoMock, nil, feedbackHandler, &config.ConfigMap{})
+	syncer.unmanagedRoles = &unmanagedRoles{tagName: "RAITO_UNMANAGED", taggedRoles: set.NewSet[string]("TAGGED_ROLE", "UNLINKED_ROLE")}
+
+	// When
+	err := syncer.removeRolesToRemove(context.Background(), map[string]*importer.AccessProvider{
+		"TAGGED_ROLE":   {Id: "AccessProviderId1"},
+		"RoleName1":     {Id: "AccessProviderId2"},
+		"UNLINKED_ROLE": nil,
+	})
+
+	// Then
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []importer.AccessProviderSyncFeedback{
+		{
+			AccessProvider: "AccessProviderId1",
+			ExternalId:     ptr.String("TAGGED_ROLE"),
+			Errors:         []string{`refusing to drop role "TAGGED_ROLE" because it has tag RAITO_UNMANAGED set to true`},
+		},
+		{
+			AccessProvider: "AccessProviderId2",
+			ExternalId:     ptr.String("RoleName1"),
+		},
+	}, feedbackHandler.AccessProviderFeedback)
+}
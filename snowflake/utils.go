@@ -2,6 +2,9 @@ package snowflake
 
 import (
 	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"encoding/pem"
 	"fmt"
 	"os"
@@ -19,6 +22,9 @@ const (
 	externalIdDatabaseRolePrefix    = "DATABASEROLE###DATABASE:"
 	externalIdRoleDivider           = "###ROLE:"
 	externalIdApplicationRolePrefix = "APPLICATIONROLE###APPLICATION:"
+
+	privateKeyEnvPrefix = "env:"
+	pemBeginMarker      = "-----BEGIN"
 )
 
 var Logger hclog.Logger
@@ -126,6 +132,13 @@ func isRoleWithPrefixByExternalId(externalId string, prefix string) bool {
 	return false
 }
 
+// definitionHash returns a short hash of the given parts. It is used in the names of policies, so the same definition always results in the same name.
+func definitionHash(parts ...string) string {
+	hash := sha256.Sum256([]byte(strings.Join(parts, "\n")))
+
+	return hex.EncodeToString(hash[:])[:8]
+}
+
 func parseCommaSeparatedList(list string) set.Set[string] {
 	list = strings.TrimSpace(list)
 
@@ -142,6 +155,50 @@ func parseCommaSeparatedList(list string) set.Set[string] {
 	return ret
 }
 
+// LoadPrivateKeyFromParameter loads a private key from the value of a private key parameter.
+// The value can be an inline PEM, a base64-encoded PEM, an 'env:VAR_NAME' reference to an environment variable containing (base64-encoded) PEM data,
+// or the path of a file containing the PEM data.
+func LoadPrivateKeyFromParameter(value string, passphrase string) (*rsa.PrivateKey, error) {
+	if envVar, found := strings.CutPrefix(value, privateKeyEnvPrefix); found {
+		envValue, exists := os.LookupEnv(envVar)
+		if !exists || envValue == "" {
+			return nil, fmt.Errorf("environment variable %q is not set", envVar)
+		}
+
+		pemData, ok := privateKeyPemData(envValue)
+		if !ok {
+			return nil, fmt.Errorf("environment variable %q does not contain PEM data", envVar)
+		}
+
+		return LoadPrivateKey(pemData, passphrase)
+	}
+
+	if pemData, ok := privateKeyPemData(value); ok {
+		return LoadPrivateKey(pemData, passphrase)
+	}
+
+	return LoadPrivateKeyFromFile(value, passphrase)
+}
+
+// privateKeyPemData returns the PEM data if the value is an inline or base64-encoded PEM.
+func privateKeyPemData(value string) ([]byte, bool) {
+	value = strings.TrimSpace(value)
+
+	if strings.HasPrefix(value, pemBeginMarker) {
+		// Inline keys are often passed on a single line with escaped newlines
+		value = strings.ReplaceAll(value, "\\n", "\n")
+
+		return []byte(value), true
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(value), ""))
+	if err == nil && strings.HasPrefix(strings.TrimSpace(string(decoded)), pemBeginMarker) {
+		return decoded, true
+	}
+
+	return nil, false
+}
+
 func LoadPrivateKeyFromFile(file string, passphrase string) (*rsa.PrivateKey, error) {
 	pemData, err := os.ReadFile(file)
 	if err != nil {
@@ -1,9 +1,18 @@
 package snowflake
 
 import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestUtils_CleanDoubleQuotes(t *testing.T) {
@@ -24,3 +33,43 @@ func TestUtils_CleanDoubleQuotes(t *testing.T) {
 		assert.Equal(t, v, cleanDoubleQuotes(k))
 	}
 }
+
+func TestUtils_LoadPrivateKeyFromParameter(t *testing.T) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	pkcs8Key, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	require.NoError(t, err)
+
+	pemData := string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8Key}))
+
+	keyFile := filepath.Join(t.TempDir(), "rsa_key.p8")
+	require.NoError(t, os.WriteFile(keyFile, []byte(pemData), 0600))
+
+	t.Setenv("RAITO_TEST_PRIVATE_KEY", base64.StdEncoding.EncodeToString([]byte(pemData)))
+
+	tests := []struct {
+		name  string
+		value string
+	}{
+		{name: "file", value: keyFile},
+		{name: "inline PEM", value: pemData},
+		{name: "inline PEM with escaped newlines", value: strings.ReplaceAll(pemData, "\n", "\\n")},
+		{name: "base64 PEM", value: base64.StdEncoding.EncodeToString([]byte(pemData))},
+		{name: "environment variable", value: "env:RAITO_TEST_PRIVATE_KEY"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, err := LoadPrivateKeyFromParameter(tt.value, "")
+			require.NoError(t, err)
+			assert.True(t, privateKey.Equal(key))
+		})
+	}
+
+	_, err = LoadPrivateKeyFromParameter("env:RAITO_TEST_PRIVATE_KEY_NOT_SET", "")
+	require.Error(t, err)
+
+	_, err = LoadPrivateKeyFromParameter(filepath.Join(t.TempDir(), "does-not-exist"), "")
+	require.Error(t, err)
+}